@@ -7,6 +7,7 @@ import (
 	"os"
 	"runtime/debug"
 	"sync"
+	"sync/atomic"
 
 	"github.com/okex/exchain/x/vmbridge"
 
@@ -31,12 +32,19 @@ import (
 	icatypes "github.com/okex/exchain/libs/ibc-go/modules/apps/27-interchain-accounts/types"
 	ibcfeetypes "github.com/okex/exchain/libs/ibc-go/modules/apps/29-fee/types"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"google.golang.org/grpc/encoding"
 	"google.golang.org/grpc/encoding/proto"
 
 	ibcfee "github.com/okex/exchain/libs/ibc-go/modules/apps/29-fee"
+	nfttransfer "github.com/okex/exchain/libs/ibc-go/modules/apps/nft-transfer"
+	nfttransferkeeper "github.com/okex/exchain/libs/ibc-go/modules/apps/nft-transfer/keeper"
+	nfttransfertypes "github.com/okex/exchain/libs/ibc-go/modules/apps/nft-transfer/types"
+	packetforward "github.com/okex/exchain/libs/ibc-go/modules/apps/packet-forward"
+	packetforwardkeeper "github.com/okex/exchain/libs/ibc-go/modules/apps/packet-forward/keeper"
+	packetforwardtypes "github.com/okex/exchain/libs/ibc-go/modules/apps/packet-forward/types"
 
 	"github.com/okex/exchain/app/utils/appstatus"
 
@@ -86,6 +94,7 @@ import (
 	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 	dbm "github.com/okex/exchain/libs/tm-db"
 	"github.com/okex/exchain/x/ammswap"
+	ammswapclient "github.com/okex/exchain/x/ammswap/client"
 	commonversion "github.com/okex/exchain/x/common/version"
 	"github.com/okex/exchain/x/dex"
 	dexclient "github.com/okex/exchain/x/dex/client"
@@ -103,14 +112,26 @@ import (
 	"github.com/okex/exchain/x/genutil"
 	"github.com/okex/exchain/x/gov"
 	"github.com/okex/exchain/x/gov/keeper"
+	"github.com/okex/exchain/x/ibchooks"
+	"github.com/okex/exchain/x/icq"
+	icqkeeper "github.com/okex/exchain/x/icq/keeper"
+	icqtypes "github.com/okex/exchain/x/icq/types"
 	"github.com/okex/exchain/x/infura"
+	"github.com/okex/exchain/x/nft"
+	nftkeeper "github.com/okex/exchain/x/nft/keeper"
+	nfttypes "github.com/okex/exchain/x/nft/types"
 	"github.com/okex/exchain/x/order"
 	"github.com/okex/exchain/x/params"
 	paramsclient "github.com/okex/exchain/x/params/client"
 	paramstypes "github.com/okex/exchain/x/params/types"
+	"github.com/okex/exchain/x/ratelimit"
+	ratelimitclient "github.com/okex/exchain/x/ratelimit/client"
+	ratelimitkeeper "github.com/okex/exchain/x/ratelimit/keeper"
+	ratelimittypes "github.com/okex/exchain/x/ratelimit/types"
 	"github.com/okex/exchain/x/slashing"
 	"github.com/okex/exchain/x/staking"
 	"github.com/okex/exchain/x/token"
+	vmbridgeclient "github.com/okex/exchain/x/vmbridge/client"
 	"github.com/okex/exchain/x/wasm"
 	wasmclient "github.com/okex/exchain/x/wasm/client"
 	wasmkeeper "github.com/okex/exchain/x/wasm/keeper"
@@ -153,6 +174,7 @@ var (
 			distr.WithdrawRewardEnabledProposalHandler,
 			distr.RewardTruncatePrecisionProposalHandler,
 			dexclient.DelistProposalHandler, farmclient.ManageWhiteListProposalHandler,
+			ammswapclient.ManageStableSwapPoolProposalHandler,
 			evmclient.ManageContractDeploymentWhitelistProposalHandler,
 			evmclient.ManageContractBlockedListProposalHandler,
 			evmclient.ManageContractMethodGuFactorProposalHandler,
@@ -166,6 +188,11 @@ var (
 			erc20client.ContractTemplateProposalHandler,
 			client.UpdateClientProposalHandler,
 			fsclient.FeeSplitSharesProposalHandler,
+			vmbridgeclient.RegisterTokenPairProposalHandler,
+			vmbridgeclient.RegisterNFTPairProposalHandler,
+			ratelimitclient.SetRateLimitProposalHandler,
+			ratelimitclient.RemoveRateLimitProposalHandler,
+			ratelimitclient.ResetRateLimitProposalHandler,
 			wasmclient.MigrateContractProposalHandler,
 			wasmclient.UpdateContractAdminProposalHandler,
 			wasmclient.PinCodesProposalHandler,
@@ -192,8 +219,14 @@ var (
 		erc20.AppModuleBasic{},
 		wasm.AppModuleBasic{},
 		feesplit.AppModuleBasic{},
+		vmbridge.AppModuleBasic{},
 		ica.AppModuleBasic{},
 		ibcfee.AppModuleBasic{},
+		packetforward.AppModuleBasic{},
+		ratelimit.AppModuleBasic{},
+		nft.AppModuleBasic{},
+		nfttransfer.AppModuleBasic{},
+		icq.AppModuleBasic{},
 		icamauth.AppModuleBasic{},
 	)
 
@@ -287,8 +320,17 @@ type OKExChainApp struct {
 	ICAControllerKeeper  icacontrollerkeeper.Keeper
 	ICAHostKeeper        icahostkeeper.Keeper
 	VMBridgeKeeper       *vmbridge.Keeper
+	IBCHooksKeeper       ibchooks.Keeper
+	PacketForwardKeeper  packetforwardkeeper.Keeper
+	RateLimitKeeper      ratelimitkeeper.Keeper
+	NFTKeeper            nftkeeper.Keeper
+	NFTTransferKeeper    nfttransferkeeper.Keeper
+	ICQKeeper            icqkeeper.Keeper
 
 	WasmHandler wasmkeeper.HandlerOption
+
+	// laneValidators holds the validators of the system mempool lane, see updateLaneValidators
+	laneValidators atomic.Value
 }
 
 // NewOKExChainApp returns a reference to a new initialized OKExChain application.
@@ -310,6 +352,7 @@ func NewOKExChainApp(
 		"Venus3Height", tmtypes.GetVenus3Height(),
 		"Veneus4Height", tmtypes.GetVenus4Height(),
 		"EarthHeight", tmtypes.GetEarthHeight(),
+		"JupiterHeight", tmtypes.GetJupiterHeight(),
 		"MarsHeight", tmtypes.GetMarsHeight(),
 	)
 	onceLog.Do(func() {
@@ -340,8 +383,13 @@ func NewOKExChainApp(
 		mpt.StoreKey,
 		wasm.StoreKey,
 		feesplit.StoreKey,
+		vmbridge.StoreKey,
 		icacontrollertypes.StoreKey, icahosttypes.StoreKey, ibcfeetypes.StoreKey,
 		icamauthtypes.StoreKey,
+		packetforwardtypes.StoreKey,
+		ratelimittypes.StoreKey,
+		nfttypes.StoreKey, nfttransfertypes.StoreKey,
+		icqtypes.StoreKey,
 	)
 
 	tkeys := sdk.NewTransientStoreKeys(params.TStoreKey)
@@ -381,6 +429,7 @@ func NewOKExChainApp(
 	app.subspaces[feesplit.ModuleName] = app.ParamsKeeper.Subspace(feesplit.ModuleName)
 	app.subspaces[icacontrollertypes.SubModuleName] = app.ParamsKeeper.Subspace(icacontrollertypes.SubModuleName)
 	app.subspaces[icahosttypes.SubModuleName] = app.ParamsKeeper.Subspace(icahosttypes.SubModuleName)
+	app.subspaces[icqtypes.ModuleName] = app.ParamsKeeper.Subspace(icqtypes.ModuleName)
 
 	//proxy := codec.NewMarshalProxy(cc, cdc)
 	app.marshal = codecProxy
@@ -455,6 +504,8 @@ func NewOKExChainApp(
 	scopedICAControllerKeeper := app.CapabilityKeeper.ScopeToModule(icacontrollertypes.SubModuleName)
 	scopedICAHostKeeper := app.CapabilityKeeper.ScopeToModule(icahosttypes.SubModuleName)
 	scopedICAMauthKeeper := app.CapabilityKeeper.ScopeToModule(icamauthtypes.ModuleName)
+	scopedNFTTransferKeeper := app.CapabilityKeeper.ScopeToModule(nfttransfertypes.ModuleName)
+	scopedICQKeeper := app.CapabilityKeeper.ScopeToModule(icqtypes.ModuleName)
 
 	v2keeper := ibc.NewKeeper(
 		codecProxy, keys[ibchost.StoreKey], app.GetSubspace(ibchost.ModuleName), &stakingKeeper, app.UpgradeKeeper, &scopedIBCKeeper, interfaceReg,
@@ -464,13 +515,18 @@ func NewOKExChainApp(
 	facadedKeeper.RegisterKeeper(ibccommon.DefaultFactory(tmtypes.HigherThanVenus4, ibc.IBCV4, v4Keeper))
 	app.IBCKeeper = facadedKeeper
 	supplyKeeperAdapter := supply.NewSupplyKeeperAdapter(app.SupplyKeeper)
+	// the rate limit keeper wraps the channel keeper of the transfer keeper to limit the outflow of the sent packets
+	app.RateLimitKeeper = ratelimitkeeper.NewKeeper(keys[ratelimittypes.StoreKey], app.SupplyKeeper, v2keeper.ChannelKeeper)
 	// Create Transfer Keepers
 	app.TransferKeeper = ibctransferkeeper.NewKeeper(
 		codecProxy, keys[ibctransfertypes.StoreKey], app.GetSubspace(ibctransfertypes.ModuleName),
-		v2keeper.ChannelKeeper, &v2keeper.PortKeeper,
+		app.RateLimitKeeper, &v2keeper.PortKeeper,
 		app.SupplyKeeper, supplyKeeperAdapter, scopedTransferKeeper, interfaceReg,
 	)
 	ibctransfertypes.SetMarshal(codecProxy)
+	app.NFTKeeper = nftkeeper.NewKeeper(keys[nfttypes.StoreKey])
+	app.NFTTransferKeeper = nfttransferkeeper.NewKeeper(keys[nfttransfertypes.StoreKey], v2keeper.ChannelKeeper,
+		&v2keeper.PortKeeper, nftkeeper.NewICS721Keeper(app.NFTKeeper), scopedNFTTransferKeeper)
 	app.IBCFeeKeeper = ibcfeekeeper.NewKeeper(codecProxy, keys[ibcfeetypes.StoreKey], app.GetSubspace(ibcfeetypes.ModuleName),
 		v2keeper.ChannelKeeper, // may be replaced with IBC middleware
 		v2keeper.ChannelKeeper,
@@ -530,8 +586,24 @@ func NewOKExChainApp(
 		wasmConfig,
 		supportedFeatures,
 		vmbridge.GetWasmOpts(app.marshal.GetProtocMarshal()),
+		wasm.WithQueryCacheMetrics(prometheus.DefaultRegisterer),
 	)
 	(&app.WasmKeeper).SetInnerTxKeeper(app.EvmKeeper)
+	// the wasm contracts are registered for fee split by their creator or admin, the keepers hold a pointer to the
+	// wasm keeper so that they see the query plugins and message handlers set below
+	app.FeeSplitKeeper.SetWasmKeeper(&app.WasmKeeper)
+
+	wasmModule := wasm.NewAppModule(*app.marshal, &app.WasmKeeper)
+	app.WasmPermissionKeeper = wasmModule.GetPermissionKeeper()
+	app.VMBridgeKeeper = vmbridge.NewKeeper(app.marshal, app.Logger(), keys[vmbridge.StoreKey], app.EvmKeeper, app.WasmPermissionKeeper, app.AccountKeeper, app.BankKeeper, app.SwapKeeper, app.NFTKeeper)
+	// the nft custom queries fall back to the vmbridge custom queries
+	(&app.WasmKeeper).SetQueryPlugins(nftkeeper.RegisterCustomQuerier(app.NFTKeeper, vmbridge.RegisterCustomQuerier(*app.VMBridgeKeeper).Custom))
+	(&app.WasmKeeper).SetMessageHandlerDecorator(vmbridge.RegisterTokenMessenger(*app.VMBridgeKeeper))
+	(&app.WasmKeeper).SetMessageHandlerDecorator(nftkeeper.RegisterNFTMessenger(app.NFTKeeper))
+	// the host answers the interchain queries through the grpc query router, the controller calls back the wasm contracts
+	app.ICQKeeper = icqkeeper.NewKeeper(keys[icqtypes.StoreKey], app.GetSubspace(icqtypes.ModuleName), v2keeper.ChannelKeeper,
+		&v2keeper.PortKeeper, scopedICQKeeper, app.GRPCQueryRouter(), &app.WasmKeeper, app.WasmPermissionKeeper)
+	(&app.WasmKeeper).SetMessageHandlerDecorator(icqkeeper.RegisterICQMessenger(app.ICQKeeper))
 
 	app.ParamsKeeper.RegisterSignal(wasm.SetNeedParamsUpdate)
 
@@ -543,18 +615,22 @@ func NewOKExChainApp(
 		AddRoute(distr.RouterKey, distr.NewDistributionProposalHandler(app.DistrKeeper)).
 		AddRoute(dex.RouterKey, dex.NewProposalHandler(&app.DexKeeper)).
 		AddRoute(farm.RouterKey, farm.NewManageWhiteListProposalHandler(&app.FarmKeeper)).
+		AddRoute(ammswap.RouterKey, ammswap.NewManageStableSwapPoolProposalHandler(&app.SwapKeeper)).
 		AddRoute(evm.RouterKey, evm.NewManageContractDeploymentWhitelistProposalHandler(app.EvmKeeper)).
 		AddRoute(mint.RouterKey, mint.NewManageTreasuresProposalHandler(&app.MintKeeper)).
 		AddRoute(ibcclienttypes.RouterKey, ibcclient.NewClientUpdateProposalHandler(app.IBCKeeper.V2Keeper.ClientKeeper)).
 		AddRoute(erc20.RouterKey, erc20.NewProposalHandler(&app.Erc20Keeper)).
 		AddRoute(feesplit.RouterKey, feesplit.NewProposalHandler(&app.FeeSplitKeeper)).
 		AddRoute(wasm.RouterKey, wasm.NewWasmProposalHandler(&app.WasmKeeper, wasm.NecessaryProposals)).
+		AddRoute(vmbridge.RouterKey, vmbridge.NewProposalHandler(app.VMBridgeKeeper)).
+		AddRoute(ratelimittypes.RouterKey, ratelimit.NewProposalHandler(app.RateLimitKeeper)).
 		AddRoute(params.UpgradeRouterKey, params.NewUpgradeProposalHandler(&app.ParamsKeeper))
 
 	govProposalHandlerRouter := keeper.NewProposalHandlerRouter()
 	govProposalHandlerRouter.AddRoute(params.RouterKey, &app.ParamsKeeper).
 		AddRoute(dex.RouterKey, &app.DexKeeper).
 		AddRoute(farm.RouterKey, &app.FarmKeeper).
+		AddRoute(ammswap.RouterKey, &app.SwapKeeper).
 		AddRoute(evm.RouterKey, app.EvmKeeper).
 		AddRoute(mint.RouterKey, &app.MintKeeper).
 		AddRoute(erc20.RouterKey, &app.Erc20Keeper).
@@ -570,6 +646,7 @@ func NewOKExChainApp(
 	app.ParamsKeeper.SetGovKeeper(app.GovKeeper)
 	app.DexKeeper.SetGovKeeper(app.GovKeeper)
 	app.FarmKeeper.SetGovKeeper(app.GovKeeper)
+	app.SwapKeeper.SetGovKeeper(app.GovKeeper)
 	app.EvmKeeper.SetGovKeeper(app.GovKeeper)
 	app.MintKeeper.SetGovKeeper(app.GovKeeper)
 	app.Erc20Keeper.SetGovKeeper(app.GovKeeper)
@@ -582,7 +659,14 @@ func NewOKExChainApp(
 
 	left := common.NewDisaleProxyMiddleware()
 	middle := ibctransfer.NewIBCModule(app.TransferKeeper, transferModule)
-	right := ibcfee.NewIBCMiddleware(middle, app.IBCFeeKeeper)
+	app.IBCHooksKeeper = ibchooks.NewKeeper(app.BankKeeper, &app.WasmKeeper, app.WasmPermissionKeeper, app.VMBridgeKeeper)
+	hooksMiddleware := ibchooks.NewIBCMiddleware(middle, v2keeper.ChannelKeeper, app.IBCHooksKeeper)
+	// the asynchronous acknowledgements of the forwarded packets are written through the fee keeper
+	app.PacketForwardKeeper = packetforwardkeeper.NewKeeper(keys[packetforwardtypes.StoreKey], app.TransferKeeper,
+		supplyKeeperAdapter, scopedTransferKeeper, app.IBCFeeKeeper)
+	forwardMiddleware := packetforward.NewIBCMiddleware(hooksMiddleware, v2keeper.ChannelKeeper, app.PacketForwardKeeper)
+	ratelimitMiddleware := ratelimit.NewIBCMiddleware(forwardMiddleware, v2keeper.ChannelKeeper, app.RateLimitKeeper)
+	right := ibcfee.NewIBCMiddleware(ratelimitMiddleware, app.IBCFeeKeeper)
 	transferStack := ibcporttypes.NewFacadedMiddleware(left,
 		ibccommon.DefaultFactory(tmtypes.HigherThanVenus4, ibc.IBCV4, right),
 		ibccommon.DefaultFactory(tmtypes.HigherThanVenus1, ibc.IBCV2, middle))
@@ -602,6 +686,8 @@ func NewOKExChainApp(
 	ibcRouter.AddRoute(icacontrollertypes.SubModuleName, icaControllerStack)
 	ibcRouter.AddRoute(icahosttypes.SubModuleName, icaHostStack)
 	ibcRouter.AddRoute(icamauthtypes.ModuleName, icaControllerStack)
+	ibcRouter.AddRoute(nfttransfertypes.ModuleName, nfttransfer.NewIBCModule(app.NFTTransferKeeper))
+	ibcRouter.AddRoute(icqtypes.ModuleName, icq.NewIBCModule(app.ICQKeeper))
 
 	//ibcRouter.AddRoute(ibcmock.ModuleName, mockModule)
 	v2keeper.SetRouter(ibcRouter)
@@ -612,9 +698,6 @@ func NewOKExChainApp(
 		staking.NewMultiStakingHooks(app.DistrKeeper.Hooks(), app.SlashingKeeper.Hooks()),
 	)
 
-	wasmModule := wasm.NewAppModule(*app.marshal, &app.WasmKeeper)
-	app.WasmPermissionKeeper = wasmModule.GetPermissionKeeper()
-	app.VMBridgeKeeper = vmbridge.NewKeeper(app.marshal, app.Logger(), app.EvmKeeper, app.WasmPermissionKeeper, app.AccountKeeper, app.BankKeeper)
 	app.EvmKeeper.SetCallToCM(vmbridge.PrecompileHooks(app.VMBridgeKeeper))
 	// Set EVM hooks
 	app.EvmKeeper.SetHooks(
@@ -658,7 +741,13 @@ func NewOKExChainApp(
 		erc20.NewAppModule(app.Erc20Keeper),
 		wasmModule,
 		feesplit.NewAppModule(app.FeeSplitKeeper),
+		vmbridge.NewAppModule(*app.VMBridgeKeeper),
 		ibcfee.NewAppModule(app.IBCFeeKeeper),
+		packetforward.NewAppModule(app.PacketForwardKeeper),
+		ratelimit.NewAppModule(app.RateLimitKeeper),
+		nft.NewAppModule(app.NFTKeeper),
+		nfttransfer.NewAppModule(app.NFTTransferKeeper),
+		icq.NewAppModule(app.ICQKeeper),
 		ica.NewAppModule(codecProxy, &app.ICAControllerKeeper, &app.ICAHostKeeper),
 		icamauth.NewAppModule(codecProxy, app.ICAMauthKeeper),
 	)
@@ -673,6 +762,7 @@ func NewOKExChainApp(
 		order.ModuleName,
 		token.ModuleName,
 		dex.ModuleName,
+		ammswap.ModuleName,
 		mint.ModuleName,
 		distr.ModuleName,
 		slashing.ModuleName,
@@ -682,6 +772,7 @@ func NewOKExChainApp(
 		evm.ModuleName,
 		ibchost.ModuleName,
 		ibctransfertypes.ModuleName,
+		ratelimittypes.ModuleName,
 		wasm.ModuleName,
 	)
 	app.mm.SetOrderEndBlockers(
@@ -708,6 +799,8 @@ func NewOKExChainApp(
 		erc20.ModuleName,
 		wasm.ModuleName,
 		feesplit.ModuleName,
+		vmbridge.ModuleName,
+		nfttypes.ModuleName,
 		ibchost.ModuleName,
 		icatypes.ModuleName, ibcfeetypes.ModuleName,
 	)
@@ -754,7 +847,7 @@ func NewOKExChainApp(
 	}
 	app.SetAnteHandler(ante.NewAnteHandler(app.AccountKeeper, app.EvmKeeper, app.SupplyKeeper, validateMsgHook(app.OrderKeeper), app.WasmHandler, app.IBCKeeper, app.StakingKeeper, app.ParamsKeeper))
 	app.SetEndBlocker(app.EndBlocker)
-	app.SetGasRefundHandler(refund.NewGasRefundHandler(app.AccountKeeper, app.SupplyKeeper, app.EvmKeeper))
+	app.SetGasRefundHandler(feeSplitGasRefundHandler(app.FeeSplitKeeper, refund.NewGasRefundHandler(app.AccountKeeper, app.SupplyKeeper, app.EvmKeeper)))
 	app.SetAccNonceHandler(NewAccNonceHandler(app.AccountKeeper))
 	app.AddCustomizeModuleOnStopLogic(NewEvmModuleStopLogic(app.EvmKeeper))
 	app.SetMptCommitHandler(NewMptCommitHandler(app.EvmKeeper))
@@ -784,6 +877,7 @@ func NewOKExChainApp(
 		app.InitUpgrade(ctx)
 		app.WasmKeeper.UpdateGasRegister(ctx)
 		app.WasmKeeper.UpdateCurBlockNum(ctx)
+		app.updateLaneValidators(ctx)
 	}
 
 	app.ScopedIBCKeeper = scopedIBCKeeper
@@ -69,6 +69,7 @@ func (app *OKExChainApp) Commit(req abci.RequestCommit) abci.ResponseCommit {
 		}
 	}
 	res := app.BaseApp.Commit(req)
+	app.updateLaneValidators(app.BaseApp.NewContext(true, abci.Header{}))
 
 	// we call watch#Commit here ,because
 	// 1. this round commit a valid block
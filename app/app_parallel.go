@@ -17,6 +17,7 @@ import (
 	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 	"github.com/okex/exchain/x/evm"
 	evmtypes "github.com/okex/exchain/x/evm/types"
+	"github.com/okex/exchain/x/feesplit"
 	wasmkeeper "github.com/okex/exchain/x/wasm/keeper"
 )
 
@@ -55,6 +56,15 @@ func updateFeeCollectorHandler(bk bank.Keeper, sk supply.Keeper) sdk.UpdateFeeCo
 	}
 }
 
+// feeSplitGasRefundHandler sets the fee split of the executed wasm contract of a successful tx before the unused
+// gas is refunded, the developer fee is then sent to the withdrawer by updateFeeCollectorHandler like the evm fee splits
+func feeSplitGasRefundHandler(fk feesplit.Keeper, next sdk.GasRefundHandler) sdk.GasRefundHandler {
+	return func(ctx sdk.Context, tx sdk.Tx) (sdk.Coins, error) {
+		fk.PostWasmTxProcessing(ctx, tx)
+		return next(ctx, tx)
+	}
+}
+
 // fixLogForParallelTxHandler fix log for parallel tx
 func fixLogForParallelTxHandler(ek *evm.Keeper) sdk.LogFix {
 	return func(tx []sdk.Tx, logIndex []int, hasEnterEvmTx []bool, anteErrs []error, resp []abci.ResponseDeliverTx) (logs [][]byte) {
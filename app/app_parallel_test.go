@@ -252,6 +252,7 @@ func TestParallelTxs(t *testing.T) {
 	tmtypes.UnittestOnlySetMilestoneVenus1Height(1)
 	tmtypes.UnittestOnlySetMilestoneVenus2Height(1)
 	tmtypes.UnittestOnlySetMilestoneEarthHeight(1)
+	tmtypes.UnittestOnlySetMilestoneJupiterHeight(1)
 	tmtypes.UnittestOnlySetMilestoneVenus6Height(1)
 
 	env := new(Env)
@@ -0,0 +1,340 @@
+package light
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	rpctypes "github.com/okex/exchain/app/rpc/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/store/mpt"
+	"github.com/okex/exchain/libs/cosmos-sdk/store/rootmulti"
+	storetypes "github.com/okex/exchain/libs/cosmos-sdk/store/types"
+	"github.com/okex/exchain/libs/iavl"
+	"github.com/okex/exchain/libs/tendermint/crypto/merkle"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+)
+
+const (
+	jsonrpcVersion = "2.0"
+
+	// maxRequestContentLength is the same limit as the eth rpc server of geth
+	maxRequestContentLength = 1024 * 1024 * 5
+
+	errCodeInvalidRequest = -32600
+	errCodeInvalidParams  = -32602
+	errCodeInternal       = -32603
+	// errCodeVerification is returned when a response of the primary doesn't
+	// match the state proven by the app hash
+	errCodeVerification = -32099
+)
+
+type jsonrpcMessage struct {
+	Version string          `json:"jsonrpc,omitempty"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Error   *jsonError      `json:"error,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+}
+
+type jsonError struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+type verifyError struct {
+	method string
+	err    error
+}
+
+func (e verifyError) Error() string {
+	return fmt.Sprintf("failed to verify the response of %s: %s", e.method, e.err)
+}
+
+type handlerFunc func(ctx context.Context, params []json.RawMessage) (interface{}, error)
+
+// Proxy serves the eth json-rpc of the primary node. The responses of
+// eth_getBalance, eth_getCode and eth_getStorageAt are verified against the
+// app hash verified by the light client before they are returned, the other
+// requests are forwarded to the primary without verification (UNSAFE).
+type Proxy struct {
+	verifier *Verifier
+	eth      *rpc.Client
+	logger   log.Logger
+
+	handlers map[string]handlerFunc
+}
+
+var _ http.Handler = (*Proxy)(nil)
+
+// NewProxy returns a Proxy forwarding the eth json-rpc requests with eth,
+// the client of the primary node.
+func NewProxy(verifier *Verifier, eth *rpc.Client, logger log.Logger) *Proxy {
+	p := &Proxy{
+		verifier: verifier,
+		eth:      eth,
+		logger:   logger,
+	}
+	p.handlers = map[string]handlerFunc{
+		"eth_getBalance":   p.getBalance,
+		"eth_getCode":      p.getCode,
+		"eth_getStorageAt": p.getStorageAt,
+	}
+	return p
+}
+
+// ServeHTTP implements http.Handler, both single and batch requests are supported.
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestContentLength))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	var resp interface{}
+	if body = bytes.TrimSpace(body); len(body) > 0 && body[0] == '[' {
+		var msgs []*jsonrpcMessage
+		if err := json.Unmarshal(body, &msgs); err != nil {
+			resp = errorMessage(nil, errCodeInvalidRequest, err)
+		} else {
+			resps := make([]*jsonrpcMessage, len(msgs))
+			for i, msg := range msgs {
+				resps[i] = p.handle(r.Context(), msg)
+			}
+			resp = resps
+		}
+	} else {
+		var msg jsonrpcMessage
+		if err := json.Unmarshal(body, &msg); err != nil {
+			resp = errorMessage(nil, errCodeInvalidRequest, err)
+		} else {
+			resp = p.handle(r.Context(), &msg)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		p.logger.Error("failed to write response", "err", err)
+	}
+}
+
+func (p *Proxy) handle(ctx context.Context, msg *jsonrpcMessage) *jsonrpcMessage {
+	var params []json.RawMessage
+	if len(msg.Params) > 0 {
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return errorMessage(msg.ID, errCodeInvalidParams, err)
+		}
+	}
+
+	var result interface{}
+	var err error
+	if handler, ok := p.handlers[msg.Method]; ok {
+		result, err = handler(ctx, params)
+	} else {
+		result, err = p.forward(ctx, msg.Method, params)
+	}
+	if err != nil {
+		return p.errorResponse(msg, err)
+	}
+
+	bz, err := json.Marshal(result)
+	if err != nil {
+		return errorMessage(msg.ID, errCodeInternal, err)
+	}
+	return &jsonrpcMessage{Version: jsonrpcVersion, ID: msg.ID, Result: bz}
+}
+
+func (p *Proxy) forward(ctx context.Context, method string, params []json.RawMessage) (json.RawMessage, error) {
+	args := make([]interface{}, len(params))
+	for i := range params {
+		args[i] = params[i]
+	}
+	var result json.RawMessage
+	err := p.eth.CallContext(ctx, &result, method, args...)
+	return result, err
+}
+
+func (p *Proxy) errorResponse(msg *jsonrpcMessage, err error) *jsonrpcMessage {
+	var verr verifyError
+	if errors.As(err, &verr) {
+		p.logger.Error("verification failed", "method", msg.Method, "err", verr.err)
+		return errorMessage(msg.ID, errCodeVerification, err)
+	}
+	// keep the error of the primary as it is
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		resp := errorMessage(msg.ID, rpcErr.ErrorCode(), err)
+		var dataErr rpc.DataError
+		if errors.As(err, &dataErr) {
+			resp.Error.Data = dataErr.ErrorData()
+		}
+		return resp
+	}
+	return errorMessage(msg.ID, errCodeInternal, err)
+}
+
+func errorMessage(id json.RawMessage, code int, err error) *jsonrpcMessage {
+	return &jsonrpcMessage{
+		Version: jsonrpcVersion,
+		ID:      id,
+		Error:   &jsonError{Code: code, Message: err.Error()},
+	}
+}
+
+// resolveHeight returns the height of the block parameter, the latest
+// verifiable height is used for latest and pending.
+func (p *Proxy) resolveHeight(ctx context.Context, param json.RawMessage) (int64, error) {
+	var bnh rpctypes.BlockNumberOrHash
+	if err := json.Unmarshal(param, &bnh); err != nil {
+		return 0, err
+	}
+	if hash, ok := bnh.Hash(); ok {
+		var block struct {
+			Number hexutil.Uint64 `json:"number"`
+		}
+		if err := p.eth.CallContext(ctx, &block, "eth_getBlockByHash", hash, false); err != nil {
+			return 0, err
+		}
+		height := int64(block.Number)
+		if err := p.verifier.VerifyBlockHash(height, hash); err != nil {
+			return 0, verifyError{method: "eth_getBlockByHash", err: err}
+		}
+		return height, nil
+	}
+
+	number, _ := bnh.Number()
+	if number == rpctypes.LatestBlockNumber || number == rpctypes.PendingBlockNumber {
+		return p.verifier.LatestHeight()
+	}
+	return number.Int64(), nil
+}
+
+func parseParams(params []json.RawMessage, n int) error {
+	if len(params) != n {
+		return fmt.Errorf("expected %d params, got %d", n, len(params))
+	}
+	return nil
+}
+
+func (p *Proxy) getBalance(ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	if err := parseParams(params, 2); err != nil {
+		return nil, err
+	}
+	var addr ethcmn.Address
+	if err := json.Unmarshal(params[0], &addr); err != nil {
+		return nil, err
+	}
+	height, err := p.resolveHeight(ctx, params[1])
+	if err != nil {
+		return nil, err
+	}
+
+	var balance hexutil.Big
+	if err := p.eth.CallContext(ctx, &balance, "eth_getBalance", addr, hexutil.Uint64(height)); err != nil {
+		return nil, err
+	}
+	acc, err := p.verifier.Account(addr, height)
+	if err != nil {
+		return nil, verifyError{method: "eth_getBalance", err: err}
+	}
+	if expected := accountBalance(acc); expected.Cmp(balance.ToInt()) != 0 {
+		return nil, verifyError{method: "eth_getBalance",
+			err: fmt.Errorf("balance of %s mismatch: %s vs %s", addr.Hex(), expected, balance.ToInt())}
+	}
+	return &balance, nil
+}
+
+func (p *Proxy) getCode(ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	if err := parseParams(params, 2); err != nil {
+		return nil, err
+	}
+	var addr ethcmn.Address
+	if err := json.Unmarshal(params[0], &addr); err != nil {
+		return nil, err
+	}
+	height, err := p.resolveHeight(ctx, params[1])
+	if err != nil {
+		return nil, err
+	}
+
+	var code hexutil.Bytes
+	if err := p.eth.CallContext(ctx, &code, "eth_getCode", addr, hexutil.Uint64(height)); err != nil {
+		return nil, err
+	}
+	acc, err := p.verifier.Account(addr, height)
+	if err != nil {
+		return nil, verifyError{method: "eth_getCode", err: err}
+	}
+	if err := verifyCode(acc, code); err != nil {
+		return nil, verifyError{method: "eth_getCode", err: err}
+	}
+	return code, nil
+}
+
+func (p *Proxy) getStorageAt(ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	if err := parseParams(params, 3); err != nil {
+		return nil, err
+	}
+	var addr ethcmn.Address
+	if err := json.Unmarshal(params[0], &addr); err != nil {
+		return nil, err
+	}
+	var key string
+	if err := json.Unmarshal(params[1], &key); err != nil {
+		return nil, err
+	}
+	height, err := p.resolveHeight(ctx, params[2])
+	if err != nil {
+		return nil, err
+	}
+
+	var value hexutil.Bytes
+	if err := p.eth.CallContext(ctx, &value, "eth_getStorageAt", addr, key, hexutil.Uint64(height)); err != nil {
+		return nil, err
+	}
+	storageProof := func() (ethcmn.Hash, []string, error) {
+		var res rpctypes.AccountResult
+		if err := p.eth.CallContext(ctx, &res, "eth_getProof", addr, []string{key}, hexutil.Uint64(height)); err != nil {
+			return ethcmn.Hash{}, nil, err
+		}
+		if len(res.StorageProof) != 1 {
+			return ethcmn.Hash{}, nil, fmt.Errorf("expected 1 storage proof, got %d", len(res.StorageProof))
+		}
+		return res.StorageHash, res.StorageProof[0].Proof, nil
+	}
+	expected, err := p.verifier.Storage(addr, ethcmn.HexToHash(key), height, storageProof)
+	if err != nil {
+		return nil, verifyError{method: "eth_getStorageAt", err: err}
+	}
+	if !bytes.Equal(expected.Bytes(), value) {
+		return nil, verifyError{method: "eth_getStorageAt",
+			err: fmt.Errorf("storage %s of %s mismatch: %s vs %s", key, addr.Hex(), expected.Hex(), value)}
+	}
+	return value, nil
+}
+
+// proofOpDecoders returns the decoders of all the proof ops of the app stores.
+func proofOpDecoders() map[string]merkle.OpDecoder {
+	return map[string]merkle.OpDecoder{
+		iavl.ProofOpIAVLValue:                    iavl.ValueOpDecoder,
+		iavl.ProofOpIAVLAbsence:                  iavl.AbsenceOpDecoder,
+		mpt.ProofOpMptValue:                      mpt.MptValueOpDecoder,
+		mpt.ProofOpMptAbsence:                    mpt.MptAbsenceOpDecoder,
+		rootmulti.ProofOpMultiStore:              rootmulti.MultiStoreProofOpDecoder,
+		storetypes.ProofOpIAVLCommitment:         storetypes.CommitmentOpDecoder,
+		storetypes.ProofOpSimpleMerkleCommitment: storetypes.CommitmentOpDecoder,
+	}
+}
@@ -0,0 +1,244 @@
+package light
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	ethermint "github.com/okex/exchain/app/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	"github.com/okex/exchain/libs/cosmos-sdk/store/mpt"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/auth"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/auth/exported"
+	lite "github.com/okex/exchain/libs/tendermint/lite2"
+	lrpc "github.com/okex/exchain/libs/tendermint/lite2/rpc"
+	rpcclient "github.com/okex/exchain/libs/tendermint/rpc/client"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	evmtypes "github.com/okex/exchain/x/evm/types"
+)
+
+const paramsStoreName = "params"
+
+// evmRootHashKey is the key of the evm trie root in the params store, it's
+// saved by the evm module at the end of every block after the Mars height.
+var evmRootHashKey = append([]byte("custom/"+evmtypes.DefaultParamspace+"/"), evmtypes.KeyPrefixEvmRootHash...)
+
+// Verifier reads the account states of a height from the primary node and
+// verifies them against the app hash of the header verified by the light
+// client. The app hash of height H is in the header of height H+1.
+type Verifier struct {
+	lc     *lite.Client
+	client *lrpc.Client
+	next   rpcclient.Client
+	cdc    *codec.Codec
+}
+
+// NewVerifier returns a Verifier querying next, the proofs of which are
+// verified with the headers of lc.
+func NewVerifier(lc *lite.Client, next rpcclient.Client, cdc *codec.Codec) *Verifier {
+	client := lrpc.NewClient(next, lc)
+	for typ, dec := range proofOpDecoders() {
+		client.RegisterOpDecoder(typ, dec)
+	}
+	return &Verifier{
+		lc:     lc,
+		client: client,
+		next:   next,
+		cdc:    cdc,
+	}
+}
+
+// LatestHeight returns the latest height the state of which can be verified,
+// that's the height before the latest block of the primary.
+func (v *Verifier) LatestHeight() (int64, error) {
+	status, err := v.next.Status()
+	if err != nil {
+		return 0, err
+	}
+	height := status.SyncInfo.LatestBlockHeight - 1
+	if height <= 0 {
+		return 0, errors.New("no verifiable height yet")
+	}
+	return height, nil
+}
+
+// VerifyBlockHash checks the verified header at height has the given hash.
+func (v *Verifier) VerifyBlockHash(height int64, hash ethcmn.Hash) error {
+	h, err := v.lc.VerifyHeaderAtHeight(height, time.Now())
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(h.Hash(), hash.Bytes()) {
+		return fmt.Errorf("block %d has hash %X, not %s", height, h.Hash(), hash.Hex())
+	}
+	return nil
+}
+
+// Account returns the verified account of the address at height, nil means
+// the account doesn't exist.
+func (v *Verifier) Account(addr ethcmn.Address, height int64) (exported.Account, error) {
+	storeName := auth.StoreKey
+	if tmtypes.HigherThanMars(height) {
+		storeName = mpt.StoreKey
+	}
+	value, err := v.query(storeName, auth.AddressStoreKey(addr.Bytes()), height)
+	if err != nil || value == nil {
+		return nil, err
+	}
+
+	acc, err := v.cdc.UnmarshalBinaryBareWithRegisteredUnmarshaller(value, (*exported.Account)(nil))
+	if err == nil {
+		return acc.(exported.Account), nil
+	}
+	var account exported.Account
+	if err := v.cdc.UnmarshalBinaryBare(value, &account); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// Storage returns the verified value of the storage key of the address at height.
+// storageProof is the storage proof of the key returned by eth_getProof,
+// which is only used after the Mars height.
+func (v *Verifier) Storage(addr ethcmn.Address, key ethcmn.Hash, height int64,
+	storageProof func() (ethcmn.Hash, []string, error)) (ethcmn.Hash, error) {
+
+	if !tmtypes.HigherThanMars(height) {
+		// the storage is in the evm iavl store before the Mars height
+		storeKey := append(evmtypes.AddressStoragePrefix(addr), evmtypes.GetStorageByAddressKey(addr.Bytes(), key.Bytes()).Bytes()...)
+		value, err := v.query(evmtypes.StoreKey, storeKey, height)
+		if err != nil {
+			return ethcmn.Hash{}, err
+		}
+		return ethcmn.BytesToHash(value), nil
+	}
+
+	// app hash -> evm trie root -> storage trie root -> storage value
+	evmRoot, err := v.query(paramsStoreName, evmRootHashKey, height)
+	if err != nil {
+		return ethcmn.Hash{}, err
+	}
+	if len(evmRoot) == 0 {
+		return ethcmn.Hash{}, fmt.Errorf("evm root hash of height %d not found", height)
+	}
+
+	path := fmt.Sprintf("custom/%s/%s/%s", evmtypes.ModuleName, evmtypes.QueryStorageRootProof, addr.Hex())
+	res, err := v.next.ABCIQueryWithOptions(path, nil, rpcclient.ABCIQueryOptions{Height: height})
+	if err != nil {
+		return ethcmn.Hash{}, err
+	}
+	if res.Response.IsErr() {
+		return ethcmn.Hash{}, fmt.Errorf("query storage root proof: %s", res.Response.Log)
+	}
+	var rootProof evmtypes.QueryResStorageProof
+	if err := v.cdc.UnmarshalJSON(res.Response.Value, &rootProof); err != nil {
+		return ethcmn.Hash{}, err
+	}
+	storageRoot, err := verifyStorageRoot(ethcmn.BytesToHash(evmRoot), addr, rootProof)
+	if err != nil {
+		return ethcmn.Hash{}, err
+	}
+
+	storageHash, proof, err := storageProof()
+	if err != nil {
+		return ethcmn.Hash{}, err
+	}
+	if storageHash != storageRoot {
+		return ethcmn.Hash{}, fmt.Errorf("storage hash of %s mismatch: %s vs %s", addr.Hex(), storageHash.Hex(), storageRoot.Hex())
+	}
+	storageKey := key
+	if evmtypes.TrieUseCompositeKey {
+		storageKey = evmtypes.GetStorageByAddressKey(addr.Bytes(), key.Bytes())
+	}
+	return verifyStorageValue(storageRoot, storageKey, proof)
+}
+
+// query returns the value of the key in the store at height with its proof
+// verified, nil means the key is absent.
+func (v *Verifier) query(storeName string, key []byte, height int64) ([]byte, error) {
+	res, err := v.client.ABCIQueryWithOptions(fmt.Sprintf("/store/%s/key", storeName), key,
+		rpcclient.ABCIQueryOptions{Height: height, Prove: true})
+	if err != nil {
+		return nil, err
+	}
+	if res.Response.Height != height {
+		return nil, fmt.Errorf("query height mismatch: %d vs %d", res.Response.Height, height)
+	}
+	return res.Response.Value, nil
+}
+
+// verifyStorageRoot returns the storage root of the address in the evm trie,
+// which is the empty root for an account without storage.
+func verifyStorageRoot(evmRoot ethcmn.Hash, addr ethcmn.Address, res evmtypes.QueryResStorageProof) (ethcmn.Hash, error) {
+	value, err := mpt.VerifyProof(evmRoot, addr.Bytes(), res.Proof)
+	if err != nil {
+		return ethcmn.Hash{}, err
+	}
+	if !bytes.Equal(value, res.Value) {
+		return ethcmn.Hash{}, fmt.Errorf("storage root of %s mismatch: %X vs %X", addr.Hex(), value, res.Value)
+	}
+	if len(value) == 0 {
+		return mpt.EmptyRootHash, nil
+	}
+	return ethcmn.BytesToHash(value), nil
+}
+
+// verifyStorageValue returns the value of the storage key proven by the
+// hex-encoded trie nodes, the values in the storage trie are rlp encoded.
+func verifyStorageValue(storageRoot ethcmn.Hash, storageKey ethcmn.Hash, proof []string) (ethcmn.Hash, error) {
+	nodes := make(mpt.ProofList, len(proof))
+	for i, node := range proof {
+		bz, err := hexutil.Decode(node)
+		if err != nil {
+			return ethcmn.Hash{}, fmt.Errorf("invalid proof node %d: %s", i, err)
+		}
+		nodes[i] = bz
+	}
+	enc, err := mpt.VerifyProof(storageRoot, storageKey.Bytes(), nodes)
+	if err != nil || len(enc) == 0 {
+		return ethcmn.Hash{}, err
+	}
+	_, content, _, err := rlp.Split(enc)
+	if err != nil {
+		return ethcmn.Hash{}, err
+	}
+	return ethcmn.BytesToHash(content), nil
+}
+
+// accountBalance returns the balance of the account as eth_getBalance does.
+func accountBalance(acc exported.Account) *big.Int {
+	if acc == nil {
+		return big.NewInt(0)
+	}
+	balance := acc.GetCoins().AmountOf(sdk.DefaultBondDenom).BigInt()
+	if balance == nil {
+		return big.NewInt(0)
+	}
+	return balance
+}
+
+// verifyCode checks the code hash of the account is the hash of code.
+func verifyCode(acc exported.Account, code []byte) error {
+	var codeHash []byte
+	if ethAcc, ok := acc.(*ethermint.EthAccount); ok {
+		codeHash = ethAcc.CodeHash
+	}
+	if len(codeHash) == 0 || bytes.Equal(codeHash, ethcrypto.Keccak256(nil)) {
+		if len(code) != 0 {
+			return errors.New("code of an account without code")
+		}
+		return nil
+	}
+	if !bytes.Equal(codeHash, ethcrypto.Keccak256(code)) {
+		return fmt.Errorf("code hash mismatch: %X vs %X", codeHash, ethcrypto.Keccak256(code))
+	}
+	return nil
+}
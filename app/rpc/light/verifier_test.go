@@ -0,0 +1,131 @@
+package light
+
+import (
+	"math/big"
+	"testing"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/stretchr/testify/require"
+
+	ethermint "github.com/okex/exchain/app/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/store/mpt"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/auth"
+	evmtypes "github.com/okex/exchain/x/evm/types"
+)
+
+// newSecureTrie returns a trie keyed by the hash of the keys, as the evm and
+// storage tries are, and a function proving a key of it.
+func newSecureTrie(t *testing.T, kvs map[string][]byte) (ethcmn.Hash, func(key []byte) [][]byte) {
+	tr, err := trie.New(ethcmn.Hash{}, trie.NewDatabase(memorydb.New()))
+	require.NoError(t, err)
+	for k, v := range kvs {
+		require.NoError(t, tr.TryUpdate(ethcrypto.Keccak256([]byte(k)), v))
+	}
+	prove := func(key []byte) [][]byte {
+		proofDb := mpt.ProofList{}
+		require.NoError(t, tr.Prove(ethcrypto.Keccak256(key), 0, &proofDb))
+		return proofDb
+	}
+	return tr.Hash(), prove
+}
+
+func toHexNodes(proof [][]byte) []string {
+	nodes := make([]string, len(proof))
+	for i, node := range proof {
+		nodes[i] = hexutil.Encode(node)
+	}
+	return nodes
+}
+
+func TestVerifyStorageRoot(t *testing.T) {
+	addr := ethcmn.BytesToAddress([]byte("contract"))
+	other := ethcmn.BytesToAddress([]byte("no storage"))
+	storageRoot := ethcmn.BytesToHash([]byte("storage root"))
+	evmRoot, prove := newSecureTrie(t, map[string][]byte{
+		string(addr.Bytes()): storageRoot.Bytes(),
+		"another contract":   ethcmn.BytesToHash([]byte("another root")).Bytes(),
+	})
+
+	root, err := verifyStorageRoot(evmRoot, addr, evmtypes.QueryResStorageProof{Value: storageRoot.Bytes(), Proof: prove(addr.Bytes())})
+	require.NoError(t, err)
+	require.Equal(t, storageRoot, root)
+
+	// the primary lies about the storage root
+	_, err = verifyStorageRoot(evmRoot, addr, evmtypes.QueryResStorageProof{Value: other.Bytes(), Proof: prove(addr.Bytes())})
+	require.Error(t, err)
+
+	// an account without storage has the empty root
+	root, err = verifyStorageRoot(evmRoot, other, evmtypes.QueryResStorageProof{Value: []byte{}, Proof: prove(other.Bytes())})
+	require.NoError(t, err)
+	require.Equal(t, mpt.EmptyRootHash, root)
+
+	// the proof of an address is not the proof of another one
+	_, err = verifyStorageRoot(evmRoot, other, evmtypes.QueryResStorageProof{Value: storageRoot.Bytes(), Proof: prove(addr.Bytes())})
+	require.Error(t, err)
+}
+
+func TestVerifyStorageValue(t *testing.T) {
+	key := ethcmn.BytesToHash([]byte("key"))
+	value := ethcmn.BytesToHash([]byte("value"))
+	enc, err := rlp.EncodeToBytes(ethcmn.TrimLeftZeroes(value.Bytes()))
+	require.NoError(t, err)
+	storageRoot, prove := newSecureTrie(t, map[string][]byte{
+		string(key.Bytes()): enc,
+		"another key":       enc,
+	})
+
+	res, err := verifyStorageValue(storageRoot, key, toHexNodes(prove(key.Bytes())))
+	require.NoError(t, err)
+	require.Equal(t, value, res)
+
+	// absent key
+	absent := ethcmn.BytesToHash([]byte("absent"))
+	res, err = verifyStorageValue(storageRoot, absent, toHexNodes(prove(absent.Bytes())))
+	require.NoError(t, err)
+	require.Equal(t, ethcmn.Hash{}, res)
+
+	// empty storage
+	res, err = verifyStorageValue(mpt.EmptyRootHash, key, nil)
+	require.NoError(t, err)
+	require.Equal(t, ethcmn.Hash{}, res)
+
+	_, err = verifyStorageValue(ethcmn.BytesToHash([]byte("wrong root")), key, toHexNodes(prove(key.Bytes())))
+	require.Error(t, err)
+	_, err = verifyStorageValue(storageRoot, key, []string{"not hex"})
+	require.Error(t, err)
+}
+
+func TestVerifyCode(t *testing.T) {
+	code := []byte{0x60, 0x80, 0x60, 0x40}
+	addr := sdk.AccAddress(ethcmn.BytesToAddress([]byte("contract")).Bytes())
+	acc := &ethermint.EthAccount{
+		BaseAccount: auth.NewBaseAccount(addr, sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 3)), nil, 0, 0),
+		CodeHash:    ethcrypto.Keccak256(code),
+	}
+	require.NoError(t, verifyCode(acc, code))
+	require.Error(t, verifyCode(acc, code[1:]))
+	require.Error(t, verifyCode(acc, nil))
+
+	acc.CodeHash = ethcrypto.Keccak256(nil)
+	require.NoError(t, verifyCode(acc, nil))
+	require.Error(t, verifyCode(acc, code))
+
+	// absent account
+	require.NoError(t, verifyCode(nil, nil))
+	require.Error(t, verifyCode(nil, code))
+}
+
+func TestAccountBalance(t *testing.T) {
+	addr := sdk.AccAddress(ethcmn.BytesToAddress([]byte("account")).Bytes())
+	acc := &ethermint.EthAccount{
+		BaseAccount: auth.NewBaseAccount(addr, sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 3)), nil, 0, 0),
+	}
+	require.Equal(t, sdk.NewDec(3).BigInt(), accountBalance(acc))
+	require.Equal(t, big.NewInt(0), accountBalance(nil))
+}
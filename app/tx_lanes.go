@@ -0,0 +1,80 @@
+package app
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	channeltypes "github.com/okex/exchain/libs/ibc-go/modules/core/04-channel/types"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	"github.com/okex/exchain/x/gov"
+	govtypes "github.com/okex/exchain/x/gov/types"
+	"github.com/okex/exchain/x/slashing"
+	"github.com/okex/exchain/x/staking"
+	stakingexported "github.com/okex/exchain/x/staking/exported"
+)
+
+const (
+	// TxLaneSystem is the mempool lane of the validator operations and governance votes of the validators
+	TxLaneSystem = "system"
+	// TxLaneRelayer is the mempool lane of the ibc packet relaying txs
+	TxLaneRelayer = "relayer"
+)
+
+// systemMsgTypes are the route and type of the msgs in the system lane
+var systemMsgTypes = map[string]map[string]struct{}{
+	slashing.RouterKey: {"unjail": {}},
+	staking.RouterKey:  {"edit_validator": {}, "edit_validator_commission_rate": {}},
+	gov.RouterKey:      {govtypes.TypeMsgVote: {}},
+}
+
+// GetTxLane implements mempool.TxLaneClassifier, a tx is only in a lane if all of its msgs are
+func (app *OKExChainApp) GetTxLane(tx abci.TxEssentials) string {
+	sdkTx, ok := tx.(sdk.Tx)
+	if !ok {
+		return ""
+	}
+	msgs := sdkTx.GetMsgs()
+	if len(msgs) == 0 {
+		return ""
+	}
+	validators, _ := app.laneValidators.Load().(map[string]bool)
+	lane := getMsgLane(msgs[0], validators)
+	for _, msg := range msgs[1:] {
+		if getMsgLane(msg, validators) != lane {
+			return ""
+		}
+	}
+	return lane
+}
+
+// getMsgLane returns the lane of the msg. The system lane only takes the msgs signed by a bonded validator, except
+// for unjail whose validator is never bonded, so that other accounts can not crowd out the validators with cheap msgs.
+func getMsgLane(msg sdk.Msg, validators map[string]bool) string {
+	switch msg.(type) {
+	case channeltypes.MsgRecvPacket, *channeltypes.MsgRecvPacket,
+		channeltypes.MsgAcknowledgement, *channeltypes.MsgAcknowledgement,
+		channeltypes.MsgTimeout, *channeltypes.MsgTimeout:
+		return TxLaneRelayer
+	}
+	if _, ok := systemMsgTypes[msg.Route()][msg.Type()]; !ok {
+		return ""
+	}
+	signers := msg.GetSigners()
+	if len(signers) != 1 {
+		return ""
+	}
+	bonded, isValidator := validators[string(signers[0])]
+	if !isValidator || (!bonded && msg.Route() != slashing.RouterKey) {
+		return ""
+	}
+	return TxLaneSystem
+}
+
+// updateLaneValidators reloads the validators of the system lane, keyed by the account of their operator and
+// mapped to whether they are bonded
+func (app *OKExChainApp) updateLaneValidators(ctx sdk.Context) {
+	validators := make(map[string]bool)
+	app.StakingKeeper.IterateValidators(ctx, func(_ int64, validator stakingexported.ValidatorI) bool {
+		validators[string(validator.GetOperator())] = validator.IsBonded()
+		return false
+	})
+	app.laneValidators.Store(validators)
+}
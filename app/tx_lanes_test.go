@@ -0,0 +1,63 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/auth"
+	clienttypes "github.com/okex/exchain/libs/ibc-go/modules/core/02-client/types"
+	channeltypes "github.com/okex/exchain/libs/ibc-go/modules/core/04-channel/types"
+	"github.com/okex/exchain/x/gov"
+	govtypes "github.com/okex/exchain/x/gov/types"
+	"github.com/okex/exchain/x/slashing"
+	"github.com/okex/exchain/x/staking"
+)
+
+func TestGetTxLane(t *testing.T) {
+	app := &OKExChainApp{}
+	valAddr := sdk.ValAddress("validator")
+	unbondedValAddr := sdk.ValAddress("unbonded")
+	accAddr := sdk.AccAddress("voter")
+	app.laneValidators.Store(map[string]bool{string(valAddr): true, string(unbondedValAddr): false})
+
+	unjail := slashing.NewMsgUnjail(valAddr)
+	unbondedUnjail := slashing.NewMsgUnjail(unbondedValAddr)
+	editValidator := staking.NewMsgEditValidator(valAddr, staking.Description{})
+	unbondedEditValidator := staking.NewMsgEditValidator(unbondedValAddr, staking.Description{})
+	vote := gov.NewMsgVote(sdk.AccAddress(valAddr), 1, govtypes.OptionYes)
+	accountVote := gov.NewMsgVote(accAddr, 1, govtypes.OptionYes)
+	recvPacket := channeltypes.MsgRecvPacket{}
+	ack := &channeltypes.MsgAcknowledgement{}
+	timeout := &channeltypes.MsgTimeout{}
+	updateClient := &clienttypes.MsgUpdateClient{}
+	deposit := gov.NewMsgDeposit(accAddr, 1, sdk.SysCoins{})
+
+	testCases := []struct {
+		msgs []sdk.Msg
+		lane string
+	}{
+		{[]sdk.Msg{unjail}, TxLaneSystem},
+		{[]sdk.Msg{unbondedUnjail}, TxLaneSystem},
+		{[]sdk.Msg{editValidator}, TxLaneSystem},
+		{[]sdk.Msg{unbondedEditValidator}, ""},
+		{[]sdk.Msg{vote, unjail}, TxLaneSystem},
+		{[]sdk.Msg{accountVote}, ""},
+		{[]sdk.Msg{accountVote, unjail}, ""},
+		{[]sdk.Msg{recvPacket}, TxLaneRelayer},
+		{[]sdk.Msg{recvPacket, ack, timeout}, TxLaneRelayer},
+		{[]sdk.Msg{updateClient}, ""},
+		{[]sdk.Msg{updateClient, recvPacket}, ""},
+		{[]sdk.Msg{deposit}, ""},
+		{[]sdk.Msg{vote, deposit}, ""},
+		{[]sdk.Msg{vote, recvPacket}, ""},
+		{nil, ""},
+	}
+	for i, tc := range testCases {
+		tx := auth.NewStdTx(tc.msgs, auth.StdFee{}, nil, "")
+		require.Equal(t, tc.lane, app.GetTxLane(tx), "test case %d", i)
+	}
+	require.Equal(t, "", app.GetTxLane(nil))
+	require.Equal(t, "", (&OKExChainApp{}).GetTxLane(auth.NewStdTx([]sdk.Msg{vote}, auth.StdFee{}, nil, "")))
+}
@@ -111,6 +111,7 @@ func RegisterAppFlag(cmd *cobra.Command) {
 
 	cmd.Flags().String(tmdb.FlagGoLeveldbOpts, "", "Options of goleveldb. (cache_size=128MB,handlers_num=1024)")
 	cmd.Flags().String(tmdb.FlagRocksdbOpts, "", "Options of rocksdb. (block_size=4KB,block_cache=1GB,statistics=true,allow_mmap_reads=true,max_open_files=-1,unordered_write=true,pipelined_write=true)")
+	cmd.Flags().String(tmdb.FlagPebbledbOpts, "", "Options of pebbledb. (block_size=32KB,block_cache=1GB,statistics=true,max_open_files=16384,memtable_size=256MB,l0_compaction_threshold=2,l0_stop_writes_threshold=1000,lbase_max_bytes=64MB,max_concurrent_compactions=8,bytes_per_sync=512KB)")
 	cmd.Flags().String(types.FlagNodeMode, "", "Node mode (rpc|val|archive) is used to manage flags")
 
 	cmd.Flags().Bool(consensus.EnablePrerunTx, true, "enable proactively runtx mode, default open")
@@ -49,8 +49,12 @@ import (
 )
 
 const (
-	flagHeight  = "height"
-	flagPruning = "enable_pruning"
+	flagHeight      = "height"
+	flagPruning     = "enable_pruning"
+	flagFromBackend = "from"
+	flagToBackend   = "to"
+
+	convertBatchSize = 10000
 
 	blockDBName = "blockstore"
 	stateDBName = "state"
@@ -69,6 +73,7 @@ func dataCmd(ctx *server.Context) *cobra.Command {
 		pruningCmd(ctx),
 		queryCmd(ctx),
 		dbConvertCmd(ctx),
+		doctorCmd(ctx),
 	)
 
 	return cmd
@@ -88,7 +93,7 @@ func pruningCmd(ctx *server.Context) *cobra.Command {
 
 	cmd.PersistentFlags().Int64P(flagHeight, "r", 0, "Removes block or state up to (but not including) a height")
 	cmd.PersistentFlags().BoolP(flagPruning, "p", true, "Enable pruning")
-	cmd.PersistentFlags().String(sdk.FlagDBBackend, tmtypes.DBBackend, "Database backend: goleveldb | rocksdb")
+	cmd.PersistentFlags().String(sdk.FlagDBBackend, tmtypes.DBBackend, "Database backend: goleveldb | rocksdb | pebbledb")
 	return cmd
 }
 
@@ -244,11 +249,23 @@ func pruneBlockCmd(ctx *server.Context) *cobra.Command {
 func dbConvertCmd(ctx *server.Context) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "convert",
-		Short: "Convert " + system.ChainName + " data from goleveldb to rocksdb",
+		Short: "Convert " + system.ChainName + " data between db backends, from goleveldb to rocksdb by default",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			config := ctx.Config
 			config.SetRoot(viper.GetString(flags.FlagHome))
 
+			fromType := dbm.BackendType(viper.GetString(flagFromBackend))
+			toType := dbm.BackendType(viper.GetString(flagToBackend))
+			if fromType == toType {
+				return fmt.Errorf("the source and target db_backend are both %s", fromType)
+			}
+			if err := checkBackend(fromType); err != nil {
+				return err
+			}
+			if err := checkBackend(toType); err != nil {
+				return err
+			}
+
 			// {home}/data/*
 			fromDir := ctx.Config.DBDir()
 			toDir := filepath.Join(ctx.Config.RootDir, "data_convert")
@@ -269,7 +286,7 @@ func dbConvertCmd(ctx *server.Context) *cobra.Command {
 					wg.Add(1)
 					go func(name, fromDir, toDir string) {
 						defer wg.Done()
-						LtoR(name, fromDir, toDir)
+						convertDB(name, fromDir, toDir, fromType, toType)
 					}(str[0], fromDir, toDir)
 				} else {
 					// cp
@@ -294,10 +311,51 @@ func dbConvertCmd(ctx *server.Context) *cobra.Command {
 			return nil
 		},
 	}
+	cmd.Flags().String(flagFromBackend, string(dbm.GoLevelDBBackend), "Source database backend: goleveldb | rocksdb | pebbledb")
+	cmd.Flags().String(flagToBackend, string(dbm.RocksDBBackend), "Target database backend: rocksdb | pebbledb | goleveldb")
 
 	return cmd
 }
 
+// convertDB copies all the kvs of the db from fromDir to toDir in the target backend,
+// the target db is compacted at last
+func convertDB(name, fromDir, toDir string, fromType, toType dbm.BackendType) {
+	log.Printf("convert %s(%s => %s) start...\n", name, fromType, toType)
+
+	fromDB := dbm.NewDB(name, fromType, fromDir)
+	defer fromDB.Close()
+	toDB := dbm.NewDB(name, toType, toDir)
+	defer toDB.Close()
+
+	iter, err := fromDB.Iterator(nil, nil)
+	if err != nil {
+		panic(err)
+	}
+	batch := toDB.NewBatch()
+	count := 0
+	for ; iter.Valid(); iter.Next() {
+		batch.Set(iter.Key(), iter.Value())
+		count++
+		if count%convertBatchSize == 0 {
+			if err := batch.Write(); err != nil {
+				panic(err)
+			}
+			batch.Close()
+			batch = toDB.NewBatch()
+		}
+	}
+	iter.Close()
+	if err := batch.WriteSync(); err != nil {
+		panic(err)
+	}
+	batch.Close()
+	log.Printf("convert %s(%s => %s) end, %d keys.\n", name, fromType, toType, count)
+
+	log.Printf("compact %s start...\n", name)
+	backends[toType](toDB)
+	log.Printf("compact %s end.\n", name)
+}
+
 func getPruneBlockParams(blockStoreDB dbm.DB) (baseHeight, retainHeight int64) {
 	baseHeight, size := getBlockInfo(blockStoreDB)
 
@@ -449,6 +507,17 @@ func pruneApp(appDB dbm.DB, from, to int64) {
 }
 
 func initAppStore(appDB dbm.DB) *rootmulti.Store {
+	rs := mountAppStore(appDB)
+	err := rs.LoadLatestVersion()
+	if err != nil {
+		panic(err)
+	}
+
+	return rs
+}
+
+// mountAppStore mounts the stores of the application without loading them
+func mountAppStore(appDB dbm.DB) *rootmulti.Store {
 	cms := cmstore.NewCommitMultiStore(appDB)
 
 	keys := sdk.NewKVStoreKeys(
@@ -468,11 +537,6 @@ func initAppStore(appDB dbm.DB) *rootmulti.Store {
 		cms.MountStoreWithDB(key, sdk.StoreTypeTransient, nil)
 	}
 
-	err := cms.LoadLatestVersion()
-	if err != nil {
-		panic(err)
-	}
-
 	rs, ok := cms.(*rootmulti.Store)
 	if !ok {
 		panic("cms of from app is not rootmulti store")
@@ -555,7 +619,7 @@ func queryCmd(ctx *server.Context) *cobra.Command {
 	}
 
 	cmd.AddCommand(queryBlockState, queryAppState)
-	cmd.PersistentFlags().String(sdk.FlagDBBackend, tmtypes.DBBackend, "Database backend: goleveldb | rocksdb")
+	cmd.PersistentFlags().String(sdk.FlagDBBackend, tmtypes.DBBackend, "Database backend: goleveldb | rocksdb | pebbledb")
 
 	return cmd
 }
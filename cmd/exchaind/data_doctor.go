@@ -0,0 +1,290 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/client/flags"
+	"github.com/okex/exchain/libs/cosmos-sdk/server"
+	"github.com/okex/exchain/libs/cosmos-sdk/store/flatkv"
+	"github.com/okex/exchain/libs/cosmos-sdk/store/mpt"
+	"github.com/okex/exchain/libs/cosmos-sdk/store/rootmulti"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/tendermint/consensus"
+	tmlog "github.com/okex/exchain/libs/tendermint/libs/log"
+	tmos "github.com/okex/exchain/libs/tendermint/libs/os"
+	sm "github.com/okex/exchain/libs/tendermint/state"
+	"github.com/okex/exchain/libs/tendermint/store"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	dbm "github.com/okex/exchain/libs/tm-db"
+	"github.com/okex/exchain/x/evm/watcher"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	flagRollback = "rollback"
+	flagDryRun   = "dry-run"
+	flagDepth    = "depth"
+
+	flatKVDBName = "flat"
+)
+
+// doctorPlan is the result of the check of the stores and the rollback needed
+// to make them consistent again
+type doctorPlan struct {
+	blockBase   int64
+	blockHeight int64
+	// blockTop is the highest height below which the checked blocks are fine
+	blockTop    int64
+	stateHeight int64
+	appHeight   int64
+	wal         consensus.WALInfo
+
+	blockTarget int64
+	stateTarget int64
+	// appTarget is 0 if the application doesn't need to be rolled back
+	appTarget int64
+	// walTarget is the #ENDHEIGHT to truncate the WAL after, 0 if not needed
+	walTarget int64
+	issues    []string
+}
+
+func (p *doctorPlan) issuef(format string, args ...interface{}) {
+	p.issues = append(p.issues, fmt.Sprintf(format, args...))
+}
+
+func doctorCmd(ctx *server.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Check the consensus WAL, blocks, states and application states for inconsistencies",
+		Long: `Check the consensus WAL, the block store, the state store and the application
+commit info for inconsistencies, for instance after a power loss, and report them.
+With --rollback the WAL is repaired and all the stores are rolled back to the last
+consistent height, from which the node replays the blocks on start.
+With --dry-run the rollback is only printed.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := ctx.Config
+			config.SetRoot(viper.GetString(flags.FlagHome))
+
+			if err := checkBackend(dbm.BackendType(ctx.Config.DBBackend)); err != nil {
+				return err
+			}
+
+			blockStoreDB := initDB(config, blockDBName)
+			stateDB := initDB(config, stateDBName)
+			appDB := initDB(config, appDBName)
+			defer func() {
+				blockStoreDB.Close()
+				stateDB.Close()
+				appDB.Close()
+			}()
+
+			blockStore := store.NewBlockStore(blockStoreDB)
+			rs := mountAppStore(appDB)
+			rs.SetLogger(tmlog.NewNopLogger())
+			walFile := config.Consensus.WalFile()
+
+			plan, err := checkStores(blockStore, stateDB, rs, walFile, viper.GetInt64(flagDepth))
+			if err != nil {
+				return err
+			}
+			printDoctorPlan(plan)
+
+			if !viper.GetBool(flagRollback) || !plan.needRollback() {
+				return nil
+			}
+			if viper.GetBool(flagDryRun) {
+				log.Println("dry run, nothing is changed")
+				return nil
+			}
+			return rollbackStores(plan, blockStore, stateDB, rs, walFile)
+		},
+	}
+
+	cmd.Flags().Bool(flagRollback, false, "Repair the WAL and roll back all the stores to the last consistent height")
+	cmd.Flags().Bool(flagDryRun, false, "Only print the rollback, used with --rollback")
+	cmd.Flags().Int64(flagDepth, 100, "Number of the latest blocks to check for corruption")
+	cmd.Flags().String(sdk.FlagDBBackend, tmtypes.DBBackend, "Database backend: goleveldb | rocksdb | pebbledb")
+	return cmd
+}
+
+// checkStores scans the stores and computes the heights to roll them back to.
+// They are consistent when the block store is at most one block ahead of the
+// state store and the application is not ahead of the block store, the WAL
+// must not have any #ENDHEIGHT after the block store.
+func checkStores(blockStore *store.BlockStore, stateDB dbm.DB, rs *rootmulti.Store,
+	walFile string, depth int64) (*doctorPlan, error) {
+	plan := &doctorPlan{
+		blockBase:   blockStore.Base(),
+		blockHeight: blockStore.Height(),
+	}
+	state := sm.LoadState(stateDB)
+	if state.IsEmpty() {
+		return nil, fmt.Errorf("no state found")
+	}
+	plan.stateHeight = state.LastBlockHeight
+	plan.appHeight = rs.GetLatestVersion()
+
+	var err error
+	plan.wal, err = consensus.ScanWAL(walFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan WAL %s: %w", walFile, err)
+	}
+	if plan.wal.Corruption != nil {
+		plan.issuef("WAL is corrupted: %s", plan.wal.Corruption)
+	}
+
+	plan.blockTop = plan.blockHeight
+	for height := plan.blockHeight; height > plan.blockHeight-depth && height >= plan.blockBase && height > 0; height-- {
+		if err := blockStore.CheckBlock(height); err != nil {
+			plan.issuef("block %d is corrupted: %v", height, err)
+			plan.blockTop = height - 1
+		}
+	}
+
+	if plan.stateHeight <= plan.blockTop {
+		plan.stateTarget = plan.stateHeight
+		plan.blockTarget = plan.stateHeight + 1
+		if plan.blockTarget > plan.blockTop {
+			plan.blockTarget = plan.blockTop
+		}
+		if plan.blockTarget < plan.blockTop {
+			plan.issuef("block store height %d is ahead of state store height %d", plan.blockTop, plan.stateHeight)
+		}
+	} else {
+		plan.issuef("state store height %d is ahead of block store height %d", plan.stateHeight, plan.blockTop)
+		// the state is rebuilt from the meta of the next block
+		plan.stateTarget = plan.blockTop - 1
+		plan.blockTarget = plan.blockTop
+	}
+	if plan.stateTarget < plan.blockBase || plan.stateTarget < 1 {
+		return nil, fmt.Errorf("no consistent height found, block store is [%d ~ %d], state store height is %d",
+			plan.blockBase, plan.blockTop, plan.stateHeight)
+	}
+
+	if plan.appHeight > plan.blockTarget {
+		plan.issuef("application height %d is ahead of block store height %d", plan.appHeight, plan.blockTarget)
+		plan.appTarget, err = rs.GetCommitVersionUpTo(plan.blockTarget)
+		if err != nil {
+			return nil, err
+		}
+		if plan.appTarget == 0 {
+			return nil, fmt.Errorf("no application version found up to height %d", plan.blockTarget)
+		}
+	}
+
+	if plan.wal.LastEndHeight > plan.blockTarget {
+		plan.issuef("WAL #ENDHEIGHT %d is ahead of block store height %d", plan.wal.LastEndHeight, plan.blockTarget)
+		plan.walTarget = plan.blockTarget
+	}
+	return plan, nil
+}
+
+func (p *doctorPlan) needRollback() bool {
+	return len(p.issues) > 0
+}
+
+func printDoctorPlan(plan *doctorPlan) {
+	log.Printf("block store: [%d ~ %d]\n", plan.blockBase, plan.blockHeight)
+	log.Printf("state store: %d\n", plan.stateHeight)
+	log.Printf("application: %d\n", plan.appHeight)
+	log.Printf("WAL: %d files, last #ENDHEIGHT %d\n", len(plan.wal.Files), plan.wal.LastEndHeight)
+
+	if !plan.needRollback() {
+		log.Println("--------- no inconsistency found ---------")
+		return
+	}
+	log.Println("--------- inconsistencies ---------")
+	for _, issue := range plan.issues {
+		log.Println(issue)
+	}
+	log.Println("--------- rollback ---------")
+	if plan.wal.Corruption != nil {
+		log.Printf("WAL: drop the data from %s\n", plan.wal.Corruption)
+	}
+	if plan.walTarget > 0 {
+		log.Printf("WAL: drop the data after #ENDHEIGHT %d\n", plan.walTarget)
+	}
+	if plan.appTarget > 0 {
+		log.Printf("application: %d -> %d\n", plan.appHeight, plan.appTarget)
+	}
+	if plan.stateTarget < plan.stateHeight {
+		log.Printf("state store: %d -> %d\n", plan.stateHeight, plan.stateTarget)
+	}
+	if plan.blockTarget < plan.blockHeight {
+		log.Printf("block store: %d -> %d\n", plan.blockHeight, plan.blockTarget)
+	}
+}
+
+// rollbackStores rolls the stores back from the highest one down, so that the
+// check finds the same heights again if it is interrupted.
+func rollbackStores(plan *doctorPlan, blockStore *store.BlockStore, stateDB dbm.DB,
+	rs *rootmulti.Store, walFile string) error {
+	log.Println("--------- rollback start ---------")
+	if plan.wal.Corruption != nil {
+		if _, err := consensus.RepairWAL(walFile); err != nil {
+			return fmt.Errorf("failed to repair WAL: %w", err)
+		}
+	}
+	if plan.walTarget > 0 {
+		if _, err := consensus.TruncateWAL(walFile, plan.walTarget); err != nil {
+			return fmt.Errorf("failed to truncate WAL: %w", err)
+		}
+	}
+	if plan.appTarget > 0 {
+		if err := rollbackApp(rs, plan.appTarget); err != nil {
+			return err
+		}
+	}
+	if plan.stateTarget < plan.stateHeight {
+		if _, err := sm.RollbackState(stateDB, blockStore, plan.stateTarget); err != nil {
+			return fmt.Errorf("failed to roll back state store: %w", err)
+		}
+	}
+	if plan.blockTarget < plan.blockHeight {
+		if _, err := blockStore.DeleteBlocksFromTop(plan.blockTarget); err != nil {
+			return fmt.Errorf("failed to roll back block store: %w", err)
+		}
+	}
+	log.Println("--------- rollback done ---------")
+	return nil
+}
+
+// rollbackApp rolls back the mpt and the multistore, whose versions are checked
+// before changing them, then the flat kv and watch dbs built from them.
+func rollbackApp(rs *rootmulti.Store, version int64) error {
+	if err := mpt.RollbackVersions(mpt.InstanceOfMptStore(), version); err != nil {
+		return fmt.Errorf("failed to roll back mpt: %w", err)
+	}
+	if err := rs.RollbackToVersion(version); err != nil {
+		return fmt.Errorf("failed to roll back application: %w", err)
+	}
+
+	dataDir := filepath.Join(viper.GetString(flags.FlagHome), watcher.WatchDbDir)
+	if tmos.FileExists(filepath.Join(dataDir, flatKVDBName+".db")) {
+		flatKVDB, err := sdk.NewDB(flatKVDBName, dataDir)
+		if err != nil {
+			return err
+		}
+		err = flatkv.ResetDB(flatKVDB)
+		flatKVDB.Close()
+		if err != nil {
+			return fmt.Errorf("failed to reset flat kv db: %w", err)
+		}
+	}
+	if tmos.FileExists(filepath.Join(dataDir, watcher.WatchDBName+".db")) {
+		watchDB, err := sdk.NewDB(watcher.WatchDBName, dataDir)
+		if err != nil {
+			return err
+		}
+		// the watcher writes the blocks when the application executes them
+		err = watcher.RollbackWatchDB(watchDB, version)
+		watchDB.Close()
+		if err != nil {
+			return fmt.Errorf("failed to roll back watch db: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package main
+
+import (
+	dbm "github.com/okex/exchain/libs/tm-db"
+)
+
+func init() {
+	dbCompactor := func(db dbm.DB) {
+		if pdb, ok := db.(*dbm.PebbleDB); ok {
+			if err := pdb.Compact(); err != nil {
+				panic(err)
+			}
+		}
+	}
+
+	registerDBCompactor(dbm.PebbleDBBackend, dbCompactor)
+}
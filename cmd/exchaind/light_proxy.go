@@ -0,0 +1,147 @@
+package main
+
+import (
+	"encoding/hex"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/okex/exchain/app/rpc/light"
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	tmos "github.com/okex/exchain/libs/tendermint/libs/os"
+	lite "github.com/okex/exchain/libs/tendermint/lite2"
+	dbs "github.com/okex/exchain/libs/tendermint/lite2/store/db"
+	rpchttp "github.com/okex/exchain/libs/tendermint/rpc/client/http"
+	dbm "github.com/okex/exchain/libs/tm-db"
+	evmtypes "github.com/okex/exchain/x/evm/types"
+)
+
+const (
+	flagLightLaddr          = "laddr"
+	flagLightPrimary        = "primary"
+	flagLightWitnesses      = "witnesses"
+	flagLightEthRPC         = "eth-rpc"
+	flagLightHome           = "home-dir"
+	flagLightTrustingPeriod = "trusting-period"
+	flagLightHeight         = "height"
+	flagLightHash           = "hash"
+)
+
+func lightProxyCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "light-proxy [chainID]",
+		Short: "Run a light client proxy server, verifying the eth json-rpc",
+		Long: `Run a light client proxy server, verifying the eth json-rpc.
+
+The headers of the primary node are verified by a light client cross-checked
+with the witnesses. The responses of eth_getBalance, eth_getCode and
+eth_getStorageAt are verified against the app hash of the verified header
+with the merkle proofs of the primary before they are returned. The other
+requests are forwarded to the eth json-rpc of the primary without verification.
+
+The state of height H is proven by the header of height H+1, so latest and
+pending are served at the height before the latest block of the primary.
+`,
+		Args: cobra.ExactArgs(1),
+		Example: `light-proxy exchain-66 -p tcp://127.0.0.1:26657 -w tcp://10.0.0.1:26657 --eth-rpc http://127.0.0.1:8545
+	--height 5810700 --hash 28B97BE9F6DE51AC69F70E0B7BFD7E5C9CD1A595B7DC31AFF27C50D4948020CD`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLightProxy(cdc, args[0])
+		},
+	}
+	cmd.Flags().String(flagLightLaddr, "localhost:8546", "Serve the proxy on the given address")
+	cmd.Flags().StringP(flagLightPrimary, "p", "", "Connect to a Tendermint node at this address")
+	cmd.Flags().StringP(flagLightWitnesses, "w", "", "Tendermint nodes to cross-check the primary node, comma-separated")
+	cmd.Flags().String(flagLightEthRPC, "http://localhost:8545", "The eth json-rpc of the primary node")
+	cmd.Flags().String(flagLightHome, ".exchain-light", "Specify the home directory")
+	cmd.Flags().Duration(flagLightTrustingPeriod, 168*time.Hour,
+		"Trusting period. Should be significantly less than the unbonding period")
+	cmd.Flags().Int64(flagLightHeight, 1, "Trusted header's height")
+	cmd.Flags().String(flagLightHash, "", "Trusted header's hash")
+	cmd.Flags().BoolVar(&evmtypes.TrieUseCompositeKey, evmtypes.FlagTrieUseCompositeKey, false,
+		"Use composite key to store contract state in mpt, must be the same as the primary")
+	return cmd
+}
+
+func runLightProxy(cdc *codec.Codec, chainID string) error {
+	logger := log.NewTMLogger(log.NewSyncWriter(os.Stdout))
+
+	primaryAddr := viper.GetString(flagLightPrimary)
+	witnessesAddrs := strings.Split(viper.GetString(flagLightWitnesses), ",")
+	home := viper.GetString(flagLightHome)
+	trustingPeriod := viper.GetDuration(flagLightTrustingPeriod)
+	trustedHeight := viper.GetInt64(flagLightHeight)
+	trustedHash, err := hex.DecodeString(viper.GetString(flagLightHash))
+	if err != nil {
+		return errors.Wrap(err, "invalid trusted header's hash")
+	}
+
+	db, err := dbm.NewGoLevelDB("light-client-db", home)
+	if err != nil {
+		return errors.Wrap(err, "new goleveldb")
+	}
+
+	var c *lite.Client
+	if trustedHeight > 0 && len(trustedHash) > 0 { // fresh installation
+		c, err = lite.NewHTTPClient(
+			chainID,
+			lite.TrustOptions{
+				Period: trustingPeriod,
+				Height: trustedHeight,
+				Hash:   trustedHash,
+			},
+			primaryAddr,
+			witnessesAddrs,
+			dbs.New(db, chainID),
+			lite.Logger(logger),
+		)
+	} else { // continue from latest state
+		c, err = lite.NewHTTPClientFromTrustedStore(
+			chainID,
+			trustingPeriod,
+			primaryAddr,
+			witnessesAddrs,
+			dbs.New(db, chainID),
+			lite.Logger(logger),
+		)
+	}
+	if err != nil {
+		return err
+	}
+
+	rpcClient, err := rpchttp.New(primaryAddr, "/websocket")
+	if err != nil {
+		return errors.Wrapf(err, "http client for %s", primaryAddr)
+	}
+	ethAddr := viper.GetString(flagLightEthRPC)
+	ethClient, err := rpc.Dial(ethAddr)
+	if err != nil {
+		return errors.Wrapf(err, "eth rpc client for %s", ethAddr)
+	}
+	defer ethClient.Close()
+
+	proxy := light.NewProxy(light.NewVerifier(c, rpcClient, cdc), ethClient, logger)
+	listenAddr := viper.GetString(flagLightLaddr)
+	listener, err := net.Listen("tcp", listenAddr)
+	if err != nil {
+		return err
+	}
+	// Stop upon receiving SIGTERM or CTRL-C.
+	tmos.TrapSignal(logger, func() {
+		listener.Close()
+	})
+
+	logger.Info("Starting light proxy...", "laddr", listenAddr, "primary", primaryAddr, "eth-rpc", ethAddr)
+	if err := http.Serve(listener, proxy); err != nil && !strings.Contains(err.Error(), "use of closed network connection") {
+		logger.Error("light proxy Serve", "err", err)
+	}
+	return nil
+}
@@ -89,6 +89,7 @@ func main() {
 		client.TestnetCmd(ctx, codecProxy.GetCdc(), app.ModuleBasics, auth.GenesisAccountIterator{}),
 		replayCmd(ctx, client.RegisterAppFlag, codecProxy, newApp, registry, registerRoutes),
 		repairStateCmd(ctx),
+		rollbackCmd(ctx),
 		displayStateCmd(ctx),
 		mpt.MptCmd(ctx),
 		fss.Command(ctx),
@@ -99,6 +100,7 @@ func main() {
 		exportAppCmd(ctx),
 		iaviewerCmd(ctx, codecProxy.GetCdc()),
 		subscribeCmd(codecProxy.GetCdc()),
+		lightProxyCmd(codecProxy.GetCdc()),
 	)
 
 	subFunc := func(logger log.Logger) log.Subscriber {
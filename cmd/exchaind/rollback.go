@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/client/flags"
+	"github.com/okex/exchain/libs/cosmos-sdk/server"
+	"github.com/okex/exchain/libs/cosmos-sdk/store/rootmulti"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/tendermint/consensus"
+	tmlog "github.com/okex/exchain/libs/tendermint/libs/log"
+	sm "github.com/okex/exchain/libs/tendermint/state"
+	"github.com/okex/exchain/libs/tendermint/store"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	dbm "github.com/okex/exchain/libs/tm-db"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const flagBlocks = "blocks"
+
+func rollbackCmd(ctx *server.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rollback",
+		Short: "Roll back the node by the given number of blocks",
+		Long: `Roll back the state store and the block store by the given number of blocks,
+and the application states (iavl and mpt), the watch db and the flat kv db with them,
+so that the node executes the blocks again on start. The application is rolled back
+to its latest version not higher than the new height, the blocks between them are
+replayed from the block store. The node must be stopped.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := ctx.Config
+			config.SetRoot(viper.GetString(flags.FlagHome))
+
+			if err := checkBackend(dbm.BackendType(ctx.Config.DBBackend)); err != nil {
+				return err
+			}
+			blocks := viper.GetInt64(flagBlocks)
+			if blocks <= 0 {
+				return fmt.Errorf("--%s must be positive", flagBlocks)
+			}
+
+			blockStoreDB := initDB(config, blockDBName)
+			stateDB := initDB(config, stateDBName)
+			appDB := initDB(config, appDBName)
+			defer func() {
+				blockStoreDB.Close()
+				stateDB.Close()
+				appDB.Close()
+			}()
+
+			blockStore := store.NewBlockStore(blockStoreDB)
+			rs := mountAppStore(appDB)
+			rs.SetLogger(tmlog.NewNopLogger())
+
+			state := sm.LoadState(stateDB)
+			if state.IsEmpty() {
+				return fmt.Errorf("no state found")
+			}
+			stateHeight, blockHeight, appHeight := state.LastBlockHeight, blockStore.Height(), rs.GetLatestVersion()
+			if (blockHeight != stateHeight && blockHeight != stateHeight+1) || appHeight > blockHeight {
+				return fmt.Errorf("the stores are inconsistent, block store height %d, state store height %d, "+
+					"application height %d, please check them with `exchaind data doctor`", blockHeight, stateHeight, appHeight)
+			}
+
+			height := stateHeight - blocks
+			if height < blockStore.Base() || height < 1 {
+				return fmt.Errorf("cannot roll back %d blocks from height %d, the lowest block is %d",
+					blocks, stateHeight, blockStore.Base())
+			}
+			appVersion, err := rs.GetCommitVersionUpTo(height)
+			if err != nil {
+				return err
+			}
+			if appVersion == 0 {
+				return fmt.Errorf("no application version found up to height %d", height)
+			}
+
+			log.Printf("block store: %d -> %d\n", blockHeight, height)
+			log.Printf("state store: %d -> %d\n", stateHeight, height)
+			log.Printf("application: %d -> %d\n", appHeight, appVersion)
+			if viper.GetBool(flagDryRun) {
+				log.Println("dry run, nothing is changed")
+				return nil
+			}
+			return rollbackNode(config.Consensus.WalFile(), blockStore, stateDB, rs, height, appVersion)
+		},
+	}
+
+	cmd.Flags().Int64(flagBlocks, 1, "Number of the latest blocks to roll back")
+	cmd.Flags().Bool(flagDryRun, false, "Only print the heights to roll back to")
+	cmd.Flags().String(sdk.FlagDBBackend, tmtypes.DBBackend, "Database backend: goleveldb | rocksdb | pebbledb")
+	return cmd
+}
+
+// rollbackNode rolls back the stores of the node from the top down, the WAL first
+// and the block store last.
+func rollbackNode(walFile string, blockStore *store.BlockStore, stateDB dbm.DB,
+	rs *rootmulti.Store, height, appVersion int64) error {
+	log.Println("--------- rollback start ---------")
+	wal, err := consensus.ScanWAL(walFile)
+	if err != nil {
+		return fmt.Errorf("failed to scan WAL %s: %w", walFile, err)
+	}
+	if wal.LastEndHeight > height {
+		if _, err := consensus.TruncateWAL(walFile, height); err != nil {
+			return fmt.Errorf("failed to truncate WAL: %w", err)
+		}
+	}
+	if err := rollbackApp(rs, appVersion); err != nil {
+		return err
+	}
+	if _, err := sm.RollbackState(stateDB, blockStore, height); err != nil {
+		return fmt.Errorf("failed to roll back state store: %w", err)
+	}
+	if _, err := blockStore.DeleteBlocksFromTop(height); err != nil {
+		return fmt.Errorf("failed to roll back block store: %w", err)
+	}
+	log.Println("--------- rollback done ---------")
+	return nil
+}
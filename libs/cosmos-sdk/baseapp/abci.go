@@ -554,6 +554,21 @@ func handleQueryApp(app *BaseApp, path []string, req abci.RequestQuery) abci.Res
 			}
 			return handleSimulateWithBuffer(app, path, req.Height, queryData.TxBytes, queryData.OverridesBytes)
 
+		case "simulateBundle":
+			var txs [][]byte
+			if err := codec.Cdc.UnmarshalBinaryBare(req.Data, &txs); err != nil {
+				return sdkerrors.QueryResult(sdkerrors.Wrap(err, "failed to decode bundle txs"))
+			}
+			results, err := app.SimulateBundle(txs)
+			if err != nil {
+				return sdkerrors.QueryResult(err)
+			}
+			return abci.ResponseQuery{
+				Codespace: sdkerrors.RootCodespace,
+				Height:    app.LastBlockHeight(),
+				Value:     codec.Cdc.MustMarshalBinaryBare(sdk.BundleSimulationResponse{Results: results}),
+			}
+
 		case "trace":
 			var queryParam sdk.QueryTraceTx
 			err := json.Unmarshal(req.Data, &queryParam)
@@ -43,6 +43,7 @@ func handleGasRefund(info *runTxInfo, cacheTxCtxFunc CacheTxContextFunc, gasRefu
 	}
 
 	gasRefundCtx.SetOutOfGas(info.outOfGas)
+	gasRefundCtx.SetTxSucceeded(info.runMsgFinished && info.result != nil)
 	refund, err := gasRefundHandler(gasRefundCtx, info.tx)
 	if err != nil {
 		panic(err)
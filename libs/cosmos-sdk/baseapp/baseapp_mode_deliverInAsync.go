@@ -21,6 +21,7 @@ func (m *modeHandlerDeliverInAsync) handleDeferRefund(info *runTxInfo) {
 		gasRefundCtx.SetMultiStore(info.msCache)
 	}
 	gasRefundCtx.SetOutOfGas(info.outOfGas)
+	gasRefundCtx.SetTxSucceeded(info.runMsgFinished && info.result != nil)
 	refundGas, err := app.GasRefundHandler(gasRefundCtx, info.tx)
 	if err != nil {
 		panic(err)
@@ -1021,6 +1021,65 @@ func TestSimulateTx(t *testing.T) {
 	}
 }
 
+func TestSimulateBundle(t *testing.T) {
+	anteKey := []byte("ante-key")
+	anteOpt := func(bapp *BaseApp) { bapp.SetAnteHandler(anteHandlerTxTest(t, capKey1, anteKey)) }
+
+	deliverKey := []byte("deliver-key")
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, handlerMsgCounter(t, capKey1, deliverKey))
+	}
+
+	app := setupBaseApp(t, anteOpt, routerOpt)
+	app.InitChain(abci.RequestInitChain{})
+
+	cdc := codec.New()
+	registerTestCodec(cdc)
+
+	header := abci.Header{Height: 1}
+	app.BeginBlock(abci.RequestBeginBlock{Header: header})
+	app.EndBlock(abci.RequestEndBlock{})
+	app.Commit(abci.RequestCommit{})
+
+	simulate := func(txs ...*txTest) []sdk.BundleTxResult {
+		bundle := make([][]byte, len(txs))
+		for i, tx := range txs {
+			bundle[i] = cdc.MustMarshalBinaryLengthPrefixed(tx)
+		}
+		res := app.Query(abci.RequestQuery{
+			Path: "/app/simulateBundle",
+			Data: codec.Cdc.MustMarshalBinaryBare(bundle),
+		})
+		require.True(t, res.IsOK(), res.Log)
+		var simRes sdk.BundleSimulationResponse
+		require.NoError(t, codec.Cdc.UnmarshalBinaryBare(res.Value, &simRes))
+		require.Len(t, simRes.Results, len(txs))
+		return simRes.Results
+	}
+
+	// the txs run in order on the same state
+	results := simulate(newTxCounter(0, 0), newTxCounter(1, 1))
+	require.Equal(t, abci.CodeTypeOK, results[0].Code, results[0].Log)
+	require.Equal(t, abci.CodeTypeOK, results[1].Code, results[1].Log)
+
+	// a failed tx doesn't stop the following ones
+	failedAnte := newTxCounter(1, 1)
+	failedAnte.setFailOnAnte(true)
+	failedHandler := newTxCounter(1, 1)
+	failedHandler.setFailOnHandler(true)
+	// the ante of the tx failed in the handler is kept as in a block
+	results = simulate(newTxCounter(0, 0), failedAnte, failedHandler, newTxCounter(2, 1))
+	require.Equal(t, abci.CodeTypeOK, results[0].Code, results[0].Log)
+	require.NotEqual(t, abci.CodeTypeOK, results[1].Code)
+	require.NotEqual(t, abci.CodeTypeOK, results[2].Code)
+	require.Equal(t, abci.CodeTypeOK, results[3].Code, results[3].Log)
+
+	// the simulations don't change the committed state
+	store := app.cms.GetKVStore(capKey1)
+	require.Equal(t, int64(0), getIntFromStore(store, anteKey))
+	require.Equal(t, int64(0), getIntFromStore(store, deliverKey))
+}
+
 func TestRunInvalidTransaction(t *testing.T) {
 	anteOpt := func(bapp *BaseApp) {
 		bapp.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx, simulate bool) (newCtx sdk.Context, err error) {
@@ -78,7 +78,11 @@ func (app *BaseApp) beginBlockForTracing(firstTx []byte, block *tmtypes.Block) (
 		Hash:   block.Hash(),
 		Header: tmtypes.TM2PB.Header(&block.Header),
 	}
+	return app.beginTraceBlock(req)
+}
 
+// beginTraceBlock inits the traceState on top of the state of the block before req
+func (app *BaseApp) beginTraceBlock(req abci.RequestBeginBlock) (*state, error) {
 	//set traceState instead of app.deliverState
 	//need to reset to version = req.Header.Height-1
 	traceState, err := app.newTraceState(req.Header, req.Header.Height-1)
@@ -108,3 +112,37 @@ func (app *BaseApp) beginBlockForTracing(firstTx []byte, block *tmtypes.Block) (
 	// No need to set the signed validators for addition to context in deliverTx
 	return traceState, nil
 }
+
+// SimulateBundle runs the txs of a bundle in order on top of the latest committed
+// state, as they are delivered at the top of the next block, and returns the
+// result of every tx. The failure of a tx doesn't stop the following ones.
+func (app *BaseApp) SimulateBundle(txs [][]byte) ([]sdk.BundleTxResult, error) {
+	header := app.checkState.ctx.BlockHeader()
+	header.Height = app.LastBlockHeight() + 1
+	traceState, err := app.beginTraceBlock(abci.RequestBeginBlock{Header: header})
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "failed to beginblock for the bundle")
+	}
+	traceState.ctx.SetIsTraceTxLog(false)
+
+	results := make([]sdk.BundleTxResult, len(txs))
+	for i, txBytes := range txs {
+		tx, err := app.txDecoder(txBytes, header.Height)
+		if err != nil {
+			_, code, log := sdkerrors.ABCIInfo(err, app.trace)
+			results[i] = sdk.BundleTxResult{Code: code, Log: log}
+			continue
+		}
+		info, err := app.tracetx(txBytes, tx, header.Height, traceState)
+		results[i] = sdk.BundleTxResult{
+			GasWanted: tx.GetGas(),
+			GasUsed:   info.gInfo.GasUsed,
+			TxHash:    tx.TxHash(),
+			From:      tx.GetEthAddr(),
+		}
+		if err != nil {
+			_, results[i].Code, results[i].Log = sdkerrors.ABCIInfo(err, app.trace)
+		}
+	}
+	return results, nil
+}
@@ -258,6 +258,10 @@ func startInProcess(ctx *Context, cdc *codec.CodecProxy, registry jsonpb.AnyReso
 		tmNode.Mempool().SetTxInfoParser(parser)
 	}
 
+	if classifier, ok := app.(mempool.TxLaneClassifier); ok {
+		tmNode.Mempool().SetTxLaneFunc(classifier.GetTxLane)
+	}
+
 	// run forever (the node will not be returned)
 	select {}
 }
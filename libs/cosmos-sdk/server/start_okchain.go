@@ -192,6 +192,8 @@ func RegisterServerFlags(cmd *cobra.Command) *cobra.Command {
 	cmd.Flags().Int(tmtypes.FlagDDSCompressType, 0, "delta compress type. 0|1|2|3")
 	cmd.Flags().Int(tmtypes.FlagDDSCompressFlag, 0, "delta compress flag. 0|1|2")
 	cmd.Flags().Int(tmtypes.FlagBufferSize, 10, "delta buffer size")
+	cmd.Flags().String(tmtypes.FlagDeltaBroker, tmtypes.DeltaBrokerRedis, "Distribute deltas through redis or p2p. redis|p2p")
+	cmd.Flags().String(tmtypes.FlagDeltaProducers, "", "Comma separated node ids of the trusted delta producers (used by the p2p delta broker)")
 	cmd.Flags().String(FlagLogServerUrl, "", "log server url")
 	cmd.Flags().Int(tmtypes.FlagDeltaVersion, tmtypes.DeltaVersion, "Specify delta version")
 	cmd.Flags().Int(tmtypes.FlagBlockCompressType, 0, "block compress type. 0|1|2|3")
@@ -207,3 +207,33 @@ func (st *Store) setLatestVersion(batch dbm.Batch, version int64) {
 	latestBytes := cdc.MustMarshalBinaryLengthPrefixed(version)
 	batch.Set([]byte(latestVersionKey), latestBytes)
 }
+
+// ResetDB deletes all the data of the flat kv db, which is only a cache of the
+// latest values of the iavl trees and is filled again from them on reads.
+func ResetDB(db dbm.DB) error {
+	const batchSize = 10000
+	for {
+		it, err := db.Iterator(nil, nil)
+		if err != nil {
+			return err
+		}
+		batch := db.NewBatch()
+		n := 0
+		for ; it.Valid() && n < batchSize; it.Next() {
+			key := make([]byte, len(it.Key()))
+			copy(key, it.Key())
+			batch.Delete(key)
+			n++
+		}
+		it.Close()
+		if n == 0 {
+			batch.Close()
+			return nil
+		}
+		err = batch.Write()
+		batch.Close()
+		if err != nil {
+			return err
+		}
+	}
+}
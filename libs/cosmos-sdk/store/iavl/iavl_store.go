@@ -106,6 +106,35 @@ func HasVersion(db dbm.DB, version int64) (bool, error) {
 	}
 	return tree.VersionExistsInDb(version), nil
 }
+
+// RollbackVersions deletes the versions of the tree later than the given version,
+// the tree must have the version if it has any later one.
+func RollbackVersions(db dbm.DB, version int64) error {
+	tree, err := iavl.NewMutableTreeWithOpts(db, iavlconfig.DynamicConfig.GetIavlCacheSize(), &iavl.Options{InitialVersion: 0})
+	if err != nil {
+		return err
+	}
+	versions, err := tree.GetVersions()
+	if err != nil {
+		return err
+	}
+	var hasLater bool
+	for _, v := range versions {
+		if v > version {
+			hasLater = true
+			break
+		}
+	}
+	if !hasLater {
+		return nil
+	}
+	if !tree.VersionExistsInDb(version) {
+		return fmt.Errorf("version %d does not exist", version)
+	}
+	_, err = tree.LoadVersionForOverwriting(version)
+	return err
+}
+
 func GetCommitVersions(db dbm.DB) ([]int64, error) {
 	tree, err := iavl.NewMutableTreeWithOpts(db, iavlconfig.DynamicConfig.GetIavlCacheSize(), &iavl.Options{InitialVersion: 0})
 	if err != nil {
@@ -2,6 +2,7 @@ package mpt
 
 import (
 	"encoding/binary"
+	"fmt"
 	"path/filepath"
 	"sync"
 
@@ -85,3 +86,64 @@ func (ms *MptStore) SetMptRootHash(height uint64, hash ethcmn.Hash) {
 func (ms *MptStore) HasVersion(height int64) bool {
 	return ms.GetMptRootHash(uint64(height)) != ethcmn.Hash{}
 }
+
+// RollbackVersions deletes the acc and evm mpt root hashes of the heights after the
+// version and sets it as their latest stored height. The tries of the version must
+// be stored in the db, nothing is changed otherwise.
+func RollbackVersions(db ethstate.Database, version int64) error {
+	diskDB := db.TrieDB().DiskDB()
+	tries := []struct {
+		name                  string
+		rootPrefix, latestKey []byte
+	}{
+		{"acc", KeyPrefixAccRootMptHash, KeyPrefixAccLatestStoredHeight},
+		{"evm", KeyPrefixEvmRootMptHash, KeyPrefixEvmLatestStoredHeight},
+	}
+
+	heightBytes := sdk.Uint64ToBigEndian(uint64(version))
+	for _, t := range tries {
+		latest, err := diskDB.Get(t.latestKey)
+		// the trie is not used yet
+		if err != nil || len(latest) == 0 {
+			continue
+		}
+		if latestHeight := binary.BigEndian.Uint64(latest); latestHeight < uint64(version) {
+			return fmt.Errorf("the %s mpt of version %d is not stored, the latest stored version is %d",
+				t.name, version, latestHeight)
+		}
+		root, err := diskDB.Get(append(t.rootPrefix, heightBytes...))
+		if err != nil || len(root) == 0 {
+			continue
+		}
+		if _, err := db.OpenTrie(ethcmn.BytesToHash(root)); err != nil {
+			return fmt.Errorf("the %s mpt of version %d is not stored: %w", t.name, version, err)
+		}
+	}
+
+	batch := diskDB.NewBatch()
+	for _, t := range tries {
+		if latest, err := diskDB.Get(t.latestKey); err != nil || len(latest) == 0 {
+			continue
+		}
+		it := diskDB.NewIterator(t.rootPrefix, sdk.Uint64ToBigEndian(uint64(version)+1))
+		for it.Next() {
+			// the trie nodes share the db, their keys are hashes
+			if len(it.Key()) != len(t.rootPrefix)+len(heightBytes) {
+				continue
+			}
+			if err := batch.Delete(ethcmn.CopyBytes(it.Key())); err != nil {
+				it.Release()
+				return err
+			}
+		}
+		err := it.Error()
+		it.Release()
+		if err != nil {
+			return err
+		}
+		if err := batch.Put(t.latestKey, heightBytes); err != nil {
+			return err
+		}
+	}
+	return batch.Write()
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/okex/exchain/libs/cosmos-sdk/client/flags"
 	"github.com/okex/exchain/libs/cosmos-sdk/types"
 	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -58,6 +59,32 @@ func (suite *InstanceTestSuite) TestMptRootHash() {
 	}
 }
 
+func TestRollbackVersions(t *testing.T) {
+	dirtyDisabled := TrieDirtyDisabled
+	TrieDirtyDisabled = true
+	defer func() { TrieDirtyDisabled = dirtyDisabled }()
+
+	mptStore, err := mockMptStore(nil, types.CommitID{})
+	require.NoError(t, err)
+	key := []byte("key")
+	for i := 1; i <= 5; i++ {
+		mptStore.Set(key, []byte(fmt.Sprintf("value-%d", i)))
+		mptStore.CommitterCommit(nil)
+	}
+	require.Equal(t, uint64(5), mptStore.GetLatestStoredBlockHeight())
+
+	require.Error(t, RollbackVersions(mptStore.db, 6))
+	require.NoError(t, RollbackVersions(mptStore.db, 3))
+	require.Equal(t, uint64(3), mptStore.GetLatestStoredBlockHeight())
+	require.True(t, mptStore.HasVersion(3))
+	require.False(t, mptStore.HasVersion(4))
+	require.False(t, mptStore.HasVersion(5))
+
+	rolled, err := generateMptStore(nil, types.CommitID{Version: 3}, mptStore.db)
+	require.NoError(t, err)
+	require.Equal(t, []byte("value-3"), rolled.Get(key))
+}
+
 func generateKeccakHash(height uint64) ethcmn.Hash {
 	return ethcmn.BytesToHash(crypto.Keccak256([]byte(fmt.Sprintf("height-%d", height))))
 }
@@ -1,6 +1,14 @@
 package mpt
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/trie"
 	"github.com/okex/exchain/libs/tendermint/crypto/merkle"
 )
 
@@ -35,3 +43,132 @@ func newProofOpMptAbsence(key []byte, proof ProofList) merkle.ProofOp {
 		Data: bz,
 	}
 }
+
+// MptValueOp is a merkle.ProofOperator proving a key-value pair of an mpt
+// store, the proof is the list of trie nodes on the path of the hashed key.
+type MptValueOp struct {
+	key   []byte
+	Proof ProofList
+}
+
+var _ merkle.ProofOperator = MptValueOp{}
+
+func NewMptValueOp(key []byte, proof ProofList) MptValueOp {
+	return MptValueOp{key: key, Proof: proof}
+}
+
+func MptValueOpDecoder(pop merkle.ProofOp) (merkle.ProofOperator, error) {
+	if pop.Type != ProofOpMptValue {
+		return nil, fmt.Errorf("unexpected ProofOp.Type; got %v, want %v", pop.Type, ProofOpMptValue)
+	}
+	var proof ProofList
+	if err := cdc.UnmarshalBinaryLengthPrefixed(pop.Data, &proof); err != nil {
+		return nil, fmt.Errorf("decoding ProofOp.Data into MptValueOp: %s", err)
+	}
+	return NewMptValueOp(pop.Key, proof), nil
+}
+
+func (op MptValueOp) ProofOp() merkle.ProofOp {
+	return newProofOpMptValue(op.key, op.Proof)
+}
+
+func (op MptValueOp) GetKey() []byte {
+	return op.key
+}
+
+// Run verifies the value in args against the root computed from the proof
+// and returns the root.
+func (op MptValueOp) Run(args [][]byte) ([][]byte, error) {
+	if len(args) != 1 {
+		return nil, errors.New("value size is not 1")
+	}
+	root := op.Proof.RootHash()
+	value, err := VerifyProof(root, op.key, op.Proof)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(value, args[0]) {
+		return nil, fmt.Errorf("value mismatch for key %X: %X vs %X", op.key, value, args[0])
+	}
+	return [][]byte{root.Bytes()}, nil
+}
+
+// MptAbsenceOp is a merkle.ProofOperator proving a key is absent from an mpt
+// store.
+type MptAbsenceOp struct {
+	key   []byte
+	Proof ProofList
+}
+
+var _ merkle.ProofOperator = MptAbsenceOp{}
+
+func NewMptAbsenceOp(key []byte, proof ProofList) MptAbsenceOp {
+	return MptAbsenceOp{key: key, Proof: proof}
+}
+
+func MptAbsenceOpDecoder(pop merkle.ProofOp) (merkle.ProofOperator, error) {
+	if pop.Type != ProofOpMptAbsence {
+		return nil, fmt.Errorf("unexpected ProofOp.Type; got %v, want %v", pop.Type, ProofOpMptAbsence)
+	}
+	var proof ProofList
+	if err := cdc.UnmarshalBinaryLengthPrefixed(pop.Data, &proof); err != nil {
+		return nil, fmt.Errorf("decoding ProofOp.Data into MptAbsenceOp: %s", err)
+	}
+	return NewMptAbsenceOp(pop.Key, proof), nil
+}
+
+func (op MptAbsenceOp) ProofOp() merkle.ProofOp {
+	return newProofOpMptAbsence(op.key, op.Proof)
+}
+
+func (op MptAbsenceOp) GetKey() []byte {
+	return op.key
+}
+
+// Run verifies the key is absent from the trie of the proof and returns the
+// root.
+func (op MptAbsenceOp) Run(args [][]byte) ([][]byte, error) {
+	if len(args) != 0 {
+		return nil, fmt.Errorf("expected 0 args, got %v", len(args))
+	}
+	root := op.Proof.RootHash()
+	value, err := VerifyProof(root, op.key, op.Proof)
+	if err != nil {
+		return nil, err
+	}
+	if value != nil {
+		return nil, fmt.Errorf("key %X exists in the proof", op.key)
+	}
+	return [][]byte{root.Bytes()}, nil
+}
+
+// RootHash returns the root of the trie the proof is generated from, the
+// first node of a proof is the root node.
+func (n ProofList) RootHash() ethcmn.Hash {
+	if len(n) == 0 {
+		return EmptyRootHash
+	}
+	return crypto.Keccak256Hash(n[0])
+}
+
+// VerifyProof returns the value of key in the secure trie with the given root,
+// nil means the key is absent. The proof is generated by Prove with the hashed key.
+func VerifyProof(root ethcmn.Hash, key []byte, proof ProofList) ([]byte, error) {
+	if len(proof) == 0 {
+		if root == EmptyRootHash {
+			return nil, nil
+		}
+		return nil, errors.New("empty proof for a non-empty trie")
+	}
+	proofDB := memorydb.New()
+	for _, node := range proof {
+		if err := proofDB.Put(crypto.Keccak256(node), node); err != nil {
+			return nil, err
+		}
+	}
+	value, err := trie.VerifyProof(root, crypto.Keccak256(key), proofDB)
+	if err != nil {
+		return nil, fmt.Errorf("verifying mpt proof of key %X: %s", key, err)
+	}
+	return value, nil
+}
@@ -0,0 +1,59 @@
+package mpt
+
+import (
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	"github.com/okex/exchain/libs/tendermint/crypto/merkle"
+)
+
+func (suite *StoreTestSuite) TestMPTStoreQueryProof() {
+	store := suite.mptStore
+	cid, _ := store.CommitterCommit(nil)
+
+	prt := merkle.NewProofRuntime()
+	prt.RegisterOpDecoder(ProofOpMptValue, MptValueOpDecoder)
+	prt.RegisterOpDecoder(ProofOpMptAbsence, MptAbsenceOpDecoder)
+
+	// existing key
+	key := []byte(commonKeys[0])
+	res := store.Query(abci.RequestQuery{Path: "/key", Data: key, Height: cid.Version, Prove: true})
+	suite.Require().Equal(uint32(0), res.Code)
+	suite.Require().Equal([]byte(commonValues[0]), res.Value)
+	suite.Require().Len(res.Proof.Ops, 1)
+	suite.Require().Equal(ProofOpMptValue, res.Proof.Ops[0].Type)
+
+	kp := merkle.KeyPath{}.AppendKey(key, merkle.KeyEncodingURL)
+	suite.Require().NoError(prt.VerifyValue(res.Proof, cid.Hash, kp.String(), res.Value))
+	suite.Require().Error(prt.VerifyValue(res.Proof, cid.Hash, kp.String(), []byte("wrong value")))
+	suite.Require().Error(prt.VerifyValue(res.Proof, EmptyRootHashBytes, kp.String(), res.Value))
+
+	// the proof of a key is not the proof of another one
+	otherKp := merkle.KeyPath{}.AppendKey([]byte(commonKeys[1]), merkle.KeyEncodingURL)
+	suite.Require().Error(prt.VerifyValue(res.Proof, cid.Hash, otherKp.String(), res.Value))
+
+	// absent key
+	absentKey := []byte("absent key")
+	res = store.Query(abci.RequestQuery{Path: "/key", Data: absentKey, Height: cid.Version, Prove: true})
+	suite.Require().Equal(uint32(0), res.Code)
+	suite.Require().Nil(res.Value)
+	suite.Require().Len(res.Proof.Ops, 1)
+	suite.Require().Equal(ProofOpMptAbsence, res.Proof.Ops[0].Type)
+
+	kp = merkle.KeyPath{}.AppendKey(absentKey, merkle.KeyEncodingURL)
+	suite.Require().NoError(prt.VerifyAbsence(res.Proof, cid.Hash, kp.String()))
+
+	// an existing key can't be proven absent with the proof of its value
+	res = store.Query(abci.RequestQuery{Path: "/key", Data: key, Height: cid.Version, Prove: true})
+	op, err := MptAbsenceOpDecoder(merkle.ProofOp{Type: ProofOpMptAbsence, Key: key, Data: res.Proof.Ops[0].Data})
+	suite.Require().NoError(err)
+	_, err = op.Run(nil)
+	suite.Require().Error(err)
+}
+
+func (suite *StoreTestSuite) TestVerifyProofEmptyTrie() {
+	value, err := VerifyProof(EmptyRootHash, []byte("key"), nil)
+	suite.Require().NoError(err)
+	suite.Require().Nil(value)
+
+	_, err = VerifyProof(NilHash, []byte("key"), nil)
+	suite.Require().Error(err)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"math"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -220,6 +221,12 @@ func (rs *Store) LoadVersion(ver int64) error {
 }
 
 func (rs *Store) GetCommitVersion() (int64, error) {
+	return rs.GetCommitVersionUpTo(math.MaxInt64)
+}
+
+// GetCommitVersionUpTo returns the latest version not greater than upTo that
+// every store has.
+func (rs *Store) GetCommitVersionUpTo(upTo int64) (int64, error) {
 	var firstSp storeParams
 	var firstKey types.StoreKey
 	isFindIavlStoreParam := false
@@ -251,6 +258,9 @@ func (rs *Store) GetCommitVersion() (int64, error) {
 	rs.logger.Info("GetCommitVersion", "iavl:", firstKey.Name(), "versions :", versions)
 	//find version in rootmultistore
 	for _, version := range versions {
+		if version > upTo {
+			continue
+		}
 		hasVersion, err := rs.hasVersion(version)
 		if err != nil {
 			return 0, err
@@ -264,6 +274,58 @@ func (rs *Store) GetCommitVersion() (int64, error) {
 	return 0, fmt.Errorf("not found any proper version")
 }
 
+// RollbackToVersion deletes the versions of the IAVL stores later than the given
+// version, which every store must have, and makes it the latest version.
+func (rs *Store) RollbackToVersion(version int64) error {
+	if _, err := getCommitInfo(rs.db, version); err != nil {
+		return err
+	}
+	ok, err := rs.hasVersion(version)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("not every store has version %d", version)
+	}
+
+	for _, sp := range rs.storesParams {
+		if sp.typ != types.StoreTypeIAVL {
+			continue
+		}
+		if err := iavl.RollbackVersions(rs.storeParamsDB(sp), version); err != nil {
+			return fmt.Errorf("failed to roll back store %s: %w", sp.key.Name(), err)
+		}
+	}
+
+	versions, err := getVersions(rs.db)
+	if err != nil {
+		return err
+	}
+	pruneHeights, err := getPruningHeights(rs.db, false)
+	if err != nil {
+		return err
+	}
+	notAfter := func(heights []int64) []int64 {
+		kept := make([]int64, 0, len(heights))
+		for _, h := range heights {
+			if h <= version {
+				kept = append(kept, h)
+			}
+		}
+		return kept
+	}
+
+	batch := rs.db.NewBatch()
+	defer batch.Close()
+	for v := version + 1; v <= getLatestVersion(rs.db); v++ {
+		batch.Delete([]byte(fmt.Sprintf(commitInfoKeyFmt, v)))
+	}
+	setLatestVersion(batch, version)
+	setVersions(batch, notAfter(versions))
+	setPruningHeights(batch, notAfter(pruneHeights))
+	return batch.WriteSync()
+}
+
 // hasVersion means every storesParam in store has this version.
 func (rs *Store) hasVersion(targetVersion int64) (bool, error) {
 	latestVersion := rs.GetLatestVersion()
@@ -994,16 +1056,16 @@ func findVersionInSubStores(rs *Store, params storeParams, version int64) (bool,
 	return iavl.HasVersion(db, version)
 }
 func (rs *Store) getCommitVersionFromParams(params storeParams) ([]int64, error) {
-	var db dbm.DB
+	return iavl.GetCommitVersions(rs.storeParamsDB(params))
+}
 
+// storeParamsDB returns the db of the IAVL store of the params
+func (rs *Store) storeParamsDB(params storeParams) dbm.DB {
 	if params.db != nil {
-		db = dbm.NewPrefixDB(params.db, []byte("s/_/"))
-	} else {
-		prefix := "s/k:" + params.key.Name() + "/"
-		db = dbm.NewPrefixDB(rs.db, []byte(prefix))
+		return dbm.NewPrefixDB(params.db, []byte("s/_/"))
 	}
-
-	return iavl.GetCommitVersions(db)
+	prefix := "s/k:" + params.key.Name() + "/"
+	return dbm.NewPrefixDB(rs.db, []byte(prefix))
 }
 
 func (rs *Store) GetDBWriteCount() int {
@@ -16,6 +16,7 @@ import (
 
 	abci "github.com/okex/exchain/libs/tendermint/abci/types"
 	"github.com/okex/exchain/libs/tendermint/crypto/merkle"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
 	dbm "github.com/okex/exchain/libs/tm-db"
 	"github.com/stretchr/testify/require"
 
@@ -407,6 +408,47 @@ func TestMultiStoreRestart(t *testing.T) {
 	require.Equal(t, []byte(fmt.Sprintf("%s:%d", v3, 3)), val3, "Reloaded value not the same as last flushed value")
 }
 
+func TestMultiStoreRollbackToVersion(t *testing.T) {
+	tmtypes.UnittestOnlySetMilestoneVenus1Height(-1)
+	db := dbm.NewMemDB()
+	multi := newMultiStoreWithMounts(db, types.PruneNothing)
+	multi.SetLogger(log.NewNopLogger())
+	require.NoError(t, multi.LoadLatestVersion())
+
+	k := []byte("wind")
+	for i := 1; i <= 5; i++ {
+		multi.getStoreByName("store1").(types.KVStore).Set(k, []byte(fmt.Sprintf("blows:%d", i)))
+		multi.CommitterCommitMap(nil)
+	}
+	cinfo3, err := getCommitInfo(db, 3)
+	require.NoError(t, err)
+	version, err := multi.GetCommitVersionUpTo(3)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), version)
+
+	multi = newMultiStoreWithMounts(db, types.PruneNothing)
+	multi.SetLogger(log.NewNopLogger())
+	require.Error(t, multi.RollbackToVersion(6))
+	require.NoError(t, multi.RollbackToVersion(3))
+	require.Equal(t, int64(3), multi.GetLatestVersion())
+	_, err = getCommitInfo(db, 4)
+	require.Error(t, err)
+
+	multi = newMultiStoreWithMounts(db, types.PruneNothing)
+	multi.SetLogger(log.NewNopLogger())
+	require.NoError(t, multi.LoadLatestVersion())
+	require.Equal(t, cinfo3.CommitID(), multi.LastCommitID())
+	require.Equal(t, []byte("blows:3"), multi.getStoreByName("store1").(types.KVStore).Get(k))
+	version, err = multi.GetCommitVersion()
+	require.NoError(t, err)
+	require.Equal(t, int64(3), version)
+
+	// the rolled back versions are committed again
+	multi.getStoreByName("store1").(types.KVStore).Set(k, []byte("blows:4'"))
+	commitID, _ := multi.CommitterCommitMap(nil)
+	require.Equal(t, int64(4), commitID.Version)
+}
+
 func TestMultiStoreQuery(t *testing.T) {
 	db := dbm.NewMemDB()
 	multi := newMultiStoreWithMounts(db, types.PruneNothing)
@@ -59,6 +59,7 @@ type Context struct {
 
 	statedb         vm.StateDB
 	outOfGas        bool
+	txSucceeded     bool // txSucceeded is set before the gas refund, it is true if the msgs of the tx are executed successfully
 	mempoolSimulate bool // if mempoolSimulate = true, then is mempool simulate tx
 }
 
@@ -591,6 +592,14 @@ func (c *Context) GetOutOfGas() bool {
 	return c.outOfGas
 }
 
+func (c *Context) SetTxSucceeded(v bool) {
+	c.txSucceeded = v
+}
+
+func (c *Context) IsTxSucceeded() bool {
+	return c.txSucceeded
+}
+
 type AccountCache struct {
 	FromAcc       interface{} // must be auth.Account
 	ToAcc         interface{} // must be auth.Account
@@ -46,6 +46,22 @@ type SimulationResponse struct {
 	Result *Result
 }
 
+// BundleTxResult is the result of a tx of a simulated bundle.
+type BundleTxResult struct {
+	Code      uint32
+	Log       string
+	GasWanted uint64
+	GasUsed   uint64
+	TxHash    []byte
+	From      string
+}
+
+// BundleSimulationResponse defines the response generated when a bundle is
+// simulated by the Baseapp, with the result of every tx in order.
+type BundleSimulationResponse struct {
+	Results []BundleTxResult
+}
+
 // ABCIMessageLogs represents a slice of ABCIMessageLog.
 type ABCIMessageLogs []ABCIMessageLog
 
@@ -594,7 +594,12 @@ func (tree *MutableTree) LoadVersionForOverwriting(targetVersion int64) (int64,
 		return latestVersion, err
 	}
 
-	if err = tree.enableFastStorageAndCommitLocked(batch); err != nil {
+	if GetEnableFastStorage() {
+		err = tree.enableFastStorageAndCommitLocked(batch)
+	} else {
+		err = tree.ndb.Commit(batch)
+	}
+	if err != nil {
 		return latestVersion, err
 	}
 
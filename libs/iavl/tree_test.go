@@ -1420,6 +1420,34 @@ func TestLoadVersionForOverwriting(t *testing.T) {
 	require.NoError(err, "SaveVersion should not fail.")
 }
 
+func TestLoadVersionForOverwritingWithoutFastStorage(t *testing.T) {
+	enabled := GetEnableFastStorage()
+	SetEnableFastStorage(false)
+	defer SetEnableFastStorage(enabled)
+
+	mdb := db.NewMemDB()
+	tree, err := NewMutableTree(mdb, 0)
+	require.NoError(t, err)
+	for count := 1; count <= 10; count++ {
+		tree.Set([]byte("key"+strconv.Itoa(count)), []byte("value"))
+		_, _, _, err = tree.SaveVersion(false)
+		require.NoError(t, err)
+	}
+
+	tree, err = NewMutableTree(mdb, 0)
+	require.NoError(t, err)
+	_, err = tree.LoadVersionForOverwriting(5)
+	require.NoError(t, err)
+
+	// the later versions are deleted from the db
+	tree, err = NewMutableTree(mdb, 0)
+	require.NoError(t, err)
+	latest, err := tree.LoadVersion(0)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), latest)
+	require.False(t, tree.VersionExistsInDb(6))
+}
+
 func TestDeleteVersionsCompare(t *testing.T) {
 	require := require.New(t)
 
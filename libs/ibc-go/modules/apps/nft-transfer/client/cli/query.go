@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/client"
+	"github.com/okex/exchain/libs/cosmos-sdk/client/context"
+	"github.com/okex/exchain/libs/cosmos-sdk/client/flags"
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/version"
+	"github.com/okex/exchain/libs/ibc-go/modules/apps/nft-transfer/types"
+	"github.com/spf13/cobra"
+)
+
+// GetQueryCmd returns the cli query commands for this module
+func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        "nft-transfer",
+		Short:                      "IBC non-fungible token transfer query subcommands",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	cmd.AddCommand(flags.GetCommands(
+		GetCmdQueryClassTraces(queryRoute, cdc),
+		GetCmdQueryClassTrace(queryRoute, cdc),
+		GetCmdQueryEscrowAddress(queryRoute, cdc),
+	)...)
+
+	return cmd
+}
+
+// GetCmdQueryClassTraces implements a command to return all the class traces
+func GetCmdQueryClassTraces(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "class-traces",
+		Short:   "Query the trace info of all the nft classes received through IBC",
+		Example: fmt.Sprintf("$ %s query nft-transfer class-traces", version.ClientName),
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			bz, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryClassTraces), nil)
+			if err != nil {
+				return err
+			}
+
+			var traces []types.ClassTrace
+			cdc.MustUnmarshalJSON(bz, &traces)
+			return cliCtx.PrintOutput(traces)
+		},
+	}
+}
+
+// GetCmdQueryClassTrace implements a command to return the trace of a voucher class
+func GetCmdQueryClassTrace(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "class-trace [hash/class-id]",
+		Short:   "Query the trace info of a voucher class by its hash or its class id",
+		Example: fmt.Sprintf("$ %s query nft-transfer class-trace ibc/27A6394C3F9FF9C9DCF5DFFADF9BB5FE9A37C7E92B006199894CF1824DF9AC7C", version.ClientName),
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			data, err := cdc.MarshalJSON(types.NewQueryClassTraceParams(args[0]))
+			if err != nil {
+				return err
+			}
+			bz, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryClassTrace), data)
+			if err != nil {
+				return err
+			}
+
+			var trace types.ClassTrace
+			cdc.MustUnmarshalJSON(bz, &trace)
+			return cliCtx.PrintOutput(trace)
+		},
+	}
+}
+
+// GetCmdQueryEscrowAddress implements a command to return the escrow address of a channel
+func GetCmdQueryEscrowAddress(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "escrow-address [port-id] [channel-id]",
+		Short:   "Query the escrow address of the nfts sent over a channel",
+		Example: fmt.Sprintf("$ %s query nft-transfer escrow-address nft-transfer channel-0", version.ClientName),
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			data, err := cdc.MarshalJSON(types.NewQueryEscrowAddressParams(args[0], args[1]))
+			if err != nil {
+				return err
+			}
+			bz, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryEscrowAddress), data)
+			if err != nil {
+				return err
+			}
+
+			var address sdk.AccAddress
+			cdc.MustUnmarshalJSON(bz, &address)
+			return cliCtx.PrintOutput(address)
+		},
+	}
+}
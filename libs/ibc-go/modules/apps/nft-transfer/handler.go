@@ -0,0 +1,56 @@
+package nfttransfer
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	"github.com/okex/exchain/libs/ibc-go/modules/apps/nft-transfer/keeper"
+	"github.com/okex/exchain/libs/ibc-go/modules/apps/nft-transfer/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+)
+
+// NewHandler returns a handler for the ICS-721 messages
+func NewHandler(k keeper.Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+		ctx.SetEventManager(sdk.NewEventManager())
+		if !tmtypes.HigherThanJupiter(ctx.BlockHeight()) {
+			errMsg := fmt.Sprintf("nft transfer not support at height %d", ctx.BlockHeight())
+			return nil, sdkerrors.Wrap(types.ErrTransferNotSupport, errMsg)
+		}
+
+		switch msg := msg.(type) {
+		case types.MsgTransfer:
+			return handleMsgTransfer(ctx, k, msg)
+		default:
+			errMsg := fmt.Sprintf("unrecognized %s message type: %T", types.ModuleName, msg)
+			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, errMsg)
+		}
+	}
+}
+
+func handleMsgTransfer(ctx sdk.Context, k keeper.Keeper, msg types.MsgTransfer) (*sdk.Result, error) {
+	if _, err := k.SendTransfer(
+		ctx, msg.SourcePort, msg.SourceChannel, msg.ClassID, msg.TokenIDs, msg.Sender, msg.Receiver,
+		msg.TimeoutHeight, msg.TimeoutTimestamp, msg.Memo,
+	); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeTransfer,
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender.String()),
+			sdk.NewAttribute(types.AttributeKeyReceiver, msg.Receiver),
+			sdk.NewAttribute(types.AttributeKeyClassID, msg.ClassID),
+			sdk.NewAttribute(types.AttributeKeyTokenIDs, strings.Join(msg.TokenIDs, ",")),
+			sdk.NewAttribute(types.AttributeKeyMemo, msg.Memo),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		),
+	})
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
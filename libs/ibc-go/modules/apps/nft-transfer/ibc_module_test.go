@@ -0,0 +1,299 @@
+package nfttransfer_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/store"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	capabilitytypes "github.com/okex/exchain/libs/cosmos-sdk/x/capability/types"
+	nfttransfer "github.com/okex/exchain/libs/ibc-go/modules/apps/nft-transfer"
+	"github.com/okex/exchain/libs/ibc-go/modules/apps/nft-transfer/keeper"
+	"github.com/okex/exchain/libs/ibc-go/modules/apps/nft-transfer/types"
+	clienttypes "github.com/okex/exchain/libs/ibc-go/modules/core/02-client/types"
+	channeltypes "github.com/okex/exchain/libs/ibc-go/modules/core/04-channel/types"
+	"github.com/okex/exchain/libs/ibc-go/modules/core/exported"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	"github.com/okex/exchain/libs/tendermint/crypto/ed25519"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	tmdb "github.com/okex/exchain/libs/tm-db"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	testClass    = "kitty"
+	testChannelA = "channel-0"
+	testChannelB = "channel-1"
+)
+
+var (
+	alice = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	bob   = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+)
+
+// testChain is one side of the channel, with its own nft store and ICS-721 keeper
+type testChain struct {
+	nft     *mockNFTKeeper
+	channel *mockChannelKeeper
+	keeper  keeper.Keeper
+	module  nfttransfer.IBCModule
+}
+
+type IBCModuleTestSuite struct {
+	suite.Suite
+
+	ctx    sdk.Context
+	chainA testChain
+	chainB testChain
+}
+
+func TestIBCModuleTestSuite(t *testing.T) {
+	suite.Run(t, new(IBCModuleTestSuite))
+}
+
+func (suite *IBCModuleTestSuite) SetupTest() {
+	keyA, keyB := sdk.NewKVStoreKey("a"), sdk.NewKVStoreKey("b")
+	ms := store.NewCommitMultiStore(tmdb.NewMemDB())
+	ms.MountStoreWithDB(keyA, sdk.StoreTypeIAVL, nil)
+	ms.MountStoreWithDB(keyB, sdk.StoreTypeIAVL, nil)
+	suite.Require().NoError(ms.LoadLatestVersion())
+	suite.ctx = sdk.NewContext(ms, abci.Header{Height: 2, Time: time.Unix(1000, 0)}, false, log.NewNopLogger())
+
+	suite.chainA = newTestChain(keyA, testChannelA, testChannelB)
+	suite.chainB = newTestChain(keyB, testChannelB, testChannelA)
+
+	suite.chainA.nft.classes[testClass] = "ipfs://kitty"
+	for _, id := range []string{"1", "2"} {
+		suite.Require().NoError(suite.chainA.nft.MintNFT(suite.ctx, testClass, id, "ipfs://kitty/"+id, "", alice))
+	}
+}
+
+func newTestChain(key sdk.StoreKey, channelID, counterpartyChannelID string) testChain {
+	nftKeeper := &mockNFTKeeper{classes: map[string]string{}, nfts: map[string]*mockNFT{}}
+	channelKeeper := &mockChannelKeeper{channelID: channelID, counterpartyChannelID: counterpartyChannelID}
+	k := keeper.NewKeeper(key, channelKeeper, nil, nftKeeper, mockScopedKeeper{})
+	return testChain{
+		nft:     nftKeeper,
+		channel: channelKeeper,
+		keeper:  k,
+		module:  nfttransfer.NewIBCModule(k),
+	}
+}
+
+func (suite *IBCModuleTestSuite) send(chain testChain, classID string, tokenIDs []string, sender, receiver sdk.AccAddress) channeltypes.Packet {
+	_, err := chain.keeper.SendTransfer(suite.ctx, types.PortID, chain.channel.channelID, classID, tokenIDs, sender,
+		receiver.String(), clienttypes.NewHeight(0, 100), 0, "")
+	suite.Require().NoError(err)
+	return chain.channel.packet
+}
+
+func (suite *IBCModuleTestSuite) voucherClassID() string {
+	return types.ParseClassTrace(types.GetClassPrefix(types.PortID, testChannelB) + testClass).IBCClassID()
+}
+
+func (suite *IBCModuleTestSuite) TestRoundTrip() {
+	escrowA := types.GetEscrowAddress(types.PortID, testChannelA)
+
+	// A is the source, the nfts are escrowed and B mints the vouchers
+	packet := suite.send(suite.chainA, testClass, []string{"1", "2"}, alice, bob)
+	suite.Require().Equal(escrowA, suite.chainA.nft.GetNFTOwner(suite.ctx, testClass, "1"))
+	suite.Require().Equal(escrowA, suite.chainA.nft.GetNFTOwner(suite.ctx, testClass, "2"))
+
+	ack := suite.chainB.module.OnRecvPacket(suite.ctx, packet, nil)
+	suite.Require().True(ack.Success())
+	voucher := suite.voucherClassID()
+	suite.Require().Equal("ipfs://kitty", suite.chainB.nft.classes[voucher])
+	suite.Require().Equal(bob, suite.chainB.nft.GetNFTOwner(suite.ctx, voucher, "1"))
+	uri, _, _ := suite.chainB.nft.GetNFTInfo(suite.ctx, voucher, "2")
+	suite.Require().Equal("ipfs://kitty/2", uri)
+	suite.Require().Len(suite.chainB.keeper.GetClassTraces(suite.ctx), 1)
+
+	// B is the sink, the voucher is burned and A unescrows the nft
+	packet = suite.send(suite.chainB, voucher, []string{"1"}, bob, alice)
+	suite.Require().True(suite.chainB.nft.GetNFTOwner(suite.ctx, voucher, "1").Empty())
+	data, err := types.UnmarshalPacketData(packet.GetData())
+	suite.Require().NoError(err)
+	suite.Require().Equal(types.GetClassPrefix(types.PortID, testChannelB)+testClass, data.ClassID)
+
+	ack = suite.chainA.module.OnRecvPacket(suite.ctx, packet, nil)
+	suite.Require().True(ack.Success())
+	suite.Require().Equal(alice, suite.chainA.nft.GetNFTOwner(suite.ctx, testClass, "1"))
+	suite.Require().Equal(escrowA, suite.chainA.nft.GetNFTOwner(suite.ctx, testClass, "2"))
+}
+
+func (suite *IBCModuleTestSuite) TestSendNotOwner() {
+	_, err := suite.chainA.keeper.SendTransfer(suite.ctx, types.PortID, testChannelA, testClass, []string{"1"}, bob,
+		alice.String(), clienttypes.NewHeight(0, 100), 0, "")
+	suite.Require().ErrorIs(err, types.ErrNotOwner)
+}
+
+func (suite *IBCModuleTestSuite) TestRecvNotEscrowed() {
+	// a packet claiming to return nft 1 of A, which was never sent
+	data := types.NewNonFungibleTokenPacketData(types.GetClassPrefix(types.PortID, testChannelB)+testClass,
+		"", "", []string{"1"}, nil, nil, bob.String(), bob.String(), "")
+	packet := channeltypes.NewPacket(data.GetBytes(), 1, types.PortID, testChannelB, types.PortID, testChannelA,
+		clienttypes.NewHeight(0, 100), 0)
+
+	ack := suite.chainA.module.OnRecvPacket(suite.ctx, packet, nil)
+	suite.Require().False(ack.Success())
+	suite.Require().Equal(alice, suite.chainA.nft.GetNFTOwner(suite.ctx, testClass, "1"))
+}
+
+func (suite *IBCModuleTestSuite) TestRefund() {
+	// the escrowed nfts of the source are returned on timeout
+	packet := suite.send(suite.chainA, testClass, []string{"1"}, alice, bob)
+	suite.Require().NoError(suite.chainA.module.OnTimeoutPacket(suite.ctx, packet, nil))
+	suite.Require().Equal(alice, suite.chainA.nft.GetNFTOwner(suite.ctx, testClass, "1"))
+
+	// the burned vouchers of the sink are minted back on an error acknowledgement
+	packet = suite.send(suite.chainA, testClass, []string{"1"}, alice, bob)
+	suite.Require().True(suite.chainB.module.OnRecvPacket(suite.ctx, packet, nil).Success())
+	voucher := suite.voucherClassID()
+	packet = suite.send(suite.chainB, voucher, []string{"1"}, bob, alice)
+	suite.Require().True(suite.chainB.nft.GetNFTOwner(suite.ctx, voucher, "1").Empty())
+
+	ack := channeltypes.NewErrorAcknowledgementV4(errors.New("failed"))
+	suite.Require().NoError(suite.chainB.module.OnAcknowledgementPacket(suite.ctx, packet, ack.Acknowledgement(), nil))
+	suite.Require().Equal(bob, suite.chainB.nft.GetNFTOwner(suite.ctx, voucher, "1"))
+	uri, _, _ := suite.chainB.nft.GetNFTInfo(suite.ctx, voucher, "1")
+	suite.Require().Equal("ipfs://kitty/1", uri)
+
+	// nothing is refunded on a successful acknowledgement
+	packet = suite.send(suite.chainB, voucher, []string{"1"}, bob, alice)
+	ack = channeltypes.NewResultAcknowledgement([]byte{byte(1)})
+	suite.Require().NoError(suite.chainB.module.OnAcknowledgementPacket(suite.ctx, packet, ack.Acknowledgement(), nil))
+	suite.Require().True(suite.chainB.nft.GetNFTOwner(suite.ctx, voucher, "1").Empty())
+}
+
+func (suite *IBCModuleTestSuite) TestOnChanOpenInit() {
+	suite.chainA.keeper.SetPort(suite.ctx, types.PortID)
+	testCases := []struct {
+		name    string
+		order   channeltypes.Order
+		version string
+		expPass bool
+	}{
+		{"valid", channeltypes.UNORDERED, types.Version, true},
+		{"empty version", channeltypes.UNORDERED, "", true},
+		{"ordered channel", channeltypes.ORDERED, types.Version, false},
+		{"ics20 version", channeltypes.UNORDERED, "ics20-1", false},
+	}
+	for _, tc := range testCases {
+		version, err := suite.chainA.module.OnChanOpenInit(suite.ctx, tc.order, nil, types.PortID, testChannelA,
+			&capabilitytypes.Capability{}, channeltypes.NewCounterparty(types.PortID, testChannelB), tc.version)
+		if tc.expPass {
+			suite.Require().NoError(err, tc.name)
+			suite.Require().Equal(types.Version, version, tc.name)
+		} else {
+			suite.Require().Error(err, tc.name)
+		}
+	}
+}
+
+type mockNFT struct {
+	uri, data string
+	owner     sdk.AccAddress
+}
+
+type mockNFTKeeper struct {
+	classes map[string]string
+	nfts    map[string]*mockNFT
+}
+
+func (m *mockNFTKeeper) HasClass(_ sdk.Context, classID string) bool {
+	_, found := m.classes[classID]
+	return found
+}
+
+func (m *mockNFTKeeper) GetClassInfo(_ sdk.Context, classID string) (string, string, bool) {
+	uri, found := m.classes[classID]
+	return uri, "", found
+}
+
+func (m *mockNFTKeeper) SaveClassInfo(_ sdk.Context, classID, uri, _ string) error {
+	m.classes[classID] = uri
+	return nil
+}
+
+func (m *mockNFTKeeper) GetNFTInfo(_ sdk.Context, classID, tokenID string) (string, string, bool) {
+	nft, found := m.nfts[classID+"/"+tokenID]
+	if !found {
+		return "", "", false
+	}
+	return nft.uri, nft.data, true
+}
+
+func (m *mockNFTKeeper) GetNFTOwner(_ sdk.Context, classID, tokenID string) sdk.AccAddress {
+	if nft, found := m.nfts[classID+"/"+tokenID]; found {
+		return nft.owner
+	}
+	return nil
+}
+
+func (m *mockNFTKeeper) MintNFT(_ sdk.Context, classID, tokenID, uri, data string, receiver sdk.AccAddress) error {
+	if _, found := m.classes[classID]; !found {
+		return errors.New("class not found")
+	}
+	if _, found := m.nfts[classID+"/"+tokenID]; found {
+		return errors.New("nft exists")
+	}
+	m.nfts[classID+"/"+tokenID] = &mockNFT{uri: uri, data: data, owner: receiver}
+	return nil
+}
+
+func (m *mockNFTKeeper) BurnNFT(_ sdk.Context, classID, tokenID string) error {
+	if _, found := m.nfts[classID+"/"+tokenID]; !found {
+		return errors.New("nft not found")
+	}
+	delete(m.nfts, classID+"/"+tokenID)
+	return nil
+}
+
+func (m *mockNFTKeeper) TransferNFT(_ sdk.Context, classID, tokenID string, receiver sdk.AccAddress) error {
+	nft, found := m.nfts[classID+"/"+tokenID]
+	if !found {
+		return errors.New("nft not found")
+	}
+	nft.owner = receiver
+	return nil
+}
+
+type mockChannelKeeper struct {
+	channelID             string
+	counterpartyChannelID string
+	sequence              uint64
+	packet                channeltypes.Packet
+}
+
+func (m *mockChannelKeeper) GetChannel(_ sdk.Context, _, channelID string) (channeltypes.Channel, bool) {
+	if channelID != m.channelID {
+		return channeltypes.Channel{}, false
+	}
+	counterparty := channeltypes.NewCounterparty(types.PortID, m.counterpartyChannelID)
+	return channeltypes.NewChannel(channeltypes.OPEN, channeltypes.UNORDERED, counterparty, nil, types.Version), true
+}
+
+func (m *mockChannelKeeper) GetNextSequenceSend(sdk.Context, string, string) (uint64, bool) {
+	return m.sequence + 1, true
+}
+
+func (m *mockChannelKeeper) SendPacket(_ sdk.Context, _ *capabilitytypes.Capability, packet exported.PacketI) error {
+	m.sequence++
+	m.packet = packet.(channeltypes.Packet)
+	return nil
+}
+
+type mockScopedKeeper struct{}
+
+func (mockScopedKeeper) GetCapability(sdk.Context, string) (*capabilitytypes.Capability, bool) {
+	return &capabilitytypes.Capability{}, true
+}
+
+func (mockScopedKeeper) AuthenticateCapability(sdk.Context, *capabilitytypes.Capability, string) bool {
+	return true
+}
+
+func (mockScopedKeeper) ClaimCapability(sdk.Context, *capabilitytypes.Capability, string) error {
+	return nil
+}
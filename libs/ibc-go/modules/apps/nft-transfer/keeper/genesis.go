@@ -0,0 +1,30 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/ibc-go/modules/apps/nft-transfer/types"
+)
+
+// InitGenesis initializes the ICS-721 state and binds to the port
+func (k Keeper) InitGenesis(ctx sdk.Context, state types.GenesisState) {
+	k.SetPort(ctx, state.PortID)
+
+	for _, trace := range state.ClassTraces {
+		k.SetClassTrace(ctx, trace)
+	}
+
+	// Only try to bind to port if it is not already bound, since we may already own
+	// port capability from capability InitGenesis
+	if !k.IsBound(ctx, state.PortID) {
+		if err := k.BindPort(ctx, state.PortID); err != nil {
+			panic(fmt.Sprintf("could not claim port capability: %v", err))
+		}
+	}
+}
+
+// ExportGenesis exports the port and the class traces of the ICS-721 module
+func (k Keeper) ExportGenesis(ctx sdk.Context) types.GenesisState {
+	return types.NewGenesisState(k.GetPort(ctx), k.GetClassTraces(ctx))
+}
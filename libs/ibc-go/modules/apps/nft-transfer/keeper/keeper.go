@@ -0,0 +1,134 @@
+package keeper
+
+import (
+	"strings"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	capabilitytypes "github.com/okex/exchain/libs/cosmos-sdk/x/capability/types"
+	"github.com/okex/exchain/libs/ibc-go/modules/apps/nft-transfer/types"
+	host "github.com/okex/exchain/libs/ibc-go/modules/core/24-host"
+	tmbytes "github.com/okex/exchain/libs/tendermint/libs/bytes"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+)
+
+// Keeper defines the ICS-721 non-fungible token transfer keeper
+type Keeper struct {
+	storeKey sdk.StoreKey
+
+	channelKeeper types.ChannelKeeper
+	portKeeper    types.PortKeeper
+	nftKeeper     types.NFTKeeper
+	scopedKeeper  types.ScopedKeeper
+}
+
+// NewKeeper creates a new ICS-721 Keeper instance
+func NewKeeper(
+	key sdk.StoreKey, channelKeeper types.ChannelKeeper, portKeeper types.PortKeeper,
+	nftKeeper types.NFTKeeper, scopedKeeper types.ScopedKeeper,
+) Keeper {
+	return Keeper{
+		storeKey:      key,
+		channelKeeper: channelKeeper,
+		portKeeper:    portKeeper,
+		nftKeeper:     nftKeeper,
+		scopedKeeper:  scopedKeeper,
+	}
+}
+
+// Logger returns a module-specific logger.
+func (k Keeper) Logger(ctx sdk.Context) log.Logger {
+	return ctx.Logger().With("module", "x/"+host.ModuleName+"-"+types.ModuleName)
+}
+
+// IsBound checks if the ICS-721 module is already bound to the desired port
+func (k Keeper) IsBound(ctx sdk.Context, portID string) bool {
+	_, ok := k.scopedKeeper.GetCapability(ctx, host.PortPath(portID))
+	return ok
+}
+
+// BindPort binds the port and claims the returned capability
+func (k Keeper) BindPort(ctx sdk.Context, portID string) error {
+	cap := k.portKeeper.BindPort(ctx, portID)
+	return k.ClaimCapability(ctx, cap, host.PortPath(portID))
+}
+
+// GetPort returns the portID of the ICS-721 module
+func (k Keeper) GetPort(ctx sdk.Context) string {
+	store := ctx.KVStore(k.storeKey)
+	return string(store.Get(types.PortKey))
+}
+
+// SetPort sets the portID of the ICS-721 module
+func (k Keeper) SetPort(ctx sdk.Context, portID string) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.PortKey, []byte(portID))
+}
+
+// AuthenticateCapability wraps the scopedKeeper's AuthenticateCapability function
+func (k Keeper) AuthenticateCapability(ctx sdk.Context, cap *capabilitytypes.Capability, name string) bool {
+	return k.scopedKeeper.AuthenticateCapability(ctx, cap, name)
+}
+
+// ClaimCapability allows the ICS-721 module to claim a capability that IBC module passes to it
+func (k Keeper) ClaimCapability(ctx sdk.Context, cap *capabilitytypes.Capability, name string) error {
+	return k.scopedKeeper.ClaimCapability(ctx, cap, name)
+}
+
+// GetClassTrace returns the class trace of the hash
+func (k Keeper) GetClassTrace(ctx sdk.Context, hash tmbytes.HexBytes) (trace types.ClassTrace, found bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetClassTraceKey(hash))
+	if bz == nil {
+		return trace, false
+	}
+	types.ModuleCdc.MustUnmarshalBinaryBare(bz, &trace)
+	return trace, true
+}
+
+// HasClassTrace checks if the class trace of the hash exists
+func (k Keeper) HasClassTrace(ctx sdk.Context, hash tmbytes.HexBytes) bool {
+	return ctx.KVStore(k.storeKey).Has(types.GetClassTraceKey(hash))
+}
+
+// SetClassTrace stores the class trace under its hash
+func (k Keeper) SetClassTrace(ctx sdk.Context, trace types.ClassTrace) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetClassTraceKey(trace.Hash()), types.ModuleCdc.MustMarshalBinaryBare(trace))
+}
+
+// GetClassTraces returns all the class traces
+func (k Keeper) GetClassTraces(ctx sdk.Context) []types.ClassTrace {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.ClassTraceKey)
+	defer iterator.Close()
+
+	traces := make([]types.ClassTrace, 0)
+	for ; iterator.Valid(); iterator.Next() {
+		var trace types.ClassTrace
+		types.ModuleCdc.MustUnmarshalBinaryBare(iterator.Value(), &trace)
+		traces = append(traces, trace)
+	}
+	return traces
+}
+
+// ClassPathFromHash returns the full class path of a voucher class id "ibc/{hash}"
+func (k Keeper) ClassPathFromHash(ctx sdk.Context, classID string) (string, error) {
+	hash, err := parseClassHash(classID)
+	if err != nil {
+		return "", err
+	}
+	trace, found := k.GetClassTrace(ctx, hash)
+	if !found {
+		return "", sdkerrors.Wrap(types.ErrTraceNotFound, hash.String())
+	}
+	return trace.GetFullClassPath(), nil
+}
+
+// parseClassHash parses the hash of a voucher class id "ibc/{hash}" or of a bare hash
+func parseClassHash(classID string) (tmbytes.HexBytes, error) {
+	hexHash := strings.TrimPrefix(classID, types.ClassPrefix+"/")
+	hash, err := types.ParseHexHash(hexHash)
+	if err != nil {
+		return nil, sdkerrors.Wrapf(types.ErrInvalidClassID, "invalid class hash %s: %s", hexHash, err)
+	}
+	return hash, nil
+}
@@ -0,0 +1,46 @@
+package keeper
+
+import (
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	"github.com/okex/exchain/libs/ibc-go/modules/apps/nft-transfer/types"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+)
+
+// NewQuerier is the module level router for state queries
+func NewQuerier(k Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, error) {
+		if len(path) < 1 {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest,
+				"Insufficient parameters, at least 1 parameter is required")
+		}
+
+		switch path[0] {
+		case types.QueryClassTraces:
+			return codec.MarshalJSONIndent(types.ModuleCdc, k.GetClassTraces(ctx))
+		case types.QueryClassTrace:
+			var params types.QueryClassTraceParams
+			if err := types.ModuleCdc.UnmarshalJSON(req.Data, &params); err != nil {
+				return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+			}
+			hash, err := parseClassHash(params.Hash)
+			if err != nil {
+				return nil, err
+			}
+			trace, found := k.GetClassTrace(ctx, hash)
+			if !found {
+				return nil, sdkerrors.Wrap(types.ErrTraceNotFound, hash.String())
+			}
+			return codec.MarshalJSONIndent(types.ModuleCdc, trace)
+		case types.QueryEscrowAddress:
+			var params types.QueryEscrowAddressParams
+			if err := types.ModuleCdc.UnmarshalJSON(req.Data, &params); err != nil {
+				return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+			}
+			return codec.MarshalJSONIndent(types.ModuleCdc, types.GetEscrowAddress(params.PortID, params.ChannelID))
+		default:
+			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "unknown query endpoint")
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package keeper
+
+import (
+	"strings"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	"github.com/okex/exchain/libs/ibc-go/modules/apps/nft-transfer/types"
+	clienttypes "github.com/okex/exchain/libs/ibc-go/modules/core/02-client/types"
+	channeltypes "github.com/okex/exchain/libs/ibc-go/modules/core/04-channel/types"
+	host "github.com/okex/exchain/libs/ibc-go/modules/core/24-host"
+)
+
+// SendTransfer sends the nfts of a class to another chain, it returns the sequence of the sent packet.
+// Like ICS-20, there are 2 possible cases:
+//
+// 1. Sender chain is acting as the source zone. The nfts are transferred to the escrow address
+// of the channel and the receiving chain mints vouchers of them.
+//
+// 2. Sender chain is acting as the sink zone. The nfts are vouchers of the receiving chain, they are
+// burned and the receiving chain unescrows the original nfts.
+func (k Keeper) SendTransfer(
+	ctx sdk.Context,
+	sourcePort,
+	sourceChannel,
+	classID string,
+	tokenIDs []string,
+	sender sdk.AccAddress,
+	receiver string,
+	timeoutHeight clienttypes.Height,
+	timeoutTimestamp uint64,
+	memo string,
+) (uint64, error) {
+	sourceChannelEnd, found := k.channelKeeper.GetChannel(ctx, sourcePort, sourceChannel)
+	if !found {
+		return 0, sdkerrors.Wrapf(channeltypes.ErrChannelNotFound, "port ID (%s) channel ID (%s)", sourcePort, sourceChannel)
+	}
+	destinationPort := sourceChannelEnd.GetCounterparty().GetPortID()
+	destinationChannel := sourceChannelEnd.GetCounterparty().GetChannelID()
+
+	sequence, found := k.channelKeeper.GetNextSequenceSend(ctx, sourcePort, sourceChannel)
+	if !found {
+		return 0, sdkerrors.Wrapf(
+			channeltypes.ErrSequenceSendNotFound,
+			"source port: %s, source channel: %s", sourcePort, sourceChannel,
+		)
+	}
+
+	channelCap, ok := k.scopedKeeper.GetCapability(ctx, host.ChannelCapabilityPath(sourcePort, sourceChannel))
+	if !ok {
+		return 0, sdkerrors.Wrap(channeltypes.ErrChannelCapabilityNotFound, "module does not own channel capability")
+	}
+
+	// the packet carries the full class path, the receiving chain performs the class prefixing
+	fullClassPath := classID
+	if strings.HasPrefix(classID, types.ClassPrefix+"/") {
+		var err error
+		if fullClassPath, err = k.ClassPathFromHash(ctx, classID); err != nil {
+			return 0, err
+		}
+	}
+	classURI, classData, found := k.nftKeeper.GetClassInfo(ctx, classID)
+	if !found {
+		return 0, sdkerrors.Wrapf(types.ErrInvalidClassID, "class %s not found", classID)
+	}
+
+	isSource := types.SenderChainIsSource(sourcePort, sourceChannel, fullClassPath)
+	escrowAddress := types.GetEscrowAddress(sourcePort, sourceChannel)
+	tokenURIs := make([]string, len(tokenIDs))
+	tokenData := make([]string, len(tokenIDs))
+	for i, tokenID := range tokenIDs {
+		if owner := k.nftKeeper.GetNFTOwner(ctx, classID, tokenID); !owner.Equals(sender) {
+			return 0, sdkerrors.Wrapf(types.ErrNotOwner, "nft %s of class %s", tokenID, classID)
+		}
+		tokenURIs[i], tokenData[i], _ = k.nftKeeper.GetNFTInfo(ctx, classID, tokenID)
+
+		if isSource {
+			if err := k.nftKeeper.TransferNFT(ctx, classID, tokenID, escrowAddress); err != nil {
+				return 0, err
+			}
+		} else if err := k.nftKeeper.BurnNFT(ctx, classID, tokenID); err != nil {
+			return 0, err
+		}
+	}
+
+	packetData := types.NewNonFungibleTokenPacketData(
+		fullClassPath, classURI, classData, tokenIDs, tokenURIs, tokenData, sender.String(), receiver, memo,
+	)
+	packet := channeltypes.NewPacket(
+		packetData.GetBytes(),
+		sequence,
+		sourcePort,
+		sourceChannel,
+		destinationPort,
+		destinationChannel,
+		timeoutHeight,
+		timeoutTimestamp,
+	)
+	if err := k.channelKeeper.SendPacket(ctx, channelCap, packet); err != nil {
+		return 0, err
+	}
+	return sequence, nil
+}
+
+// OnRecvPacket processes a cross chain nft transfer. If the sender chain is the source of the class,
+// vouchers are minted to the receiver under the class "ibc/{hash}". Otherwise the nfts this chain
+// previously sent are unescrowed to the receiver.
+func (k Keeper) OnRecvPacket(ctx sdk.Context, packet channeltypes.Packet, data types.NonFungibleTokenPacketData) error {
+	if err := data.ValidateBasic(); err != nil {
+		return err
+	}
+	receiver, err := sdk.AccAddressFromBech32(data.Receiver)
+	if err != nil {
+		return err
+	}
+
+	if types.ReceiverChainIsSource(packet.GetSourcePort(), packet.GetSourceChannel(), data.ClassID) {
+		// remove the prefix added by the sender chain
+		unprefixedClassPath := data.ClassID[len(types.GetClassPrefix(packet.GetSourcePort(), packet.GetSourceChannel())):]
+		classID := types.ParseClassTrace(unprefixedClassPath).IBCClassID()
+		escrowAddress := types.GetEscrowAddress(packet.GetDestPort(), packet.GetDestChannel())
+		for _, tokenID := range data.TokenIDs {
+			if owner := k.nftKeeper.GetNFTOwner(ctx, classID, tokenID); !owner.Equals(escrowAddress) {
+				return sdkerrors.Wrapf(types.ErrNotOwner, "nft %s of class %s is not escrowed", tokenID, classID)
+			}
+			if err := k.nftKeeper.TransferNFT(ctx, classID, tokenID, receiver); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	prefixedClassPath := types.GetClassPrefix(packet.GetDestPort(), packet.GetDestChannel()) + data.ClassID
+	trace := types.ParseClassTrace(prefixedClassPath)
+	if !k.HasClassTrace(ctx, trace.Hash()) {
+		k.SetClassTrace(ctx, trace)
+	}
+	voucherClassID := trace.IBCClassID()
+	if !k.nftKeeper.HasClass(ctx, voucherClassID) {
+		if err := k.nftKeeper.SaveClassInfo(ctx, voucherClassID, data.ClassURI, data.ClassData); err != nil {
+			return err
+		}
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeClassTrace,
+			sdk.NewAttribute(types.AttributeKeyTraceHash, trace.Hash().String()),
+			sdk.NewAttribute(types.AttributeKeyClassID, voucherClassID),
+		),
+	)
+
+	for i, tokenID := range data.TokenIDs {
+		if err := k.nftKeeper.MintNFT(ctx, voucherClassID, tokenID, data.TokenURI(i), data.TokenDataAt(i), receiver); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// OnAcknowledgementPacket refunds the nfts to the sender if the packet was acknowledged with an error
+func (k Keeper) OnAcknowledgementPacket(ctx sdk.Context, packet channeltypes.Packet, data types.NonFungibleTokenPacketData, ack channeltypes.Acknowledgement) error {
+	if ack.Success() {
+		return nil
+	}
+	return k.refundPacketToken(ctx, packet, data)
+}
+
+// OnTimeoutPacket refunds the nfts to the sender
+func (k Keeper) OnTimeoutPacket(ctx sdk.Context, packet channeltypes.Packet, data types.NonFungibleTokenPacketData) error {
+	return k.refundPacketToken(ctx, packet, data)
+}
+
+// refundPacketToken unescrows the nfts sent from the source zone, or mints the burned vouchers back
+func (k Keeper) refundPacketToken(ctx sdk.Context, packet channeltypes.Packet, data types.NonFungibleTokenPacketData) error {
+	sender, err := sdk.AccAddressFromBech32(data.Sender)
+	if err != nil {
+		return err
+	}
+
+	classID := types.ParseClassTrace(data.ClassID).IBCClassID()
+	isSource := types.SenderChainIsSource(packet.GetSourcePort(), packet.GetSourceChannel(), data.ClassID)
+	for i, tokenID := range data.TokenIDs {
+		if isSource {
+			if err := k.nftKeeper.TransferNFT(ctx, classID, tokenID, sender); err != nil {
+				return err
+			}
+		} else if err := k.nftKeeper.MintNFT(ctx, classID, tokenID, data.TokenURI(i), data.TokenDataAt(i), sender); err != nil {
+			return err
+		}
+	}
+	return nil
+}
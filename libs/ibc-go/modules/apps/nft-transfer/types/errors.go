@@ -0,0 +1,19 @@
+package types
+
+import (
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+)
+
+// ICS-721 sentinel errors
+var (
+	ErrInvalidPacketTimeout = sdkerrors.Register(ModuleName, 2, "invalid packet timeout")
+	ErrInvalidClassID       = sdkerrors.Register(ModuleName, 3, "invalid class id")
+	ErrInvalidClassTrace    = sdkerrors.Register(ModuleName, 4, "invalid class trace")
+	ErrTraceNotFound        = sdkerrors.Register(ModuleName, 5, "class trace not found")
+	ErrInvalidTokenID       = sdkerrors.Register(ModuleName, 6, "invalid token id")
+	ErrInvalidPacketData    = sdkerrors.Register(ModuleName, 7, "invalid packet data")
+	ErrInvalidVersion       = sdkerrors.Register(ModuleName, 8, "invalid ICS721 version")
+	ErrMaxTransferChannels  = sdkerrors.Register(ModuleName, 9, "max nft transfer channels")
+	ErrNotOwner             = sdkerrors.Register(ModuleName, 10, "sender is not the owner of the nft")
+	ErrTransferNotSupport   = sdkerrors.Register(ModuleName, 11, "nft transfer is not supported")
+)
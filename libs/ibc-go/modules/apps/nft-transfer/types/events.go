@@ -0,0 +1,18 @@
+package types
+
+// ICS-721 events
+const (
+	EventTypeTransfer   = "ibc_nft_transfer"
+	EventTypePacket     = "non_fungible_token_packet"
+	EventTypeTimeout    = "timeout"
+	EventTypeClassTrace = "class_trace"
+
+	AttributeKeyReceiver       = "receiver"
+	AttributeKeyClassID        = "class_id"
+	AttributeKeyTokenIDs       = "token_ids"
+	AttributeKeyMemo           = "memo"
+	AttributeKeyRefundReceiver = "refund_receiver"
+	AttributeKeyAckSuccess     = "success"
+	AttributeKeyAckError       = "error"
+	AttributeKeyTraceHash      = "trace_hash"
+)
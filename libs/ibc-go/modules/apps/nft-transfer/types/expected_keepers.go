@@ -0,0 +1,40 @@
+package types
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	capabilitytypes "github.com/okex/exchain/libs/cosmos-sdk/x/capability/types"
+	channeltypes "github.com/okex/exchain/libs/ibc-go/modules/core/04-channel/types"
+	ibcexported "github.com/okex/exchain/libs/ibc-go/modules/core/exported"
+)
+
+// NFTKeeper defines the expected native nft store. The classes and the nfts are passed as plain
+// fields, so that the store does not depend on this module.
+type NFTKeeper interface {
+	HasClass(ctx sdk.Context, classID string) bool
+	GetClassInfo(ctx sdk.Context, classID string) (uri, data string, found bool)
+	SaveClassInfo(ctx sdk.Context, classID, uri, data string) error
+	GetNFTInfo(ctx sdk.Context, classID, tokenID string) (uri, data string, found bool)
+	GetNFTOwner(ctx sdk.Context, classID, tokenID string) sdk.AccAddress
+	MintNFT(ctx sdk.Context, classID, tokenID, uri, data string, receiver sdk.AccAddress) error
+	BurnNFT(ctx sdk.Context, classID, tokenID string) error
+	TransferNFT(ctx sdk.Context, classID, tokenID string, receiver sdk.AccAddress) error
+}
+
+// ChannelKeeper defines the expected IBC channel keeper
+type ChannelKeeper interface {
+	GetChannel(ctx sdk.Context, srcPort, srcChan string) (channel channeltypes.Channel, found bool)
+	GetNextSequenceSend(ctx sdk.Context, portID, channelID string) (uint64, bool)
+	SendPacket(ctx sdk.Context, channelCap *capabilitytypes.Capability, packet ibcexported.PacketI) error
+}
+
+// PortKeeper defines the expected IBC port keeper
+type PortKeeper interface {
+	BindPort(ctx sdk.Context, portID string) *capabilitytypes.Capability
+}
+
+// ScopedKeeper defines the expected scoped capability keeper of the ICS-721 module
+type ScopedKeeper interface {
+	GetCapability(ctx sdk.Context, name string) (*capabilitytypes.Capability, bool)
+	AuthenticateCapability(ctx sdk.Context, cap *capabilitytypes.Capability, name string) bool
+	ClaimCapability(ctx sdk.Context, cap *capabilitytypes.Capability, name string) error
+}
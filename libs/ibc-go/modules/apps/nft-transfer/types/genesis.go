@@ -0,0 +1,45 @@
+package types
+
+import (
+	"fmt"
+
+	host "github.com/okex/exchain/libs/ibc-go/modules/core/24-host"
+)
+
+// GenesisState defines the ICS-721 genesis state
+type GenesisState struct {
+	PortID      string       `json:"port_id"`
+	ClassTraces []ClassTrace `json:"class_traces"`
+}
+
+// NewGenesisState creates a new ICS-721 GenesisState instance
+func NewGenesisState(portID string, classTraces []ClassTrace) GenesisState {
+	return GenesisState{
+		PortID:      portID,
+		ClassTraces: classTraces,
+	}
+}
+
+// DefaultGenesisState returns a GenesisState with the default port and no class traces
+func DefaultGenesisState() GenesisState {
+	return NewGenesisState(PortID, nil)
+}
+
+// Validate performs basic genesis state validation returning an error upon any failure
+func (gs GenesisState) Validate() error {
+	if err := host.PortIdentifierValidator(gs.PortID); err != nil {
+		return err
+	}
+	seen := make(map[string]bool)
+	for _, trace := range gs.ClassTraces {
+		if err := trace.Validate(); err != nil {
+			return err
+		}
+		hash := trace.Hash().String()
+		if seen[hash] {
+			return fmt.Errorf("duplicated class trace %s", trace.GetFullClassPath())
+		}
+		seen[hash] = true
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package types
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
+const (
+	// ModuleName defines the ICS-721 non-fungible token transfer module name
+	ModuleName = "nonfungibletokentransfer"
+
+	// Version defines the current version the ICS-721 module supports
+	Version = "ics721-1"
+
+	// PortID is the default port id that the ICS-721 module binds to
+	PortID = "nft-transfer"
+
+	// StoreKey is the store key string for the ICS-721 module
+	StoreKey = ModuleName
+
+	// RouterKey is the message route for the ICS-721 module
+	RouterKey = ModuleName
+
+	// QuerierRoute is the querier route for the ICS-721 module
+	QuerierRoute = ModuleName
+
+	// ClassPrefix is the prefix used for the class ids of the vouchers
+	ClassPrefix = "ibc"
+
+	// query endpoints supported by the querier
+	QueryClassTraces   = "class-traces"
+	QueryClassTrace    = "class-trace"
+	QueryEscrowAddress = "escrow-address"
+)
+
+var (
+	// PortKey defines the key to store the port ID in store
+	PortKey = []byte{0x01}
+	// ClassTraceKey defines the key to store the class traces in store
+	ClassTraceKey = []byte{0x02}
+)
+
+// GetClassTraceKey returns the store key of the class trace of the hash
+func GetClassTraceKey(hash []byte) []byte {
+	return append(ClassTraceKey, hash...)
+}
+
+// GetEscrowAddress returns the escrow address of the nfts sent over the specified channel.
+// The address is derived the same way as the ICS-20 escrow addresses, under the ICS-721 version.
+func GetEscrowAddress(portID, channelID string) sdk.AccAddress {
+	// a slash is used to create domain separation between port and channel identifiers to
+	// prevent address collisions between escrow addresses created for different channels
+	contents := fmt.Sprintf("%s/%s", portID, channelID)
+
+	// ADR 028 AddressHash construction
+	preImage := []byte(Version)
+	preImage = append(preImage, 0)
+	preImage = append(preImage, contents...)
+	hash := sha256.Sum256(preImage)
+	return hash[:20]
+}
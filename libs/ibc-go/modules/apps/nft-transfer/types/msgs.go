@@ -0,0 +1,79 @@
+package types
+
+import (
+	"strings"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	clienttypes "github.com/okex/exchain/libs/ibc-go/modules/core/02-client/types"
+	host "github.com/okex/exchain/libs/ibc-go/modules/core/24-host"
+)
+
+// MsgTransfer sends nfts of a class to another chain over an ICS-721 channel
+type MsgTransfer struct {
+	SourcePort       string             `json:"source_port"`
+	SourceChannel    string             `json:"source_channel"`
+	ClassID          string             `json:"class_id"`
+	TokenIDs         []string           `json:"token_ids"`
+	Sender           sdk.AccAddress     `json:"sender"`
+	Receiver         string             `json:"receiver"`
+	TimeoutHeight    clienttypes.Height `json:"timeout_height"`
+	TimeoutTimestamp uint64             `json:"timeout_timestamp"`
+	Memo             string             `json:"memo"`
+}
+
+// NewMsgTransfer creates a new MsgTransfer instance
+func NewMsgTransfer(
+	sourcePort, sourceChannel, classID string, tokenIDs []string, sender sdk.AccAddress, receiver string,
+	timeoutHeight clienttypes.Height, timeoutTimestamp uint64, memo string,
+) MsgTransfer {
+	return MsgTransfer{
+		SourcePort:       sourcePort,
+		SourceChannel:    sourceChannel,
+		ClassID:          classID,
+		TokenIDs:         tokenIDs,
+		Sender:           sender,
+		Receiver:         receiver,
+		TimeoutHeight:    timeoutHeight,
+		TimeoutTimestamp: timeoutTimestamp,
+		Memo:             memo,
+	}
+}
+
+func (msg MsgTransfer) Route() string { return RouterKey }
+
+func (msg MsgTransfer) Type() string { return "transfer" }
+
+func (msg MsgTransfer) ValidateBasic() error {
+	if err := host.PortIdentifierValidator(msg.SourcePort); err != nil {
+		return sdkerrors.Wrap(err, "invalid source port ID")
+	}
+	if err := host.ChannelIdentifierValidator(msg.SourceChannel); err != nil {
+		return sdkerrors.Wrap(err, "invalid source channel ID")
+	}
+	if strings.TrimSpace(msg.ClassID) == "" {
+		return sdkerrors.Wrap(ErrInvalidClassID, "class id cannot be blank")
+	}
+	if err := validateTokenIDs(msg.TokenIDs); err != nil {
+		return err
+	}
+	if msg.Sender.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "sender cannot be empty")
+	}
+	if strings.TrimSpace(msg.Receiver) == "" {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "receiver cannot be blank")
+	}
+	if msg.TimeoutHeight.IsZero() && msg.TimeoutTimestamp == 0 {
+		return sdkerrors.Wrap(ErrInvalidPacketTimeout, "timeout height and timeout timestamp cannot both be 0")
+	}
+	return nil
+}
+
+func (msg MsgTransfer) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg MsgTransfer) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
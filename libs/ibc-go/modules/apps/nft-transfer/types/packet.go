@@ -0,0 +1,114 @@
+package types
+
+import (
+	"encoding/json"
+	"strings"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+)
+
+// NonFungibleTokenPacketData defines a struct for the packet payload of ICS-721.
+// The uris and the data of the tokens are either empty or match the token ids one by one.
+type NonFungibleTokenPacketData struct {
+	ClassID   string   `json:"classId"`
+	ClassURI  string   `json:"classUri,omitempty"`
+	ClassData string   `json:"classData,omitempty"`
+	TokenIDs  []string `json:"tokenIds"`
+	TokenURIs []string `json:"tokenUris,omitempty"`
+	TokenData []string `json:"tokenData,omitempty"`
+	Sender    string   `json:"sender"`
+	Receiver  string   `json:"receiver"`
+	Memo      string   `json:"memo,omitempty"`
+}
+
+// NewNonFungibleTokenPacketData constructs a new NonFungibleTokenPacketData instance
+func NewNonFungibleTokenPacketData(
+	classID, classURI, classData string, tokenIDs, tokenURIs, tokenData []string, sender, receiver, memo string,
+) NonFungibleTokenPacketData {
+	return NonFungibleTokenPacketData{
+		ClassID:   classID,
+		ClassURI:  classURI,
+		ClassData: classData,
+		TokenIDs:  tokenIDs,
+		TokenURIs: tokenURIs,
+		TokenData: tokenData,
+		Sender:    sender,
+		Receiver:  receiver,
+		Memo:      memo,
+	}
+}
+
+// ValidateBasic is used for validating the nft transfer
+func (data NonFungibleTokenPacketData) ValidateBasic() error {
+	if strings.TrimSpace(data.ClassID) == "" {
+		return sdkerrors.Wrap(ErrInvalidClassID, "class id cannot be blank")
+	}
+	if err := validateTokenIDs(data.TokenIDs); err != nil {
+		return err
+	}
+	if len(data.TokenURIs) != 0 && len(data.TokenURIs) != len(data.TokenIDs) {
+		return sdkerrors.Wrap(ErrInvalidPacketData, "the number of the token uris must match the token ids")
+	}
+	if len(data.TokenData) != 0 && len(data.TokenData) != len(data.TokenIDs) {
+		return sdkerrors.Wrap(ErrInvalidPacketData, "the number of the token data must match the token ids")
+	}
+	if strings.TrimSpace(data.Sender) == "" {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "sender address cannot be blank")
+	}
+	if strings.TrimSpace(data.Receiver) == "" {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "receiver address cannot be blank")
+	}
+	return nil
+}
+
+// TokenURI returns the uri of the i-th token
+func (data NonFungibleTokenPacketData) TokenURI(i int) string {
+	if i < len(data.TokenURIs) {
+		return data.TokenURIs[i]
+	}
+	return ""
+}
+
+// TokenDataAt returns the data of the i-th token
+func (data NonFungibleTokenPacketData) TokenDataAt(i int) string {
+	if i < len(data.TokenData) {
+		return data.TokenData[i]
+	}
+	return ""
+}
+
+// GetBytes is a helper for serialising
+func (data NonFungibleTokenPacketData) GetBytes() []byte {
+	bz, err := json.Marshal(data)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// UnmarshalPacketData decodes the ICS-721 packet data
+func UnmarshalPacketData(bz []byte) (NonFungibleTokenPacketData, error) {
+	var data NonFungibleTokenPacketData
+	if err := json.Unmarshal(bz, &data); err != nil {
+		return data, sdkerrors.Wrapf(sdkerrors.ErrInvalidType, "cannot unmarshal ICS-721 transfer packet data: %s", err)
+	}
+	return data, nil
+}
+
+func validateTokenIDs(tokenIDs []string) error {
+	if len(tokenIDs) == 0 {
+		return sdkerrors.Wrap(ErrInvalidTokenID, "token ids cannot be empty")
+	}
+	seen := make(map[string]bool, len(tokenIDs))
+	for _, id := range tokenIDs {
+		if strings.TrimSpace(id) == "" {
+			return sdkerrors.Wrap(ErrInvalidTokenID, "token id cannot be blank")
+		}
+		if seen[id] {
+			return sdkerrors.Wrapf(ErrInvalidTokenID, "duplicated token id %s", id)
+		}
+		seen[id] = true
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package types
+
+// QueryClassTraceParams queries the class trace of a voucher class id or a trace hash
+type QueryClassTraceParams struct {
+	Hash string `json:"hash"`
+}
+
+// NewQueryClassTraceParams creates a new instance of QueryClassTraceParams
+func NewQueryClassTraceParams(hash string) QueryClassTraceParams {
+	return QueryClassTraceParams{Hash: hash}
+}
+
+// QueryEscrowAddressParams queries the escrow address of a channel
+type QueryEscrowAddressParams struct {
+	PortID    string `json:"port_id"`
+	ChannelID string `json:"channel_id"`
+}
+
+// NewQueryEscrowAddressParams creates a new instance of QueryEscrowAddressParams
+func NewQueryEscrowAddressParams(portID, channelID string) QueryEscrowAddressParams {
+	return QueryEscrowAddressParams{PortID: portID, ChannelID: channelID}
+}
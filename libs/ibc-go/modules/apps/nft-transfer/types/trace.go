@@ -0,0 +1,130 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	host "github.com/okex/exchain/libs/ibc-go/modules/core/24-host"
+	tmbytes "github.com/okex/exchain/libs/tendermint/libs/bytes"
+)
+
+// ClassTrace contains the base class id of an nft class and the port/channel pairs
+// it was transferred through
+type ClassTrace struct {
+	// path defines the chain of port/channel identifiers used for tracing the source of the class
+	Path string `json:"path" yaml:"path"`
+	// base class id of the relayed nft class
+	BaseClassID string `json:"base_class_id" yaml:"base_class_id"`
+}
+
+// ParseClassTrace parses a full class path into a class trace. Unlike the ICS-20 denoms, a base class
+// id may contain slashes, so only the leading port/channel pairs are taken as the path.
+func ParseClassTrace(rawClassID string) ClassTrace {
+	parts := strings.Split(rawClassID, "/")
+
+	i := 0
+	for ; i+2 < len(parts); i += 2 {
+		if host.PortIdentifierValidator(parts[i]) != nil || !isChannelID(parts[i+1]) {
+			break
+		}
+	}
+
+	return ClassTrace{
+		Path:        strings.Join(parts[:i], "/"),
+		BaseClassID: strings.Join(parts[i:], "/"),
+	}
+}
+
+func isChannelID(id string) bool {
+	return strings.HasPrefix(id, "channel-") && host.ChannelIdentifierValidator(id) == nil
+}
+
+// Hash returns the hex bytes of the SHA256 hash of the full class path
+func (ct ClassTrace) Hash() tmbytes.HexBytes {
+	hash := sha256.Sum256([]byte(ct.GetFullClassPath()))
+	return hash[:]
+}
+
+// GetFullClassPath returns the full class path: tracePath + "/" + baseClassID.
+// If there exists no trace then the base class id is returned.
+func (ct ClassTrace) GetFullClassPath() string {
+	if ct.Path == "" {
+		return ct.BaseClassID
+	}
+	return ct.GetPrefix() + ct.BaseClassID
+}
+
+// GetPrefix returns the receiving class id prefix composed by the trace info and a separator
+func (ct ClassTrace) GetPrefix() string {
+	return ct.Path + "/"
+}
+
+// IBCClassID returns the class id of the vouchers on this chain in the format 'ibc/{hash(tracePath + baseClassID)}'.
+// If the trace is empty, it returns the base class id.
+func (ct ClassTrace) IBCClassID() string {
+	if ct.Path != "" {
+		return fmt.Sprintf("%s/%s", ClassPrefix, ct.Hash())
+	}
+	return ct.BaseClassID
+}
+
+// Validate performs a basic validation of the class trace
+func (ct ClassTrace) Validate() error {
+	if strings.TrimSpace(ct.BaseClassID) == "" {
+		return sdkerrors.Wrap(ErrInvalidClassTrace, "base class id cannot be blank")
+	}
+	if ct.Path == "" {
+		return nil
+	}
+
+	parts := strings.Split(ct.Path, "/")
+	if len(parts)%2 != 0 {
+		return sdkerrors.Wrapf(ErrInvalidClassTrace, "path %s must be port/channel pairs", ct.Path)
+	}
+	for i := 0; i < len(parts); i += 2 {
+		if err := host.PortIdentifierValidator(parts[i]); err != nil {
+			return sdkerrors.Wrapf(ErrInvalidClassTrace, "invalid port %s: %s", parts[i], err)
+		}
+		if !isChannelID(parts[i+1]) {
+			return sdkerrors.Wrapf(ErrInvalidClassTrace, "invalid channel %s", parts[i+1])
+		}
+	}
+	return nil
+}
+
+func (ct ClassTrace) String() string {
+	return fmt.Sprintf(`Class Trace:
+  Path:          %s
+  Base Class ID: %s`, ct.Path, ct.BaseClassID)
+}
+
+// SenderChainIsSource returns false if the class originally came from the receiving chain
+func SenderChainIsSource(sourcePort, sourceChannel, classID string) bool {
+	return !ReceiverChainIsSource(sourcePort, sourceChannel, classID)
+}
+
+// ReceiverChainIsSource returns true if the class originally came from the receiving chain,
+// in which case the class path on the sender chain is prefixed by the source port and channel
+func ReceiverChainIsSource(sourcePort, sourceChannel, classID string) bool {
+	return strings.HasPrefix(classID, GetClassPrefix(sourcePort, sourceChannel))
+}
+
+// GetClassPrefix returns the receiving class id prefix
+func GetClassPrefix(portID, channelID string) string {
+	return fmt.Sprintf("%s/%s/", portID, channelID)
+}
+
+// ParseHexHash parses a hex hash in string format to bytes and validates its correctness
+func ParseHexHash(hexHash string) (tmbytes.HexBytes, error) {
+	hash, err := hex.DecodeString(hexHash)
+	if err != nil {
+		return nil, err
+	}
+	if len(hash) != sha256.Size {
+		return nil, fmt.Errorf("expected %d bytes, got %d", sha256.Size, len(hash))
+	}
+	return hash, nil
+}
@@ -0,0 +1,60 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseClassTrace(t *testing.T) {
+	testCases := []struct {
+		name     string
+		classID  string
+		expTrace ClassTrace
+	}{
+		{"base class", "kitty", ClassTrace{BaseClassID: "kitty"}},
+		{"base class with slashes", "a/b/c", ClassTrace{BaseClassID: "a/b/c"}},
+		{"one hop", "nft-transfer/channel-0/kitty", ClassTrace{Path: "nft-transfer/channel-0", BaseClassID: "kitty"}},
+		{"two hops", "nft-transfer/channel-0/nft-transfer/channel-3/kitty",
+			ClassTrace{Path: "nft-transfer/channel-0/nft-transfer/channel-3", BaseClassID: "kitty"}},
+		{"one hop with slashes in base class", "nft-transfer/channel-0/wasm.ex1/kitty",
+			ClassTrace{Path: "nft-transfer/channel-0", BaseClassID: "wasm.ex1/kitty"}},
+		{"trace only", "nft-transfer/channel-0", ClassTrace{BaseClassID: "nft-transfer/channel-0"}},
+	}
+	for _, tc := range testCases {
+		trace := ParseClassTrace(tc.classID)
+		require.Equal(t, tc.expTrace, trace, tc.name)
+		require.Equal(t, tc.classID, trace.GetFullClassPath(), tc.name)
+		require.NoError(t, trace.Validate(), tc.name)
+	}
+}
+
+func TestClassTraceIBCClassID(t *testing.T) {
+	require.Equal(t, "kitty", ParseClassTrace("kitty").IBCClassID())
+
+	classID := ParseClassTrace("nft-transfer/channel-0/kitty").IBCClassID()
+	require.Regexp(t, "^ibc/[0-9A-F]{64}$", classID)
+
+	hash, err := ParseHexHash(classID[len(ClassPrefix)+1:])
+	require.NoError(t, err)
+	require.Equal(t, ParseClassTrace("nft-transfer/channel-0/kitty").Hash(), hash)
+}
+
+func TestPacketDataValidateBasic(t *testing.T) {
+	testCases := []struct {
+		name    string
+		data    NonFungibleTokenPacketData
+		expPass bool
+	}{
+		{"valid", NewNonFungibleTokenPacketData("kitty", "", "", []string{"1", "2"}, nil, nil, "sender", "receiver", ""), true},
+		{"valid with uris", NewNonFungibleTokenPacketData("kitty", "uri", "", []string{"1"}, []string{"uri1"}, []string{"data1"}, "sender", "receiver", ""), true},
+		{"blank class", NewNonFungibleTokenPacketData(" ", "", "", []string{"1"}, nil, nil, "sender", "receiver", ""), false},
+		{"no tokens", NewNonFungibleTokenPacketData("kitty", "", "", nil, nil, nil, "sender", "receiver", ""), false},
+		{"duplicated tokens", NewNonFungibleTokenPacketData("kitty", "", "", []string{"1", "1"}, nil, nil, "sender", "receiver", ""), false},
+		{"uris mismatch", NewNonFungibleTokenPacketData("kitty", "", "", []string{"1", "2"}, []string{"uri1"}, nil, "sender", "receiver", ""), false},
+		{"blank receiver", NewNonFungibleTokenPacketData("kitty", "", "", []string{"1"}, nil, nil, "sender", "", ""), false},
+	}
+	for _, tc := range testCases {
+		require.Equal(t, tc.expPass, tc.data.ValidateBasic() == nil, tc.name)
+	}
+}
@@ -0,0 +1,230 @@
+package packetforward
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	capabilitytypes "github.com/okex/exchain/libs/cosmos-sdk/x/capability/types"
+	"github.com/okex/exchain/libs/ibc-go/modules/apps/packet-forward/keeper"
+	"github.com/okex/exchain/libs/ibc-go/modules/apps/packet-forward/types"
+	transfertypes "github.com/okex/exchain/libs/ibc-go/modules/apps/transfer/types"
+	channeltypes "github.com/okex/exchain/libs/ibc-go/modules/core/04-channel/types"
+	porttypes "github.com/okex/exchain/libs/ibc-go/modules/core/05-port/types"
+	"github.com/okex/exchain/libs/ibc-go/modules/core/exported"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+)
+
+var _ porttypes.Middleware = &IBCMiddleware{}
+
+// IBCMiddleware implements the ICS26 callbacks of the packet forward middleware, which wraps the ics20 transfer
+// module to forward the received tokens to the next chain named in the memo of the packet.
+type IBCMiddleware struct {
+	app         porttypes.IBCModule
+	ics4Wrapper porttypes.ICS4Wrapper
+	keeper      keeper.Keeper
+}
+
+// NewIBCMiddleware creates a new IBCMiddleware given the keeper, the ics4 wrapper and the underlying application
+func NewIBCMiddleware(app porttypes.IBCModule, ics4Wrapper porttypes.ICS4Wrapper, k keeper.Keeper) IBCMiddleware {
+	return IBCMiddleware{
+		app:         app,
+		ics4Wrapper: ics4Wrapper,
+		keeper:      k,
+	}
+}
+
+// OnChanOpenInit implements the IBCMiddleware interface
+func (im IBCMiddleware) OnChanOpenInit(
+	ctx sdk.Context,
+	order channeltypes.Order,
+	connectionHops []string,
+	portID string,
+	channelID string,
+	chanCap *capabilitytypes.Capability,
+	counterparty channeltypes.Counterparty,
+	version string,
+) (string, error) {
+	return im.app.OnChanOpenInit(ctx, order, connectionHops, portID, channelID, chanCap, counterparty, version)
+}
+
+// OnChanOpenTry implements the IBCMiddleware interface
+func (im IBCMiddleware) OnChanOpenTry(
+	ctx sdk.Context,
+	order channeltypes.Order,
+	connectionHops []string,
+	portID,
+	channelID string,
+	chanCap *capabilitytypes.Capability,
+	counterparty channeltypes.Counterparty,
+	version string,
+	counterpartyVersion string,
+) (string, error) {
+	return im.app.OnChanOpenTry(ctx, order, connectionHops, portID, channelID, chanCap, counterparty, version, counterpartyVersion)
+}
+
+// OnChanOpenAck implements the IBCMiddleware interface
+func (im IBCMiddleware) OnChanOpenAck(
+	ctx sdk.Context,
+	portID,
+	channelID string,
+	counterpartyChannelID string,
+	counterpartyVersion string,
+) error {
+	return im.app.OnChanOpenAck(ctx, portID, channelID, counterpartyChannelID, counterpartyVersion)
+}
+
+// OnChanOpenConfirm implements the IBCMiddleware interface
+func (im IBCMiddleware) OnChanOpenConfirm(
+	ctx sdk.Context,
+	portID,
+	channelID string,
+) error {
+	return im.app.OnChanOpenConfirm(ctx, portID, channelID)
+}
+
+// OnChanCloseInit implements the IBCMiddleware interface
+func (im IBCMiddleware) OnChanCloseInit(
+	ctx sdk.Context,
+	portID,
+	channelID string,
+) error {
+	return im.app.OnChanCloseInit(ctx, portID, channelID)
+}
+
+// OnChanCloseConfirm implements the IBCMiddleware interface
+func (im IBCMiddleware) OnChanCloseConfirm(
+	ctx sdk.Context,
+	portID,
+	channelID string,
+) error {
+	return im.app.OnChanCloseConfirm(ctx, portID, channelID)
+}
+
+// OnRecvPacket implements the IBCMiddleware interface.
+// A packet whose memo carries forward metadata is received by an intermediary account derived from the channel and
+// the sender, then the tokens are sent to the next chain. The acknowledgement is written asynchronously once the
+// forwarded packet is acknowledged or finally timed out.
+func (im IBCMiddleware) OnRecvPacket(
+	ctx sdk.Context,
+	packet channeltypes.Packet,
+	relayer sdk.AccAddress,
+) exported.Acknowledgement {
+	if !tmtypes.HigherThanJupiter(ctx.BlockHeight()) {
+		return im.app.OnRecvPacket(ctx, packet, relayer)
+	}
+
+	var data transfertypes.FungibleTokenPacketData
+	if err := transfertypes.ModuleCdc.UnmarshalJSON(packet.GetData(), &data); err != nil || len(data.Memo) == 0 {
+		return im.app.OnRecvPacket(ctx, packet, relayer)
+	}
+	metadata, found, err := types.ParseForwardMetadata(data.Memo)
+	if err != nil {
+		return channeltypes.NewErrorAcknowledgementV4(err)
+	}
+	if !found {
+		return im.app.OnRecvPacket(ctx, packet, relayer)
+	}
+
+	intermediary := types.DeriveIntermediary(packet.GetDestChannel(), data.Sender)
+	recvData := data
+	recvData.Receiver = intermediary.String()
+	recvData.Memo = ""
+	ack := im.app.OnRecvPacket(ctx, withPacketData(packet, recvData), relayer)
+	if ack == nil || !ack.Success() {
+		return ack
+	}
+
+	token, _, err := types.ReceivedToken(packet, data)
+	if err != nil {
+		return channeltypes.NewErrorAcknowledgementV4(err)
+	}
+	// an error acknowledgement reverts the receipt of the tokens, the sender chain refunds them
+	if err := im.keeper.ForwardTransferPacket(ctx, packet, intermediary, token, metadata); err != nil {
+		return channeltypes.NewErrorAcknowledgementV4(err)
+	}
+	return nil
+}
+
+// OnAcknowledgementPacket implements the IBCMiddleware interface.
+// Once the transfer module handled the acknowledgement of a forwarded packet, the acknowledgement of the
+// original packet is written.
+func (im IBCMiddleware) OnAcknowledgementPacket(
+	ctx sdk.Context,
+	packet channeltypes.Packet,
+	acknowledgement []byte,
+	relayer sdk.AccAddress,
+) error {
+	if err := im.app.OnAcknowledgementPacket(ctx, packet, acknowledgement, relayer); err != nil {
+		return err
+	}
+	if !tmtypes.HigherThanJupiter(ctx.BlockHeight()) {
+		return nil
+	}
+
+	var ack channeltypes.Acknowledgement
+	if err := channeltypes.SubModuleCdc.UnmarshalJSON(acknowledgement, &ack); err != nil {
+		return nil
+	}
+	return im.keeper.OnForwardedPacketAck(ctx, packet, ack)
+}
+
+// OnTimeoutPacket implements the IBCMiddleware interface.
+// A timed out forwarded packet is resent while retries remain, otherwise the original packet fails.
+func (im IBCMiddleware) OnTimeoutPacket(
+	ctx sdk.Context,
+	packet channeltypes.Packet,
+	relayer sdk.AccAddress,
+) error {
+	if err := im.app.OnTimeoutPacket(ctx, packet, relayer); err != nil {
+		return err
+	}
+	if !tmtypes.HigherThanJupiter(ctx.BlockHeight()) {
+		return nil
+	}
+
+	var data transfertypes.FungibleTokenPacketData
+	if err := transfertypes.ModuleCdc.UnmarshalJSON(packet.GetData(), &data); err != nil {
+		return nil
+	}
+	return im.keeper.OnForwardedPacketTimeout(ctx, packet, data)
+}
+
+// NegotiateAppVersion implements the IBCMiddleware interface
+func (im IBCMiddleware) NegotiateAppVersion(
+	ctx sdk.Context,
+	order channeltypes.Order,
+	connectionID string,
+	portID string,
+	counterparty channeltypes.Counterparty,
+	proposedVersion string,
+) (string, error) {
+	return im.app.NegotiateAppVersion(ctx, order, connectionID, portID, counterparty, proposedVersion)
+}
+
+// SendPacket implements the ICS4 Wrapper interface
+func (im IBCMiddleware) SendPacket(
+	ctx sdk.Context,
+	chanCap *capabilitytypes.Capability,
+	packet exported.PacketI,
+) error {
+	return im.ics4Wrapper.SendPacket(ctx, chanCap, packet)
+}
+
+// WriteAcknowledgement implements the ICS4 Wrapper interface
+func (im IBCMiddleware) WriteAcknowledgement(
+	ctx sdk.Context,
+	chanCap *capabilitytypes.Capability,
+	packet exported.PacketI,
+	ack exported.Acknowledgement,
+) error {
+	return im.ics4Wrapper.WriteAcknowledgement(ctx, chanCap, packet, ack)
+}
+
+// GetAppVersion returns the application version of the underlying application
+func (im IBCMiddleware) GetAppVersion(ctx sdk.Context, portID, channelID string) (string, bool) {
+	return im.ics4Wrapper.GetAppVersion(ctx, portID, channelID)
+}
+
+// withPacketData returns a copy of the packet with the ics20 packet data
+func withPacketData(packet channeltypes.Packet, data transfertypes.FungibleTokenPacketData) channeltypes.Packet {
+	packet.Data = data.GetBytes()
+	return packet
+}
@@ -0,0 +1,351 @@
+package packetforward_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/store"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	capabilitytypes "github.com/okex/exchain/libs/cosmos-sdk/x/capability/types"
+	packetforward "github.com/okex/exchain/libs/ibc-go/modules/apps/packet-forward"
+	"github.com/okex/exchain/libs/ibc-go/modules/apps/packet-forward/keeper"
+	"github.com/okex/exchain/libs/ibc-go/modules/apps/packet-forward/types"
+	transfertypes "github.com/okex/exchain/libs/ibc-go/modules/apps/transfer/types"
+	clienttypes "github.com/okex/exchain/libs/ibc-go/modules/core/02-client/types"
+	channeltypes "github.com/okex/exchain/libs/ibc-go/modules/core/04-channel/types"
+	porttypes "github.com/okex/exchain/libs/ibc-go/modules/core/05-port/types"
+	"github.com/okex/exchain/libs/ibc-go/modules/core/exported"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	tmdb "github.com/okex/exchain/libs/tm-db"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	testChannel     = "channel-0"
+	testNextChannel = "channel-1"
+	testSender      = "cosmos1sender"
+	testReceiver    = "cosmos1receiver"
+)
+
+var testVoucher = transfertypes.ParseDenomTrace("transfer/" + testChannel + "/uatom").IBCDenom()
+
+type MiddlewareTestSuite struct {
+	suite.Suite
+
+	ctx        sdk.Context
+	bank       *mockBankKeeper
+	transfer   *mockTransferKeeper
+	ics4       *mockICS4Wrapper
+	app        *mockTransferModule
+	keeper     keeper.Keeper
+	middleware packetforward.IBCMiddleware
+}
+
+func TestMiddlewareTestSuite(t *testing.T) {
+	suite.Run(t, new(MiddlewareTestSuite))
+}
+
+func (suite *MiddlewareTestSuite) SetupTest() {
+	key := sdk.NewKVStoreKey(types.StoreKey)
+	ms := store.NewCommitMultiStore(tmdb.NewMemDB())
+	ms.MountStoreWithDB(key, sdk.StoreTypeIAVL, nil)
+	suite.Require().NoError(ms.LoadLatestVersion())
+	suite.ctx = sdk.NewContext(ms, abci.Header{Height: 2, Time: time.Unix(1000, 0)}, false, log.NewNopLogger())
+	tmtypes.UnittestOnlySetMilestoneEarthHeight(1)
+	tmtypes.UnittestOnlySetMilestoneJupiterHeight(1)
+
+	suite.bank = &mockBankKeeper{balances: map[string]sdk.Coins{}}
+	suite.transfer = &mockTransferKeeper{bank: suite.bank}
+	suite.ics4 = &mockICS4Wrapper{}
+	suite.app = &mockTransferModule{bank: suite.bank}
+	suite.keeper = keeper.NewKeeper(key, suite.transfer, suite.bank, mockScopedKeeper{}, suite.ics4)
+	suite.middleware = packetforward.NewIBCMiddleware(suite.app, nil, suite.keeper)
+}
+
+func (suite *MiddlewareTestSuite) packet(memo string) channeltypes.Packet {
+	data := transfertypes.NewFungibleTokenPacketData("uatom", "100", testSender, testReceiver)
+	data.Memo = memo
+	return channeltypes.NewPacket(data.GetBytes(), 1, "transfer", "channel-7", "transfer", testChannel, clienttypes.NewHeight(0, 100), 0)
+}
+
+func (suite *MiddlewareTestSuite) forwardMemo(retries uint8) string {
+	return fmt.Sprintf(`{"forward":{"receiver":"osmo1receiver","port":"transfer","channel":"%s","timeout":"1m","retries":%d,`+
+		`"next":{"wasm":{"contract":"osmo1contract","msg":{}}}}}`, testNextChannel, retries)
+}
+
+// forwarded returns the packet sent by the mock transfer keeper for the last forward
+func (suite *MiddlewareTestSuite) forwarded() channeltypes.Packet {
+	sent := suite.transfer.sent[len(suite.transfer.sent)-1]
+	data := transfertypes.NewFungibleTokenPacketData("transfer/"+testChannel+"/uatom", sent.token.Amount.String(), sent.sender.String(), sent.receiver)
+	data.Memo = sent.memo
+	return channeltypes.NewPacket(data.GetBytes(), sent.sequence, sent.port, sent.channel, "transfer", "channel-9", clienttypes.ZeroHeight(), sent.timeout)
+}
+
+func (suite *MiddlewareTestSuite) TestOnRecvPacket() {
+	intermediary := types.DeriveIntermediary(testChannel, testSender)
+
+	testCases := []struct {
+		name      string
+		memo      string
+		malleate  func()
+		expAck    bool
+		expPass   bool
+		expSent   bool
+		receiver  string
+		expInMemo string
+	}{
+		{"no memo is passed through", "", func() {}, true, true, false, testReceiver, ""},
+		{"memo without forward is passed through", `{"wasm":{}}`, func() {}, true, true, false, testReceiver, `{"wasm":{}}`},
+		{"invalid forward metadata", `{"forward":{"receiver":"osmo1receiver","port":"transfer"}}`, func() {}, true, false, false, "", ""},
+		{"forward", suite.forwardMemo(2), func() {}, false, false, true, intermediary.String(), ""},
+		{"transfer module fails", suite.forwardMemo(2), func() { suite.app.fail = true }, true, false, false, "", ""},
+		{"forward send fails", suite.forwardMemo(2), func() { suite.transfer.fail = true }, true, false, false, intermediary.String(), ""},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest()
+			tc.malleate()
+
+			ack := suite.middleware.OnRecvPacket(suite.ctx, suite.packet(tc.memo), nil)
+			if !tc.expAck {
+				suite.Require().Nil(ack)
+			} else {
+				suite.Require().NotNil(ack)
+				suite.Require().Equal(tc.expPass, ack.Success())
+			}
+			if tc.receiver != "" {
+				suite.Require().Equal(tc.receiver, suite.app.received.Receiver)
+				suite.Require().Equal(tc.expInMemo, suite.app.received.Memo)
+			}
+
+			if !tc.expSent {
+				suite.Require().Empty(suite.transfer.sent)
+				return
+			}
+			suite.Require().Len(suite.transfer.sent, 1)
+			sent := suite.transfer.sent[0]
+			suite.Require().Equal(testNextChannel, sent.channel)
+			suite.Require().Equal("osmo1receiver", sent.receiver)
+			suite.Require().Equal(intermediary, sent.sender)
+			suite.Require().Equal(testVoucher, sent.token.Denom)
+			suite.Require().Equal(`{"wasm":{"contract":"osmo1contract","msg":{}}}`, sent.memo)
+			suite.Require().Equal(uint64(suite.ctx.BlockTime().Add(time.Minute).UnixNano()), sent.timeout)
+			suite.Require().True(suite.bank.balances[intermediary.String()].IsZero())
+
+			inFlight, found := suite.keeper.GetInFlightPacket(suite.ctx, "transfer", testNextChannel, sent.sequence)
+			suite.Require().True(found)
+			suite.Require().Equal(suite.packet(tc.memo), inFlight.OriginalPacket)
+			suite.Require().Equal(uint8(2), inFlight.RetriesRemaining)
+		})
+	}
+}
+
+func (suite *MiddlewareTestSuite) TestOnAcknowledgementPacket() {
+	intermediary := types.DeriveIntermediary(testChannel, testSender)
+
+	testCases := []struct {
+		name    string
+		ack     channeltypes.Acknowledgement
+		expPass bool
+	}{
+		{"success", channeltypes.NewResultAcknowledgement([]byte{1}), true},
+		{"error is passed back and the tokens are reverted", channeltypes.NewErrorAcknowledgement("failed"), false},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest()
+			original := suite.packet(suite.forwardMemo(0))
+			suite.Require().Nil(suite.middleware.OnRecvPacket(suite.ctx, original, nil))
+			forwarded := suite.forwarded()
+
+			err := suite.middleware.OnAcknowledgementPacket(suite.ctx, forwarded, tc.ack.Acknowledgement(), nil)
+			suite.Require().NoError(err)
+			suite.Require().True(suite.app.acked)
+
+			suite.Require().Equal(original, suite.ics4.packet)
+			suite.Require().Equal(tc.expPass, suite.ics4.ack.Success())
+			_, found := suite.keeper.GetInFlightPacket(suite.ctx, forwarded.SourcePort, forwarded.SourceChannel, forwarded.Sequence)
+			suite.Require().False(found)
+			// the vouchers refunded to the intermediary are burned on failure
+			suite.Require().True(suite.bank.balances[intermediary.String()].IsZero())
+			suite.Require().Equal(!tc.expPass, suite.bank.burned)
+		})
+	}
+
+	suite.Run("packet not forwarded", func() {
+		suite.SetupTest()
+		packet := suite.packet("")
+		err := suite.middleware.OnAcknowledgementPacket(suite.ctx, packet, channeltypes.NewResultAcknowledgement([]byte{1}).Acknowledgement(), nil)
+		suite.Require().NoError(err)
+		suite.Require().Nil(suite.ics4.ack)
+	})
+}
+
+func (suite *MiddlewareTestSuite) TestOnTimeoutPacket() {
+	original := suite.packet(suite.forwardMemo(1))
+	suite.Require().Nil(suite.middleware.OnRecvPacket(suite.ctx, original, nil))
+
+	// the first timeout resends the packet
+	first := suite.forwarded()
+	suite.Require().NoError(suite.middleware.OnTimeoutPacket(suite.ctx, first, nil))
+	suite.Require().Len(suite.transfer.sent, 2)
+	suite.Require().Nil(suite.ics4.ack)
+	second := suite.forwarded()
+	suite.Require().Equal(first.GetData(), second.GetData())
+	inFlight, found := suite.keeper.GetInFlightPacket(suite.ctx, second.SourcePort, second.SourceChannel, second.Sequence)
+	suite.Require().True(found)
+	suite.Require().Equal(uint8(0), inFlight.RetriesRemaining)
+
+	// no retries remain, the original packet fails
+	suite.Require().NoError(suite.middleware.OnTimeoutPacket(suite.ctx, second, nil))
+	suite.Require().Len(suite.transfer.sent, 2)
+	suite.Require().Equal(original, suite.ics4.packet)
+	suite.Require().False(suite.ics4.ack.Success())
+	suite.Require().True(suite.bank.burned)
+}
+
+type mockTransferModule struct {
+	porttypes.IBCModule
+	bank     *mockBankKeeper
+	fail     bool
+	acked    bool
+	received transfertypes.FungibleTokenPacketData
+}
+
+// OnRecvPacket credits the receiver with the vouchers like the transfer module
+func (m *mockTransferModule) OnRecvPacket(_ sdk.Context, packet channeltypes.Packet, _ sdk.AccAddress) exported.Acknowledgement {
+	if m.fail {
+		return channeltypes.NewErrorAcknowledgement("transfer failed")
+	}
+	if err := transfertypes.ModuleCdc.UnmarshalJSON(packet.GetData(), &m.received); err != nil {
+		return channeltypes.NewErrorAcknowledgement(err.Error())
+	}
+	token, _, err := types.ReceivedToken(packet, m.received)
+	if err != nil {
+		return channeltypes.NewErrorAcknowledgement(err.Error())
+	}
+	receiver, err := sdk.AccAddressFromBech32(m.received.Receiver)
+	if err == nil {
+		m.bank.balances[receiver.String()] = m.bank.balances[receiver.String()].Add(token.ToCoin())
+	}
+	return channeltypes.NewResultAcknowledgement([]byte{1})
+}
+
+// OnAcknowledgementPacket refunds the sender of the forwarded packet on an error acknowledgement
+func (m *mockTransferModule) OnAcknowledgementPacket(_ sdk.Context, packet channeltypes.Packet, acknowledgement []byte, _ sdk.AccAddress) error {
+	m.acked = true
+	var ack channeltypes.Acknowledgement
+	if err := channeltypes.SubModuleCdc.UnmarshalJSON(acknowledgement, &ack); err != nil {
+		return err
+	}
+	if !ack.Success() {
+		m.refund(packet)
+	}
+	return nil
+}
+
+func (m *mockTransferModule) OnTimeoutPacket(_ sdk.Context, packet channeltypes.Packet, _ sdk.AccAddress) error {
+	m.refund(packet)
+	return nil
+}
+
+func (m *mockTransferModule) refund(packet channeltypes.Packet) {
+	var data transfertypes.FungibleTokenPacketData
+	transfertypes.ModuleCdc.MustUnmarshalJSON(packet.GetData(), &data)
+	amount, _ := sdk.NewIntFromString(data.Amount)
+	token := sdk.CoinAdapter{Denom: transfertypes.ParseDenomTrace(data.Denom).IBCDenom(), Amount: amount}
+	sender, _ := sdk.AccAddressFromBech32(data.Sender)
+	m.bank.balances[sender.String()] = m.bank.balances[sender.String()].Add(token.ToCoin())
+}
+
+type sentTransfer struct {
+	port, channel, receiver, memo string
+	sender                        sdk.AccAddress
+	token                         sdk.CoinAdapter
+	timeout                       uint64
+	sequence                      uint64
+}
+
+type mockTransferKeeper struct {
+	bank *mockBankKeeper
+	fail bool
+	sent []sentTransfer
+}
+
+func (m *mockTransferKeeper) SendTransferWithMemo(
+	_ sdk.Context, sourcePort, sourceChannel string, token sdk.CoinAdapter, sender sdk.AccAddress,
+	receiver string, _ clienttypes.Height, timeoutTimestamp uint64, memo string,
+) (uint64, error) {
+	if m.fail {
+		return 0, errors.New("send failed")
+	}
+	balance, negative := m.bank.balances[sender.String()].SafeSub(sdk.NewCoins(token.ToCoin()))
+	if negative {
+		return 0, errors.New("insufficient funds")
+	}
+	m.bank.balances[sender.String()] = balance
+	sequence := uint64(len(m.sent) + 1)
+	m.sent = append(m.sent, sentTransfer{
+		port: sourcePort, channel: sourceChannel, receiver: receiver, memo: memo,
+		sender: sender, token: token, timeout: timeoutTimestamp, sequence: sequence,
+	})
+	return sequence, nil
+}
+
+type mockBankKeeper struct {
+	balances map[string]sdk.Coins
+	burned   bool
+}
+
+func (m *mockBankKeeper) SendCoins(_ sdk.Context, from sdk.AccAddress, to sdk.AccAddress, amt sdk.Coins) error {
+	balance, negative := m.balances[from.String()].SafeSub(amt)
+	if negative {
+		return errors.New("insufficient funds")
+	}
+	m.balances[from.String()] = balance
+	m.balances[to.String()] = m.balances[to.String()].Add(amt...)
+	return nil
+}
+
+func (m *mockBankKeeper) SendCoinsFromAccountToModule(ctx sdk.Context, from sdk.AccAddress, module string, amt sdk.Coins) error {
+	balance, negative := m.balances[from.String()].SafeSub(amt)
+	if negative {
+		return errors.New("insufficient funds")
+	}
+	m.balances[from.String()] = balance
+	m.balances[module] = m.balances[module].Add(amt...)
+	return nil
+}
+
+func (m *mockBankKeeper) BurnCoins(_ sdk.Context, module string, amt sdk.Coins) error {
+	balance, negative := m.balances[module].SafeSub(amt)
+	if negative {
+		return errors.New("insufficient funds")
+	}
+	m.balances[module] = balance
+	m.burned = true
+	return nil
+}
+
+type mockScopedKeeper struct{}
+
+func (mockScopedKeeper) GetCapability(sdk.Context, string) (*capabilitytypes.Capability, bool) {
+	return &capabilitytypes.Capability{}, true
+}
+
+type mockICS4Wrapper struct {
+	porttypes.ICS4Wrapper
+	packet exported.PacketI
+	ack    exported.Acknowledgement
+}
+
+func (m *mockICS4Wrapper) WriteAcknowledgement(_ sdk.Context, _ *capabilitytypes.Capability, packet exported.PacketI, ack exported.Acknowledgement) error {
+	m.packet, m.ack = packet, ack
+	return nil
+}
@@ -0,0 +1,231 @@
+package keeper
+
+import (
+	"fmt"
+	"strconv"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	"github.com/okex/exchain/libs/ibc-go/modules/apps/packet-forward/types"
+	transfertypes "github.com/okex/exchain/libs/ibc-go/modules/apps/transfer/types"
+	clienttypes "github.com/okex/exchain/libs/ibc-go/modules/core/02-client/types"
+	channeltypes "github.com/okex/exchain/libs/ibc-go/modules/core/04-channel/types"
+	porttypes "github.com/okex/exchain/libs/ibc-go/modules/core/05-port/types"
+	host "github.com/okex/exchain/libs/ibc-go/modules/core/24-host"
+	"github.com/okex/exchain/libs/ibc-go/modules/core/exported"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+)
+
+// Keeper defines the packet forward middleware keeper
+type Keeper struct {
+	storeKey sdk.StoreKey
+
+	transferKeeper types.TransferKeeper
+	bankKeeper     types.BankKeeper
+	scopedKeeper   types.ScopedKeeper
+	ics4Wrapper    porttypes.ICS4Wrapper
+}
+
+// NewKeeper creates a new packet forward middleware Keeper instance.
+// The ics4 wrapper writes the asynchronous acknowledgements of the forwarded packets, the scoped keeper
+// must be the one of the transfer module which owns the channel capabilities.
+func NewKeeper(
+	key sdk.StoreKey, transferKeeper types.TransferKeeper, bankKeeper types.BankKeeper,
+	scopedKeeper types.ScopedKeeper, ics4Wrapper porttypes.ICS4Wrapper,
+) Keeper {
+	return Keeper{
+		storeKey:       key,
+		transferKeeper: transferKeeper,
+		bankKeeper:     bankKeeper,
+		scopedKeeper:   scopedKeeper,
+		ics4Wrapper:    ics4Wrapper,
+	}
+}
+
+// Logger returns a module-specific logger.
+func (k Keeper) Logger(ctx sdk.Context) log.Logger {
+	return ctx.Logger().With("module", "x/"+host.ModuleName+"-"+types.ModuleName)
+}
+
+// SetInFlightPacket stores the packet forwarded with the given sequence until it is acknowledged or timed out
+func (k Keeper) SetInFlightPacket(ctx sdk.Context, portID, channelID string, sequence uint64, packet types.InFlightPacket) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.KeyInFlightPacket(portID, channelID, sequence), types.ModuleCdc.MustMarshalBinaryBare(packet))
+}
+
+// GetInFlightPacket returns the packet forwarded with the given sequence
+func (k Keeper) GetInFlightPacket(ctx sdk.Context, portID, channelID string, sequence uint64) (types.InFlightPacket, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.KeyInFlightPacket(portID, channelID, sequence))
+	if bz == nil {
+		return types.InFlightPacket{}, false
+	}
+	var packet types.InFlightPacket
+	types.ModuleCdc.MustUnmarshalBinaryBare(bz, &packet)
+	return packet, true
+}
+
+// DeleteInFlightPacket removes the packet forwarded with the given sequence
+func (k Keeper) DeleteInFlightPacket(ctx sdk.Context, portID, channelID string, sequence uint64) {
+	ctx.KVStore(k.storeKey).Delete(types.KeyInFlightPacket(portID, channelID, sequence))
+}
+
+// ForwardTransferPacket sends the tokens received by the intermediary for the original packet to the next chain.
+// The acknowledgement of the original packet is written once the forwarded packet is acknowledged.
+func (k Keeper) ForwardTransferPacket(
+	ctx sdk.Context, original channeltypes.Packet, intermediary sdk.AccAddress,
+	token sdk.CoinAdapter, metadata types.ForwardMetadata,
+) error {
+	memo, err := metadata.NextMemo()
+	if err != nil {
+		return err
+	}
+	inFlight := types.NewInFlightPacket(original, intermediary.String(), memo, int64(metadata.GetTimeout()), metadata.GetRetries())
+	return k.sendForward(ctx, metadata.Port, metadata.Channel, metadata.Receiver, token, inFlight)
+}
+
+// OnForwardedPacketAck writes the acknowledgement of the original packet once the forwarded packet is acknowledged.
+// On an error acknowledgement the transfer module already refunded the intermediary, the received tokens are
+// reverted and the error is passed back so that the sender chain refunds the sender.
+func (k Keeper) OnForwardedPacketAck(ctx sdk.Context, packet channeltypes.Packet, ack channeltypes.Acknowledgement) error {
+	inFlight, found := k.GetInFlightPacket(ctx, packet.GetSourcePort(), packet.GetSourceChannel(), packet.GetSequence())
+	if !found {
+		return nil
+	}
+	k.DeleteInFlightPacket(ctx, packet.GetSourcePort(), packet.GetSourceChannel(), packet.GetSequence())
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypePacketForwardAck,
+		sdk.NewAttribute(types.AttributeKeyChannel, packet.GetSourceChannel()),
+		sdk.NewAttribute(types.AttributeKeySequence, strconv.FormatUint(packet.GetSequence(), 10)),
+		sdk.NewAttribute(types.AttributeKeyAckSuccess, strconv.FormatBool(ack.Success())),
+	))
+
+	if ack.Success() {
+		return k.writeAcknowledgement(ctx, inFlight.OriginalPacket, channeltypes.NewResultAcknowledgement([]byte{byte(1)}))
+	}
+	return k.failInFlightPacket(ctx, inFlight, sdkerrors.Wrap(types.ErrForwardFailed, ack.GetError()))
+}
+
+// OnForwardedPacketTimeout resends the timed out forwarded packet while retries remain, after which the
+// original packet fails as if the forwarded packet was acknowledged with an error.
+func (k Keeper) OnForwardedPacketTimeout(ctx sdk.Context, packet channeltypes.Packet, data transfertypes.FungibleTokenPacketData) error {
+	inFlight, found := k.GetInFlightPacket(ctx, packet.GetSourcePort(), packet.GetSourceChannel(), packet.GetSequence())
+	if !found {
+		return nil
+	}
+	k.DeleteInFlightPacket(ctx, packet.GetSourcePort(), packet.GetSourceChannel(), packet.GetSequence())
+
+	reason := sdkerrors.Wrapf(types.ErrForwardFailed, "packet timed out on channel %s", packet.GetSourceChannel())
+	if inFlight.RetriesRemaining > 0 {
+		inFlight.RetriesRemaining--
+		amount, ok := sdk.NewIntFromString(data.Amount)
+		if !ok {
+			return sdkerrors.Wrapf(transfertypes.ErrInvalidAmount, "unable to parse transfer amount (%s) into sdk.Int", data.Amount)
+		}
+		// the transfer module refunded the intermediary with the denomination it sent
+		token := sdk.CoinAdapter{Denom: transfertypes.ParseDenomTrace(data.Denom).IBCDenom(), Amount: amount}
+
+		cacheCtx, writeCache := ctx.CacheContext()
+		err := k.sendForward(cacheCtx, packet.GetSourcePort(), packet.GetSourceChannel(), data.Receiver, token, inFlight)
+		if err == nil {
+			writeCache()
+			ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+			ctx.EventManager().EmitEvent(sdk.NewEvent(
+				types.EventTypePacketForwardRetry,
+				sdk.NewAttribute(types.AttributeKeyChannel, packet.GetSourceChannel()),
+				sdk.NewAttribute(types.AttributeKeySequence, strconv.FormatUint(packet.GetSequence(), 10)),
+				sdk.NewAttribute(types.AttributeKeyRetries, strconv.FormatUint(uint64(inFlight.RetriesRemaining), 10)),
+			))
+			return nil
+		}
+		reason = sdkerrors.Wrapf(types.ErrForwardFailed, "failed to retry the timed out packet: %s", err)
+	}
+	return k.failInFlightPacket(ctx, inFlight, reason)
+}
+
+func (k Keeper) sendForward(
+	ctx sdk.Context, portID, channelID, receiver string,
+	token sdk.CoinAdapter, inFlight types.InFlightPacket,
+) error {
+	intermediary, err := sdk.AccAddressFromBech32(inFlight.Intermediary)
+	if err != nil {
+		return err
+	}
+	timeoutTimestamp := uint64(ctx.BlockTime().UnixNano() + inFlight.Timeout)
+	sequence, err := k.transferKeeper.SendTransferWithMemo(
+		ctx, portID, channelID, token, intermediary, receiver,
+		clienttypes.ZeroHeight(), timeoutTimestamp, inFlight.Memo,
+	)
+	if err != nil {
+		return sdkerrors.Wrap(types.ErrForwardTransfer, err.Error())
+	}
+	k.SetInFlightPacket(ctx, portID, channelID, sequence, inFlight)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypePacketForward,
+		sdk.NewAttribute(types.AttributeKeyReceiver, receiver),
+		sdk.NewAttribute(types.AttributeKeyPort, portID),
+		sdk.NewAttribute(types.AttributeKeyChannel, channelID),
+		sdk.NewAttribute(types.AttributeKeySequence, strconv.FormatUint(sequence, 10)),
+		sdk.NewAttribute(types.AttributeKeySrcChannel, inFlight.OriginalPacket.GetDestChannel()),
+		sdk.NewAttribute(types.AttributeKeySrcSequence, strconv.FormatUint(inFlight.OriginalPacket.GetSequence(), 10)),
+	))
+	return nil
+}
+
+// failInFlightPacket reverts the tokens received for the original packet and acknowledges it with the error,
+// the sender chain then refunds the sender, or fails its own in-flight packet for a multi-hop forward
+func (k Keeper) failInFlightPacket(ctx sdk.Context, inFlight types.InFlightPacket, reason error) error {
+	if err := k.revertReceivedTokens(ctx, inFlight); err != nil {
+		return err
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypePacketForwardFailed,
+		sdk.NewAttribute(types.AttributeKeySrcChannel, inFlight.OriginalPacket.GetDestChannel()),
+		sdk.NewAttribute(types.AttributeKeySrcSequence, strconv.FormatUint(inFlight.OriginalPacket.GetSequence(), 10)),
+		sdk.NewAttribute(types.AttributeKeyFailureReason, reason.Error()),
+	))
+	return k.writeAcknowledgement(ctx, inFlight.OriginalPacket, channeltypes.NewErrorAcknowledgementV4(reason))
+}
+
+// revertReceivedTokens undoes the receipt of the original packet by the transfer module: unescrowed tokens go
+// back to the escrow account and minted vouchers are burned
+func (k Keeper) revertReceivedTokens(ctx sdk.Context, inFlight types.InFlightPacket) error {
+	var data transfertypes.FungibleTokenPacketData
+	if err := transfertypes.ModuleCdc.UnmarshalJSON(inFlight.OriginalPacket.GetData(), &data); err != nil {
+		return sdkerrors.Wrap(types.ErrRefund, err.Error())
+	}
+	token, isSource, err := types.ReceivedToken(inFlight.OriginalPacket, data)
+	if err != nil {
+		return sdkerrors.Wrap(types.ErrRefund, err.Error())
+	}
+	intermediary, err := sdk.AccAddressFromBech32(inFlight.Intermediary)
+	if err != nil {
+		return sdkerrors.Wrap(types.ErrRefund, err.Error())
+	}
+
+	coins := sdk.NewCoins(token.ToCoin())
+	if isSource {
+		escrowAddress := transfertypes.GetEscrowAddress(inFlight.OriginalPacket.GetDestPort(), inFlight.OriginalPacket.GetDestChannel())
+		if err := k.bankKeeper.SendCoins(ctx, intermediary, escrowAddress, coins); err != nil {
+			return sdkerrors.Wrap(types.ErrRefund, err.Error())
+		}
+		return nil
+	}
+
+	if err := k.bankKeeper.SendCoinsFromAccountToModule(ctx, intermediary, transfertypes.ModuleName, coins); err != nil {
+		return sdkerrors.Wrap(types.ErrRefund, err.Error())
+	}
+	if err := k.bankKeeper.BurnCoins(ctx, transfertypes.ModuleName, coins); err != nil {
+		panic(fmt.Sprintf("cannot burn coins after a successful send to a module account: %v", err))
+	}
+	return nil
+}
+
+func (k Keeper) writeAcknowledgement(ctx sdk.Context, packet channeltypes.Packet, ack exported.Acknowledgement) error {
+	chanCap, ok := k.scopedKeeper.GetCapability(ctx, host.ChannelCapabilityPath(packet.GetDestPort(), packet.GetDestChannel()))
+	if !ok {
+		return sdkerrors.Wrap(channeltypes.ErrChannelCapabilityNotFound, "module does not own channel capability")
+	}
+	return k.ics4Wrapper.WriteAcknowledgement(ctx, chanCap, packet, ack)
+}
@@ -0,0 +1,115 @@
+package packetforward
+
+import (
+	"encoding/json"
+
+	"github.com/gorilla/mux"
+	"github.com/spf13/cobra"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/client/context"
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/types/module"
+	"github.com/okex/exchain/libs/cosmos-sdk/types/upgrade"
+	"github.com/okex/exchain/libs/ibc-go/modules/apps/packet-forward/keeper"
+	"github.com/okex/exchain/libs/ibc-go/modules/apps/packet-forward/types"
+	"github.com/okex/exchain/libs/ibc-go/modules/core/base"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+)
+
+var (
+	_ module.AppModule      = AppModule{}
+	_ module.AppModuleBasic = AppModuleBasic{}
+	_ upgrade.UpgradeModule = AppModule{}
+)
+
+// AppModuleBasic is the packet forward middleware AppModuleBasic
+type AppModuleBasic struct{}
+
+// Name implements AppModuleBasic interface
+func (AppModuleBasic) Name() string {
+	return types.ModuleName
+}
+
+// RegisterCodec implements AppModuleBasic interface
+func (AppModuleBasic) RegisterCodec(*codec.Codec) {}
+
+// DefaultGenesis returns nil, the middleware has no genesis state
+func (AppModuleBasic) DefaultGenesis() json.RawMessage {
+	return nil
+}
+
+// ValidateGenesis implements AppModuleBasic interface
+func (AppModuleBasic) ValidateGenesis(json.RawMessage) error {
+	return nil
+}
+
+// RegisterRESTRoutes implements AppModuleBasic interface
+func (AppModuleBasic) RegisterRESTRoutes(context.CLIContext, *mux.Router) {}
+
+// GetTxCmd implements AppModuleBasic interface
+func (AppModuleBasic) GetTxCmd(*codec.Codec) *cobra.Command {
+	return nil
+}
+
+// GetQueryCmd implements AppModuleBasic interface
+func (AppModuleBasic) GetQueryCmd(*codec.Codec) *cobra.Command {
+	return nil
+}
+
+// AppModule is the packet forward middleware AppModule, it only owns the store of the in-flight packets
+type AppModule struct {
+	AppModuleBasic
+	*base.BaseIBCUpgradeModule
+	keeper keeper.Keeper
+}
+
+// NewAppModule creates a new packet forward middleware AppModule
+func NewAppModule(k keeper.Keeper) AppModule {
+	m := AppModule{
+		keeper: k,
+	}
+	m.BaseIBCUpgradeModule = base.NewBaseIBCUpgradeModule(m)
+	return m
+}
+
+// RegisterInvariants implements AppModule interface
+func (AppModule) RegisterInvariants(sdk.InvariantRegistry) {}
+
+// Route returns an empty route, the middleware has no messages
+func (AppModule) Route() string {
+	return ""
+}
+
+// NewHandler implements AppModule interface
+func (AppModule) NewHandler() sdk.Handler {
+	return nil
+}
+
+// QuerierRoute implements AppModule interface
+func (AppModule) QuerierRoute() string {
+	return ""
+}
+
+// NewQuerierHandler implements AppModule interface
+func (AppModule) NewQuerierHandler() sdk.Querier {
+	return nil
+}
+
+// InitGenesis implements AppModule interface
+func (AppModule) InitGenesis(sdk.Context, json.RawMessage) []abci.ValidatorUpdate {
+	return nil
+}
+
+// ExportGenesis implements AppModule interface
+func (AppModule) ExportGenesis(sdk.Context) json.RawMessage {
+	return nil
+}
+
+// BeginBlock implements AppModule interface
+func (AppModule) BeginBlock(sdk.Context, abci.RequestBeginBlock) {}
+
+// EndBlock implements AppModule interface
+func (AppModule) EndBlock(sdk.Context, abci.RequestEndBlock) []abci.ValidatorUpdate {
+	return []abci.ValidatorUpdate{}
+}
@@ -0,0 +1,12 @@
+package types
+
+import (
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+)
+
+// ModuleCdc is the amino codec of the packet forward middleware, used to encode the in-flight packets
+var ModuleCdc = codec.New()
+
+func init() {
+	ModuleCdc.Seal()
+}
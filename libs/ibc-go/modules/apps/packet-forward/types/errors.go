@@ -0,0 +1,13 @@
+package types
+
+import (
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+)
+
+// packet forward middleware sentinel errors
+var (
+	ErrInvalidForwardMetadata = sdkerrors.Register(ModuleName, 2, "invalid forward metadata")
+	ErrForwardTransfer        = sdkerrors.Register(ModuleName, 3, "failed to forward the transfer")
+	ErrForwardFailed          = sdkerrors.Register(ModuleName, 4, "forwarded packet failed on the next chain")
+	ErrRefund                 = sdkerrors.Register(ModuleName, 5, "failed to refund the forwarded tokens")
+)
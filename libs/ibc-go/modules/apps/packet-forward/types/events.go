@@ -0,0 +1,19 @@
+package types
+
+// packet forward middleware events
+const (
+	EventTypePacketForward       = "packet_forward"
+	EventTypePacketForwardRetry  = "packet_forward_retry"
+	EventTypePacketForwardAck    = "packet_forward_ack"
+	EventTypePacketForwardFailed = "packet_forward_failed"
+
+	AttributeKeyReceiver      = "receiver"
+	AttributeKeyPort          = "port"
+	AttributeKeyChannel       = "channel"
+	AttributeKeySequence      = "sequence"
+	AttributeKeySrcChannel    = "src_channel"
+	AttributeKeySrcSequence   = "src_sequence"
+	AttributeKeyRetries       = "retries_remaining"
+	AttributeKeyAckSuccess    = "success"
+	AttributeKeyFailureReason = "failure_reason"
+)
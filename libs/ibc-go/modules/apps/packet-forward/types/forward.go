@@ -0,0 +1,161 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	transfertypes "github.com/okex/exchain/libs/ibc-go/modules/apps/transfer/types"
+	channeltypes "github.com/okex/exchain/libs/ibc-go/modules/core/04-channel/types"
+	host "github.com/okex/exchain/libs/ibc-go/modules/core/24-host"
+	"github.com/okex/exchain/libs/tendermint/crypto"
+)
+
+// PacketMetadata is the memo of an ics20 packet that must be forwarded to the next chain
+type PacketMetadata struct {
+	Forward *ForwardMetadata `json:"forward"`
+}
+
+// ForwardMetadata names the receiver on the next chain and the channel the tokens are forwarded over.
+// Next is the memo of the forwarded packet, which may carry the forward metadata of a further hop.
+type ForwardMetadata struct {
+	Receiver string          `json:"receiver"`
+	Port     string          `json:"port"`
+	Channel  string          `json:"channel"`
+	Timeout  Duration        `json:"timeout,omitempty"`
+	Retries  *uint8          `json:"retries,omitempty"`
+	Next     json.RawMessage `json:"next,omitempty"`
+}
+
+// Duration is a time.Duration read either from a duration string such as "10m" or from nanoseconds
+type Duration time.Duration
+
+// MarshalJSON implements json.Marshaler
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (d *Duration) UnmarshalJSON(bz []byte) error {
+	var nanos int64
+	if err := json.Unmarshal(bz, &nanos); err == nil {
+		*d = Duration(nanos)
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(bz, &s); err != nil {
+		return err
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// ParseForwardMetadata returns the forward metadata of the memo, found is false if the memo does not ask for forwarding
+func ParseForwardMetadata(memo string) (m ForwardMetadata, found bool, err error) {
+	memo = strings.TrimSpace(memo)
+	if !strings.HasPrefix(memo, "{") {
+		return ForwardMetadata{}, false, nil
+	}
+	var metadata PacketMetadata
+	if err := json.Unmarshal([]byte(memo), &metadata); err != nil || metadata.Forward == nil {
+		return ForwardMetadata{}, false, nil
+	}
+	return *metadata.Forward, true, metadata.Forward.ValidateBasic()
+}
+
+// ValidateBasic checks the forward metadata
+func (m ForwardMetadata) ValidateBasic() error {
+	if strings.TrimSpace(m.Receiver) == "" {
+		return sdkerrors.Wrap(ErrInvalidForwardMetadata, "receiver cannot be blank")
+	}
+	if err := host.PortIdentifierValidator(m.Port); err != nil {
+		return sdkerrors.Wrapf(ErrInvalidForwardMetadata, "invalid port: %s", err)
+	}
+	if err := host.ChannelIdentifierValidator(m.Channel); err != nil {
+		return sdkerrors.Wrapf(ErrInvalidForwardMetadata, "invalid channel: %s", err)
+	}
+	if m.Timeout < 0 {
+		return sdkerrors.Wrap(ErrInvalidForwardMetadata, "timeout cannot be negative")
+	}
+	if _, err := m.NextMemo(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetTimeout returns the timeout of the forwarded packet
+func (m ForwardMetadata) GetTimeout() time.Duration {
+	if m.Timeout == 0 {
+		return DefaultForwardTimeout
+	}
+	return time.Duration(m.Timeout)
+}
+
+// GetRetries returns how many times the forwarded packet is resent on timeout
+func (m ForwardMetadata) GetRetries() uint8 {
+	if m.Retries == nil {
+		return DefaultRetriesOnTimeout
+	}
+	return *m.Retries
+}
+
+// NextMemo returns the memo of the forwarded packet, next may be given either as a json object or as a string
+func (m ForwardMetadata) NextMemo() (string, error) {
+	next := bytes.TrimSpace(m.Next)
+	if len(next) == 0 || bytes.Equal(next, []byte("null")) {
+		return "", nil
+	}
+	switch next[0] {
+	case '{':
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, next); err != nil {
+			return "", sdkerrors.Wrapf(ErrInvalidForwardMetadata, "invalid next: %s", err)
+		}
+		return buf.String(), nil
+	case '"':
+		var s string
+		if err := json.Unmarshal(next, &s); err != nil {
+			return "", sdkerrors.Wrapf(ErrInvalidForwardMetadata, "invalid next: %s", err)
+		}
+		return s, nil
+	default:
+		return "", sdkerrors.Wrap(ErrInvalidForwardMetadata, "next must be a json object or a string")
+	}
+}
+
+// DeriveIntermediary returns the account which receives the tokens on this chain before they are forwarded.
+// It is derived from the channel and the original sender so that nobody controls it.
+func DeriveIntermediary(channel, originalSender string) sdk.AccAddress {
+	return sdk.AccAddress(crypto.AddressHash([]byte(fmt.Sprintf("%s/%s/%s", ModuleName, channel, originalSender))))
+}
+
+// ReceivedToken returns the token the transfer module credited on this chain for the received packet, in the
+// form accepted by the transfer keeper. isSource is true if the token was unescrowed rather than minted as voucher.
+func ReceivedToken(packet channeltypes.Packet, data transfertypes.FungibleTokenPacketData) (token sdk.CoinAdapter, isSource bool, err error) {
+	amount, ok := sdk.NewIntFromString(data.Amount)
+	if !ok {
+		return sdk.CoinAdapter{}, false, sdkerrors.Wrapf(transfertypes.ErrInvalidAmount, "unable to parse transfer amount (%s) into sdk.Int", data.Amount)
+	}
+
+	var denom string
+	isSource = transfertypes.ReceiverChainIsSource(packet.GetSourcePort(), packet.GetSourceChannel(), data.Denom)
+	if isSource {
+		voucherPrefix := transfertypes.GetDenomPrefix(packet.GetSourcePort(), packet.GetSourceChannel())
+		denom = data.Denom[len(voucherPrefix):]
+		if trace := transfertypes.ParseDenomTrace(denom); trace.Path != "" {
+			denom = trace.IBCDenom()
+		}
+	} else {
+		prefixedDenom := transfertypes.GetDenomPrefix(packet.GetDestPort(), packet.GetDestChannel()) + data.Denom
+		denom = transfertypes.ParseDenomTrace(prefixedDenom).IBCDenom()
+	}
+	return sdk.CoinAdapter{Denom: denom, Amount: amount}, isSource, nil
+}
@@ -0,0 +1,58 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseForwardMetadata(t *testing.T) {
+	testCases := []struct {
+		name     string
+		memo     string
+		expFound bool
+		expPass  bool
+	}{
+		{"empty memo", "", false, true},
+		{"plain text memo", "hello", false, true},
+		{"memo without forward", `{"wasm":{"contract":"ex1"}}`, false, true},
+		{"forward", `{"forward":{"receiver":"osmo1receiver","port":"transfer","channel":"channel-1"}}`, true, true},
+		{"forward with options", `{"forward":{"receiver":"osmo1receiver","port":"transfer","channel":"channel-1","timeout":"10m","retries":2,"next":"{}"}}`, true, true},
+		{"missing receiver", `{"forward":{"port":"transfer","channel":"channel-1"}}`, true, false},
+		{"invalid channel", `{"forward":{"receiver":"osmo1receiver","port":"transfer","channel":"c"}}`, true, false},
+		{"invalid next", `{"forward":{"receiver":"osmo1receiver","port":"transfer","channel":"channel-1","next":1}}`, true, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, found, err := ParseForwardMetadata(tc.memo)
+			require.Equal(t, tc.expFound, found)
+			if tc.expPass {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestForwardMetadataDefaults(t *testing.T) {
+	m, found, err := ParseForwardMetadata(`{"forward":{"receiver":"r","port":"transfer","channel":"channel-1"}}`)
+	require.True(t, found)
+	require.NoError(t, err)
+	require.Equal(t, DefaultForwardTimeout, m.GetTimeout())
+	require.Equal(t, DefaultRetriesOnTimeout, m.GetRetries())
+	next, err := m.NextMemo()
+	require.NoError(t, err)
+	require.Empty(t, next)
+
+	m, _, err = ParseForwardMetadata(`{"forward":{"receiver":"r","port":"transfer","channel":"channel-1","timeout":60000000000,"retries":0,` +
+		`"next":{"forward": {"receiver":"r2","port":"transfer","channel":"channel-2"}}}}`)
+	require.NoError(t, err)
+	require.Equal(t, time.Minute, m.GetTimeout())
+	require.Equal(t, uint8(0), m.GetRetries())
+	next, err = m.NextMemo()
+	require.NoError(t, err)
+	require.Equal(t, `{"forward":{"receiver":"r2","port":"transfer","channel":"channel-2"}}`, next)
+}
@@ -0,0 +1,29 @@
+package types
+
+import (
+	channeltypes "github.com/okex/exchain/libs/ibc-go/modules/core/04-channel/types"
+)
+
+// InFlightPacket is a forwarded packet waiting for its acknowledgement. Once acknowledged, the acknowledgement
+// of the packet received by this chain is written, an error acknowledgement refunds the tokens on the sender chain.
+type InFlightPacket struct {
+	// the packet received by this chain
+	OriginalPacket channeltypes.Packet `json:"original_packet"`
+	// the intermediary account which sent the forwarded packet
+	Intermediary string `json:"intermediary"`
+	// the memo of the forwarded packet
+	Memo             string `json:"memo"`
+	Timeout          int64  `json:"timeout"`
+	RetriesRemaining uint8  `json:"retries_remaining"`
+}
+
+// NewInFlightPacket creates a new InFlightPacket instance
+func NewInFlightPacket(original channeltypes.Packet, intermediary, memo string, timeout int64, retries uint8) InFlightPacket {
+	return InFlightPacket{
+		OriginalPacket:   original,
+		Intermediary:     intermediary,
+		Memo:             memo,
+		Timeout:          timeout,
+		RetriesRemaining: retries,
+	}
+}
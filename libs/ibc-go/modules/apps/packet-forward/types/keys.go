@@ -0,0 +1,33 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	transfertypes "github.com/okex/exchain/libs/ibc-go/modules/apps/transfer/types"
+)
+
+const (
+	// ModuleName defines the packet forward middleware name
+	ModuleName = "packetforward"
+
+	// StoreKey is the store key string for the packet forward middleware
+	StoreKey = ModuleName
+
+	// InFlightPacketPrefix is the key prefix for the forwarded packets waiting for an acknowledgement
+	InFlightPacketPrefix = "inFlightPacket"
+)
+
+var (
+	// DefaultForwardTimeout is the timeout of a forwarded packet when the memo does not set one
+	DefaultForwardTimeout = time.Duration(transfertypes.DefaultRelativePacketTimeoutTimestamp)
+
+	// DefaultRetriesOnTimeout is the number of times a timed out forwarded packet is resent
+	// when the memo does not set the retries
+	DefaultRetriesOnTimeout uint8 = 3
+)
+
+// KeyInFlightPacket returns the key of the in-flight packet sent on the given port and channel with the given sequence
+func KeyInFlightPacket(portID, channelID string, sequence uint64) []byte {
+	return []byte(fmt.Sprintf("%s/%s/%s/%d", InFlightPacketPrefix, portID, channelID, sequence))
+}
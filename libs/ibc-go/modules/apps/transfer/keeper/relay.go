@@ -10,6 +10,7 @@ import (
 	clienttypes "github.com/okex/exchain/libs/ibc-go/modules/core/02-client/types"
 	channeltypes "github.com/okex/exchain/libs/ibc-go/modules/core/04-channel/types"
 	host "github.com/okex/exchain/libs/ibc-go/modules/core/24-host"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 )
 
 // SendTransfer handles transfer sending logic. There are 2 possible cases:
@@ -54,8 +55,29 @@ func (k Keeper) SendTransfer(
 	timeoutHeight clienttypes.Height,
 	timeoutTimestamp uint64,
 ) error {
+	_, err := k.SendTransferWithMemo(ctx, sourcePort, sourceChannel, adapterToken, sender, receiver, timeoutHeight, timeoutTimestamp, "")
+	return err
+}
+
+// SendTransferWithMemo behaves like SendTransfer but attaches the given memo to the
+// packet data and returns the sequence of the packet that was sent. It is used by
+// middlewares which need to track the outgoing packet, e.g. packet forwarding.
+func (k Keeper) SendTransferWithMemo(
+	ctx sdk.Context,
+	sourcePort,
+	sourceChannel string,
+	adapterToken sdk.CoinAdapter,
+	sender sdk.AccAddress,
+	receiver string,
+	timeoutHeight clienttypes.Height,
+	timeoutTimestamp uint64,
+	memo string,
+) (uint64, error) {
 	if !k.GetSendEnabled(ctx) {
-		return types.ErrSendDisabled
+		return 0, types.ErrSendDisabled
+	}
+	if len(memo) != 0 && !tmtypes.HigherThanJupiter(ctx.BlockHeight()) {
+		return 0, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "memo is not supported at height %d", ctx.BlockHeight())
 	}
 
 	transferAmountDec := sdk.NewDecFromIntWithPrec(adapterToken.Amount, sdk.Precision)
@@ -66,7 +88,7 @@ func (k Keeper) SendTransfer(
 	)
 	sourceChannelEnd, found := k.channelKeeper.GetChannel(ctx, sourcePort, sourceChannel)
 	if !found {
-		return sdkerrors.Wrapf(channeltypes.ErrChannelNotFound, "port ID (%s) channel ID (%s)", sourcePort, sourceChannel)
+		return 0, sdkerrors.Wrapf(channeltypes.ErrChannelNotFound, "port ID (%s) channel ID (%s)", sourcePort, sourceChannel)
 	}
 
 	destinationPort := sourceChannelEnd.GetCounterparty().GetPortID()
@@ -75,7 +97,7 @@ func (k Keeper) SendTransfer(
 	// get the next sequence
 	sequence, found := k.channelKeeper.GetNextSequenceSend(ctx, sourcePort, sourceChannel)
 	if !found {
-		return sdkerrors.Wrapf(
+		return 0, sdkerrors.Wrapf(
 			channeltypes.ErrSequenceSendNotFound,
 			"source port: %s, source channel: %s", sourcePort, sourceChannel,
 		)
@@ -85,7 +107,7 @@ func (k Keeper) SendTransfer(
 	// See spec for this logic: https://github.com/cosmos/ics/tree/master/spec/ics-020-fungible-token-transfer#packet-relay
 	channelCap, ok := k.scopedKeeper.GetCapability(ctx, host.ChannelCapabilityPath(sourcePort, sourceChannel))
 	if !ok {
-		return sdkerrors.Wrap(channeltypes.ErrChannelCapabilityNotFound, "module does not own channel capability")
+		return 0, sdkerrors.Wrap(channeltypes.ErrChannelCapabilityNotFound, "module does not own channel capability")
 	}
 
 	// NOTE: denomination and hex hash correctness checked during msg.ValidateBasic
@@ -97,7 +119,7 @@ func (k Keeper) SendTransfer(
 	if strings.HasPrefix(token.Denom, "ibc/") {
 		fullDenomPath, err = k.DenomPathFromHash(ctx, token.Denom)
 		if err != nil {
-			return err
+			return 0, err
 		}
 	}
 
@@ -117,14 +139,14 @@ func (k Keeper) SendTransfer(
 		if err := k.bankKeeper.SendCoins(
 			ctx, sender, escrowAddress, sdk.NewCoins(token),
 		); err != nil {
-			return err
+			return 0, err
 		}
 	} else {
 		// transfer the coins to the module account and burn them
 		if err := k.bankKeeper.SendCoinsFromAccountToModule(
 			ctx, sender, types.ModuleName, sdk.NewCoins(token),
 		); err != nil {
-			return err
+			return 0, err
 		}
 
 		if err := k.bankKeeper.BurnCoins(
@@ -139,6 +161,7 @@ func (k Keeper) SendTransfer(
 	packetData := types.NewFungibleTokenPacketData(
 		fullDenomPath, adapterToken.Amount.String(), sender.String(), receiver,
 	)
+	packetData.Memo = memo
 
 	packet := channeltypes.NewPacket(
 		packetData.GetBytes(),
@@ -152,10 +175,10 @@ func (k Keeper) SendTransfer(
 	)
 
 	if err := k.channelKeeper.SendPacket(ctx, channelCap, packet); err != nil {
-		return err
+		return 0, err
 	}
 
-	return k.CallAfterSendTransferHooks(ctx, sourcePort, sourceChannel, token, sender, receiver, isSource)
+	return sequence, k.CallAfterSendTransferHooks(ctx, sourcePort, sourceChannel, token, sender, receiver, isSource)
 }
 
 // OnRecvPacket processes a cross chain fungible token transfer. If the
@@ -6,6 +6,7 @@ import (
 
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 )
 
 var (
@@ -58,3 +59,16 @@ func (ftpd FungibleTokenPacketData) ValidateBasic() error {
 func (ftpd FungibleTokenPacketData) GetBytes() []byte {
 	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&ftpd))
 }
+
+// UnmarshalPacketData decodes the packet data received at the given height. The memo is
+// unknown to the packet data before the Jupiter height, it is dropped there as the former
+// decoder did.
+func UnmarshalPacketData(bz []byte, height int64, ftpd *FungibleTokenPacketData) error {
+	if err := ModuleCdc.UnmarshalJSON(bz, ftpd); err != nil {
+		return err
+	}
+	if !tmtypes.HigherThanJupiter(height) {
+		ftpd.Memo = ""
+	}
+	return nil
+}
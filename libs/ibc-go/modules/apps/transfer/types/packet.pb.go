@@ -34,6 +34,8 @@ type FungibleTokenPacketData struct {
 	Sender string `protobuf:"bytes,3,opt,name=sender,proto3" json:"sender,omitempty"`
 	// the recipient address on the destination chain
 	Receiver string `protobuf:"bytes,4,opt,name=receiver,proto3" json:"receiver,omitempty"`
+	// optional memo
+	Memo string `protobuf:"bytes,5,opt,name=memo,proto3" json:"memo,omitempty"`
 }
 
 func (m *FungibleTokenPacketData) Reset()         { *m = FungibleTokenPacketData{} }
@@ -97,6 +99,13 @@ func (m *FungibleTokenPacketData) GetReceiver() string {
 	return ""
 }
 
+func (m *FungibleTokenPacketData) GetMemo() string {
+	if m != nil {
+		return m.Memo
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*FungibleTokenPacketData)(nil), "ibc.applications.transfer.v2.FungibleTokenPacketData")
 }
@@ -106,22 +115,23 @@ func init() {
 }
 
 var fileDescriptor_653ca2ce9a5ca313 = []byte{
-	// 240 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0x8f, 0xbf, 0x4a, 0xc5, 0x30,
-	0x14, 0x87, 0x1b, 0xff, 0x5c, 0x34, 0x63, 0x11, 0x2d, 0x22, 0x41, 0x9c, 0x74, 0x30, 0x81, 0xeb,
-	0xe0, 0x2e, 0xe2, 0xac, 0xe2, 0xe4, 0x96, 0xa4, 0xc7, 0x1a, 0x6e, 0x93, 0x13, 0x92, 0xb4, 0x20,
-	0xbe, 0x84, 0x8f, 0xe5, 0x78, 0x47, 0x47, 0x69, 0x5f, 0x44, 0x9a, 0xaa, 0xdc, 0xf1, 0xfb, 0xce,
-	0xef, 0x0c, 0x1f, 0xbd, 0x30, 0x4a, 0x0b, 0xe9, 0x7d, 0x6b, 0xb4, 0x4c, 0x06, 0x5d, 0x14, 0x29,
-	0x48, 0x17, 0x5f, 0x20, 0x88, 0x7e, 0x29, 0xbc, 0xd4, 0x2b, 0x48, 0xdc, 0x07, 0x4c, 0x58, 0x9e,
-	0x18, 0xa5, 0xf9, 0xe6, 0x94, 0xff, 0x4d, 0x79, 0xbf, 0x3c, 0x7b, 0xa7, 0x47, 0x77, 0x9d, 0x6b,
-	0x8c, 0x6a, 0xe1, 0x09, 0x57, 0xe0, 0xee, 0xf3, 0xeb, 0xad, 0x4c, 0xb2, 0x3c, 0xa0, 0xbb, 0x35,
-	0x38, 0xb4, 0x15, 0x39, 0x25, 0xe7, 0xfb, 0x8f, 0x33, 0x94, 0x87, 0x74, 0x21, 0x2d, 0x76, 0x2e,
-	0x55, 0x5b, 0x59, 0xff, 0xd2, 0xe4, 0x23, 0xb8, 0x1a, 0x42, 0xb5, 0x3d, 0xfb, 0x99, 0xca, 0x63,
-	0xba, 0x17, 0x40, 0x83, 0xe9, 0x21, 0x54, 0x3b, 0xf9, 0xf2, 0xcf, 0x37, 0x0f, 0x9f, 0x03, 0x23,
-	0xeb, 0x81, 0x91, 0xef, 0x81, 0x91, 0x8f, 0x91, 0x15, 0xeb, 0x91, 0x15, 0x5f, 0x23, 0x2b, 0x9e,
-	0xaf, 0x1b, 0x93, 0x5e, 0x3b, 0xc5, 0x35, 0x5a, 0xa1, 0x31, 0x5a, 0x8c, 0xc2, 0x28, 0x7d, 0xd9,
-	0xe0, 0x94, 0x67, 0xb1, 0xee, 0x5a, 0x88, 0x53, 0xff, 0x46, 0x77, 0x7a, 0xf3, 0x10, 0xd5, 0x22,
-	0x47, 0x5f, 0xfd, 0x04, 0x00, 0x00, 0xff, 0xff, 0x9d, 0x75, 0xf2, 0x46, 0x21, 0x01, 0x00, 0x00,
+	// 249 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0x90, 0xb1, 0x4a, 0x04, 0x31,
+	0x10, 0x86, 0x2f, 0x7a, 0x77, 0x68, 0xca, 0x20, 0xba, 0x88, 0x04, 0xb1, 0xd2, 0xc2, 0x0d, 0x9c,
+	0x85, 0xbd, 0x88, 0xb5, 0x8a, 0x95, 0x5d, 0x92, 0x1d, 0xd7, 0x70, 0x9b, 0x4c, 0x48, 0xb2, 0x0b,
+	0x3e, 0x85, 0x3e, 0x96, 0xe5, 0x95, 0x96, 0xb2, 0xfb, 0x22, 0xb2, 0x59, 0x95, 0xeb, 0xe6, 0xfb,
+	0xe6, 0x9f, 0x62, 0x7e, 0x7a, 0x61, 0x94, 0x16, 0xd2, 0xfb, 0xc6, 0x68, 0x99, 0x0c, 0xba, 0x28,
+	0x52, 0x90, 0x2e, 0xbe, 0x40, 0x10, 0xdd, 0x4a, 0x78, 0xa9, 0xd7, 0x90, 0x4a, 0x1f, 0x30, 0x21,
+	0x3b, 0x31, 0x4a, 0x97, 0xdb, 0xd1, 0xf2, 0x2f, 0x5a, 0x76, 0xab, 0xb3, 0x77, 0x42, 0x8f, 0xee,
+	0x5a, 0x57, 0x1b, 0xd5, 0xc0, 0x13, 0xae, 0xc1, 0xdd, 0xe7, 0xdb, 0x5b, 0x99, 0x24, 0x3b, 0xa0,
+	0x8b, 0x0a, 0x1c, 0xda, 0x82, 0x9c, 0x92, 0xf3, 0xfd, 0xc7, 0x09, 0xd8, 0x21, 0x5d, 0x4a, 0x8b,
+	0xad, 0x4b, 0xc5, 0x4e, 0xd6, 0xbf, 0x34, 0xfa, 0x08, 0xae, 0x82, 0x50, 0xec, 0x4e, 0x7e, 0x22,
+	0x76, 0x4c, 0xf7, 0x02, 0x68, 0x30, 0x1d, 0x84, 0x62, 0x9e, 0x37, 0xff, 0xcc, 0x18, 0x9d, 0x5b,
+	0xb0, 0x58, 0x2c, 0xb2, 0xcf, 0xf3, 0xcd, 0xc3, 0x67, 0xcf, 0xc9, 0xa6, 0xe7, 0xe4, 0xbb, 0xe7,
+	0xe4, 0x63, 0xe0, 0xb3, 0xcd, 0xc0, 0x67, 0x5f, 0x03, 0x9f, 0x3d, 0x5f, 0xd7, 0x26, 0xbd, 0xb6,
+	0xaa, 0xd4, 0x68, 0x85, 0xc6, 0x68, 0x31, 0x0a, 0xa3, 0xf4, 0x65, 0x8d, 0xe3, 0xcf, 0x16, 0xab,
+	0xb6, 0x81, 0x38, 0x96, 0xb2, 0x55, 0x46, 0x7a, 0xf3, 0x10, 0xd5, 0x32, 0x37, 0x71, 0xf5, 0x33,
+	0x00, 0x92, 0xb8, 0xf1, 0x30, 0x36, 0x01, 0x00, 0x00,
 }
 
 func (m *FungibleTokenPacketData) Marshal() (dAtA []byte, err error) {
@@ -144,6 +154,13 @@ func (m *FungibleTokenPacketData) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	_ = i
 	var l int
 	_ = l
+	if len(m.Memo) > 0 {
+		i -= len(m.Memo)
+		copy(dAtA[i:], m.Memo)
+		i = encodeVarintPacket(dAtA, i, uint64(len(m.Memo)))
+		i--
+		dAtA[i] = 0x2a
+	}
 	if len(m.Receiver) > 0 {
 		i -= len(m.Receiver)
 		copy(dAtA[i:], m.Receiver)
@@ -208,6 +225,10 @@ func (m *FungibleTokenPacketData) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovPacket(uint64(l))
 	}
+	l = len(m.Memo)
+	if l > 0 {
+		n += 1 + l + sovPacket(uint64(l))
+	}
 	return n
 }
 
@@ -374,6 +395,38 @@ func (m *FungibleTokenPacketData) Unmarshal(dAtA []byte) error {
 			}
 			m.Receiver = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Memo", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPacket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPacket
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPacket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Memo = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPacket(dAtA[iNdEx:])
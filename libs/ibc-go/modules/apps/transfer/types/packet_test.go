@@ -3,6 +3,7 @@ package types
 import (
 	"testing"
 
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 	"github.com/stretchr/testify/require"
 )
 
@@ -40,3 +41,20 @@ func TestFungibleTokenPacketDataValidateBasic(t *testing.T) {
 		}
 	}
 }
+
+// TestUnmarshalPacketData tests the memo is only decoded from the Jupiter height
+func TestUnmarshalPacketData(t *testing.T) {
+	tmtypes.UnittestOnlySetMilestoneJupiterHeight(10)
+	defer tmtypes.UnittestOnlySetMilestoneJupiterHeight(0)
+
+	packetData := NewFungibleTokenPacketData(denom, amount, addr1.String(), addr2)
+	packetData.Memo = "memo"
+
+	var data FungibleTokenPacketData
+	require.NoError(t, UnmarshalPacketData(packetData.GetBytes(), 9, &data))
+	require.Equal(t, "", data.Memo)
+	require.Equal(t, amount, data.Amount)
+
+	require.NoError(t, UnmarshalPacketData(packetData.GetBytes(), 10, &data))
+	require.Equal(t, packetData, data)
+}
@@ -30,7 +30,6 @@ eg, L = latency = 0.1s
 const (
 	requestIntervalMS         = 2
 	maxTotalRequesters        = 600
-	maxPendingRequests        = maxTotalRequesters
 	maxPendingRequestsPerPeer = 20
 
 	// Minimum recv rate to ensure we're receiving blocks from a peer fast
@@ -74,6 +73,9 @@ type BlockPool struct {
 	// atomic
 	numPending int32 // number of requests pending assignment or block response
 
+	// maximum number of requesters (the download window ahead of pool.height)
+	maxRequesters int
+
 	requestsCh chan<- BlockRequest
 	errorsCh   chan<- peerError
 }
@@ -88,6 +90,8 @@ func NewBlockPool(start int64, requestsCh chan<- BlockRequest, errorsCh chan<- p
 		height:     start,
 		numPending: 0,
 
+		maxRequesters: maxTotalRequesters,
+
 		requestsCh: requestsCh,
 		errorsCh:   errorsCh,
 	}
@@ -102,6 +106,17 @@ func (pool *BlockPool) SetHeight(height int64) {
 	pool.height = height
 }
 
+// SetMaxRequesters sets the maximum number of blocks requested ahead of
+// pool.height. It must be called before the pool is started.
+func (pool *BlockPool) SetMaxRequesters(max int) {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+
+	if max > 0 {
+		pool.maxRequesters = max
+	}
+}
+
 // OnStart implements service.Service by spawning requesters routine and recording
 // pool's start time.
 func (pool *BlockPool) OnStart() error {
@@ -133,12 +148,12 @@ func (pool *BlockPool) makeRequestersRoutine() {
 
 		_, numPending, lenRequesters := pool.GetStatus()
 		switch {
-		case numPending >= maxPendingRequests:
+		case numPending >= int32(pool.maxRequesters):
 			// sleep for a bit.
 			time.Sleep(requestIntervalMS * time.Millisecond)
 			// check for timed out peers
 			pool.removeTimedoutPeers()
-		case lenRequesters >= maxTotalRequesters:
+		case lenRequesters >= pool.maxRequesters:
 			// sleep for a bit.
 			time.Sleep(requestIntervalMS * time.Millisecond)
 			// check for timed out peers
@@ -224,6 +239,27 @@ func (pool *BlockPool) PeekTwoBlocks() (first, second *types.Block, firstParts *
 	return
 }
 
+// PeekBlocks returns up to n consecutive downloaded blocks starting at
+// pool.height, it stops at the first block which is not received yet.
+func (pool *BlockPool) PeekBlocks(n int) (blocks []*types.Block, parts []*types.PartSet) {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+
+	for h := pool.height; h < pool.height+int64(n); h++ {
+		r := pool.requesters[h]
+		if r == nil {
+			break
+		}
+		block, blockParts := r.getBlock()
+		if block == nil {
+			break
+		}
+		blocks = append(blocks, block)
+		parts = append(parts, blockParts)
+	}
+	return
+}
+
 // PopRequest pops the first block at pool.height.
 // It must have been validated by 'second'.Commit from PeekTwoBlocks().
 func (pool *BlockPool) PopRequest() {
@@ -81,16 +81,55 @@ type BlockchainReactor struct {
 	errorsCh   <-chan peerError
 
 	finishCh chan struct{}
+
+	downloadWindow  int
+	pipelinedVerify bool
+	verifyWorkers   int
+}
+
+// BlockchainReactorOption sets an optional parameter on the BlockchainReactor.
+type BlockchainReactorOption func(*BlockchainReactor)
+
+// WithDownloadWindow sets the maximum number of blocks requested ahead of the
+// current height across all peers.
+func WithDownloadWindow(window int) BlockchainReactorOption {
+	return func(bcR *BlockchainReactor) {
+		if window > 0 {
+			bcR.downloadWindow = window
+		}
+	}
+}
+
+// WithPipelinedVerify verifies the commits of the downloaded blocks with
+// workers goroutines while the previous block is executed.
+func WithPipelinedVerify(workers int) BlockchainReactorOption {
+	return func(bcR *BlockchainReactor) {
+		bcR.pipelinedVerify = true
+		bcR.verifyWorkers = workers
+	}
 }
 
 // NewBlockchainReactor returns new reactor instance.
-func NewBlockchainReactor(state sm.State, blockExec *sm.BlockExecutor, store *store.BlockStore, fastSync bool) *BlockchainReactor {
+func NewBlockchainReactor(state sm.State, blockExec *sm.BlockExecutor, store *store.BlockStore, fastSync bool,
+	options ...BlockchainReactorOption) *BlockchainReactor {
 	if state.LastBlockHeight != store.Height() {
 		panic(fmt.Sprintf("state (%v) and store (%v) height mismatch", state.LastBlockHeight,
 			store.Height()))
 	}
 
-	requestsCh := make(chan BlockRequest, maxTotalRequesters)
+	bcR := &BlockchainReactor{
+		curState:       state,
+		blockExec:      blockExec,
+		store:          store,
+		fastSync:       fastSync,
+		mtx:            sync.RWMutex{},
+		downloadWindow: maxTotalRequesters,
+	}
+	for _, option := range options {
+		option(bcR)
+	}
+
+	requestsCh := make(chan BlockRequest, bcR.downloadWindow)
 
 	const capacity = 1000                      // must be bigger than peers count
 	errorsCh := make(chan peerError, capacity) // so we don't block in #Receive#pool.AddBlock
@@ -102,18 +141,12 @@ func NewBlockchainReactor(state sm.State, blockExec *sm.BlockExecutor, store *st
 		requestsCh,
 		errorsCh,
 	)
+	pool.SetMaxRequesters(bcR.downloadWindow)
 
-	bcR := &BlockchainReactor{
-		curState:   state,
-		blockExec:  blockExec,
-		store:      store,
-		pool:       pool,
-		fastSync:   fastSync,
-		mtx:        sync.RWMutex{},
-		requestsCh: requestsCh,
-		errorsCh:   errorsCh,
-		finishCh:   finishCh,
-	}
+	bcR.pool = pool
+	bcR.requestsCh = requestsCh
+	bcR.errorsCh = errorsCh
+	bcR.finishCh = finishCh
 	bcR.BaseReactor = *p2p.NewBaseReactor("BlockchainReactor", bcR)
 	return bcR
 }
@@ -281,6 +314,12 @@ func (bcR *BlockchainReactor) poolRoutine() {
 	bcR.pool.Reset()
 	bcR.pool.Start()
 
+	var verifier *blockVerifier
+	if bcR.pipelinedVerify {
+		verifier = newBlockVerifier(chainID, bcR.verifyWorkers)
+		defer verifier.stop()
+	}
+
 	blocksSynced := uint64(0)
 
 	lastHundred := time.Now()
@@ -363,8 +402,16 @@ FOR_LOOP:
 			// NOTE: we can probably make this more efficient, but note that calling
 			// first.Hash() doesn't verify the tx contents, so MakePartSet() is
 			// currently necessary.
-			err := bcR.curState.Validators.VerifyCommitLight(
-				chainID, firstID, first.Height, second.LastCommit)
+			var err error
+			if verifier != nil {
+				// verify the following blocks while this one is being executed
+				blocks, parts := bcR.pool.PeekBlocks(verifier.lookahead + 1)
+				verifier.schedule(bcR.curState, blocks, parts)
+				err = verifier.verify(first.Height, firstID, second.LastCommit, bcR.curState.Validators)
+			} else {
+				err = bcR.curState.Validators.VerifyCommitLight(
+					chainID, firstID, first.Height, second.LastCommit)
+			}
 			if err != nil {
 				bcR.Logger.Error("Error in validation", "err", err)
 				peerID := bcR.pool.RedoRequest(first.Height)
@@ -0,0 +1,178 @@
+package v0
+
+import (
+	"bytes"
+	"runtime"
+	"sync"
+
+	sm "github.com/okex/exchain/libs/tendermint/state"
+	"github.com/okex/exchain/libs/tendermint/types"
+)
+
+const (
+	// number of blocks verified ahead of the executing block per worker
+	verifyLookaheadPerWorker = 4
+)
+
+// verifyTask is the commit verification of the block at height, done is
+// closed once err is set.
+type verifyTask struct {
+	height   int64
+	blockID  types.BlockID
+	commit   *types.Commit
+	vals     *types.ValidatorSet
+	valsHash []byte
+
+	err  error
+	done chan struct{}
+}
+
+func (t *verifyTask) match(blockID types.BlockID, commit *types.Commit) bool {
+	return t.commit == commit && t.blockID.Equals(blockID)
+}
+
+// blockVerifier verifies the commits of the downloaded blocks in parallel,
+// ahead of the block being executed by poolRoutine.
+//
+// The validator set a block is verified with is only a guess made from the
+// current state, so the result is used only if the set turns out to be the
+// one of the state the block is applied on, otherwise the commit is verified
+// again synchronously.
+type blockVerifier struct {
+	chainID   string
+	lookahead int
+
+	mtx   sync.Mutex
+	tasks map[int64]*verifyTask
+
+	taskCh chan *verifyTask
+	quit   chan struct{}
+	wg     sync.WaitGroup
+}
+
+// newBlockVerifier starts workers goroutines, the number of CPUs is used if
+// workers is not positive.
+func newBlockVerifier(chainID string, workers int) *blockVerifier {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	lookahead := workers * verifyLookaheadPerWorker
+	bv := &blockVerifier{
+		chainID:   chainID,
+		lookahead: lookahead,
+		tasks:     make(map[int64]*verifyTask),
+		taskCh:    make(chan *verifyTask, lookahead),
+		quit:      make(chan struct{}),
+	}
+	bv.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go bv.workerRoutine()
+	}
+	return bv
+}
+
+func (bv *blockVerifier) workerRoutine() {
+	defer bv.wg.Done()
+	for {
+		select {
+		case task := <-bv.taskCh:
+			task.err = task.vals.VerifyCommitLight(bv.chainID, task.blockID, task.height, task.commit)
+			close(task.done)
+		case <-bv.quit:
+			return
+		}
+	}
+}
+
+// stop terminates the workers, it must not be called concurrently with verify.
+func (bv *blockVerifier) stop() {
+	close(bv.quit)
+	bv.wg.Wait()
+}
+
+// schedule queues the verification of every block but the last one of the
+// consecutive blocks, the commit of a block is the LastCommit of the next one.
+// Blocks are verified with the validator set of state which their header
+// refers to, blocks matching neither Validators nor NextValidators are left
+// for the synchronous verification.
+func (bv *blockVerifier) schedule(state sm.State, blocks []*types.Block, parts []*types.PartSet) {
+	var vals, nextVals *types.ValidatorSet
+	for i := 0; i+1 < len(blocks); i++ {
+		block, commit := blocks[i], blocks[i+1].LastCommit
+		if commit == nil {
+			break
+		}
+		blockID := types.BlockID{Hash: block.Hash(), PartsHeader: parts[i].Header()}
+
+		bv.mtx.Lock()
+		task := bv.tasks[block.Height]
+		bv.mtx.Unlock()
+		if task != nil && task.match(blockID, commit) {
+			continue
+		}
+
+		var taskVals *types.ValidatorSet
+		var valsHash []byte
+		if hash := state.Validators.Hash(block.Height); bytes.Equal(hash, block.ValidatorsHash) {
+			if vals == nil {
+				vals = copyValidatorSet(state.Validators)
+			}
+			taskVals, valsHash = vals, hash
+		} else if hash := state.NextValidators.Hash(block.Height); bytes.Equal(hash, block.ValidatorsHash) {
+			if nextVals == nil {
+				nextVals = copyValidatorSet(state.NextValidators)
+			}
+			taskVals, valsHash = nextVals, hash
+		} else {
+			continue
+		}
+
+		task = &verifyTask{
+			height:   block.Height,
+			blockID:  blockID,
+			commit:   commit,
+			vals:     taskVals,
+			valsHash: valsHash,
+			done:     make(chan struct{}),
+		}
+		select {
+		case bv.taskCh <- task:
+			bv.mtx.Lock()
+			bv.tasks[block.Height] = task
+			bv.mtx.Unlock()
+		default:
+			// the workers are busy, the block will be scheduled next time
+			return
+		}
+	}
+}
+
+// verify returns the result of the scheduled verification if it was done for
+// the same block, commit and validator set, otherwise it verifies the commit
+// with vals. The results up to height are dropped.
+func (bv *blockVerifier) verify(height int64, blockID types.BlockID, commit *types.Commit,
+	vals *types.ValidatorSet) error {
+
+	bv.mtx.Lock()
+	task := bv.tasks[height]
+	for h := range bv.tasks {
+		if h <= height {
+			delete(bv.tasks, h)
+		}
+	}
+	bv.mtx.Unlock()
+
+	if task != nil && task.match(blockID, commit) && bytes.Equal(task.valsHash, vals.Hash(height)) {
+		<-task.done
+		return task.err
+	}
+	return vals.VerifyCommitLight(bv.chainID, blockID, height, commit)
+}
+
+// copyValidatorSet returns a copy of vals which can be read by several
+// goroutines, the total voting power is computed lazily so it's done here.
+func copyValidatorSet(vals *types.ValidatorSet) *types.ValidatorSet {
+	cpy := vals.Copy()
+	cpy.TotalVotingPower()
+	return cpy
+}
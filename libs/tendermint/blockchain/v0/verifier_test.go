@@ -0,0 +1,88 @@
+package v0
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/okex/exchain/libs/tendermint/config"
+	sm "github.com/okex/exchain/libs/tendermint/state"
+	"github.com/okex/exchain/libs/tendermint/types"
+)
+
+// makeSignedBlocks returns blocks 1..n, the LastCommit of each block is signed
+// by the genesis validator for the previous block.
+func makeSignedBlocks(t *testing.T, state sm.State, privVal types.PrivValidator, n int64) (
+	[]*types.Block, []*types.PartSet) {
+
+	var blocks []*types.Block
+	var parts []*types.PartSet
+	lastCommit := types.NewCommit(0, 0, types.BlockID{}, nil)
+	for height := int64(1); height <= n; height++ {
+		if height > 1 {
+			last := blocks[len(blocks)-1]
+			lastID := types.BlockID{Hash: last.Hash(), PartsHeader: parts[len(parts)-1].Header()}
+			vote, err := types.MakeVote(last.Height, lastID, state.Validators, privVal, state.ChainID, time.Now())
+			require.NoError(t, err)
+			lastCommit = types.NewCommit(vote.Height, vote.Round, lastID, []types.CommitSig{vote.CommitSig()})
+		}
+		block := makeBlock(height, state, lastCommit)
+		blocks = append(blocks, block)
+		parts = append(parts, block.MakePartSet(types.BlockPartSizeBytes))
+	}
+	return blocks, parts
+}
+
+func TestBlockVerifier(t *testing.T) {
+	config = cfg.ResetTestRoot("blockchain_verifier_test")
+	genDoc, privVals := randGenesisDoc(1, false, 30)
+	state, err := sm.MakeGenesisState(genDoc)
+	require.NoError(t, err)
+
+	blocks, parts := makeSignedBlocks(t, state, privVals[0], 6)
+
+	bv := newBlockVerifier(state.ChainID, 2)
+	defer bv.stop()
+
+	bv.schedule(state, blocks, parts)
+	// the last block has no commit yet
+	bv.mtx.Lock()
+	assert.Equal(t, len(blocks)-1, len(bv.tasks))
+	bv.mtx.Unlock()
+
+	for i := 0; i+1 < len(blocks); i++ {
+		blockID := types.BlockID{Hash: blocks[i].Hash(), PartsHeader: parts[i].Header()}
+		assert.NoError(t, bv.verify(blocks[i].Height, blockID, blocks[i+1].LastCommit, state.Validators))
+	}
+	// the results are dropped once used
+	bv.mtx.Lock()
+	assert.Empty(t, bv.tasks)
+	bv.mtx.Unlock()
+}
+
+func TestBlockVerifierRejectsBadCommit(t *testing.T) {
+	config = cfg.ResetTestRoot("blockchain_verifier_test")
+	genDoc, privVals := randGenesisDoc(1, false, 30)
+	state, err := sm.MakeGenesisState(genDoc)
+	require.NoError(t, err)
+
+	blocks, parts := makeSignedBlocks(t, state, privVals[0], 3)
+	// tamper with the signature of the commit for block 1
+	commit := blocks[1].LastCommit
+	commit.Signatures[0].Signature = append([]byte{}, commit.Signatures[0].Signature...)
+	commit.Signatures[0].Signature[0] ^= 0xff
+
+	bv := newBlockVerifier(state.ChainID, 1)
+	defer bv.stop()
+
+	bv.schedule(state, blocks, parts)
+	blockID := types.BlockID{Hash: blocks[0].Hash(), PartsHeader: parts[0].Header()}
+	assert.Error(t, bv.verify(blocks[0].Height, blockID, commit, state.Validators))
+
+	// a result computed with another validator set is not used
+	blockID = types.BlockID{Hash: blocks[1].Hash(), PartsHeader: parts[1].Header()}
+	otherVals, _ := types.RandValidatorSet(1, 30)
+	assert.Error(t, bv.verify(blocks[1].Height, blockID, blocks[2].LastCommit, otherVals))
+}
@@ -36,6 +36,21 @@ func AddNodeFlags(cmd *cobra.Command) {
 	// node flags
 	cmd.Flags().Bool("fast_sync", config.FastSyncMode, "Fast blockchain syncing")
 	cmd.Flags().Bool("auto_fast_sync", config.AutoFastSync, "Switch to FastSync mode automatically")
+	cmd.Flags().Bool(
+		"fastsync.pipelined_verify",
+		config.FastSync.PipelinedVerify,
+		"Verify the commits of downloaded blocks in parallel while executing the previous block (v0 only)",
+	)
+	cmd.Flags().Int(
+		"fastsync.download_window",
+		config.FastSync.DownloadWindow,
+		"Maximum number of blocks requested ahead of the current height across all peers",
+	)
+	cmd.Flags().Int(
+		"fastsync.verify_workers",
+		config.FastSync.VerifyWorkers,
+		"Number of goroutines verifying commits when pipelined verify is on, 0 means the number of CPUs",
+	)
 	cmd.Flags().BytesHexVar(
 		&genesisHash,
 		"genesis_hash",
@@ -160,6 +175,51 @@ func AddNodeFlags(cmd *cobra.Command) {
 		config.Mempool.SortTxByGp,
 		"Sort tx by gas price in mempool",
 	)
+	cmd.Flags().Bool(
+		"mempool.tx_announce",
+		config.Mempool.TxAnnounce,
+		"Gossip txs by announcing their hashes to peers which fetch the unseen ones",
+	)
+	cmd.Flags().Int(
+		"mempool.tx_announce_min_size",
+		config.Mempool.TxAnnounceMinSize,
+		"Txs smaller than this size in bytes are pushed to peers instead of announced",
+	)
+	cmd.Flags().String(
+		"mempool.lanes",
+		config.Mempool.Lanes,
+		"Lanes in priority order with the percentage of the block space reserved for their txs, e.g. system:10,relayer:10",
+	)
+	cmd.Flags().Bool(
+		"mempool.enable_bundles",
+		config.Mempool.EnableBundles,
+		"Accept bundles of txs included contiguously at the top of their target block, all of them or none",
+	)
+	cmd.Flags().Int(
+		"mempool.max_bundles",
+		config.Mempool.MaxBundles,
+		"Maximum number of bundles waiting for their target block",
+	)
+	cmd.Flags().Int(
+		"mempool.max_bundle_txs",
+		config.Mempool.MaxBundleTxs,
+		"Maximum number of txs in a bundle",
+	)
+	cmd.Flags().Int(
+		"mempool.max_bundles_per_block",
+		config.Mempool.MaxBundlesPerBlock,
+		"Maximum number of bundles included in a block",
+	)
+	cmd.Flags().Int(
+		"mempool.max_bundle_simulations",
+		config.Mempool.MaxBundleSimulations,
+		"Maximum number of bundles simulated when a block is proposed, including the ones failing",
+	)
+	cmd.Flags().Int64(
+		"mempool.max_bundle_blocks_ahead",
+		config.Mempool.MaxBundleBlocksAhead,
+		"Bundles can target the blocks up to this many blocks after the latest one",
+	)
 	cmd.Flags().Uint64(
 		"mempool.tx_price_bump",
 		config.Mempool.TxPriceBump,
@@ -233,7 +293,7 @@ func AddNodeFlags(cmd *cobra.Command) {
 	cmd.Flags().String(
 		"db_backend",
 		types.DBBackend,
-		"Database backend: goleveldb | cleveldb | boltdb | rocksdb")
+		"Database backend: goleveldb | cleveldb | boltdb | rocksdb | pebbledb")
 	cmd.Flags().String(
 		"db_dir",
 		config.DBPath,
@@ -5,6 +5,8 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/okex/exchain/libs/tendermint/types"
@@ -177,7 +179,7 @@ type BaseConfig struct { //nolint: maligned
 	// when it is many blocks behind the tip of the chain.
 	AutoFastSync bool `mapstructure:"auto_fast_sync"`
 
-	// Database backend: goleveldb | cleveldb | boltdb | rocksdb
+	// Database backend: goleveldb | cleveldb | boltdb | rocksdb | pebbledb
 	// * goleveldb (github.com/syndtr/goleveldb - most popular implementation)
 	//   - pure go
 	//   - stable
@@ -193,6 +195,9 @@ type BaseConfig struct { //nolint: maligned
 	//   - EXPERIMENTAL
 	//   - requires gcc
 	//   - use rocksdb build tag (go build -tags rocksdb)
+	// * pebbledb (uses github.com/cockroachdb/pebble)
+	//   - EXPERIMENTAL
+	//   - pure go
 	DBBackend string `mapstructure:"db_backend"`
 
 	// Database directory
@@ -690,6 +695,15 @@ type MempoolConfig struct {
 	NodeKeyWhitelist           []string `mapstructure:"node_key_whitelist"`
 	PendingRemoveEvent         bool     `mapstructure:"pending_remove_event"`
 	MaxTxLimitPerPeer          uint64   `mapstructure:"max_tx_limit_per_peer"`
+	TxAnnounce                 bool     `mapstructure:"tx_announce"`
+	TxAnnounceMinSize          int      `mapstructure:"tx_announce_min_size"`
+	Lanes                      string   `mapstructure:"lanes"`
+	EnableBundles              bool     `mapstructure:"enable_bundles"`
+	MaxBundles                 int      `mapstructure:"max_bundles"`
+	MaxBundleTxs               int      `mapstructure:"max_bundle_txs"`
+	MaxBundlesPerBlock         int      `mapstructure:"max_bundles_per_block"`
+	MaxBundleSimulations       int      `mapstructure:"max_bundle_simulations"`
+	MaxBundleBlocksAhead       int64    `mapstructure:"max_bundle_blocks_ahead"`
 }
 
 // DefaultMempoolConfig returns a default configuration for the Tendermint mempool
@@ -717,6 +731,15 @@ func DefaultMempoolConfig() *MempoolConfig {
 		NodeKeyWhitelist:           []string{},
 		PendingRemoveEvent:         false,
 		MaxTxLimitPerPeer:          100,
+		TxAnnounce:                 false,
+		TxAnnounceMinSize:          1024,
+		Lanes:                      "",
+		EnableBundles:              false,
+		MaxBundles:                 1000,
+		MaxBundleTxs:               16,
+		MaxBundlesPerBlock:         8,
+		MaxBundleSimulations:       16,
+		MaxBundleBlocksAhead:       25,
 	}
 }
 
@@ -758,21 +781,99 @@ func (cfg *MempoolConfig) ValidateBasic() error {
 	if cfg.ForceRecheckGap <= 0 {
 		return errors.New("force_recheck_gap can't be negative or zero")
 	}
+	if cfg.TxAnnounceMinSize < 0 {
+		return errors.New("tx_announce_min_size can't be negative")
+	}
+	if _, err := ParseMempoolLanes(cfg.Lanes); err != nil {
+		return errors.Wrap(err, "invalid lanes")
+	}
+	if cfg.MaxBundles < 0 {
+		return errors.New("max_bundles can't be negative")
+	}
+	if cfg.MaxBundleTxs < 0 {
+		return errors.New("max_bundle_txs can't be negative")
+	}
+	if cfg.MaxBundlesPerBlock < 0 {
+		return errors.New("max_bundles_per_block can't be negative")
+	}
+	if cfg.MaxBundleSimulations < 0 {
+		return errors.New("max_bundle_simulations can't be negative")
+	}
+	if cfg.MaxBundleBlocksAhead < 0 {
+		return errors.New("max_bundle_blocks_ahead can't be negative")
+	}
+	if cfg.EnableBundles && (cfg.MaxBundles == 0 || cfg.MaxBundleTxs == 0 ||
+		cfg.MaxBundlesPerBlock == 0 || cfg.MaxBundleSimulations == 0 || cfg.MaxBundleBlocksAhead == 0) {
+		return errors.New("max_bundles, max_bundle_txs, max_bundles_per_block, max_bundle_simulations " +
+			"and max_bundle_blocks_ahead must be positive when bundles are enabled")
+	}
 	return nil
 }
 
+// MempoolLane is a mempool lane with a share of the block space reserved for its txs
+type MempoolLane struct {
+	Name    string
+	Percent int64
+}
+
+// ParseMempoolLanes parses lanes in priority order from the "name:percent,name:percent" format.
+// The reserved percentages must not add up to more than 100.
+func ParseMempoolLanes(s string) ([]MempoolLane, error) {
+	var lanes []MempoolLane
+	var total int64
+	names := make(map[string]struct{})
+	for _, lane := range strings.Split(s, ",") {
+		lane = strings.TrimSpace(lane)
+		if lane == "" {
+			continue
+		}
+		parts := strings.Split(lane, ":")
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("lane %q is not in the name:percent format", lane)
+		}
+		name := strings.TrimSpace(parts[0])
+		if _, ok := names[name]; ok {
+			return nil, fmt.Errorf("duplicate lane %s", name)
+		}
+		names[name] = struct{}{}
+		percent, err := strconv.ParseInt(strings.TrimSpace(parts[1]), 10, 64)
+		if err != nil || percent <= 0 || percent > 100 {
+			return nil, fmt.Errorf("lane %s must reserve 1 to 100 percent of the block", name)
+		}
+		total += percent
+		lanes = append(lanes, MempoolLane{Name: name, Percent: percent})
+	}
+	if total > 100 {
+		return nil, fmt.Errorf("lanes reserve %d percent of the block", total)
+	}
+	return lanes, nil
+}
+
 //-----------------------------------------------------------------------------
 // FastSyncConfig
 
 // FastSyncConfig defines the configuration for the Tendermint fast sync service
 type FastSyncConfig struct {
 	Version string `mapstructure:"version"`
+
+	// PipelinedVerify verifies the commits of downloaded blocks in parallel
+	// while the previous block is being executed (v0 only)
+	PipelinedVerify bool `mapstructure:"pipelined_verify"`
+	// DownloadWindow is the maximum number of blocks requested ahead of the
+	// current height across all peers
+	DownloadWindow int `mapstructure:"download_window"`
+	// VerifyWorkers is the number of goroutines verifying commits when
+	// PipelinedVerify is enabled, 0 means the number of CPUs
+	VerifyWorkers int `mapstructure:"verify_workers"`
 }
 
 // DefaultFastSyncConfig returns a default configuration for the fast sync service
 func DefaultFastSyncConfig() *FastSyncConfig {
 	return &FastSyncConfig{
-		Version: "v0",
+		Version:         "v0",
+		PipelinedVerify: false,
+		DownloadWindow:  600,
+		VerifyWorkers:   0,
 	}
 }
 
@@ -783,6 +884,15 @@ func TestFastSyncConfig() *FastSyncConfig {
 
 // ValidateBasic performs basic validation.
 func (cfg *FastSyncConfig) ValidateBasic() error {
+	if cfg.DownloadWindow <= 0 {
+		return errors.New("download_window must be positive")
+	}
+	if cfg.VerifyWorkers < 0 {
+		return errors.New("verify_workers can't be negative")
+	}
+	if cfg.PipelinedVerify && cfg.Version != "v0" {
+		return fmt.Errorf("pipelined_verify is not supported by fastsync version %s", cfg.Version)
+	}
 	switch cfg.Version {
 	case "v0":
 		return nil
@@ -112,6 +112,11 @@ func TestMempoolConfigValidateBasic(t *testing.T) {
 		"CacheSize",
 		"MaxTxBytes",
 		"MaxTxNumPerBlock",
+		"MaxBundles",
+		"MaxBundleTxs",
+		"MaxBundlesPerBlock",
+		"MaxBundleSimulations",
+		"MaxBundleBlocksAhead",
 	}
 
 	for _, fieldName := range fieldsToTest {
@@ -121,6 +126,45 @@ func TestMempoolConfigValidateBasic(t *testing.T) {
 	}
 }
 
+func TestMempoolConfigValidateBundles(t *testing.T) {
+	cfg := TestMempoolConfig()
+	cfg.EnableBundles = true
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.MaxBundlesPerBlock = 0
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.EnableBundles = false
+	assert.NoError(t, cfg.ValidateBasic())
+}
+
+func TestParseMempoolLanes(t *testing.T) {
+	testCases := map[string]struct {
+		lanes    string
+		expLanes []MempoolLane
+		expErr   bool
+	}{
+		"empty":          {lanes: ""},
+		"lanes":          {lanes: "system:10, relayer:20", expLanes: []MempoolLane{{"system", 10}, {"relayer", 20}}},
+		"whole block":    {lanes: "system:100", expLanes: []MempoolLane{{"system", 100}}},
+		"missing name":   {lanes: ":10", expErr: true},
+		"missing share":  {lanes: "system", expErr: true},
+		"zero share":     {lanes: "system:0", expErr: true},
+		"duplicate lane": {lanes: "system:10,system:10", expErr: true},
+		"over 100":       {lanes: "system:60,relayer:50", expErr: true},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			lanes, err := ParseMempoolLanes(tc.lanes)
+			if tc.expErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expLanes, lanes)
+		})
+	}
+}
+
 func TestFastSyncConfigValidateBasic(t *testing.T) {
 	cfg := TestFastSyncConfig()
 	assert.NoError(t, cfg.ValidateBasic())
@@ -131,6 +175,21 @@ func TestFastSyncConfigValidateBasic(t *testing.T) {
 
 	cfg.Version = "invalid"
 	assert.Error(t, cfg.ValidateBasic())
+
+	// pipelined verify is only supported by v0
+	cfg = TestFastSyncConfig()
+	cfg.PipelinedVerify = true
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.Version = "v2"
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestFastSyncConfig()
+	cfg.DownloadWindow = 0
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestFastSyncConfig()
+	cfg.VerifyWorkers = -1
+	assert.Error(t, cfg.ValidateBasic())
 }
 
 func TestConsensusConfig_ValidateBasic(t *testing.T) {
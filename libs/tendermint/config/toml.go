@@ -90,7 +90,7 @@ fast_sync = {{ .BaseConfig.FastSyncMode }}
 # when it is many blocks behind the tip of the chain.
 auto_fast_sync = {{ .BaseConfig.AutoFastSync }}
 
-# Database backend: goleveldb | cleveldb | boltdb | rocksdb
+# Database backend: goleveldb | cleveldb | boltdb | rocksdb | pebbledb
 # * goleveldb (github.com/syndtr/goleveldb - most popular implementation)
 #   - pure go
 #   - stable
@@ -106,6 +106,10 @@ auto_fast_sync = {{ .BaseConfig.AutoFastSync }}
 #   - EXPERIMENTAL
 #   - requires gcc
 #   - use rocksdb build tag (go build -tags rocksdb)
+# * pebbledb (uses github.com/cockroachdb/pebble)
+#   - EXPERIMENTAL
+#   - pure go
+#   - no compaction stalls like goleveldb
 db_backend = "{{ .BaseConfig.DBBackend }}"
 
 # Database directory
@@ -328,6 +332,38 @@ sort_tx_by_gp = {{ .Mempool.SortTxByGp }}
 # Minimum price bump percentage to replace an already existing transaction (nonce)
 tx_price_bump = {{ .Mempool.TxPriceBump }}
 
+# Gossip txs by announcing their hashes first, peers fetch only the txs they have not seen.
+# Peers that do not support it still receive the txs.
+tx_announce = {{ .Mempool.TxAnnounce }}
+
+# Txs smaller than this size (in bytes) are pushed instead of announced
+tx_announce_min_size = {{ .Mempool.TxAnnounceMinSize }}
+
+# Lanes in priority order with the percentage of the block space reserved for their txs,
+# e.g. "system:10,relayer:10". Txs are assigned to lanes by the app, the remaining block space
+# is filled with txs of all lanes by gas price.
+lanes = "{{ .Mempool.Lanes }}"
+
+# Accept bundles, ordered sets of txs included contiguously at the top of their target block,
+# all of them or none. A bundle is simulated before it's accepted and again when a block is proposed.
+enable_bundles = {{ .Mempool.EnableBundles }}
+
+# Maximum number of bundles waiting for their target block
+max_bundles = {{ .Mempool.MaxBundles }}
+
+# Maximum number of txs in a bundle
+max_bundle_txs = {{ .Mempool.MaxBundleTxs }}
+
+# Maximum number of bundles included in a block
+max_bundles_per_block = {{ .Mempool.MaxBundlesPerBlock }}
+
+# Maximum number of bundles simulated when a block is proposed, including the ones failing,
+# which are dropped from the mempool
+max_bundle_simulations = {{ .Mempool.MaxBundleSimulations }}
+
+# Bundles can target the blocks up to this many blocks after the latest one
+max_bundle_blocks_ahead = {{ .Mempool.MaxBundleBlocksAhead }}
+
 # Node key whitelist used in mempool to reduce CPU and Memory tradeoff 
 node_key_whitelist = [{{ range .Mempool.NodeKeyWhitelist }}{{ printf "%q, " . }}{{end}}]
 
@@ -340,6 +376,16 @@ node_key_whitelist = [{{ range .Mempool.NodeKeyWhitelist }}{{ printf "%q, " . }}
 #   3) "v2" - refactor of v1 version for better usability
 version = "{{ .FastSync.Version }}"
 
+# Verify the commits of downloaded blocks in parallel and overlap the
+# verification of block N+1 with the execution of block N (v0 only)
+pipelined_verify = {{ .FastSync.PipelinedVerify }}
+
+# Maximum number of blocks requested ahead of the current height across all peers
+download_window = {{ .FastSync.DownloadWindow }}
+
+# Number of goroutines verifying commits when pipelined_verify is on, 0 means the number of CPUs
+verify_workers = {{ .FastSync.VerifyWorkers }}
+
 ##### consensus configuration options #####
 [consensus]
 
@@ -0,0 +1,217 @@
+package consensus
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	auto "github.com/okex/exchain/libs/tendermint/libs/autofile"
+	tmos "github.com/okex/exchain/libs/tendermint/libs/os"
+)
+
+// WALCorruption is the first corrupted message of a WAL
+type WALCorruption struct {
+	File   string
+	Offset int64
+	Err    error
+
+	pos walPosition
+}
+
+func (c WALCorruption) String() string {
+	return fmt.Sprintf("%s at offset %d: %v", c.File, c.Offset, c.Err)
+}
+
+// WALInfo is the result of scanning a WAL
+type WALInfo struct {
+	// Files are the files of the WAL from the oldest one to the head
+	Files []string
+	// LastEndHeight is the height of the last #ENDHEIGHT before any corruption,
+	// -1 if there is none.
+	LastEndHeight int64
+	Corruption    *WALCorruption
+}
+
+// walPosition is a position in the files of a WAL
+type walPosition struct {
+	file   int
+	offset int64
+}
+
+// ScanWAL decodes the WAL of walFile without opening it for writing, from the
+// oldest file to the head, until the first corrupted message.
+func ScanWAL(walFile string) (WALInfo, error) {
+	info := WALInfo{LastEndHeight: -1}
+	files, err := walFiles(walFile)
+	if err != nil {
+		return info, err
+	}
+	info.Files = files
+	info.Corruption, err = walkWAL(files, func(msg *TimedWALMessage, _ walPosition) {
+		if m, ok := msg.Msg.(EndHeightMessage); ok {
+			info.LastEndHeight = m.Height
+		}
+	})
+	return info, err
+}
+
+// RepairWAL drops the messages of the WAL from the first corrupted one on. It
+// returns false if there is no corruption. The dropped data is backed up into
+// the files with the suffix .<time>.CORRUPTED.
+func RepairWAL(walFile string) (bool, error) {
+	files, err := walFiles(walFile)
+	if err != nil {
+		return false, err
+	}
+	corruption, err := walkWAL(files, func(*TimedWALMessage, walPosition) {})
+	if err != nil || corruption == nil {
+		return false, err
+	}
+	return true, truncateWAL(files, corruption.pos, "CORRUPTED")
+}
+
+// TruncateWAL drops the messages of the WAL after the last #ENDHEIGHT of the
+// given height, it returns false if there are none. The dropped data is backed
+// up into the files with the suffix .<time>.TRUNCATED.
+func TruncateWAL(walFile string, height int64) (bool, error) {
+	files, err := walFiles(walFile)
+	if err != nil {
+		return false, err
+	}
+	var pos *walPosition
+	if _, err = walkWAL(files, func(msg *TimedWALMessage, end walPosition) {
+		if m, ok := msg.Msg.(EndHeightMessage); ok && m.Height == height {
+			pos = &end
+		}
+	}); err != nil {
+		return false, err
+	}
+	if pos == nil {
+		return false, fmt.Errorf("#ENDHEIGHT %d not found in WAL %s", height, walFile)
+	}
+	hasMore, err := hasDataAfter(files, *pos)
+	if err != nil || !hasMore {
+		return false, err
+	}
+	return true, truncateWAL(files, *pos, "TRUNCATED")
+}
+
+// walFiles returns the files of the WAL from the oldest one to the head
+func walFiles(walFile string) ([]string, error) {
+	if !tmos.FileExists(walFile) {
+		return nil, nil
+	}
+	group, err := auto.OpenGroup(walFile)
+	if err != nil {
+		return nil, err
+	}
+	defer group.Close()
+
+	var files []string
+	for index := group.MinIndex(); index <= group.MaxIndex(); index++ {
+		files = append(files, group.FilePath(index))
+	}
+	return files, nil
+}
+
+// walkWAL calls fn with the messages of the WAL files in order and the position
+// after each of them. It stops at the first corrupted message and returns it.
+func walkWAL(files []string, fn func(msg *TimedWALMessage, end walPosition)) (*WALCorruption, error) {
+	for i, file := range files {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		rd := &countingReader{rd: bufio.NewReader(f)}
+		dec := NewWALDecoder(rd)
+		for {
+			start := rd.n
+			msg, err := dec.Decode()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				f.Close()
+				if IsDataCorruptionError(err) {
+					return &WALCorruption{File: file, Offset: start, Err: err, pos: walPosition{i, start}}, nil
+				}
+				return nil, err
+			}
+			fn(msg, walPosition{i, rd.n})
+		}
+		f.Close()
+	}
+	return nil, nil
+}
+
+// hasDataAfter returns true if the WAL files have any data after the position
+func hasDataAfter(files []string, pos walPosition) (bool, error) {
+	for i := pos.file; i < len(files); i++ {
+		fi, err := os.Stat(files[i])
+		if err != nil {
+			return false, err
+		}
+		if (i == pos.file && fi.Size() > pos.offset) || (i > pos.file && fi.Size() > 0) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// truncateWAL cuts the WAL files at the position, the cut file becomes the head.
+// The cut file and the ones after it are backed up first.
+func truncateWAL(files []string, pos walPosition, backupTag string) error {
+	suffix := fmt.Sprintf(".%s.%s", time.Now().Format("20060102150405"), backupTag)
+	cut := files[pos.file]
+	if err := copyFile(cut, cut+suffix); err != nil {
+		return err
+	}
+	for _, file := range files[pos.file+1:] {
+		if err := os.Rename(file, file+suffix); err != nil {
+			return err
+		}
+	}
+	if err := os.Truncate(cut, pos.offset); err != nil {
+		return err
+	}
+	if head := files[len(files)-1]; cut != head {
+		return os.Rename(cut, head)
+	}
+	return nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// countingReader fills the buffers it reads into and counts the bytes read,
+// a message cut at the end of a file is reported as corrupted.
+type countingReader struct {
+	rd *bufio.Reader
+	n  int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := io.ReadFull(r.rd, p)
+	r.n += int64(n)
+	return n, err
+}
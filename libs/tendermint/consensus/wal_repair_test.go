@@ -0,0 +1,95 @@
+package consensus
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	tmtime "github.com/okex/exchain/libs/tendermint/types/time"
+)
+
+func writeWALFile(t *testing.T, path string, heights []int64, garbage []byte) {
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+	enc := NewWALEncoder(f)
+	for _, height := range heights {
+		require.NoError(t, enc.Encode(&TimedWALMessage{Time: tmtime.Now(), Msg: EndHeightMessage{height}}))
+	}
+	_, err = f.Write(garbage)
+	require.NoError(t, err)
+}
+
+func TestScanRepairTruncateWAL(t *testing.T) {
+	walDir, err := ioutil.TempDir("", "wal")
+	require.NoError(t, err)
+	defer os.RemoveAll(walDir)
+	walFile := filepath.Join(walDir, "wal")
+
+	info, err := ScanWAL(walFile)
+	require.NoError(t, err)
+	require.Empty(t, info.Files)
+	require.Equal(t, int64(-1), info.LastEndHeight)
+
+	writeWALFile(t, walFile+".000", []int64{0, 1, 2}, nil)
+	writeWALFile(t, walFile+".001", []int64{3, 4}, nil)
+	// a message cut at the end of the head
+	writeWALFile(t, walFile, []int64{5}, []byte{0x1, 0x2, 0x3})
+
+	info, err = ScanWAL(walFile)
+	require.NoError(t, err)
+	require.Equal(t, []string{walFile + ".000", walFile + ".001", walFile}, info.Files)
+	require.Equal(t, int64(5), info.LastEndHeight)
+	require.NotNil(t, info.Corruption)
+	require.Equal(t, walFile, info.Corruption.File)
+
+	repaired, err := RepairWAL(walFile)
+	require.NoError(t, err)
+	require.True(t, repaired)
+	info, err = ScanWAL(walFile)
+	require.NoError(t, err)
+	require.Nil(t, info.Corruption)
+	require.Equal(t, int64(5), info.LastEndHeight)
+
+	repaired, err = RepairWAL(walFile)
+	require.NoError(t, err)
+	require.False(t, repaired)
+
+	// nothing after the last #ENDHEIGHT
+	truncated, err := TruncateWAL(walFile, 5)
+	require.NoError(t, err)
+	require.False(t, truncated)
+
+	_, err = TruncateWAL(walFile, 6)
+	require.Error(t, err)
+
+	truncated, err = TruncateWAL(walFile, 1)
+	require.NoError(t, err)
+	require.True(t, truncated)
+	info, err = ScanWAL(walFile)
+	require.NoError(t, err)
+	require.Equal(t, []string{walFile}, info.Files)
+	require.Nil(t, info.Corruption)
+	require.Equal(t, int64(1), info.LastEndHeight)
+
+	// the dropped data is backed up
+	backups, err := filepath.Glob(walFile + "*.TRUNCATED")
+	require.NoError(t, err)
+	require.Len(t, backups, 3)
+	backups, err = filepath.Glob(walFile + "*.CORRUPTED")
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+
+	// the truncated WAL can be opened and searched
+	wal, err := NewWAL(walFile)
+	require.NoError(t, err)
+	require.NoError(t, wal.Start())
+	defer wal.Stop()
+	gr, found, err := wal.SearchForEndHeight(1, &WALSearchOptions{})
+	require.NoError(t, err)
+	require.True(t, found)
+	gr.Close()
+}
@@ -7,3 +7,13 @@ type DeltaBroker interface {
 	SetDeltas(height int64, bytes []byte) error
 	GetDeltas(height int64) ([]byte, error, int64)
 }
+
+// VerifiableDeltaBroker is a DeltaBroker which distributes the app hash committed by the producer along with the
+// deltas, and to which a consumer reports the deltas which did not result in the app hash agreed on by the validators.
+type VerifiableDeltaBroker interface {
+	DeltaBroker
+	SetVerifiableDeltas(height int64, bytes []byte, appHash []byte) error
+	GetAppHash(height int64) []byte
+	// ReportMismatch reports that applying the deltas of the height did not result in the app hash of the next block
+	ReportMismatch(height int64)
+}
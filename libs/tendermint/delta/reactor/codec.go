@@ -0,0 +1,14 @@
+package reactor
+
+import (
+	amino "github.com/tendermint/go-amino"
+
+	cryptoamino "github.com/okex/exchain/libs/tendermint/crypto/encoding/amino"
+)
+
+var cdc = amino.NewCodec()
+
+func init() {
+	RegisterMessages(cdc)
+	cryptoamino.RegisterAmino(cdc)
+}
@@ -0,0 +1,402 @@
+package reactor
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	amino "github.com/tendermint/go-amino"
+
+	"github.com/okex/exchain/libs/tendermint/crypto"
+	"github.com/okex/exchain/libs/tendermint/crypto/tmhash"
+	"github.com/okex/exchain/libs/tendermint/delta"
+	"github.com/okex/exchain/libs/tendermint/p2p"
+)
+
+const (
+	DeltaChannel = byte(0x50)
+
+	maxMsgSize = 64 * 1024 * 1024 // 64MB
+
+	// keepHeights is the number of most recent heights whose deltas are kept for peers catching up
+	keepHeights = 100
+
+	// requestIntervalMS is the interval at which missing deltas of a height are requested again
+	requestIntervalMS = 500
+)
+
+var _ delta.VerifiableDeltaBroker = (*Reactor)(nil)
+
+// Reactor gossips deltas signed by trusted producers, identified by their node key, and
+// implements delta.DeltaBroker on top of it.
+// Deltas are pushed to all peers when produced or first received, and missing deltas are
+// requested from peers.
+type Reactor struct {
+	p2p.BaseReactor
+
+	// privKey signs the produced deltas, it is nil if this node does not produce deltas
+	privKey   crypto.PrivKey
+	producers map[p2p.ID]struct{}
+
+	mtx       sync.RWMutex
+	deltas    map[int64]*DeltasMessage
+	mrh       int64
+	requested map[int64]time.Time
+	untrusted map[p2p.ID]struct{}
+}
+
+// NewReactor returns a new Reactor accepting deltas of the given producers. If privKey is
+// not nil the deltas set on the reactor are signed with it.
+func NewReactor(privKey crypto.PrivKey, producers []p2p.ID) *Reactor {
+	dR := &Reactor{
+		privKey:   privKey,
+		producers: make(map[p2p.ID]struct{}, len(producers)),
+		deltas:    make(map[int64]*DeltasMessage),
+		requested: make(map[int64]time.Time),
+		untrusted: make(map[p2p.ID]struct{}),
+	}
+	for _, id := range producers {
+		dR.producers[id] = struct{}{}
+	}
+	if privKey != nil {
+		dR.producers[p2p.PubKeyToID(privKey.PubKey())] = struct{}{}
+	}
+	dR.BaseReactor = *p2p.NewBaseReactor("Delta", dR)
+	return dR
+}
+
+// ParseProducers parses a comma separated list of producer node IDs.
+func ParseProducers(s string) ([]p2p.ID, error) {
+	var ids []p2p.ID
+	for _, id := range strings.Split(s, ",") {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+		bz, err := hex.DecodeString(id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid producer id %s: %v", id, err)
+		}
+		if len(bz) != crypto.AddressSize {
+			return nil, fmt.Errorf("invalid producer id %s: expected %d bytes, got %d", id, crypto.AddressSize, len(bz))
+		}
+		ids = append(ids, p2p.ID(strings.ToLower(id)))
+	}
+	return ids, nil
+}
+
+// GetChannels implements Reactor.
+// It returns the list of channels for this reactor.
+func (dR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
+	return []*p2p.ChannelDescriptor{
+		{
+			ID:                  DeltaChannel,
+			Priority:            5,
+			SendQueueCapacity:   100,
+			RecvBufferCapacity:  50 * 4096,
+			RecvMessageCapacity: maxMsgSize,
+		},
+	}
+}
+
+// Receive implements Reactor.
+// It stores and relays the deltas of trusted producers and answers requests for stored deltas.
+func (dR *Reactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
+	msg, err := decodeMsg(msgBytes)
+	if err != nil {
+		dR.Logger.Error("Error decoding message", "src", src, "chId", chID, "err", err)
+		dR.Switch.StopPeerForError(src, err)
+		return
+	}
+
+	if err = msg.ValidateBasic(); err != nil {
+		dR.Logger.Error("Peer sent us invalid msg", "peer", src, "msg", msg, "err", err)
+		dR.Switch.StopPeerForError(src, err)
+		return
+	}
+
+	dR.Logger.Debug("Receive", "src", src, "chId", chID, "msg", msg)
+
+	switch msg := msg.(type) {
+	case *DeltasMessage:
+		producer := msg.Producer()
+		if !dR.isTrusted(producer) {
+			dR.Logger.Debug("Ignore deltas of untrusted producer", "producer", producer, "height", msg.Height)
+			return
+		}
+		if !msg.Verify() {
+			dR.Logger.Error("Peer sent us deltas with an invalid signature", "peer", src, "msg", msg)
+			dR.Switch.StopPeerForError(src, fmt.Errorf("invalid deltas signature of height %d", msg.Height))
+			return
+		}
+		if dR.add(msg) {
+			dR.relay(src, msgBytes)
+		}
+	case *DeltasRequestMessage:
+		if deltas := dR.get(msg.Height); deltas != nil {
+			src.TrySend(DeltaChannel, cdc.MustMarshalBinaryBare(deltas))
+		}
+	default:
+		dR.Logger.Error(fmt.Sprintf("Unknown message type %v", reflect.TypeOf(msg)))
+	}
+}
+
+// relay sends the deltas to all peers but src
+func (dR *Reactor) relay(src p2p.Peer, msgBytes []byte) {
+	for _, peer := range dR.Switch.Peers().List() {
+		if src != nil && peer.ID() == src.ID() {
+			continue
+		}
+		peer.TrySend(DeltaChannel, msgBytes)
+	}
+}
+
+func (dR *Reactor) isTrusted(producer p2p.ID) bool {
+	dR.mtx.RLock()
+	defer dR.mtx.RUnlock()
+	_, trusted := dR.producers[producer]
+	_, untrusted := dR.untrusted[producer]
+	return trusted && !untrusted
+}
+
+// add stores the deltas and returns true if they are new and recent enough to be kept
+func (dR *Reactor) add(msg *DeltasMessage) bool {
+	dR.mtx.Lock()
+	defer dR.mtx.Unlock()
+	if msg.Height <= dR.mrh-keepHeights {
+		return false
+	}
+	if _, ok := dR.deltas[msg.Height]; ok {
+		return false
+	}
+	dR.deltas[msg.Height] = msg
+	delete(dR.requested, msg.Height)
+	if msg.Height > dR.mrh {
+		dR.mrh = msg.Height
+		for h := range dR.deltas {
+			if h <= dR.mrh-keepHeights {
+				delete(dR.deltas, h)
+			}
+		}
+		for h := range dR.requested {
+			if h <= dR.mrh-keepHeights {
+				delete(dR.requested, h)
+			}
+		}
+	}
+	return true
+}
+
+func (dR *Reactor) get(height int64) *DeltasMessage {
+	dR.mtx.RLock()
+	defer dR.mtx.RUnlock()
+	return dR.deltas[height]
+}
+
+// MostRecentHeight returns the height of the most recent deltas received or produced.
+func (dR *Reactor) MostRecentHeight() int64 {
+	dR.mtx.RLock()
+	defer dR.mtx.RUnlock()
+	return dR.mrh
+}
+
+// request asks the peers for the deltas of the height unless they were asked recently
+func (dR *Reactor) request(height int64) {
+	now := time.Now()
+	dR.mtx.Lock()
+	if at, ok := dR.requested[height]; ok && now.Sub(at) < requestIntervalMS*time.Millisecond {
+		dR.mtx.Unlock()
+		return
+	}
+	dR.requested[height] = now
+	dR.mtx.Unlock()
+
+	if dR.Switch != nil {
+		dR.Switch.Broadcast(DeltaChannel, cdc.MustMarshalBinaryBare(&DeltasRequestMessage{Height: height}))
+	}
+}
+
+//-----------------------------------------------------------------------------
+// DeltaBroker
+
+// GetLocker implements DeltaBroker. Every producer gossips its own deltas, so no lock is required.
+func (dR *Reactor) GetLocker() bool {
+	return true
+}
+
+// ReleaseLocker implements DeltaBroker.
+func (dR *Reactor) ReleaseLocker() {}
+
+// ResetMostRecentHeightAfterUpload implements DeltaBroker.
+func (dR *Reactor) ResetMostRecentHeightAfterUpload(height int64, upload func(int64) bool) (bool, int64, error) {
+	mrh := dR.MostRecentHeight()
+	if mrh >= height || !upload(mrh) {
+		return false, mrh, nil
+	}
+	return true, mrh, nil
+}
+
+// SetDeltas implements DeltaBroker.
+func (dR *Reactor) SetDeltas(height int64, bytes []byte) error {
+	return dR.SetVerifiableDeltas(height, bytes, nil)
+}
+
+// SetVerifiableDeltas implements VerifiableDeltaBroker. It signs the deltas and pushes them to all peers.
+func (dR *Reactor) SetVerifiableDeltas(height int64, bytes []byte, appHash []byte) error {
+	if dR.privKey == nil {
+		return fmt.Errorf("node is not a delta producer")
+	}
+	if len(bytes) == 0 {
+		return fmt.Errorf("delta is empty")
+	}
+	msg := &DeltasMessage{
+		Height:  height,
+		Deltas:  bytes,
+		AppHash: appHash,
+		PubKey:  dR.privKey.PubKey(),
+	}
+	sig, err := dR.privKey.Sign(msg.SignBytes())
+	if err != nil {
+		return err
+	}
+	msg.Signature = sig
+	if !dR.add(msg) {
+		return fmt.Errorf("deltas of height %d already exist", height)
+	}
+	if dR.Switch != nil {
+		dR.relay(nil, cdc.MustMarshalBinaryBare(msg))
+	}
+	return nil
+}
+
+// GetDeltas implements DeltaBroker. Missing deltas are requested from the peers.
+func (dR *Reactor) GetDeltas(height int64) ([]byte, error, int64) {
+	if deltas := dR.get(height); deltas != nil {
+		return deltas.Deltas, nil, dR.MostRecentHeight()
+	}
+	dR.request(height)
+	return nil, fmt.Errorf("get empty delta"), dR.MostRecentHeight()
+}
+
+// GetAppHash implements VerifiableDeltaBroker.
+func (dR *Reactor) GetAppHash(height int64) []byte {
+	if deltas := dR.get(height); deltas != nil {
+		return deltas.AppHash
+	}
+	return nil
+}
+
+// ReportMismatch implements VerifiableDeltaBroker. The producer of the deltas is not trusted
+// anymore and its deltas are dropped.
+func (dR *Reactor) ReportMismatch(height int64) {
+	dR.mtx.Lock()
+	defer dR.mtx.Unlock()
+	deltas, ok := dR.deltas[height]
+	if !ok {
+		return
+	}
+	producer := deltas.Producer()
+	dR.untrusted[producer] = struct{}{}
+	for h, d := range dR.deltas {
+		if d.Producer() == producer {
+			delete(dR.deltas, h)
+		}
+	}
+	dR.Logger.Error("Distrust delta producer", "producer", producer, "height", height)
+}
+
+//-----------------------------------------------------------------------------
+// Messages
+
+// Message is a message sent or received by the Reactor.
+type Message interface {
+	ValidateBasic() error
+}
+
+func RegisterMessages(cdc *amino.Codec) {
+	cdc.RegisterInterface((*Message)(nil), nil)
+	cdc.RegisterConcrete(&DeltasMessage{}, "tendermint/delta/DeltasMessage", nil)
+	cdc.RegisterConcrete(&DeltasRequestMessage{}, "tendermint/delta/DeltasRequestMessage", nil)
+}
+
+func decodeMsg(bz []byte) (msg Message, err error) {
+	if len(bz) > maxMsgSize {
+		return msg, fmt.Errorf("msg exceeds max size (%d > %d)", len(bz), maxMsgSize)
+	}
+	err = cdc.UnmarshalBinaryBare(bz, &msg)
+	return
+}
+
+//-------------------------------------
+
+// DeltasMessage contains the encoded deltas of a height and the app hash committed by
+// their producer, signed with the producer's node key.
+type DeltasMessage struct {
+	Height    int64
+	Deltas    []byte
+	AppHash   []byte
+	PubKey    crypto.PubKey
+	Signature []byte
+}
+
+// ValidateBasic performs basic validation.
+func (m *DeltasMessage) ValidateBasic() error {
+	if m.Height <= 0 {
+		return fmt.Errorf("invalid height %d", m.Height)
+	}
+	if len(m.Deltas) == 0 {
+		return fmt.Errorf("empty deltas")
+	}
+	if m.PubKey == nil {
+		return fmt.Errorf("missing producer public key")
+	}
+	if len(m.Signature) == 0 {
+		return fmt.Errorf("missing signature")
+	}
+	return nil
+}
+
+// SignBytes returns the bytes signed by the producer: the height, the hash of the deltas and the app hash.
+func (m *DeltasMessage) SignBytes() []byte {
+	bz := make([]byte, 8, 8+tmhash.Size+len(m.AppHash))
+	binary.BigEndian.PutUint64(bz, uint64(m.Height))
+	bz = append(bz, tmhash.Sum(m.Deltas)...)
+	return append(bz, m.AppHash...)
+}
+
+// Verify returns true if the deltas are signed by the producer.
+func (m *DeltasMessage) Verify() bool {
+	return m.PubKey.VerifyBytes(m.SignBytes(), m.Signature)
+}
+
+// Producer returns the node ID of the producer.
+func (m *DeltasMessage) Producer() p2p.ID {
+	return p2p.PubKeyToID(m.PubKey)
+}
+
+// String returns a string representation of the DeltasMessage.
+func (m *DeltasMessage) String() string {
+	return fmt.Sprintf("[DeltasMessage height:%d size:%d appHash:%X]", m.Height, len(m.Deltas), m.AppHash)
+}
+
+// DeltasRequestMessage requests the deltas of a height.
+type DeltasRequestMessage struct {
+	Height int64
+}
+
+// ValidateBasic performs basic validation.
+func (m *DeltasRequestMessage) ValidateBasic() error {
+	if m.Height <= 0 {
+		return fmt.Errorf("invalid height %d", m.Height)
+	}
+	return nil
+}
+
+// String returns a string representation of the DeltasRequestMessage.
+func (m *DeltasRequestMessage) String() string {
+	return fmt.Sprintf("[DeltasRequestMessage %d]", m.Height)
+}
@@ -0,0 +1,142 @@
+package reactor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/okex/exchain/libs/tendermint/config"
+	"github.com/okex/exchain/libs/tendermint/crypto/ed25519"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	"github.com/okex/exchain/libs/tendermint/p2p"
+)
+
+// connect the reactors through N switches
+func makeAndConnectReactors(reactors []*Reactor) {
+	for i, r := range reactors {
+		r.SetLogger(log.TestingLogger().With("validator", i))
+	}
+	p2p.MakeConnectedSwitches(cfg.DefaultP2PConfig(), len(reactors), func(i int, s *p2p.Switch) *p2p.Switch {
+		s.AddReactor("DELTA", reactors[i])
+		return s
+	}, p2p.Connect2Switches)
+}
+
+func stopReactors(reactors []*Reactor) {
+	for _, r := range reactors {
+		r.Switch.Stop()
+	}
+}
+
+func waitForDeltas(t *testing.T, r *Reactor, height int64) []byte {
+	timeout := time.After(10 * time.Second)
+	for {
+		if bz, err, _ := r.GetDeltas(height); err == nil {
+			return bz
+		}
+		select {
+		case <-timeout:
+			t.Fatalf("timed out waiting for deltas of height %d", height)
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+}
+
+func TestReactorGossipDeltas(t *testing.T) {
+	producerKey := ed25519.GenPrivKey()
+	producerID := p2p.PubKeyToID(producerKey.PubKey())
+	reactors := []*Reactor{
+		NewReactor(producerKey, nil),
+		NewReactor(nil, []p2p.ID{producerID}),
+		NewReactor(nil, []p2p.ID{producerID}),
+	}
+
+	// produced before the peers are connected, so they catch up by request
+	require.NoError(t, reactors[0].SetVerifiableDeltas(1, []byte("deltas1"), []byte("apphash1")))
+
+	makeAndConnectReactors(reactors)
+	defer stopReactors(reactors)
+
+	for _, r := range reactors[1:] {
+		assert.Equal(t, []byte("deltas1"), waitForDeltas(t, r, 1))
+		assert.Equal(t, []byte("apphash1"), r.GetAppHash(1))
+	}
+
+	// pushed to the connected peers
+	require.NoError(t, reactors[0].SetVerifiableDeltas(2, []byte("deltas2"), []byte("apphash2")))
+	for _, r := range reactors[1:] {
+		assert.Equal(t, []byte("deltas2"), waitForDeltas(t, r, 2))
+		assert.Equal(t, int64(2), r.MostRecentHeight())
+	}
+	require.Error(t, reactors[0].SetVerifiableDeltas(2, []byte("deltas2"), []byte("apphash2")))
+}
+
+func TestReactorIgnoreUntrustedProducer(t *testing.T) {
+	reactors := []*Reactor{
+		NewReactor(ed25519.GenPrivKey(), nil),
+		NewReactor(nil, []p2p.ID{p2p.PubKeyToID(ed25519.GenPrivKey().PubKey())}),
+	}
+	makeAndConnectReactors(reactors)
+	defer stopReactors(reactors)
+
+	require.NoError(t, reactors[0].SetDeltas(1, []byte("deltas1")))
+	time.Sleep(200 * time.Millisecond)
+	_, err, mrh := reactors[1].GetDeltas(1)
+	require.Error(t, err)
+	assert.Equal(t, int64(0), mrh)
+}
+
+func TestReactorReportMismatch(t *testing.T) {
+	r := NewReactor(ed25519.GenPrivKey(), nil)
+	require.NoError(t, r.SetVerifiableDeltas(1, []byte("deltas1"), []byte("apphash1")))
+	require.NoError(t, r.SetVerifiableDeltas(2, []byte("deltas2"), []byte("apphash2")))
+
+	r.ReportMismatch(1)
+	for _, height := range []int64{1, 2} {
+		_, err, _ := r.GetDeltas(height)
+		require.Error(t, err)
+	}
+	assert.False(t, r.isTrusted(p2p.PubKeyToID(r.privKey.PubKey())))
+}
+
+func TestReactorKeepHeights(t *testing.T) {
+	r := NewReactor(ed25519.GenPrivKey(), nil)
+	require.NoError(t, r.SetDeltas(1, []byte("deltas1")))
+	require.NoError(t, r.SetDeltas(keepHeights+1, []byte("deltas")))
+
+	assert.Nil(t, r.get(1))
+	require.Error(t, r.SetDeltas(1, []byte("deltas1")))
+}
+
+func TestDeltasMessageVerify(t *testing.T) {
+	r := NewReactor(ed25519.GenPrivKey(), nil)
+	require.NoError(t, r.SetVerifiableDeltas(1, []byte("deltas1"), []byte("apphash1")))
+	msg := r.get(1)
+
+	bz := cdc.MustMarshalBinaryBare(msg)
+	decoded, err := decodeMsg(bz)
+	require.NoError(t, err)
+	require.NoError(t, decoded.ValidateBasic())
+	assert.True(t, decoded.(*DeltasMessage).Verify())
+
+	forged := *msg
+	forged.AppHash = []byte("apphash2")
+	assert.False(t, forged.Verify())
+	forged = *msg
+	forged.Height = 2
+	assert.False(t, forged.Verify())
+}
+
+func TestParseProducers(t *testing.T) {
+	id := p2p.PubKeyToID(ed25519.GenPrivKey().PubKey())
+	ids, err := ParseProducers(" " + string(id) + ", ")
+	require.NoError(t, err)
+	assert.Equal(t, []p2p.ID{id}, ids)
+
+	_, err = ParseProducers("xyz")
+	require.Error(t, err)
+	_, err = ParseProducers("abcd")
+	require.Error(t, err)
+}
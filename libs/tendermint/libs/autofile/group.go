@@ -198,6 +198,11 @@ func (g *Group) MinIndex() int {
 	return g.minIndex
 }
 
+// FilePath returns the path of the file of the given index in the group.
+func (g *Group) FilePath(index int) string {
+	return filePathForIndex(g.Head.Path, index, g.MaxIndex())
+}
+
 // Write writes the contents of p into the current head of the group. It
 // returns the number of bytes written. If nn < len(p), it also returns an
 // error explaining why the write is short.
@@ -351,8 +351,6 @@ func (l *CList) WaitChan() <-chan struct{} {
 
 // Panics if list grows beyond its max length.
 func (l *CList) PushBack(v interface{}) *CElement {
-	l.mtx.Lock()
-
 	// Construct a new element
 	e := &CElement{
 		prev:       nil,
@@ -364,6 +362,13 @@ func (l *CList) PushBack(v interface{}) *CElement {
 		removed:    false,
 		Value:      v,
 	}
+	return l.PushBackElement(e)
+}
+
+// PushBackElement appends a new or detached element to the list.
+// Panics if list grows beyond its max length.
+func (l *CList) PushBackElement(e *CElement) *CElement {
+	l.mtx.Lock()
 
 	// Release waiters on FrontWait/BackWait maybe
 	if l.len == 0 {
@@ -104,17 +104,18 @@ func (c *Client) ABCIQueryWithOptions(path string, data tmbytes.HexBytes,
 		return nil, err
 	}
 
+	// XXX How do we encode the key into a string...
+	storeName, err := parseQueryStorePath(path)
+	if err != nil {
+		return nil, err
+	}
+	kp := merkle.KeyPath{}
+	kp = kp.AppendKey([]byte(storeName), merkle.KeyEncodingURL)
+	kp = kp.AppendKey(resp.Key, merkle.KeyEncodingURL)
+
 	// Validate the value proof against the trusted header.
 	if resp.Value != nil {
 		// Value exists
-		// XXX How do we encode the key into a string...
-		storeName, err := parseQueryStorePath(path)
-		if err != nil {
-			return nil, err
-		}
-		kp := merkle.KeyPath{}
-		kp = kp.AppendKey([]byte(storeName), merkle.KeyEncodingURL)
-		kp = kp.AppendKey(resp.Key, merkle.KeyEncodingURL)
 		err = c.prt.VerifyValue(resp.Proof, h.AppHash, kp.String(), resp.Value)
 		if err != nil {
 			return nil, fmt.Errorf("verify value proof: %w", err)
@@ -123,8 +124,7 @@ func (c *Client) ABCIQueryWithOptions(path string, data tmbytes.HexBytes,
 	}
 
 	// OR validate the ansence proof against the trusted header.
-	// XXX How do we encode the key into a string...
-	err = c.prt.VerifyAbsence(resp.Proof, h.AppHash, string(resp.Key))
+	err = c.prt.VerifyAbsence(resp.Proof, h.AppHash, kp.String())
 	if err != nil {
 		return nil, fmt.Errorf("verify absence proof: %w", err)
 	}
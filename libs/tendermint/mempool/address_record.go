@@ -45,7 +45,9 @@ func (ar *AddressRecord) AddItem(address string, cElement *clist.CElement) {
 	}
 }
 
-func (ar *AddressRecord) checkRepeatedAndAddItem(memTx *mempoolTx, txPriceBump int64, cb func(*clist.CElement) *clist.CElement) *clist.CElement {
+// checkRepeatedAndAddItem adds the tx, a tx of the same sender and nonce is replaced and returned
+// if the gas price of the new tx is bumped enough
+func (ar *AddressRecord) checkRepeatedAndAddItem(memTx *mempoolTx, txPriceBump int64, cb func(*clist.CElement) *clist.CElement) (*clist.CElement, *clist.CElement, error) {
 	gasPrice := memTx.realTx.GetGasPrice()
 	nonce := memTx.realTx.GetNonce()
 	newElement := clist.NewCElement(memTx, memTx.from, gasPrice, nonce)
@@ -62,35 +64,32 @@ func (ar *AddressRecord) checkRepeatedAndAddItem(memTx *mempoolTx, txPriceBump i
 		cb(newElement)
 		am.maxNonce = newElement.Nonce
 		am.items[newElement.Nonce] = newElement
-		return newElement
+		return newElement, nil, nil
 	}
 
-	for _, e := range am.items {
-		if e.Nonce == nonce {
-			// only replace tx for bigger gas price
-			expectedGasPrice := MultiPriceBump(e.GasPrice, txPriceBump)
-			if gasPrice.Cmp(expectedGasPrice) <= 0 {
-				return nil
-			}
+	if e, ok := am.items[nonce]; ok {
+		// only replace tx for bigger gas price
+		if err := checkReplaceGasPrice(memTx.from, nonce, e.GasPrice, gasPrice, txPriceBump); err != nil {
+			return nil, nil, err
+		}
 
-			// delete the old element and reorganize the elements whose nonce is greater the the new element
-			ar.removeElement(e)
-			items := []*clist.CElement{newElement}
-			for _, item := range am.items {
-				if item.Nonce > nonce {
-					items = append(items, item)
-				}
+		// delete the old element and reorganize the elements whose nonce is greater the the new element
+		ar.removeElement(e)
+		items := []*clist.CElement{newElement}
+		for _, item := range am.items {
+			if item.Nonce > nonce {
+				items = append(items, item)
 			}
-			ar.reorganizeElements(items)
-			am.items[newElement.Nonce] = newElement
-			return newElement
 		}
+		ar.reorganizeElements(items)
+		am.items[newElement.Nonce] = newElement
+		return newElement, e, nil
 	}
 
 	cb(newElement)
 	am.items[newElement.Nonce] = newElement
 
-	return newElement
+	return newElement, nil, nil
 }
 
 func (ar *AddressRecord) CleanItems(address string, nonce uint64, cb func(element *clist.CElement)) {
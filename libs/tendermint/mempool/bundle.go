@@ -0,0 +1,331 @@
+package mempool
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	"github.com/okex/exchain/libs/tendermint/crypto/tmhash"
+	"github.com/okex/exchain/libs/tendermint/types"
+)
+
+const simulateBundlePath = "app/simulateBundle"
+
+// Bundle is an ordered set of txs included contiguously at the top of the block
+// of TargetHeight, all of them or none.
+type Bundle struct {
+	Txs          types.Txs
+	TargetHeight int64
+	// RevertingTxHashes are the hashes of the txs allowed to fail, the bundle is
+	// not included if any other tx of it fails.
+	RevertingTxHashes [][]byte
+}
+
+// Hash returns the hash of the bundle, which is the hash of its tx hashes.
+func (b Bundle) Hash() []byte {
+	hashes := make([]byte, 0, len(b.Txs)*tmhash.Size)
+	for _, tx := range b.Txs {
+		hashes = append(hashes, tx.Hash(b.TargetHeight)...)
+	}
+	return tmhash.Sum(hashes)
+}
+
+// mempoolBundle is a bundle accepted by the mempool
+type mempoolBundle struct {
+	Bundle
+	hash      []byte
+	reverting map[string]struct{}
+	bytes     int64
+}
+
+func newMempoolBundle(bundle Bundle) (*mempoolBundle, error) {
+	mb := &mempoolBundle{
+		Bundle:    bundle,
+		hash:      bundle.Hash(),
+		reverting: make(map[string]struct{}, len(bundle.RevertingTxHashes)),
+	}
+	hashes := make(map[string]struct{}, len(bundle.Txs))
+	for _, tx := range bundle.Txs {
+		hash := hex.EncodeToString(tx.Hash(bundle.TargetHeight))
+		if _, ok := hashes[hash]; ok {
+			return nil, fmt.Errorf("duplicate tx %s in the bundle", hash)
+		}
+		hashes[hash] = struct{}{}
+		mb.bytes += int64(len(tx)) + types.ComputeAminoOverhead(tx, 1)
+	}
+	for _, h := range bundle.RevertingTxHashes {
+		hash := hex.EncodeToString(h)
+		if _, ok := hashes[hash]; !ok {
+			return nil, fmt.Errorf("reverting tx %s is not in the bundle", hash)
+		}
+		mb.reverting[hash] = struct{}{}
+	}
+	return mb, nil
+}
+
+// checkResults returns an error if a tx not allowed to fail failed in the simulation
+func (mb *mempoolBundle) checkResults(results []BundleTxResult) error {
+	for i, res := range results {
+		if res.Code == abci.CodeTypeOK {
+			continue
+		}
+		hash := hex.EncodeToString(mb.Txs[i].Hash(mb.TargetHeight))
+		if _, ok := mb.reverting[hash]; !ok {
+			return ErrBundleTxReverted{index: i, hash: hash, log: res.Log}
+		}
+	}
+	return nil
+}
+
+// bundlePool holds the accepted bundles by their target height
+type bundlePool struct {
+	mtx      sync.Mutex
+	byHeight map[int64][]*mempoolBundle
+	hashes   map[string]struct{}
+}
+
+func newBundlePool() *bundlePool {
+	return &bundlePool{
+		byHeight: make(map[int64][]*mempoolBundle),
+		hashes:   make(map[string]struct{}),
+	}
+}
+
+func (bp *bundlePool) add(mb *mempoolBundle, latestHeight int64, maxBundles int) error {
+	bp.mtx.Lock()
+	defer bp.mtx.Unlock()
+	if mb.TargetHeight <= latestHeight {
+		return fmt.Errorf("target height %d of the bundle is committed", mb.TargetHeight)
+	}
+	hash := hex.EncodeToString(mb.hash)
+	if _, ok := bp.hashes[hash]; ok {
+		return ErrBundleExists
+	}
+	if len(bp.hashes) >= maxBundles {
+		return ErrBundlePoolIsFull{maxBundles: maxBundles}
+	}
+	bp.hashes[hash] = struct{}{}
+	bp.byHeight[mb.TargetHeight] = append(bp.byHeight[mb.TargetHeight], mb)
+	return nil
+}
+
+func (bp *bundlePool) has(hash []byte) bool {
+	bp.mtx.Lock()
+	defer bp.mtx.Unlock()
+	_, ok := bp.hashes[hex.EncodeToString(hash)]
+	return ok
+}
+
+// get returns the bundles of the height in the order they were accepted
+func (bp *bundlePool) get(height int64) []*mempoolBundle {
+	bp.mtx.Lock()
+	defer bp.mtx.Unlock()
+	bundles := bp.byHeight[height]
+	return bundles[:len(bundles):len(bundles)]
+}
+
+// remove drops the bundle, the slices returned by get before are left unchanged
+func (bp *bundlePool) remove(mb *mempoolBundle) {
+	bp.mtx.Lock()
+	defer bp.mtx.Unlock()
+	hash := hex.EncodeToString(mb.hash)
+	if _, ok := bp.hashes[hash]; !ok {
+		return
+	}
+	delete(bp.hashes, hash)
+	bundles := bp.byHeight[mb.TargetHeight]
+	for i, b := range bundles {
+		if b == mb {
+			bundles = append(bundles[:i:i], bundles[i+1:]...)
+			break
+		}
+	}
+	if len(bundles) == 0 {
+		delete(bp.byHeight, mb.TargetHeight)
+		return
+	}
+	bp.byHeight[mb.TargetHeight] = bundles
+}
+
+// prune drops the bundles targeting the committed heights
+func (bp *bundlePool) prune(height int64) {
+	bp.mtx.Lock()
+	defer bp.mtx.Unlock()
+	for h, bundles := range bp.byHeight {
+		if h > height {
+			continue
+		}
+		for _, mb := range bundles {
+			delete(bp.hashes, hex.EncodeToString(mb.hash))
+		}
+		delete(bp.byHeight, h)
+	}
+}
+
+func (bp *bundlePool) size() int {
+	bp.mtx.Lock()
+	defer bp.mtx.Unlock()
+	return len(bp.hashes)
+}
+
+func (bp *bundlePool) reset() {
+	bp.mtx.Lock()
+	defer bp.mtx.Unlock()
+	bp.byHeight = make(map[int64][]*mempoolBundle)
+	bp.hashes = make(map[string]struct{})
+}
+
+// AddBundle validates and simulates the bundle on the latest state, and keeps it
+// for its target height if none of its txs fails but the reverting ones.
+// The hash of the bundle is returned.
+func (mem *CListMempool) AddBundle(bundle Bundle) ([]byte, error) {
+	if !mem.config.EnableBundles {
+		return nil, ErrBundlesDisabled
+	}
+	height := mem.Height()
+	if len(bundle.Txs) == 0 {
+		return nil, errors.New("empty bundle")
+	}
+	if len(bundle.Txs) > mem.config.MaxBundleTxs {
+		return nil, fmt.Errorf("bundle has %d txs, max: %d", len(bundle.Txs), mem.config.MaxBundleTxs)
+	}
+	if bundle.TargetHeight <= height || bundle.TargetHeight > height+mem.config.MaxBundleBlocksAhead {
+		return nil, fmt.Errorf("target height %d of the bundle must be in (%d, %d]",
+			bundle.TargetHeight, height, height+mem.config.MaxBundleBlocksAhead)
+	}
+	for _, tx := range bundle.Txs {
+		if len(tx) > mem.config.MaxTxBytes {
+			return nil, ErrTxTooLarge{mem.config.MaxTxBytes, len(tx)}
+		}
+		if mem.preCheck != nil {
+			if err := mem.preCheck(tx); err != nil {
+				return nil, ErrPreCheck{err}
+			}
+		}
+	}
+
+	mb, err := newMempoolBundle(bundle)
+	if err != nil {
+		return nil, err
+	}
+	if mem.bundles.has(mb.hash) {
+		return nil, ErrBundleExists
+	}
+	results, err := mem.simulateBundle(bundle.Txs)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to simulate the bundle")
+	}
+	if err := mb.checkResults(results); err != nil {
+		return nil, err
+	}
+	if err := mem.bundles.add(mb, mem.Height(), mem.config.MaxBundles); err != nil {
+		return nil, err
+	}
+	mem.logger.Info("Added bundle", "hash", hex.EncodeToString(mb.hash), "txs", len(bundle.Txs),
+		"targetHeight", bundle.TargetHeight)
+	return mb.hash, nil
+}
+
+// simulateBundle runs the txs in order on the latest state by the app
+func (mem *CListMempool) simulateBundle(txs types.Txs) ([]BundleTxResult, error) {
+	res, err := mem.proxyAppConn.QuerySync(abci.RequestQuery{
+		Path: simulateBundlePath,
+		Data: cdc.MustMarshalBinaryBare(txs),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if res.Code != abci.CodeTypeOK {
+		return nil, errors.New(res.Log)
+	}
+	var simRes BundleSimulationResponse
+	if err := cdc.UnmarshalBinaryBare(res.Value, &simRes); err != nil {
+		return nil, err
+	}
+	if len(simRes.Results) != len(txs) {
+		return nil, fmt.Errorf("simulated %d txs of %d", len(simRes.Results), len(txs))
+	}
+	return simRes.Results, nil
+}
+
+// reapedBundles are the bundles reaped into the block being proposed
+type reapedBundles struct {
+	txs   []types.Tx
+	bytes int64
+	gas   int64
+	// keys are the mempool keys of the bundle txs
+	keys map[[32]byte]struct{}
+	// senders of the bundle txs, their mempool txs are left for the next blocks
+	// as their nonces are taken by the bundles
+	senders map[string]struct{}
+}
+
+func (rb *reapedBundles) hasSender(from string) bool {
+	_, ok := rb.senders[from]
+	return from != "" && ok
+}
+
+// reapBundles reaps the bundles of the next height in the order they were accepted,
+// each one only if it fits in the block as a whole and none of its txs but the
+// reverting ones fails on top of the bundles reaped before it.
+// At most MaxBundleSimulations bundles are simulated, the failing ones count too
+// and are dropped from the mempool, so that the bundles can't stall the proposal.
+func (mem *CListMempool) reapBundles(limits reapLimits) *reapedBundles {
+	rb := &reapedBundles{
+		keys:    make(map[[32]byte]struct{}),
+		senders: make(map[string]struct{}),
+	}
+	if !mem.config.EnableBundles {
+		return rb
+	}
+	height := mem.Height() + 1
+	var reaped, simulated int
+	for _, mb := range mem.bundles.get(height) {
+		if reaped >= mem.config.MaxBundlesPerBlock || simulated >= mem.config.MaxBundleSimulations {
+			break
+		}
+		if exceeds(rb.bytes, mb.bytes, limits.bytes) || int64(len(rb.txs)+len(mb.Txs)) > limits.txNum {
+			continue
+		}
+
+		txs := append(rb.txs[:len(rb.txs):len(rb.txs)], mb.Txs...)
+		simulated++
+		results, err := mem.simulateBundle(txs)
+		if err != nil {
+			mem.logger.Error("failed to simulate bundle, drop it", "hash", hex.EncodeToString(mb.hash), "err", err)
+			mem.bundles.remove(mb)
+			continue
+		}
+		results = results[len(rb.txs):]
+		if err := mb.checkResults(results); err != nil {
+			mem.logger.Info("drop bundle", "hash", hex.EncodeToString(mb.hash), "reason", err)
+			mem.bundles.remove(mb)
+			continue
+		}
+		var gas int64
+		for _, res := range results {
+			gas += int64(res.GasWanted)
+		}
+		if exceeds(rb.gas, gas, limits.gas) {
+			continue
+		}
+
+		rb.txs = txs
+		rb.bytes += mb.bytes
+		rb.gas += gas
+		for i, res := range results {
+			rb.keys[txOrTxHashToKey(mb.Txs[i], res.TxHash, mem.Height())] = struct{}{}
+			if res.From != "" {
+				rb.senders[res.From] = struct{}{}
+			}
+		}
+		reaped++
+	}
+	if reaped > 0 {
+		mem.logger.Info("ReapBundles", "ProposingHeight", height, "bundles", reaped, "txs", len(rb.txs))
+	}
+	return rb
+}
@@ -0,0 +1,206 @@
+package mempool
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/okex/exchain/libs/tendermint/abci/example/kvstore"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	cfg "github.com/okex/exchain/libs/tendermint/config"
+	"github.com/okex/exchain/libs/tendermint/proxy"
+	"github.com/okex/exchain/libs/tendermint/types"
+)
+
+// bundleApp simulates bundles of "sender:name" txs. The txs named fail* always
+// fail and the txs named spend* fail after another spend* tx in the sequence.
+type bundleApp struct {
+	*kvstore.Application
+}
+
+func (app *bundleApp) Query(req abci.RequestQuery) abci.ResponseQuery {
+	if req.Path != simulateBundlePath {
+		return app.Application.Query(req)
+	}
+	var txs types.Txs
+	if err := cdc.UnmarshalBinaryBare(req.Data, &txs); err != nil {
+		return abci.ResponseQuery{Code: 1, Log: err.Error()}
+	}
+	var res BundleSimulationResponse
+	var spent bool
+	for _, tx := range txs {
+		parts := bytes.SplitN(tx, []byte(":"), 2)
+		r := BundleTxResult{GasWanted: 1, GasUsed: 1, From: string(parts[0])}
+		name := parts[len(parts)-1]
+		switch {
+		case bytes.HasPrefix(name, []byte("fail")):
+			r.Code, r.Log = 1, "failed"
+		case bytes.HasPrefix(name, []byte("spend")):
+			if spent {
+				r.Code, r.Log = 1, "already spent"
+			}
+			spent = true
+		}
+		res.Results = append(res.Results, r)
+	}
+	return abci.ResponseQuery{Value: cdc.MustMarshalBinaryBare(res)}
+}
+
+func newBundleMempool(t *testing.T) (*CListMempool, cleanupFunc) {
+	config := cfg.ResetTestRoot("mempool_test")
+	config.Mempool.EnableBundles = true
+	config.Mempool.MaxBundles = 4
+	config.Mempool.MaxBundleTxs = 3
+	config.Mempool.MaxBundlesPerBlock = 2
+	config.Mempool.MaxBundleSimulations = 3
+	config.Mempool.MaxBundleBlocksAhead = 5
+	app := &bundleApp{kvstore.NewApplication()}
+	return newMempoolWithAppAndConfig(proxy.NewLocalClientCreator(app), config)
+}
+
+func newBundle(height int64, txs ...string) Bundle {
+	bundle := Bundle{TargetHeight: height}
+	for _, tx := range txs {
+		bundle.Txs = append(bundle.Txs, types.Tx(tx))
+	}
+	return bundle
+}
+
+func TestAddBundle(t *testing.T) {
+	mempool, cleanup := newBundleMempool(t)
+	defer cleanup()
+
+	hash, err := mempool.AddBundle(newBundle(1, "a:0", "b:0"))
+	require.NoError(t, err)
+	require.Equal(t, newBundle(1, "a:0", "b:0").Hash(), hash)
+	_, err = mempool.AddBundle(newBundle(1, "a:0", "b:0"))
+	require.Equal(t, ErrBundleExists, err)
+
+	// invalid bundles
+	for name, bundle := range map[string]Bundle{
+		"empty":         newBundle(1),
+		"too many txs":  newBundle(1, "a:1", "a:2", "a:3", "a:4"),
+		"committed":     newBundle(0, "a:1"),
+		"too far ahead": newBundle(6, "a:1"),
+		"duplicate tx":  newBundle(1, "a:1", "a:1"),
+		"failed tx":     newBundle(1, "a:1", "a:fail"),
+		"unknown reverting tx": {
+			Txs: types.Txs{types.Tx("a:1")}, TargetHeight: 1,
+			RevertingTxHashes: [][]byte{types.Tx("a:2").Hash(1)},
+		},
+	} {
+		_, err := mempool.AddBundle(bundle)
+		require.Error(t, err, name)
+	}
+
+	// the failed tx is allowed to revert
+	bundle := newBundle(1, "a:1", "a:fail")
+	bundle.RevertingTxHashes = [][]byte{types.Tx("a:fail").Hash(1)}
+	_, err = mempool.AddBundle(bundle)
+	require.NoError(t, err)
+
+	_, err = mempool.AddBundle(newBundle(5, "a:2"))
+	require.NoError(t, err)
+	_, err = mempool.AddBundle(newBundle(5, "a:3"))
+	require.NoError(t, err)
+	_, err = mempool.AddBundle(newBundle(5, "a:4"))
+	require.IsType(t, ErrBundlePoolIsFull{}, err)
+	require.Equal(t, 4, mempool.bundles.size())
+
+	// the bundles of the committed heights are dropped
+	mempool.Lock()
+	require.NoError(t, mempool.Update(1, nil, nil, nil, nil))
+	mempool.Unlock()
+	require.Equal(t, 2, mempool.bundles.size())
+
+	mempool.Flush()
+	require.Equal(t, 0, mempool.bundles.size())
+
+	mempool.config.EnableBundles = false
+	_, err = mempool.AddBundle(newBundle(2, "a:5"))
+	require.Equal(t, ErrBundlesDisabled, err)
+}
+
+func TestReapBundles(t *testing.T) {
+	mempool, cleanup := newBundleMempool(t)
+	defer cleanup()
+	mempool.config.MaxBundles = 10
+
+	mempool.addTx(newLaneTx("m:0", "m", 900, 0, ""))
+	mempool.addTx(newLaneTx("a:0", "a", 800, 0, ""))
+	mempool.addTx(newLaneTx("b:0", "b", 700, 0, ""))
+	mempool.addTx(newLaneTx("n:0", "n", 600, 0, ""))
+
+	for _, bundle := range []Bundle{
+		newBundle(1, "a:0", "a:spend0"),
+		// conflicts with the first bundle
+		newBundle(1, "b:0", "b:spend1"),
+		newBundle(1, "c:0", "c:1"),
+		newBundle(1, "d:0"),
+		newBundle(2, "e:0"),
+	} {
+		_, err := mempool.AddBundle(bundle)
+		require.NoError(t, err)
+	}
+
+	// the bundles are on top of the block, the txs of their senders are left in the mempool
+	txs := mempool.ReapMaxBytesMaxGas(-1, -1)
+	require.Equal(t, types.Txs{
+		types.Tx("a:0"), types.Tx("a:spend0"), types.Tx("c:0"), types.Tx("c:1"),
+		types.Tx("m:0"), types.Tx("b:0"), types.Tx("n:0"),
+	}, types.Txs(txs))
+	// the conflicting bundle is dropped
+	require.Equal(t, 4, mempool.bundles.size())
+	require.False(t, mempool.bundles.has(newBundle(1, "b:0", "b:spend1").Hash()))
+
+	// a bundle is included as a whole or not at all
+	txBytes := int64(3) + types.ComputeAminoOverhead(types.Tx("a:0"), 1)
+	spendBytes := int64(8) + types.ComputeAminoOverhead(types.Tx("a:spend0"), 1)
+	txs = mempool.ReapMaxBytesMaxGas(txBytes+spendBytes+2*txBytes-1, -1)
+	require.Equal(t, types.Txs{
+		types.Tx("a:0"), types.Tx("a:spend0"), types.Tx("d:0"),
+	}, types.Txs(txs))
+
+	txs = mempool.ReapMaxBytesMaxGas(-1, 3)
+	require.Equal(t, types.Txs{
+		types.Tx("a:0"), types.Tx("a:spend0"), types.Tx("d:0"),
+	}, types.Txs(txs))
+
+	// the lanes are filled after the bundles
+	mempool.lanes = []cfg.MempoolLane{{Name: "system", Percent: 50}}
+	mempool.txLane = func(abci.TxEssentials) string { return "" }
+	mempool.addTx(newLaneTx("s:0", "s", 100, 0, "system"))
+	txs = mempool.ReapMaxBytesMaxGas(-1, -1)
+	require.Equal(t, types.Txs{
+		types.Tx("a:0"), types.Tx("a:spend0"), types.Tx("c:0"), types.Tx("c:1"),
+		types.Tx("s:0"), types.Tx("m:0"), types.Tx("b:0"), types.Tx("n:0"),
+	}, types.Txs(txs))
+}
+
+func TestReapBundlesMaxSimulations(t *testing.T) {
+	mempool, cleanup := newBundleMempool(t)
+	defer cleanup()
+	mempool.config.MaxBundles = 10
+
+	for _, bundle := range []Bundle{
+		newBundle(1, "a:spend0"),
+		// conflict with the first bundle
+		newBundle(1, "b:spend1"),
+		newBundle(1, "c:spend2"),
+		newBundle(1, "d:spend3"),
+		newBundle(1, "e:0"),
+	} {
+		_, err := mempool.AddBundle(bundle)
+		require.NoError(t, err)
+	}
+
+	// the failing bundles count toward the simulations and are dropped
+	txs := mempool.ReapMaxBytesMaxGas(-1, -1)
+	require.Equal(t, types.Txs{types.Tx("a:spend0")}, types.Txs(txs))
+	require.Equal(t, 3, mempool.bundles.size())
+
+	txs = mempool.ReapMaxBytesMaxGas(-1, -1)
+	require.Equal(t, types.Txs{types.Tx("a:spend0"), types.Tx("e:0")}, types.Txs(txs))
+	require.Equal(t, 2, mempool.bundles.size())
+}
@@ -89,6 +89,13 @@ type CListMempool struct {
 
 	txInfoparser TxInfoParser
 
+	// lanes in priority order, txs are assigned to lanes by txLane
+	lanes  []cfg.MempoolLane
+	txLane TxLaneFunc
+
+	// bundles waiting for their target block
+	bundles *bundlePool
+
 	checkCnt    int64
 	checkRPCCnt int64
 	checkP2PCnt int64
@@ -136,12 +143,15 @@ func NewCListMempool(
 	if config.SortTxByGp {
 		txQueue = NewOptimizedTxQueue(int64(config.TxPriceBump))
 	} else {
-		txQueue = NewBaseTxQueue()
+		txQueue = NewBaseTxQueue(int64(config.TxPriceBump))
 	}
 
 	gpoConfig := NewGPOConfig(cfg.DynamicConfig.GetDynamicGpWeight(), cfg.DynamicConfig.GetDynamicGpCheckBlocks())
 	gpo := NewOracle(gpoConfig)
 
+	// the lanes are validated with the config
+	lanes, _ := cfg.ParseMempoolLanes(config.Lanes)
+
 	mempool := &CListMempool{
 		config:        config,
 		proxyAppConn:  proxyAppConn,
@@ -156,6 +166,8 @@ func NewCListMempool(
 		simQueue:      make(chan *mempoolTx, 200000),
 		gpo:           gpo,
 		peersTxCount:  make(map[string]uint64, 0),
+		lanes:         lanes,
+		bundles:       newBundlePool(),
 	}
 
 	if config.PendingRemoveEvent {
@@ -220,6 +232,11 @@ func WithPostCheck(f PostCheckFunc) CListMempoolOption {
 	return func(mem *CListMempool) { mem.postCheck = f }
 }
 
+// WithTxLane sets the function assigning txs to the mempool lanes.
+func WithTxLane(f TxLaneFunc) CListMempoolOption {
+	return func(mem *CListMempool) { mem.txLane = f }
+}
+
 // WithMetrics sets the metrics.
 func WithMetrics(metrics *Metrics) CListMempoolOption {
 	return func(mem *CListMempool) { mem.metrics = metrics }
@@ -266,6 +283,7 @@ func (mem *CListMempool) Flush() {
 
 	_ = atomic.SwapInt64(&mem.txsBytes, 0)
 	mem.cache.Reset()
+	mem.bundles.reset()
 }
 
 // TxsFront returns the first transaction in the ordered list for peer
@@ -512,9 +530,14 @@ func (mem *CListMempool) reqResCb(
 // Called from:
 //   - resCbFirstTime (lock not held) if tx is valid
 func (mem *CListMempool) addTx(memTx *mempoolTx) error {
-	if err := mem.txs.Insert(memTx); err != nil {
+	replaced, err := mem.txs.Insert(memTx)
+	if err != nil {
 		return err
 	}
+	if replaced != nil {
+		atomic.AddInt64(&mem.txsBytes, int64(-len(replaced.tx)))
+		mem.evictReplacedTx(replaced)
+	}
 	if cfg.DynamicConfig.GetMaxGasUsedPerBlock() > -1 && cfg.DynamicConfig.GetEnablePGU() && atomic.LoadUint32(&memTx.isSim) == 0 {
 		select {
 		case mem.simQueue <- memTx:
@@ -536,6 +559,25 @@ func (mem *CListMempool) addTx(memTx *mempoolTx) error {
 	return nil
 }
 
+// evictReplacedTx cleans up the tx replaced by a tx of the same sender and nonce with a bumped gas price
+func (mem *CListMempool) evictReplacedTx(replaced *mempoolTx) {
+	var replacedHash []byte
+	if replaced.realTx != nil {
+		replacedHash = replaced.realTx.TxHash()
+	}
+	mem.logger.Debug("mempool", "replace Tx", hex.EncodeToString(replacedHash), "nonce", replaced.realTx.GetNonce(), "gp", replaced.realTx.GetGasPrice())
+	mem.cache.RemoveKey(txOrTxHashToKey(replaced.tx, replacedHash, replaced.Height()))
+
+	if mem.config.PendingRemoveEvent {
+		mem.rmPendingTxChan <- types.EventDataRmPendingTx{
+			Hash:   replacedHash,
+			From:   replaced.realTx.GetEthAddr(),
+			Nonce:  replaced.realTx.GetNonce(),
+			Reason: types.Replaced,
+		}
+	}
+}
+
 // Called from:
 //   - Update (lock held) if tx was committed
 //   - resCbRecheck (lock not held) if tx was invalidated
@@ -602,12 +644,14 @@ func (mem *CListMempool) addPendingTx(memTx *mempoolTx) error {
 		return err
 	}
 
-	// add tx to PendingPool
-	if err := mem.pendingPool.validate(memTx.from, memTx.tx, memTx.height); err != nil {
+	// add tx to PendingPool, a pending tx of the same nonce is replaced only with a bumped gas price
+	if err := mem.pendingPool.validate(memTx, int64(mem.config.TxPriceBump)); err != nil {
 		return err
 	}
 	pendingTx := memTx
-	mem.pendingPool.addTx(pendingTx)
+	if replaced := mem.pendingPool.addTx(pendingTx); replaced != nil {
+		mem.evictReplacedTx(replaced)
+	}
 	mem.logger.Debug("mempool", "add-pending-Tx", hex.EncodeToString(memTx.realTx.TxHash()), "nonce", memTx.realTx.GetNonce(), "gp", memTx.realTx.GetGasPrice())
 
 	mem.logger.Debug("pending pool addTx", "tx", pendingTx)
@@ -751,6 +795,7 @@ func (mem *CListMempool) resCbFirstTime(
 				signature:   txInfo.wtx.GetSignature(),
 				from:        r.CheckTx.Tx.GetEthAddr(),
 				senderNonce: r.CheckTx.SenderNonce,
+				lane:        mem.getTxLane(r.CheckTx.Tx),
 			}
 			if txInfo.isGasPrecise {
 				// gas for hgu is precise, just mark it simulated, so it will not be simulated again
@@ -821,6 +866,9 @@ func (mem *CListMempool) resCbRecheck(req *abci.Request, res *abci.Response) {
 		}
 		if (r.CheckTx.Code == abci.CodeTypeOK) && postCheckErr == nil {
 			// Good, nothing to do.
+		} else if mem.recheckCursor.Removed() {
+			// Tx was replaced by a tx of the same nonce while it was being rechecked,
+			// it has already been evicted.
 		} else {
 			// Tx became invalidated due to newly committed block.
 			mem.logger.Info("Tx is no longer valid", "tx", txIDStringer{tx, memTx.height}, "res", r, "err", postCheckErr)
@@ -907,8 +955,22 @@ func (mem *CListMempool) ReapMaxBytesMaxGas(maxBytes, maxGas int64) []types.Tx {
 		mem.info.txCount = simCount
 		mem.info.gasUsed = simGas
 	}()
+	// the bundles are at the top of the block
+	bundles := mem.reapBundles(reapLimits{bytes: maxBytes, gas: maxGas, txNum: cfg.DynamicConfig.GetMaxTxNumPerBlock()})
+	if len(mem.lanes) > 0 && mem.txLane != nil {
+		txs, simCount, simGas = mem.reapLanes(maxBytes, maxGas, bundles)
+		return txs
+	}
+	txs = append(txs, bundles.txs...)
+	totalBytes, totalGas, totalTxNum = bundles.bytes, bundles.gas, int64(len(bundles.txs))
+	for key := range bundles.keys {
+		txFilter[key] = struct{}{}
+	}
 	for e := mem.txs.Front(); e != nil; e = e.Next() {
 		memTx := e.Value.(*mempoolTx)
+		if bundles.hasSender(memTx.from) {
+			continue
+		}
 		key := txOrTxHashToKey(memTx.tx, memTx.realTx.TxHash(), mem.Height())
 		if _, ok := txFilter[key]; ok {
 			// Just log error and ignore the dup tx. and it will be packed into the next block and deleted from mempool
@@ -1033,6 +1095,7 @@ func (mem *CListMempool) Update(
 	postCheck PostCheckFunc,
 ) error {
 	mem.resetPeerCount()
+	mem.bundles.prune(height)
 	// no need to update when mempool is unavailable
 	if mem.config.Sealed {
 		return mem.updateSealed(height, txs, deliverTxResponses)
@@ -1288,6 +1351,16 @@ func MultiPriceBump(rawPrice *big.Int, priceBump int64) *big.Int {
 	return new(big.Int).Add(inc, rawPrice)
 }
 
+// checkReplaceGasPrice checks that the gas price of a tx replacing the one of the same sender and nonce
+// is bigger than its gas price bumped by priceBump percent
+func checkReplaceGasPrice(address string, nonce uint64, oldPrice, newPrice *big.Int, priceBump int64) error {
+	minPrice := MultiPriceBump(oldPrice, priceBump)
+	if newPrice.Cmp(minPrice) <= 0 {
+		return ErrTxReplaceUnderpriced{address: address, nonce: nonce, gasPrice: newPrice, minPrice: minPrice}
+	}
+	return nil
+}
+
 //--------------------------------------------------------------------------------
 
 // mempoolTx is a transaction that successfully ran
@@ -1311,6 +1384,9 @@ type mempoolTx struct {
 	isWrapCMTx  bool
 	wrapCMNonce uint64
 
+	// lane is the mempool lane the tx was assigned to
+	lane string
+
 	// ids of peers who've sent us this tx (as a map for quick lookups).
 	// senders: PeerID -> bool
 	senders   map[uint16]struct{}
@@ -1345,6 +1421,7 @@ type txCache interface {
 	Reset()
 	Push(tx types.Tx) bool
 	PushKey(key [sha256.Size]byte) bool
+	Has(key [sha256.Size]byte) bool
 	Remove(tx types.Tx)
 	RemoveKey(key [sha256.Size]byte)
 }
@@ -1395,6 +1472,14 @@ func (cache *mapTxCache) PushKey(txHash [32]byte) bool {
 	return true
 }
 
+// Has returns true if the tx with the given key is in the cache.
+func (cache *mapTxCache) Has(txHash [32]byte) bool {
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+
+	return cache.cacheMap.Has(txHash[:])
+}
+
 // Remove removes the given tx from the cache.
 func (cache *mapTxCache) Remove(tx types.Tx) {
 	txHash := txKey(tx)
@@ -1412,6 +1497,7 @@ var _ txCache = (*nopTxCache)(nil)
 func (nopTxCache) Reset()                    {}
 func (nopTxCache) Push(types.Tx) bool        { return true }
 func (nopTxCache) PushKey(key [32]byte) bool { return true }
+func (nopTxCache) Has(key [32]byte) bool     { return false }
 func (nopTxCache) Remove(types.Tx)           {}
 func (nopTxCache) RemoveKey(key [32]byte)    {}
 
@@ -1461,6 +1547,10 @@ func (mem *CListMempool) SetTxInfoParser(parser TxInfoParser) {
 	mem.txInfoparser = parser
 }
 
+func (mem *CListMempool) SetTxLaneFunc(f TxLaneFunc) {
+	mem.txLane = f
+}
+
 func (mem *CListMempool) pendingPoolJob() {
 	for addressNonce := range mem.pendingPoolNotify {
 		timeStart := time.Now()
@@ -639,6 +639,90 @@ func TestReplaceTx(t *testing.T) {
 	require.Equal(t, []uint64{9740, 5853, 9227, 9526, 9140}, gasPrices)
 }
 
+func TestReplaceTxInFIFOQueue(t *testing.T) {
+	app := kvstore.NewApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	config := cfg.ResetTestRoot("mempool_test")
+	config.Mempool.SortTxByGp = false
+	mempool, cleanup := newMempoolWithAppAndConfig(cc, config)
+	defer cleanup()
+
+	testCases := []struct {
+		Tx     *mempoolTx
+		expErr bool
+	}{
+		{&mempoolTx{height: 1, gasWanted: 1, tx: []byte("10000"), from: "1", realTx: abci.MockTx{GasPrice: big.NewInt(9740)}}, false},
+		{&mempoolTx{height: 1, gasWanted: 1, tx: []byte("20000"), from: "2", realTx: abci.MockTx{GasPrice: big.NewInt(5000)}}, false},
+		{&mempoolTx{height: 1, gasWanted: 1, tx: []byte("10001"), from: "1", realTx: abci.MockTx{GasPrice: big.NewInt(5853), Nonce: 1}}, false},
+		{&mempoolTx{height: 1, gasWanted: 1, tx: []byte("10002"), from: "1", realTx: abci.MockTx{GasPrice: big.NewInt(8315), Nonce: 2}}, false},
+		{&mempoolTx{height: 1, gasWanted: 1, tx: []byte("10003"), from: "1", realTx: abci.MockTx{GasPrice: big.NewInt(6500), Nonce: 1}}, false},
+		{&mempoolTx{height: 1, gasWanted: 1, tx: []byte("10004"), from: "1", realTx: abci.MockTx{GasPrice: big.NewInt(7000), Nonce: 1}}, true},
+	}
+	for _, tc := range testCases {
+		err := mempool.addTx(tc.Tx)
+		if tc.expErr {
+			require.IsType(t, ErrTxReplaceUnderpriced{}, err)
+		} else {
+			require.NoError(t, err)
+		}
+	}
+	require.Equal(t, 4, mempool.Size())
+
+	// the replacing tx is appended with the later txs of its sender
+	var txs []string
+	for e := mempool.txs.Front(); e != nil; e = e.Next() {
+		txs = append(txs, string(e.Value.(*mempoolTx).tx))
+	}
+	require.Equal(t, []string{"10000", "20000", "10003", "10002"}, txs)
+	_, found := mempool.txs.Load(txKey([]byte("10001")))
+	require.False(t, found)
+	nonce, _ := mempool.txs.GetAddressNonce("1")
+	require.Equal(t, uint64(2), nonce)
+	require.Equal(t, 3, mempool.txs.GetAddressTxsCnt("1"))
+}
+
+func TestReplaceTxWhileRechecking(t *testing.T) {
+	app := kvstore.NewApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	config := cfg.ResetTestRoot("mempool_test")
+	config.Mempool.PendingRemoveEvent = true
+	mempool, cleanup := newMempoolWithAppAndConfig(cc, config)
+	defer cleanup()
+	rmPendingTxChan := make(chan types.EventDataRmPendingTx, 10)
+	mempool.rmPendingTxChan = rmPendingTxChan
+
+	oldTx := &mempoolTx{height: 1, gasWanted: 1, tx: []byte("old"), from: "1",
+		realTx: abci.MockTx{Hash: []byte("old"), From: "1", GasPrice: big.NewInt(1000)}}
+	require.NoError(t, mempool.addTx(oldTx))
+
+	// the gas price is not bumped enough
+	underpriced := &mempoolTx{height: 1, gasWanted: 1, tx: []byte("underpriced"), from: "1",
+		realTx: abci.MockTx{Hash: []byte("underpriced"), From: "1", GasPrice: big.NewInt(1100)}}
+	require.IsType(t, ErrTxReplaceUnderpriced{}, mempool.addTx(underpriced))
+
+	// the tx is replaced while it's being rechecked
+	mempool.recheckCursor = mempool.txs.Front()
+	mempool.recheckEnd = mempool.txs.Back()
+	newTx := &mempoolTx{height: 1, gasWanted: 1, tx: []byte("newTx"), from: "1",
+		realTx: abci.MockTx{Hash: []byte("newTx"), From: "1", GasPrice: big.NewInt(1101)}}
+	require.NoError(t, mempool.addTx(newTx))
+	require.Equal(t, 1, mempool.Size())
+	require.EqualValues(t, len(newTx.tx), mempool.TxsBytes())
+
+	event := <-rmPendingTxChan
+	require.Equal(t, []byte("old"), event.Hash)
+	require.Equal(t, types.Replaced, event.Reason)
+
+	// the recheck response of the replaced tx must not evict the new tx
+	mempool.resCbRecheck(abci.ToRequestCheckTx(abci.RequestCheckTx{Tx: oldTx.tx}),
+		abci.ToResponseCheckTx(abci.ResponseCheckTx{Code: 1}))
+	require.Nil(t, mempool.recheckCursor)
+	require.Equal(t, 1, mempool.Size())
+	require.EqualValues(t, len(newTx.tx), mempool.TxsBytes())
+	require.Equal(t, newTx, mempool.txs.Front().Value.(*mempoolTx))
+	require.Len(t, rmPendingTxChan, 0)
+}
+
 func TestAddAndSortTxByRandom(t *testing.T) {
 	app := kvstore.NewApplication()
 	cc := proxy.NewLocalClientCreator(app)
@@ -938,7 +1022,7 @@ func BenchmarkMempoolLogAddTx(b *testing.B) {
 	options = append(options, log.AllowErrorWith("module", "benchmark"))
 	logger = log.NewFilter(logger, options...)
 
-	mem := &CListMempool{height: 123456, logger: logger, txs: NewBaseTxQueue()}
+	mem := &CListMempool{height: 123456, logger: logger, txs: NewBaseTxQueue(0)}
 	tx := []byte("tx")
 
 	memTx := &mempoolTx{
@@ -2,6 +2,7 @@ package mempool
 
 import (
 	"fmt"
+	"math/big"
 
 	"github.com/pkg/errors"
 )
@@ -11,6 +12,10 @@ var (
 	ErrTxInCache = errors.New("tx already exists in cache")
 	// ErrNoSuchTx is returned to the client if there hasn't target tx in mempool
 	ErrNoSuchTx = errors.New("no such tx in mempool")
+	// ErrBundlesDisabled is returned when a bundle is sent to a mempool not accepting bundles
+	ErrBundlesDisabled = errors.New("bundles are not enabled")
+	// ErrBundleExists is returned if the bundle was accepted earlier
+	ErrBundleExists = errors.New("bundle already exists")
 )
 
 // ErrTxTooLarge means the tx is too big to be sent in a message to other peers
@@ -39,6 +44,41 @@ func (e ErrMempoolIsFull) Error() string {
 		e.txsBytes, e.maxTxsBytes)
 }
 
+// ErrBundlePoolIsFull means the bundles waiting for their target block reach the limit
+type ErrBundlePoolIsFull struct {
+	maxBundles int
+}
+
+func (e ErrBundlePoolIsFull) Error() string {
+	return fmt.Sprintf("bundle pool is full: max bundles %d", e.maxBundles)
+}
+
+// ErrBundleTxReverted means a tx of the bundle not allowed to revert failed in the simulation
+type ErrBundleTxReverted struct {
+	index int
+	hash  string
+	log   string
+}
+
+func (e ErrBundleTxReverted) Error() string {
+	return fmt.Sprintf("tx %d (%s) of the bundle reverted: %s", e.index, e.hash, e.log)
+}
+
+// ErrTxReplaceUnderpriced means the tx can't replace the tx of the same sender and nonce,
+// its gas price is not bumped enough
+type ErrTxReplaceUnderpriced struct {
+	address  string
+	nonce    uint64
+	gasPrice *big.Int
+	minPrice *big.Int
+}
+
+func (e ErrTxReplaceUnderpriced) Error() string {
+	return fmt.Sprintf(
+		"failed to replace tx for account %s with nonce %d, the provided gas price %d must be bigger than %d",
+		e.address, e.nonce, e.gasPrice, e.minPrice)
+}
+
 // ErrPreCheck is returned when tx is too big
 type ErrPreCheck struct {
 	Reason error
@@ -82,7 +82,8 @@ func (p *PendingPool) hasTx(tx types.Tx, height int64) bool {
 	return exist
 }
 
-func (p *PendingPool) addTx(pendingTx *mempoolTx) {
+// addTx adds the tx to the pending pool, the replaced tx of the same sender and nonce is returned
+func (p *PendingPool) addTx(pendingTx *mempoolTx) *mempoolTx {
 	p.mtx.Lock()
 	defer p.mtx.Unlock()
 	blacklist := strings.Split(cfg.DynamicConfig.GetPendingPoolBlacklist(), ",")
@@ -90,14 +91,20 @@ func (p *PendingPool) addTx(pendingTx *mempoolTx) {
 	// Above case should be avoided.
 	for _, address := range blacklist {
 		if address != "" && pendingTx.from == address {
-			return
+			return nil
 		}
 	}
 	if _, ok := p.addressTxsMap[pendingTx.from]; !ok {
 		p.addressTxsMap[pendingTx.from] = make(map[uint64]*mempoolTx)
 	}
-	p.addressTxsMap[pendingTx.from][pendingTx.realTx.GetNonce()] = pendingTx
+	nonce := pendingTx.realTx.GetNonce()
+	replaced := p.addressTxsMap[pendingTx.from][nonce]
+	if replaced != nil {
+		delete(p.txsMap, txID(replaced.tx, replaced.height))
+	}
+	p.addressTxsMap[pendingTx.from][nonce] = pendingTx
 	p.txsMap[txID(pendingTx.tx, pendingTx.height)] = pendingTx
+	return replaced
 }
 
 func (p *PendingPool) removeTx(address string, nonce uint64) {
@@ -180,7 +187,8 @@ func (p *PendingPool) handlePeriodCounter() {
 	}
 }
 
-func (p *PendingPool) validate(address string, tx types.Tx, height int64) error {
+func (p *PendingPool) validate(memTx *mempoolTx, txPriceBump int64) error {
+	address, tx, height := memTx.from, memTx.tx, memTx.height
 	// tx already in pending pool
 	if p.hasTx(tx, height) {
 		return ErrTxAlreadyInPendingPool{
@@ -188,6 +196,12 @@ func (p *PendingPool) validate(address string, tx types.Tx, height int64) error
 		}
 	}
 
+	// the tx replaces the pending tx of the same nonce, it doesn't take more space
+	nonce := memTx.realTx.GetNonce()
+	if pendingTx := p.getTx(address, nonce); pendingTx != nil {
+		return checkReplaceGasPrice(address, nonce, pendingTx.realTx.GetGasPrice(), memTx.realTx.GetGasPrice(), txPriceBump)
+	}
+
 	poolSize := p.Size()
 	if poolSize >= p.maxSize {
 		return ErrPendingPoolIsFull{
@@ -60,7 +60,12 @@ func TestAddtxRandom(t *testing.T) {
 		txRes := pool.addressTxsMap[tx.from][tx.realTx.GetNonce()]
 		assert.Equal(t, tx, txRes)
 	}
-	assert.Equal(t, txCount, pool.Size(), fmt.Sprintf("Expected to txs length %v but got %v", txCount, pool.Size()))
+	// txs of the same address and nonce are replaced
+	nonceCount := 0
+	for _, txsMap := range pool.addressTxsMap {
+		nonceCount += len(txsMap)
+	}
+	assert.Equal(t, nonceCount, pool.Size(), fmt.Sprintf("Expected to txs length %v but got %v", nonceCount, pool.Size()))
 }
 
 func TestRemovetx(t *testing.T) {
@@ -204,3 +209,26 @@ func TestHandlePeriodCounter(t *testing.T) {
 	pool.handlePeriodCounter()
 	assert.Equal(t, 0, pool.Size())
 }
+
+func TestReplacePendingTx(t *testing.T) {
+	pool := newPendingPool(100, 3, 10, 1)
+	tx := &mempoolTx{height: 1, gasWanted: 1, tx: []byte("1"), from: "1", realTx: abci.MockTx{GasPrice: big.NewInt(1000), Nonce: 5}}
+	assert.NoError(t, pool.validate(tx, 10))
+	assert.Nil(t, pool.addTx(tx))
+
+	// the price bump is not enough
+	underpriced := &mempoolTx{height: 1, gasWanted: 1, tx: []byte("2"), from: "1", realTx: abci.MockTx{GasPrice: big.NewInt(1100), Nonce: 5}}
+	assert.IsType(t, ErrTxReplaceUnderpriced{}, pool.validate(underpriced, 10))
+
+	// the replacement is allowed even though the address limit is reached
+	replacement := &mempoolTx{height: 1, gasWanted: 1, tx: []byte("3"), from: "1", realTx: abci.MockTx{GasPrice: big.NewInt(1101), Nonce: 5}}
+	assert.NoError(t, pool.validate(replacement, 10))
+	assert.Equal(t, tx, pool.addTx(replacement))
+	assert.Equal(t, 1, pool.Size())
+	assert.Equal(t, replacement, pool.getTx("1", 5))
+	assert.False(t, pool.hasTx(tx.tx, tx.height))
+
+	// a new nonce is still limited
+	next := &mempoolTx{height: 1, gasWanted: 1, tx: []byte("4"), from: "1", realTx: abci.MockTx{GasPrice: big.NewInt(1000), Nonce: 6}}
+	assert.IsType(t, ErrPendingPoolAddressLimit{}, pool.validate(next, 10))
+}
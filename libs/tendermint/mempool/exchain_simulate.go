@@ -31,3 +31,19 @@ type Result struct {
 	// handler execution.
 	Events []abci.Event
 }
+
+// BundleTxResult is the result of a tx of a bundle simulated by the Baseapp.
+type BundleTxResult struct {
+	Code      uint32
+	Log       string
+	GasWanted uint64
+	GasUsed   uint64
+	TxHash    []byte
+	From      string
+}
+
+// BundleSimulationResponse defines the response generated when a bundle is simulated
+// by the Baseapp, with the result of every tx in order.
+type BundleSimulationResponse struct {
+	Results []BundleTxResult
+}
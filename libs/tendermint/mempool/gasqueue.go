@@ -2,7 +2,6 @@ package mempool
 
 import (
 	"crypto/sha256"
-	"fmt"
 	"sort"
 	"sync"
 
@@ -33,16 +32,15 @@ func (q *GasTxQueue) Len() int {
 	return q.sortedTxs.Len()
 }
 
-func (q *GasTxQueue) Insert(memTx *mempoolTx) error {
+func (q *GasTxQueue) Insert(memTx *mempoolTx) (*mempoolTx, error) {
 	/*
 		1. insert tx list
 		2. insert address record
 		3. insert tx map
 	*/
-	ele := q.AddressRecord.checkRepeatedAndAddItem(memTx, q.txPriceBump, q.sortedTxs.InsertElement)
-	if ele == nil {
-		return fmt.Errorf("failed to replace tx for acccount %s with nonce %d, "+
-			"the provided gas price %d is not bigger enough", memTx.from, memTx.realTx.GetNonce(), memTx.realTx.GetGasPrice())
+	ele, replaced, err := q.AddressRecord.checkRepeatedAndAddItem(memTx, q.txPriceBump, q.sortedTxs.InsertElement)
+	if err != nil {
+		return nil, err
 	}
 	txHash := txOrTxHashToKey(memTx.tx, memTx.realTx.TxHash(), memTx.height)
 
@@ -51,7 +49,10 @@ func (q *GasTxQueue) Insert(memTx *mempoolTx) error {
 	ele2 := q.bcTxs.PushBack(memTx)
 	ele2.Address = memTx.from
 	q.bcTxsMap.Store(txHash, ele2)
-	return nil
+	if replaced != nil {
+		return replaced.Value.(*mempoolTx), nil
+	}
+	return nil, nil
 }
 
 func (q *GasTxQueue) Remove(element *clist.CElement) {
@@ -0,0 +1,158 @@
+package mempool
+
+import (
+	"encoding/hex"
+	"sync/atomic"
+
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	cfg "github.com/okex/exchain/libs/tendermint/config"
+	"github.com/okex/exchain/libs/tendermint/types"
+)
+
+// TxLaneClassifier is implemented by apps which assign txs to the mempool lanes.
+type TxLaneClassifier interface {
+	GetTxLane(tx abci.TxEssentials) string
+}
+
+func (mem *CListMempool) getTxLane(tx abci.TxEssentials) string {
+	if mem.txLane == nil || tx == nil {
+		return ""
+	}
+	return mem.txLane(tx)
+}
+
+// reapLimits are the block space limits of a reap, negative bytes or gas are unlimited
+type reapLimits struct {
+	bytes int64
+	gas   int64
+	txNum int64
+}
+
+// share returns the limits reserved by percent
+func (l reapLimits) share(percent int64) reapLimits {
+	share := reapLimits{bytes: -1, gas: -1, txNum: l.txNum * percent / 100}
+	if l.bytes > -1 {
+		share.bytes = l.bytes * percent / 100
+	}
+	if l.gas > -1 {
+		share.gas = l.gas * percent / 100
+	}
+	return share
+}
+
+type laneReaper struct {
+	mem    *CListMempool
+	limits reapLimits
+
+	totalBytes int64
+	totalGas   int64
+	txs        []types.Tx
+	simCount   int64
+	simGas     int64
+
+	// visited are the txs reaped or skipped as duplicates
+	visited  map[*mempoolTx]struct{}
+	txFilter map[[32]byte]struct{}
+	bundles  *reapedBundles
+}
+
+// reapLanes reaps the txs of the lanes in priority order, each up to the block space reserved
+// for it, and then fills the remaining block space with the txs of all lanes in mempool order.
+// A tx is only reaped after the txs of its sender which precede it in the mempool.
+// The reaped bundles take the top of the block before the lanes.
+func (mem *CListMempool) reapLanes(maxBytes, maxGas int64, bundles *reapedBundles) ([]types.Tx, int64, int64) {
+	r := &laneReaper{
+		mem: mem,
+		limits: reapLimits{
+			bytes: maxBytes,
+			gas:   maxGas,
+			txNum: cfg.DynamicConfig.GetMaxTxNumPerBlock(),
+		},
+		totalBytes: bundles.bytes,
+		totalGas:   bundles.gas,
+		txs:        make([]types.Tx, 0, mem.txs.Len()+len(bundles.txs)),
+		visited:    make(map[*mempoolTx]struct{}),
+		txFilter:   make(map[[32]byte]struct{}, len(bundles.keys)),
+		bundles:    bundles,
+	}
+	r.txs = append(r.txs, bundles.txs...)
+	for key := range bundles.keys {
+		r.txFilter[key] = struct{}{}
+	}
+	for _, lane := range mem.lanes {
+		lane := lane
+		r.fill(&lane)
+	}
+	r.fill(nil)
+	return r.txs, r.simCount, r.simGas
+}
+
+// fill reaps the txs of the lane within its reserved block space, or the txs of all lanes
+// within the block space left if lane is nil.
+func (r *laneReaper) fill(lane *cfg.MempoolLane) {
+	limits := r.limits
+	if lane != nil {
+		limits = r.limits.share(lane.Percent)
+	}
+	var laneBytes, laneGas, laneTxNum int64
+	blocked := make(map[string]struct{})
+
+	for e := r.mem.txs.Front(); e != nil; e = e.Next() {
+		memTx := e.Value.(*mempoolTx)
+		if _, ok := r.visited[memTx]; ok {
+			continue
+		}
+		if _, ok := blocked[memTx.from]; ok {
+			continue
+		}
+		if r.bundles.hasSender(memTx.from) {
+			continue
+		}
+		if lane != nil && memTx.lane != lane.Name {
+			// later txs of the sender must wait for this one
+			if memTx.from != "" {
+				blocked[memTx.from] = struct{}{}
+			}
+			continue
+		}
+
+		key := txOrTxHashToKey(memTx.tx, memTx.realTx.TxHash(), r.mem.Height())
+		if _, ok := r.txFilter[key]; ok {
+			// Just log error and ignore the dup tx. and it will be packed into the next block and deleted from mempool
+			r.mem.logger.Error("found duptx in same block", "tx hash", hex.EncodeToString(key[:]))
+			r.visited[memTx] = struct{}{}
+			continue
+		}
+
+		txBytes := int64(len(memTx.tx)) + types.ComputeAminoOverhead(memTx.tx, 1)
+		gasWanted := atomic.LoadInt64(&memTx.gasWanted)
+		if exceeds(r.totalBytes, txBytes, r.limits.bytes) || exceeds(laneBytes, txBytes, limits.bytes) {
+			return
+		}
+		if len(r.txs) > 0 && (exceeds(r.totalGas, gasWanted, r.limits.gas) || exceeds(laneGas, gasWanted, limits.gas)) {
+			return
+		}
+		if int64(len(r.txs)) >= r.limits.txNum || laneTxNum >= limits.txNum {
+			return
+		}
+
+		r.txFilter[key] = struct{}{}
+		r.visited[memTx] = struct{}{}
+		atomic.AddUint32(&memTx.outdated, 1)
+		r.totalBytes += txBytes
+		r.totalGas += gasWanted
+		laneBytes += txBytes
+		laneGas += gasWanted
+		laneTxNum++
+		r.txs = append(r.txs, memTx.tx)
+		r.simGas += gasWanted
+		if atomic.LoadUint32(&memTx.isSim) > 0 {
+			r.simCount++
+		}
+	}
+}
+
+// exceeds returns true if adding size to total exceeds max, a negative max is unlimited
+func exceeds(total, size, max int64) bool {
+	return max > -1 && total+size > max
+}
@@ -0,0 +1,127 @@
+package mempool
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/okex/exchain/libs/tendermint/abci/example/kvstore"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	cfg "github.com/okex/exchain/libs/tendermint/config"
+	"github.com/okex/exchain/libs/tendermint/proxy"
+	"github.com/okex/exchain/libs/tendermint/types"
+)
+
+func newLaneMempool(t *testing.T, lanes string) (*CListMempool, cleanupFunc) {
+	config := cfg.ResetTestRoot("mempool_test")
+	config.Mempool.SortTxByGp = true
+	config.Mempool.Lanes = lanes
+	mempool, cleanup := newMempoolWithAppAndConfig(proxy.NewLocalClientCreator(kvstore.NewApplication()), config)
+	// the lanes of the test txs are set directly
+	mempool.SetTxLaneFunc(func(abci.TxEssentials) string { return "" })
+	return mempool, cleanup
+}
+
+func newLaneTx(tx string, from string, gasPrice int64, nonce uint64, lane string) *mempoolTx {
+	return &mempoolTx{
+		height:    1,
+		gasWanted: 1,
+		tx:        []byte(tx),
+		from:      from,
+		lane:      lane,
+		realTx:    abci.MockTx{GasPrice: big.NewInt(gasPrice), Nonce: nonce},
+	}
+}
+
+func TestReapLanes(t *testing.T) {
+	mempool, cleanup := newLaneMempool(t, "system:20,relayer:20")
+	defer cleanup()
+
+	mempool.addTx(newLaneTx("n0", "n0", 900, 0, ""))
+	mempool.addTx(newLaneTx("n1", "n1", 800, 0, ""))
+	mempool.addTx(newLaneTx("n2", "n2", 700, 0, ""))
+	mempool.addTx(newLaneTx("n3", "n3", 600, 0, ""))
+	mempool.addTx(newLaneTx("n4", "n4", 500, 0, ""))
+	mempool.addTx(newLaneTx("n5", "n5", 400, 0, ""))
+	mempool.addTx(newLaneTx("r0", "r0", 200, 0, "relayer"))
+	mempool.addTx(newLaneTx("s0", "s0", 100, 0, "system"))
+	mempool.addTx(newLaneTx("s1", "s1", 50, 0, "system"))
+	require.Equal(t, 9, mempool.txs.Len())
+
+	// each lane has the gas of 2 txs reserved in priority order, the rest is filled in mempool order
+	txs := mempool.ReapMaxBytesMaxGas(-1, 10)
+	require.Equal(t, types.Txs{
+		[]byte("s0"), []byte("s1"), []byte("r0"),
+		[]byte("n0"), []byte("n1"), []byte("n2"), []byte("n3"), []byte("n4"), []byte("n5"),
+	}, types.Txs(txs))
+
+	// only the reserved gas of the system lane is left for it
+	txs = mempool.ReapMaxBytesMaxGas(-1, 5)
+	require.Equal(t, types.Txs{
+		[]byte("s0"), []byte("r0"), []byte("n0"), []byte("n1"), []byte("n2"),
+	}, types.Txs(txs))
+
+	// without the lanes, the low priced txs don't fit in the block
+	mempool.lanes = nil
+	txs = mempool.ReapMaxBytesMaxGas(-1, 5)
+	require.Equal(t, types.Txs{
+		[]byte("n0"), []byte("n1"), []byte("n2"), []byte("n3"), []byte("n4"),
+	}, types.Txs(txs))
+}
+
+func TestReapLanesNonceOrder(t *testing.T) {
+	mempool, cleanup := newLaneMempool(t, "system:50")
+	defer cleanup()
+
+	mempool.addTx(newLaneTx("a0", "a", 300, 0, ""))
+	mempool.addTx(newLaneTx("a1", "a", 900, 1, "system"))
+	mempool.addTx(newLaneTx("b0", "b", 500, 0, ""))
+	mempool.addTx(newLaneTx("c0", "c", 100, 0, "system"))
+	require.Equal(t, 4, mempool.txs.Len())
+
+	// a1 can't be reaped in the system lane before a0
+	txs := mempool.ReapMaxBytesMaxGas(-1, -1)
+	require.Equal(t, types.Txs{
+		[]byte("c0"), []byte("b0"), []byte("a0"), []byte("a1"),
+	}, types.Txs(txs))
+}
+
+func TestReapLanesLimits(t *testing.T) {
+	mempool, cleanup := newLaneMempool(t, "system:50")
+	defer cleanup()
+
+	for _, tx := range []*mempoolTx{
+		newLaneTx("s0", "s0", 100, 0, "system"),
+		newLaneTx("s1", "s1", 100, 0, "system"),
+		newLaneTx("s2", "s2", 100, 0, "system"),
+		newLaneTx("n0", "n0", 500, 0, ""),
+	} {
+		mempool.addTx(tx)
+	}
+
+	// the lane is limited to half of the block bytes
+	txBytes := int64(2) + types.ComputeAminoOverhead([]byte("s0"), 1)
+	txs := mempool.ReapMaxBytesMaxGas(4*txBytes, -1)
+	require.Equal(t, types.Txs{
+		[]byte("s0"), []byte("s1"), []byte("n0"), []byte("s2"),
+	}, types.Txs(txs))
+
+	txs = mempool.ReapMaxBytesMaxGas(3*txBytes, -1)
+	require.Equal(t, types.Txs{
+		[]byte("s0"), []byte("n0"), []byte("s1"),
+	}, types.Txs(txs))
+
+	// without a classifier, the lanes are ignored
+	mempool.txLane = nil
+	txs = mempool.ReapMaxBytesMaxGas(3*txBytes, -1)
+	require.Equal(t, types.Txs{
+		[]byte("n0"), []byte("s0"), []byte("s1"),
+	}, types.Txs(txs))
+}
+
+func TestReapLimitsShare(t *testing.T) {
+	limits := reapLimits{bytes: 1000, gas: -1, txNum: 300}
+	require.Equal(t, reapLimits{bytes: 250, gas: -1, txNum: 75}, limits.share(25))
+	require.Equal(t, limits, limits.share(100))
+}
@@ -27,6 +27,10 @@ type Mempool interface {
 	ReapMaxBytesMaxGas(maxBytes, maxGas int64) []types.Tx
 	ReapEssentialTx(tx types.Tx) abci.TxEssentials
 
+	// AddBundle simulates the bundle and keeps it to be reaped at the top of
+	// the block of its target height, all of its txs or none.
+	AddBundle(bundle Bundle) ([]byte, error)
+
 	// ReapMaxTxs reaps up to max transactions from the mempool.
 	// If max is negative, there is no cap on the size of all returned
 	// transactions (~ all available transactions).
@@ -90,6 +94,8 @@ type Mempool interface {
 
 	SetTxInfoParser(parser TxInfoParser)
 
+	SetTxLaneFunc(f TxLaneFunc)
+
 	GetTxSimulateGas(txHash string) int64
 
 	GetEnableDeleteMinGPTx() bool
@@ -109,6 +115,10 @@ type PreCheckFunc func(types.Tx) error
 // transaction doesn't require more gas than available for the block.
 type PostCheckFunc func(types.Tx, *abci.ResponseCheckTx) error
 
+// TxLaneFunc returns the mempool lane of a checked transaction. Transactions
+// of an unknown or empty lane only use the block space not reserved for lanes.
+type TxLaneFunc func(abci.TxEssentials) string
+
 // TxInfo are parameters that get passed when attempting to add a tx to the
 // mempool.
 type TxInfo struct {
@@ -44,6 +44,7 @@ type Reactor struct {
 	nodeKey          *p2p.NodeKey
 	nodeKeyWhitelist map[string]struct{}
 	enableWtx        bool
+	txRequests       *txRequests
 }
 
 func (memR *Reactor) SetNodeKey(key *p2p.NodeKey) {
@@ -121,6 +122,7 @@ func NewReactor(config *cfg.MempoolConfig, mempool *CListMempool) *Reactor {
 		ids:              newMempoolIDs(),
 		nodeKeyWhitelist: make(map[string]struct{}),
 		enableWtx:        cfg.DynamicConfig.GetEnableWtx(),
+		txRequests:       newTxRequests(),
 	}
 	for _, nodeKey := range config.GetNodeKeyWhitelist() {
 		memR.nodeKeyWhitelist[nodeKey] = struct{}{}
@@ -153,12 +155,19 @@ func (memR *Reactor) OnStart() error {
 // GetChannels implements Reactor.
 // It returns the list of channels for this reactor.
 func (memR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
-	return []*p2p.ChannelDescriptor{
+	channels := []*p2p.ChannelDescriptor{
 		{
 			ID:       MempoolChannel,
 			Priority: 5,
 		},
 	}
+	if memR.config.TxAnnounce {
+		channels = append(channels, &p2p.ChannelDescriptor{
+			ID:       MempoolAnnounceChannel,
+			Priority: 5,
+		})
+	}
+	return channels
 }
 
 // AddPeer implements Reactor.
@@ -269,6 +278,18 @@ func (memR *Reactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
 			txInfo.from = msg.From
 		}
 		txInfo.wrapCMTx = msg.Wtx
+	case *TxAnnounceMessage:
+		if err := memR.handleTxAnnounce(src, msg); err != nil {
+			memR.Logger.Error("Error handling tx announcement", "src", src, "msg", msg, "err", err)
+			memR.Switch.StopPeerForError(src, err)
+		}
+		return
+	case *TxRequestMessage:
+		if err := memR.handleTxRequest(src, msg); err != nil {
+			memR.Logger.Error("Error handling tx request", "src", src, "msg", msg, "err", err)
+			memR.Switch.StopPeerForError(src, err)
+		}
+		return
 	default:
 		memR.Logger.Error(fmt.Sprintf("Unknown message type %v", reflect.TypeOf(msg)))
 		return
@@ -291,6 +312,7 @@ func (memR *Reactor) broadcastTxRoutine(peer p2p.Peer) {
 		return
 	}
 	_, isInWhiteList := memR.nodeKeyWhitelist[string(peer.ID())]
+	announce := memR.peerSupportsTxAnnounce(peer)
 
 	peerID := memR.ids.GetForPeer(peer)
 	var next *clist.CElement
@@ -338,51 +360,12 @@ func (memR *Reactor) broadcastTxRoutine(peer p2p.Peer) {
 		_, ok = memTx.senders[peerID]
 		memTx.senderMtx.RUnlock()
 		if !ok {
-			var getFromPool bool
-			// send memTx
-			var msg Message
-			if memTx.nodeKey != nil && memTx.signature != nil {
-				msg = &WtxMessage{
-					Wtx: &WrappedTx{
-						Payload:   memTx.tx,
-						From:      memTx.from,
-						Signature: memTx.signature,
-						NodeKey:   memTx.nodeKey,
-					},
-				}
-			} else if memR.enableWtx {
-				if wtx, err := memR.wrapTx(memTx.tx, memTx.from); err == nil {
-					msg = &WtxMessage{
-						Wtx: wtx,
-					}
-				}
-			} else if memTx.isWrapCMTx {
-				wmsg := &WrapCMTxMessage{Wtx: &types.WrapCMTx{Tx: memTx.tx, Nonce: memTx.wrapCMNonce}}
-				if isInWhiteList {
-					wmsg.From = memTx.from
-				} else {
-					wmsg.From = ""
-				}
-				msg = wmsg
+			var success bool
+			if announce && len(memTx.tx) >= memR.config.TxAnnounceMinSize {
+				success = memR.announceTx(peer, memTx)
 			} else {
-				txMsg := txMessageDeocdePool.Get().(*TxMessage)
-				txMsg.Tx = memTx.tx
-				if isInWhiteList {
-					txMsg.From = memTx.from
-				} else {
-					txMsg.From = ""
-				}
-				msg = txMsg
-				getFromPool = true
+				success = peer.Send(MempoolChannel, memR.txMessageBytes(memTx, isInWhiteList))
 			}
-
-			msgBz := memR.encodeMsg(msg)
-			if getFromPool {
-				getFromPool = false
-				txMessageDeocdePool.Put(msg)
-			}
-
-			success := peer.Send(MempoolChannel, msgBz)
 			if !success {
 				time.Sleep(peerCatchupSleepIntervalMS * time.Millisecond)
 				continue
@@ -401,6 +384,52 @@ func (memR *Reactor) broadcastTxRoutine(peer p2p.Peer) {
 	}
 }
 
+// txMessageBytes returns the encoded message that pushes memTx to a peer.
+func (memR *Reactor) txMessageBytes(memTx *mempoolTx, isInWhiteList bool) []byte {
+	var getFromPool bool
+	var msg Message
+	if memTx.nodeKey != nil && memTx.signature != nil {
+		msg = &WtxMessage{
+			Wtx: &WrappedTx{
+				Payload:   memTx.tx,
+				From:      memTx.from,
+				Signature: memTx.signature,
+				NodeKey:   memTx.nodeKey,
+			},
+		}
+	} else if memR.enableWtx {
+		if wtx, err := memR.wrapTx(memTx.tx, memTx.from); err == nil {
+			msg = &WtxMessage{
+				Wtx: wtx,
+			}
+		}
+	} else if memTx.isWrapCMTx {
+		wmsg := &WrapCMTxMessage{Wtx: &types.WrapCMTx{Tx: memTx.tx, Nonce: memTx.wrapCMNonce}}
+		if isInWhiteList {
+			wmsg.From = memTx.from
+		} else {
+			wmsg.From = ""
+		}
+		msg = wmsg
+	} else {
+		txMsg := txMessageDeocdePool.Get().(*TxMessage)
+		txMsg.Tx = memTx.tx
+		if isInWhiteList {
+			txMsg.From = memTx.from
+		} else {
+			txMsg.From = ""
+		}
+		msg = txMsg
+		getFromPool = true
+	}
+
+	msgBz := memR.encodeMsg(msg)
+	if getFromPool {
+		txMessageDeocdePool.Put(msg)
+	}
+	return msgBz
+}
+
 //-----------------------------------------------------------------------------
 // Messages
 
@@ -412,6 +441,8 @@ func RegisterMessages(cdc *amino.Codec) {
 	cdc.RegisterConcrete(&TxMessage{}, "tendermint/mempool/TxMessage", nil)
 	cdc.RegisterConcrete(&WtxMessage{}, "tendermint/mempool/WtxMessage", nil)
 	cdc.RegisterConcrete(&WrapCMTxMessage{}, "tendermint/mempool/WrapTxMessage", nil)
+	cdc.RegisterConcrete(&TxAnnounceMessage{}, "tendermint/mempool/TxAnnounceMessage", nil)
+	cdc.RegisterConcrete(&TxRequestMessage{}, "tendermint/mempool/TxRequestMessage", nil)
 
 	cdc.RegisterConcreteMarshaller("tendermint/mempool/TxMessage", func(codec *amino.Codec, i interface{}) ([]byte, error) {
 		txmp, ok := i.(*TxMessage)
@@ -49,6 +49,16 @@ func mempoolLogger() log.Logger {
 
 // connect N mempool reactors through N switches
 func makeAndConnectReactors(config *cfg.Config, n int) []*Reactor {
+	configs := make([]*cfg.Config, n)
+	for i := range configs {
+		configs[i] = config
+	}
+	return makeAndConnectReactorsWithConfigs(configs)
+}
+
+// connect N mempool reactors with their own configs through N switches
+func makeAndConnectReactorsWithConfigs(configs []*cfg.Config) []*Reactor {
+	n := len(configs)
 	reactors := make([]*Reactor, n)
 	logger := mempoolLogger()
 	for i := 0; i < n; i++ {
@@ -57,11 +67,11 @@ func makeAndConnectReactors(config *cfg.Config, n int) []*Reactor {
 		mempool, cleanup := newMempoolWithApp(cc)
 		defer cleanup()
 
-		reactors[i] = NewReactor(config.Mempool, mempool) // so we dont start the consensus states
+		reactors[i] = NewReactor(configs[i].Mempool, mempool) // so we dont start the consensus states
 		reactors[i].SetLogger(logger.With("validator", i))
 	}
 
-	p2p.MakeConnectedSwitches(config.P2P, n, func(i int, s *p2p.Switch) *p2p.Switch {
+	p2p.MakeConnectedSwitches(configs[0].P2P, n, func(i int, s *p2p.Switch) *p2p.Switch {
 		s.AddReactor("MEMPOOL", reactors[i])
 		return s
 
@@ -0,0 +1,244 @@
+package mempool
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/okex/exchain/libs/tendermint/p2p"
+)
+
+const (
+	// MempoolAnnounceChannel carries tx announcements and requests. A peer supports
+	// announce-then-fetch gossip if it lists this channel in its NodeInfo.
+	MempoolAnnounceChannel = byte(0x31)
+
+	// txRequestTimeout is the time after which an announced tx is requested from
+	// the next peer which announced it if it did not arrive from the peer it was
+	// requested from
+	txRequestTimeout = 2 * time.Second
+
+	// maxTxAnnouncers is the max number of alternate announcers kept for a tx
+	maxTxAnnouncers = 8
+
+	// maxTxRequestHashes is the max number of tx hashes in a TxRequestMessage
+	maxTxRequestHashes = 256
+
+	// pruneTxRequestsSize is the number of in-flight requests above which expired
+	// requests are pruned
+	pruneTxRequestsSize = 10000
+)
+
+// TxAnnounceMessage announces a tx to a peer which fetches it with a
+// TxRequestMessage if it has not seen it yet.
+type TxAnnounceMessage struct {
+	Hash  []byte
+	Size  uint32
+	From  string
+	Nonce uint64
+}
+
+// String returns a string representation of the TxAnnounceMessage.
+func (m *TxAnnounceMessage) String() string {
+	return fmt.Sprintf("[TxAnnounceMessage %X size:%d from:%s nonce:%d]", m.Hash, m.Size, m.From, m.Nonce)
+}
+
+// TxRequestMessage requests announced txs from a peer.
+type TxRequestMessage struct {
+	Hashes [][]byte
+}
+
+// String returns a string representation of the TxRequestMessage.
+func (m *TxRequestMessage) String() string {
+	return fmt.Sprintf("[TxRequestMessage %d txs]", len(m.Hashes))
+}
+
+// txRequest is an announced tx requested from a peer at the given time
+type txRequest struct {
+	at time.Time
+	// the other peers which announced the tx, in the order of their announcements
+	announcers []p2p.ID
+}
+
+// txRequests tracks the announced txs requested from peers, so a tx announced by
+// several peers is only fetched once, and from the other announcers in turn if
+// the requests time out.
+type txRequests struct {
+	mtx       sync.Mutex
+	requested map[[sha256.Size]byte]*txRequest
+}
+
+func newTxRequests() *txRequests {
+	return &txRequests{requested: make(map[[sha256.Size]byte]*txRequest)}
+}
+
+// tryRequest returns true if the tx was not requested within txRequestTimeout and
+// marks it as requested at now. Otherwise the announcer is kept as an alternate
+// peer to request the tx from.
+func (r *txRequests) tryRequest(key [sha256.Size]byte, announcer p2p.ID, now time.Time) bool {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if req, ok := r.requested[key]; ok && now.Sub(req.at) < txRequestTimeout {
+		if len(req.announcers) < maxTxAnnouncers && !containsPeerID(req.announcers, announcer) {
+			req.announcers = append(req.announcers, announcer)
+		}
+		return false
+	}
+	if len(r.requested) >= pruneTxRequestsSize {
+		for k, req := range r.requested {
+			if now.Sub(req.at) >= txRequestTimeout {
+				delete(r.requested, k)
+			}
+		}
+	}
+	r.requested[key] = &txRequest{at: now}
+	return true
+}
+
+func containsPeerID(ids []p2p.ID, id p2p.ID) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
+
+// nextAnnouncer returns the next alternate announcer of the tx requested at the
+// given time and marks the tx as requested from it at now. It returns false if
+// the tx was requested again meanwhile or if there is no other announcer, the
+// tx is requested from the next peer announcing it then.
+func (r *txRequests) nextAnnouncer(key [sha256.Size]byte, at, now time.Time) (p2p.ID, bool) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	req, ok := r.requested[key]
+	if !ok || !req.at.Equal(at) {
+		return "", false
+	}
+	if len(req.announcers) == 0 {
+		delete(r.requested, key)
+		return "", false
+	}
+	announcer := req.announcers[0]
+	req.announcers = req.announcers[1:]
+	req.at = now
+	return announcer, true
+}
+
+// peerSupportsTxAnnounce returns true if both this node and the peer gossip txs
+// by announcing them first.
+func (memR *Reactor) peerSupportsTxAnnounce(peer p2p.Peer) bool {
+	if !memR.config.TxAnnounce {
+		return false
+	}
+	ni, ok := peer.NodeInfo().(p2p.DefaultNodeInfo)
+	return ok && bytes.IndexByte(ni.Channels, MempoolAnnounceChannel) >= 0
+}
+
+// announceTx announces memTx to the peer. Small txs are pushed instead as the
+// announcement would save little.
+func (memR *Reactor) announceTx(peer p2p.Peer, memTx *mempoolTx) bool {
+	msg := &TxAnnounceMessage{
+		Hash:  txKeySlice(memTx.tx),
+		Size:  uint32(len(memTx.tx)),
+		From:  memTx.from,
+		Nonce: memTx.senderNonce,
+	}
+	return peer.Send(MempoolAnnounceChannel, cdc.MustMarshalBinaryBare(msg))
+}
+
+// handleTxAnnounce requests the announced tx from src unless it is already known,
+// stale or requested from another peer, src is an alternate announcer then.
+func (memR *Reactor) handleTxAnnounce(src p2p.Peer, msg *TxAnnounceMessage) error {
+	if len(msg.Hash) != sha256.Size {
+		return fmt.Errorf("invalid tx hash length %d", len(msg.Hash))
+	}
+	if int(msg.Size) > memR.config.MaxTxBytes {
+		return nil
+	}
+	var key [sha256.Size]byte
+	copy(key[:], msg.Hash)
+
+	if memR.mempool.cache.Has(key) {
+		// the peer has the tx, do not send it back
+		if ele, ok := memR.mempool.txs.Load(key); ok {
+			memTx := ele.Value.(*mempoolTx)
+			memTx.senderMtx.Lock()
+			memTx.senders[memR.ids.GetForPeer(src)] = struct{}{}
+			memTx.senderMtx.Unlock()
+		}
+		return nil
+	}
+	if msg.From != "" && memR.mempool.accountRetriever != nil &&
+		msg.Nonce < memR.mempool.accountRetriever.GetAccountNonce(msg.From) {
+		return nil
+	}
+	now := time.Now()
+	if !memR.txRequests.tryRequest(key, src.ID(), now) {
+		return nil
+	}
+	memR.requestTx(src, key, now)
+	return nil
+}
+
+// requestTx requests the tx from peer, and from the next announcer if it did not
+// arrive within txRequestTimeout.
+func (memR *Reactor) requestTx(peer p2p.Peer, key [sha256.Size]byte, at time.Time) {
+	req := &TxRequestMessage{Hashes: [][]byte{key[:]}}
+	if !peer.Send(MempoolAnnounceChannel, cdc.MustMarshalBinaryBare(req)) {
+		memR.requestTxFromNextAnnouncer(key, at)
+		return
+	}
+	time.AfterFunc(txRequestTimeout, func() {
+		memR.requestTxFromNextAnnouncer(key, at)
+	})
+}
+
+// requestTxFromNextAnnouncer requests the tx requested at the given time from the
+// next connected peer which announced it, unless the tx arrived meanwhile.
+func (memR *Reactor) requestTxFromNextAnnouncer(key [sha256.Size]byte, at time.Time) {
+	for !memR.mempool.cache.Has(key) {
+		now := time.Now()
+		id, ok := memR.txRequests.nextAnnouncer(key, at, now)
+		if !ok {
+			return
+		}
+		at = now
+		if peer := memR.Switch.Peers().Get(id); peer != nil {
+			memR.requestTx(peer, key, at)
+			return
+		}
+	}
+}
+
+// handleTxRequest sends the requested txs that are still in the mempool to src.
+func (memR *Reactor) handleTxRequest(src p2p.Peer, msg *TxRequestMessage) error {
+	if len(msg.Hashes) > maxTxRequestHashes {
+		return fmt.Errorf("too many requested txs %d, max %d", len(msg.Hashes), maxTxRequestHashes)
+	}
+	_, isInWhiteList := memR.nodeKeyWhitelist[string(src.ID())]
+	for _, hash := range msg.Hashes {
+		if len(hash) != sha256.Size {
+			return fmt.Errorf("invalid tx hash length %d", len(hash))
+		}
+		var key [sha256.Size]byte
+		copy(key[:], hash)
+		ele, ok := memR.mempool.txs.Load(key)
+		if !ok {
+			continue
+		}
+		if !src.Send(MempoolChannel, memR.txMessageBytes(ele.Value.(*mempoolTx), isInWhiteList)) {
+			break
+		}
+	}
+	return nil
+}
+
+func txKeySlice(tx []byte) []byte {
+	key := txKey(tx)
+	return key[:]
+}
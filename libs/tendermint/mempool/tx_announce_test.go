@@ -0,0 +1,158 @@
+package mempool
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/okex/exchain/libs/tendermint/config"
+	"github.com/okex/exchain/libs/tendermint/p2p"
+	"github.com/okex/exchain/libs/tendermint/types"
+)
+
+func txAnnounceConfig(enabled bool) *cfg.Config {
+	config := cfg.TestConfig()
+	config.Mempool.TxAnnounce = enabled
+	config.Mempool.TxAnnounceMinSize = 0
+	return config
+}
+
+func setPeerStates(reactors []*Reactor) {
+	for _, r := range reactors {
+		for _, peer := range r.Switch.Peers().List() {
+			peer.Set(types.PeerStateKey, peerState{1})
+		}
+	}
+}
+
+func TestReactorTxAnnounce(t *testing.T) {
+	testCases := map[string]struct {
+		announce    []bool
+		expRequests int
+	}{
+		"announce": {
+			announce:    []bool{true, true},
+			expRequests: 100,
+		},
+		"old receiver": {
+			announce: []bool{true, false},
+		},
+		"old sender": {
+			announce: []bool{false, true},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			configs := make([]*cfg.Config, len(tc.announce))
+			for i, enabled := range tc.announce {
+				configs[i] = txAnnounceConfig(enabled)
+			}
+			reactors := makeAndConnectReactorsWithConfigs(configs)
+			defer func() {
+				for _, r := range reactors {
+					r.Stop()
+				}
+			}()
+			setPeerStates(reactors)
+
+			txs := checkTxs(t, reactors[0].mempool, 100, UnknownPeerID)
+			waitForTxsOnReactors(t, txs, reactors)
+
+			// the receiver requested every tx it was announced
+			reactors[1].txRequests.mtx.Lock()
+			assert.Equal(t, tc.expRequests, len(reactors[1].txRequests.requested))
+			reactors[1].txRequests.mtx.Unlock()
+		})
+	}
+}
+
+func TestReactorTxAnnounceMinSize(t *testing.T) {
+	configs := []*cfg.Config{txAnnounceConfig(true), txAnnounceConfig(true)}
+	configs[0].Mempool.TxAnnounceMinSize = 1024
+	reactors := makeAndConnectReactorsWithConfigs(configs)
+	defer func() {
+		for _, r := range reactors {
+			r.Stop()
+		}
+	}()
+	setPeerStates(reactors)
+
+	// small txs are pushed
+	txs := checkTxs(t, reactors[0].mempool, 10, UnknownPeerID)
+	waitForTxsOnReactors(t, txs, reactors)
+	assert.Empty(t, reactors[1].txRequests.requested)
+}
+
+func TestTxRequests(t *testing.T) {
+	requests := newTxRequests()
+	key := txKey([]byte("tx"))
+	now := time.Now()
+
+	require.True(t, requests.tryRequest(key, "peer0", now))
+	// already requested from another peer, the announcers are kept once
+	require.False(t, requests.tryRequest(key, "peer1", now.Add(txRequestTimeout/2)))
+	require.False(t, requests.tryRequest(key, "peer2", now.Add(txRequestTimeout/2)))
+	require.False(t, requests.tryRequest(key, "peer1", now.Add(txRequestTimeout/2)))
+
+	// the request was superseded
+	_, ok := requests.nextAnnouncer(key, now.Add(time.Second), now.Add(txRequestTimeout))
+	require.False(t, ok)
+
+	// the request timed out, the tx is requested from the other announcers in turn
+	at := now.Add(txRequestTimeout)
+	id, ok := requests.nextAnnouncer(key, now, at)
+	require.True(t, ok)
+	require.Equal(t, p2p.ID("peer1"), id)
+	require.False(t, requests.tryRequest(key, "peer3", at.Add(txRequestTimeout/2)))
+	id, ok = requests.nextAnnouncer(key, at, at.Add(txRequestTimeout))
+	require.True(t, ok)
+	require.Equal(t, p2p.ID("peer2"), id)
+	at = at.Add(txRequestTimeout)
+	id, ok = requests.nextAnnouncer(key, at, at.Add(txRequestTimeout))
+	require.True(t, ok)
+	require.Equal(t, p2p.ID("peer3"), id)
+	at = at.Add(txRequestTimeout)
+
+	// no announcer left, the next announcement requests the tx
+	_, ok = requests.nextAnnouncer(key, at, at.Add(txRequestTimeout))
+	require.False(t, ok)
+	require.True(t, requests.tryRequest(key, "peer4", at))
+}
+
+func TestTxRequestsMaxAnnouncers(t *testing.T) {
+	requests := newTxRequests()
+	key := txKey([]byte("tx"))
+	now := time.Now()
+
+	require.True(t, requests.tryRequest(key, "peer", now))
+	for i := 0; i < maxTxAnnouncers*2; i++ {
+		require.False(t, requests.tryRequest(key, p2p.ID(fmt.Sprintf("peer%d", i)), now))
+	}
+	require.Len(t, requests.requested[key].announcers, maxTxAnnouncers)
+}
+
+func TestTxAnnounceMessagesAmino(t *testing.T) {
+	hash := txKeySlice([]byte("tx"))
+	testCases := []Message{
+		&TxAnnounceMessage{Hash: hash, Size: 2, From: "0x01", Nonce: 3},
+		&TxAnnounceMessage{Hash: hash},
+		&TxRequestMessage{Hashes: [][]byte{hash, hash}},
+	}
+	reactor := Reactor{config: cfg.TestMempoolConfig()}
+	for _, msg := range testCases {
+		bz := reactor.encodeMsg(msg)
+		decoded, err := reactor.decodeMsg(bz)
+		require.NoError(t, err)
+		assert.Equal(t, msg, decoded)
+	}
+}
+
+func TestHandleTxRequestTooManyHashes(t *testing.T) {
+	reactor := Reactor{config: cfg.TestMempoolConfig()}
+	hashes := make([][]byte, maxTxRequestHashes+1)
+	err := reactor.handleTxRequest(nil, &TxRequestMessage{Hashes: hashes})
+	require.Error(t, err)
+}
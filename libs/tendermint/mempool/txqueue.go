@@ -2,6 +2,7 @@ package mempool
 
 import (
 	"crypto/sha256"
+	"sort"
 	"sync"
 
 	"github.com/okex/exchain/libs/tendermint/libs/clist"
@@ -10,7 +11,8 @@ import (
 
 type ITransactionQueue interface {
 	Len() int
-	Insert(tx *mempoolTx) error
+	// Insert adds the tx, the replaced tx of the same sender and nonce is returned
+	Insert(tx *mempoolTx) (*mempoolTx, error)
 	Remove(element *clist.CElement)
 	RemoveByKey(key [sha256.Size]byte) *clist.CElement
 	Front() *clist.CElement
@@ -32,34 +34,42 @@ type AddressRecorder interface {
 }
 
 type BaseTxQueue struct {
-	txs    *clist.CList // FIFO list
-	txsMap sync.Map     //txKey -> CElement
+	txPriceBump int64
+	txs         *clist.CList // FIFO list
+	txsMap      sync.Map     //txKey -> CElement
 
 	*AddressRecord
 }
 
-func NewBaseTxQueue() *BaseTxQueue {
-	return &BaseTxQueue{
-		txs:           clist.New(),
-		AddressRecord: newAddressRecord(nil),
+func NewBaseTxQueue(txPriceBump int64) *BaseTxQueue {
+	q := &BaseTxQueue{
+		txPriceBump: txPriceBump,
+		txs:         clist.New(),
 	}
+	q.AddressRecord = newAddressRecord(q)
+	return q
 }
 
 func (q *BaseTxQueue) Len() int {
 	return q.txs.Len()
 }
 
-func (q *BaseTxQueue) Insert(tx *mempoolTx) error {
+func (q *BaseTxQueue) Insert(tx *mempoolTx) (*mempoolTx, error) {
 	/*
-		1. insert tx list
+		1. insert tx list, the tx of the same sender and nonce is replaced
 		2. insert address record
 		3. insert tx map
 	*/
-	ele := q.txs.PushBack(tx)
+	ele, replaced, err := q.AddressRecord.checkRepeatedAndAddItem(tx, q.txPriceBump, q.txs.PushBackElement)
+	if err != nil {
+		return nil, err
+	}
 
-	q.AddressRecord.AddItem(ele.Address, ele)
 	q.txsMap.Store(txKey(ele.Value.(*mempoolTx).tx), ele)
-	return nil
+	if replaced != nil {
+		return replaced.Value.(*mempoolTx), nil
+	}
+	return nil, nil
 }
 
 func (q *BaseTxQueue) Remove(element *clist.CElement) {
@@ -122,6 +132,25 @@ func (q *BaseTxQueue) removeElementByKey(key [32]byte) *clist.CElement {
 	return nil
 }
 
+// reorganizeElements appends the new tx which replaced a tx of the sender, followed by the txs of the sender with
+// a bigger nonce, so that the txs of the sender stay in nonce order
+func (q *BaseTxQueue) reorganizeElements(items []*clist.CElement) {
+	if len(items) == 0 {
+		return
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Nonce < items[j].Nonce })
+
+	for _, item := range items[1:] {
+		q.txs.DetachElement(item)
+		item.NewDetachPrev()
+		item.NewDetachNext()
+	}
+
+	for _, item := range items {
+		q.txs.PushBackElement(item)
+	}
+}
+
 func (q *BaseTxQueue) CleanItems(address string, nonce uint64) {
 	q.AddressRecord.CleanItems(address, nonce, q.removeElement)
 }
@@ -33,6 +33,7 @@ func (Mempool) CheckTx(_ types.Tx, _ func(*abci.Response), _ mempl.TxInfo) error
 	return nil
 }
 func (Mempool) ReapMaxBytesMaxGas(_, _ int64) []types.Tx      { return nil }
+func (Mempool) AddBundle(_ mempl.Bundle) ([]byte, error)      { return nil, nil }
 func (Mempool) ReapEssentialTx(tx types.Tx) abci.TxEssentials { return nil }
 func (Mempool) ReapMaxTxs(n int) types.Txs                    { return types.Txs{} }
 func (Mempool) ReapUserTxsCnt(address string) int             { return 0 }
@@ -77,6 +78,8 @@ func (Mempool) SetTxInfoParser(_ mempl.TxInfoParser) {
 
 }
 
+func (Mempool) SetTxLaneFunc(_ mempl.TxLaneFunc) {}
+
 func (Mempool) GetTxSimulateGas(txHash string) int64 { return 0 }
 
 func (Mempool) SetEnableDeleteMinGPTx(enable bool) {
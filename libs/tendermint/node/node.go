@@ -19,6 +19,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rs/cors"
+	"github.com/spf13/viper"
 
 	amino "github.com/tendermint/go-amino"
 
@@ -33,6 +34,7 @@ import (
 	"github.com/okex/exchain/libs/tendermint/consensus"
 	cs "github.com/okex/exchain/libs/tendermint/consensus"
 	"github.com/okex/exchain/libs/tendermint/crypto"
+	deltareactor "github.com/okex/exchain/libs/tendermint/delta/reactor"
 	"github.com/okex/exchain/libs/tendermint/evidence"
 	"github.com/okex/exchain/libs/tendermint/libs/log"
 	tmpubsub "github.com/okex/exchain/libs/tendermint/libs/pubsub"
@@ -379,6 +381,24 @@ func createMempoolAndMempoolReactor(config *cfg.Config, proxyApp proxy.AppConns,
 	return mempoolReactor, mempool
 }
 
+// createDeltaReactor returns the reactor gossiping deltas if the p2p delta broker is used, nil otherwise.
+func createDeltaReactor(nodeKey *p2p.NodeKey, logger log.Logger) (*deltareactor.Reactor, error) {
+	if viper.GetString(types.FlagDeltaBroker) != types.DeltaBrokerP2P {
+		return nil, nil
+	}
+	producers, err := deltareactor.ParseProducers(viper.GetString(types.FlagDeltaProducers))
+	if err != nil {
+		return nil, err
+	}
+	var privKey crypto.PrivKey
+	if types.UploadDelta {
+		privKey = nodeKey.PrivKey
+	}
+	deltaReactor := deltareactor.NewReactor(privKey, producers)
+	deltaReactor.SetLogger(logger.With("module", "delta"))
+	return deltaReactor, nil
+}
+
 func createEvidenceReactor(config *cfg.Config, dbProvider DBProvider,
 	stateDB dbm.DB, logger log.Logger) (*evidence.Reactor, *evidence.Pool, error) {
 
@@ -403,7 +423,11 @@ func createBlockchainReactor(config *cfg.Config,
 
 	switch config.FastSync.Version {
 	case "v0":
-		bcReactor = bcv0.NewBlockchainReactor(state.Copy(), blockExec, blockStore, fastSync)
+		options := []bcv0.BlockchainReactorOption{bcv0.WithDownloadWindow(config.FastSync.DownloadWindow)}
+		if config.FastSync.PipelinedVerify {
+			options = append(options, bcv0.WithPipelinedVerify(config.FastSync.VerifyWorkers))
+		}
+		bcReactor = bcv0.NewBlockchainReactor(state.Copy(), blockExec, blockStore, fastSync, options...)
 	case "v1":
 		bcReactor = bcv1.NewBlockchainReactor(state.Copy(), blockExec, blockStore, fastSync)
 	case "v2":
@@ -689,14 +713,24 @@ func NewNode(config *cfg.Config,
 		return nil, err
 	}
 
+	// Make Delta Reactor
+	deltaReactor, err := createDeltaReactor(nodeKey, logger)
+	if err != nil {
+		return nil, err
+	}
+
 	// make block executor for consensus and blockchain reactors to execute blocks
+	blockExecOptions := []sm.BlockExecutorOption{sm.BlockExecutorWithMetrics(smMetrics)}
+	if deltaReactor != nil {
+		blockExecOptions = append(blockExecOptions, sm.BlockExecutorWithDeltaBroker(deltaReactor))
+	}
 	blockExec := sm.NewBlockExecutor(
 		stateDB,
 		logger.With("module", "state"),
 		proxyApp.Consensus(),
 		mempool,
 		evidencePool,
-		sm.BlockExecutorWithMetrics(smMetrics),
+		blockExecOptions...,
 	)
 	blockExec.SetIsAsyncSaveDB(true)
 	if _, ok := txIndexer.(*null.TxIndex); ok {
@@ -729,6 +763,9 @@ func NewNode(config *cfg.Config,
 		config, transport, p2pMetrics, peerFilters, mempoolReactor, bcReactor,
 		consensusReactor, evidenceReactor, nodeInfo, nodeKey, p2pLogger,
 	)
+	if deltaReactor != nil {
+		sw.AddReactor("DELTA", deltaReactor)
+	}
 
 	err = sw.AddPersistentPeers(splitAndTrimEmpty(config.P2P.PersistentPeers, ",", " "))
 	if err != nil {
@@ -1290,6 +1327,14 @@ func makeNodeInfo(
 		nodeInfo.Channels = append(nodeInfo.Channels, pex.PexChannel)
 	}
 
+	if config.Mempool.TxAnnounce {
+		nodeInfo.Channels = append(nodeInfo.Channels, mempl.MempoolAnnounceChannel)
+	}
+
+	if viper.GetString(types.FlagDeltaBroker) == types.DeltaBrokerP2P {
+		nodeInfo.Channels = append(nodeInfo.Channels, deltareactor.DeltaChannel)
+	}
+
 	lAddr := config.P2P.ExternalAddress
 
 	if lAddr == "" {
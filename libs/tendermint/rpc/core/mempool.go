@@ -11,6 +11,7 @@ import (
 	"github.com/okex/exchain/libs/cosmos-sdk/baseapp"
 	abci "github.com/okex/exchain/libs/tendermint/abci/types"
 	"github.com/okex/exchain/libs/tendermint/config"
+	tmbytes "github.com/okex/exchain/libs/tendermint/libs/bytes"
 	mempl "github.com/okex/exchain/libs/tendermint/mempool"
 	ctypes "github.com/okex/exchain/libs/tendermint/rpc/core/types"
 	rpctypes "github.com/okex/exchain/libs/tendermint/rpc/jsonrpc/types"
@@ -58,6 +59,28 @@ func BroadcastTxSync(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcas
 	}, nil
 }
 
+// BroadcastBundle submits the txs as a bundle, which is included contiguously at
+// the top of the block of targetHeight, all of the txs or none. The bundle is
+// simulated before it's accepted, and it's dropped if any of its txs but the
+// ones of revertingTxHashes fails.
+func BroadcastBundle(ctx *rpctypes.Context, txs []types.Tx, targetHeight int64,
+	revertingTxHashes [][]byte) (*ctypes.ResultBroadcastBundle, error) {
+
+	hash, err := env.Mempool.AddBundle(mempl.Bundle{
+		Txs:               txs,
+		TargetHeight:      targetHeight,
+		RevertingTxHashes: revertingTxHashes,
+	})
+	if err != nil {
+		return nil, err
+	}
+	txHashes := make([]tmbytes.HexBytes, len(txs))
+	for i, tx := range txs {
+		txHashes[i] = tx.Hash(targetHeight)
+	}
+	return &ctypes.ResultBroadcastBundle{Hash: hash, TargetHeight: targetHeight, TxHashes: txHashes}, nil
+}
+
 // BroadcastTxCommit returns with the responses from CheckTx and DeliverTx.
 // More: https://docs.tendermint.com/master/rpc/#/Tx/broadcast_tx_commit
 func BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
@@ -42,6 +42,7 @@ var Routes = map[string]*rpc.RPCFunc{
 	"broadcast_tx_commit": rpc.NewRPCFunc(BroadcastTxCommit, "tx"),
 	"broadcast_tx_sync":   rpc.NewRPCFunc(BroadcastTxSync, "tx"),
 	"broadcast_tx_async":  rpc.NewRPCFunc(BroadcastTxAsync, "tx"),
+	"broadcast_bundle":    rpc.NewRPCFunc(BroadcastBundle, "txs,target_height,reverting_tx_hashes"),
 
 	// abci API
 	"abci_query": rpc.NewRPCFunc(ABCIQuery, "path,data,height,prove"),
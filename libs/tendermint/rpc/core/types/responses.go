@@ -166,6 +166,13 @@ type ResultBroadcastTx struct {
 	Hash bytes.HexBytes `json:"hash"`
 }
 
+// Bundle accepted by the mempool
+type ResultBroadcastBundle struct {
+	Hash         bytes.HexBytes   `json:"hash"`
+	TargetHeight int64            `json:"target_height"`
+	TxHashes     []bytes.HexBytes `json:"tx_hashes"`
+}
+
 // CheckTx and DeliverTx results
 type ResultBroadcastTxCommit struct {
 	CheckTx   abci.ResponseCheckTx   `json:"check_tx"`
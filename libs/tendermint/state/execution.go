@@ -8,6 +8,7 @@ import (
 	"github.com/okex/exchain/libs/system/trace"
 	abci "github.com/okex/exchain/libs/tendermint/abci/types"
 	cfg "github.com/okex/exchain/libs/tendermint/config"
+	"github.com/okex/exchain/libs/tendermint/delta"
 	"github.com/okex/exchain/libs/tendermint/global"
 	"github.com/okex/exchain/libs/tendermint/libs/automation"
 	"github.com/okex/exchain/libs/tendermint/libs/fail"
@@ -90,6 +91,13 @@ func BlockExecutorWithMetrics(metrics *Metrics) BlockExecutorOption {
 	}
 }
 
+// BlockExecutorWithDeltaBroker distributes deltas through the broker instead of redis.
+func BlockExecutorWithDeltaBroker(broker delta.DeltaBroker) BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.deltaContext.deltaBroker = broker
+	}
+}
+
 // NewBlockExecutor returns a new BlockExecutor with a NopEventBus.
 // Call SetEventBus to provide one.
 func NewBlockExecutor(
@@ -188,7 +196,13 @@ func (blockExec *BlockExecutor) CreateProposalBlock(
 // If the block is invalid, it returns an error.
 // Validation does not mutate state, but does require historical information from the stateDB,
 // ie. to verify evidence from a validator at an old height.
+// If the deltas applied to the last block don't match the app hash of the block, the last
+// block is executed again and the block is validated against the state executed.
 func (blockExec *BlockExecutor) ValidateBlock(state State, block *types.Block) error {
+	state, err := blockExec.settleDelta(state, block, false)
+	if err != nil {
+		return err
+	}
 	if IgnoreSmbCheck {
 		// debug only
 		return nil
@@ -228,6 +242,11 @@ func (blockExec *BlockExecutor) ApplyBlock(
 		blockExec.metrics.CommittedHeight.Set(float64(block.Height))
 	}()
 
+	state, err := blockExec.settleDelta(state, block, true)
+	if err != nil {
+		return state, 0, err
+	}
+
 	if err := blockExec.ValidateBlock(state, block); err != nil {
 		return state, 0, ErrInvalidBlock(err)
 	}
@@ -290,6 +309,7 @@ func (blockExec *BlockExecutor) ApplyBlock(
 	}
 
 	// Update the state with the block and responses.
+	lastState := state
 	state, err = updateState(state, blockID, &block.Header, abciResponses, validatorUpdates)
 	if err != nil {
 		return state, 0, fmt.Errorf("commit failed for application: %v", err)
@@ -299,7 +319,15 @@ func (blockExec *BlockExecutor) ApplyBlock(
 	startTime = time.Now().UnixNano()
 
 	// Lock mempool, commit app state, update mempoool.
-	commitResp, retainHeight, err := blockExec.commit(state, block, deltaInfo, abciResponses.DeliverTxs, trc)
+	// The verified deltas are committed once the next block confirms their app hash.
+	var commitResp *abci.ResponseCommit
+	var retainHeight int64
+	if deltaInfo != nil && deltaInfo.appHash != nil {
+		commitResp = &abci.ResponseCommit{Data: deltaInfo.appHash}
+		dc.pendingDelta = &pendingDelta{deltaInfo: deltaInfo, block: block, blockID: blockID, lastState: lastState}
+	} else {
+		commitResp, retainHeight, err = blockExec.commit(state, block, deltaInfo, abciResponses.DeliverTxs, trc)
+	}
 	endTime = time.Now().UnixNano()
 	blockExec.metrics.CommitTime.Set(float64(endTime-startTime) / 1e6)
 	if err != nil {
@@ -320,7 +348,7 @@ func (blockExec *BlockExecutor) ApplyBlock(
 	blockExec.logger.Debug("SaveState", "state", &state)
 	fail.Fail() // XXX
 
-	dc.postApplyBlock(block.Height, deltaInfo, abciResponses, commitResp.DeltaMap, blockExec.isFastSync)
+	dc.postApplyBlock(block.Height, deltaInfo, abciResponses, commitResp.DeltaMap, commitResp.Data, blockExec.isFastSync)
 
 	// Events are fired after everything else.
 	// NOTE: if we crash between Commit and Save, events wont be fired during replay
@@ -351,7 +379,10 @@ func (blockExec *BlockExecutor) runAbci(block *types.Block, deltaInfo *DeltaInfo
 	if deltaInfo != nil {
 		blockExec.logger.Info("Apply delta", "height", block.Height, "deltas-length", deltaInfo.deltaLen)
 		t0 := time.Now()
-		execBlockOnProxyAppWithDeltas(blockExec.proxyApp, block, blockExec.db)
+		// the block of verified deltas is begun when they are committed
+		if deltaInfo.appHash == nil {
+			execBlockOnProxyAppWithDeltas(blockExec.proxyApp, block, blockExec.db)
+		}
 		abciResponses = deltaInfo.abciResponses
 		duration = time.Now().Sub(t0)
 	} else {
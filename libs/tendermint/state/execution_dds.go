@@ -1,8 +1,10 @@
 package state
 
 import (
+	"bytes"
 	"fmt"
 	"github.com/okex/exchain/libs/system/trace"
+	"math"
 	"sync/atomic"
 	"time"
 
@@ -79,6 +81,20 @@ type DeltaContext struct {
 
 	idMap    identityMapType
 	identity string
+
+	// the verified deltas applied to the last block, committed once the next block confirms their app hash
+	pendingDelta *pendingDelta
+}
+
+// pendingDelta is the last block applied with verified deltas, which are not committed to the app yet
+type pendingDelta struct {
+	deltaInfo *DeltaInfo
+	block     *types.Block
+	blockID   types.BlockID
+	lastState State
+
+	// the state of the block executed again without the deltas
+	executed *State
 }
 
 func newDeltaContext(l log.Logger) *DeltaContext {
@@ -110,15 +126,17 @@ func (dc *DeltaContext) init() {
 		if dc.bufferSize < 5 {
 			dc.bufferSize = 5
 		}
-		url := viper.GetString(types.FlagRedisUrl)
-		auth := viper.GetString(types.FlagRedisAuth)
-		expire := time.Duration(viper.GetInt(types.FlagRedisExpire)) * time.Second
-		dbNum := viper.GetInt(types.FlagRedisDB)
-		if dbNum < 0 || dbNum > 15 {
-			panic("delta-redis-db only support 0~15")
+		if dc.deltaBroker == nil {
+			url := viper.GetString(types.FlagRedisUrl)
+			auth := viper.GetString(types.FlagRedisAuth)
+			expire := time.Duration(viper.GetInt(types.FlagRedisExpire)) * time.Second
+			dbNum := viper.GetInt(types.FlagRedisDB)
+			if dbNum < 0 || dbNum > 15 {
+				panic("delta-redis-db only support 0~15")
+			}
+			dc.deltaBroker = redis_cgi.NewRedisClient(url, auth, expire, dbNum, dc.logger)
+			dc.logger.Info("Init delta broker", "url", url)
 		}
-		dc.deltaBroker = redis_cgi.NewRedisClient(url, auth, expire, dbNum, dc.logger)
-		dc.logger.Info("Init delta broker", "url", url)
 	}
 
 	// control if iavl produce delta or not
@@ -163,7 +181,7 @@ func (dc *DeltaContext) statistic(applied bool, txnum int, delta *DeltaInfo) {
 }
 
 func (dc *DeltaContext) postApplyBlock(height int64, deltaInfo *DeltaInfo,
-	abciResponses *ABCIResponses, deltaMap interface{}, isFastSync bool) {
+	abciResponses *ABCIResponses, deltaMap interface{}, appHash []byte, isFastSync bool) {
 
 	// delta consumer
 	if dc.downloadDelta {
@@ -184,9 +202,9 @@ func (dc *DeltaContext) postApplyBlock(height int64, deltaInfo *DeltaInfo,
 		dc.logger.Info("Post apply block", "height", height, "delta-applied", applied,
 			"applied-ratio", dc.hitRatio(), "delta-length", deltaLen)
 
-		if applied && types.FastQuery {
-			evmWatchDataManager.ApplyWatchData(deltaInfo.watchData)
-			wasmWatchDataManager.ApplyWatchData(deltaInfo.wasmWatchData)
+		// the watch data of the verified deltas is applied once they are committed
+		if applied && deltaInfo.appHash == nil {
+			dc.applyWatchData(deltaInfo)
 		}
 	}
 
@@ -196,12 +214,118 @@ func (dc *DeltaContext) postApplyBlock(height int64, deltaInfo *DeltaInfo,
 
 		wdFunc := evmWatchDataManager.CreateWatchDataGenerator()
 		wasmWdFunc := wasmWatchDataManager.CreateWatchDataGenerator()
-		go dc.uploadData(height, abciResponses, deltaMap, wdFunc, wasmWdFunc)
+		go dc.uploadData(height, abciResponses, deltaMap, appHash, wdFunc, wasmWdFunc)
 	}
 	types.WasmStoreCode = false
 }
 
-func (dc *DeltaContext) uploadData(height int64, abciResponses *ABCIResponses, deltaMap interface{}, wdFunc, wasmWdFunc func() ([]byte, error)) {
+func (dc *DeltaContext) applyWatchData(deltaInfo *DeltaInfo) {
+	if types.FastQuery {
+		evmWatchDataManager.ApplyWatchData(deltaInfo.watchData)
+		wasmWatchDataManager.ApplyWatchData(deltaInfo.wasmWatchData)
+	}
+}
+
+// settleDelta settles the verified deltas applied to the last block against the app hash of the block to
+// apply. The deltas are committed to the app if the hashes match, otherwise the last block is executed again
+// without the deltas and the state executed is returned to go on with. The matching deltas are only committed
+// once the block is confirmed, the validation of a proposal just gets the state to validate it against.
+func (blockExec *BlockExecutor) settleDelta(state State, block *types.Block, confirmed bool) (State, error) {
+	dc := blockExec.deltaContext
+	pending := dc.pendingDelta
+	if confirmed {
+		dc.pendingDelta = nil
+	}
+	if pending == nil || pending.block.Height != block.Height-1 {
+		return state, nil
+	}
+	if pending.executed != nil {
+		return *pending.executed, nil
+	}
+	if !bytes.Equal(block.AppHash, pending.deltaInfo.appHash) {
+		executed, err := blockExec.executeDeltaBlock(pending)
+		if err != nil {
+			return state, err
+		}
+		pending.executed = &executed
+		return executed, nil
+	}
+	if !confirmed {
+		return state, nil
+	}
+
+	execBlockOnProxyAppWithDeltas(blockExec.proxyApp, pending.block, blockExec.db)
+	commitResp, _, err := blockExec.commit(state, pending.block, pending.deltaInfo,
+		pending.deltaInfo.abciResponses.DeliverTxs, trace.NewTracer(trace.ApplyBlock))
+	if err != nil {
+		return state, fmt.Errorf("commit failed for application: %v", err)
+	}
+	if !bytes.Equal(commitResp.Data, pending.deltaInfo.appHash) {
+		return state, fmt.Errorf("committing the deltas of height %d from %s resulted in app hash %X instead of %X",
+			pending.block.Height, pending.deltaInfo.from, commitResp.Data, pending.deltaInfo.appHash)
+	}
+	dc.applyWatchData(pending.deltaInfo)
+	return state, nil
+}
+
+// executeDeltaBlock discards the deltas applied to the block and executes it again, then commits the app and
+// overwrites the state and the responses saved with the deltas. If the producer committed another app hash, it
+// is reported to the broker and the buffered deltas are dropped.
+func (blockExec *BlockExecutor) executeDeltaBlock(pending *pendingDelta) (State, error) {
+	dc := blockExec.deltaContext
+	block := pending.block
+	blockExec.logger.Error("Applied delta does not match the block app hash, execute the block again",
+		"height", block.Height,
+		"from", pending.deltaInfo.from,
+		"delta-app-hash", fmt.Sprintf("%X", pending.deltaInfo.appHash))
+
+	abciResponses, _, err := blockExec.runAbci(block, nil)
+	if err != nil {
+		return State{}, ErrProxyAppConn(err)
+	}
+	abciValUpdates := abciResponses.EndBlock.ValidatorUpdates
+	err = validateValidatorUpdates(abciValUpdates, pending.lastState.ConsensusParams.Validator)
+	if err != nil {
+		return State{}, fmt.Errorf("error in validator updates: %v", err)
+	}
+	validatorUpdates, err := types.PB2TM.ValidatorUpdates(abciValUpdates)
+	if err != nil {
+		return State{}, err
+	}
+	state, err := updateState(pending.lastState, pending.blockID, &block.Header, abciResponses, validatorUpdates)
+	if err != nil {
+		return State{}, fmt.Errorf("commit failed for application: %v", err)
+	}
+	commitResp, _, err := blockExec.commit(state, block, nil, abciResponses.DeliverTxs, trace.NewTracer(trace.ApplyBlock))
+	if err != nil {
+		return State{}, fmt.Errorf("commit failed for application: %v", err)
+	}
+	state.AppHash = commitResp.Data
+
+	// wait for the async saves of the block, not to be overwritten by them
+	blockExec.tryWaitLastBlockSave(block.Height)
+	blockExec.isWaitingLastBlock = false
+	SaveABCIResponses(blockExec.db, block.Height, abciResponses)
+	SaveState(blockExec.db, state)
+
+	if !bytes.Equal(state.AppHash, pending.deltaInfo.appHash) {
+		if vb, ok := dc.deltaBroker.(delta.VerifiableDeltaBroker); ok {
+			vb.ReportMismatch(block.Height)
+		}
+		dc.dataMap.remove(math.MaxInt64)
+	}
+	return state, nil
+}
+
+// getAppHash returns the app hash the producer committed with the deltas of the height, if the broker distributes it
+func (dc *DeltaContext) getAppHash(height int64) []byte {
+	if vb, ok := dc.deltaBroker.(delta.VerifiableDeltaBroker); ok {
+		return vb.GetAppHash(height)
+	}
+	return nil
+}
+
+func (dc *DeltaContext) uploadData(height int64, abciResponses *ABCIResponses, deltaMap interface{}, appHash []byte, wdFunc, wasmWdFunc func() ([]byte, error)) {
 	if abciResponses == nil || deltaMap == nil {
 		dc.logger.Error("Failed to upload", "height", height, "error", fmt.Errorf("empty data"))
 		return
@@ -212,6 +336,7 @@ func (dc *DeltaContext) uploadData(height int64, abciResponses *ABCIResponses, d
 		CompressType: dc.compressType,
 		CompressFlag: dc.compressFlag,
 		From:         dc.identity,
+		AppHash:      appHash,
 	}
 
 	var err error
@@ -275,7 +400,12 @@ func (dc *DeltaContext) upload(deltas *types.Deltas, txnum float64, mrh int64) b
 
 	t2 := time.Now()
 	// set into dds
-	if err = dc.deltaBroker.SetDeltas(deltas.Height, deltaBytes); err != nil {
+	if vb, ok := dc.deltaBroker.(delta.VerifiableDeltaBroker); ok {
+		err = vb.SetVerifiableDeltas(deltas.Height, deltaBytes, deltas.AppHash)
+	} else {
+		err = dc.deltaBroker.SetDeltas(deltas.Height, deltaBytes)
+	}
+	if err != nil {
 		dc.logger.Error("Failed to upload delta", "target-height", deltas.Height,
 			"mrh", mrh, "error", err)
 		return false
@@ -392,6 +522,7 @@ func (dc *DeltaContext) downloadRoutine() {
 			from:        delta.From,
 			deltaLen:    delta.Size(),
 			deltaHeight: delta.Height,
+			appHash:     delta.AppHash,
 		}
 		err = deltaInfo.bytes2DeltaInfo(&delta.Payload)
 		if err == nil {
@@ -461,6 +592,7 @@ func (dc *DeltaContext) download(height int64) (error, *types.Deltas, int64) {
 		dc.logger.Error("Downloaded an invalid delta:", "target-height", height, "err", err)
 		return err, nil, latestHeight
 	}
+	delta.AppHash = dc.getAppHash(height)
 
 	cacheMap, cacheList := dc.dataMap.info()
 	dc.logger.Info("Downloaded delta successfully:",
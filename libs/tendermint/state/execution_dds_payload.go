@@ -28,6 +28,7 @@ type DeltaInfo struct {
 	from          string
 	deltaLen      int
 	deltaHeight   int64
+	appHash       []byte
 	abciResponses *ABCIResponses
 	treeDeltaMap  interface{}
 	watchData     interface{}
@@ -1,6 +1,7 @@
 package state_test
 
 import (
+	"encoding/binary"
 	"testing"
 	"time"
 
@@ -49,6 +50,79 @@ func TestApplyBlock(t *testing.T) {
 	// TODO check state and mempool
 }
 
+// TestApplyBlockWithVerifiedDelta ensures the verified deltas are committed once the
+// next block confirms their app hash and the block is executed again on mismatch.
+func TestApplyBlockWithVerifiedDelta(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		txs       types.Txs
+		deltaHash []byte
+	}{
+		// kvstore commits the number of txs as the app hash
+		{"matching delta", nil, appHashOfSize(0)},
+		{"mismatching delta", makeTxs(1), appHashOfSize(0)},
+	}
+
+	for _, tc := range testCases {
+		app := kvstore.NewApplication()
+		cc := proxy.NewLocalClientCreator(app)
+		proxyApp := proxy.NewAppConns(cc)
+		require.Nil(t, proxyApp.Start(), tc.desc)
+
+		state, stateDB, privVals := makeState(1, 1)
+		blockExec := sm.NewBlockExecutor(stateDB, log.TestingLogger(), proxyApp.Consensus(),
+			mock.Mempool{}, sm.MockEvidencePool{})
+
+		block, _ := state.MakeBlock(1, tc.txs, new(types.Commit), nil, state.Validators.GetProposer().Address)
+		blockID := types.BlockID{Hash: block.Hash(), PartsHeader: block.MakePartSet(testPartSize).Header()}
+		abciResponses := &sm.ABCIResponses{
+			DeliverTxs: make([]*abci.ResponseDeliverTx, len(block.Txs)),
+			EndBlock:   &abci.ResponseEndBlock{},
+			BeginBlock: &abci.ResponseBeginBlock{},
+		}
+		for i := range abciResponses.DeliverTxs {
+			abciResponses.DeliverTxs[i] = &abci.ResponseDeliverTx{}
+		}
+		sm.SetVerifiedDelta(blockExec, 1, abciResponses, tc.deltaHash)
+
+		// the deltas are not committed before the next block confirms them
+		state, _, err := blockExec.ApplyBlock(state, blockID, block)
+		require.Nil(t, err, tc.desc)
+		assert.Equal(t, tc.deltaHash, state.AppHash, tc.desc)
+		assert.EqualValues(t, 0, app.Info(abci.RequestInfo{}).LastBlockHeight, tc.desc)
+
+		commit, err := makeValidCommit(1, blockID, state.LastValidators, privVals)
+		require.Nil(t, err, tc.desc)
+		block, _ = state.MakeBlock(2, nil, commit, nil, state.Validators.GetProposer().Address)
+		block.AppHash = appHashOfSize(int64(len(tc.txs)))
+		blockID = types.BlockID{Hash: block.Hash(), PartsHeader: block.MakePartSet(testPartSize).Header()}
+
+		require.Nil(t, blockExec.ValidateBlock(state, block), tc.desc)
+		state, _, err = blockExec.ApplyBlock(state, blockID, block)
+		require.Nil(t, err, tc.desc)
+		assert.EqualValues(t, 2, state.LastBlockHeight, tc.desc)
+		assert.Equal(t, appHashOfSize(int64(len(tc.txs))), app.Info(abci.RequestInfo{}).LastBlockAppHash, tc.desc)
+		assert.EqualValues(t, 2, app.Info(abci.RequestInfo{}).LastBlockHeight, tc.desc)
+
+		// the state and the responses saved with the mismatching deltas are overwritten
+		abciResponses, err = sm.LoadABCIResponses(stateDB, 1)
+		require.Nil(t, err, tc.desc)
+		require.Len(t, abciResponses.DeliverTxs, len(tc.txs), tc.desc)
+		for _, res := range abciResponses.DeliverTxs {
+			assert.NotEmpty(t, res.Events, tc.desc)
+		}
+		assert.Equal(t, state.AppHash, sm.LoadState(stateDB).AppHash, tc.desc)
+
+		proxyApp.Stop()
+	}
+}
+
+func appHashOfSize(size int64) []byte {
+	appHash := make([]byte, 8)
+	binary.PutVarint(appHash, size)
+	return appHash
+}
+
 // TestBeginBlockValidators ensures we send absent validators list.
 func TestBeginBlockValidators(t *testing.T) {
 	app := &testApp{}
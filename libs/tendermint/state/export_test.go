@@ -49,3 +49,12 @@ func SaveConsensusParamsInfo(db dbm.DB, nextHeight, changeHeight int64, params t
 func SaveValidatorsInfo(db dbm.DB, height, lastHeightChanged int64, valSet *types.ValidatorSet) {
 	saveValidatorsInfo(db, height, lastHeightChanged, valSet)
 }
+
+// SetVerifiedDelta makes the block executor download the deltas and buffers the
+// deltas of the height committed by the producer with the app hash, exclusively
+// and explicitly for testing.
+func SetVerifiedDelta(blockExec *BlockExecutor, height int64, abciResponses *ABCIResponses, appHash []byte) {
+	blockExec.deltaContext.downloadDelta = true
+	blockExec.deltaContext.dataMap.insert(height,
+		&DeltaInfo{deltaHeight: height, abciResponses: abciResponses, appHash: appHash}, height)
+}
@@ -0,0 +1,80 @@
+package state
+
+import (
+	"fmt"
+
+	dbm "github.com/okex/exchain/libs/tm-db"
+)
+
+// RollbackState rebuilds the state of the given height, lower than the height of
+// the latest state, and saves it as the latest state. It is rebuilt from the
+// validators and the consensus params saved for the heights after it and from
+// the metas of the block of the height and the next one in the block store.
+func RollbackState(db dbm.DB, blockStore BlockStore, height int64) (State, error) {
+	latest := LoadState(db)
+	if latest.IsEmpty() {
+		return State{}, fmt.Errorf("no state found")
+	}
+	if height == latest.LastBlockHeight {
+		return latest, nil
+	}
+	if height > latest.LastBlockHeight {
+		return State{}, fmt.Errorf("cannot roll back the state of height %d to the higher height %d",
+			latest.LastBlockHeight, height)
+	}
+
+	meta := blockStore.LoadBlockMeta(height)
+	if meta == nil {
+		return State{}, fmt.Errorf("block meta of height %d not found", height)
+	}
+	// the app hash and the results hash of the height are in the next header
+	nextMeta := blockStore.LoadBlockMeta(height + 1)
+	if nextMeta == nil {
+		return State{}, fmt.Errorf("block meta of height %d not found", height+1)
+	}
+
+	lastValidators, err := LoadValidators(db, height)
+	if err != nil {
+		return State{}, err
+	}
+	validators, err := LoadValidators(db, height+1)
+	if err != nil {
+		return State{}, err
+	}
+	nextValidators, err := LoadValidators(db, height+2)
+	if err != nil {
+		return State{}, err
+	}
+	// the validators of height+2 were saved with the state of the height
+	valInfo := loadValidatorsInfo(db, height+2)
+	params, err := LoadConsensusParams(db, height+1)
+	if err != nil {
+		return State{}, err
+	}
+	paramsInfo := loadConsensusParamsInfo(db, height+1)
+
+	state := State{
+		Version: Version{
+			Consensus: nextMeta.Header.Version,
+			Software:  latest.Version.Software,
+		},
+		ChainID: latest.ChainID,
+
+		LastBlockHeight: height,
+		LastBlockID:     meta.BlockID,
+		LastBlockTime:   meta.Header.Time,
+
+		NextValidators:              nextValidators,
+		Validators:                  validators,
+		LastValidators:              lastValidators,
+		LastHeightValidatorsChanged: valInfo.LastHeightChanged,
+
+		ConsensusParams:                  params,
+		LastHeightConsensusParamsChanged: paramsInfo.LastHeightChanged,
+
+		LastResultsHash: nextMeta.Header.LastResultsHash,
+		AppHash:         nextMeta.Header.AppHash,
+	}
+	SaveState(db, state)
+	return state, nil
+}
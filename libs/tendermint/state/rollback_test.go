@@ -0,0 +1,66 @@
+package state_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sm "github.com/okex/exchain/libs/tendermint/state"
+	"github.com/okex/exchain/libs/tendermint/types"
+)
+
+type metaBlockStore struct {
+	sm.BlockStore
+	metas map[int64]*types.BlockMeta
+}
+
+func (bs metaBlockStore) LoadBlockMeta(height int64) *types.BlockMeta {
+	return bs.metas[height]
+}
+
+func TestRollbackState(t *testing.T) {
+	state, stateDB, _ := makeState(2, 1)
+	bs := metaBlockStore{metas: make(map[int64]*types.BlockMeta)}
+	states := make(map[int64]sm.State)
+
+	for height := int64(1); height <= 6; height++ {
+		power := int64(1000)
+		if height == 3 {
+			power = 20
+		}
+		header, blockID, responses := makeHeaderPartsResponsesValPowerChange(state, power)
+		if height == 4 {
+			params := state.ConsensusParams
+			params.Block.MaxBytes++
+			header, blockID, responses = makeHeaderPartsResponsesParams(state, params)
+		}
+		validatorUpdates, err := types.PB2TM.ValidatorUpdates(responses.EndBlock.ValidatorUpdates)
+		require.NoError(t, err)
+		state, err = sm.UpdateState(state, blockID, &header, responses, validatorUpdates)
+		require.NoError(t, err)
+		state.AppHash = []byte(fmt.Sprintf("app hash %d", height))
+		sm.SaveState(stateDB, state)
+
+		bs.metas[height] = &types.BlockMeta{BlockID: blockID, Header: header}
+		states[height] = state
+	}
+	// the header of height 7 has the app hash of height 6
+	block := makeBlock(state, 7)
+	bs.metas[7] = &types.BlockMeta{Header: block.Header}
+
+	_, err := sm.RollbackState(stateDB, bs, 7)
+	require.Error(t, err)
+
+	for _, height := range []int64{6, 5, 3, 2} {
+		rolled, err := sm.RollbackState(stateDB, bs, height)
+		require.NoError(t, err)
+		require.True(t, states[height].Equals(rolled), "height %d", height)
+		require.True(t, states[height].Equals(sm.LoadState(stateDB)), "height %d", height)
+	}
+
+	// the block meta of the height is required
+	delete(bs.metas, 1)
+	_, err = sm.RollbackState(stateDB, bs, 1)
+	require.Error(t, err)
+}
@@ -300,6 +300,44 @@ func (bs *BlockStore) LoadSeenCommit(height int64) *types.Commit {
 	return commit
 }
 
+// CheckBlock returns an error if the block of the given height is not fully
+// stored or doesn't match its meta. It doesn't panic on corrupted data.
+func (bs *BlockStore) CheckBlock(height int64) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("corrupted block %d: %v", height, r)
+		}
+	}()
+
+	meta := bs.LoadBlockMeta(height)
+	if meta == nil {
+		return fmt.Errorf("block meta of height %d not found", height)
+	}
+	if meta.Header.Height != height {
+		return fmt.Errorf("block meta of height %d has height %d", height, meta.Header.Height)
+	}
+	for i := 0; i < meta.BlockID.PartsHeader.Total; i++ {
+		part := bs.LoadBlockPart(height, i)
+		if part == nil {
+			return fmt.Errorf("part %d of block %d not found", i, height)
+		}
+		if err := part.Proof.Verify(meta.BlockID.PartsHeader.Hash, part.Bytes); err != nil {
+			return fmt.Errorf("invalid part %d of block %d: %v", i, height, err)
+		}
+	}
+	block := bs.LoadBlock(height)
+	if block == nil {
+		return fmt.Errorf("block %d not found", height)
+	}
+	if !bytes.Equal(block.Hash(), meta.BlockID.Hash) {
+		return fmt.Errorf("hash of block %d is %X, expected %X", height, block.Hash(), meta.BlockID.Hash)
+	}
+	if bs.LoadSeenCommit(height) == nil {
+		return fmt.Errorf("seen commit of block %d not found", height)
+	}
+	return nil
+}
+
 // PruneBlocks removes block up to (but not including) a height. It returns number of blocks pruned.
 func (bs *BlockStore) PruneBlocks(height int64) (uint64, error) {
 	return bs.deleteBatch(height, false)
@@ -560,6 +560,31 @@ func TestDeleteBlocksFromTop(t *testing.T) {
 	assert.Nil(t, bs.LoadBlock(2))
 }
 
+func TestCheckBlock(t *testing.T) {
+	bs, db := freshBlockStore()
+	for h := int64(1); h <= 4; h++ {
+		block := makeBlock(h, state, new(types.Commit))
+		bs.SaveBlock(block, block.MakePartSet(2), makeTestCommit(h, tmtime.Now()))
+	}
+	for h := int64(1); h <= 4; h++ {
+		require.NoError(t, bs.CheckBlock(h))
+	}
+	require.Error(t, bs.CheckBlock(5))
+
+	// a missing part
+	require.NoError(t, db.Delete(calcBlockPartKey(1, 0)))
+	require.Error(t, bs.CheckBlock(1))
+	// a garbage part
+	require.NoError(t, db.Set(calcBlockPartKey(2, 0), []byte("garbage")))
+	require.Error(t, bs.CheckBlock(2))
+	// a part of another block
+	require.NoError(t, db.Set(calcBlockPartKey(3, 0), cdc.MustMarshalBinaryBare(bs.LoadBlockPart(4, 0))))
+	require.Error(t, bs.CheckBlock(3))
+	// a missing seen commit
+	require.NoError(t, db.Delete(calcSeenCommitKey(4)))
+	require.Error(t, bs.CheckBlock(4))
+}
+
 func TestLoadBlockMeta(t *testing.T) {
 	bs, db := freshBlockStore()
 	height := int64(10)
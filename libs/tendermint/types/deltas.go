@@ -29,6 +29,15 @@ const (
 
 	// FlagDeltaVersion specify the DeltaVersion
 	FlagDeltaVersion = "delta-version"
+
+	// FlagDeltaBroker specify how deltas are distributed, redis or p2p
+	FlagDeltaBroker = "delta-broker"
+	// FlagDeltaProducers is the comma separated node ids of the producers whose deltas are
+	// accepted by the p2p delta broker
+	FlagDeltaProducers = "delta-producers"
+
+	DeltaBrokerRedis = "redis"
+	DeltaBrokerP2P   = "p2p"
 )
 
 var (
@@ -360,6 +369,9 @@ type Deltas struct {
 	CompressType int
 	CompressFlag int
 	From         string
+	// AppHash is the app hash committed by the producer. It is not part of the encoding and
+	// only distributed by a delta.VerifiableDeltaBroker.
+	AppHash []byte
 
 	marshalElapsed  time.Duration
 	compressElapsed time.Duration
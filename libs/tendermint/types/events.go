@@ -51,6 +51,7 @@ const (
 	Recheck RmPendingTxReason = iota
 	MinGasPrice
 	Confirmed
+	Replaced
 )
 
 var EnableEventBlockTime = false
@@ -42,6 +42,9 @@ var (
 	MILESTONE_VENUS5_HEIGHT string
 	milestoneVenus5Height   int64
 
+	MILESTONE_JUPITER_HEIGHT string
+	milestoneJupiterHeight   int64
+
 	MILESTONE_VENUS6_NAME       = "venus6"
 	milestoneVenus6Height int64 = 0
 
@@ -88,6 +91,7 @@ func init() {
 		milestoneEarthHeight = string2number(MILESTONE_EARTH_HEIGHT)
 		milestoneVenus4Height = string2number(MILESTONE_VENUS4_HEIGHT)
 		milestoneVenus5Height = string2number(MILESTONE_VENUS5_HEIGHT)
+		milestoneJupiterHeight = string2number(MILESTONE_JUPITER_HEIGHT)
 	})
 }
 
@@ -315,6 +319,26 @@ func GetVenus5Height() int64 {
 // =========== Venus5 ===============
 // ==================================
 
+// ==================================
+// =========== Jupiter ===============
+func UnittestOnlySetMilestoneJupiterHeight(h int64) {
+	milestoneJupiterHeight = h
+}
+
+func HigherThanJupiter(h int64) bool {
+	if milestoneJupiterHeight == 0 {
+		return false
+	}
+	return h >= milestoneJupiterHeight
+}
+
+func GetJupiterHeight() int64 {
+	return milestoneJupiterHeight
+}
+
+// =========== Jupiter ===============
+// ==================================
+
 // ==================================
 // =========== Venus6 ===============
 func HigherThanVenus6(h int64) bool {
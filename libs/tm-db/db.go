@@ -3,6 +3,7 @@ package db
 import (
 	"bufio"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
@@ -36,11 +37,17 @@ const (
 	//   - requires gcc
 	//   - use rocksdb build tag (go build -tags rocksdb)
 	RocksDBBackend BackendType = "rocksdb"
+	// PebbleDBBackend represents pebble (uses github.com/cockroachdb/pebble)
+	//   - EXPERIMENTAL
+	//   - pure go
+	//   - no compaction stalls like goleveldb
+	PebbleDBBackend BackendType = "pebbledb"
 	// UnknownDBBackend unknown db type
 	UnknownDBBackend BackendType = "unknown"
 
 	FlagGoLeveldbOpts = "goleveldb.opts"
 	FlagRocksdbOpts   = "rocksdb.opts"
+	FlagPebbledbOpts  = "pebbledb.opts"
 )
 
 type dbCreator func(name string, dir string) (DB, error)
@@ -86,10 +93,14 @@ func NewDB(name string, backend BackendType, dir string) DB {
 	return db
 }
 
-// checkDBType check whether the db file is goleveldb or rocksdb,
-// only goleveldb and rocksdb are supported, otherwise it returns unknown.
+// checkDBType check whether the db file is goleveldb, rocksdb or pebbledb,
+// only goleveldb, rocksdb and pebbledb are supported, otherwise it returns unknown.
 // Ignore artificial changes to db files
 func checkDBType(name string, dir string) BackendType {
+	if isPebbleDB(name, dir) {
+		return PebbleDBBackend
+	}
+
 	logPath := filepath.Join(dir, name+".db", "LOG")
 	file, err := os.Open(logPath)
 	if err != nil {
@@ -120,3 +131,16 @@ func checkDBType(name string, dir string) BackendType {
 
 	return UnknownDBBackend
 }
+
+// isPebbleDB checks the OPTIONS file, pebble writes its version there and writes no LOG file
+func isPebbleDB(name string, dir string) bool {
+	optionsPaths, err := filepath.Glob(filepath.Join(dir, name+".db", "OPTIONS-*"))
+	if err != nil || len(optionsPaths) == 0 {
+		return false
+	}
+	content, err := ioutil.ReadFile(optionsPaths[len(optionsPaths)-1])
+	if err != nil {
+		return false
+	}
+	return strings.Contains(string(content), "pebble_version")
+}
@@ -0,0 +1,303 @@
+package db
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strconv"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/bloom"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/viper"
+)
+
+func init() {
+	dbCreator := func(name string, dir string) (DB, error) {
+		return NewPebbleDB(name, dir)
+	}
+	registerDBCreator(PebbleDBBackend, dbCreator, false)
+}
+
+// PebbleDB is a PebbleDB backend.
+type PebbleDB struct {
+	db *pebble.DB
+}
+
+var _ DB = (*PebbleDB)(nil)
+
+const (
+	pebbleBlockSize                = "block_size"
+	pebbleBlockCache               = "block_cache"
+	pebbleStatistics               = "statistics"
+	pebbleMaxOpenFiles             = "max_open_files"
+	pebbleMemTableSize             = "memtable_size"
+	pebbleL0CompactionThreshold    = "l0_compaction_threshold"
+	pebbleL0StopWritesThreshold    = "l0_stop_writes_threshold"
+	pebbleLBaseMaxBytes            = "lbase_max_bytes"
+	pebbleMaxConcurrentCompactions = "max_concurrent_compactions"
+	pebbleBytesPerSync             = "bytes_per_sync"
+
+	defaultPebbleBlockCache   = 1 << 30
+	defaultPebbleMemTableSize = 256 << 20
+	defaultPebbleMaxOpenFiles = 16384
+)
+
+func NewPebbleDB(name string, dir string) (*PebbleDB, error) {
+	// default pebble option, tuned like the default rocksdb options:
+	// 1GB block cache, 256MB memtable(at most 2 memtables may be queued before stalling writes).
+	params := parseOptParams(viper.GetString(FlagPebbledbOpts))
+
+	cacheSize := int64(defaultPebbleBlockCache)
+	if v, ok := params[pebbleBlockCache]; ok {
+		size, err := toBytes(v)
+		if err != nil {
+			panic(fmt.Sprintf("Invalid options parameter %s: %s", pebbleBlockCache, err))
+		}
+		cacheSize = int64(size)
+	}
+	cache := pebble.NewCache(cacheSize)
+	defer cache.Unref()
+
+	opts := &pebble.Options{
+		Cache:                       cache,
+		MaxOpenFiles:                defaultPebbleMaxOpenFiles,
+		MemTableSize:                defaultPebbleMemTableSize,
+		MemTableStopWritesThreshold: 4,
+		L0CompactionThreshold:       2,
+		L0StopWritesThreshold:       1000,
+		LBaseMaxBytes:               64 << 20,
+		MaxConcurrentCompactions:    func() int { return runtime.NumCPU() },
+		Levels:                      make([]pebble.LevelOptions, 7),
+	}
+
+	blockSize := 32 << 10
+	if v, ok := params[pebbleBlockSize]; ok {
+		size, err := toBytes(v)
+		if err != nil {
+			panic(fmt.Sprintf("Invalid options parameter %s: %s", pebbleBlockSize, err))
+		}
+		blockSize = int(size)
+	}
+	for i := range opts.Levels {
+		l := &opts.Levels[i]
+		l.BlockSize = blockSize
+		l.IndexBlockSize = 256 << 10
+		l.FilterPolicy = bloom.FilterPolicy(10)
+		l.FilterType = pebble.TableFilter
+		if i > 0 {
+			l.TargetFileSize = opts.Levels[i-1].TargetFileSize * 2
+		}
+		l.EnsureDefaults()
+	}
+
+	if v, ok := params[pebbleMaxOpenFiles]; ok {
+		maxOpenFiles, err := strconv.Atoi(v)
+		if err != nil {
+			panic(fmt.Sprintf("Invalid options parameter %s: %s", pebbleMaxOpenFiles, err))
+		}
+		opts.MaxOpenFiles = maxOpenFiles
+	}
+
+	if v, ok := params[pebbleMemTableSize]; ok {
+		size, err := toBytes(v)
+		if err != nil {
+			panic(fmt.Sprintf("Invalid options parameter %s: %s", pebbleMemTableSize, err))
+		}
+		opts.MemTableSize = int(size)
+	}
+
+	if v, ok := params[pebbleL0CompactionThreshold]; ok {
+		threshold, err := strconv.Atoi(v)
+		if err != nil {
+			panic(fmt.Sprintf("Invalid options parameter %s: %s", pebbleL0CompactionThreshold, err))
+		}
+		opts.L0CompactionThreshold = threshold
+	}
+
+	if v, ok := params[pebbleL0StopWritesThreshold]; ok {
+		threshold, err := strconv.Atoi(v)
+		if err != nil {
+			panic(fmt.Sprintf("Invalid options parameter %s: %s", pebbleL0StopWritesThreshold, err))
+		}
+		opts.L0StopWritesThreshold = threshold
+	}
+
+	if v, ok := params[pebbleLBaseMaxBytes]; ok {
+		size, err := toBytes(v)
+		if err != nil {
+			panic(fmt.Sprintf("Invalid options parameter %s: %s", pebbleLBaseMaxBytes, err))
+		}
+		opts.LBaseMaxBytes = int64(size)
+	}
+
+	if v, ok := params[pebbleMaxConcurrentCompactions]; ok {
+		concurrency, err := strconv.Atoi(v)
+		if err != nil || concurrency <= 0 {
+			panic(fmt.Sprintf("Invalid options parameter %s: %s", pebbleMaxConcurrentCompactions, v))
+		}
+		opts.MaxConcurrentCompactions = func() int { return concurrency }
+	}
+
+	if v, ok := params[pebbleBytesPerSync]; ok {
+		size, err := toBytes(v)
+		if err != nil {
+			panic(fmt.Sprintf("Invalid options parameter %s: %s", pebbleBytesPerSync, err))
+		}
+		opts.BytesPerSync = int(size)
+	}
+
+	database, err := NewPebbleDBWithOptions(name, dir, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if v, ok := params[pebbleStatistics]; ok {
+		enable, err := strconv.ParseBool(v)
+		if err != nil {
+			panic(fmt.Sprintf("Invalid options parameter %s: %s", pebbleStatistics, err))
+		}
+		if enable && name == "application" {
+			pdbMetrics := NewPebbleDBMetrics(database.db)
+			prometheus.Unregister(pdbMetrics)
+			prometheus.MustRegister(pdbMetrics)
+		}
+	}
+	return database, nil
+}
+
+func NewPebbleDBWithOptions(name string, dir string, opts *pebble.Options) (*PebbleDB, error) {
+	dbPath := filepath.Join(dir, name+".db")
+	opts.EnsureDefaults()
+	db, err := pebble.Open(dbPath, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &PebbleDB{db: db}, nil
+}
+
+// Get implements DB.
+func (db *PebbleDB) Get(key []byte) ([]byte, error) {
+	key = nonNilBytes(key)
+	res, closer, err := db.db.Get(key)
+	if err != nil {
+		if err == pebble.ErrNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer closer.Close()
+	return cp(res), nil
+}
+
+func (db *PebbleDB) GetUnsafeValue(key []byte, processor UnsafeValueProcessor) (interface{}, error) {
+	key = nonNilBytes(key)
+	res, closer, err := db.db.Get(key)
+	if err != nil {
+		if err == pebble.ErrNotFound {
+			return processor(nil)
+		}
+		return nil, err
+	}
+	defer closer.Close()
+	return processor(res)
+}
+
+// Has implements DB.
+func (db *PebbleDB) Has(key []byte) (bool, error) {
+	bytes, err := db.Get(key)
+	if err != nil {
+		return false, err
+	}
+	return bytes != nil, nil
+}
+
+// Set implements DB.
+func (db *PebbleDB) Set(key []byte, value []byte) error {
+	key = nonNilBytes(key)
+	value = nonNilBytes(value)
+	return db.db.Set(key, value, pebble.NoSync)
+}
+
+// SetSync implements DB.
+func (db *PebbleDB) SetSync(key []byte, value []byte) error {
+	key = nonNilBytes(key)
+	value = nonNilBytes(value)
+	return db.db.Set(key, value, pebble.Sync)
+}
+
+// Delete implements DB.
+func (db *PebbleDB) Delete(key []byte) error {
+	key = nonNilBytes(key)
+	return db.db.Delete(key, pebble.NoSync)
+}
+
+// DeleteSync implements DB.
+func (db *PebbleDB) DeleteSync(key []byte) error {
+	key = nonNilBytes(key)
+	return db.db.Delete(key, pebble.Sync)
+}
+
+func (db *PebbleDB) DB() *pebble.DB {
+	return db.db
+}
+
+// Close implements DB.
+func (db *PebbleDB) Close() error {
+	return db.db.Close()
+}
+
+// Print implements DB.
+func (db *PebbleDB) Print() error {
+	itr, err := db.Iterator(nil, nil)
+	if err != nil {
+		return err
+	}
+	defer itr.Close()
+	for ; itr.Valid(); itr.Next() {
+		key := itr.Key()
+		value := itr.Value()
+		fmt.Printf("[%X]:\t[%X]\n", key, value)
+	}
+	return nil
+}
+
+// Stats implements DB.
+func (db *PebbleDB) Stats() map[string]string {
+	return map[string]string{
+		"pebble.stats": db.db.Metrics().String(),
+	}
+}
+
+// NewBatch implements DB.
+func (db *PebbleDB) NewBatch() Batch {
+	return NewPebbleDBBatch(db)
+}
+
+// Iterator implements DB.
+func (db *PebbleDB) Iterator(start, end []byte) (Iterator, error) {
+	itr := db.db.NewIter(&pebble.IterOptions{LowerBound: start, UpperBound: end})
+	return NewPebbleDBIterator(itr, start, end, false), nil
+}
+
+// ReverseIterator implements DB.
+func (db *PebbleDB) ReverseIterator(start, end []byte) (Iterator, error) {
+	itr := db.db.NewIter(&pebble.IterOptions{LowerBound: start, UpperBound: end})
+	return NewPebbleDBIterator(itr, start, end, true), nil
+}
+
+// Compact implements DB, the whole key range of the db is compacted.
+func (db *PebbleDB) Compact() error {
+	itr := db.db.NewIter(nil)
+	defer itr.Close()
+	if !itr.First() {
+		return itr.Error()
+	}
+	first := cp(itr.Key())
+	if !itr.Last() {
+		return itr.Error()
+	}
+	// the end key of pebble.DB.Compact must be bigger than the start key
+	last := append(cp(itr.Key()), 0)
+	return db.db.Compact(first, last, true)
+}
@@ -0,0 +1,81 @@
+package db
+
+import "github.com/cockroachdb/pebble"
+
+type PebbleDBBatch struct {
+	db    *PebbleDB
+	batch *pebble.Batch
+}
+
+var _ Batch = (*PebbleDBBatch)(nil)
+
+func NewPebbleDBBatch(db *PebbleDB) *PebbleDBBatch {
+	return &PebbleDBBatch{
+		db:    db,
+		batch: db.db.NewBatch(),
+	}
+}
+
+func (b *PebbleDBBatch) assertOpen() {
+	if b.batch == nil {
+		panic("batch has been written or closed")
+	}
+}
+
+// Set implements Batch.
+func (b *PebbleDBBatch) Set(key, value []byte) {
+	b.assertOpen()
+	if err := b.batch.Set(nonNilBytes(key), nonNilBytes(value), nil); err != nil {
+		panic(err)
+	}
+}
+
+// Delete implements Batch.
+func (b *PebbleDBBatch) Delete(key []byte) {
+	b.assertOpen()
+	if err := b.batch.Delete(nonNilBytes(key), nil); err != nil {
+		panic(err)
+	}
+}
+
+// Write implements Batch.
+func (b *PebbleDBBatch) Write() error {
+	b.assertOpen()
+	err := b.batch.Commit(pebble.NoSync)
+	if err != nil {
+		return err
+	}
+	// Make sure batch cannot be used afterwards. Callers should still call Close(), for errors.
+	b.Close()
+	return nil
+}
+
+// WriteSync implements Batch.
+func (b *PebbleDBBatch) WriteSync() error {
+	b.assertOpen()
+	err := b.batch.Commit(pebble.Sync)
+	if err != nil {
+		return err
+	}
+	// Make sure batch cannot be used afterwards. Callers should still call Close(), for errors.
+	b.Close()
+	return nil
+}
+
+// Close implements Batch.
+func (b *PebbleDBBatch) Close() {
+	if b.batch != nil {
+		b.batch.Close()
+		b.batch = nil
+	}
+}
+
+func (b *PebbleDBBatch) Size() int {
+	b.assertOpen()
+	return int(b.batch.Count())
+}
+
+func (b *PebbleDBBatch) Reset() {
+	b.assertOpen()
+	b.batch.Reset()
+}
@@ -0,0 +1,97 @@
+package db
+
+import "github.com/cockroachdb/pebble"
+
+type PebbleDBIterator struct {
+	source     *pebble.Iterator
+	start, end []byte
+	isReverse  bool
+	isInvalid  bool
+}
+
+var _ Iterator = (*PebbleDBIterator)(nil)
+
+// NewPebbleDBIterator positions the source iterator, the domain is enforced by the bounds of the source.
+func NewPebbleDBIterator(source *pebble.Iterator, start, end []byte, isReverse bool) *PebbleDBIterator {
+	if isReverse {
+		source.Last()
+	} else {
+		source.First()
+	}
+	return &PebbleDBIterator{
+		source:    source,
+		start:     start,
+		end:       end,
+		isReverse: isReverse,
+		isInvalid: false,
+	}
+}
+
+// Domain implements Iterator.
+func (itr *PebbleDBIterator) Domain() ([]byte, []byte) {
+	return itr.start, itr.end
+}
+
+// Valid implements Iterator.
+func (itr *PebbleDBIterator) Valid() bool {
+	// Once invalid, forever invalid.
+	if itr.isInvalid {
+		return false
+	}
+
+	// Panic on DB error.  No way to recover.
+	itr.assertNoError()
+
+	// If source is invalid, invalid.
+	if !itr.source.Valid() {
+		itr.isInvalid = true
+		return false
+	}
+
+	// It's valid.
+	return true
+}
+
+// Key implements Iterator.
+func (itr *PebbleDBIterator) Key() []byte {
+	itr.assertIsValid()
+	return cp(itr.source.Key())
+}
+
+// Value implements Iterator.
+func (itr *PebbleDBIterator) Value() []byte {
+	itr.assertIsValid()
+	return cp(itr.source.Value())
+}
+
+// Next implements Iterator.
+func (itr *PebbleDBIterator) Next() {
+	itr.assertIsValid()
+	if itr.isReverse {
+		itr.source.Prev()
+	} else {
+		itr.source.Next()
+	}
+}
+
+// Error implements Iterator.
+func (itr *PebbleDBIterator) Error() error {
+	return itr.source.Error()
+}
+
+// Close implements Iterator.
+func (itr *PebbleDBIterator) Close() {
+	itr.source.Close()
+}
+
+func (itr *PebbleDBIterator) assertNoError() {
+	if err := itr.source.Error(); err != nil {
+		panic(err)
+	}
+}
+
+func (itr *PebbleDBIterator) assertIsValid() {
+	if !itr.Valid() {
+		panic("pebbleDBIterator is invalid")
+	}
+}
@@ -0,0 +1,156 @@
+package db
+
+import (
+	"strconv"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type PebbleDBMetrics struct {
+	db *pebble.DB
+
+	blockCacheSize            *prometheus.GaugeVec
+	blockCacheCount           *prometheus.GaugeVec
+	blockCacheHit             *prometheus.GaugeVec
+	blockCacheMiss            *prometheus.GaugeVec
+	tableCacheSize            *prometheus.GaugeVec
+	tableCacheCount           *prometheus.GaugeVec
+	tableCacheHit             *prometheus.GaugeVec
+	tableCacheMiss            *prometheus.GaugeVec
+	bloomFilterHit            *prometheus.GaugeVec
+	bloomFilterMiss           *prometheus.GaugeVec
+	compactionCount           *prometheus.GaugeVec
+	compactionEstimateDebt    *prometheus.GaugeVec
+	compactionInProgress      *prometheus.GaugeVec
+	compactionInProgressBytes *prometheus.GaugeVec
+	flushCount                *prometheus.GaugeVec
+	memtableSize              *prometheus.GaugeVec
+	memtableCount             *prometheus.GaugeVec
+	walSize                   *prometheus.GaugeVec
+	walBytesWritten           *prometheus.GaugeVec
+	readAmp                   *prometheus.GaugeVec
+	diskSpaceUsage            *prometheus.GaugeVec
+	levelFiles                *prometheus.GaugeVec
+	levelSize                 *prometheus.GaugeVec
+	levelSublevels            *prometheus.GaugeVec
+	levelBytesCompacted       *prometheus.GaugeVec
+}
+
+func NewPebbleDBMetrics(db *pebble.DB) *PebbleDBMetrics {
+	return &PebbleDBMetrics{
+		db:                        db,
+		blockCacheSize:            prometheus.NewGaugeVec(prometheus.GaugeOpts{Namespace: "pebbledb", Name: "block_cache_size"}, nil),
+		blockCacheCount:           prometheus.NewGaugeVec(prometheus.GaugeOpts{Namespace: "pebbledb", Name: "block_cache_count"}, nil),
+		blockCacheHit:             prometheus.NewGaugeVec(prometheus.GaugeOpts{Namespace: "pebbledb", Name: "block_cache_hit"}, nil),
+		blockCacheMiss:            prometheus.NewGaugeVec(prometheus.GaugeOpts{Namespace: "pebbledb", Name: "block_cache_miss"}, nil),
+		tableCacheSize:            prometheus.NewGaugeVec(prometheus.GaugeOpts{Namespace: "pebbledb", Name: "table_cache_size"}, nil),
+		tableCacheCount:           prometheus.NewGaugeVec(prometheus.GaugeOpts{Namespace: "pebbledb", Name: "table_cache_count"}, nil),
+		tableCacheHit:             prometheus.NewGaugeVec(prometheus.GaugeOpts{Namespace: "pebbledb", Name: "table_cache_hit"}, nil),
+		tableCacheMiss:            prometheus.NewGaugeVec(prometheus.GaugeOpts{Namespace: "pebbledb", Name: "table_cache_miss"}, nil),
+		bloomFilterHit:            prometheus.NewGaugeVec(prometheus.GaugeOpts{Namespace: "pebbledb", Name: "bloom_filter_hit"}, nil),
+		bloomFilterMiss:           prometheus.NewGaugeVec(prometheus.GaugeOpts{Namespace: "pebbledb", Name: "bloom_filter_miss"}, nil),
+		compactionCount:           prometheus.NewGaugeVec(prometheus.GaugeOpts{Namespace: "pebbledb", Name: "compaction_count"}, nil),
+		compactionEstimateDebt:    prometheus.NewGaugeVec(prometheus.GaugeOpts{Namespace: "pebbledb", Name: "compaction_estimated_debt"}, nil),
+		compactionInProgress:      prometheus.NewGaugeVec(prometheus.GaugeOpts{Namespace: "pebbledb", Name: "compaction_in_progress"}, nil),
+		compactionInProgressBytes: prometheus.NewGaugeVec(prometheus.GaugeOpts{Namespace: "pebbledb", Name: "compaction_in_progress_bytes"}, nil),
+		flushCount:                prometheus.NewGaugeVec(prometheus.GaugeOpts{Namespace: "pebbledb", Name: "flush_count"}, nil),
+		memtableSize:              prometheus.NewGaugeVec(prometheus.GaugeOpts{Namespace: "pebbledb", Name: "memtable_size"}, nil),
+		memtableCount:             prometheus.NewGaugeVec(prometheus.GaugeOpts{Namespace: "pebbledb", Name: "memtable_count"}, nil),
+		walSize:                   prometheus.NewGaugeVec(prometheus.GaugeOpts{Namespace: "pebbledb", Name: "wal_size"}, nil),
+		walBytesWritten:           prometheus.NewGaugeVec(prometheus.GaugeOpts{Namespace: "pebbledb", Name: "wal_bytes_written"}, nil),
+		readAmp:                   prometheus.NewGaugeVec(prometheus.GaugeOpts{Namespace: "pebbledb", Name: "read_amp"}, nil),
+		diskSpaceUsage:            prometheus.NewGaugeVec(prometheus.GaugeOpts{Namespace: "pebbledb", Name: "disk_space_usage"}, nil),
+		levelFiles:                prometheus.NewGaugeVec(prometheus.GaugeOpts{Namespace: "pebbledb", Name: "level_files"}, []string{"level"}),
+		levelSize:                 prometheus.NewGaugeVec(prometheus.GaugeOpts{Namespace: "pebbledb", Name: "level_size"}, []string{"level"}),
+		levelSublevels:            prometheus.NewGaugeVec(prometheus.GaugeOpts{Namespace: "pebbledb", Name: "level_sublevels"}, []string{"level"}),
+		levelBytesCompacted:       prometheus.NewGaugeVec(prometheus.GaugeOpts{Namespace: "pebbledb", Name: "level_bytes_compacted"}, []string{"level"}),
+	}
+}
+
+func (dbm *PebbleDBMetrics) Describe(dc chan<- *prometheus.Desc) {
+	dbm.blockCacheSize.Describe(dc)
+	dbm.blockCacheCount.Describe(dc)
+	dbm.blockCacheHit.Describe(dc)
+	dbm.blockCacheMiss.Describe(dc)
+	dbm.tableCacheSize.Describe(dc)
+	dbm.tableCacheCount.Describe(dc)
+	dbm.tableCacheHit.Describe(dc)
+	dbm.tableCacheMiss.Describe(dc)
+	dbm.bloomFilterHit.Describe(dc)
+	dbm.bloomFilterMiss.Describe(dc)
+	dbm.compactionCount.Describe(dc)
+	dbm.compactionEstimateDebt.Describe(dc)
+	dbm.compactionInProgress.Describe(dc)
+	dbm.compactionInProgressBytes.Describe(dc)
+	dbm.flushCount.Describe(dc)
+	dbm.memtableSize.Describe(dc)
+	dbm.memtableCount.Describe(dc)
+	dbm.walSize.Describe(dc)
+	dbm.walBytesWritten.Describe(dc)
+	dbm.readAmp.Describe(dc)
+	dbm.diskSpaceUsage.Describe(dc)
+	dbm.levelFiles.Describe(dc)
+	dbm.levelSize.Describe(dc)
+	dbm.levelSublevels.Describe(dc)
+	dbm.levelBytesCompacted.Describe(dc)
+}
+
+func (dbm *PebbleDBMetrics) Collect(mc chan<- prometheus.Metric) {
+	m := dbm.db.Metrics()
+
+	dbm.blockCacheSize.WithLabelValues().Set(float64(m.BlockCache.Size))
+	dbm.blockCacheCount.WithLabelValues().Set(float64(m.BlockCache.Count))
+	dbm.blockCacheHit.WithLabelValues().Set(float64(m.BlockCache.Hits))
+	dbm.blockCacheMiss.WithLabelValues().Set(float64(m.BlockCache.Misses))
+	dbm.tableCacheSize.WithLabelValues().Set(float64(m.TableCache.Size))
+	dbm.tableCacheCount.WithLabelValues().Set(float64(m.TableCache.Count))
+	dbm.tableCacheHit.WithLabelValues().Set(float64(m.TableCache.Hits))
+	dbm.tableCacheMiss.WithLabelValues().Set(float64(m.TableCache.Misses))
+	dbm.bloomFilterHit.WithLabelValues().Set(float64(m.Filter.Hits))
+	dbm.bloomFilterMiss.WithLabelValues().Set(float64(m.Filter.Misses))
+	dbm.compactionCount.WithLabelValues().Set(float64(m.Compact.Count))
+	dbm.compactionEstimateDebt.WithLabelValues().Set(float64(m.Compact.EstimatedDebt))
+	dbm.compactionInProgress.WithLabelValues().Set(float64(m.Compact.NumInProgress))
+	dbm.compactionInProgressBytes.WithLabelValues().Set(float64(m.Compact.InProgressBytes))
+	dbm.flushCount.WithLabelValues().Set(float64(m.Flush.Count))
+	dbm.memtableSize.WithLabelValues().Set(float64(m.MemTable.Size))
+	dbm.memtableCount.WithLabelValues().Set(float64(m.MemTable.Count))
+	dbm.walSize.WithLabelValues().Set(float64(m.WAL.Size))
+	dbm.walBytesWritten.WithLabelValues().Set(float64(m.WAL.BytesWritten))
+	dbm.readAmp.WithLabelValues().Set(float64(m.ReadAmp()))
+	dbm.diskSpaceUsage.WithLabelValues().Set(float64(m.DiskSpaceUsage()))
+	for i, l := range m.Levels {
+		level := strconv.Itoa(i)
+		dbm.levelFiles.WithLabelValues(level).Set(float64(l.NumFiles))
+		dbm.levelSize.WithLabelValues(level).Set(float64(l.Size))
+		dbm.levelSublevels.WithLabelValues(level).Set(float64(l.Sublevels))
+		dbm.levelBytesCompacted.WithLabelValues(level).Set(float64(l.BytesCompacted))
+	}
+
+	dbm.blockCacheSize.Collect(mc)
+	dbm.blockCacheCount.Collect(mc)
+	dbm.blockCacheHit.Collect(mc)
+	dbm.blockCacheMiss.Collect(mc)
+	dbm.tableCacheSize.Collect(mc)
+	dbm.tableCacheCount.Collect(mc)
+	dbm.tableCacheHit.Collect(mc)
+	dbm.tableCacheMiss.Collect(mc)
+	dbm.bloomFilterHit.Collect(mc)
+	dbm.bloomFilterMiss.Collect(mc)
+	dbm.compactionCount.Collect(mc)
+	dbm.compactionEstimateDebt.Collect(mc)
+	dbm.compactionInProgress.Collect(mc)
+	dbm.compactionInProgressBytes.Collect(mc)
+	dbm.flushCount.Collect(mc)
+	dbm.memtableSize.Collect(mc)
+	dbm.memtableCount.Collect(mc)
+	dbm.walSize.Collect(mc)
+	dbm.walBytesWritten.Collect(mc)
+	dbm.readAmp.Collect(mc)
+	dbm.diskSpaceUsage.Collect(mc)
+	dbm.levelFiles.Collect(mc)
+	dbm.levelSize.Collect(mc)
+	dbm.levelSublevels.Collect(mc)
+	dbm.levelBytesCompacted.Collect(mc)
+}
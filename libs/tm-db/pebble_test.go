@@ -0,0 +1,64 @@
+package db
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPebbleDBBackend(t *testing.T) {
+	name := fmt.Sprintf("test_%x", randStr(12))
+	dir := os.TempDir()
+	db := NewDB(name, PebbleDBBackend, dir)
+	defer cleanupDBDir(dir, name)
+
+	_, ok := db.(*PebbleDB)
+	assert.True(t, ok)
+}
+
+func TestPebbleDBStats(t *testing.T) {
+	name := fmt.Sprintf("test_%x", randStr(12))
+	dir := os.TempDir()
+	db := NewDB(name, PebbleDBBackend, dir)
+	defer cleanupDBDir(dir, name)
+
+	assert.NotEmpty(t, db.Stats())
+}
+
+func TestPebbleDBCheckType(t *testing.T) {
+	name := fmt.Sprintf("test_%x", randStr(12))
+	dir := os.TempDir()
+	db := NewDB(name, PebbleDBBackend, dir)
+	defer cleanupDBDir(dir, name)
+	require.NoError(t, db.Set([]byte("key"), []byte("value")))
+	require.NoError(t, db.Close())
+
+	assert.Equal(t, PebbleDBBackend, checkDBType(name, dir))
+	assert.Panics(t, func() { NewDB(name, GoLevelDBBackend, dir) })
+}
+
+func TestPebbleDBCompact(t *testing.T) {
+	name := fmt.Sprintf("test_%x", randStr(12))
+	dir := os.TempDir()
+	db := NewDB(name, PebbleDBBackend, dir)
+	defer cleanupDBDir(dir, name)
+	defer db.Close()
+
+	// compacting an empty db is a no-op
+	require.NoError(t, db.Compact())
+
+	batch := db.NewBatch()
+	for i := 0; i < 100; i++ {
+		batch.Set([]byte(fmt.Sprintf("key%03d", i)), []byte("value"))
+	}
+	require.NoError(t, batch.WriteSync())
+	batch.Close()
+	require.NoError(t, db.Compact())
+
+	value, err := db.Get([]byte("key099"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+}
@@ -4,9 +4,10 @@ import (
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 )
 
-// BeginBlocker check for infraction evidence or downtime of validators
+// BeginBlocker updates the price checkpoints of the swap token pairs
 // on every begin block
 func BeginBlocker(ctx sdk.Context, k Keeper) {
+	k.UpdateTWAPCheckpoints(ctx)
 }
 
 // EndBlocker called every block, process inflation, update validator set.
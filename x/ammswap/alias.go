@@ -12,6 +12,7 @@ const (
 	StoreKey          = types.StoreKey
 	DefaultParamspace = types.DefaultParamspace
 	QuerierRoute      = types.QuerierRoute
+	DefaultCodespace  = types.DefaultCodespace
 )
 
 var (
@@ -34,5 +35,6 @@ type (
 	Params = types.Params
 
 	// nolint
-	SwapTokenPair = types.SwapTokenPair
+	SwapTokenPair  = types.SwapTokenPair
+	StableSwapPool = types.StableSwapPool
 )
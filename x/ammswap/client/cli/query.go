@@ -11,7 +11,9 @@ import (
 	"github.com/okex/exchain/libs/cosmos-sdk/version"
 	"github.com/okex/exchain/x/ammswap/types"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	"strings"
+	"time"
 )
 
 // GetQueryCmd returns the cli query commands for this module
@@ -32,6 +34,9 @@ func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
 			GetCmdAllSwapTokenPairs(queryRoute, cdc),
 			GetCmdRedeemableAssets(queryRoute, cdc),
 			GetCmdQueryBuyAmount(queryRoute, cdc),
+			GetCmdQuerySwapRoute(queryRoute, cdc),
+			GetCmdQueryTWAP(queryRoute, cdc),
+			GetCmdQueryStableSwapPool(queryRoute, cdc),
 		)...,
 	)
 
@@ -109,6 +114,107 @@ $ %s query swap amount 100eth-245 xxb`, version.ClientName,
 	}
 }
 
+// GetCmdQuerySwapRoute queries the best swap route and its quote between two tokens
+func GetCmdQuerySwapRoute(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "route [token-amount] [target-token]",
+		Short: "Query the best swap route and its quote between two tokens",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(
+				`Query the best swap route for selling the given amount of token to buy the target token.
+With --exact-output, query the best route for buying the given amount of token by selling the target token.
+
+Example:
+$ %s query swap route 100eth-245 xxb
+$ %s query swap route 10xxb eth-245 --exact-output`, version.ClientName, version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			exactOutput := viper.GetBool(flagExactOutput)
+			params := types.NewQuerySwapRouteParams(args[0], args[1], exactOutput)
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QuerySwapRoute), bz)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+	cmd.Flags().Bool(flagExactOutput, false, "Whether the token amount is the exact amount to buy")
+	return cmd
+}
+
+// GetCmdQueryTWAP queries the time-weighted average prices of a pool over a window
+func GetCmdQueryTWAP(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "twap [base-token] [quote-token] [window]",
+		Short: "Query the time-weighted average prices of a pool over a window",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(
+				`Query the time-weighted average prices of a pool over the window which ends at the latest block.
+
+Example:
+$ %s query swap twap eth-355 okt 1h`, version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			window, err := time.ParseDuration(args[2])
+			if err != nil {
+				return err
+			}
+			params := types.NewQueryTWAPParams(args[0], args[1], int64(window.Seconds()))
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryTWAP), bz)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+}
+
+// GetCmdQueryStableSwapPool queries the amplification coefficient schedule of a stable swap pool
+func GetCmdQueryStableSwapPool(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "stable-pool [base-token] [quote-token]",
+		Short: "Query the amplification coefficient of a stable swap pool",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(
+				`Query the current amplification coefficient of a stable swap pool and its ramp schedule.
+
+Example:
+$ %s query swap stable-pool usdt-355 usdc-366`, version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			swapTokenPairName := types.GetSwapTokenPairName(args[0], args[1])
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", queryRoute, types.QueryStableSwapPool, swapTokenPairName), nil)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+}
+
 // GetCmdQueryParams queries the parameters of the AMM swap system
 func GetCmdQueryParams(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
@@ -9,10 +9,14 @@ import (
 	"github.com/okex/exchain/libs/cosmos-sdk/client/context"
 	client "github.com/okex/exchain/libs/cosmos-sdk/client/flags"
 	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	interfacetypes "github.com/okex/exchain/libs/cosmos-sdk/codec/types"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/version"
 	"github.com/okex/exchain/libs/cosmos-sdk/x/auth"
 	"github.com/okex/exchain/libs/cosmos-sdk/x/auth/client/utils"
+	swaputils "github.com/okex/exchain/x/ammswap/client/utils"
 	"github.com/okex/exchain/x/ammswap/types"
+	"github.com/okex/exchain/x/gov"
 	"github.com/spf13/cobra"
 )
 
@@ -30,6 +34,9 @@ const (
 	flagRecipient        = "recipient"
 	flagToken0           = "token0"
 	flagToken1           = "token1"
+	flagBuyAmount        = "buy-amount"
+	flagHops             = "hops"
+	flagExactOutput      = "exact-output"
 )
 
 // GetTxCmd returns the transaction commands for this module
@@ -44,6 +51,7 @@ func GetTxCmd(cdc *codec.Codec) *cobra.Command {
 		getCmdRemoveLiquidity(cdc),
 		getCmdCreateExchange(cdc),
 		getCmdTokenSwap(cdc),
+		getCmdSwapRoute(cdc),
 	)...)
 
 	return txCmd
@@ -257,3 +265,131 @@ $ exchaincli tx swap token --sell-amount 1eth-355 --min-buy-amount 60btc-366
 
 	return cmd
 }
+
+func getCmdSwapRoute(cdc *codec.Codec) *cobra.Command {
+	// flags
+	var soldTokenAmount string
+	var boughtTokenAmount string
+	var hops []string
+	var exactOutput bool
+	var deadline string
+	var recipient string
+	cmd := &cobra.Command{
+		Use:   "route",
+		Short: "swap token along a route of pools",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`swap token along a route of pools. The route is given by the intermediate tokens.
+By default the whole sell amount is sold and buy amount is the minimum to buy.
+With --exact-output, exactly buy amount is bought and sell amount is the maximum to sell.
+
+Example:
+$ exchaincli tx swap route --sell-amount 1eth-355 --buy-amount 60btc-366 --hops okt,usdk-017
+$ exchaincli tx swap route --sell-amount 2eth-355 --buy-amount 60btc-366 --hops okt --exact-output
+
+`),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			soldTokenAmount, err := sdk.ParseDecCoin(soldTokenAmount)
+			if err != nil {
+				return err
+			}
+			boughtTokenAmount, err := sdk.ParseDecCoin(boughtTokenAmount)
+			if err != nil {
+				return err
+			}
+			dur, err := time.ParseDuration(deadline)
+			if err != nil {
+				return err
+			}
+			deadline := time.Now().Add(dur).Unix()
+			var recip sdk.AccAddress
+			if recipient == "" {
+				recip = cliCtx.FromAddress
+			} else {
+				recip, err = sdk.AccAddressFromBech32(recipient)
+				if err != nil {
+					return err
+				}
+			}
+
+			msg := types.NewMsgSwapRoute(soldTokenAmount, boughtTokenAmount, hops, exactOutput,
+				deadline, recip, cliCtx.FromAddress)
+
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().StringVarP(&soldTokenAmount, flagSellAmount, "", "",
+		"Amount expected to sell, or maximum amount expected to sell with --exact-output")
+	cmd.Flags().StringVarP(&boughtTokenAmount, flagBuyAmount, "", "",
+		"Minimum amount expected to buy, or exact amount expected to buy with --exact-output")
+	cmd.Flags().StringSliceVarP(&hops, flagHops, "", nil,
+		"The intermediate tokens of the route, in order. For example \"okt,usdk-017\"")
+	cmd.Flags().BoolVarP(&exactOutput, flagExactOutput, "", false,
+		"Whether buy amount is the exact amount to buy")
+	cmd.Flags().StringVarP(&recipient, flagRecipient, "", "",
+		"The address to receive the amount bought")
+	cmd.Flags().StringVarP(&deadline, flagDeadlineDuration, "", "100s",
+		"Duration after which this transaction can no longer be executed. such as \"300ms\", \"1.5h\" or \"2h45m\". Valid time units are \"ns\", \"us\" (or \"µs\"), \"ms\", \"s\", \"m\", \"h\".")
+	cmd.MarkFlagRequired(flagSellAmount)
+	cmd.MarkFlagRequired(flagBuyAmount)
+
+	return cmd
+}
+
+// GetCmdManageStableSwapPoolProposal implements a command handler for submitting a manage stable swap pool proposal transaction
+func GetCmdManageStableSwapPoolProposal(cdcP *codec.CodecProxy, reg interfacetypes.InterfaceRegistry) *cobra.Command {
+	return &cobra.Command{
+		Use:   "manage-stable-swap-pool [proposal-file]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Submit a manage stable swap pool proposal",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Submit a manage stable swap pool proposal along with an initial deposit.
+The proposal creates a stable swap pool of the two tokens with the amplification coefficient, or ramps the
+amplification coefficient of an existing stable swap pool to the new one over the ramp duration (in nanoseconds).
+The proposal details must be supplied via a JSON file.
+
+Example:
+$ %s tx gov submit-proposal manage-stable-swap-pool <path/to/proposal.json> --from=<key_or_address>
+
+Where proposal.json contains:
+
+{
+ "title": "manage stable swap pool",
+ "description": "create a stable swap pool of usdt and usdc",
+ "token0_name": "usdt-355",
+ "token1_name": "usdc-366",
+ "amplification": "100",
+ "ramp_duration": "0",
+ "deposit": [
+   {
+     "denom": "%s",
+     "amount": "100"
+   }
+ ]
+}
+`, version.ClientName, sdk.DefaultBondDenom,
+			)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cdc := cdcP.GetCdc()
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			proposal, err := swaputils.ParseManageStableSwapPoolProposalJSON(cdc, args[0])
+			if err != nil {
+				return err
+			}
+
+			from := cliCtx.GetFromAddress()
+			content := types.NewManageStableSwapPoolProposal(proposal.Title, proposal.Description, proposal.Token0Name,
+				proposal.Token1Name, proposal.Amplification, proposal.RampDuration)
+			msg := gov.NewMsgSubmitProposal(content, proposal.Deposit, from)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
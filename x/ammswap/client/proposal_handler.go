@@ -0,0 +1,12 @@
+package client
+
+import (
+	"github.com/okex/exchain/x/ammswap/client/cli"
+	"github.com/okex/exchain/x/ammswap/client/rest"
+	govcli "github.com/okex/exchain/x/gov/client"
+)
+
+var (
+	// ManageStableSwapPoolProposalHandler alias gov NewProposalHandler
+	ManageStableSwapPoolProposalHandler = govcli.NewProposalHandler(cli.GetCmdManageStableSwapPoolProposal, rest.ManageStableSwapPoolProposalRESTHandler)
+)
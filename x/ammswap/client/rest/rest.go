@@ -2,6 +2,7 @@ package rest
 
 import (
 	"github.com/gorilla/mux"
+	govRest "github.com/okex/exchain/x/gov/client/rest"
 
 	"github.com/okex/exchain/libs/cosmos-sdk/client/context"
 )
@@ -10,3 +11,8 @@ import (
 func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router) {
 	registerQueryRoutes(cliCtx, r)
 }
+
+// ManageStableSwapPoolProposalRESTHandler defines ammswap proposal handler
+func ManageStableSwapPoolProposalRESTHandler(context.CLIContext) govRest.ProposalRESTHandler {
+	return govRest.ProposalRESTHandler{}
+}
@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"io/ioutil"
+	"time"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
+// ManageStableSwapPoolProposalJSON defines a ManageStableSwapPoolProposalJSON with a deposit used to parse manage
+// stable swap pool proposals from a JSON file.
+type ManageStableSwapPoolProposalJSON struct {
+	Title         string        `json:"title" yaml:"title"`
+	Description   string        `json:"description" yaml:"description"`
+	Token0Name    string        `json:"token0_name" yaml:"token0_name"`
+	Token1Name    string        `json:"token1_name" yaml:"token1_name"`
+	Amplification uint64        `json:"amplification" yaml:"amplification"`
+	RampDuration  time.Duration `json:"ramp_duration" yaml:"ramp_duration"`
+	Deposit       sdk.SysCoins  `json:"deposit" yaml:"deposit"`
+}
+
+// ParseManageStableSwapPoolProposalJSON parse json from proposal file to ManageStableSwapPoolProposalJSON struct
+func ParseManageStableSwapPoolProposalJSON(cdc *codec.Codec, proposalFilePath string) (
+	proposal ManageStableSwapPoolProposalJSON, err error) {
+	contents, err := ioutil.ReadFile(proposalFilePath)
+	if err != nil {
+		return
+	}
+
+	err = cdc.UnmarshalJSON(contents, &proposal)
+	return
+}
@@ -10,8 +10,9 @@ import (
 
 // GenesisState stores genesis data, all slashing state that must be provided at genesis
 type GenesisState struct {
-	Params               Params          `json:"params"`
-	SwapTokenPairRecords []SwapTokenPair `json:"swap_token_pair_records"`
+	Params               Params           `json:"params"`
+	SwapTokenPairRecords []SwapTokenPair  `json:"swap_token_pair_records"`
+	StableSwapPools      []StableSwapPool `json:"stable_swap_pools,omitempty"`
 }
 
 // nolint
@@ -32,6 +33,14 @@ func ValidateGenesis(data GenesisState) error {
 			return fmt.Errorf("invalid SwapTokenPairRecord: PoolToken: %s. Error: invalid PoolToken", record.PoolTokenName)
 		}
 	}
+	for _, pool := range data.StableSwapPools {
+		if err := types.ValidateAmplification(pool.InitialAmplification); err != nil {
+			return fmt.Errorf("invalid StableSwapPool: %s. Error: %s", pool.TokenPairName, err)
+		}
+		if err := types.ValidateAmplification(pool.FutureAmplification); err != nil {
+			return fmt.Errorf("invalid StableSwapPool: %s. Error: %s", pool.TokenPairName, err)
+		}
+	}
 	return nil
 }
 
@@ -49,6 +58,9 @@ func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
 	for _, record := range data.SwapTokenPairRecords {
 		keeper.SetSwapTokenPair(ctx, record.TokenPairName(), record)
 	}
+	for _, pool := range data.StableSwapPools {
+		keeper.SetStableSwapPool(ctx, pool)
+	}
 }
 
 // ExportGenesis exports genesis from keeper
@@ -62,5 +74,5 @@ func ExportGenesis(ctx sdk.Context, k Keeper) GenesisState {
 
 	}
 	params := k.GetParams(ctx)
-	return GenesisState{SwapTokenPairRecords: records, Params: params}
+	return GenesisState{SwapTokenPairRecords: records, Params: params, StableSwapPools: k.GetStableSwapPools(ctx)}
 }
@@ -1,12 +1,14 @@
 package ammswap
 
 import (
-	"github.com/okex/exchain/x/ammswap/keeper"
+	"strings"
+
 	"github.com/okex/exchain/x/ammswap/types"
 	"github.com/okex/exchain/x/common"
 	"github.com/okex/exchain/x/common/perf"
 
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 )
 
 // NewHandler creates an sdk.Handler for all the ammswap type messages
@@ -36,6 +38,11 @@ func NewHandler(k Keeper) sdk.Handler {
 			handlerFun = func() (*sdk.Result, error) {
 				return handleMsgTokenToToken(ctx, k, msg)
 			}
+		case types.MsgSwapRoute:
+			name = "handleMsgSwapRoute"
+			handlerFun = func() (*sdk.Result, error) {
+				return handleMsgSwapRoute(ctx, k, msg)
+			}
 		default:
 			return nil, types.ErrSwapUnknownMsgType()
 		}
@@ -57,6 +64,70 @@ func handleMsgTokenToToken(ctx sdk.Context, k Keeper, msg types.MsgTokenToToken)
 	}
 }
 
+func handleMsgSwapRoute(ctx sdk.Context, k Keeper, msg types.MsgSwapRoute) (*sdk.Result, error) {
+	if !tmtypes.HigherThanJupiter(ctx.BlockHeight()) {
+		return types.ErrSwapRouteDisabled(ctx.BlockHeight()).Result()
+	}
+	event := sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName))
+
+	if msg.Deadline < ctx.BlockTime().Unix() {
+		return types.ErrBlockTimeBigThanDeadline().Result()
+	}
+	path := msg.GetPath()
+	swapTokenPairs, err := k.GetSwapRoutePairs(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	params := k.GetParams(ctx)
+	var amounts []sdk.SysCoin
+	if msg.ExactOutput {
+		amounts, err = k.CalculateRouteAmountsIn(ctx, swapTokenPairs, path, msg.BoughtTokenAmount, params)
+		if err != nil {
+			return nil, err
+		}
+		if amounts[0].Amount.GT(msg.SoldTokenAmount.Amount) {
+			return types.ErrLessThan("max sold token amount", "token sell amount").Result()
+		}
+	} else {
+		amounts, err = k.CalculateRouteAmountsOut(ctx, swapTokenPairs, path, msg.SoldTokenAmount, params)
+		if err != nil {
+			return nil, err
+		}
+		if amounts[len(amounts)-1].Amount.LT(msg.BoughtTokenAmount.Amount) {
+			return types.ErrLessThan("token buy amount", "min bought token amount").Result()
+		}
+	}
+	tokenSell, tokenBuy := amounts[0], amounts[len(amounts)-1]
+
+	if err := common.HasSufficientCoins(msg.Sender, k.GetTokenKeeper().GetCoins(ctx, msg.Sender),
+		sdk.SysCoins{tokenSell}); err != nil {
+		return common.ErrInsufficientCoins(DefaultParamspace, err.Error()).Result()
+	}
+
+	// transfer coins, the intermediate tokens stay in the pool
+	if err := k.SendCoinsToPool(ctx, sdk.SysCoins{tokenSell}, msg.Sender); err != nil {
+		return types.ErrSendCoinsToPoolFailed(err.Error()).Result()
+	}
+	if err := k.SendCoinsFromPoolToAccount(ctx, sdk.SysCoins{tokenBuy}, msg.Recipient); err != nil {
+		return types.ErrSendCoinsFromPoolToAccountFailed(err.Error()).Result()
+	}
+
+	// update every swapTokenPair on the route
+	for i, swapTokenPair := range swapTokenPairs {
+		updateSwapTokenPairReserves(&swapTokenPair, amounts[i], amounts[i+1])
+		k.SetSwapTokenPair(ctx, swapTokenPair.TokenPairName(), swapTokenPair)
+		k.OnSwapToken(ctx, msg.Recipient, swapTokenPair, amounts[i], amounts[i+1])
+	}
+
+	event.AppendAttributes(sdk.NewAttribute("sold_token_amount", tokenSell.String()))
+	event.AppendAttributes(sdk.NewAttribute("bought_token_amount", tokenBuy.String()))
+	event.AppendAttributes(sdk.NewAttribute("route", strings.Join(path, ",")))
+	event.AppendAttributes(sdk.NewAttribute("recipient", msg.Recipient.String()))
+	ctx.EventManager().EmitEvent(event)
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
 func handleMsgCreateExchange(ctx sdk.Context, k Keeper, msg types.MsgCreateExchange) (*sdk.Result, error) {
 	event := sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName))
 
@@ -248,7 +319,7 @@ func swapToken(ctx sdk.Context, k Keeper, msg types.MsgTokenToToken) (*sdk.Resul
 		return types.ErrIsZeroValue("base pooled coin or quote pooled coin").Result()
 	}
 	params := k.GetParams(ctx)
-	tokenBuy := keeper.CalculateTokenToBuy(swapTokenPair, msg.SoldTokenAmount, msg.MinBoughtTokenAmount.Denom, params)
+	tokenBuy := k.GetTokenToBuy(ctx, swapTokenPair, msg.SoldTokenAmount, msg.MinBoughtTokenAmount.Denom, params)
 	if tokenBuy.IsZero() {
 		return types.ErrIsZeroValue("token buy").Result()
 	}
@@ -297,13 +368,13 @@ func swapTokenByRouter(ctx sdk.Context, k Keeper, msg types.MsgTokenToToken) (*s
 	params := k.GetParams(ctx)
 	msgOne := msg
 	msgOne.MinBoughtTokenAmount = nativeAmount
-	tokenNative := keeper.CalculateTokenToBuy(swapTokenPairOne, msgOne.SoldTokenAmount, msgOne.MinBoughtTokenAmount.Denom, params)
+	tokenNative := k.GetTokenToBuy(ctx, swapTokenPairOne, msgOne.SoldTokenAmount, msgOne.MinBoughtTokenAmount.Denom, params)
 	if tokenNative.IsZero() {
 		return types.ErrIsZeroValue("token native").Result()
 	}
 	msgTwo := msg
 	msgTwo.SoldTokenAmount = tokenNative
-	tokenBuy := keeper.CalculateTokenToBuy(swapTokenPairTwo, msgTwo.SoldTokenAmount, msgTwo.MinBoughtTokenAmount.Denom, params)
+	tokenBuy := k.GetTokenToBuy(ctx, swapTokenPairTwo, msgTwo.SoldTokenAmount, msgTwo.MinBoughtTokenAmount.Denom, params)
 	// sanity check. user may set MinBoughtTokenAmount to zero on front end.
 	// if set zero,this will not return err
 	if tokenBuy.IsZero() {
@@ -344,16 +415,20 @@ func swapTokenNativeToken(
 	}
 
 	// update swapTokenPair
-	if msg.MinBoughtTokenAmount.Denom < msg.SoldTokenAmount.Denom {
-		swapTokenPair.QuotePooledCoin = swapTokenPair.QuotePooledCoin.Add(msg.SoldTokenAmount)
+	updateSwapTokenPairReserves(&swapTokenPair, msg.SoldTokenAmount, tokenBuy)
+	k.SetSwapTokenPair(ctx, msg.GetSwapTokenPairName(), swapTokenPair)
+	k.OnSwapToken(ctx, msg.Recipient, swapTokenPair, msg.SoldTokenAmount, tokenBuy)
+	return &sdk.Result{}, nil
+}
+
+func updateSwapTokenPairReserves(swapTokenPair *SwapTokenPair, tokenSell, tokenBuy sdk.SysCoin) {
+	if tokenBuy.Denom < tokenSell.Denom {
+		swapTokenPair.QuotePooledCoin = swapTokenPair.QuotePooledCoin.Add(tokenSell)
 		swapTokenPair.BasePooledCoin = swapTokenPair.BasePooledCoin.Sub(tokenBuy)
 	} else {
 		swapTokenPair.QuotePooledCoin = swapTokenPair.QuotePooledCoin.Sub(tokenBuy)
-		swapTokenPair.BasePooledCoin = swapTokenPair.BasePooledCoin.Add(msg.SoldTokenAmount)
+		swapTokenPair.BasePooledCoin = swapTokenPair.BasePooledCoin.Add(tokenSell)
 	}
-	k.SetSwapTokenPair(ctx, msg.GetSwapTokenPairName(), swapTokenPair)
-	k.OnSwapToken(ctx, msg.Recipient, swapTokenPair, msg.SoldTokenAmount, tokenBuy)
-	return &sdk.Result{}, nil
 }
 
 func coinSort(coins sdk.SysCoins) sdk.SysCoins {
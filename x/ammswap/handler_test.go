@@ -11,6 +11,7 @@ import (
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	"github.com/okex/exchain/libs/cosmos-sdk/x/supply"
 	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 	"github.com/okex/exchain/x/ammswap/keeper"
 	"github.com/okex/exchain/x/ammswap/types"
 	"github.com/okex/exchain/x/token"
@@ -456,6 +457,87 @@ func TestHandleMsgTokenToTokenDirectly(t *testing.T) {
 	}
 }
 
+func TestHandleMsgSwapRoute(t *testing.T) {
+	mapp, addrKeysSlice := getMockAppWithBalance(t, 1, 100000)
+	keeper := mapp.swapKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10).WithBlockTime(time.Now())
+	testToken := token.InitTestToken(types.TestBasePooledToken)
+	secondTestToken := token.InitTestToken(types.TestBasePooledToken2)
+	testQuoteToken := token.InitTestToken(types.TestQuotePooledToken)
+	mapp.swapKeeper.SetParams(ctx, types.DefaultParams())
+
+	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
+	handler := NewHandler(keeper)
+	mapp.tokenKeeper.NewToken(ctx, testToken)
+	mapp.tokenKeeper.NewToken(ctx, secondTestToken)
+	mapp.tokenKeeper.NewToken(ctx, testQuoteToken)
+	addr := addrKeysSlice[0].Address
+
+	_, err := handler(ctx, types.NewMsgCreateExchange(testToken.Symbol, types.TestQuotePooledToken, addr))
+	require.Nil(t, err)
+	_, err = handler(ctx, types.NewMsgCreateExchange(secondTestToken.Symbol, types.TestQuotePooledToken, addr))
+	require.Nil(t, err)
+
+	minLiquidity := sdk.NewDec(1)
+	quoteAmount := sdk.NewDecCoinFromDec(types.TestQuotePooledToken, sdk.NewDec(10000))
+	deadLine := time.Now().Unix()
+	_, err = handler(ctx, types.NewMsgAddLiquidity(minLiquidity,
+		sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(10000)), quoteAmount, deadLine, addr))
+	require.Nil(t, err)
+	_, err = handler(ctx, types.NewMsgAddLiquidity(minLiquidity,
+		sdk.NewDecCoinFromDec(types.TestBasePooledToken2, sdk.NewDec(10000)), quoteAmount, deadLine, addr))
+	require.Nil(t, err)
+
+	hops := []string{types.TestQuotePooledToken}
+	soldTokenAmount := sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(2))
+	boughtTokenAmount := sdk.NewDecCoinFromDec(types.TestBasePooledToken2, sdk.NewDec(1))
+	invalidBoughtTokenAmount := sdk.NewDecCoinFromDec(types.TestBasePooledToken2, sdk.NewDec(100000))
+	insufficientSoldTokenAmount := sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(10000000))
+	tests := []struct {
+		testCase          string
+		soldTokenAmount   sdk.SysCoin
+		boughtTokenAmount sdk.SysCoin
+		hops              []string
+		exactOutput       bool
+		deadLine          int64
+		exceptResultCode  uint32
+	}{
+		{"(exactInput) success", soldTokenAmount, boughtTokenAmount, hops, false, deadLine, sdk.CodeOK},
+		{"(exactOutput) success", soldTokenAmount, boughtTokenAmount, hops, true, deadLine, sdk.CodeOK},
+		{"blockTime exceeded deadline", soldTokenAmount, boughtTokenAmount, hops, false, 0, sdk.CodeInternal},
+		{"unknown swapTokenPair", soldTokenAmount, boughtTokenAmount, nil, false, deadLine, sdk.CodeInternal},
+		{"(exactInput) insufficient SoldTokenAmount", insufficientSoldTokenAmount, boughtTokenAmount, hops, false, deadLine, sdk.CodeInsufficientCoins},
+		{"(exactInput) bought amount less than BoughtTokenAmount", soldTokenAmount, invalidBoughtTokenAmount, hops, false, deadLine, sdk.CodeInternal},
+		{"(exactOutput) sold amount bigger than SoldTokenAmount", sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(1)), boughtTokenAmount, hops, true, deadLine, sdk.CodeInternal},
+		{"(exactOutput) insufficient pool reserve", soldTokenAmount, invalidBoughtTokenAmount, hops, true, deadLine, sdk.CodeInternal},
+	}
+
+	// the swap route is rejected before the jupiter height
+	_, err = handler(ctx, types.NewMsgSwapRoute(soldTokenAmount, boughtTokenAmount, hops, false, deadLine, addr, addr))
+	testCode(t, err, types.CodeSwapRouteDisabled)
+	tmtypes.UnittestOnlySetMilestoneJupiterHeight(1)
+	defer tmtypes.UnittestOnlySetMilestoneJupiterHeight(0)
+
+	for _, testCase := range tests {
+		fmt.Println(testCase.testCase)
+		msg := types.NewMsgSwapRoute(testCase.soldTokenAmount, testCase.boughtTokenAmount, testCase.hops,
+			testCase.exactOutput, testCase.deadLine, addr, addr)
+		_, err := handler(ctx, msg)
+		testCode(t, err, testCase.exceptResultCode)
+	}
+
+	// the native token only passes through the pools
+	acc := mapp.AccountKeeper.GetAccount(ctx, addr)
+	require.Equal(t, sdk.NewDec(80000), acc.GetCoins().AmountOf(types.TestQuotePooledToken))
+	require.True(t, acc.GetCoins().AmountOf(types.TestBasePooledToken2).GT(sdk.NewDec(90002)))
+	swapTokenPair, err := keeper.GetSwapTokenPair(ctx, types.GetSwapTokenPairName(types.TestBasePooledToken, types.TestQuotePooledToken))
+	require.Nil(t, err)
+	swapTokenPair2, err := keeper.GetSwapTokenPair(ctx, types.GetSwapTokenPairName(types.TestBasePooledToken2, types.TestQuotePooledToken))
+	require.Nil(t, err)
+	require.Equal(t, swapTokenPair.QuotePooledCoin.Amount.Add(swapTokenPair2.QuotePooledCoin.Amount), sdk.NewDec(20000))
+}
+
 func TestGetInputPrice(t *testing.T) {
 	tests := []struct {
 		testCase           string
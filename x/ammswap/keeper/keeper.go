@@ -17,6 +17,7 @@ import (
 type Keeper struct {
 	supplyKeeper types.SupplyKeeper
 	tokenKeeper  types.TokenKeeper
+	govKeeper    types.GovKeeper
 
 	storeKey       sdk.StoreKey
 	cdc            *codec.Codec
@@ -143,7 +144,9 @@ func (k Keeper) GetTokenKeeper() types.TokenKeeper {
 
 // GetParams gets inflation params from the global param store
 func (k Keeper) GetParams(ctx sdk.Context) (params types.Params) {
-	k.paramSpace.GetParamSet(ctx, &params)
+	k.paramSpace.Get(ctx, types.KeyFeeRate, &params.FeeRate)
+	// the twap oracle stays disabled on chains started before its param existed, until the param is set
+	k.paramSpace.GetIfExists(ctx, types.KeyTwapRetentionPeriod, &params.TwapRetentionPeriod)
 	return params
 }
 
@@ -196,6 +199,11 @@ func GetInputPrice(inputAmount, inputReserve, outputReserve, feeRate sdk.Dec) sd
 	return common.MulAndQuo(inputAmountWithFee, outputReserve, denominator)
 }
 
+// SetGovKeeper sets keeper of gov
+func (k *Keeper) SetGovKeeper(gk types.GovKeeper) {
+	k.govKeeper = gk
+}
+
 func (k *Keeper) SetObserverKeeper(bk types.BackendKeeper) {
 	k.ObserverKeeper = append(k.ObserverKeeper, bk)
 }
@@ -4,10 +4,12 @@ package keeper
 
 import (
 	"testing"
+	"time"
 
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	"github.com/okex/exchain/libs/cosmos-sdk/x/supply"
 	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 	"github.com/okex/exchain/x/ammswap/types"
 	"github.com/stretchr/testify/require"
 )
@@ -91,3 +93,194 @@ func TestGetInputPrice(t *testing.T) {
 	expectedAmount := sdk.NewDec(0)
 	require.Equal(t, expectedAmount.String(), outputAmount.String())
 }
+
+func TestGetOutputPrice(t *testing.T) {
+	inputReserve := sdk.NewDec(100)
+	outputReserve := sdk.NewDec(100)
+	feeRate := sdk.NewDecWithPrec(3, 3)
+	outputAmount := sdk.NewDec(50)
+	inputAmount := GetOutputPrice(outputAmount, inputReserve, outputReserve, feeRate)
+	require.Equal(t, "100.300902708124373121", inputAmount.String())
+	// selling the calculated amount buys at least the expected amount
+	require.True(t, GetInputPrice(inputAmount, inputReserve, outputReserve, feeRate).GTE(outputAmount))
+}
+
+func TestCalculateRouteAmounts(t *testing.T) {
+	mapp, _ := GetTestInput(t, 1)
+	keeper := mapp.swapKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10)
+	params := types.DefaultParams()
+	path := []string{types.TestBasePooledToken, types.TestQuotePooledToken, types.TestBasePooledToken2}
+	pairs := []types.SwapTokenPair{
+		{
+			QuotePooledCoin: sdk.NewDecCoinFromDec(types.TestQuotePooledToken, sdk.NewDec(100)),
+			BasePooledCoin:  sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(100)),
+		},
+		{
+			QuotePooledCoin: sdk.NewDecCoinFromDec(types.TestQuotePooledToken, sdk.NewDec(100)),
+			BasePooledCoin:  sdk.NewDecCoinFromDec(types.TestBasePooledToken2, sdk.NewDec(100)),
+		},
+	}
+
+	sellToken := sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(10))
+	amountsOut, err := keeper.CalculateRouteAmountsOut(ctx, pairs, path, sellToken, params)
+	require.Nil(t, err)
+	require.Equal(t, 3, len(amountsOut))
+	require.Equal(t, sellToken, amountsOut[0])
+	require.Equal(t, CalculateTokenToBuy(pairs[0], sellToken, path[1], params), amountsOut[1])
+	require.Equal(t, CalculateTokenToBuy(pairs[1], amountsOut[1], path[2], params), amountsOut[2])
+
+	// buying the same amount exactly costs no less than the amount sold
+	amountsIn, err := keeper.CalculateRouteAmountsIn(ctx, pairs, path, amountsOut[2], params)
+	require.Nil(t, err)
+	require.Equal(t, amountsOut[2], amountsIn[2])
+	require.True(t, amountsIn[0].Amount.GTE(sellToken.Amount))
+	require.True(t, amountsIn[0].Amount.Sub(sellToken.Amount).LT(sdk.NewDecWithPrec(1, 12)))
+
+	// the bought amount can not exhaust the pool
+	_, err = keeper.CalculateRouteAmountsIn(ctx, pairs, path, sdk.NewDecCoinFromDec(types.TestBasePooledToken2, sdk.NewDec(100)), params)
+	require.NotNil(t, err)
+}
+
+func TestKeeper_TWAP(t *testing.T) {
+	mapp, _ := GetTestInput(t, 1)
+	keeper := mapp.swapKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10)
+	start := ctx.BlockTime()
+	at := func(seconds int64) sdk.Context {
+		return ctx.WithBlockTime(start.Add(time.Duration(seconds) * time.Second))
+	}
+
+	params := types.DefaultParams()
+	params.TwapRetentionPeriod = time.Minute
+	keeper.SetParams(ctx, params)
+
+	swapTokenPair := types.GetTestSwapTokenPair()
+	swapTokenPair.BasePooledCoin.Amount = sdk.NewDec(100)
+	swapTokenPair.QuotePooledCoin.Amount = sdk.NewDec(200)
+	keeper.SetSwapTokenPair(ctx, types.TestSwapTokenPairName, swapTokenPair)
+	keeper.UpdateTWAPCheckpoints(at(0))
+
+	// the price of the base token is 2 for 10 seconds, then 4 for 30 seconds
+	swapTokenPair.QuotePooledCoin.Amount = sdk.NewDec(400)
+	keeper.SetSwapTokenPair(ctx, types.TestSwapTokenPairName, swapTokenPair)
+	keeper.UpdateTWAPCheckpoints(at(10))
+	// unchanged reserves do not add any checkpoint
+	keeper.UpdateTWAPCheckpoints(at(20))
+	require.Equal(t, 2, len(keeper.GetTWAPCheckpoints(ctx, types.TestSwapTokenPairName)))
+
+	twap, err := keeper.GetTWAP(at(40), types.TestSwapTokenPairName, 40)
+	require.Nil(t, err)
+	require.Equal(t, sdk.MustNewDecFromStr("3.5"), twap.BasePrice)
+	twap, err = keeper.GetTWAP(at(40), types.TestSwapTokenPairName, 20)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewDec(4), twap.BasePrice)
+	require.Equal(t, sdk.MustNewDecFromStr("0.25"), twap.QuotePrice)
+
+	price, err := keeper.GetTWAPPrice(at(40), types.TestQuotePooledToken, types.TestBasePooledToken, 20)
+	require.Nil(t, err)
+	require.Equal(t, sdk.MustNewDecFromStr("0.25"), price)
+
+	// the window can not start before the first checkpoint
+	_, err = keeper.GetTWAP(at(40), types.TestSwapTokenPairName, 41)
+	require.NotNil(t, err)
+	_, err = keeper.GetTWAP(at(40), types.TestSwapTokenPairName, 0)
+	require.NotNil(t, err)
+
+	// the checkpoints out of the retention period are pruned, except the latest of them
+	swapTokenPair.QuotePooledCoin.Amount = sdk.NewDec(300)
+	keeper.SetSwapTokenPair(ctx, types.TestSwapTokenPairName, swapTokenPair)
+	keeper.UpdateTWAPCheckpoints(at(100))
+	checkpoints := keeper.GetTWAPCheckpoints(ctx, types.TestSwapTokenPairName)
+	require.Equal(t, 2, len(checkpoints))
+	require.Equal(t, start.Unix()+10, checkpoints[0].Timestamp)
+
+	// the oracle is disabled with a zero retention period
+	params.TwapRetentionPeriod = 0
+	keeper.SetParams(ctx, params)
+	_, err = keeper.GetTWAP(at(100), types.TestSwapTokenPairName, 10)
+	require.NotNil(t, err)
+}
+
+func TestGetStablePrice(t *testing.T) {
+	feeRate := sdk.MustNewDecFromStr("0.003")
+	reserve := sdk.NewDec(1000)
+	inputAmount := sdk.NewDec(10)
+
+	// a balanced stable swap pool gives out much more than a constant product pool
+	outputAmount := GetStableInputPrice(inputAmount, reserve, reserve, 100, feeRate)
+	require.Equal(t, sdk.MustNewDecFromStr("9.969505444021224165"), outputAmount)
+	require.True(t, outputAmount.GT(GetInputPrice(inputAmount, reserve, reserve, feeRate)))
+
+	// buying the same amount exactly costs no less than the amount sold
+	soldAmount := GetStableOutputPrice(outputAmount, reserve, reserve, 100, feeRate)
+	require.True(t, soldAmount.GTE(inputAmount))
+	require.True(t, soldAmount.Sub(inputAmount).LT(sdk.NewDecWithPrec(1, 15)))
+
+	// the pool can not be exhausted
+	require.True(t, GetStableInputPrice(sdk.NewDec(100000), reserve, reserve, 100, feeRate).LT(reserve))
+	// the price moves against the scarce token
+	require.True(t, GetStableInputPrice(sdk.NewDec(100), sdk.NewDec(1800), sdk.NewDec(200), 100, feeRate).LT(sdk.NewDec(100)))
+	require.True(t, GetStableInputPrice(sdk.NewDec(100), sdk.NewDec(200), sdk.NewDec(1800), 100, feeRate).GT(sdk.NewDec(100)))
+}
+
+func TestKeeper_StableSwapPool(t *testing.T) {
+	mapp, _ := GetTestInput(t, 1)
+	keeper := mapp.swapKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10)
+	params := types.DefaultParams()
+	keeper.SetParams(ctx, params)
+
+	swapTokenPair := types.GetTestSwapTokenPair()
+	swapTokenPair.BasePooledCoin.Amount = sdk.NewDec(1000)
+	swapTokenPair.QuotePooledCoin.Amount = sdk.NewDec(1000)
+	keeper.SetSwapTokenPair(ctx, types.TestSwapTokenPairName, swapTokenPair)
+	sellToken := sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(10))
+
+	// the stable swap pools are not read before the jupiter height
+	keeper.SetStableSwapPool(ctx, types.NewStableSwapPool(types.TestSwapTokenPairName, 100, ctx.BlockTime().Unix()))
+	_, found := keeper.GetAmplification(ctx, types.TestSwapTokenPairName)
+	require.False(t, found)
+	require.NotNil(t, keeper.CheckMsgManageStableSwapPoolProposal(ctx, types.NewManageStableSwapPoolProposal("title",
+		"description", types.TestBasePooledToken, types.TestQuotePooledToken, 1000, types.MinAmplificationRampDuration)))
+	ctx.KVStore(keeper.storeKey).Delete(types.GetStableSwapPoolKey(types.TestSwapTokenPairName))
+
+	tmtypes.UnittestOnlySetMilestoneJupiterHeight(1)
+	defer tmtypes.UnittestOnlySetMilestoneJupiterHeight(0)
+
+	// a swap token pair without a stable swap pool is a constant product pool
+	_, found = keeper.GetAmplification(ctx, types.TestSwapTokenPairName)
+	require.False(t, found)
+	require.Equal(t, CalculateTokenToBuy(swapTokenPair, sellToken, types.TestQuotePooledToken, params),
+		keeper.GetTokenToBuy(ctx, swapTokenPair, sellToken, types.TestQuotePooledToken, params))
+
+	// a constant product pool can not become a stable swap pool
+	proposal := types.NewManageStableSwapPoolProposal("title", "description", types.TestBasePooledToken,
+		types.TestQuotePooledToken, 100, 0)
+	require.NotNil(t, keeper.CheckMsgManageStableSwapPoolProposal(ctx, proposal))
+
+	keeper.SetStableSwapPool(ctx, types.NewStableSwapPool(types.TestSwapTokenPairName, 100, ctx.BlockTime().Unix()))
+	require.Equal(t, CalculateStableTokenToBuy(swapTokenPair, sellToken, types.TestQuotePooledToken, 100, params),
+		keeper.GetTokenToBuy(ctx, swapTokenPair, sellToken, types.TestQuotePooledToken, params))
+
+	// ramp the amplification
+	proposal.Amplification, proposal.RampDuration = 1000, types.MinAmplificationRampDuration
+	require.Nil(t, keeper.CheckMsgManageStableSwapPoolProposal(ctx, proposal))
+	keeper.ManageStableSwapPool(ctx, proposal)
+	amplification, found := keeper.GetAmplification(ctx, types.TestSwapTokenPairName)
+	require.True(t, found)
+	require.Equal(t, uint64(100), amplification)
+	amplification, _ = keeper.GetAmplification(ctx.WithBlockTime(ctx.BlockTime().Add(12*time.Hour)), types.TestSwapTokenPairName)
+	require.Equal(t, uint64(550), amplification)
+	amplification, _ = keeper.GetAmplification(ctx.WithBlockTime(ctx.BlockTime().Add(48*time.Hour)), types.TestSwapTokenPairName)
+	require.Equal(t, uint64(1000), amplification)
+
+	// the amplification can not change too much or too fast
+	proposal.Amplification = 100000
+	require.NotNil(t, keeper.CheckMsgManageStableSwapPoolProposal(ctx, proposal))
+	proposal.Amplification, proposal.RampDuration = 500, time.Hour
+	require.NotNil(t, keeper.CheckMsgManageStableSwapPoolProposal(ctx, proposal))
+}
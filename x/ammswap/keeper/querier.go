@@ -28,6 +28,12 @@ func NewQuerier(k Keeper) sdk.Querier {
 			res, err = querySwapQuoteInfo(ctx, req, k)
 		case types.QuerySwapAddLiquidityQuote:
 			res, err = querySwapAddLiquidityQuote(ctx, req, k)
+		case types.QuerySwapRoute:
+			res, err = querySwapRoute(ctx, req, k)
+		case types.QueryTWAP:
+			res, err = queryTWAP(ctx, req, k)
+		case types.QueryStableSwapPool:
+			res, err = queryStableSwapPool(ctx, path[1:], k)
 
 		default:
 			return nil, types.ErrSwapUnknownQueryType()
@@ -92,7 +98,7 @@ func queryBuyAmount(
 		if tokenPair.BasePooledCoin.IsZero() || tokenPair.QuotePooledCoin.IsZero() {
 			return nil, types.ErrIsZeroValue("base pooled coin or quote pooled coin")
 		}
-		buyAmount = CalculateTokenToBuy(tokenPair, queryParams.SoldToken, queryParams.TokenToBuy, params).Amount
+		buyAmount = keeper.GetTokenToBuy(ctx, tokenPair, queryParams.SoldToken, queryParams.TokenToBuy, params).Amount
 	} else {
 		tokenPairName1 := types.GetSwapTokenPairName(queryParams.SoldToken.Denom, sdk.DefaultBondDenom)
 		tokenPair1, err := keeper.GetSwapTokenPair(ctx, tokenPairName1)
@@ -110,8 +116,8 @@ func queryBuyAmount(
 		if tokenPair2.BasePooledCoin.IsZero() || tokenPair2.QuotePooledCoin.IsZero() {
 			return nil, types.ErrIsZeroValue("base pooled coin or quote pooled coin")
 		}
-		nativeToken := CalculateTokenToBuy(tokenPair1, queryParams.SoldToken, sdk.DefaultBondDenom, params)
-		buyAmount = CalculateTokenToBuy(tokenPair2, nativeToken, queryParams.TokenToBuy, params).Amount
+		nativeToken := keeper.GetTokenToBuy(ctx, tokenPair1, queryParams.SoldToken, sdk.DefaultBondDenom, params)
+		buyAmount = keeper.GetTokenToBuy(ctx, tokenPair2, nativeToken, queryParams.TokenToBuy, params).Amount
 	}
 
 	bz := keeper.cdc.MustMarshalJSON(buyAmount)
@@ -184,7 +190,7 @@ func querySwapQuoteInfo(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (
 		if tokenPair.BasePooledCoin.Amount.IsZero() || tokenPair.QuotePooledCoin.IsZero() {
 			return nil, types.ErrIsZeroValue("base pooled coin or quote pooled coin")
 		}
-		buyAmount = CalculateTokenToBuy(tokenPair, sellAmount, queryParams.BuyToken, swapParams).Amount
+		buyAmount = keeper.GetTokenToBuy(ctx, tokenPair, sellAmount, queryParams.BuyToken, swapParams).Amount
 		// calculate market price
 		if tokenPair.BasePooledCoin.Denom == sellAmount.Denom {
 			marketPrice = tokenPair.QuotePooledCoin.Amount.Quo(tokenPair.BasePooledCoin.Amount)
@@ -210,8 +216,8 @@ func querySwapQuoteInfo(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (
 		if tokenPair2.BasePooledCoin.Amount.IsZero() || tokenPair2.QuotePooledCoin.IsZero() {
 			return nil, types.ErrIsZeroValue("base pooled coin or quote pooled coin")
 		}
-		nativeToken := CalculateTokenToBuy(tokenPair1, sellAmount, common.NativeToken, swapParams)
-		buyAmount = CalculateTokenToBuy(tokenPair2, nativeToken, queryParams.BuyToken, swapParams).Amount
+		nativeToken := keeper.GetTokenToBuy(ctx, tokenPair1, sellAmount, common.NativeToken, swapParams)
+		buyAmount = keeper.GetTokenToBuy(ctx, tokenPair2, nativeToken, queryParams.BuyToken, swapParams).Amount
 
 		// calculate market price
 		var sellTokenMarketPrice sdk.Dec
@@ -233,7 +239,7 @@ func querySwapQuoteInfo(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (
 		// calculate fee
 		fee1 := sdk.NewDecCoinFromDec(sellAmount.Denom, sellAmount.Amount.Mul(swapParams.FeeRate))
 		routeTokenFee := sdk.NewDecCoinFromDec(common.NativeToken, nativeToken.Amount.Mul(swapParams.FeeRate))
-		fee2 := CalculateTokenToBuy(tokenPair1, routeTokenFee, sellAmount.Denom, swapParams)
+		fee2 := keeper.GetTokenToBuy(ctx, tokenPair1, routeTokenFee, sellAmount.Denom, swapParams)
 		fee = fee1.Add(fee2)
 
 		// swap by route
@@ -328,3 +334,75 @@ func querySwapAddLiquidityQuote(ctx sdk.Context, req abci.RequestQuery, keeper K
 	return bz, nil
 
 }
+
+func querySwapRoute(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var queryParams types.QuerySwapRouteParams
+	err := keeper.cdc.UnmarshalJSON(req.Data, &queryParams)
+	if err != nil {
+		return nil, common.ErrUnMarshalJSONFailed(err.Error())
+	}
+	if queryParams.TokenAmount == "" || queryParams.TargetToken == "" {
+		return nil, types.ErrSellAmountOrBuyTokenIsEmpty()
+	}
+
+	tokenAmount, err := sdk.ParseDecCoin(queryParams.TokenAmount)
+	if err != nil {
+		return nil, types.ErrConvertSellTokenAmount(queryParams.TokenAmount, err)
+	}
+	if tokenAmount.Denom == queryParams.TargetToken {
+		return nil, types.ErrSellAmountEqualBuyToken()
+	}
+
+	routeInfo, err := keeper.GetBestSwapRoute(ctx, tokenAmount, queryParams.TargetToken, queryParams.ExactOutput)
+	if err != nil {
+		return nil, err
+	}
+
+	response := common.GetBaseResponse(routeInfo)
+	bz, err := json.Marshal(response)
+	if err != nil {
+		return nil, common.ErrMarshalJSONFailed(err.Error())
+	}
+	return bz, nil
+}
+
+func queryTWAP(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var queryParams types.QueryTWAPParams
+	err := keeper.cdc.UnmarshalJSON(req.Data, &queryParams)
+	if err != nil {
+		return nil, common.ErrUnMarshalJSONFailed(err.Error())
+	}
+	if queryParams.BaseToken == "" || queryParams.QuoteToken == "" {
+		return nil, types.ErrQueryParamsBaseTokenIsEmpty()
+	}
+
+	twap, err := keeper.GetTWAP(ctx, types.GetSwapTokenPairName(queryParams.BaseToken, queryParams.QuoteToken), queryParams.Window)
+	if err != nil {
+		return nil, err
+	}
+
+	response := common.GetBaseResponse(twap)
+	bz, err := json.Marshal(response)
+	if err != nil {
+		return nil, common.ErrMarshalJSONFailed(err.Error())
+	}
+	return bz, nil
+}
+
+func queryStableSwapPool(ctx sdk.Context, path []string, keeper Keeper) ([]byte, sdk.Error) {
+	tokenPairName := path[0]
+	pool, found := keeper.GetStableSwapPool(ctx, tokenPairName)
+	if !found {
+		return nil, types.ErrNonExistStableSwapPool(tokenPairName)
+	}
+
+	response := common.GetBaseResponse(types.QueryStableSwapPoolResponse{
+		StableSwapPool: pool,
+		Amplification:  pool.Amplification(ctx.BlockTime().Unix()),
+	})
+	bz, err := json.Marshal(response)
+	if err != nil {
+		return nil, common.ErrMarshalJSONFailed(err.Error())
+	}
+	return bz, nil
+}
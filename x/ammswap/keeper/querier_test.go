@@ -153,3 +153,39 @@ func TestQueryBuyAmount(t *testing.T) {
 	expectedToken = "33.233233333634235135"
 	require.Equal(t, expectedToken, result)
 }
+
+func TestQuerySwapRoute(t *testing.T) {
+	mapp, addrList, ctx, keeper, querier := initQurierTest(t)
+
+	poolTokenAmount := sdk.NewDec(1)
+	initTestPool(t, addrList, mapp, ctx, keeper, sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(100)),
+		sdk.NewDecCoinFromDec(types.TestQuotePooledToken, sdk.NewDec(100)), poolTokenAmount)
+	initTestPool(t, addrList, mapp, ctx, keeper, sdk.NewDecCoinFromDec(types.TestBasePooledToken2, sdk.NewDec(100)),
+		sdk.NewDecCoinFromDec(types.TestQuotePooledToken, sdk.NewDec(100)), poolTokenAmount)
+	// a shallow direct pool loses to the route through the native token
+	initTestPool(t, addrList, mapp, ctx, keeper, sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(100)),
+		sdk.NewDecCoinFromDec(types.TestBasePooledToken2, sdk.NewDec(10)), poolTokenAmount)
+
+	path := []string{types.QuerySwapRoute}
+	queryParams := types.NewQuerySwapRouteParams("10"+types.TestBasePooledToken, types.TestBasePooledToken2, false)
+	resultBytes, err := querier(ctx, path, abci.RequestQuery{Data: keeper.cdc.MustMarshalJSON(queryParams)})
+	require.Nil(t, err)
+	var response struct {
+		Data types.SwapRouteInfo `json:"data"`
+	}
+	require.Nil(t, json.Unmarshal(resultBytes, &response))
+	expectedPath := []string{types.TestBasePooledToken, types.TestQuotePooledToken, types.TestBasePooledToken2}
+	require.Equal(t, expectedPath, response.Data.Path)
+	require.Equal(t, []string{types.TestQuotePooledToken}, response.Data.Hops())
+
+	queryParams = types.NewQuerySwapRouteParams("5"+types.TestBasePooledToken2, types.TestBasePooledToken, true)
+	resultBytes, err = querier(ctx, path, abci.RequestQuery{Data: keeper.cdc.MustMarshalJSON(queryParams)})
+	require.Nil(t, err)
+	require.Nil(t, json.Unmarshal(resultBytes, &response))
+	require.Equal(t, expectedPath, response.Data.Path)
+	require.Equal(t, "5.000000000000000000", response.Data.BoughtAmount.Amount.String())
+
+	queryParams = types.NewQuerySwapRouteParams("5"+types.TestBasePooledToken2, types.TestBasePooledToken3, false)
+	_, err = querier(ctx, path, abci.RequestQuery{Data: keeper.cdc.MustMarshalJSON(queryParams)})
+	require.NotNil(t, err)
+}
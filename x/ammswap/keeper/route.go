@@ -0,0 +1,175 @@
+package keeper
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/ammswap/types"
+)
+
+// CalculateTokenToSell calculates the amount to sell for buying exactly buyToken
+func CalculateTokenToSell(swapTokenPair types.SwapTokenPair, buyToken sdk.SysCoin, sellTokenDenom string, params types.Params) (sdk.SysCoin, error) {
+	var inputReserve, outputReserve sdk.Dec
+	if buyToken.Denom < sellTokenDenom {
+		inputReserve = swapTokenPair.QuotePooledCoin.Amount
+		outputReserve = swapTokenPair.BasePooledCoin.Amount
+	} else {
+		inputReserve = swapTokenPair.BasePooledCoin.Amount
+		outputReserve = swapTokenPair.QuotePooledCoin.Amount
+	}
+	if buyToken.Amount.GTE(outputReserve) || params.FeeRate.GTE(sdk.OneDec()) {
+		return sdk.SysCoin{}, types.ErrInsufficientPoolReserve(swapTokenPair.TokenPairName())
+	}
+	tokenSellAmt := GetOutputPrice(buyToken.Amount, inputReserve, outputReserve, params.FeeRate)
+	return sdk.NewDecCoinFromDec(sellTokenDenom, tokenSellAmt), nil
+}
+
+// GetOutputPrice is the inverse of GetInputPrice, it returns the input amount needed to get outputAmount.
+// The result is rounded up so that the pool never gives out more than it is paid for.
+func GetOutputPrice(outputAmount, inputReserve, outputReserve, feeRate sdk.Dec) sdk.Dec {
+	numerator := inputReserve.Mul(outputAmount).MulTruncate(sdk.NewDec(1000))
+	denominator := outputReserve.Sub(outputAmount).MulTruncate(sdk.OneDec().Sub(feeRate).MulTruncate(sdk.NewDec(1000)))
+	return numerator.QuoRoundUp(denominator).Add(sdk.SmallestDec())
+}
+
+// GetSwapRoutePairs returns the swap token pairs along the path
+func (k Keeper) GetSwapRoutePairs(ctx sdk.Context, path []string) ([]types.SwapTokenPair, error) {
+	pairs := make([]types.SwapTokenPair, 0, len(path)-1)
+	for i := 0; i < len(path)-1; i++ {
+		tokenPairName := types.GetSwapTokenPairName(path[i], path[i+1])
+		swapTokenPair, err := k.GetSwapTokenPair(ctx, tokenPairName)
+		if err != nil {
+			return nil, err
+		}
+		if swapTokenPair.BasePooledCoin.IsZero() || swapTokenPair.QuotePooledCoin.IsZero() {
+			return nil, types.ErrIsZeroValue("base pooled coin or quote pooled coin")
+		}
+		pairs = append(pairs, swapTokenPair)
+	}
+	return pairs, nil
+}
+
+// CalculateRouteAmountsOut returns the token amount at each step of the path when selling exactly sellToken
+func (k Keeper) CalculateRouteAmountsOut(ctx sdk.Context, pairs []types.SwapTokenPair, path []string, sellToken sdk.SysCoin, params types.Params) ([]sdk.SysCoin, error) {
+	amounts := make([]sdk.SysCoin, len(path))
+	amounts[0] = sellToken
+	for i, pair := range pairs {
+		amounts[i+1] = k.GetTokenToBuy(ctx, pair, amounts[i], path[i+1], params)
+		if amounts[i+1].IsZero() {
+			return nil, types.ErrIsZeroValue("token buy")
+		}
+	}
+	return amounts, nil
+}
+
+// CalculateRouteAmountsIn returns the token amount at each step of the path when buying exactly buyToken
+func (k Keeper) CalculateRouteAmountsIn(ctx sdk.Context, pairs []types.SwapTokenPair, path []string, buyToken sdk.SysCoin, params types.Params) ([]sdk.SysCoin, error) {
+	amounts := make([]sdk.SysCoin, len(path))
+	amounts[len(path)-1] = buyToken
+	for i := len(pairs) - 1; i >= 0; i-- {
+		tokenSell, err := k.GetTokenToSell(ctx, pairs[i], amounts[i+1], path[i], params)
+		if err != nil {
+			return nil, err
+		}
+		amounts[i] = tokenSell
+	}
+	return amounts, nil
+}
+
+// GetBestSwapRoute searches the swap token pairs for the route with the best quote.
+// With exact output, tokenAmount is bought and the route selling the least targetToken wins,
+// otherwise tokenAmount is sold and the route buying the most targetToken wins.
+func (k Keeper) GetBestSwapRoute(ctx sdk.Context, tokenAmount sdk.SysCoin, targetToken string, exactOutput bool) (types.SwapRouteInfo, error) {
+	sellToken, buyToken := tokenAmount.Denom, targetToken
+	if exactOutput {
+		sellToken, buyToken = targetToken, tokenAmount.Denom
+	}
+
+	// build the graph of the tokens which are connected by non-empty pools
+	pools := make(map[string]types.SwapTokenPair)
+	neighbors := make(map[string][]string)
+	for _, pair := range k.GetSwapTokenPairs(ctx) {
+		if pair.BasePooledCoin.IsZero() || pair.QuotePooledCoin.IsZero() {
+			continue
+		}
+		base, quote := pair.BasePooledCoin.Denom, pair.QuotePooledCoin.Denom
+		pools[pair.TokenPairName()] = pair
+		neighbors[base] = append(neighbors[base], quote)
+		neighbors[quote] = append(neighbors[quote], base)
+	}
+
+	params := k.GetParams(ctx)
+	var best types.SwapRouteInfo
+	found := false
+	path := []string{sellToken}
+	visited := map[string]bool{sellToken: true}
+
+	var search func(denom string)
+	search = func(denom string) {
+		for _, next := range neighbors[denom] {
+			if visited[next] {
+				continue
+			}
+			path = append(path, next)
+			if next == buyToken {
+				if info, ok := k.quoteSwapRoute(ctx, pools, path, tokenAmount, exactOutput, params); ok {
+					if !found || isBetterSwapRoute(info, best, exactOutput) {
+						best, found = info, true
+					}
+				}
+			} else if len(path) < types.MaxSwapRouteHops+2 {
+				visited[next] = true
+				search(next)
+				visited[next] = false
+			}
+			path = path[:len(path)-1]
+		}
+	}
+	search(sellToken)
+
+	if !found {
+		return types.SwapRouteInfo{}, types.ErrSwapRouteNotFound(sellToken, buyToken)
+	}
+	return best, nil
+}
+
+func (k Keeper) quoteSwapRoute(ctx sdk.Context, pools map[string]types.SwapTokenPair, path []string, tokenAmount sdk.SysCoin,
+	exactOutput bool, params types.Params) (types.SwapRouteInfo, bool) {
+	pairs := make([]types.SwapTokenPair, 0, len(path)-1)
+	for i := 0; i < len(path)-1; i++ {
+		pairs = append(pairs, pools[types.GetSwapTokenPairName(path[i], path[i+1])])
+	}
+
+	var amounts []sdk.SysCoin
+	var err error
+	if exactOutput {
+		amounts, err = k.CalculateRouteAmountsIn(ctx, pairs, path, tokenAmount, params)
+	} else {
+		amounts, err = k.CalculateRouteAmountsOut(ctx, pairs, path, tokenAmount, params)
+	}
+	if err != nil {
+		return types.SwapRouteInfo{}, false
+	}
+
+	info := types.SwapRouteInfo{
+		Path:         append([]string{}, path...),
+		Amounts:      amounts,
+		SoldAmount:   amounts[0],
+		BoughtAmount: amounts[len(amounts)-1],
+		Price:        sdk.ZeroDec(),
+	}
+	if info.SoldAmount.Amount.IsPositive() {
+		info.Price = info.BoughtAmount.Amount.Quo(info.SoldAmount.Amount)
+	}
+	return info, true
+}
+
+// isBetterSwapRoute prefers the better amount, and the shorter path when the amounts are equal
+func isBetterSwapRoute(info, best types.SwapRouteInfo, exactOutput bool) bool {
+	if exactOutput {
+		if !info.SoldAmount.Amount.Equal(best.SoldAmount.Amount) {
+			return info.SoldAmount.Amount.LT(best.SoldAmount.Amount)
+		}
+	} else if !info.BoughtAmount.Amount.Equal(best.BoughtAmount.Amount) {
+		return info.BoughtAmount.Amount.GT(best.BoughtAmount.Amount)
+	}
+	return len(info.Path) < len(best.Path)
+}
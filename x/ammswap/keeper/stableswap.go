@@ -0,0 +1,174 @@
+package keeper
+
+import (
+	"fmt"
+	"math/big"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	"github.com/okex/exchain/x/ammswap/types"
+)
+
+// SetStableSwapPool sets the amplification schedule of a stable swap pool
+func (k Keeper) SetStableSwapPool(ctx sdk.Context, pool types.StableSwapPool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(pool)
+	store.Set(types.GetStableSwapPoolKey(pool.TokenPairName), bz)
+}
+
+// GetStableSwapPool gets the amplification schedule of a stable swap pool
+func (k Keeper) GetStableSwapPool(ctx sdk.Context, tokenPairName string) (types.StableSwapPool, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetStableSwapPoolKey(tokenPairName))
+	if bz == nil {
+		return types.StableSwapPool{}, false
+	}
+	var pool types.StableSwapPool
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &pool)
+	return pool, true
+}
+
+// GetStableSwapPools gets the amplification schedules of all the stable swap pools
+func (k Keeper) GetStableSwapPools(ctx sdk.Context) []types.StableSwapPool {
+	var result []types.StableSwapPool
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.StableSwapPoolPrefixKey)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var pool types.StableSwapPool
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &pool)
+		result = append(result, pool)
+	}
+	return result
+}
+
+// GetAmplification returns the current amplification coefficient of a swap token pair,
+// and false if the swap token pair is a constant product pool.
+// The stable swap pools are only read after the jupiter height, not to cost a read to every swap before it.
+func (k Keeper) GetAmplification(ctx sdk.Context, tokenPairName string) (uint64, bool) {
+	if !tmtypes.HigherThanJupiter(ctx.BlockHeight()) {
+		return 0, false
+	}
+	pool, found := k.GetStableSwapPool(ctx, tokenPairName)
+	if !found {
+		return 0, false
+	}
+	return pool.Amplification(ctx.BlockTime().Unix()), true
+}
+
+// GetTokenToBuy calculates the amount to buy with the invariant of the swap token pair
+func (k Keeper) GetTokenToBuy(ctx sdk.Context, swapTokenPair types.SwapTokenPair, sellToken sdk.SysCoin, buyTokenDenom string, params types.Params) sdk.SysCoin {
+	amplification, ok := k.GetAmplification(ctx, swapTokenPair.TokenPairName())
+	if !ok {
+		return CalculateTokenToBuy(swapTokenPair, sellToken, buyTokenDenom, params)
+	}
+	return CalculateStableTokenToBuy(swapTokenPair, sellToken, buyTokenDenom, amplification, params)
+}
+
+// GetTokenToSell calculates the amount to sell for buying exactly buyToken with the invariant of the swap token pair
+func (k Keeper) GetTokenToSell(ctx sdk.Context, swapTokenPair types.SwapTokenPair, buyToken sdk.SysCoin, sellTokenDenom string, params types.Params) (sdk.SysCoin, error) {
+	amplification, ok := k.GetAmplification(ctx, swapTokenPair.TokenPairName())
+	if !ok {
+		return CalculateTokenToSell(swapTokenPair, buyToken, sellTokenDenom, params)
+	}
+	return CalculateStableTokenToSell(swapTokenPair, buyToken, sellTokenDenom, amplification, params)
+}
+
+// CheckMsgManageStableSwapPoolProposal checks msg manage stable swap pool proposal
+func (k Keeper) CheckMsgManageStableSwapPoolProposal(ctx sdk.Context, proposal types.ManageStableSwapPoolProposal) sdk.Error {
+	if !tmtypes.HigherThanJupiter(ctx.BlockHeight()) {
+		return types.ErrInvalidStableSwapPool("stable swap pools are not enabled before the jupiter height")
+	}
+	tokenPairName := proposal.GetSwapTokenPairName()
+	// ramp the amplification of an existing stable swap pool
+	if pool, found := k.GetStableSwapPool(ctx, tokenPairName); found {
+		return types.ValidateAmplificationRamp(pool.Amplification(ctx.BlockTime().Unix()), proposal.Amplification, proposal.RampDuration)
+	}
+
+	// create a new stable swap pool
+	if proposal.RampDuration != 0 {
+		return types.ErrInvalidStableSwapPool(fmt.Sprintf("%s does not exist to ramp its amplification", tokenPairName))
+	}
+	if err := k.IsTokenExist(ctx, proposal.Token0Name); err != nil {
+		return err
+	}
+	if err := k.IsTokenExist(ctx, proposal.Token1Name); err != nil {
+		return err
+	}
+	if _, err := k.GetSwapTokenPair(ctx, tokenPairName); err == nil {
+		return types.ErrInvalidStableSwapPool(fmt.Sprintf("%s is a constant product pool", tokenPairName))
+	}
+	if _, err := k.GetPoolTokenInfo(ctx, types.GetPoolTokenName(proposal.Token0Name, proposal.Token1Name)); err == nil {
+		return types.ErrPoolTokenPairExist()
+	}
+	return nil
+}
+
+// ManageStableSwapPool creates the stable swap pool of the proposal, or ramps its amplification coefficient
+func (k Keeper) ManageStableSwapPool(ctx sdk.Context, proposal types.ManageStableSwapPoolProposal) {
+	tokenPairName := proposal.GetSwapTokenPairName()
+	now := ctx.BlockTime().Unix()
+	if pool, found := k.GetStableSwapPool(ctx, tokenPairName); found {
+		k.SetStableSwapPool(ctx, pool.Ramp(proposal.Amplification, now, proposal.RampDuration))
+		return
+	}
+
+	// the stable swap pool shares the pool token and the swap token pair with the constant product pools
+	k.NewPoolToken(ctx, types.GetPoolTokenName(proposal.Token0Name, proposal.Token1Name))
+	swapTokenPair := types.NewSwapPair(proposal.Token0Name, proposal.Token1Name)
+	k.SetSwapTokenPair(ctx, tokenPairName, swapTokenPair)
+	k.SetStableSwapPool(ctx, types.NewStableSwapPool(tokenPairName, proposal.Amplification, now))
+	k.OnCreateExchange(ctx, swapTokenPair)
+}
+
+// CalculateStableTokenToBuy calculates the amount to buy from a stable swap pool
+func CalculateStableTokenToBuy(swapTokenPair types.SwapTokenPair, sellToken sdk.SysCoin, buyTokenDenom string,
+	amplification uint64, params types.Params) sdk.SysCoin {
+	inputReserve, outputReserve := getInputOutputReserves(swapTokenPair, buyTokenDenom, sellToken.Denom)
+	tokenBuyAmt := GetStableInputPrice(sellToken.Amount, inputReserve, outputReserve, amplification, params.FeeRate)
+	return sdk.NewDecCoinFromDec(buyTokenDenom, tokenBuyAmt)
+}
+
+// CalculateStableTokenToSell calculates the amount to sell for buying exactly buyToken from a stable swap pool
+func CalculateStableTokenToSell(swapTokenPair types.SwapTokenPair, buyToken sdk.SysCoin, sellTokenDenom string,
+	amplification uint64, params types.Params) (sdk.SysCoin, error) {
+	inputReserve, outputReserve := getInputOutputReserves(swapTokenPair, buyToken.Denom, sellTokenDenom)
+	if buyToken.Amount.GTE(outputReserve) || params.FeeRate.GTE(sdk.OneDec()) {
+		return sdk.SysCoin{}, types.ErrInsufficientPoolReserve(swapTokenPair.TokenPairName())
+	}
+	tokenSellAmt := GetStableOutputPrice(buyToken.Amount, inputReserve, outputReserve, amplification, params.FeeRate)
+	return sdk.NewDecCoinFromDec(sellTokenDenom, tokenSellAmt), nil
+}
+
+// GetStableInputPrice returns the output amount of selling inputAmount to a stable swap pool.
+// The fee is taken from the input, and the result is rounded down in favor of the pool.
+func GetStableInputPrice(inputAmount, inputReserve, outputReserve sdk.Dec, amplification uint64, feeRate sdk.Dec) sdk.Dec {
+	inputAmountWithFee := inputAmount.MulTruncate(sdk.OneDec().Sub(feeRate))
+	x, y := inputReserve.BigInt(), outputReserve.BigInt()
+	d := types.GetStableSwapD(x, y, amplification)
+	newY := types.GetStableSwapY(new(big.Int).Add(x, inputAmountWithFee.BigInt()), d, amplification)
+	dy := new(big.Int).Sub(y, newY)
+	dy.Sub(dy, big.NewInt(1))
+	if dy.Sign() <= 0 {
+		return sdk.ZeroDec()
+	}
+	return sdk.NewDecFromBigIntWithPrec(dy, sdk.Precision)
+}
+
+// GetStableOutputPrice is the inverse of GetStableInputPrice, it returns the input amount needed to get outputAmount.
+// The result is rounded up so that the pool never gives out more than it is paid for.
+func GetStableOutputPrice(outputAmount, inputReserve, outputReserve sdk.Dec, amplification uint64, feeRate sdk.Dec) sdk.Dec {
+	x, y := inputReserve.BigInt(), outputReserve.BigInt()
+	d := types.GetStableSwapD(x, y, amplification)
+	newX := types.GetStableSwapY(new(big.Int).Sub(y, outputAmount.BigInt()), d, amplification)
+	dx := new(big.Int).Sub(newX, x)
+	dx.Add(dx, big.NewInt(1))
+	inputAmountWithFee := sdk.NewDecFromBigIntWithPrec(dx, sdk.Precision)
+	return inputAmountWithFee.QuoRoundUp(sdk.OneDec().Sub(feeRate)).Add(sdk.SmallestDec())
+}
+
+// getInputOutputReserves returns the reserves of the sold token and the bought token
+func getInputOutputReserves(swapTokenPair types.SwapTokenPair, buyTokenDenom, sellTokenDenom string) (sdk.Dec, sdk.Dec) {
+	if buyTokenDenom < sellTokenDenom {
+		return swapTokenPair.QuotePooledCoin.Amount, swapTokenPair.BasePooledCoin.Amount
+	}
+	return swapTokenPair.BasePooledCoin.Amount, swapTokenPair.QuotePooledCoin.Amount
+}
@@ -0,0 +1,141 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/ammswap/types"
+)
+
+// SetTWAPCheckpoint sets the price checkpoint of a swap token pair
+func (k Keeper) SetTWAPCheckpoint(ctx sdk.Context, tokenPairName string, checkpoint types.TWAPCheckpoint) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(checkpoint)
+	store.Set(types.GetTWAPCheckpointKey(tokenPairName, checkpoint.Timestamp), bz)
+}
+
+// GetTWAPCheckpoints returns all the price checkpoints of a swap token pair, from the oldest to the latest
+func (k Keeper) GetTWAPCheckpoints(ctx sdk.Context, tokenPairName string) []types.TWAPCheckpoint {
+	var result []types.TWAPCheckpoint
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.GetTWAPCheckpointsKey(tokenPairName))
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var checkpoint types.TWAPCheckpoint
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &checkpoint)
+		result = append(result, checkpoint)
+	}
+	return result
+}
+
+// GetTWAPCheckpointAt returns the latest price checkpoint of a swap token pair not later than timestamp
+func (k Keeper) GetTWAPCheckpointAt(ctx sdk.Context, tokenPairName string, timestamp int64) (types.TWAPCheckpoint, bool) {
+	if timestamp < 0 {
+		return types.TWAPCheckpoint{}, false
+	}
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.ReverseIterator(types.GetTWAPCheckpointsKey(tokenPairName),
+		types.GetTWAPCheckpointKey(tokenPairName, timestamp+1))
+	defer iterator.Close()
+	if !iterator.Valid() {
+		return types.TWAPCheckpoint{}, false
+	}
+	var checkpoint types.TWAPCheckpoint
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &checkpoint)
+	return checkpoint, true
+}
+
+// UpdateTWAPCheckpoints accumulates the prices of all the swap token pairs whose prices changed since their
+// latest checkpoint, and prunes the checkpoints out of the retention period. It is called at the beginning of
+// every block, so the reserves moved within a block never get into the prices.
+func (k Keeper) UpdateTWAPCheckpoints(ctx sdk.Context) {
+	retention := k.GetParams(ctx).TwapRetentionPeriod
+	if retention <= 0 {
+		return
+	}
+	height, now := ctx.BlockHeight(), ctx.BlockTime().Unix()
+	cutoff := now - int64(retention.Seconds())
+	for _, swapTokenPair := range k.GetSwapTokenPairs(ctx) {
+		tokenPairName := swapTokenPair.TokenPairName()
+		// the amplification of a ramping stable swap pool moves its prices without changing the reserves
+		amplification, _ := k.GetAmplification(ctx, tokenPairName)
+		latest, found := k.GetTWAPCheckpointAt(ctx, tokenPairName, now)
+		switch {
+		case !found:
+			k.SetTWAPCheckpoint(ctx, tokenPairName, types.NewTWAPCheckpoint(height, now, swapTokenPair, amplification))
+		case !latest.SamePrices(swapTokenPair, amplification):
+			checkpoint := latest.Accumulate(height, now)
+			checkpoint.BasePooledAmount = swapTokenPair.BasePooledCoin.Amount
+			checkpoint.QuotePooledAmount = swapTokenPair.QuotePooledCoin.Amount
+			checkpoint.Amplification = amplification
+			k.SetTWAPCheckpoint(ctx, tokenPairName, checkpoint)
+		}
+		k.pruneTWAPCheckpoints(ctx, tokenPairName, cutoff)
+	}
+}
+
+// pruneTWAPCheckpoints deletes the checkpoints before cutoff, except the latest of them which
+// the windows starting between it and cutoff still need
+func (k Keeper) pruneTWAPCheckpoints(ctx sdk.Context, tokenPairName string, cutoff int64) {
+	if cutoff <= 0 {
+		return
+	}
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.Iterator(types.GetTWAPCheckpointsKey(tokenPairName), types.GetTWAPCheckpointKey(tokenPairName, cutoff))
+	var keys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	iterator.Close()
+	for i := 0; i < len(keys)-1; i++ {
+		store.Delete(keys[i])
+	}
+}
+
+// GetTWAP returns the time-weighted average prices of a swap token pair over the window (in seconds)
+// which ends at the current block time
+func (k Keeper) GetTWAP(ctx sdk.Context, tokenPairName string, window int64) (types.TWAP, error) {
+	if k.GetParams(ctx).TwapRetentionPeriod <= 0 {
+		return types.TWAP{}, types.ErrTWAPDisabled()
+	}
+	if window <= 0 {
+		return types.TWAP{}, types.ErrInvalidTWAPWindow("window must be positive")
+	}
+	if _, err := k.GetSwapTokenPair(ctx, tokenPairName); err != nil {
+		return types.TWAP{}, err
+	}
+
+	end := ctx.BlockTime().Unix()
+	start := end - window
+	startCheckpoint, found := k.GetTWAPCheckpointAt(ctx, tokenPairName, start)
+	if !found {
+		return types.TWAP{}, types.ErrInvalidTWAPWindow(fmt.Sprintf("no price checkpoint of %s before %d", tokenPairName, start))
+	}
+	endCheckpoint, _ := k.GetTWAPCheckpointAt(ctx, tokenPairName, end)
+	startCheckpoint = startCheckpoint.Accumulate(startCheckpoint.Height, start)
+	endCheckpoint = endCheckpoint.Accumulate(endCheckpoint.Height, end)
+
+	liquidSeconds := endCheckpoint.LiquidSeconds - startCheckpoint.LiquidSeconds
+	if liquidSeconds <= 0 {
+		return types.TWAP{}, types.ErrIsZeroValue(fmt.Sprintf("liquidity of %s within the window", tokenPairName))
+	}
+	return types.TWAP{
+		TokenPairName: tokenPairName,
+		StartTime:     start,
+		EndTime:       end,
+		BasePrice:     endCheckpoint.BasePriceCumulative.Sub(startCheckpoint.BasePriceCumulative).QuoInt64(liquidSeconds),
+		QuotePrice:    endCheckpoint.QuotePriceCumulative.Sub(startCheckpoint.QuotePriceCumulative).QuoInt64(liquidSeconds),
+	}, nil
+}
+
+// GetTWAPPrice returns the time-weighted average price of token in quoteToken over the window (in seconds)
+func (k Keeper) GetTWAPPrice(ctx sdk.Context, token, quoteToken string, window int64) (sdk.Dec, error) {
+	twap, err := k.GetTWAP(ctx, types.GetSwapTokenPairName(token, quoteToken), window)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+	baseToken, _ := types.GetBaseQuoteTokenName(token, quoteToken)
+	if token == baseToken {
+		return twap.BasePrice, nil
+	}
+	return twap.QuotePrice, nil
+}
@@ -0,0 +1,30 @@
+package ammswap
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/ammswap/types"
+	"github.com/okex/exchain/x/common"
+	govTypes "github.com/okex/exchain/x/gov/types"
+)
+
+// NewManageStableSwapPoolProposalHandler handles "gov" type message in "ammswap"
+func NewManageStableSwapPoolProposalHandler(k *Keeper) govTypes.Handler {
+	return func(ctx sdk.Context, proposal *govTypes.Proposal) (err sdk.Error) {
+		switch content := proposal.Content.(type) {
+		case types.ManageStableSwapPoolProposal:
+			return handleManageStableSwapPoolProposal(ctx, k, content)
+		default:
+			return common.ErrUnknownProposalType(DefaultCodespace, content.ProposalType())
+		}
+	}
+}
+
+func handleManageStableSwapPoolProposal(ctx sdk.Context, k *Keeper, p types.ManageStableSwapPoolProposal) sdk.Error {
+	// the state may change during the voting period, so check it again
+	if sdkErr := k.CheckMsgManageStableSwapPoolProposal(ctx, p); sdkErr != nil {
+		return sdkErr
+	}
+
+	k.ManageStableSwapPool(ctx, p)
+	return nil
+}
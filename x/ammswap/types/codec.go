@@ -10,6 +10,8 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgRemoveLiquidity{}, "okexchain/ammswap/MsgRemoveLiquidity", nil)
 	cdc.RegisterConcrete(MsgCreateExchange{}, "okexchain/ammswap/MsgCreateExchange", nil)
 	cdc.RegisterConcrete(MsgTokenToToken{}, "okexchain/ammswap/MsgSwapToken", nil)
+	cdc.RegisterConcrete(MsgSwapRoute{}, "okexchain/ammswap/MsgSwapRoute", nil)
+	cdc.RegisterConcrete(ManageStableSwapPoolProposal{}, "okexchain/ammswap/ManageStableSwapPoolProposal", nil)
 }
 
 // ModuleCdc defines the module codec
@@ -56,6 +56,15 @@ const (
 	CodeIsSwapTokenPairExist                    uint32 = 65043
 	CodeIsPoolTokenPairExist                    uint32 = 65044
 	CodeInternalError                           uint32 = 65045
+	CodeInvalidSwapRoute                        uint32 = 65046
+	CodeSwapRouteNotFound                       uint32 = 65047
+	CodeInsufficientPoolReserve                 uint32 = 65048
+	CodeTWAPDisabled                            uint32 = 65049
+	CodeInvalidTWAPWindow                       uint32 = 65050
+	CodeInvalidAmplification                    uint32 = 65051
+	CodeNonExistStableSwapPool                  uint32 = 65052
+	CodeInvalidStableSwapPool                   uint32 = 65053
+	CodeSwapRouteDisabled                       uint32 = 65054
 )
 
 func ErrNonExistSwapTokenPair(tokenPairName string) sdk.EnvelopedErr {
@@ -239,3 +248,39 @@ func ErrSwapTokenPairExist() sdk.EnvelopedErr {
 func ErrPoolTokenPairExist() sdk.EnvelopedErr {
 	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeIsPoolTokenPairExist, "the pool token pair already exists")}
 }
+
+func ErrInvalidSwapRoute(msg string) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeInvalidSwapRoute, fmt.Sprintf("invalid swap route: %s", msg))}
+}
+
+func ErrSwapRouteNotFound(sellToken, buyToken string) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeSwapRouteNotFound, fmt.Sprintf("no swap route found from %s to %s", sellToken, buyToken))}
+}
+
+func ErrInsufficientPoolReserve(tokenPairName string) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeInsufficientPoolReserve, fmt.Sprintf("insufficient reserve in swap token pair: %s", tokenPairName))}
+}
+
+func ErrTWAPDisabled() sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeTWAPDisabled, "twap oracle is disabled")}
+}
+
+func ErrInvalidTWAPWindow(msg string) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeInvalidTWAPWindow, fmt.Sprintf("invalid twap window: %s", msg))}
+}
+
+func ErrInvalidAmplification(msg string) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeInvalidAmplification, fmt.Sprintf("invalid amplification: %s", msg))}
+}
+
+func ErrNonExistStableSwapPool(tokenPairName string) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeNonExistStableSwapPool, fmt.Sprintf("stable swap pool is not exist: %s", tokenPairName))}
+}
+
+func ErrInvalidStableSwapPool(msg string) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeInvalidStableSwapPool, fmt.Sprintf("invalid stable swap pool: %s", msg))}
+}
+
+func ErrSwapRouteDisabled(height int64) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeSwapRouteDisabled, fmt.Sprintf("swap route is not supported at height %d", height))}
+}
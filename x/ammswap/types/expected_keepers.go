@@ -2,6 +2,7 @@ package types
 
 import (
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	govtypes "github.com/okex/exchain/x/gov/types"
 	"github.com/okex/exchain/x/params"
 	token "github.com/okex/exchain/x/token/types"
 )
@@ -10,6 +11,7 @@ import (
 type ParamSubspace interface {
 	WithKeyTable(table params.KeyTable) params.Subspace
 	Get(ctx sdk.Context, key []byte, ptr interface{})
+	GetIfExists(ctx sdk.Context, key []byte, ptr interface{})
 	GetParamSet(ctx sdk.Context, ps params.ParamSet)
 	SetParamSet(ctx sdk.Context, ps params.ParamSet)
 }
@@ -41,6 +43,12 @@ type TokenKeeper interface {
 	GetTokensInfo(ctx sdk.Context) (tokens []token.Token)
 }
 
+// GovKeeper defines the expected gov Keeper
+type GovKeeper interface {
+	GetDepositParams(ctx sdk.Context) govtypes.DepositParams
+	GetVotingParams(ctx sdk.Context) govtypes.VotingParams
+}
+
 type BackendKeeper interface {
 	OnSwapToken(ctx sdk.Context, address sdk.AccAddress, swapTokenPair SwapTokenPair, sellAmount sdk.SysCoin, buyAmount sdk.SysCoin)
 	OnSwapCreateExchange(ctx sdk.Context, swapTokenPair SwapTokenPair)
@@ -1,5 +1,9 @@
 package types
 
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
 const (
 	// ModuleName is the name of the module
 	ModuleName = "ammswap"
@@ -21,14 +25,39 @@ const (
 	QueryBuyAmount             = "buy"
 	QuerySwapQuoteInfo         = "swapQuoteInfo"
 	QuerySwapAddLiquidityQuote = "swapAddLiquidityQuote"
+	QuerySwapRoute             = "swapRoute"
+	QueryTWAP                  = "twap"
+	QueryStableSwapPool        = "stableSwapPool"
 )
 
 var (
 	// TokenPairPrefixKey to be used for KVStore
 	TokenPairPrefixKey = []byte{0x01}
+	// TWAPCheckpointPrefixKey to be used for the price checkpoints of swap token pairs
+	TWAPCheckpointPrefixKey = []byte{0x02}
+	// StableSwapPoolPrefixKey to be used for the amplification schedules of stable swap pools
+	StableSwapPoolPrefixKey = []byte{0x03}
 )
 
 // nolint
 func GetTokenPairKey(key string) []byte {
 	return append(TokenPairPrefixKey, []byte(key)...)
 }
+
+// GetTWAPCheckpointsKey returns the prefix of all the price checkpoints of a swap token pair
+func GetTWAPCheckpointsKey(tokenPairName string) []byte {
+	key := make([]byte, 0, len(TWAPCheckpointPrefixKey)+1+len(tokenPairName))
+	key = append(key, TWAPCheckpointPrefixKey...)
+	key = append(key, byte(len(tokenPairName)))
+	return append(key, []byte(tokenPairName)...)
+}
+
+// GetTWAPCheckpointKey returns the key of the price checkpoint of a swap token pair at timestamp
+func GetTWAPCheckpointKey(tokenPairName string, timestamp int64) []byte {
+	return append(GetTWAPCheckpointsKey(tokenPairName), sdk.Uint64ToBigEndian(uint64(timestamp))...)
+}
+
+// GetStableSwapPoolKey returns the key of the stable swap pool of a swap token pair
+func GetStableSwapPoolKey(tokenPairName string) []byte {
+	return append(StableSwapPoolPrefixKey, []byte(tokenPairName)...)
+}
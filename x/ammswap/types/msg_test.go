@@ -263,3 +263,64 @@ func TestMsgTokenToTokenInvalid(t *testing.T) {
 		testCode(t, err, testCase.exceptResultCode)
 	}
 }
+
+func TestMsgSwapRoute(t *testing.T) {
+	addr, err := hex.DecodeString(addrStr)
+	require.Nil(t, err)
+	boughtTokenAmount := sdk.NewDecCoinFromDec(TestBasePooledToken, sdk.NewDec(1))
+	deadLine := time.Now().Unix()
+	soldTokenAmount := sdk.NewDecCoinFromDec(TestBasePooledToken2, sdk.NewDec(2))
+	hops := []string{TestQuotePooledToken}
+	msg := NewMsgSwapRoute(soldTokenAmount, boughtTokenAmount, hops, true, deadLine, addr, addr)
+
+	require.Nil(t, msg.ValidateBasic())
+	require.Equal(t, RouterKey, msg.Route())
+	require.Equal(t, TypeMsgSwapRoute, msg.Type())
+
+	bytesMsg := msg.GetSignBytes()
+	resMsg := &MsgSwapRoute{}
+	err = json.Unmarshal(bytesMsg, resMsg)
+	require.Nil(t, err)
+	resAddr := msg.GetSigners()[0]
+	require.EqualValues(t, addr, resAddr)
+	require.Equal(t, []string{TestBasePooledToken2, TestQuotePooledToken, TestBasePooledToken}, msg.GetPath())
+}
+
+func TestMsgSwapRouteInvalid(t *testing.T) {
+	addr, err := hex.DecodeString(addrStr)
+	require.Nil(t, err)
+	boughtTokenAmount := sdk.NewDecCoinFromDec(TestBasePooledToken, sdk.NewDec(1))
+	zeroBoughtTokenAmount := sdk.NewDecCoinFromDec(TestBasePooledToken, sdk.ZeroDec())
+	deadLine := time.Now().Unix()
+	soldTokenAmount := sdk.NewDecCoinFromDec(TestBasePooledToken2, sdk.NewDec(2))
+	zeroSoldTokenAmount := sdk.NewDecCoinFromDec(TestBasePooledToken2, sdk.ZeroDec())
+
+	tests := []struct {
+		testCase          string
+		soldTokenAmount   sdk.SysCoin
+		boughtTokenAmount sdk.SysCoin
+		hops              []string
+		exactOutput       bool
+		recipient         sdk.AccAddress
+		addr              sdk.AccAddress
+		exceptResultCode  uint32
+	}{
+		{"success", soldTokenAmount, boughtTokenAmount, []string{TestQuotePooledToken}, false, addr, addr, sdk.CodeOK},
+		{"success(direct)", soldTokenAmount, boughtTokenAmount, nil, false, addr, addr, sdk.CodeOK},
+		{"success(zero min bought token amount)", soldTokenAmount, zeroBoughtTokenAmount, nil, false, addr, addr, sdk.CodeOK},
+		{"empty sender", soldTokenAmount, boughtTokenAmount, nil, false, addr, nil, CodeAddressIsRequire},
+		{"empty recipient", soldTokenAmount, boughtTokenAmount, nil, false, nil, addr, CodeAddressIsRequire},
+		{"zero SoldTokenAmount", zeroSoldTokenAmount, boughtTokenAmount, nil, false, addr, addr, CodeSoldTokenAmountIsNegative},
+		{"zero exact BoughtTokenAmount", soldTokenAmount, zeroBoughtTokenAmount, nil, true, addr, addr, CodeIsZeroValue},
+		{"same sold and bought token", soldTokenAmount, soldTokenAmount, nil, false, addr, addr, CodeInvalidSwapRoute},
+		{"repeated hop", soldTokenAmount, boughtTokenAmount, []string{TestQuotePooledToken, TestQuotePooledToken}, false, addr, addr, CodeInvalidSwapRoute},
+		{"too many hops", soldTokenAmount, boughtTokenAmount, []string{"aaa", "bbb", "ddd", "eee"}, false, addr, addr, CodeInvalidSwapRoute},
+		{"invalid hop", soldTokenAmount, boughtTokenAmount, []string{"1aaa"}, false, addr, addr, CodeValidateDenom},
+	}
+	for _, testCase := range tests {
+		msg := NewMsgSwapRoute(testCase.soldTokenAmount, testCase.boughtTokenAmount, testCase.hops, testCase.exactOutput,
+			deadLine, testCase.recipient, testCase.addr)
+		err := msg.ValidateBasic()
+		testCode(t, err, testCase.exceptResultCode)
+	}
+}
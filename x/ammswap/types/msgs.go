@@ -8,6 +8,10 @@ import (
 const (
 	TypeMsgAddLiquidity = "add_liquidity"
 	TypeMsgTokenSwap    = "token_swap"
+	TypeMsgSwapRoute    = "swap_route"
+
+	// MaxSwapRouteHops is the max number of intermediate tokens a routed swap may pass through
+	MaxSwapRouteHops = 3
 )
 
 // MsgAddLiquidity Deposit quote_amount and base_amount at current ratio to mint pool tokens.
@@ -265,3 +269,83 @@ func (msg MsgTokenToToken) GetSigners() []sdk.AccAddress {
 func (msg MsgTokenToToken) GetSwapTokenPairName() string {
 	return GetSwapTokenPairName(msg.MinBoughtTokenAmount.Denom, msg.SoldTokenAmount.Denom)
 }
+
+// MsgSwapRoute define the message for swap along a route of pools.
+// With exact input, SoldTokenAmount is sold in full and at least BoughtTokenAmount must be bought.
+// With exact output, exactly BoughtTokenAmount is bought and at most SoldTokenAmount may be sold.
+type MsgSwapRoute struct {
+	SoldTokenAmount   sdk.SysCoin    `json:"sold_token_amount"`   // Amount of tokens sold, or max amount of tokens sold with exact output.
+	BoughtTokenAmount sdk.SysCoin    `json:"bought_token_amount"` // Minimum amount of tokens bought, or exact amount of tokens bought with exact output.
+	Hops              []string       `json:"hops"`                // Intermediate tokens, every two adjacent tokens on the path identify a pool.
+	ExactOutput       bool           `json:"exact_output"`        // Whether BoughtTokenAmount is the exact amount to buy.
+	Deadline          int64          `json:"deadline"`            // Time after which this transaction can no longer be executed.
+	Recipient         sdk.AccAddress `json:"recipient"`           // Recipient address,transfer Tokens to recipient.default recipient is sender.
+	Sender            sdk.AccAddress `json:"sender"`              // Sender
+}
+
+// NewMsgSwapRoute is a constructor function for MsgSwapRoute
+func NewMsgSwapRoute(
+	soldTokenAmount, boughtTokenAmount sdk.SysCoin, hops []string, exactOutput bool, deadline int64,
+	recipient, sender sdk.AccAddress,
+) MsgSwapRoute {
+	return MsgSwapRoute{
+		SoldTokenAmount:   soldTokenAmount,
+		BoughtTokenAmount: boughtTokenAmount,
+		Hops:              hops,
+		ExactOutput:       exactOutput,
+		Deadline:          deadline,
+		Recipient:         recipient,
+		Sender:            sender,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgSwapRoute) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgSwapRoute) Type() string { return TypeMsgSwapRoute }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgSwapRoute) ValidateBasic() sdk.Error {
+	if msg.Sender.Empty() {
+		return ErrAddressIsRequire("sender")
+	}
+
+	if msg.Recipient.Empty() {
+		return ErrAddressIsRequire("recipient")
+	}
+
+	if !(msg.SoldTokenAmount.IsPositive()) {
+		return ErrSoldTokenAmountIsNegative()
+	}
+	if !msg.SoldTokenAmount.IsValid() {
+		return ErrSoldTokenAmount()
+	}
+
+	if !msg.BoughtTokenAmount.IsValid() {
+		return ErrMinBoughtTokenAmount()
+	}
+	if msg.ExactOutput && !msg.BoughtTokenAmount.IsPositive() {
+		return ErrIsZeroValue("exact bought token amount")
+	}
+
+	return ValidateSwapRoute(msg.GetPath())
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgSwapRoute) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgSwapRoute) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// GetPath returns all the tokens on the route, from the sold token to the bought token
+func (msg MsgSwapRoute) GetPath() []string {
+	path := make([]string, 0, len(msg.Hops)+2)
+	path = append(path, msg.SoldTokenAmount.Denom)
+	path = append(path, msg.Hops...)
+	return append(path, msg.BoughtTokenAmount.Denom)
+}
@@ -2,6 +2,7 @@ package types
 
 import (
 	"fmt"
+	"time"
 
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 
@@ -11,6 +12,9 @@ import (
 // FeeRate defines swap fee rate
 var (
 	defaultFeeRate = sdk.NewDecWithPrec(3, 3)
+
+	defaultTwapRetentionPeriod = 24 * time.Hour
+	maxTwapRetentionPeriod     = 30 * 24 * time.Hour
 )
 
 // Default parameter namespace
@@ -22,7 +26,8 @@ const (
 
 // Parameter store keys
 var (
-	KeyFeeRate = []byte("FeeRate")
+	KeyFeeRate             = []byte("FeeRate")
+	KeyTwapRetentionPeriod = []byte("TwapRetentionPeriod")
 )
 
 // ParamKeyTable for swap module
@@ -33,19 +38,23 @@ func ParamKeyTable() params.KeyTable {
 // Params - used for initializing default parameter for swap at genesis
 type Params struct {
 	FeeRate sdk.Dec `json:"fee_rate"`
+	// TwapRetentionPeriod bounds how long the price checkpoints of the pools are kept, zero disables the TWAP oracle
+	TwapRetentionPeriod time.Duration `json:"twap_retention_period"`
 }
 
 // NewParams creates a new Params object
-func NewParams(feeRate sdk.Dec) Params {
+func NewParams(feeRate sdk.Dec, twapRetentionPeriod time.Duration) Params {
 	return Params{
-		FeeRate: feeRate,
+		FeeRate:             feeRate,
+		TwapRetentionPeriod: twapRetentionPeriod,
 	}
 }
 
 // String implements the stringer interface for Params
 func (p Params) String() string {
 	return fmt.Sprintf(`Poolswap Params:
-  TradeFeeRate: %s`, p.FeeRate)
+  TradeFeeRate: %s
+  TwapRetentionPeriod: %s`, p.FeeRate, p.TwapRetentionPeriod)
 }
 
 func validateParams(value interface{}) error {
@@ -63,14 +72,30 @@ func validateParams(value interface{}) error {
 	return nil
 }
 
+func validateTwapRetentionPeriod(value interface{}) error {
+	v, ok := value.(time.Duration)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", value)
+	}
+
+	if v < 0 {
+		return fmt.Errorf("twap retention period cannot be negative: %s", v)
+	}
+	if v > maxTwapRetentionPeriod {
+		return fmt.Errorf("twap retention period too large: %s", v)
+	}
+	return nil
+}
+
 // ParamSetPairs implements params.ParamSet
 func (p *Params) ParamSetPairs() params.ParamSetPairs {
 	return params.ParamSetPairs{
 		{Key: KeyFeeRate, Value: &p.FeeRate, ValidatorFn: validateParams},
+		{Key: KeyTwapRetentionPeriod, Value: &p.TwapRetentionPeriod, ValidatorFn: validateTwapRetentionPeriod},
 	}
 }
 
 // DefaultParams defines the parameters for this module
 func DefaultParams() Params {
-	return NewParams(defaultFeeRate)
+	return NewParams(defaultFeeRate, defaultTwapRetentionPeriod)
 }
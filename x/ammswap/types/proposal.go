@@ -0,0 +1,121 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	govtypes "github.com/okex/exchain/x/gov/types"
+)
+
+const (
+	// proposalTypeManageStableSwapPool defines the type for a ManageStableSwapPoolProposal
+	proposalTypeManageStableSwapPool = "ManageStableSwapPool"
+)
+
+func init() {
+	govtypes.RegisterProposalType(proposalTypeManageStableSwapPool)
+	govtypes.RegisterProposalTypeCodec(ManageStableSwapPoolProposal{}, "okexchain/ammswap/ManageStableSwapPoolProposal")
+}
+
+var _ govtypes.Content = (*ManageStableSwapPoolProposal)(nil)
+
+// ManageStableSwapPoolProposal - structure for the proposal to create a stable swap pool of two tokens,
+// or to ramp the amplification coefficient of an existing one over RampDuration
+type ManageStableSwapPoolProposal struct {
+	Title         string        `json:"title" yaml:"title"`
+	Description   string        `json:"description" yaml:"description"`
+	Token0Name    string        `json:"token0_name" yaml:"token0_name"`
+	Token1Name    string        `json:"token1_name" yaml:"token1_name"`
+	Amplification uint64        `json:"amplification" yaml:"amplification"`
+	RampDuration  time.Duration `json:"ramp_duration" yaml:"ramp_duration"`
+}
+
+// NewManageStableSwapPoolProposal creates a new instance of ManageStableSwapPoolProposal
+func NewManageStableSwapPoolProposal(title, description, token0Name, token1Name string, amplification uint64,
+	rampDuration time.Duration) ManageStableSwapPoolProposal {
+	return ManageStableSwapPoolProposal{
+		Title:         title,
+		Description:   description,
+		Token0Name:    token0Name,
+		Token1Name:    token1Name,
+		Amplification: amplification,
+		RampDuration:  rampDuration,
+	}
+}
+
+// GetTitle returns title of a manage stable swap pool proposal object
+func (mp ManageStableSwapPoolProposal) GetTitle() string {
+	return mp.Title
+}
+
+// GetDescription returns description of a manage stable swap pool proposal object
+func (mp ManageStableSwapPoolProposal) GetDescription() string {
+	return mp.Description
+}
+
+// ProposalRoute returns route key of a manage stable swap pool proposal object
+func (mp ManageStableSwapPoolProposal) ProposalRoute() string {
+	return RouterKey
+}
+
+// ProposalType returns type of a manage stable swap pool proposal object
+func (mp ManageStableSwapPoolProposal) ProposalType() string {
+	return proposalTypeManageStableSwapPool
+}
+
+// ValidateBasic validates a manage stable swap pool proposal
+func (mp ManageStableSwapPoolProposal) ValidateBasic() sdk.Error {
+	if len(strings.TrimSpace(mp.Title)) == 0 {
+		return govtypes.ErrInvalidProposalContent("title is required")
+	}
+	if len(mp.Title) > govtypes.MaxTitleLength {
+		return govtypes.ErrInvalidProposalContent("title length is longer than the maximum title length")
+	}
+
+	if len(mp.Description) == 0 {
+		return govtypes.ErrInvalidProposalContent("description is required")
+	}
+
+	if len(mp.Description) > govtypes.MaxDescriptionLength {
+		return govtypes.ErrInvalidProposalContent("description length is longer than the maximum description length")
+	}
+
+	if mp.ProposalType() != proposalTypeManageStableSwapPool {
+		return govtypes.ErrInvalidProposalType(mp.ProposalType())
+	}
+
+	if err := ValidateSwapAmountName(mp.Token0Name); err != nil {
+		return err
+	}
+	if err := ValidateSwapAmountName(mp.Token1Name); err != nil {
+		return err
+	}
+	if mp.Token0Name == mp.Token1Name {
+		return ErrToken0NameEqualToken1Name()
+	}
+
+	if mp.RampDuration < 0 {
+		return govtypes.ErrInvalidProposalContent("ramp duration can not be negative")
+	}
+	return ValidateAmplification(mp.Amplification)
+}
+
+// GetSwapTokenPairName returns the name of the swap token pair managed by the proposal
+func (mp ManageStableSwapPoolProposal) GetSwapTokenPairName() string {
+	return GetSwapTokenPairName(mp.Token0Name, mp.Token1Name)
+}
+
+// String returns a human readable string representation of a ManageStableSwapPoolProposal
+func (mp ManageStableSwapPoolProposal) String() string {
+	return fmt.Sprintf(`ManageStableSwapPoolProposal:
+ Title:					%s
+ Description:        	%s
+ Type:                	%s
+ Token0Name:			%s
+ Token1Name:			%s
+ Amplification:			%d
+ RampDuration:			%s`,
+		mp.Title, mp.Description, mp.ProposalType(), mp.Token0Name, mp.Token1Name, mp.Amplification, mp.RampDuration)
+}
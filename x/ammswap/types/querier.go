@@ -50,3 +50,60 @@ type QueryBuyAmountParams struct {
 	SoldToken  sdk.SysCoin
 	TokenToBuy string
 }
+
+// QuerySwapRouteParams defines the params of the best swap route query.
+// With exact output, TokenAmount is the amount to buy and TargetToken is the token to sell,
+// otherwise TokenAmount is the amount to sell and TargetToken is the token to buy.
+type QuerySwapRouteParams struct {
+	TokenAmount string `json:"token_amount"`
+	TargetToken string `json:"target_token"`
+	ExactOutput bool   `json:"exact_output"`
+}
+
+// NewQuerySwapRouteParams creates a new instance of QuerySwapRouteParams
+func NewQuerySwapRouteParams(tokenAmount, targetToken string, exactOutput bool) QuerySwapRouteParams {
+	return QuerySwapRouteParams{
+		TokenAmount: tokenAmount,
+		TargetToken: targetToken,
+		ExactOutput: exactOutput,
+	}
+}
+
+// SwapRouteInfo is the quote of a swap along a route of pools
+type SwapRouteInfo struct {
+	Path         []string      `json:"path"`          // all the tokens on the route, from the sold token to the bought token
+	Amounts      []sdk.SysCoin `json:"amounts"`       // token amount at each step of the path
+	SoldAmount   sdk.SysCoin   `json:"sold_amount"`   // amount of tokens sold
+	BoughtAmount sdk.SysCoin   `json:"bought_amount"` // amount of tokens bought
+	Price        sdk.Dec       `json:"price"`         // bought amount divided by sold amount
+}
+
+// Hops returns the intermediate tokens of the route
+func (r SwapRouteInfo) Hops() []string {
+	if len(r.Path) <= 2 {
+		return nil
+	}
+	return r.Path[1 : len(r.Path)-1]
+}
+
+// QueryTWAPParams defines the params of the twap query, Window is in seconds
+type QueryTWAPParams struct {
+	BaseToken  string `json:"base_token"`
+	QuoteToken string `json:"quote_token"`
+	Window     int64  `json:"window"`
+}
+
+// NewQueryTWAPParams creates a new instance of QueryTWAPParams
+func NewQueryTWAPParams(baseToken, quoteToken string, window int64) QueryTWAPParams {
+	return QueryTWAPParams{
+		BaseToken:  baseToken,
+		QuoteToken: quoteToken,
+		Window:     window,
+	}
+}
+
+// QueryStableSwapPoolResponse defines the response of a stable swap pool query, with the current amplification
+type QueryStableSwapPoolResponse struct {
+	StableSwapPool
+	Amplification uint64 `json:"amplification"`
+}
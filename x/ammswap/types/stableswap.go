@@ -0,0 +1,198 @@
+package types
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
+const (
+	// MinAmplification is the minimum amplification coefficient of a stable swap pool
+	MinAmplification uint64 = 1
+	// MaxAmplification is the maximum amplification coefficient of a stable swap pool
+	MaxAmplification uint64 = 1000000
+	// MaxAmplificationChange is the maximum factor by which a ramp can change the amplification coefficient
+	MaxAmplificationChange uint64 = 10
+	// MinAmplificationRampDuration is the minimum duration of an amplification coefficient ramp
+	MinAmplificationRampDuration = 24 * time.Hour
+
+	// stableSwapMaxIterations bounds the Newton's method iterations of the StableSwap invariant
+	stableSwapMaxIterations = 255
+)
+
+// StableSwapPool defines the amplification coefficient schedule of a swap token pair priced with the StableSwap
+// invariant. The amplification coefficient moves linearly from InitialAmplification at InitialTime to
+// FutureAmplification at FutureTime.
+type StableSwapPool struct {
+	TokenPairName        string `json:"token_pair_name"`
+	InitialAmplification uint64 `json:"initial_amplification"`
+	FutureAmplification  uint64 `json:"future_amplification"`
+	InitialTime          int64  `json:"initial_time"`
+	FutureTime           int64  `json:"future_time"`
+}
+
+// NewStableSwapPool is a constructor function for StableSwapPool with a fixed amplification coefficient
+func NewStableSwapPool(tokenPairName string, amplification uint64, now int64) StableSwapPool {
+	return StableSwapPool{
+		TokenPairName:        tokenPairName,
+		InitialAmplification: amplification,
+		FutureAmplification:  amplification,
+		InitialTime:          now,
+		FutureTime:           now,
+	}
+}
+
+// Amplification returns the amplification coefficient at timestamp now
+func (p StableSwapPool) Amplification(now int64) uint64 {
+	if now >= p.FutureTime || p.FutureTime <= p.InitialTime {
+		return p.FutureAmplification
+	}
+	if now <= p.InitialTime {
+		return p.InitialAmplification
+	}
+	elapsed, duration := uint64(now-p.InitialTime), uint64(p.FutureTime-p.InitialTime)
+	if p.FutureAmplification > p.InitialAmplification {
+		return p.InitialAmplification + (p.FutureAmplification-p.InitialAmplification)*elapsed/duration
+	}
+	return p.InitialAmplification - (p.InitialAmplification-p.FutureAmplification)*elapsed/duration
+}
+
+// IsRamping returns true if the amplification coefficient is still moving at timestamp now
+func (p StableSwapPool) IsRamping(now int64) bool {
+	return now < p.FutureTime && p.InitialAmplification != p.FutureAmplification
+}
+
+// Ramp returns the pool whose amplification coefficient moves from the current one to amplification over duration
+func (p StableSwapPool) Ramp(amplification uint64, now int64, duration time.Duration) StableSwapPool {
+	p.InitialAmplification = p.Amplification(now)
+	p.FutureAmplification = amplification
+	p.InitialTime = now
+	p.FutureTime = now + int64(duration.Seconds())
+	return p
+}
+
+// String implement fmt.Stringer
+func (p StableSwapPool) String() string {
+	return strings.TrimSpace(fmt.Sprintf(`TokenPairName: %s
+InitialAmplification: %d
+FutureAmplification: %d
+InitialTime: %d
+FutureTime: %d`, p.TokenPairName, p.InitialAmplification, p.FutureAmplification, p.InitialTime, p.FutureTime))
+}
+
+// ValidateAmplification checks the range of an amplification coefficient
+func ValidateAmplification(amplification uint64) error {
+	if amplification < MinAmplification || amplification > MaxAmplification {
+		return ErrInvalidAmplification(fmt.Sprintf("amplification must be in [%d, %d], got %d",
+			MinAmplification, MaxAmplification, amplification))
+	}
+	return nil
+}
+
+// ValidateAmplificationRamp checks a ramp of the amplification coefficient from current to future over duration
+func ValidateAmplificationRamp(current, future uint64, duration time.Duration) error {
+	if err := ValidateAmplification(future); err != nil {
+		return err
+	}
+	if duration < MinAmplificationRampDuration {
+		return ErrInvalidAmplification(fmt.Sprintf("ramp duration must not be shorter than %s", MinAmplificationRampDuration))
+	}
+	if future > current*MaxAmplificationChange || current > future*MaxAmplificationChange {
+		return ErrInvalidAmplification(fmt.Sprintf("amplification can not change by more than %d times in a ramp, from %d to %d",
+			MaxAmplificationChange, current, future))
+	}
+	return nil
+}
+
+// GetStableSwapD solves the StableSwap invariant of two coins for D with Newton's method:
+//
+//	4A(x + y) + D = 4AD + D^3 / (4xy)
+//
+// All the amounts are in the smallest unit of sdk.Dec, and Ann is A * n^n = 4A for the two coins.
+func GetStableSwapD(x, y *big.Int, amplification uint64) *big.Int {
+	s := new(big.Int).Add(x, y)
+	if s.Sign() == 0 || x.Sign() == 0 || y.Sign() == 0 {
+		return big.NewInt(0)
+	}
+	ann := new(big.Int).SetUint64(amplification * 4)
+	annS := new(big.Int).Mul(ann, s)
+	annMinusOne := new(big.Int).Sub(ann, big.NewInt(1))
+	twoX, twoY := new(big.Int).Lsh(x, 1), new(big.Int).Lsh(y, 1)
+
+	d := new(big.Int).Set(s)
+	for i := 0; i < stableSwapMaxIterations; i++ {
+		// dP = D^3 / (4xy)
+		dP := new(big.Int).Mul(d, d)
+		dP.Quo(dP, twoX)
+		dP.Mul(dP, d)
+		dP.Quo(dP, twoY)
+
+		prev := d
+		// D = (Ann * S + 2 * dP) * D / ((Ann - 1) * D + 3 * dP)
+		numerator := new(big.Int).Add(annS, new(big.Int).Lsh(dP, 1))
+		numerator.Mul(numerator, d)
+		denominator := new(big.Int).Mul(annMinusOne, d)
+		denominator.Add(denominator, new(big.Int).Mul(dP, big.NewInt(3)))
+		d = numerator.Quo(numerator, denominator)
+
+		if new(big.Int).Sub(d, prev).CmpAbs(big.NewInt(1)) <= 0 {
+			break
+		}
+	}
+	return d
+}
+
+// GetStableSwapY solves the StableSwap invariant of two coins for the reserve of one coin,
+// given the reserve x of the other one and the invariant d
+func GetStableSwapY(x, d *big.Int, amplification uint64) *big.Int {
+	if x.Sign() <= 0 || d.Sign() == 0 {
+		return big.NewInt(0)
+	}
+	ann := new(big.Int).SetUint64(amplification * 4)
+	// c = D^3 / (4 * x * Ann), b = x + D / Ann
+	c := new(big.Int).Mul(d, d)
+	c.Quo(c, new(big.Int).Lsh(x, 1))
+	c.Mul(c, d)
+	c.Quo(c, new(big.Int).Lsh(ann, 1))
+	b := new(big.Int).Add(x, new(big.Int).Quo(d, ann))
+
+	y := new(big.Int).Set(d)
+	for i := 0; i < stableSwapMaxIterations; i++ {
+		prev := y
+		// y = (y^2 + c) / (2y + b - D)
+		numerator := new(big.Int).Mul(y, y)
+		numerator.Add(numerator, c)
+		denominator := new(big.Int).Lsh(y, 1)
+		denominator.Add(denominator, b)
+		denominator.Sub(denominator, d)
+		y = numerator.Quo(numerator, denominator)
+
+		if new(big.Int).Sub(y, prev).CmpAbs(big.NewInt(1)) <= 0 {
+			break
+		}
+	}
+	return y
+}
+
+// GetStableSwapSpotPrice returns the marginal price of the coin of reserve x in the coin of reserve y on the
+// StableSwap invariant, which is -dy/dx = (16Ax^2y^2 + D^3y) / (16Ax^2y^2 + D^3x). It is y/x when A goes to 0
+// as the constant product pools, and 1 when A goes to infinity.
+func GetStableSwapSpotPrice(x, y sdk.Dec, amplification uint64) sdk.Dec {
+	bx, by := x.BigInt(), y.BigInt()
+	d := GetStableSwapD(bx, by, amplification)
+	if d.Sign() == 0 {
+		return sdk.ZeroDec()
+	}
+	d3 := new(big.Int).Exp(d, big.NewInt(3), nil)
+	xy := new(big.Int).Mul(bx, by)
+	base := new(big.Int).Mul(xy, xy)
+	base.Mul(base, new(big.Int).SetUint64(amplification*16))
+
+	numerator := new(big.Int).Add(base, new(big.Int).Mul(d3, by))
+	denominator := new(big.Int).Add(base, new(big.Int).Mul(d3, bx))
+	numerator.Mul(numerator, sdk.OneDec().BigInt())
+	return sdk.NewDecFromBigIntWithPrec(numerator.Quo(numerator, denominator), sdk.Precision)
+}
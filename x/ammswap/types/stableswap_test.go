@@ -0,0 +1,68 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func bigInt(t *testing.T, s string) *big.Int {
+	i, ok := new(big.Int).SetString(s, 10)
+	require.True(t, ok)
+	return i
+}
+
+// the vectors are computed with get_D and get_y of the Curve StableSwap pools, whose amp is A * n^(n-1) = 2A
+func TestStableSwapCurveVectors(t *testing.T) {
+	tests := []struct {
+		amplification uint64
+		x, y, dx      string
+		d, newY       string
+	}{
+		{100, "1000000000000000000000", "1200000000000000000000", "10000000000000000000",
+			"2199954397522062696802", "1189991274122760214532"},
+		{50, "1800000000000000000000", "200000000000000000000", "100000000000000000000",
+			"1982845120944717348112", "116830936917465469130"},
+		{2000, "1000000000000000000000000", "300000000000000000000000", "10000000000000000000000",
+			"1299933672569791439555475", "290005542178313255447729"},
+	}
+	for _, test := range tests {
+		x, y := bigInt(t, test.x), bigInt(t, test.y)
+		d := GetStableSwapD(x, y, test.amplification)
+		require.Equal(t, test.d, d.String())
+		newY := GetStableSwapY(new(big.Int).Add(x, bigInt(t, test.dx)), d, test.amplification)
+		require.Equal(t, test.newY, newY.String())
+	}
+
+	require.Equal(t, int64(0), GetStableSwapD(big.NewInt(0), big.NewInt(100), 100).Int64())
+}
+
+func TestStableSwapSpotPrice(t *testing.T) {
+	// a balanced pool is at par
+	require.Equal(t, sdk.OneDec(), GetStableSwapSpotPrice(sdk.NewDec(1000), sdk.NewDec(1000), 100))
+
+	// the price is the derivative of the invariant, between par and the constant product price
+	x, y := sdk.NewDec(1000), sdk.NewDec(1200)
+	price := GetStableSwapSpotPrice(x, y, 100)
+	require.Equal(t, sdk.MustNewDecFromStr("1.000919996553389688"), price)
+	require.Equal(t, sdk.MustNewDecFromStr("0.999080849062305035"), GetStableSwapSpotPrice(y, x, 100))
+	require.True(t, price.LT(y.Quo(x)))
+	require.True(t, GetStableSwapSpotPrice(x, y, 1).GT(price))
+
+	// the checkpoints of a stable swap pool accumulate the prices on the invariant
+	checkpoint := TWAPCheckpoint{
+		BasePriceCumulative:  sdk.ZeroDec(),
+		QuotePriceCumulative: sdk.ZeroDec(),
+		BasePooledAmount:     x,
+		QuotePooledAmount:    y,
+		Amplification:        100,
+	}
+	checkpoint = checkpoint.Accumulate(1, 10)
+	require.Equal(t, price.MulInt64(10), checkpoint.BasePriceCumulative)
+	checkpoint.Amplification = 0
+	basePrice, _, ok := checkpoint.Prices()
+	require.True(t, ok)
+	require.Equal(t, y.Quo(x), basePrice)
+}
@@ -118,3 +118,21 @@ func SplitPoolToken(symbol string) (token0, token1 string) {
 	token1 = splits[1]
 	return
 }
+
+// ValidateSwapRoute checks the tokens on a swap route, from the sold token to the bought token
+func ValidateSwapRoute(path []string) error {
+	if len(path) < 2 || len(path) > MaxSwapRouteHops+2 {
+		return ErrInvalidSwapRoute(fmt.Sprintf("route must pass through at most %d intermediate tokens", MaxSwapRouteHops))
+	}
+	seen := make(map[string]bool, len(path))
+	for _, denom := range path {
+		if seen[denom] {
+			return ErrInvalidSwapRoute(fmt.Sprintf("token %s appears more than once", denom))
+		}
+		seen[denom] = true
+		if err := ValidateSwapAmountName(denom); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
+// TWAPCheckpoint records the cumulative prices of a swap token pair since its first checkpoint.
+// The pooled amounts are the reserves in effect from Timestamp until the next checkpoint, and Amplification is the
+// amplification coefficient in effect for a stable swap pool, 0 for a constant product pool.
+type TWAPCheckpoint struct {
+	Height               int64   `json:"height"`
+	Timestamp            int64   `json:"timestamp"`              // unix seconds of the block
+	BasePriceCumulative  sdk.Dec `json:"base_price_cumulative"`  // sum of base token price in quote token times seconds
+	QuotePriceCumulative sdk.Dec `json:"quote_price_cumulative"` // sum of quote token price in base token times seconds
+	LiquidSeconds        int64   `json:"liquid_seconds"`         // seconds during which both reserves were not zero
+	BasePooledAmount     sdk.Dec `json:"base_pooled_amount"`
+	QuotePooledAmount    sdk.Dec `json:"quote_pooled_amount"`
+	Amplification        uint64  `json:"amplification"`
+}
+
+// NewTWAPCheckpoint creates the first checkpoint of a swap token pair
+func NewTWAPCheckpoint(height, timestamp int64, swapTokenPair SwapTokenPair, amplification uint64) TWAPCheckpoint {
+	return TWAPCheckpoint{
+		Height:               height,
+		Timestamp:            timestamp,
+		BasePriceCumulative:  sdk.ZeroDec(),
+		QuotePriceCumulative: sdk.ZeroDec(),
+		BasePooledAmount:     swapTokenPair.BasePooledCoin.Amount,
+		QuotePooledAmount:    swapTokenPair.QuotePooledCoin.Amount,
+		Amplification:        amplification,
+	}
+}
+
+// Prices returns the spot prices given by the pooled amounts, ok is false when the pool is empty.
+// The prices of a stable swap pool are the marginal prices on its invariant.
+func (c TWAPCheckpoint) Prices() (basePrice, quotePrice sdk.Dec, ok bool) {
+	if !c.BasePooledAmount.IsPositive() || !c.QuotePooledAmount.IsPositive() {
+		return sdk.ZeroDec(), sdk.ZeroDec(), false
+	}
+	if c.Amplification > 0 {
+		return GetStableSwapSpotPrice(c.BasePooledAmount, c.QuotePooledAmount, c.Amplification),
+			GetStableSwapSpotPrice(c.QuotePooledAmount, c.BasePooledAmount, c.Amplification), true
+	}
+	return c.QuotePooledAmount.Quo(c.BasePooledAmount), c.BasePooledAmount.Quo(c.QuotePooledAmount), true
+}
+
+// Accumulate returns the checkpoint at timestamp that follows c, for a swap token pair which has
+// been holding the pooled amounts of c since c.Timestamp
+func (c TWAPCheckpoint) Accumulate(height, timestamp int64) TWAPCheckpoint {
+	next := c
+	next.Height = height
+	next.Timestamp = timestamp
+	elapsed := timestamp - c.Timestamp
+	if basePrice, quotePrice, ok := c.Prices(); ok && elapsed > 0 {
+		next.BasePriceCumulative = c.BasePriceCumulative.Add(basePrice.MulInt64(elapsed))
+		next.QuotePriceCumulative = c.QuotePriceCumulative.Add(quotePrice.MulInt64(elapsed))
+		next.LiquidSeconds = c.LiquidSeconds + elapsed
+	}
+	return next
+}
+
+// SamePrices returns whether the swap token pair still holds the pooled amounts and the amplification coefficient of c
+func (c TWAPCheckpoint) SamePrices(swapTokenPair SwapTokenPair, amplification uint64) bool {
+	return c.BasePooledAmount.Equal(swapTokenPair.BasePooledCoin.Amount) &&
+		c.QuotePooledAmount.Equal(swapTokenPair.QuotePooledCoin.Amount) &&
+		c.Amplification == amplification
+}
+
+// String implement fmt.Stringer
+func (c TWAPCheckpoint) String() string {
+	return strings.TrimSpace(fmt.Sprintf(`Height: %d
+Timestamp: %d
+BasePriceCumulative: %s
+QuotePriceCumulative: %s
+LiquidSeconds: %d
+BasePooledAmount: %s
+QuotePooledAmount: %s
+Amplification: %d`, c.Height, c.Timestamp, c.BasePriceCumulative, c.QuotePriceCumulative, c.LiquidSeconds,
+		c.BasePooledAmount, c.QuotePooledAmount, c.Amplification))
+}
+
+// TWAP is the time-weighted average price of a swap token pair over a window
+type TWAP struct {
+	TokenPairName string  `json:"token_pair_name"`
+	StartTime     int64   `json:"start_time"`
+	EndTime       int64   `json:"end_time"`
+	BasePrice     sdk.Dec `json:"base_price"`  // average price of base token in quote token
+	QuotePrice    sdk.Dec `json:"quote_price"` // average price of quote token in base token
+}
+
+// String implement fmt.Stringer
+func (t TWAP) String() string {
+	return strings.TrimSpace(fmt.Sprintf(`TokenPairName: %s
+StartTime: %d
+EndTime: %d
+BasePrice: %s
+QuotePrice: %s`, t.TokenPairName, t.StartTime, t.EndTime, t.BasePrice, t.QuotePrice))
+}
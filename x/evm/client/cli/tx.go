@@ -109,8 +109,11 @@ Where proposal.json contains:
       "denom": "%s",
       "amount": "100.000000000000000000"
     }
-  ]
+  ],
+  "is_expedited": false
 }
+
+Set "is_expedited" to true to submit an expedited proposal.
 `, version.ClientName, sdk.DefaultBondDenom,
 			)),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -137,6 +140,7 @@ Where proposal.json contains:
 			}
 
 			msg := gov.NewMsgSubmitProposal(content, proposal.Deposit, cliCtx.GetFromAddress())
+			msg.IsExpedited = proposal.IsExpedited
 			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
@@ -195,8 +199,11 @@ Where proposal.json contains:
             "denom":"%s",
             "amount":"100.000000000000000000"
         }
-    ]
+    ],
+    "is_expedited":false
 }
+
+Set "is_expedited" to true to submit an expedited proposal.
 `, version.ClientName, sdk.DefaultBondDenom,
 			)),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -223,6 +230,7 @@ Where proposal.json contains:
 			}
 
 			msg := gov.NewMsgSubmitProposal(content, proposal.Deposit, cliCtx.GetFromAddress())
+			msg.IsExpedited = proposal.IsExpedited
 			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
@@ -28,6 +28,7 @@ type (
 		ContractAddrs types.AddressList `json:"contract_addresses" yaml:"contract_addresses"`
 		IsAdded       bool              `json:"is_added" yaml:"is_added"`
 		Deposit       sdk.SysCoins      `json:"deposit" yaml:"deposit"`
+		IsExpedited   bool              `json:"is_expedited" yaml:"is_expedited"`
 	}
 	// ManageContractMethodBlockedListProposalJSON defines a ManageContractMethodBlockedListProposal with a deposit used to parse
 	// manage contract method blocked list proposals from a JSON file.
@@ -37,6 +38,7 @@ type (
 		ContractList types.BlockedContractList `json:"contract_addresses" yaml:"contract_addresses"`
 		IsAdded      bool                      `json:"is_added" yaml:"is_added"`
 		Deposit      sdk.SysCoins              `json:"deposit" yaml:"deposit"`
+		IsExpedited  bool                      `json:"is_expedited" yaml:"is_expedited"`
 	}
 
 	// ManageSysContractAddressProposalJSON defines a ManageSysContractAddressProposal with a deposit used to parse
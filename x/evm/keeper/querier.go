@@ -36,6 +36,8 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 			return queryStorageProof(ctx, path, keeper, req.Height)
 		case types.QueryStorageRoot:
 			return queryStorageRootHash(ctx, path, keeper, req.Height)
+		case types.QueryStorageRootProof:
+			return queryStorageRootProof(ctx, path, keeper, req.Height)
 		case types.QueryStorageByKey:
 			return queryStorageByKey(ctx, path, keeper)
 		case types.QueryCode:
@@ -173,22 +175,25 @@ func queryStorageProof(ctx sdk.Context, path []string, keeper Keeper, height int
 			return nil, fmt.Errorf("open %s storage trie failed: %s", addr, err.Error())
 		}
 
-		// append key
+		// the proof is generated for the same key the value is read with
 		key := ethcmn.HexToHash(path[2])
-		val, err := storageTrie.TryGet(crypto.Keccak256(append(addr.Bytes(), key.Bytes()...)))
+		storageKey := key
+		if types.TrieUseCompositeKey {
+			storageKey = types.GetStorageByAddressKey(addr.Bytes(), key.Bytes())
+		}
+		val, err := storageTrie.TryGet(storageKey.Bytes())
 		if err != nil {
 			return nil, fmt.Errorf("get %s storage in location %s failed: %s", addr, key, err.Error())
 		}
-		// check if value is found
+		// an absent value is proven as well
+		var proof mpt.ProofList
+		if err = storageTrie.Prove(crypto.Keccak256(storageKey.Bytes()), 0, &proof); err != nil {
+			return nil, fmt.Errorf("trie generate proof failed: %s", err.Error())
+		}
 		if val == nil {
-			res = types.QueryResStorageProof{Value: []byte{}, Proof: [][]byte{}}
-		} else {
-			var proof mpt.ProofList
-			if err = storageTrie.Prove(crypto.Keccak256(key.Bytes()), 0, &proof); err != nil {
-				return nil, fmt.Errorf("trie generate proof failed: %s", err.Error())
-			}
-			res = types.QueryResStorageProof{Value: val, Proof: proof}
+			val = []byte{}
 		}
+		res = types.QueryResStorageProof{Value: val, Proof: proof}
 	}
 
 	// marshal result
@@ -235,6 +240,42 @@ func queryStorageRootHash(ctx sdk.Context, path []string, keeper Keeper, height
 	}
 }
 
+// queryStorageRootProof returns the storage root of the address with its proof
+// in the evm trie, the root of which is saved in the params store.
+func queryStorageRootProof(ctx sdk.Context, path []string, keeper Keeper, height int64) ([]byte, error) {
+	if len(path) < 2 {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest,
+			"Insufficient parameters, at least 1 parameters is required")
+	}
+
+	addr := ethcmn.HexToAddress(path[1])
+	evmRootHash := keeper.GetMptRootHash(uint64(height))
+	if evmRootHash == mpt.NilHash {
+		return nil, fmt.Errorf("header %d not found", height)
+	}
+	evmTrie, err := keeper.db.OpenTrie(evmRootHash)
+	if err != nil {
+		return nil, fmt.Errorf("open evm trie failed: %s", err.Error())
+	}
+	storageRootHash, err := evmTrie.TryGet(addr.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("get %s storage root hash failed: %s", addr, err.Error())
+	}
+	var proof mpt.ProofList
+	if err = evmTrie.Prove(crypto.Keccak256(addr.Bytes()), 0, &proof); err != nil {
+		return nil, fmt.Errorf("trie generate proof failed: %s", err.Error())
+	}
+	if storageRootHash == nil {
+		storageRootHash = []byte{}
+	}
+
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, types.QueryResStorageProof{Value: storageRootHash, Proof: proof})
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
+
 func queryStorageRootBytesInHeight(keeper Keeper, addr ethcmn.Address, height int64) ([]byte, error) {
 	// query evm tire root hash based on height
 	evmRootHash := keeper.GetMptRootHash(uint64(height))
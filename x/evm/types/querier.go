@@ -9,19 +9,20 @@ import (
 
 // Supported endpoints
 const (
-	QueryBalance       = "balance"
-	QueryBlockNumber   = "blockNumber"
-	QueryStorage       = "storage"
-	QueryStorageProof  = "storageProof"
-	QueryStorageRoot   = "storageRoot"
-	QueryStorageByKey  = "storageKey"
-	QueryCode          = "code"
-	QueryCodeByHash    = "codeHash"
-	QueryNonce         = "nonce"
-	QueryHashToHeight  = "hashToHeight"
-	QueryBloom         = "bloom"
-	QueryAccount       = "account"
-	QueryExportAccount = "exportAccount"
+	QueryBalance          = "balance"
+	QueryBlockNumber      = "blockNumber"
+	QueryStorage          = "storage"
+	QueryStorageProof     = "storageProof"
+	QueryStorageRoot      = "storageRoot"
+	QueryStorageRootProof = "storageRootProof"
+	QueryStorageByKey     = "storageKey"
+	QueryCode             = "code"
+	QueryCodeByHash       = "codeHash"
+	QueryNonce            = "nonce"
+	QueryHashToHeight     = "hashToHeight"
+	QueryBloom            = "bloom"
+	QueryAccount          = "account"
+	QueryExportAccount    = "exportAccount"
 	// QueryParameters defines 	QueryParameters = "params" query route path
 	QueryParameters                  = "params"
 	QueryHeightToHash                = "heightToHash"
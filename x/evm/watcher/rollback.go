@@ -0,0 +1,127 @@
+package watcher
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	dbm "github.com/okex/exchain/libs/tm-db"
+)
+
+// RollbackWatchDB deletes the blocks, txs, receipts and contract codes of the
+// heights after the given one from the watch db and sets it as the latest
+// height. The accounts and states are only the latest ones, they are deleted and
+// the rpc queries them from the chain until the watcher writes them again.
+func RollbackWatchDB(db dbm.DB, height int64) error {
+	latestBytes, err := db.Get(keyLatestBlockHeight)
+	if err != nil || latestBytes == nil {
+		return err
+	}
+	latest, err := strconv.ParseInt(string(latestBytes), 10, 64)
+	if err != nil {
+		return err
+	}
+	if latest <= height {
+		return nil
+	}
+
+	batch := db.NewBatch()
+	defer batch.Close()
+	for h := height + 1; h <= latest; h++ {
+		if err := deleteWatchBlock(db, batch, h); err != nil {
+			return err
+		}
+	}
+
+	var codeInfo CodeInfo
+	if err := iterateWatchDB(db, prefixCode, func(key, value []byte) error {
+		if err := json.Unmarshal(value, &codeInfo); err != nil {
+			return err
+		}
+		if codeInfo.Height > uint64(height) {
+			batch.Delete(key)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	for _, prefix := range [][]byte{prefixAccount, PrefixState, prefixRpcDb} {
+		if err := iterateWatchDB(db, prefix, func(key, _ []byte) error {
+			batch.Delete(key)
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	batch.Set(keyLatestBlockHeight, []byte(strconv.FormatInt(height, 10)))
+	return batch.WriteSync()
+}
+
+// deleteWatchBlock deletes the block of the height with its txs into the batch
+func deleteWatchBlock(db dbm.DB, batch dbm.Batch, height int64) error {
+	blockInfoKey := append(prefixBlockInfo, []byte(strconv.FormatInt(height, 10))...)
+	hashBytes, err := db.Get(blockInfoKey)
+	if err != nil || hashBytes == nil {
+		return err
+	}
+	blockHash := common.HexToHash(string(hashBytes))
+	blockKey := append(prefixBlock, blockHash.Bytes()...)
+	stdTxHashKey := append(prefixStdTxHash, blockHash.Bytes()...)
+
+	var txHashes []common.Hash
+	blockBytes, err := db.Get(blockKey)
+	if err != nil {
+		return err
+	}
+	if blockBytes != nil {
+		var block Block
+		if err := json.Unmarshal(blockBytes, &block); err != nil {
+			return err
+		}
+		if txs, ok := block.Transactions.([]interface{}); ok {
+			for _, tx := range txs {
+				if hash, ok := tx.(string); ok {
+					txHashes = append(txHashes, common.HexToHash(hash))
+				}
+			}
+		}
+	}
+	stdTxHashBytes, err := db.Get(stdTxHashKey)
+	if err != nil {
+		return err
+	}
+	if stdTxHashBytes != nil {
+		var stdTxHashes []common.Hash
+		if err := json.Unmarshal(stdTxHashBytes, &stdTxHashes); err != nil {
+			return err
+		}
+		txHashes = append(txHashes, stdTxHashes...)
+	}
+
+	for _, txHash := range txHashes {
+		batch.Delete(append(prefixTx, txHash.Bytes()...))
+		batch.Delete(append(prefixReceipt, txHash.Bytes()...))
+		batch.Delete(append(prefixTxResponse, txHash.Bytes()...))
+	}
+	batch.Delete(stdTxHashKey)
+	batch.Delete(blockKey)
+	batch.Delete(blockInfoKey)
+	return nil
+}
+
+func iterateWatchDB(db dbm.DB, prefix []byte, fn func(key, value []byte) error) error {
+	it, err := dbm.IteratePrefix(db, prefix)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+	for ; it.Valid(); it.Next() {
+		key := make([]byte, len(it.Key()))
+		copy(key, it.Key())
+		if err := fn(key, it.Value()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package watcher
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	dbm "github.com/okex/exchain/libs/tm-db"
+	"github.com/stretchr/testify/require"
+)
+
+type watchMessage interface {
+	GetKey() []byte
+	GetValue() string
+}
+
+func setWatchMessage(db dbm.DB, msg watchMessage) {
+	db.Set(msg.GetKey(), []byte(msg.GetValue()))
+}
+
+func TestRollbackWatchDB(t *testing.T) {
+	db := dbm.NewMemDB()
+	hashOf := func(s string) common.Hash { return common.BytesToHash([]byte(s)) }
+
+	for height := uint64(1); height <= 3; height++ {
+		blockHash := hashOf(string(rune('a' + height)))
+		ethTx, stdTx := hashOf(string(rune('k'+height))), hashOf(string(rune('u'+height)))
+		setWatchMessage(db, NewMsgBlock(Block{Number: hexutil.Uint64(height), Hash: blockHash,
+			Transactions: []common.Hash{ethTx}}))
+		setWatchMessage(db, NewMsgBlockInfo(height, blockHash))
+		setWatchMessage(db, NewMsgBlockStdTxHash([]common.Hash{stdTx}, blockHash))
+		db.Set(append(prefixTx, ethTx.Bytes()...), []byte("tx"))
+		db.Set(append(prefixReceipt, ethTx.Bytes()...), []byte("receipt"))
+		db.Set(append(prefixTxResponse, stdTx.Bytes()...), []byte("response"))
+		setWatchMessage(db, NewMsgCode(common.BytesToAddress([]byte{byte(height)}), []byte{0x1}, height))
+	}
+	setWatchMessage(db, NewMsgLatestHeight(3))
+	db.Set(GetMsgAccountKey([]byte("addr")), []byte("account"))
+
+	require.NoError(t, RollbackWatchDB(db, 1))
+
+	q := Querier{store: &WatchStore{db: db}, sw: true}
+	latest, err := q.GetLatestBlockNumber()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), latest)
+	_, err = q.GetBlockByNumber(1, false)
+	require.NoError(t, err)
+	_, err = q.GetCode(common.BytesToAddress([]byte{1}), 0)
+	require.NoError(t, err)
+	for height := uint64(2); height <= 3; height++ {
+		_, err = q.GetBlockByNumber(height, false)
+		require.Error(t, err)
+		_, err = q.GetCode(common.BytesToAddress([]byte{byte(height)}), 0)
+		require.Error(t, err)
+		ethTx, stdTx := hashOf(string(rune('k'+height))), hashOf(string(rune('u'+height)))
+		for _, key := range [][]byte{append(prefixTx, ethTx.Bytes()...), append(prefixReceipt, ethTx.Bytes()...),
+			append(prefixTxResponse, stdTx.Bytes()...)} {
+			ok, err := db.Has(key)
+			require.NoError(t, err)
+			require.False(t, ok)
+		}
+	}
+	ok, err := db.Has(append(prefixTx, hashOf(string(rune('k'+1))).Bytes()...))
+	require.NoError(t, err)
+	require.True(t, ok)
+	ok, err = db.Has(GetMsgAccountKey([]byte("addr")))
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// nothing after the latest height
+	require.NoError(t, RollbackWatchDB(db, 5))
+	latest, err = q.GetLatestBlockNumber()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), latest)
+}
@@ -5,15 +5,20 @@ import (
 
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 	"github.com/okex/exchain/x/farm/keeper"
 	"github.com/okex/exchain/x/farm/types"
 )
 
-// BeginBlocker allocates the native token to the pools in PoolsYieldNativeToken
-// according to the value of locked token in pool
+// BeginBlocker drops the weight of the lock infos whose lock duration ended to 1:1, then allocates the native
+// token to the pools in PoolsYieldNativeToken according to the value of locked token in pool
 func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock, k keeper.Keeper) {
 	logger := k.Logger(ctx)
 
+	if tmtypes.HigherThanJupiter(ctx.BlockHeight()) {
+		k.ExpireLockTiers(ctx)
+	}
+
 	moduleAcc := k.SupplyKeeper().GetModuleAccount(ctx, MintFarmingAccount)
 	yieldedNativeTokenAmt := moduleAcc.GetCoins().AmountOf(sdk.DefaultBondDenom)
 	logger.Debug(fmt.Sprintf("MintFarmingAccount [%s] balance: %s%s",
@@ -6,6 +6,7 @@ import (
 	interfacetypes "github.com/okex/exchain/libs/cosmos-sdk/codec/types"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/okex/exchain/libs/cosmos-sdk/client/context"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
@@ -21,6 +22,10 @@ import (
 	"github.com/okex/exchain/x/farm/types"
 )
 
+const (
+	flagLockDuration = "lock-duration"
+)
+
 // GetTxCmd returns the transaction commands for this module
 func GetTxCmd(cdc *codec.Codec) *cobra.Command {
 	farmTxCmd := &cobra.Command{
@@ -137,15 +142,19 @@ $ %s tx farm provide pool-eth-xxb 1000xxb 5 10000 --from mykey
 }
 
 func GetCmdLock(cdc *codec.Codec) *cobra.Command {
+	var lockDuration time.Duration
 	cmd := &cobra.Command{
 		Use:   "lock [pool-name] [amount]",
 		Short: "lock a number of tokens for yield farming",
 		Long: strings.TrimSpace(
 			fmt.Sprintf(`Lock a number of tokens for yield farming.
+The lock duration commits all the tokens locked in the pool to one of the lock tiers in the params, which multiplies
+their weight in the rewards. Unlocking before the lock duration ends is charged with the early unlock penalty.
 
 Example:
 $ %s tx farm lock pool-eth-xxb 5eth --from mykey
-`, version.ClientName),
+$ %s tx farm lock pool-eth-xxb 5eth --lock-duration 720h --from mykey
+`, version.ClientName, version.ClientName),
 		),
 		Args: cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -159,10 +168,11 @@ $ %s tx farm lock pool-eth-xxb 5eth --from mykey
 			}
 
 			poolName := args[0]
-			msg := types.NewMsgLock(poolName, cliCtx.GetFromAddress(), amount)
+			msg := types.NewMsgLock(poolName, cliCtx.GetFromAddress(), amount, lockDuration)
 			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
+	cmd.Flags().DurationVar(&lockDuration, flagLockDuration, 0, "Lock tier to commit the locked tokens to, such as \"168h\". Zero keeps the current one")
 	return cmd
 }
 
@@ -5,6 +5,7 @@ package farm
 
 import (
 	"testing"
+	"time"
 
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	"github.com/okex/exchain/x/farm/keeper"
@@ -34,6 +35,9 @@ func TestInitAndExportGenesis(t *testing.T) {
 			Amount:           sdk.NewDecCoinFromDec(poolMsg.MinLockAmount.Denom, sdk.NewDec(1)),
 			StartBlockHeight: 10,
 			ReferencePeriod:  1,
+			LockDuration:     7 * 24 * time.Hour,
+			UnlockTime:       604800,
+			Multiplier:       sdk.NewDecWithPrec(125, 2),
 		},
 	}
 	defaultGenesisState.PoolCurrentRewards = []types.PoolCurrentRewardsRecord{
@@ -7,6 +7,7 @@ import (
 	"github.com/okex/exchain/x/common"
 
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 
 	"github.com/okex/exchain/x/common/perf"
 	"github.com/okex/exchain/x/farm/keeper"
@@ -83,26 +84,34 @@ func handleMsgProvide(ctx sdk.Context, k keeper.Keeper, msg types.MsgProvide) (*
 		return types.ErrInvalidPoolOwner(msg.Address.String(), msg.PoolName).Result()
 	}
 
-	// 1.3 Check if the provided coin denom is the same as the locked coin name
-	if len(pool.YieldedTokenInfos) != 1 {
-		panic(fmt.Sprintf("The YieldedTokenInfos length is %d, which should be 1 in current code version",
-			len(pool.YieldedTokenInfos)))
-	}
-	if pool.YieldedTokenInfos[0].RemainingAmount.Denom != msg.Amount.Denom {
-		return types.ErrInvalidDenom(pool.YieldedTokenInfos[0].RemainingAmount.Denom, msg.Amount.Denom).Result()
+	// 1.3 Find the reward stream of the provided coin, a new one is started if there is none.
+	// Before the Jupiter height a pool yields only one token, whose remaining amount must be yielded before
+	// it is provided again
+	isJupiter := tmtypes.HigherThanJupiter(ctx.BlockHeight())
+	index := pool.GetYieldedTokenInfoIndex(msg.Amount.Denom)
+	if !isJupiter {
+		if len(pool.YieldedTokenInfos) != 1 {
+			panic(fmt.Sprintf("The YieldedTokenInfos length is %d, which should be 1 in current code version",
+				len(pool.YieldedTokenInfos)))
+		}
+		if pool.YieldedTokenInfos[0].RemainingAmount.Denom != msg.Amount.Denom {
+			return types.ErrInvalidDenom(pool.YieldedTokenInfos[0].RemainingAmount.Denom, msg.Amount.Denom).Result()
+		}
+	} else if index < 0 && len(pool.YieldedTokenInfos) >= types.MaxYieldedTokenInfos {
+		return types.ErrTooManyYieldedTokens(pool.Name, types.MaxYieldedTokenInfos).Result()
 	}
 
-	// 2.1 Calculate how many provided token & native token could be yielded in current period
+	// 2. Calculate how many provided token & native token could be yielded in current period
 	updatedPool, yieldedTokens := k.CalculateAmountYieldedBetween(ctx, pool)
-
-	// 2.2 Check if remaining amount is already zero
-	remainingAmount := updatedPool.YieldedTokenInfos[0].RemainingAmount
-	if !remainingAmount.IsZero() {
-		return types.ErrRemainingAmountNotZero(remainingAmount.String()).Result()
+	if !isJupiter {
+		remainingAmount := updatedPool.YieldedTokenInfos[0].RemainingAmount
+		if !remainingAmount.IsZero() {
+			return types.ErrRemainingAmountNotZero(remainingAmount.String()).Result()
+		}
 	}
 
 	// 3. Terminate pool current period
-	k.IncrementPoolPeriod(ctx, pool.Name, pool.TotalValueLocked, yieldedTokens)
+	k.IncrementPoolPeriod(ctx, pool.Name, pool.TotalWeightLocked(), yieldedTokens)
 
 	// 4. Transfer coin to farm module account
 	if err := k.SupplyKeeper().SendCoinsFromAccountToModule(
@@ -111,10 +120,21 @@ func handleMsgProvide(ctx sdk.Context, k keeper.Keeper, msg types.MsgProvide) (*
 		return types.ErrSendCoinsFromAccountToModuleFailed(err.Error()).Result()
 	}
 
-	// 5. init a new yielded_token_info struct, then set it into store
-	updatedPool.YieldedTokenInfos[0] = types.NewYieldedTokenInfo(
-		msg.Amount, msg.StartHeightToYield, msg.AmountYieldedPerBlock,
-	)
+	// 5. top up the remaining amount of the reward stream with the new yielding schedule, then set it into store
+	if !isJupiter {
+		updatedPool.YieldedTokenInfos[0] = types.NewYieldedTokenInfo(
+			msg.Amount, msg.StartHeightToYield, msg.AmountYieldedPerBlock,
+		)
+	} else if index < 0 {
+		updatedPool.YieldedTokenInfos = append(updatedPool.YieldedTokenInfos, types.NewYieldedTokenInfo(
+			msg.Amount, msg.StartHeightToYield, msg.AmountYieldedPerBlock,
+		))
+	} else {
+		updatedPool.YieldedTokenInfos[index] = types.NewYieldedTokenInfo(
+			updatedPool.YieldedTokenInfos[index].RemainingAmount.Add(msg.Amount),
+			msg.StartHeightToYield, msg.AmountYieldedPerBlock,
+		)
+	}
 	k.SetFarmPool(ctx, updatedPool)
 
 	ctx.EventManager().EmitEvent(sdk.NewEvent(
@@ -139,7 +159,7 @@ func handleMsgClaim(ctx sdk.Context, k keeper.Keeper, msg types.MsgClaim) (*sdk.
 	updatedPool, yieldedTokens := k.CalculateAmountYieldedBetween(ctx, pool)
 
 	// 3. Withdraw rewards
-	rewards, err := k.WithdrawRewards(ctx, pool.Name, pool.TotalValueLocked, yieldedTokens, msg.Address)
+	rewards, err := k.WithdrawRewards(ctx, pool.Name, pool.TotalWeightLocked(), yieldedTokens, msg.Address)
 	if err != nil {
 		return nil, err
 	}
@@ -2,6 +2,7 @@ package farm
 
 import (
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 	"github.com/okex/exchain/x/farm/keeper"
 	"github.com/okex/exchain/x/farm/types"
 )
@@ -17,11 +18,34 @@ func handleMsgLock(ctx sdk.Context, k keeper.Keeper, msg types.MsgLock) (*sdk.Re
 	}
 
 	// 1.2. check min lock amount
-	hasLocked := k.HasLockInfo(ctx, msg.Address, msg.PoolName)
+	var lockInfo types.LockInfo
+	var hasLocked bool
+	if tmtypes.HigherThanJupiter(ctx.BlockHeight()) {
+		lockInfo, hasLocked = k.GetLockInfo(ctx, msg.Address, msg.PoolName)
+	} else {
+		hasLocked = k.HasLockInfo(ctx, msg.Address, msg.PoolName)
+	}
 	if !hasLocked && msg.Amount.Amount.LT(pool.MinLockAmount.Amount) {
 		return types.ErrLockAmountBelowMinimum(pool.MinLockAmount.Amount, msg.Amount.Amount).Result()
 	}
 
+	// 1.3 check lock tier, the locked tokens can't be committed to a shorter time than they already are.
+	// The tokens added to a boosted lock are committed again with all of them, so that they can't gain the boost
+	// without being locked for the duration of the tier
+	var tier types.LockTier
+	if msg.LockDuration != 0 {
+		var err sdk.Error
+		if tier, err = k.GetLockTier(ctx, msg.LockDuration); err != nil {
+			return nil, err
+		}
+		newUnlockTime := lockInfo.WithLockTier(tier, ctx.BlockTime().Unix()).UnlockTime
+		if hasLocked && newUnlockTime < lockInfo.UnlockTime {
+			return types.ErrLockDurationShortened(lockInfo.UnlockTime, newUnlockTime).Result()
+		}
+	} else if lockInfo.IsBoosted() {
+		return types.ErrLockDurationRequired(lockInfo.LockDuration).Result()
+	}
+
 	// 2. Calculate how many provided token & native token could be yielded in current period
 	updatedPool, yieldedTokens := k.CalculateAmountYieldedBetween(ctx, pool)
 
@@ -30,7 +54,7 @@ func handleMsgLock(ctx sdk.Context, k keeper.Keeper, msg types.MsgLock) (*sdk.Re
 	if hasLocked {
 		// If it exists, withdraw money
 		var err error
-		rewards, err = k.WithdrawRewards(ctx, pool.Name, pool.TotalValueLocked, yieldedTokens, msg.Address)
+		rewards, err = k.WithdrawRewards(ctx, pool.Name, pool.TotalWeightLocked(), yieldedTokens, msg.Address)
 		if err != nil {
 			return nil, err
 		}
@@ -41,10 +65,10 @@ func handleMsgLock(ctx sdk.Context, k keeper.Keeper, msg types.MsgLock) (*sdk.Re
 
 	} else {
 		// If it doesn't exist, only increase period
-		k.IncrementPoolPeriod(ctx, pool.Name, pool.TotalValueLocked, yieldedTokens)
+		k.IncrementPoolPeriod(ctx, pool.Name, pool.TotalWeightLocked(), yieldedTokens)
 
 		// Create new lock info
-		lockInfo := types.NewLockInfo(
+		lockInfo = types.NewLockInfo(
 			msg.Address, pool.Name, sdk.NewDecCoinFromDec(pool.MinLockAmount.Denom, sdk.ZeroDec()),
 			ctx.BlockHeight(), 0,
 		)
@@ -53,6 +77,9 @@ func handleMsgLock(ctx sdk.Context, k keeper.Keeper, msg types.MsgLock) (*sdk.Re
 	}
 
 	// 4. Update lock info
+	if msg.LockDuration != 0 {
+		k.SetLockTier(ctx, msg.Address, msg.PoolName, tier)
+	}
 	k.UpdateLockInfo(ctx, msg.Address, msg.PoolName, msg.Amount.Amount)
 
 	// 5. Send the locked-tokens from its own account to farm module account
@@ -64,6 +91,10 @@ func handleMsgLock(ctx sdk.Context, k keeper.Keeper, msg types.MsgLock) (*sdk.Re
 
 	// 6. Update farm pool
 	updatedPool.TotalValueLocked = updatedPool.TotalValueLocked.Add(msg.Amount)
+	if tmtypes.HigherThanJupiter(ctx.BlockHeight()) {
+		updatedPool.TotalBoostWeight = updatedPool.GetTotalBoostWeight().Sub(lockInfo.BoostWeight()).
+			Add(k.GetLockBoostWeight(ctx, msg.Address, msg.PoolName))
+	}
 	k.SetFarmPool(ctx, updatedPool)
 
 	// 7. notify backend
@@ -76,6 +107,7 @@ func handleMsgLock(ctx sdk.Context, k keeper.Keeper, msg types.MsgLock) (*sdk.Re
 		sdk.NewAttribute(types.AttributeKeyAddress, msg.Address.String()),
 		sdk.NewAttribute(types.AttributeKeyPool, msg.PoolName),
 		sdk.NewAttribute(sdk.AttributeKeyAmount, msg.Amount.String()),
+		sdk.NewAttribute(types.AttributeKeyLockDuration, msg.LockDuration.String()),
 	))
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
@@ -112,7 +144,7 @@ func handleMsgUnlock(ctx sdk.Context, k keeper.Keeper, msg types.MsgUnlock) (*sd
 	updatedPool, yieldedTokens := k.CalculateAmountYieldedBetween(ctx, pool)
 
 	// 3. Withdraw money
-	rewards, err := k.WithdrawRewards(ctx, pool.Name, pool.TotalValueLocked, yieldedTokens, msg.Address)
+	rewards, err := k.WithdrawRewards(ctx, pool.Name, pool.TotalWeightLocked(), yieldedTokens, msg.Address)
 	if err != nil {
 		return nil, err
 	}
@@ -120,13 +152,31 @@ func handleMsgUnlock(ctx sdk.Context, k keeper.Keeper, msg types.MsgUnlock) (*sd
 	// 4. Update the lock info
 	k.UpdateLockInfo(ctx, msg.Address, msg.PoolName, msg.Amount.Amount.Neg())
 
-	// 5. Send the locked-tokens from farm module account to its own account
-	if err = k.SupplyKeeper().SendCoinsFromModuleToAccount(ctx, ModuleName, msg.Address, msg.Amount.ToCoins()); err != nil {
-		return nil, types.ErrSendCoinsFromModuleToAccountFailed(err.Error())
+	// 5.1 Charge the penalty to the fee collector if the lock duration hasn't ended
+	penalty := k.GetEarlyUnlockPenalty(ctx, lockInfo, msg.Amount)
+	if penalty.IsPositive() {
+		if err = k.SupplyKeeper().SendCoinsFromModuleToModule(
+			ctx, ModuleName, k.GetFeeCollector(), penalty.ToCoins(),
+		); err != nil {
+			return nil, types.ErrSendCoinsFromModuleToAccountFailed(err.Error())
+		}
+	}
+
+	// 5.2 Send the rest of the locked-tokens from farm module account to its own account
+	if unlocked := msg.Amount.Sub(penalty); unlocked.IsPositive() {
+		if err = k.SupplyKeeper().SendCoinsFromModuleToAccount(
+			ctx, ModuleName, msg.Address, unlocked.ToCoins(),
+		); err != nil {
+			return nil, types.ErrSendCoinsFromModuleToAccountFailed(err.Error())
+		}
 	}
 
 	// 6. Update farm pool
 	updatedPool.TotalValueLocked = updatedPool.TotalValueLocked.Sub(msg.Amount)
+	if tmtypes.HigherThanJupiter(ctx.BlockHeight()) {
+		updatedPool.TotalBoostWeight = updatedPool.GetTotalBoostWeight().Sub(lockInfo.BoostWeight()).
+			Add(k.GetLockBoostWeight(ctx, msg.Address, msg.PoolName))
+	}
 	if updatedPool.TotalAccumulatedRewards.IsAllLT(rewards) {
 		panic("should not happen")
 	}
@@ -141,6 +191,7 @@ func handleMsgUnlock(ctx sdk.Context, k keeper.Keeper, msg types.MsgUnlock) (*sd
 		sdk.NewAttribute(types.AttributeKeyAddress, msg.Address.String()),
 		sdk.NewAttribute(types.AttributeKeyPool, msg.PoolName),
 		sdk.NewAttribute(sdk.AttributeKeyAmount, msg.Amount.String()),
+		sdk.NewAttribute(types.AttributeKeyPenalty, penalty.String()),
 	))
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
@@ -9,11 +9,13 @@ import (
 	"math"
 	"math/rand"
 	"testing"
+	"time"
 
 	"github.com/okex/exchain/x/common"
 
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 	swap "github.com/okex/exchain/x/ammswap"
 	swaptypes "github.com/okex/exchain/x/ammswap/types"
 	"github.com/okex/exchain/x/farm/keeper"
@@ -160,7 +162,7 @@ var normalGetLockMsg getMsgFunc = func(tCtx *testContext, preData interface{}) s
 	poolName := createPoolMsg.PoolName
 	address := createPoolMsg.Owner
 	amount := sdk.NewDecCoinFromDec(createPoolMsg.MinLockAmount.Denom, sdk.NewDec(1))
-	lockMsg := types.NewMsgLock(poolName, address, amount)
+	lockMsg := types.NewMsgLock(poolName, address, amount, 0)
 	return lockMsg
 }
 
@@ -521,18 +523,42 @@ func TestHandlerMsgProvide(t *testing.T) {
 			expectedErr:  types.ErrNoFarmPoolFound("abc"),
 		},
 		{
-			caseName: "failed. The coin name should be %s, not %s",
+			caseName: "success. start a new reward stream with another token",
 			preExec:  preExec,
 			getMsg: func(tCtx *testContext, preData interface{}) sdk.Msg {
 				provideMsg := normalGetProvideMsg(tCtx, preData).(types.MsgProvide)
-				provideMsg.Amount = sdk.NewDecCoinFromDec(tCtx.nonExistTokenName[0], provideMsg.Amount.Amount)
+				provideMsg.Amount = sdk.NewDecCoinFromDec("ccb", provideMsg.Amount.Amount)
 				return provideMsg
 			},
+			verification: func(t *testing.T, tCtx *testContext, err sdk.Error, testCase testCaseItem, preCoins, afterCoins sdk.SysCoins, preData interface{}) {
+				require.Nil(t, err)
+				pool, found := tCtx.k.GetFarmPool(tCtx.ctx, preData.(types.MsgCreatePool).PoolName)
+				require.True(t, found)
+				require.Equal(t, 2, len(pool.YieldedTokenInfos))
+				require.Equal(t, sdk.NewDecCoinFromDec("ccb", sdk.NewDec(10)), pool.YieldedTokenInfos[1].RemainingAmount)
+			},
+			expectedErr: nil,
+		},
+		{
+			caseName: "failed. The pool already yields the maximum number of tokens",
+			preExec: func(t *testing.T, tCtx *testContext) interface{} {
+				createPoolMsg := createPool(t, tCtx)
+				pool, found := tCtx.k.GetFarmPool(tCtx.ctx, createPoolMsg.PoolName)
+				require.True(t, found)
+				pool.YieldedTokenInfos = types.YieldedTokenInfos{}
+				for i := 0; i < types.MaxYieldedTokenInfos; i++ {
+					pool.YieldedTokenInfos = append(pool.YieldedTokenInfos, types.NewYieldedTokenInfo(
+						sdk.NewDecCoinFromDec(fmt.Sprintf("token%d", i), sdk.ZeroDec()), 0, sdk.ZeroDec()))
+				}
+				tCtx.k.SetFarmPool(tCtx.ctx, pool)
+				return createPoolMsg
+			},
+			getMsg:       normalGetProvideMsg,
 			verification: verification,
-			expectedErr:  types.ErrInvalidDenom("aab", "fff"),
+			expectedErr:  types.ErrTooManyYieldedTokens("abc", types.MaxYieldedTokenInfos),
 		},
 		{
-			caseName: "failed. The remaining amount is %s, so it's not enable to provide token repeatedly util amount become zero",
+			caseName: "success. top up the remaining amount of the reward stream",
 			preExec: func(t *testing.T, tCtx *testContext) interface{} {
 				// create pool
 				createPoolMsg := createPool(t, tCtx)
@@ -541,9 +567,15 @@ func TestHandlerMsgProvide(t *testing.T) {
 				provide(t, tCtx, createPoolMsg)
 				return createPoolMsg
 			},
-			getMsg:       normalGetProvideMsg,
-			verification: verification,
-			expectedErr:  types.ErrRemainingAmountNotZero("10.000000000000000000aab"),
+			getMsg: normalGetProvideMsg,
+			verification: func(t *testing.T, tCtx *testContext, err sdk.Error, testCase testCaseItem, preCoins, afterCoins sdk.SysCoins, preData interface{}) {
+				require.Nil(t, err)
+				pool, found := tCtx.k.GetFarmPool(tCtx.ctx, preData.(types.MsgCreatePool).PoolName)
+				require.True(t, found)
+				require.Equal(t, 1, len(pool.YieldedTokenInfos))
+				require.Equal(t, sdk.NewDecCoinFromDec("aab", sdk.NewDec(20)), pool.YieldedTokenInfos[0].RemainingAmount)
+			},
+			expectedErr: nil,
 		},
 		{
 			caseName: "insufficient amount",
@@ -1166,6 +1198,100 @@ func TestHandlerMultiLockAndUnlock(t *testing.T) {
 	require.Equal(t, 0, numLockInfo)
 }
 
+func TestHandlerLockTier(t *testing.T) {
+	tmtypes.UnittestOnlySetMilestoneJupiterHeight(1)
+	defer tmtypes.UnittestOnlySetMilestoneJupiterHeight(0)
+	tCtx := initEnvironment(t)
+	tCtx.ctx.SetBlockTime(time.Unix(1000000, 0))
+
+	// create pool
+	createPoolMsg := createPool(t, tCtx)
+	poolName := createPoolMsg.PoolName
+	lockDenom := createPoolMsg.MinLockAmount.Denom
+
+	// provide 1aab per block from the next block
+	provide(t, tCtx, createPoolMsg)
+
+	// the owner locks without a lock tier, the other one commits to 30 days for x1.5 weight
+	lock(t, tCtx, createPoolMsg)
+	tierAddr := tCtx.addrList[0]
+	lockMsg := types.NewMsgLock(poolName, tierAddr, sdk.NewDecCoinFromDec(lockDenom, sdk.NewDec(1)), 30*24*time.Hour)
+	_, err := tCtx.handler(tCtx.ctx, lockMsg)
+	require.Nil(t, err)
+
+	lockInfo, found := tCtx.k.GetLockInfo(tCtx.ctx, tierAddr, poolName)
+	require.True(t, found)
+	require.Equal(t, sdk.NewDecWithPrec(15, 1), lockInfo.Multiplier)
+	require.Equal(t, tCtx.ctx.BlockTime().Unix()+30*24*3600, lockInfo.UnlockTime)
+	pool, found := tCtx.k.GetFarmPool(tCtx.ctx, poolName)
+	require.True(t, found)
+	require.Equal(t, sdk.NewDecWithPrec(5, 1), pool.TotalBoostWeight)
+	require.Equal(t, sdk.NewDecWithPrec(25, 1), pool.TotalWeightLocked().Amount)
+
+	// 4aab yielded from height 11 to 15 are distributed by the lock weight 1 : 1.5
+	tCtx.ctx.SetBlockHeight(tCtx.ctx.BlockHeight() + 5)
+	cacheCtx, _ := tCtx.ctx.CacheContext()
+	earnings, err := tCtx.k.GetEarnings(cacheCtx, poolName, createPoolMsg.Owner)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewDecCoinsFromDec("aab", sdk.NewDecWithPrec(16, 1)), earnings.AmountYielded)
+	cacheCtx, _ = tCtx.ctx.CacheContext()
+	earnings, err = tCtx.k.GetEarnings(cacheCtx, poolName, tierAddr)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewDecCoinsFromDec("aab", sdk.NewDecWithPrec(24, 1)), earnings.AmountYielded)
+
+	// the lock duration can't be shortened, and must be one of the lock tiers
+	lockMsg.LockDuration = 7 * 24 * time.Hour
+	_, err = tCtx.handler(tCtx.ctx, lockMsg)
+	require.Equal(t, types.ErrLockDurationShortened(lockInfo.UnlockTime, tCtx.ctx.BlockTime().Unix()+7*24*3600).Error(), err.Error())
+	lockMsg.LockDuration = time.Hour
+	_, err = tCtx.handler(tCtx.ctx, lockMsg)
+	require.Equal(t, types.ErrInvalidLockDuration(time.Hour).Error(), err.Error())
+
+	// locking more into a boosted lock must commit to the lock tier again
+	lockMsg.LockDuration = 0
+	_, err = tCtx.handler(tCtx.ctx, lockMsg)
+	require.Equal(t, types.ErrLockDurationRequired(30*24*time.Hour).Error(), err.Error())
+	lockMsg.LockDuration = 30 * 24 * time.Hour
+	_, err = tCtx.handler(tCtx.ctx, lockMsg)
+	require.Nil(t, err)
+	pool, _ = tCtx.k.GetFarmPool(tCtx.ctx, poolName)
+	require.Equal(t, sdk.NewDec(1), pool.TotalBoostWeight)
+
+	// unlocking before the lock duration ends is charged with the early unlock penalty
+	feeCollector := tCtx.k.SupplyKeeper().GetModuleAccount(tCtx.ctx, tCtx.k.GetFeeCollector())
+	preFees := feeCollector.GetCoins().AmountOf(lockDenom)
+	preCoins := tCtx.k.TokenKeeper().GetCoins(tCtx.ctx, tierAddr).AmountOf(lockDenom)
+	unlockMsg := types.NewMsgUnlock(poolName, tierAddr, sdk.NewDecCoinFromDec(lockDenom, sdk.NewDec(1)))
+	_, err = tCtx.handler(tCtx.ctx, unlockMsg)
+	require.Nil(t, err)
+	feeCollector = tCtx.k.SupplyKeeper().GetModuleAccount(tCtx.ctx, tCtx.k.GetFeeCollector())
+	require.Equal(t, sdk.NewDecWithPrec(1, 1), feeCollector.GetCoins().AmountOf(lockDenom).Sub(preFees))
+	require.Equal(t, sdk.NewDecWithPrec(9, 1), tCtx.k.TokenKeeper().GetCoins(tCtx.ctx, tierAddr).AmountOf(lockDenom).Sub(preCoins))
+	pool, _ = tCtx.k.GetFarmPool(tCtx.ctx, poolName)
+	require.Equal(t, sdk.NewDecWithPrec(5, 1), pool.TotalBoostWeight)
+
+	// the weight drops to 1:1 when the lock duration ends, with the rewards of the boosted weight withdrawn
+	tCtx.ctx.SetBlockTime(time.Unix(lockInfo.UnlockTime, 0))
+	tCtx.ctx.SetBlockHeight(tCtx.ctx.BlockHeight() + 1)
+	preRewards := tCtx.k.TokenKeeper().GetCoins(tCtx.ctx, tierAddr).AmountOf("aab")
+	tCtx.k.ExpireLockTiers(tCtx.ctx)
+	require.True(t, tCtx.k.TokenKeeper().GetCoins(tCtx.ctx, tierAddr).AmountOf("aab").GT(preRewards))
+	lockInfo, _ = tCtx.k.GetLockInfo(tCtx.ctx, tierAddr, poolName)
+	require.Equal(t, sdk.OneDec(), lockInfo.Multiplier)
+	pool, _ = tCtx.k.GetFarmPool(tCtx.ctx, poolName)
+	require.True(t, pool.TotalBoostWeight.IsZero())
+
+	// no penalty after the lock duration ends
+	preCoins = tCtx.k.TokenKeeper().GetCoins(tCtx.ctx, tierAddr).AmountOf(lockDenom)
+	_, err = tCtx.handler(tCtx.ctx, unlockMsg)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewDec(1), tCtx.k.TokenKeeper().GetCoins(tCtx.ctx, tierAddr).AmountOf(lockDenom).Sub(preCoins))
+	require.False(t, tCtx.k.HasLockInfo(tCtx.ctx, tierAddr, poolName))
+	pool, _ = tCtx.k.GetFarmPool(tCtx.ctx, poolName)
+	require.True(t, pool.TotalBoostWeight.IsZero())
+	require.Equal(t, pool.TotalValueLocked, pool.TotalWeightLocked())
+}
+
 func TestHandlerRandom(t *testing.T) {
 	tCtx := initEnvironment(t)
 
@@ -47,7 +47,7 @@ func (k Keeper) CalculateAmountYieldedBetween(ctx sdk.Context, pool types.FarmPo
 }
 
 func (k Keeper) WithdrawRewards(
-	ctx sdk.Context, poolName string, totalValueLocked sdk.SysCoin, yieldedTokens sdk.SysCoins, addr sdk.AccAddress,
+	ctx sdk.Context, poolName string, totalWeightLocked sdk.SysCoin, yieldedTokens sdk.SysCoins, addr sdk.AccAddress,
 ) (sdk.SysCoins, sdk.Error) {
 	// 0. check existence of lock info
 	lockInfo, found := k.GetLockInfo(ctx, addr, poolName)
@@ -56,7 +56,7 @@ func (k Keeper) WithdrawRewards(
 	}
 
 	// 1. end current period and calculate rewards
-	endingPeriod := k.IncrementPoolPeriod(ctx, poolName, totalValueLocked, yieldedTokens)
+	endingPeriod := k.IncrementPoolPeriod(ctx, poolName, totalWeightLocked, yieldedTokens)
 	rewards := k.calculateRewards(ctx, poolName, addr, endingPeriod, lockInfo)
 
 	// 2. transfer rewards to user account
@@ -73,19 +73,20 @@ func (k Keeper) WithdrawRewards(
 	return rewards, nil
 }
 
-// IncrementPoolPeriod increments pool period, returning the period just ended
+// IncrementPoolPeriod increments pool period, returning the period just ended.
+// The rewards of the period are distributed by the lock weight, which is the locked amount multiplied by the lock tier.
 func (k Keeper) IncrementPoolPeriod(
-	ctx sdk.Context, poolName string, totalValueLocked sdk.SysCoin, yieldedTokens sdk.SysCoins,
+	ctx sdk.Context, poolName string, totalWeightLocked sdk.SysCoin, yieldedTokens sdk.SysCoins,
 ) uint64 {
 	// 1. fetch current period rewards
 	rewards := k.GetPoolCurrentRewards(ctx, poolName)
 	// 2. calculate current reward ratio
 	rewards.Rewards = rewards.Rewards.Add2(yieldedTokens)
 	var currentRatio sdk.SysCoins
-	if totalValueLocked.IsZero() {
+	if totalWeightLocked.IsZero() {
 		currentRatio = sdk.SysCoins{}
 	} else {
-		currentRatio = rewards.Rewards.QuoDecTruncate(totalValueLocked.Amount)
+		currentRatio = rewards.Rewards.QuoDecTruncate(totalWeightLocked.Amount)
 	}
 
 	// 3.1 get the previous pool historical rewards
@@ -138,10 +139,10 @@ func (k Keeper) calculateRewards(
 
 	startingPeriod := lockInfo.ReferencePeriod
 	// calculate rewards for final period
-	return k.calculateLockRewardsBetween(ctx, poolName, startingPeriod, endingPeriod, lockInfo.Amount)
+	return k.calculateLockRewardsBetween(ctx, poolName, startingPeriod, endingPeriod, lockInfo.Weight())
 }
 
-// calculateLockRewardsBetween calculate the rewards accrued by a pool between two periods
+// calculateLockRewardsBetween calculate the rewards accrued by a lock weight between two periods
 func (k Keeper) calculateLockRewardsBetween(ctx sdk.Context, poolName string, startingPeriod, endingPeriod uint64,
 	amount sdk.SysCoin) (rewards sdk.SysCoins) {
 
@@ -2,12 +2,45 @@ package keeper
 
 import (
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 	swaptypes "github.com/okex/exchain/x/ammswap/types"
 	"github.com/okex/exchain/x/farm/types"
 )
 
+// farmPoolBeforeJupiter is the encoding of FarmPool before the Jupiter height, which has no lock tiers
+type farmPoolBeforeJupiter struct {
+	Owner                   sdk.AccAddress
+	Name                    string
+	MinLockAmount           sdk.SysCoin
+	DepositAmount           sdk.SysCoin
+	TotalValueLocked        sdk.SysCoin
+	YieldedTokenInfos       types.YieldedTokenInfos
+	TotalAccumulatedRewards sdk.SysCoins
+}
+
+// lockInfoBeforeJupiter is the encoding of LockInfo before the Jupiter height, which has no lock tiers
+type lockInfoBeforeJupiter struct {
+	Owner            sdk.AccAddress
+	PoolName         string
+	Amount           sdk.SysCoin
+	StartBlockHeight int64
+	ReferencePeriod  uint64
+}
+
 func (k Keeper) SetFarmPool(ctx sdk.Context, pool types.FarmPool) {
 	store := ctx.KVStore(k.storeKey)
+	if !tmtypes.HigherThanJupiter(ctx.BlockHeight()) {
+		store.Set(types.GetFarmPoolKey(pool.Name), k.cdc.MustMarshalBinaryLengthPrefixed(farmPoolBeforeJupiter{
+			Owner:                   pool.Owner,
+			Name:                    pool.Name,
+			MinLockAmount:           pool.MinLockAmount,
+			DepositAmount:           pool.DepositAmount,
+			TotalValueLocked:        pool.TotalValueLocked,
+			YieldedTokenInfos:       pool.YieldedTokenInfos,
+			TotalAccumulatedRewards: pool.TotalAccumulatedRewards,
+		}))
+		return
+	}
 	store.Set(types.GetFarmPoolKey(pool.Name), k.cdc.MustMarshalBinaryLengthPrefixed(pool))
 }
 
@@ -105,9 +138,26 @@ func (k Keeper) DeleteAddressInFarmPool(ctx sdk.Context, poolName string, addr s
 	store.Delete(types.GetAddressInFarmPoolKey(poolName, addr))
 }
 
+// SetLockInfo sets the lock info into store, along with the expiration of its lock tier
 func (k Keeper) SetLockInfo(ctx sdk.Context, lockInfo types.LockInfo) {
 	store := ctx.KVStore(k.storeKey)
+	if !tmtypes.HigherThanJupiter(ctx.BlockHeight()) {
+		store.Set(types.GetLockInfoKey(lockInfo.Owner, lockInfo.PoolName), k.cdc.MustMarshalBinaryLengthPrefixed(lockInfoBeforeJupiter{
+			Owner:            lockInfo.Owner,
+			PoolName:         lockInfo.PoolName,
+			Amount:           lockInfo.Amount,
+			StartBlockHeight: lockInfo.StartBlockHeight,
+			ReferencePeriod:  lockInfo.ReferencePeriod,
+		}))
+		return
+	}
+	if old, found := k.GetLockInfo(ctx, lockInfo.Owner, lockInfo.PoolName); found && old.IsBoosted() {
+		store.Delete(types.GetLockExpirationKey(old.UnlockTime, old.Owner, old.PoolName))
+	}
 	store.Set(types.GetLockInfoKey(lockInfo.Owner, lockInfo.PoolName), k.cdc.MustMarshalBinaryLengthPrefixed(lockInfo))
+	if lockInfo.IsBoosted() {
+		store.Set(types.GetLockExpirationKey(lockInfo.UnlockTime, lockInfo.Owner, lockInfo.PoolName), []byte(""))
+	}
 }
 
 func (k Keeper) GetLockInfo(ctx sdk.Context, addr sdk.AccAddress, poolName string) (info types.LockInfo, found bool) {
@@ -128,6 +178,13 @@ func (k Keeper) HasLockInfo(ctx sdk.Context, addr sdk.AccAddress, poolName strin
 
 func (k Keeper) DeleteLockInfo(ctx sdk.Context, addr sdk.AccAddress, poolName string) {
 	store := ctx.KVStore(k.storeKey)
+	if !tmtypes.HigherThanJupiter(ctx.BlockHeight()) {
+		store.Delete(types.GetLockInfoKey(addr, poolName))
+		return
+	}
+	if old, found := k.GetLockInfo(ctx, addr, poolName); found && old.IsBoosted() {
+		store.Delete(types.GetLockExpirationKey(old.UnlockTime, addr, poolName))
+	}
 	store.Delete(types.GetLockInfoKey(addr, poolName))
 }
 
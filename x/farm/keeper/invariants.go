@@ -11,6 +11,7 @@ func RegisterInvariants(ir sdk.InvariantRegistry, k Keeper) {
 	ir.RegisterRoute(types.ModuleName, "module-account", moduleAccountInvariant(k))
 	ir.RegisterRoute(types.ModuleName, "yield-farming-account", yieldFarmingAccountInvariant(k))
 	ir.RegisterRoute(types.ModuleName, "mint-farming-account", mintFarmingAccountInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "lock-weight", lockWeightInvariant(k))
 }
 
 // moduleAccountInvariant checks if farm ModuleAccount is consistent with the sum of deposit amount
@@ -94,3 +95,38 @@ func mintFarmingAccountInvariant(k Keeper) sdk.Invariant {
 				moduleAcc.GetCoins(), whiteLists)), broken
 	}
 }
+
+// lockWeightInvariant checks if the total value locked and the total boost weight of every pool are consistent
+// with the sum of its lock infos
+func lockWeightInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		totalLocked := make(map[string]sdk.Dec)
+		totalBoostWeight := make(map[string]sdk.Dec)
+		k.IterateAllLockInfos(ctx, func(lockInfo types.LockInfo) (stop bool) {
+			if _, ok := totalLocked[lockInfo.PoolName]; !ok {
+				totalLocked[lockInfo.PoolName] = sdk.ZeroDec()
+				totalBoostWeight[lockInfo.PoolName] = sdk.ZeroDec()
+			}
+			totalLocked[lockInfo.PoolName] = totalLocked[lockInfo.PoolName].Add(lockInfo.Amount.Amount)
+			totalBoostWeight[lockInfo.PoolName] = totalBoostWeight[lockInfo.PoolName].Add(lockInfo.BoostWeight())
+			return false
+		})
+
+		var msg string
+		broken := false
+		for _, pool := range k.GetFarmPools(ctx) {
+			locked, boostWeight := sdk.ZeroDec(), sdk.ZeroDec()
+			if _, ok := totalLocked[pool.Name]; ok {
+				locked, boostWeight = totalLocked[pool.Name], totalBoostWeight[pool.Name]
+			}
+			if !pool.TotalValueLocked.Amount.Equal(locked) || !pool.GetTotalBoostWeight().Equal(boostWeight) {
+				broken = true
+				msg += fmt.Sprintf("\tpool %s: expected total value locked %s, total boost weight %s\n"+
+					"\tactual total value locked %s, total boost weight %s\n",
+					pool.Name, locked, boostWeight, pool.TotalValueLocked.Amount, pool.GetTotalBoostWeight())
+			}
+		}
+
+		return sdk.FormatInvariant(types.ModuleName, "lock weight", msg), broken
+	}
+}
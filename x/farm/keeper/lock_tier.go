@@ -0,0 +1,112 @@
+package keeper
+
+import (
+	"time"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/farm/types"
+)
+
+// GetLockTier returns the lock tier of the lock duration in the params
+func (k Keeper) GetLockTier(ctx sdk.Context, duration time.Duration) (types.LockTier, sdk.Error) {
+	tier, found := k.GetParams(ctx).LockTiers.Find(duration)
+	if !found {
+		return tier, types.ErrInvalidLockDuration(duration)
+	}
+	return tier, nil
+}
+
+// SetLockTier commits all the tokens locked by addr in the pool to the lock tier from the current block time.
+// The rewards of the lock info must have been withdrawn before its weight changes.
+func (k Keeper) SetLockTier(ctx sdk.Context, addr sdk.AccAddress, poolName string, tier types.LockTier) {
+	lockInfo, found := k.GetLockInfo(ctx, addr, poolName)
+	if !found {
+		panic("the lock info can't be found")
+	}
+	k.SetLockInfo(ctx, lockInfo.WithLockTier(tier, ctx.BlockTime().Unix()))
+}
+
+// GetLockBoostWeight returns the weight that the lock info of addr in the pool gains from its lock tier
+func (k Keeper) GetLockBoostWeight(ctx sdk.Context, addr sdk.AccAddress, poolName string) sdk.Dec {
+	lockInfo, found := k.GetLockInfo(ctx, addr, poolName)
+	if !found {
+		return sdk.ZeroDec()
+	}
+	return lockInfo.BoostWeight()
+}
+
+// GetEarlyUnlockPenalty returns the part of amount charged for unlocking it from the lock info
+// before its lock duration ends
+func (k Keeper) GetEarlyUnlockPenalty(ctx sdk.Context, lockInfo types.LockInfo, amount sdk.SysCoin) sdk.SysCoin {
+	penalty := sdk.SysCoin{Denom: amount.Denom, Amount: sdk.ZeroDec()}
+	if !lockInfo.IsLocked(ctx.BlockTime().Unix()) {
+		return penalty
+	}
+	penalty.Amount = amount.Amount.MulTruncate(k.GetParams(ctx).EarlyUnlockPenaltyRate)
+	return penalty
+}
+
+// ExpireLockTiers drops the weight of the lock infos whose lock duration ended by the current block time to 1:1.
+// The rewards that the lock infos earned with the boosted weight are withdrawn first, and the total weight of
+// their pools is updated in the same step, so that no reward period is ever accounted with an expired multiplier.
+// A lock info failing to expire is logged and its expiration is dropped without changing anything else.
+func (k Keeper) ExpireLockTiers(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.Iterator(types.LockExpirationPrefix, types.GetLockExpirationTimeKey(ctx.BlockTime().Unix()+1))
+	var keys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	iterator.Close()
+
+	for _, key := range keys {
+		addr, poolName := types.SplitLockExpirationKey(key)
+		cacheCtx, write := ctx.CacheContext()
+		if err := k.expireLockTier(cacheCtx, addr, poolName); err != nil {
+			k.Logger(ctx).Error("failed to expire the lock tier", "address", addr, "pool", poolName, "err", err)
+			store.Delete(key)
+			continue
+		}
+		write()
+		ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+	}
+}
+
+func (k Keeper) expireLockTier(ctx sdk.Context, addr sdk.AccAddress, poolName string) error {
+	lockInfo, found := k.GetLockInfo(ctx, addr, poolName)
+	if !found {
+		return types.ErrNoLockInfoFound(addr.String(), poolName)
+	}
+	pool, found := k.GetFarmPool(ctx, poolName)
+	if !found {
+		return types.ErrNoFarmPoolFound(poolName)
+	}
+
+	// 1. withdraw the rewards earned with the boosted weight
+	updatedPool, yieldedTokens := k.CalculateAmountYieldedBetween(ctx, pool)
+	rewards, err := k.WithdrawRewards(ctx, pool.Name, pool.TotalWeightLocked(), yieldedTokens, addr)
+	if err != nil {
+		return err
+	}
+	if updatedPool.TotalAccumulatedRewards.IsAllLT(rewards) {
+		return types.ErrInsufficientAmount(updatedPool.TotalAccumulatedRewards.String(), rewards.String())
+	}
+	updatedPool.TotalAccumulatedRewards = updatedPool.TotalAccumulatedRewards.Sub(rewards)
+
+	// 2. weight the lock info 1:1 from the period just started
+	k.SetLockInfo(ctx, lockInfo.WithoutBoost())
+	k.UpdateLockInfo(ctx, addr, poolName, sdk.ZeroDec())
+
+	// 3. update the total weight of the pool
+	updatedPool.TotalBoostWeight = updatedPool.GetTotalBoostWeight().Sub(lockInfo.BoostWeight())
+	k.SetFarmPool(ctx, updatedPool)
+
+	k.OnClaim(ctx, addr, poolName, rewards)
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeExpireLockTier,
+		sdk.NewAttribute(types.AttributeKeyAddress, addr.String()),
+		sdk.NewAttribute(types.AttributeKeyPool, poolName),
+		sdk.NewAttribute(types.AttributeKeyClaimed, rewards.String()),
+	))
+	return nil
+}
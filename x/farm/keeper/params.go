@@ -2,16 +2,34 @@ package keeper
 
 import (
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 	"github.com/okex/exchain/x/farm/types"
 )
 
 // SetParams sets the farm parameters to the param space.
 func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
+	// the genesis of the chains started before the Jupiter height has no lock tier params
+	if !tmtypes.HigherThanJupiter(ctx.BlockHeight()) && params.EarlyUnlockPenaltyRate.IsNil() {
+		k.paramSubspace.SetParamSetForInitGenesis(ctx, &params, types.IgnoreInitGenesisList)
+		return
+	}
 	k.paramSubspace.SetParamSet(ctx, &params)
 }
 
 // GetParams returns the total set of farm parameters.
 func (k Keeper) GetParams(ctx sdk.Context) (params types.Params) {
-	k.paramSubspace.GetParamSet(ctx, &params)
+	params.EarlyUnlockPenaltyRate = sdk.ZeroDec()
+	if !tmtypes.HigherThanJupiter(ctx.BlockHeight()) {
+		k.paramSubspace.GetParamSetForInitGenesis(ctx, &params, types.IgnoreInitGenesisList)
+		return
+	}
+	k.paramSubspace.Get(ctx, types.KeyQuoteSymbol, &params.QuoteSymbol)
+	k.paramSubspace.Get(ctx, types.KeyCreatePoolFee, &params.CreatePoolFee)
+	k.paramSubspace.Get(ctx, types.KeyCreatePoolDeposit, &params.CreatePoolDeposit)
+	k.paramSubspace.Get(ctx, types.KeyYieldNativeToken, &params.YieldNativeToken)
+	// lock tiers and early unlock penalties stay disabled on chains started before their params existed,
+	// until the params are set
+	k.paramSubspace.GetIfExists(ctx, types.KeyLockTiers, &params.LockTiers)
+	k.paramSubspace.GetIfExists(ctx, types.KeyEarlyUnlockPenaltyRate, &params.EarlyUnlockPenaltyRate)
 	return
 }
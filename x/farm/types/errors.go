@@ -2,6 +2,7 @@ package types
 
 import (
 	"fmt"
+	"time"
 
 	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
 
@@ -35,6 +36,10 @@ const (
 	CodeLockAmountBelowMinimum             uint32 = 66019
 	CodeSendCoinsFromModuleToAccountFailed uint32 = 66020
 	CodeSwapTokenPairNotExist              uint32 = 66021
+	CodeInvalidLockDuration                uint32 = 66022
+	CodeLockDurationShortened              uint32 = 66023
+	CodeTooManyYieldedTokens               uint32 = 66024
+	CodeLockDurationRequired               uint32 = 66025
 )
 
 // ErrInvalidInput returns an error when an input parameter is invalid
@@ -149,3 +154,26 @@ func ErrSendCoinsFromModuleToAccountFailed(content string) sdk.EnvelopedErr {
 func ErrSwapTokenPairNotExist(tokenName string) sdk.EnvelopedErr {
 	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultParamspace, CodeSwapTokenPairNotExist, fmt.Sprintf("failed. swap token pair %s does not exist", tokenName))}
 }
+
+// ErrInvalidLockDuration returns an error when the lock duration isn't one of the lock tiers
+func ErrInvalidLockDuration(duration time.Duration) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultParamspace, CodeInvalidLockDuration, fmt.Sprintf("failed. lock duration %s is not one of the lock tiers", duration))}
+}
+
+// ErrLockDurationShortened returns an error when a lock would end earlier than the lock duration already committed to
+func ErrLockDurationShortened(unlockTime, newUnlockTime int64) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultParamspace, CodeLockDurationShortened,
+		fmt.Sprintf("failed. the locked tokens are committed until %d, which can not be shortened to %d", unlockTime, newUnlockTime))}
+}
+
+// ErrTooManyYieldedTokens returns an error when a pool already has the maximum number of yielded tokens
+func ErrTooManyYieldedTokens(poolName string, max int) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultParamspace, CodeTooManyYieldedTokens,
+		fmt.Sprintf("failed. pool %s already yields the maximum number of tokens %d", poolName, max))}
+}
+
+// ErrLockDurationRequired returns an error when tokens are added to a boosted lock without committing them to a lock tier
+func ErrLockDurationRequired(lockDuration time.Duration) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultParamspace, CodeLockDurationRequired,
+		fmt.Sprintf("failed. the locked tokens are boosted by the lock tier %s, the added tokens must be committed to a lock tier", lockDuration))}
+}
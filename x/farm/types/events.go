@@ -9,6 +9,8 @@ const (
 	EventTypeUnlock      = "unlock"
 	EventTypeClaim       = "claim"
 
+	EventTypeExpireLockTier = "expire-lock-tier"
+
 	AttributeKeyAddress             = "address"
 	AttributeKeyPool                = "pool"
 	AttributeKeyStartHeightToYield  = "start_height_to_yield"
@@ -18,6 +20,8 @@ const (
 	AttributeKeyDeposit             = "deposit"
 	AttributeKeyWithdraw            = "withdraw"
 	AttributeKeyClaimed             = "claimed"
+	AttributeKeyLockDuration        = "lock_duration"
+	AttributeKeyPenalty             = "penalty"
 
 	AttributeValueCategory = ModuleName
 )
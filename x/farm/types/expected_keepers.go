@@ -9,8 +9,11 @@ import (
 type ParamSubspace interface {
 	WithKeyTable(table params.KeyTable) params.Subspace
 	Get(ctx sdk.Context, key []byte, ptr interface{})
+	GetIfExists(ctx sdk.Context, key []byte, ptr interface{})
 	GetParamSet(ctx sdk.Context, ps params.ParamSet)
 	SetParamSet(ctx sdk.Context, ps params.ParamSet)
+	GetParamSetForInitGenesis(ctx sdk.Context, ps params.ParamSet, ignoreList [][]byte)
+	SetParamSetForInitGenesis(ctx sdk.Context, ps params.ParamSet, ignoreList [][]byte)
 }
 
 type BackendKeeper interface {
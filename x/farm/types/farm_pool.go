@@ -17,6 +17,8 @@ type FarmPool struct {
 	TotalValueLocked        sdk.SysCoin       `json:"total_value_locked"`
 	YieldedTokenInfos       YieldedTokenInfos `json:"yielded_token_infos"`
 	TotalAccumulatedRewards sdk.SysCoins      `json:"total_accumulated_rewards"`
+	// sum of the weight that LockInfo gains from lock tiers
+	TotalBoostWeight sdk.Dec `json:"total_boost_weight"`
 }
 
 // NewFarmPool creates a new instance of FarmPool
@@ -32,9 +34,33 @@ func NewFarmPool(
 		TotalValueLocked:        totalValueLocked,
 		YieldedTokenInfos:       yieldedTokenInfos,
 		TotalAccumulatedRewards: accumulatedRewards,
+		TotalBoostWeight:        sdk.ZeroDec(),
 	}
 }
 
+// GetTotalBoostWeight returns the sum of the weight that lock infos gain from lock tiers
+func (fp FarmPool) GetTotalBoostWeight() sdk.Dec {
+	if fp.TotalBoostWeight.IsNil() {
+		return sdk.ZeroDec()
+	}
+	return fp.TotalBoostWeight
+}
+
+// TotalWeightLocked returns the sum of LockInfo weight, which the yielded tokens are distributed by
+func (fp FarmPool) TotalWeightLocked() sdk.SysCoin {
+	return sdk.SysCoin{Denom: fp.TotalValueLocked.Denom, Amount: fp.TotalValueLocked.Amount.Add(fp.GetTotalBoostWeight())}
+}
+
+// GetYieldedTokenInfoIndex returns the index of the reward stream yielding denom, or -1 if there is none
+func (fp FarmPool) GetYieldedTokenInfoIndex(denom string) int {
+	for i, yieldedTokenInfo := range fp.YieldedTokenInfos {
+		if yieldedTokenInfo.RemainingAmount.Denom == denom {
+			return i
+		}
+	}
+	return -1
+}
+
 func (fp FarmPool) Finished() bool {
 	for _, yieldedTokenInfo := range fp.YieldedTokenInfos {
 		if yieldedTokenInfo.RemainingAmount.IsPositive() {
@@ -53,8 +79,10 @@ func (fp FarmPool) String() string {
   Deposit Amount:                   %s
   Total Value Locked:               %s
   Yielded Token Infos:			    %s
-  Total Accumulated Rewards:        %s`,
-		fp.Name, fp.Owner, fp.MinLockAmount.String(), fp.DepositAmount, fp.TotalValueLocked, fp.YieldedTokenInfos, fp.TotalAccumulatedRewards)
+  Total Accumulated Rewards:        %s
+  Total Boost Weight:               %s`,
+		fp.Name, fp.Owner, fp.MinLockAmount.String(), fp.DepositAmount, fp.TotalValueLocked, fp.YieldedTokenInfos, fp.TotalAccumulatedRewards,
+		fp.GetTotalBoostWeight())
 }
 
 // FarmPools is a collection of FarmPool
@@ -39,6 +39,7 @@ var (
 	PoolsYieldNativeTokenPrefix = []byte{0x04}
 	PoolHistoricalRewardsPrefix = []byte{0x05}
 	PoolCurrentRewardsPrefix    = []byte{0x06}
+	LockExpirationPrefix        = []byte{0x07}
 )
 
 const (
@@ -87,3 +88,21 @@ func GetPoolHistoricalRewardsPrefix(poolName string) []byte {
 func GetPoolCurrentRewardsKey(poolName string) []byte {
 	return append(PoolCurrentRewardsPrefix, []byte(poolName)...)
 }
+
+// GetLockExpirationKey gets the key for the lock tier expiration of a lock info, ordered by the unlock time
+func GetLockExpirationKey(unlockTime int64, addr sdk.AccAddress, poolName string) []byte {
+	return append(GetLockExpirationTimeKey(unlockTime), append(addr.Bytes(), []byte(poolName)...)...)
+}
+
+// GetLockExpirationTimeKey gets the prefix key for the lock tier expirations at the unlock time
+func GetLockExpirationTimeKey(unlockTime int64) []byte {
+	b := make([]byte, PeriodByteArrayLength)
+	binary.BigEndian.PutUint64(b, uint64(unlockTime))
+	return append(LockExpirationPrefix, b...)
+}
+
+// SplitLockExpirationKey splits the address and the pool name out from a LockExpirationKey
+func SplitLockExpirationKey(key []byte) (sdk.AccAddress, string) {
+	addrIndex := len(LockExpirationPrefix) + PeriodByteArrayLength
+	return sdk.AccAddress(key[addrIndex : addrIndex+sdk.AddrLen]), string(key[addrIndex+sdk.AddrLen:])
+}
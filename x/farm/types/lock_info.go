@@ -2,6 +2,8 @@ package types
 
 import (
 	"fmt"
+	"time"
+
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 )
 
@@ -12,6 +14,10 @@ type LockInfo struct {
 	Amount           sdk.SysCoin    `json:"amount"`
 	StartBlockHeight int64          `json:"start_block_height"`
 	ReferencePeriod  uint64         `json:"reference_period"`
+	// lock tier that the locked tokens are committed to, the tokens can't be unlocked before UnlockTime without penalty
+	LockDuration time.Duration `json:"lock_duration"`
+	UnlockTime   int64         `json:"unlock_time"`
+	Multiplier   sdk.Dec       `json:"multiplier"`
 }
 
 // NewLockInfo creates a new instance of LockInfo
@@ -22,7 +28,51 @@ func NewLockInfo(owner sdk.AccAddress, poolName string, amount sdk.SysCoin, star
 		Amount:           amount,
 		StartBlockHeight: startBlockHeight,
 		ReferencePeriod:  referencePeriod,
+		Multiplier:       sdk.OneDec(),
+	}
+}
+
+// WithLockTier returns the lock info committed to the lock tier from timestamp now
+func (li LockInfo) WithLockTier(tier LockTier, now int64) LockInfo {
+	li.LockDuration = tier.Duration
+	li.UnlockTime = now + int64(tier.Duration.Seconds())
+	li.Multiplier = tier.Multiplier
+	return li
+}
+
+// IsLocked returns true if the lock duration hasn't ended at timestamp now
+func (li LockInfo) IsLocked(now int64) bool {
+	return li.LockDuration > 0 && now < li.UnlockTime
+}
+
+// IsBoosted returns true if the lock info is weighted more than 1:1 by its lock tier,
+// its multiplier drops to 1:1 when the lock duration ends
+func (li LockInfo) IsBoosted() bool {
+	return li.GetMultiplier().GT(sdk.OneDec())
+}
+
+// WithoutBoost returns the lock info weighted 1:1 after its lock duration ended
+func (li LockInfo) WithoutBoost() LockInfo {
+	li.Multiplier = sdk.OneDec()
+	return li
+}
+
+// GetMultiplier returns the multiplier of the lock weight, lock infos without a lock tier are weighted 1:1
+func (li LockInfo) GetMultiplier() sdk.Dec {
+	if li.Multiplier.IsNil() || !li.Multiplier.IsPositive() {
+		return sdk.OneDec()
 	}
+	return li.Multiplier
+}
+
+// Weight returns the weight of the lock info in the reward accounting
+func (li LockInfo) Weight() sdk.SysCoin {
+	return sdk.SysCoin{Denom: li.Amount.Denom, Amount: li.Amount.Amount.MulTruncate(li.GetMultiplier())}
+}
+
+// BoostWeight returns the weight that the lock info gains from its lock tier
+func (li LockInfo) BoostWeight() sdk.Dec {
+	return li.Weight().Amount.Sub(li.Amount.Amount)
 }
 
 // String returns a human readable string representation of LockInfo
@@ -32,6 +82,10 @@ func (li LockInfo) String() string {
   Pool Name:					%s
   Locked Amount:      			%s
   Start Block Height:           %d
-  Reference Period:             %d`,
-		li.Owner, li.PoolName, li.Amount, li.StartBlockHeight, li.ReferencePeriod)
+  Reference Period:             %d
+  Lock Duration:                %s
+  Unlock Time:                  %d
+  Multiplier:                   %s`,
+		li.Owner, li.PoolName, li.Amount, li.StartBlockHeight, li.ReferencePeriod,
+		li.LockDuration, li.UnlockTime, li.GetMultiplier())
 }
@@ -0,0 +1,86 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
+const (
+	// MaxLockTiers is the maximum number of lock tiers in the params
+	MaxLockTiers = 16
+	// MaxLockDuration is the maximum duration that tokens can be committed for
+	MaxLockDuration = 4 * 365 * 24 * time.Hour
+)
+
+var (
+	// MaxLockMultiplier is the maximum multiplier of a lock tier
+	MaxLockMultiplier = sdk.NewDec(10)
+)
+
+// LockTier multiplies the weight of a lock info in the reward accounting, when its tokens are committed for Duration
+type LockTier struct {
+	Duration   time.Duration `json:"duration"`
+	Multiplier sdk.Dec       `json:"multiplier"`
+}
+
+// NewLockTier creates a new instance of LockTier
+func NewLockTier(duration time.Duration, multiplier sdk.Dec) LockTier {
+	return LockTier{
+		Duration:   duration,
+		Multiplier: multiplier,
+	}
+}
+
+// String returns a human readable string representation of LockTier
+func (lt LockTier) String() string {
+	return fmt.Sprintf("%s: x%s", lt.Duration, lt.Multiplier)
+}
+
+// LockTiers is a collection of LockTier
+type LockTiers []LockTier
+
+// Find returns the lock tier with the duration
+func (lts LockTiers) Find(duration time.Duration) (LockTier, bool) {
+	for _, lt := range lts {
+		if lt.Duration == duration {
+			return lt, true
+		}
+	}
+	return LockTier{}, false
+}
+
+// Validate checks that the durations are increasing and that longer locks never get smaller multipliers
+func (lts LockTiers) Validate() error {
+	if len(lts) > MaxLockTiers {
+		return fmt.Errorf("too many lock tiers: %d, max is %d", len(lts), MaxLockTiers)
+	}
+	for i, lt := range lts {
+		if lt.Duration <= 0 || lt.Duration > MaxLockDuration {
+			return fmt.Errorf("lock tier duration must be in (0, %s], got %s", MaxLockDuration, lt.Duration)
+		}
+		if lt.Multiplier.IsNil() || lt.Multiplier.LT(sdk.OneDec()) || lt.Multiplier.GT(MaxLockMultiplier) {
+			return fmt.Errorf("lock tier multiplier must be in [1, %s], got %s", MaxLockMultiplier, lt.Multiplier)
+		}
+		if i > 0 {
+			if lt.Duration <= lts[i-1].Duration {
+				return fmt.Errorf("lock tier durations must be increasing, got %s after %s", lt.Duration, lts[i-1].Duration)
+			}
+			if lt.Multiplier.LT(lts[i-1].Multiplier) {
+				return fmt.Errorf("lock tier multipliers must not decrease, got %s after %s", lt.Multiplier, lts[i-1].Multiplier)
+			}
+		}
+	}
+	return nil
+}
+
+// String returns a human readable string representation of LockTiers
+func (lts LockTiers) String() string {
+	tiers := make([]string, len(lts))
+	for i, lt := range lts {
+		tiers[i] = lt.String()
+	}
+	return strings.Join(tiers, ", ")
+}
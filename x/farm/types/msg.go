@@ -1,6 +1,8 @@
 package types
 
 import (
+	"time"
+
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 )
 
@@ -171,13 +173,16 @@ type MsgLock struct {
 	PoolName string         `json:"pool_name" yaml:"pool_name"`
 	Address  sdk.AccAddress `json:"address" yaml:"address"`
 	Amount   sdk.SysCoin    `json:"amount" yaml:"amount"`
+	// LockDuration chooses the lock tier of all the tokens locked in the pool, zero keeps the current one
+	LockDuration time.Duration `json:"lock_duration,omitempty" yaml:"lock_duration"`
 }
 
-func NewMsgLock(poolName string, address sdk.AccAddress, amount sdk.SysCoin) MsgLock {
+func NewMsgLock(poolName string, address sdk.AccAddress, amount sdk.SysCoin, lockDuration time.Duration) MsgLock {
 	return MsgLock{
-		PoolName: poolName,
-		Address:  address,
-		Amount:   amount,
+		PoolName:     poolName,
+		Address:      address,
+		Amount:       amount,
+		LockDuration: lockDuration,
 	}
 }
 
@@ -201,6 +206,9 @@ func (m MsgLock) ValidateBasic() sdk.Error {
 	if m.Amount.Amount.LTE(sdk.ZeroDec()) || !m.Amount.IsValid() {
 		return ErrInvalidInputAmount(m.Amount.Amount.String())
 	}
+	if m.LockDuration < 0 || m.LockDuration > MaxLockDuration {
+		return ErrInvalidLockDuration(m.LockDuration)
+	}
 	return nil
 }
 
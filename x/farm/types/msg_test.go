@@ -4,6 +4,7 @@ package types
 
 import (
 	"testing"
+	"time"
 
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	"github.com/stretchr/testify/require"
@@ -196,7 +197,7 @@ func TestMsgLock(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		msg := NewMsgLock(test.poolName, test.addr, test.amount)
+		msg := NewMsgLock(test.poolName, test.addr, test.amount, 0)
 		require.Equal(t, lockMsgType, msg.Type())
 		require.Equal(t, ModuleName, msg.Route())
 		require.Equal(t, []sdk.AccAddress{test.addr}, msg.GetSigners())
@@ -207,6 +208,13 @@ func TestMsgLock(t *testing.T) {
 			testCode(t, err, test.errCode)
 		}
 	}
+	// the lock duration must not be negative
+	msg := NewMsgLock("pool", sdk.AccAddress{0x1}, sdk.NewDecCoinFromDec("xxb", sdk.NewDec(100)), -time.Hour)
+	testCode(t, msg.ValidateBasic(), CodeInvalidLockDuration)
+
+	// the sign bytes without a lock duration are the same as before lock tiers existed
+	msg = NewMsgLock("pool", sdk.AccAddress{0x1}, sdk.NewDecCoinFromDec("xxb", sdk.NewDec(100)), 0)
+	require.NotContains(t, string(msg.GetSignBytes()), "lock_duration")
 }
 
 func TestMsgUnlock(t *testing.T) {
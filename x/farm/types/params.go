@@ -2,6 +2,8 @@ package types
 
 import (
 	"fmt"
+	"time"
+
 	"github.com/okex/exchain/x/common"
 
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
@@ -16,12 +18,27 @@ const (
 	defaultCreatePoolDeposit = "10"
 )
 
+var (
+	defaultLockTiers = LockTiers{
+		NewLockTier(7*24*time.Hour, sdk.NewDecWithPrec(125, 2)),
+		NewLockTier(30*24*time.Hour, sdk.NewDecWithPrec(15, 1)),
+		NewLockTier(90*24*time.Hour, sdk.NewDec(2)),
+	}
+	defaultEarlyUnlockPenaltyRate = sdk.NewDecWithPrec(1, 1)
+)
+
 // Parameter store keys
 var (
 	KeyQuoteSymbol       = []byte("QuoteSymbol")
 	KeyCreatePoolFee     = []byte("CreatePoolFee")
 	KeyCreatePoolDeposit = []byte("CreatePoolDeposit")
-	keyYieldNativeToken  = []byte("YieldNativeToken")
+	KeyYieldNativeToken  = []byte("YieldNativeToken")
+
+	KeyLockTiers              = []byte("LockTiers")
+	KeyEarlyUnlockPenaltyRate = []byte("EarlyUnlockPenaltyRate")
+
+	// IgnoreInitGenesisList are the params which don't exist before the Jupiter height
+	IgnoreInitGenesisList = [][]byte{KeyLockTiers, KeyEarlyUnlockPenaltyRate}
 )
 
 // ParamKeyTable for farm module
@@ -36,6 +53,10 @@ type Params struct {
 	CreatePoolDeposit sdk.SysCoin `json:"create_pool_deposit"`
 	// proposal params
 	YieldNativeToken bool `json:"yield_native_token"`
+	// LockTiers are the lock durations that a lock can choose to multiply its weight in the reward accounting
+	LockTiers LockTiers `json:"lock_tiers"`
+	// EarlyUnlockPenaltyRate is the rate of the unlocked tokens charged when unlocking before the lock duration ends
+	EarlyUnlockPenaltyRate sdk.Dec `json:"early_unlock_penalty_rate"`
 }
 
 // String implements the stringer interface for Params
@@ -44,8 +65,10 @@ func (p Params) String() string {
   Quote Symbol:								%s
   Create Pool Fee:							%s
   Create Pool Deposit:						%s
-  Yield Native Token Enabled:               %v`,
-		p.QuoteSymbol, p.CreatePoolFee, p.CreatePoolDeposit, p.YieldNativeToken)
+  Yield Native Token Enabled:               %v
+  Lock Tiers:								%s
+  Early Unlock Penalty Rate:				%s`,
+		p.QuoteSymbol, p.CreatePoolFee, p.CreatePoolDeposit, p.YieldNativeToken, p.LockTiers, p.EarlyUnlockPenaltyRate)
 }
 
 // ParamSetPairs - Implements params.ParamSet
@@ -54,16 +77,39 @@ func (p *Params) ParamSetPairs() params.ParamSetPairs {
 		{Key: KeyQuoteSymbol, Value: &p.QuoteSymbol, ValidatorFn: common.ValidateDenom("quote symbol")},
 		{Key: KeyCreatePoolFee, Value: &p.CreatePoolFee, ValidatorFn: common.ValidateSysCoin("create pool fee")},
 		{Key: KeyCreatePoolDeposit, Value: &p.CreatePoolDeposit, ValidatorFn: common.ValidateSysCoin("create pool deposit")},
-		{Key: keyYieldNativeToken, Value: &p.YieldNativeToken, ValidatorFn: common.ValidateBool("yield native token")},
+		{Key: KeyYieldNativeToken, Value: &p.YieldNativeToken, ValidatorFn: common.ValidateBool("yield native token")},
+		{Key: KeyLockTiers, Value: &p.LockTiers, ValidatorFn: validateLockTiers},
+		{Key: KeyEarlyUnlockPenaltyRate, Value: &p.EarlyUnlockPenaltyRate, ValidatorFn: validateEarlyUnlockPenaltyRate},
 	}
 }
 
 // DefaultParams defines the parameters for this module
 func DefaultParams() Params {
 	return Params{
-		QuoteSymbol:       defaultQuoteSymbol,
-		CreatePoolFee:     sdk.NewDecCoinFromDec(common.NativeToken, sdk.MustNewDecFromStr(defaultCreatePoolFee)),
-		CreatePoolDeposit: sdk.NewDecCoinFromDec(common.NativeToken, sdk.MustNewDecFromStr(defaultCreatePoolDeposit)),
-		YieldNativeToken:  false,
+		QuoteSymbol:            defaultQuoteSymbol,
+		CreatePoolFee:          sdk.NewDecCoinFromDec(common.NativeToken, sdk.MustNewDecFromStr(defaultCreatePoolFee)),
+		CreatePoolDeposit:      sdk.NewDecCoinFromDec(common.NativeToken, sdk.MustNewDecFromStr(defaultCreatePoolDeposit)),
+		YieldNativeToken:       false,
+		LockTiers:              defaultLockTiers,
+		EarlyUnlockPenaltyRate: defaultEarlyUnlockPenaltyRate,
+	}
+}
+
+func validateLockTiers(value interface{}) error {
+	v, ok := value.(LockTiers)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", value)
+	}
+	return v.Validate()
+}
+
+func validateEarlyUnlockPenaltyRate(value interface{}) error {
+	v, ok := value.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", value)
+	}
+	if v.IsNil() || v.IsNegative() || v.GTE(sdk.OneDec()) {
+		return fmt.Errorf("early unlock penalty rate must be in [0, 1): %s", v)
 	}
+	return nil
 }
@@ -5,6 +5,7 @@ package types
 
 import (
 	"testing"
+	"time"
 
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	"github.com/stretchr/testify/require"
@@ -15,7 +16,9 @@ const (
   Quote Symbol:								usdk
   Create Pool Fee:							0.000000000000000000` + sdk.DefaultBondDenom + `
   Create Pool Deposit:						10.000000000000000000` + sdk.DefaultBondDenom + `
-  Yield Native Token Enabled:               false`
+  Yield Native Token Enabled:               false
+  Lock Tiers:								168h0m0s: x1.250000000000000000, 720h0m0s: x1.500000000000000000, 2160h0m0s: x2.000000000000000000
+  Early Unlock Penalty Rate:				0.100000000000000000`
 )
 
 func TestParams(t *testing.T) {
@@ -25,3 +28,30 @@ func TestParams(t *testing.T) {
 	require.Equal(t, defaultState.Params, defaultParams)
 	require.Equal(t, strExpected, defaultParams.String())
 }
+
+func TestValidateLockTiers(t *testing.T) {
+	require.NoError(t, DefaultParams().LockTiers.Validate())
+	require.NoError(t, LockTiers{}.Validate())
+
+	tests := []LockTiers{
+		{NewLockTier(0, sdk.OneDec())},
+		{NewLockTier(MaxLockDuration+1, sdk.OneDec())},
+		{NewLockTier(time.Hour, sdk.NewDecWithPrec(9, 1))},
+		{NewLockTier(time.Hour, MaxLockMultiplier.Add(sdk.OneDec()))},
+		{NewLockTier(2*time.Hour, sdk.NewDec(2)), NewLockTier(time.Hour, sdk.NewDec(3))},
+		{NewLockTier(time.Hour, sdk.NewDec(2)), NewLockTier(2*time.Hour, sdk.OneDec())},
+	}
+	for _, tiers := range tests {
+		require.Error(t, tiers.Validate())
+	}
+
+	tier, found := DefaultParams().LockTiers.Find(30 * 24 * time.Hour)
+	require.True(t, found)
+	require.Equal(t, sdk.NewDecWithPrec(15, 1), tier.Multiplier)
+	_, found = DefaultParams().LockTiers.Find(time.Hour)
+	require.False(t, found)
+
+	require.Error(t, validateEarlyUnlockPenaltyRate(sdk.OneDec()))
+	require.Error(t, validateEarlyUnlockPenaltyRate(sdk.NewDec(-1)))
+	require.NoError(t, validateEarlyUnlockPenaltyRate(sdk.ZeroDec()))
+}
@@ -6,6 +6,9 @@ import (
 	"strings"
 )
 
+// MaxYieldedTokenInfos is the maximum number of tokens that a pool can yield concurrently
+const MaxYieldedTokenInfos = 8
+
 // YieldedTokenInfo is the token excluding native token which can be yielded by locking other tokens including LPT and
 // token issued
 type YieldedTokenInfo struct {
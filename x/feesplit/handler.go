@@ -11,6 +11,7 @@ import (
 	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 	"github.com/okex/exchain/x/feesplit/keeper"
 	"github.com/okex/exchain/x/feesplit/types"
+	wasmtypes "github.com/okex/exchain/x/wasm/types"
 )
 
 // NewHandler defines the fees module handler instance
@@ -72,13 +73,14 @@ func handleMsgRegisterFeeSplit(
 		)
 	}
 
-	// contract must already be deployed, to avoid spam registrations
-	contractAccount, _ := k.GetEthAccount(ctx, contract)
-	if contractAccount == nil || !contractAccount.IsContract() {
-		return nil, sdkerrors.Wrapf(
-			types.ErrFeeSplitNoContractDeployed,
-			"no contract code found at address %s", msg.ContractAddress,
-		)
+	// a wasm contract is owned by its creator or its admin, its address is not
+	// derived from the deployer nonces
+	if wasmContract := getWasmContractInfo(ctx, k, contract); wasmContract != nil {
+		if err := validateWasmContractOwner(wasmContract, deployer); err != nil {
+			return nil, err
+		}
+	} else if err := validateEvmContractDeployer(ctx, msg, k, params, contract, deployer); err != nil {
+		return nil, err
 	}
 
 	if msg.WithdrawerAddress == "" {
@@ -86,31 +88,6 @@ func handleMsgRegisterFeeSplit(
 	}
 	withdrawer := sdk.MustAccAddressFromBech32(msg.WithdrawerAddress)
 
-	derivedContract := common.BytesToAddress(deployer)
-
-	// the contract can be directly deployed by an EOA or created through one
-	// or more factory contracts. If it was deployed by an EOA account, then
-	// msg.Nonces contains the EOA nonce for the deployment transaction.
-	// If it was deployed by one or more factories, msg.Nonces contains the EOA
-	// nonce for the origin factory contract, then the nonce of the factory
-	// for the creation of the next factory/contract.
-	for _, nonce := range msg.Nonces {
-		ctx.GasMeter().ConsumeGas(
-			params.AddrDerivationCostCreate,
-			"fee split registration: address derivation CREATE opcode",
-		)
-
-		derivedContract = crypto.CreateAddress(derivedContract, nonce)
-	}
-
-	if contract != derivedContract {
-		return nil, sdkerrors.Wrapf(
-			types.ErrDerivedNotMatched,
-			"not contract deployer or wrong nonce: expected %s instead of %s",
-			derivedContract, msg.ContractAddress,
-		)
-	}
-
 	// prevent storing the same address for deployer and withdrawer
 	feeSplit := types.NewFeeSplit(contract, deployer, withdrawer)
 	k.SetFeeSplit(ctx, feeSplit)
@@ -231,3 +208,73 @@ func handleMsgCancelFeeSplit(
 
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
+
+// getWasmContractInfo returns the info of the wasm contract, the wasm contracts are registered since the jupiter height
+func getWasmContractInfo(ctx sdk.Context, k keeper.Keeper, contract common.Address) *wasmtypes.ContractInfo {
+	if !tmtypes.HigherThanJupiter(ctx.BlockHeight()) {
+		return nil
+	}
+	return k.GetWasmContractInfo(ctx, contract)
+}
+
+// validateWasmContractOwner checks the deployer is the creator or the admin of the wasm contract
+func validateWasmContractOwner(contract *wasmtypes.ContractInfo, deployer sdk.AccAddress) error {
+	for _, owner := range []string{contract.Creator, contract.Admin} {
+		if owner == "" {
+			continue
+		}
+		if addr, err := sdk.WasmAddressFromBech32(owner); err == nil && addr.Equals(sdk.AccToAWasmddress(deployer)) {
+			return nil
+		}
+	}
+	return sdkerrors.Wrapf(
+		types.ErrNotWasmContractOwner,
+		"%s is not the owner of the wasm contract", deployer,
+	)
+}
+
+// validateEvmContractDeployer checks the evm contract is deployed and its address is derived from the deployer nonces
+func validateEvmContractDeployer(
+	ctx sdk.Context,
+	msg types.MsgRegisterFeeSplit,
+	k keeper.Keeper,
+	params types.Params,
+	contract common.Address,
+	deployer sdk.AccAddress,
+) error {
+	// contract must already be deployed, to avoid spam registrations
+	contractAccount, _ := k.GetEthAccount(ctx, contract)
+	if contractAccount == nil || !contractAccount.IsContract() {
+		return sdkerrors.Wrapf(
+			types.ErrFeeSplitNoContractDeployed,
+			"no contract code found at address %s", msg.ContractAddress,
+		)
+	}
+
+	derivedContract := common.BytesToAddress(deployer)
+
+	// the contract can be directly deployed by an EOA or created through one
+	// or more factory contracts. If it was deployed by an EOA account, then
+	// msg.Nonces contains the EOA nonce for the deployment transaction.
+	// If it was deployed by one or more factories, msg.Nonces contains the EOA
+	// nonce for the origin factory contract, then the nonce of the factory
+	// for the creation of the next factory/contract.
+	for _, nonce := range msg.Nonces {
+		ctx.GasMeter().ConsumeGas(
+			params.AddrDerivationCostCreate,
+			"fee split registration: address derivation CREATE opcode",
+		)
+
+		derivedContract = crypto.CreateAddress(derivedContract, nonce)
+	}
+
+	if contract != derivedContract {
+		return sdkerrors.Wrapf(
+			types.ErrDerivedNotMatched,
+			"not contract deployer or wrong nonce: expected %s instead of %s",
+			derivedContract, msg.ContractAddress,
+		)
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package feesplit_test
 
 import (
 	"fmt"
+	"io/ioutil"
 	"testing"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 	"github.com/okex/exchain/x/feesplit"
 	"github.com/okex/exchain/x/feesplit/types"
+	wasmtypes "github.com/okex/exchain/x/wasm/types"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -238,6 +240,61 @@ func (suite *FeeSplitTestSuite) TestRegisterFeeSplit() {
 	}
 }
 
+func (suite *FeeSplitTestSuite) TestRegisterWasmFeeSplit() {
+	tmtypes.UnittestOnlySetMilestoneEarthHeight(1)
+	tmtypes.UnittestOnlySetMilestoneJupiterHeight(1)
+	defer tmtypes.UnittestOnlySetMilestoneEarthHeight(0)
+	defer tmtypes.UnittestOnlySetMilestoneJupiterHeight(0)
+
+	creator := sdk.AccAddress(ethsecp256k1.GenerateAddress().Bytes())
+	admin := sdk.AccAddress(ethsecp256k1.GenerateAddress().Bytes())
+	stranger := sdk.AccAddress(ethsecp256k1.GenerateAddress().Bytes())
+	for _, addr := range []sdk.AccAddress{creator, admin, stranger} {
+		acc := authtypes.NewBaseAccountWithAddress(addr)
+		suite.app.AccountKeeper.SetAccount(suite.ctx, &acc)
+	}
+
+	suite.app.WasmKeeper.SetParams(suite.ctx, wasmtypes.TestParams())
+	wasmCode, err := ioutil.ReadFile("../wasm/keeper/testdata/hackatom.wasm")
+	suite.Require().NoError(err)
+	codeID, err := suite.app.WasmPermissionKeeper.Create(suite.ctx, sdk.AccToAWasmddress(creator), wasmCode, nil)
+	suite.Require().NoError(err)
+	initMsg := []byte(fmt.Sprintf(`{"verifier":"%s","beneficiary":"%s"}`,
+		sdk.AccToAWasmddress(creator), sdk.AccToAWasmddress(stranger)))
+
+	testCases := []struct {
+		name         string
+		deployer     sdk.AccAddress
+		expPass      bool
+		errorMessage string
+	}{
+		{"ok - registered by the creator", creator, true, ""},
+		{"ok - registered by the admin", admin, true, ""},
+		{"fail - not the contract owner", stranger, false, "not the owner of the wasm contract"},
+	}
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			wasmContract, _, err := suite.app.WasmPermissionKeeper.Instantiate(suite.ctx, codeID, sdk.AccToAWasmddress(creator),
+				sdk.AccToAWasmddress(admin), initMsg, "label", nil)
+			suite.Require().NoError(err)
+			contract := common.BytesToAddress(wasmContract.Bytes())
+
+			// the nonces are not used to register a wasm contract
+			msg := types.NewMsgRegisterFeeSplit(contract, tc.deployer, nil, []uint64{0})
+			_, err = suite.handler(suite.ctx, msg)
+			if tc.expPass {
+				suite.Require().NoError(err, tc.name)
+				feeSplit, found := suite.app.FeeSplitKeeper.GetFeeSplit(suite.ctx, contract)
+				suite.Require().True(found)
+				suite.Require().Equal(tc.deployer, feeSplit.DeployerAddress)
+			} else {
+				suite.Require().Error(err, tc.name)
+				suite.Require().Contains(err.Error(), tc.errorMessage)
+			}
+		})
+	}
+}
+
 func (suite *FeeSplitTestSuite) TestUpdateFeeSplit() {
 	deployer := ethsecp256k1.GenerateAddress()
 	deployerAddr := sdk.AccAddress(deployer.Bytes())
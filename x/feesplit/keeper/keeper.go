@@ -11,6 +11,7 @@ import (
 	"github.com/okex/exchain/libs/tendermint/libs/log"
 	"github.com/okex/exchain/x/feesplit/types"
 	"github.com/okex/exchain/x/params"
+	wasmtypes "github.com/okex/exchain/x/wasm/types"
 )
 
 // Keeper of this module maintains collections of fee splits for contracts
@@ -24,6 +25,7 @@ type Keeper struct {
 	govKeeper             types.GovKeeper
 	supplyKeeper          types.SupplyKeeper
 	accountKeeper         types.AccountKeeper
+	wasmKeeper            types.WasmKeeper
 	updateFeeSplitHandler sdk.UpdateFeeSplitHandler
 }
 
@@ -72,3 +74,16 @@ func (k Keeper) GetEthAccount(ctx sdk.Context, addr common.Address) (*ethermint.
 func (k *Keeper) SetGovKeeper(gk types.GovKeeper) {
 	k.govKeeper = gk
 }
+
+// SetWasmKeeper sets keeper of wasm
+func (k *Keeper) SetWasmKeeper(wk types.WasmKeeper) {
+	k.wasmKeeper = wk
+}
+
+// GetWasmContractInfo returns the info of a wasm contract, it returns nil if the contract does not exist
+func (k Keeper) GetWasmContractInfo(ctx sdk.Context, contract common.Address) *wasmtypes.ContractInfo {
+	if k.wasmKeeper == nil {
+		return nil
+	}
+	return k.wasmKeeper.GetContractInfo(ctx, sdk.WasmAddress(contract.Bytes()))
+}
@@ -0,0 +1,107 @@
+package keeper
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/auth/ante"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	"github.com/okex/exchain/x/feesplit/types"
+	wasmtypes "github.com/okex/exchain/x/wasm/types"
+)
+
+// PostWasmTxProcessing is called by the gas refund handler after a cosmos tx is delivered, before its unused gas
+// is refunded. If the tx is delivered successfully and executes a registered wasm contract, the contract creator
+// (or, if set, the withdraw address) receives a share from the fees of the gas consumed by the tx, like the
+// registered evm contracts. The failed txs are skipped, the refund handler runs for them too.
+// Only the txs executing a single wasm contract are supported.
+func (k Keeper) PostWasmTxProcessing(ctx sdk.Context, tx sdk.Tx) {
+	if ctx.IsCheckTx() || tx.GetType() == sdk.EvmTxType || !ctx.IsTxSucceeded() {
+		return
+	}
+	if !tmtypes.HigherThanJupiter(ctx.BlockHeight()) {
+		return
+	}
+
+	msgs := tx.GetMsgs()
+	if len(msgs) != 1 {
+		return
+	}
+	msg, ok := msgs[0].(*wasmtypes.MsgExecuteContract)
+	if !ok {
+		return
+	}
+	feeTx, ok := tx.(ante.FeeTx)
+	if !ok || feeTx.GetGas() == 0 {
+		return
+	}
+	contractAddr, err := sdk.WasmAddressFromBech32(msg.Contract)
+	if err != nil {
+		return
+	}
+
+	// For GetParams using cache, no fee is charged
+	currentGasMeter := ctx.GasMeter()
+	infGasMeter := sdk.GetReusableInfiniteGasMeter()
+	ctx.SetGasMeter(infGasMeter)
+	defer func() {
+		ctx.SetGasMeter(currentGasMeter)
+		sdk.ReturnInfiniteGasMeter(infGasMeter)
+	}()
+
+	params := k.GetParamsWithCache(ctx)
+	if !params.EnableFeeSplit {
+		return
+	}
+
+	contract := common.BytesToAddress(contractAddr.Bytes())
+	feeSplit, found := k.GetFeeSplitWithCache(ctx, contract)
+	if !found {
+		return
+	}
+
+	withdrawer := feeSplit.WithdrawerAddress
+	if withdrawer.Empty() {
+		withdrawer = feeSplit.DeployerAddress
+	}
+
+	developerShares := params.DeveloperShares
+	// if the contract shares is set by proposal
+	shares, found := k.GetContractShareWithCache(ctx, contract)
+	if found {
+		developerShares = shares
+	}
+	if developerShares.LTE(sdk.ZeroDec()) {
+		return
+	}
+
+	// the fee of the consumed gas is the share of the tx fee, as the unused gas is refunded
+	gasLimit := sdk.NewDecFromBigInt(new(big.Int).SetUint64(feeTx.GetGas()))
+	gasUsed := sdk.NewDecFromBigInt(new(big.Int).SetUint64(currentGasMeter.GasConsumed()))
+	txFee := feeTx.GetFee().AmountOf(sdk.DefaultBondDenom).Mul(gasUsed).Quo(gasLimit)
+	developerFee := txFee.Mul(developerShares)
+	if developerFee.LTE(sdk.ZeroDec()) {
+		return
+	}
+	fees := sdk.Coins{{Denom: sdk.DefaultBondDenom, Amount: developerFee}}
+
+	//distribute the fees to the contract creator / withdraw address
+	f := ctx.GetFeeSplitInfo()
+	f.Addr = withdrawer
+	f.Fee = fees
+	f.HasFee = true
+
+	ctx.EventManager().EmitEvents(
+		sdk.Events{
+			sdk.NewEvent(
+				types.EventTypeDistributeDevFeeSplit,
+				sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender),
+				sdk.NewAttribute(types.AttributeKeyContract, contractAddr.String()),
+				sdk.NewAttribute(types.AttributeKeyWithdrawerAddress, withdrawer.String()),
+				sdk.NewAttribute(sdk.AttributeKeyAmount, developerFee.String()),
+			),
+		},
+	)
+}
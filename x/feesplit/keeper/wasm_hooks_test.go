@@ -0,0 +1,61 @@
+package keeper_test
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	authtypes "github.com/okex/exchain/libs/cosmos-sdk/x/auth/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	"github.com/okex/exchain/x/feesplit/types"
+	wasmtypes "github.com/okex/exchain/x/wasm/types"
+)
+
+func (suite *KeeperTestSuite) TestPostWasmTxProcessing() {
+	tmtypes.UnittestOnlySetMilestoneEarthHeight(1)
+	tmtypes.UnittestOnlySetMilestoneJupiterHeight(1)
+	defer tmtypes.UnittestOnlySetMilestoneEarthHeight(0)
+	defer tmtypes.UnittestOnlySetMilestoneJupiterHeight(0)
+	ctx := suite.ctx
+	ctx.SetBlockHeight(2)
+
+	params := types.DefaultParams()
+	params.EnableFeeSplit = true
+	suite.app.FeeSplitKeeper.SetParams(ctx, params)
+	suite.app.FeeSplitKeeper.SetFeeSplit(ctx, types.NewFeeSplit(contract, deployer, withdraw))
+
+	execute := func(contractAddr string) *wasmtypes.MsgExecuteContract {
+		return &wasmtypes.MsgExecuteContract{Sender: deployer.String(), Contract: contractAddr, Msg: []byte("{}")}
+	}
+	fee := authtypes.NewStdFee(100000, sdk.NewDecCoinsFromDec(sdk.DefaultBondDenom, sdk.NewDecWithPrec(1, 2)))
+	registered := sdk.WasmAddress(contract.Bytes()).String()
+
+	testCases := []struct {
+		name   string
+		msgs   []sdk.Msg
+		failed bool
+		expFee sdk.Dec
+	}{
+		// half of the gas is used, the developer receives half of its fee
+		{"registered contract", []sdk.Msg{execute(registered)}, false, sdk.NewDecWithPrec(25, 4)},
+		{"unregistered contract", []sdk.Msg{execute(sdk.WasmAddress(deployer).String())}, false, sdk.ZeroDec()},
+		{"several messages", []sdk.Msg{execute(registered), execute(registered)}, false, sdk.ZeroDec()},
+		{"failed tx", []sdk.Msg{execute(registered)}, true, sdk.ZeroDec()},
+	}
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			ctx.SetFeeSplitInfo(&sdk.FeeSplitInfo{})
+			ctx.SetTxSucceeded(!tc.failed)
+			ctx.SetGasMeter(sdk.NewGasMeter(100000))
+			ctx.GasMeter().ConsumeGas(50000, "execute")
+
+			suite.app.FeeSplitKeeper.PostWasmTxProcessing(ctx, authtypes.NewStdTx(tc.msgs, fee, nil, ""))
+			info := ctx.GetFeeSplitInfo()
+			if tc.expFee.IsZero() {
+				suite.Require().False(info.HasFee)
+				return
+			}
+			suite.Require().True(info.HasFee)
+			suite.Require().Equal(withdraw, info.Addr)
+			suite.Require().Equal(tc.expFee, info.Fee.AmountOf(sdk.DefaultBondDenom))
+			suite.Require().Equal(uint64(50000), ctx.GasMeter().GasConsumed())
+		})
+	}
+}
@@ -17,4 +17,5 @@ var (
 	ErrFeeSplitDeployerIsNotEOA      = sdkerrors.Register(DefaultCodespace, 7, "deployer is not EOA")
 	ErrFeeAccountNotFound            = sdkerrors.Register(DefaultCodespace, 8, "account not found")
 	ErrDerivedNotMatched             = sdkerrors.Register(DefaultCodespace, 9, "derived address not matched")
+	ErrNotWasmContractOwner          = sdkerrors.Register(DefaultCodespace, 10, "deployer is neither the creator nor the admin of the wasm contract")
 )
@@ -5,6 +5,7 @@ import (
 	authexported "github.com/okex/exchain/libs/cosmos-sdk/x/auth/exported"
 	"github.com/okex/exchain/libs/cosmos-sdk/x/params"
 	govtypes "github.com/okex/exchain/x/gov/types"
+	wasmtypes "github.com/okex/exchain/x/wasm/types"
 )
 
 // AccountKeeper defines the expected interface needed to retrieve account info.
@@ -32,3 +33,8 @@ type EvmKeeper interface {
 	AddInnerTx(...interface{})
 	DeleteInnerTx(...interface{})
 }
+
+// WasmKeeper defines the expected wasm keeper to look up the owners of the wasm contracts
+type WasmKeeper interface {
+	GetContractInfo(ctx sdk.Context, contractAddress sdk.WasmAddress) *wasmtypes.ContractInfo
+}
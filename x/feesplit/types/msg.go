@@ -34,7 +34,8 @@ type MsgRegisterFeeSplit struct {
 	WithdrawerAddress string `json:"withdrawer_address,omitempty"`
 	// array of nonces from the address path, where the last nonce is the nonce
 	// that determines the contract's address - it can be an EOA nonce or a
-	// factory contract nonce
+	// factory contract nonce. The nonces are not used for a wasm contract, whose
+	// deployer must be the creator or the admin of the contract
 	Nonces []uint64 `json:"nonces,omitempty"`
 }
 
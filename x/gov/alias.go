@@ -48,6 +48,7 @@ var (
 	NewDepositParams           = types.NewDepositParams
 	NewTallyParams             = types.NewTallyParams
 	NewVotingParams            = types.NewVotingParams
+	NewExpeditedParams         = types.NewExpeditedParams
 	NewParams                  = types.NewParams
 	NewTallyResultFromMap      = types.NewTallyResultFromMap
 	EmptyTallyResult           = types.EmptyTallyResult
@@ -89,6 +90,7 @@ type (
 	DepositParams     = types.DepositParams
 	TallyParams       = types.TallyParams
 	VotingParams      = types.VotingParams
+	ExpeditedParams   = types.ExpeditedParams
 	Params            = types.Params
 	Proposal          = types.Proposal
 	Proposals         = types.Proposals
@@ -18,6 +18,7 @@ func parseSubmitProposalFlags() (*proposal, error) {
 		proposal.Description = viper.GetString(flagDescription)
 		proposal.Type = govutils.NormalizeProposalType(viper.GetString(flagProposalType))
 		proposal.Deposit = viper.GetString(flagDeposit)
+		proposal.Expedited = viper.GetBool(FlagExpedited)
 		return proposal, nil
 	}
 
@@ -36,6 +37,7 @@ func parseSubmitProposalFlags() (*proposal, error) {
 	if err != nil {
 		return nil, err
 	}
+	proposal.Expedited = proposal.Expedited || viper.GetBool(FlagExpedited)
 
 	return proposal, nil
 }
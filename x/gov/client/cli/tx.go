@@ -36,6 +36,7 @@ const (
 	flagProposalType = "type"
 	flagDeposit      = "deposit"
 	flagProposal     = "proposal"
+	FlagExpedited    = "expedited"
 )
 
 type proposal struct {
@@ -43,6 +44,7 @@ type proposal struct {
 	Description string
 	Type        string
 	Deposit     string
+	Expedited   bool
 }
 
 // proposalFlags defines the core required fields of a proposal. It is used to
@@ -108,6 +110,9 @@ Which is equivalent to:
 
 $ %s tx gov submit-proposal --title="Test Proposal" --description="My awesome proposal" --type="Text" \
 	--deposit="10%s" --from mykey
+
+Add "expedited": true to the JSON file or pass --expedited to submit an expedited proposal, which
+requires a higher deposit and threshold but has a shorter voting period.
 `,
 				version.ClientName, sdk.DefaultBondDenom, version.ClientName, sdk.DefaultBondDenom,
 			),
@@ -129,6 +134,7 @@ $ %s tx gov submit-proposal --title="Test Proposal" --description="My awesome pr
 
 			content := types.ContentFromProposalType(proposal.Title, proposal.Description, proposal.Type)
 			msg := types.NewMsgSubmitProposal(content, amount, cliCtx.GetFromAddress())
+			msg.IsExpedited = proposal.Expedited
 			if err := msg.ValidateBasic(); err != nil {
 				return err
 			}
@@ -143,6 +149,7 @@ $ %s tx gov submit-proposal --title="Test Proposal" --description="My awesome pr
 	cmd.Flags().String(flagDeposit, "", "deposit of proposal")
 	cmd.Flags().String(flagProposal, "",
 		"proposal file path (if this path is given, other proposal flags are ignored)")
+	cmd.Flags().Bool(FlagExpedited, false, "submit the proposal as an expedited one")
 
 	return cmd
 }
@@ -81,8 +81,28 @@ func handleActiveProposals(ctx sdk.Context, k keeper.Keeper, logger log.Logger)
 	k.IterateActiveProposalsQueue(ctx, ctx.BlockHeader().Time, func(proposal Proposal) bool {
 
 		status, distribute, tallyResults := keeper.Tally(ctx, k, proposal, true)
-		tagValue, logMsg := handleProposalAfterTally(ctx, k, &proposal, distribute, status)
 		proposal.FinalTallyResult = tallyResults
+		if proposal.Expedited && status != StatusPassed {
+			// the expedited proposal falls back to a regular one, keeping its votes and deposits
+			k.ConvertExpeditedToRegular(ctx, &proposal)
+
+			logger.Info(
+				fmt.Sprintf("expedited proposal %d (%s) failed the expedited tally; converted to regular, voting ends at %s",
+					proposal.ProposalID, proposal.GetTitle(), proposal.VotingEndTime,
+				),
+			)
+
+			ctx.EventManager().EmitEvent(
+				sdk.NewEvent(
+					types.EventTypeActiveProposal,
+					sdk.NewAttribute(types.AttributeKeyProposalID, fmt.Sprintf("%d", proposal.ProposalID)),
+					sdk.NewAttribute(types.AttributeKeyProposalResult, types.AttributeValueExpeditedProposalRejected),
+				),
+			)
+			return false
+		}
+
+		tagValue, logMsg := handleProposalAfterTally(ctx, k, &proposal, distribute, status)
 		k.SetProposal(ctx, proposal)
 		k.RemoveFromActiveProposalQueue(ctx, proposal.ProposalID, proposal.VotingEndTime)
 		k.DeleteVotes(ctx, proposal.ProposalID)
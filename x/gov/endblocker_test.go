@@ -11,6 +11,7 @@ import (
 	"github.com/okex/exchain/x/staking"
 
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 	"github.com/stretchr/testify/require"
 )
 
@@ -238,3 +239,81 @@ func TestEndBlockerIterateWaitingProposalsQueue(t *testing.T) {
 	require.False(t, waitingQueue.Valid())
 	waitingQueue.Close()
 }
+
+func TestEndBlockerExpeditedProposalConvertedToRegular(t *testing.T) {
+	ctx, _, gk, sk, _ := keeper.CreateTestInput(t, false, 100000)
+	govHandler := NewHandler(gk)
+
+	ctx.SetBlockHeight(int64(sk.GetEpoch(ctx)))
+	skHandler := staking.NewHandler(sk)
+	valAddrs := make([]sdk.ValAddress, len(keeper.Addrs[:4]))
+	for i, addr := range keeper.Addrs[:4] {
+		valAddrs[i] = sdk.ValAddress(addr)
+	}
+	keeper.CreateValidators(t, skHandler, ctx, valAddrs, []int64{10, 10, 10, 10})
+	staking.EndBlocker(ctx, sk)
+
+	content := types.NewTextProposal("Test", "description")
+	initialDeposit := sdk.SysCoins{sdk.NewInt64DecCoin(sdk.DefaultBondDenom, 500)}
+	newProposalMsg := types.NewMsgSubmitExpeditedProposal(content, initialDeposit, keeper.Addrs[0])
+
+	// expedited proposals are disabled without params
+	_, err := govHandler(ctx, newProposalMsg)
+	require.NotNil(t, err)
+
+	expeditedParams := NewExpeditedParams(initialDeposit, time.Hour*24, sdk.NewDecWithPrec(667, 3))
+	gk.SetExpeditedParams(ctx, expeditedParams)
+
+	// expedited proposals are disabled before the jupiter height
+	_, err = govHandler(ctx, newProposalMsg)
+	require.NotNil(t, err)
+	tmtypes.UnittestOnlySetMilestoneJupiterHeight(1)
+	defer tmtypes.UnittestOnlySetMilestoneJupiterHeight(0)
+
+	// initial deposit is checked against the expedited min deposit
+	_, err = govHandler(ctx, types.NewMsgSubmitExpeditedProposal(content,
+		sdk.SysCoins{sdk.NewInt64DecCoin(sdk.DefaultBondDenom, 20)}, keeper.Addrs[0]))
+	require.NotNil(t, err)
+
+	res, err := govHandler(ctx, newProposalMsg)
+	require.Nil(t, err)
+	var proposalID uint64
+	gk.Cdc().MustUnmarshalBinaryLengthPrefixed(res.Data, &proposalID)
+
+	proposal, ok := gk.GetProposal(ctx, proposalID)
+	require.True(t, ok)
+	require.True(t, proposal.Expedited)
+	require.Equal(t, StatusVotingPeriod, proposal.Status)
+	require.Equal(t, proposal.VotingStartTime.Add(expeditedParams.VotingPeriod), proposal.VotingEndTime)
+
+	// 2/3 of the voted power is enough for a regular proposal but not for an expedited one
+	for i, option := range []types.VoteOption{types.OptionYes, types.OptionYes, types.OptionNo} {
+		_, err = govHandler(ctx, NewMsgVote(keeper.Addrs[i], proposalID, option))
+		require.Nil(t, err)
+	}
+
+	newHeader := ctx.BlockHeader()
+	newHeader.Time = proposal.VotingEndTime
+	ctx.SetBlockHeader(newHeader)
+	EndBlocker(ctx, gk)
+
+	proposal, ok = gk.GetProposal(ctx, proposalID)
+	require.True(t, ok)
+	require.False(t, proposal.Expedited)
+	require.Equal(t, StatusVotingPeriod, proposal.Status)
+	require.Equal(t, proposal.VotingStartTime.Add(gk.GetVotingPeriod(ctx, nil)), proposal.VotingEndTime)
+	require.Equal(t, 3, len(gk.GetVotes(ctx, proposalID)))
+	require.Equal(t, initialDeposit, gk.SupplyKeeper().GetModuleAccount(ctx, types.ModuleName).GetCoins())
+
+	newHeader = ctx.BlockHeader()
+	newHeader.Time = proposal.VotingEndTime
+	ctx.SetBlockHeader(newHeader)
+	EndBlocker(ctx, gk)
+
+	proposal, ok = gk.GetProposal(ctx, proposalID)
+	require.True(t, ok)
+	require.Equal(t, StatusPassed, proposal.Status)
+	activeQueue := gk.ActiveProposalQueueIterator(ctx, ctx.BlockHeader().Time)
+	require.False(t, activeQueue.Valid())
+	activeQueue.Close()
+}
@@ -21,6 +21,7 @@ type GenesisState struct {
 	DepositParams      DepositParams     `json:"deposit_params" yaml:"deposit_params"`
 	VotingParams       VotingParams      `json:"voting_params" yaml:"voting_params"`
 	TallyParams        TallyParams       `json:"tally_params" yaml:"tally_params"`
+	ExpeditedParams    ExpeditedParams   `json:"expedited_params" yaml:"expedited_params"`
 }
 
 // DefaultGenesisState get raw genesis raw message for testing
@@ -42,6 +43,11 @@ func DefaultGenesisState() GenesisState {
 			Veto:            sdk.NewDecWithPrec(334, 3),
 			YesInVotePeriod: sdk.NewDecWithPrec(667, 3),
 		},
+		ExpeditedParams: ExpeditedParams{
+			MinDeposit:   sdk.SysCoins{sdk.NewDecCoin(sdk.DefaultBondDenom, sdk.NewInt(500))},
+			VotingPeriod: time.Hour * 24,
+			Threshold:    sdk.NewDecWithPrec(667, 3),
+		},
 	}
 }
 
@@ -89,6 +95,15 @@ func ValidateGenesis(data GenesisState) error {
 			data.DepositParams.MinDeposit.String())
 	}
 
+	if data.ExpeditedParams.Enabled() {
+		if err := types.ValidateExpeditedParams(data.ExpeditedParams); err != nil {
+			return fmt.Errorf("governance expedited params are invalid: %s", err)
+		}
+		if err := data.ExpeditedParams.ValidateAgainst(data.DepositParams, data.VotingParams, data.TallyParams); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -98,6 +113,9 @@ func InitGenesis(ctx sdk.Context, k keeper.Keeper, supplyKeeper keeper.SupplyKee
 	k.SetDepositParams(ctx, data.DepositParams)
 	k.SetVotingParams(ctx, data.VotingParams)
 	k.SetTallyParams(ctx, data.TallyParams)
+	if data.ExpeditedParams.Enabled() {
+		k.SetExpeditedParams(ctx, data.ExpeditedParams)
+	}
 
 	// check if the deposits pool account exists
 	moduleAcc := k.GetGovernanceAccount(ctx)
@@ -151,6 +169,7 @@ func ExportGenesis(ctx sdk.Context, k keeper.Keeper) GenesisState {
 	depositParams := k.GetDepositParams(ctx)
 	votingParams := k.GetVotingParams(ctx)
 	tallyParams := k.GetTallyParams(ctx)
+	expeditedParams := k.GetExpeditedParams(ctx)
 
 	proposals := k.GetProposalsFiltered(ctx, nil, nil, StatusNil, 0)
 
@@ -179,5 +198,6 @@ func ExportGenesis(ctx sdk.Context, k keeper.Keeper) GenesisState {
 		DepositParams:      depositParams,
 		VotingParams:       votingParams,
 		TallyParams:        tallyParams,
+		ExpeditedParams:    expeditedParams,
 	}
 }
@@ -180,6 +180,23 @@ func TestValidateGenesis(t *testing.T) {
 	require.Nil(t, err)
 	data.DepositParams.MinDeposit = sdk.SysCoins{sdk.SysCoin{Denom: sdk.DefaultBondDenom, Amount: coin}}
 	require.NotNil(t, ValidateGenesis(data))
+
+	data = DefaultGenesisState()
+	require.Nil(t, ValidateGenesis(data))
+	data.ExpeditedParams.VotingPeriod = data.VotingParams.VotingPeriod
+	require.NotNil(t, ValidateGenesis(data))
+
+	data.ExpeditedParams.VotingPeriod = time.Hour
+	data.ExpeditedParams.Threshold = data.TallyParams.Threshold
+	require.NotNil(t, ValidateGenesis(data))
+
+	data.ExpeditedParams.Threshold = sdk.NewDecWithPrec(667, 3)
+	data.ExpeditedParams.MinDeposit = sdk.SysCoins{sdk.NewDecCoin(sdk.DefaultBondDenom, sdk.NewInt(10))}
+	require.NotNil(t, ValidateGenesis(data))
+
+	// zero voting period disables expedited proposals
+	data.ExpeditedParams = types.DefaultExpeditedParams()
+	require.Nil(t, ValidateGenesis(data))
 }
 
 func TestGenesisState_Equal(t *testing.T) {
@@ -200,6 +217,11 @@ func TestGenesisState_Equal(t *testing.T) {
 			Veto:            sdk.NewDecWithPrec(334, 3),
 			YesInVotePeriod: sdk.NewDecWithPrec(667, 3),
 		},
+		ExpeditedParams: ExpeditedParams{
+			MinDeposit:   sdk.SysCoins{sdk.NewDecCoin(sdk.DefaultBondDenom, sdk.NewInt(500))},
+			VotingPeriod: time.Hour * 24,
+			Threshold:    sdk.NewDecWithPrec(667, 3),
+		},
 	}
 	require.True(t, expected.equal(DefaultGenesisState()))
 }
@@ -48,7 +48,16 @@ func handleMsgSubmitProposal(ctx sdk.Context, keeper keeper.Keeper, msg MsgSubmi
 		return sdk.EnvelopedErr{err}.Result()
 	}
 
-	proposal, err := keeper.SubmitProposal(ctx, msg.Content)
+	var proposal types.Proposal
+	if msg.IsExpedited {
+		err = keeper.CheckExpeditedInitialDeposit(ctx, msg)
+		if err != nil {
+			return sdk.EnvelopedErr{err}.Result()
+		}
+		proposal, err = keeper.SubmitExpeditedProposal(ctx, msg.Content)
+	} else {
+		proposal, err = keeper.SubmitProposal(ctx, msg.Content)
+	}
 	if err != nil {
 		return sdk.EnvelopedErr{err}.Result()
 	}
@@ -13,6 +13,29 @@ func (keeper Keeper) SetDeposit(ctx sdk.Context, deposit types.Deposit) {
 	store.Set(types.DepositKey(deposit.ProposalID, deposit.Depositor), bz)
 }
 
+// getMinDeposit returns the min deposit for the proposal to enter the voting period. An expedited proposal
+// requires the expedited min deposit, and no less than the min deposit of its route.
+func (keeper Keeper) getMinDeposit(ctx sdk.Context, content types.Content, expedited bool) sdk.SysCoins {
+	var minDeposit sdk.SysCoins
+	if !keeper.proposalHandlerRouter.HasRoute(content.ProposalRoute()) {
+		minDeposit = keeper.GetDepositParams(ctx).MinDeposit
+	} else {
+		phr := keeper.proposalHandlerRouter.GetRoute(content.ProposalRoute())
+		minDeposit = phr.GetMinDeposit(ctx, content)
+	}
+	if !expedited {
+		return minDeposit
+	}
+
+	expeditedMinDeposit := keeper.GetExpeditedParams(ctx).MinDeposit
+	var result sdk.SysCoins
+	for _, coin := range minDeposit.Add(expeditedMinDeposit...) {
+		amount := sdk.MaxDec(minDeposit.AmountOf(coin.Denom), expeditedMinDeposit.AmountOf(coin.Denom))
+		result = result.Add(sdk.NewDecCoinFromDec(coin.Denom, amount))
+	}
+	return result
+}
+
 func tryEnterVotingPeriod(
 	ctx sdk.Context, keeper Keeper, proposal *types.Proposal, depositAmount sdk.SysCoins, eventType string,
 ) {
@@ -20,13 +43,7 @@ func tryEnterVotingPeriod(
 	proposal.TotalDeposit = proposal.TotalDeposit.Add(depositAmount...)
 	// Check if deposit has provided sufficient total funds to transition the proposal into the voting period
 	activatedVotingPeriod := false
-	var minDeposit sdk.SysCoins
-	if !keeper.proposalHandlerRouter.HasRoute(proposal.ProposalRoute()) {
-		minDeposit = keeper.GetDepositParams(ctx).MinDeposit
-	} else {
-		phr := keeper.proposalHandlerRouter.GetRoute(proposal.ProposalRoute())
-		minDeposit = phr.GetMinDeposit(ctx, proposal.Content)
-	}
+	minDeposit := keeper.getMinDeposit(ctx, proposal.Content, proposal.Expedited)
 
 	if proposal.Status == types.StatusDepositPeriod && proposal.TotalDeposit.IsAllGTE(minDeposit) {
 		keeper.activateVotingPeriod(ctx, proposal)
@@ -2,8 +2,10 @@ package keeper
 
 import (
 	"testing"
+	"time"
 
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 	"github.com/stretchr/testify/require"
 
 	"github.com/okex/exchain/x/gov/types"
@@ -58,6 +60,32 @@ func TestKeeper_AddDeposit(t *testing.T) {
 	require.Nil(t, err)
 }
 
+func TestKeeper_AddExpeditedDeposit(t *testing.T) {
+	ctx, _, keeper, _, _ := CreateTestInput(t, false, 1000)
+	tmtypes.UnittestOnlySetMilestoneJupiterHeight(1)
+	defer tmtypes.UnittestOnlySetMilestoneJupiterHeight(0)
+	ctx.SetBlockHeight(1)
+
+	// the expedited min deposit is lower than the min deposit
+	keeper.SetExpeditedParams(ctx, types.NewExpeditedParams(sdk.SysCoins{sdk.NewInt64DecCoin(sdk.DefaultBondDenom, 50)},
+		time.Hour, sdk.NewDecWithPrec(667, 3)))
+	proposal, err := keeper.SubmitExpeditedProposal(ctx, types.NewTextProposal("Test", "description"))
+	require.Nil(t, err)
+
+	// an expedited proposal requires no less than the min deposit
+	err = keeper.AddDeposit(ctx, proposal.ProposalID, Addrs[0],
+		sdk.SysCoins{sdk.NewInt64DecCoin(sdk.DefaultBondDenom, 50)}, "")
+	require.Nil(t, err)
+	proposal, _ = keeper.GetProposal(ctx, proposal.ProposalID)
+	require.Equal(t, types.StatusDepositPeriod, proposal.Status)
+
+	err = keeper.AddDeposit(ctx, proposal.ProposalID, Addrs[0],
+		sdk.SysCoins{sdk.NewInt64DecCoin(sdk.DefaultBondDenom, 50)}, "")
+	require.Nil(t, err)
+	proposal, _ = keeper.GetProposal(ctx, proposal.ProposalID)
+	require.Equal(t, types.StatusVotingPeriod, proposal.Status)
+}
+
 func TestKeeper_GetDeposit(t *testing.T) {
 	ctx, _, keeper, _, _ := CreateTestInput(t, false, 1000)
 
@@ -12,6 +12,7 @@ import (
 	"github.com/okex/exchain/libs/cosmos-sdk/codec"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	"github.com/okex/exchain/libs/tendermint/libs/log"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 	"github.com/okex/exchain/x/params"
 )
 
@@ -121,6 +122,14 @@ func (keeper Keeper) GetTallyParams(ctx sdk.Context) types.TallyParams {
 	return tallyParams
 }
 
+// GetExpeditedParams returns the current ExpeditedParams from the global param store.
+// Expedited proposals stay disabled until the params are set by genesis or governance.
+func (keeper Keeper) GetExpeditedParams(ctx sdk.Context) types.ExpeditedParams {
+	expeditedParams := types.DefaultExpeditedParams()
+	keeper.paramSpace.GetIfExists(ctx, types.ParamStoreKeyExpeditedParams, &expeditedParams)
+	return expeditedParams
+}
+
 // SetDepositParams sets the current DepositParams to the global param store
 func (keeper Keeper) SetDepositParams(ctx sdk.Context, depositParams types.DepositParams) {
 	keeper.paramSpace.Set(ctx, types.ParamStoreKeyDepositParams, &depositParams)
@@ -136,6 +145,16 @@ func (keeper Keeper) SetTallyParams(ctx sdk.Context, tallyParams types.TallyPara
 	keeper.paramSpace.Set(ctx, types.ParamStoreKeyTallyParams, &tallyParams)
 }
 
+// isExpeditedEnabled returns whether expedited proposals can be submitted, which is not before the jupiter height
+func (keeper Keeper) isExpeditedEnabled(ctx sdk.Context) bool {
+	return tmtypes.HigherThanJupiter(ctx.BlockHeight()) && keeper.GetExpeditedParams(ctx).Enabled()
+}
+
+// SetExpeditedParams sets the current ExpeditedParams to the global param store
+func (keeper Keeper) SetExpeditedParams(ctx sdk.Context, expeditedParams types.ExpeditedParams) {
+	keeper.paramSpace.Set(ctx, types.ParamStoreKeyExpeditedParams, &expeditedParams)
+}
+
 // ProposalQueues
 
 // WaitingProposalQueueIterator returns an iterator for all the proposals in the Waiting Queue that expire by endTime
@@ -341,6 +360,18 @@ func (keeper Keeper) CheckMsgSubmitProposal(ctx sdk.Context, msg types.MsgSubmit
 	return nil
 }
 
+// CheckExpeditedInitialDeposit checks the initial deposit of an expedited proposal against the expedited MinDeposit
+func (keeper Keeper) CheckExpeditedInitialDeposit(ctx sdk.Context, msg types.MsgSubmitProposal) sdk.Error {
+	if !keeper.isExpeditedEnabled(ctx) {
+		return types.ErrExpeditedProposalDisabled()
+	}
+	initDeposit := keeper.getMinDeposit(ctx, msg.Content, true).MulDec(sdk.NewDecWithPrec(1, 1))
+	if err := common.HasSufficientCoins(msg.Proposer, msg.InitialDeposit, initDeposit); err != nil {
+		return types.ErrInitialDepositNotEnough(initDeposit.String())
+	}
+	return nil
+}
+
 // nolint
 func (keeper Keeper) AfterSubmitProposalHandler(ctx sdk.Context, proposal types.Proposal) {}
 
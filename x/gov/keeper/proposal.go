@@ -10,6 +10,18 @@ import (
 
 // SubmitProposal creates new proposal given a content
 func (keeper Keeper) SubmitProposal(ctx sdk.Context, content types.Content) (types.Proposal, sdk.Error) {
+	return keeper.submitProposal(ctx, content, false)
+}
+
+// SubmitExpeditedProposal creates new expedited proposal given a content
+func (keeper Keeper) SubmitExpeditedProposal(ctx sdk.Context, content types.Content) (types.Proposal, sdk.Error) {
+	if !keeper.isExpeditedEnabled(ctx) {
+		return types.Proposal{}, types.ErrExpeditedProposalDisabled()
+	}
+	return keeper.submitProposal(ctx, content, true)
+}
+
+func (keeper Keeper) submitProposal(ctx sdk.Context, content types.Content, expedited bool) (types.Proposal, sdk.Error) {
 	if !keeper.router.HasRoute(content.ProposalRoute()) {
 		return types.Proposal{}, types.ErrNoProposalHandlerExists(content)
 	}
@@ -30,6 +42,7 @@ func (keeper Keeper) SubmitProposal(ctx sdk.Context, content types.Content) (typ
 	}
 	proposal := types.NewProposal(ctx, keeper.totalPower(ctx), content, proposalID, submitTime,
 		submitTime.Add(depositPeriod))
+	proposal.Expedited = expedited
 
 	keeper.SetProposal(ctx, proposal)
 	keeper.InsertInactiveProposalQueue(ctx, proposalID, proposal.DepositEndTime)
@@ -159,11 +172,10 @@ func (keeper Keeper) SetProposalID(ctx sdk.Context, proposalID uint64) {
 func (keeper Keeper) activateVotingPeriod(ctx sdk.Context, proposal *types.Proposal) {
 	proposal.VotingStartTime = ctx.BlockHeader().Time
 	var votingPeriod time.Duration
-	if !keeper.proposalHandlerRouter.HasRoute(proposal.ProposalRoute()) {
-		votingPeriod = keeper.GetVotingPeriod(ctx, proposal.Content)
+	if proposal.Expedited {
+		votingPeriod = keeper.GetExpeditedParams(ctx).VotingPeriod
 	} else {
-		phr := keeper.proposalHandlerRouter.GetRoute(proposal.ProposalRoute())
-		votingPeriod = phr.GetVotingPeriod(ctx, proposal.Content)
+		votingPeriod = keeper.regularVotingPeriod(ctx, proposal.Content)
 	}
 	// calculate the end time of voting
 	proposal.VotingEndTime = proposal.VotingStartTime.Add(votingPeriod)
@@ -172,3 +184,27 @@ func (keeper Keeper) activateVotingPeriod(ctx sdk.Context, proposal *types.Propo
 	keeper.RemoveFromInactiveProposalQueue(ctx, proposal.ProposalID, proposal.DepositEndTime)
 	keeper.InsertActiveProposalQueue(ctx, proposal.ProposalID, proposal.VotingEndTime)
 }
+
+// ConvertExpeditedToRegular turns an expedited proposal which failed the expedited tally into a regular one.
+// The voting period is extended to the regular one counted from the original voting start time, while votes
+// and deposits are kept to be tallied again at the new voting end time.
+func (keeper Keeper) ConvertExpeditedToRegular(ctx sdk.Context, proposal *types.Proposal) {
+	keeper.RemoveFromActiveProposalQueue(ctx, proposal.ProposalID, proposal.VotingEndTime)
+
+	proposal.Expedited = false
+	proposal.VotingEndTime = proposal.VotingStartTime.Add(keeper.regularVotingPeriod(ctx, proposal.Content))
+	// never schedule the regular tally into the past
+	if blockTime := ctx.BlockHeader().Time; !proposal.VotingEndTime.After(blockTime) {
+		proposal.VotingEndTime = blockTime.Add(keeper.GetVotingParams(ctx).VotingPeriod)
+	}
+
+	keeper.InsertActiveProposalQueue(ctx, proposal.ProposalID, proposal.VotingEndTime)
+	keeper.SetProposal(ctx, *proposal)
+}
+
+func (keeper Keeper) regularVotingPeriod(ctx sdk.Context, content types.Content) time.Duration {
+	if !keeper.proposalHandlerRouter.HasRoute(content.ProposalRoute()) {
+		return keeper.GetVotingPeriod(ctx, content)
+	}
+	return keeper.proposalHandlerRouter.GetRoute(content.ProposalRoute()).GetVotingPeriod(ctx, content)
+}
@@ -134,9 +134,15 @@ func preTally(
 
 // tally and return status before voting period end time
 func tallyStatusInVotePeriod(
-	ctx sdk.Context, keeper Keeper, tallyResults types.TallyResult,
+	ctx sdk.Context, keeper Keeper, tallyResults types.TallyResult, expedited bool,
 ) (types.ProposalStatus, bool) {
 	tallyParams := keeper.GetTallyParams(ctx)
+	if expedited {
+		// an expedited proposal never passes early with less Yes than its own threshold
+		if threshold := keeper.GetExpeditedParams(ctx).Threshold; threshold.GT(tallyParams.YesInVotePeriod) {
+			tallyParams.YesInVotePeriod = threshold
+		}
+	}
 	totalPower := tallyResults.TotalPower
 	// TODO: Upgrade the spec to cover all of these cases & remove pseudocode.
 	// If there is no staked coins, the proposal fails
@@ -166,9 +172,12 @@ func tallyStatusInVotePeriod(
 
 // tally and return status expire voting period end time
 func tallyStatusExpireVotePeriod(
-	ctx sdk.Context, keeper Keeper, tallyResults types.TallyResult,
+	ctx sdk.Context, keeper Keeper, tallyResults types.TallyResult, expedited bool,
 ) (types.ProposalStatus, bool) {
 	tallyParams := keeper.GetTallyParams(ctx)
+	if expedited {
+		tallyParams.Threshold = keeper.GetExpeditedParams(ctx).Threshold
+	}
 	totalVoted := tallyResults.TotalVotedPower
 	totalPower := tallyResults.TotalPower
 	// TODO: Upgrade the spec to cover all of these cases & remove pseudo code.
@@ -207,9 +216,9 @@ func Tally(ctx sdk.Context, keeper Keeper, proposal types.Proposal, isExpireVote
 	tallyResults.TotalVotedPower = totalVotedPower
 
 	if isExpireVoteEndTime {
-		status, distribute := tallyStatusExpireVotePeriod(ctx, keeper, tallyResults)
+		status, distribute := tallyStatusExpireVotePeriod(ctx, keeper, tallyResults, proposal.Expedited)
 		return status, distribute, tallyResults
 	}
-	status, distribute := tallyStatusInVotePeriod(ctx, keeper, tallyResults)
+	status, distribute := tallyStatusInVotePeriod(ctx, keeper, tallyResults, proposal.Expedited)
 	return status, distribute, tallyResults
 }
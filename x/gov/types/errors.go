@@ -24,6 +24,7 @@ const (
 	CodeInvalidHeight            uint32 = BaseGovError + 10
 	CodeInvalidCoins             uint32 = BaseGovError + 11
 	CodeUnknownParamType         uint32 = BaseGovError + 12
+	CodeExpeditedNotEnabled      uint32 = BaseGovError + 13
 )
 
 func ErrInvalidAddress(address string) sdk.Error {
@@ -80,3 +81,7 @@ func ErrInvalidCoins() sdk.Error {
 func ErrUnknownGovParamType() sdk.Error {
 	return sdkerrors.New(DefaultCodespace, CodeUnknownParamType, "unkonwn gov param type")
 }
+
+func ErrExpeditedProposalDisabled() sdk.Error {
+	return sdkerrors.New(DefaultCodespace, CodeExpeditedNotEnabled, "expedited proposals are disabled")
+}
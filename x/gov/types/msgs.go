@@ -18,12 +18,20 @@ var _, _, _ sdk.Msg = MsgSubmitProposal{}, MsgDeposit{}, MsgVote{}
 // MsgSubmitProposal
 type MsgSubmitProposal struct {
 	Content        Content        `json:"content" yaml:"content"`
-	InitialDeposit sdk.SysCoins   `json:"initial_deposit" yaml:"initial_deposit"` //  Initial deposit paid by sender. Must be strictly positive
-	Proposer       sdk.AccAddress `json:"proposer" yaml:"proposer"`               //  Address of the proposer
+	InitialDeposit sdk.SysCoins   `json:"initial_deposit" yaml:"initial_deposit"`               //  Initial deposit paid by sender. Must be strictly positive
+	Proposer       sdk.AccAddress `json:"proposer" yaml:"proposer"`                             //  Address of the proposer
+	IsExpedited    bool           `json:"is_expedited,omitempty" yaml:"is_expedited,omitempty"` //  Whether to submit an expedited proposal
 }
 
 func NewMsgSubmitProposal(content Content, initialDeposit sdk.SysCoins, proposer sdk.AccAddress) MsgSubmitProposal {
-	return MsgSubmitProposal{content, initialDeposit, proposer}
+	return MsgSubmitProposal{Content: content, InitialDeposit: initialDeposit, Proposer: proposer}
+}
+
+// NewMsgSubmitExpeditedProposal creates a MsgSubmitProposal which requests the expedited voting process
+func NewMsgSubmitExpeditedProposal(content Content, initialDeposit sdk.SysCoins, proposer sdk.AccAddress) MsgSubmitProposal {
+	msg := NewMsgSubmitProposal(content, initialDeposit, proposer)
+	msg.IsExpedited = true
+	return msg
 }
 
 //nolint
@@ -67,7 +75,8 @@ func (msg MsgSubmitProposal) String() string {
 	return fmt.Sprintf(`Submit Proposal Message:
   Content:         %s
   Initial Deposit: %s
-`, msg.Content.String(), msg.InitialDeposit)
+  Expedited:       %t
+`, msg.Content.String(), msg.InitialDeposit, msg.IsExpedited)
 }
 
 // Implements Msg.
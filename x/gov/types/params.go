@@ -10,9 +10,10 @@ import (
 
 // Parameter store key
 var (
-	ParamStoreKeyDepositParams = []byte("depositparams")
-	ParamStoreKeyVotingParams  = []byte("votingparams")
-	ParamStoreKeyTallyParams   = []byte("tallyparams")
+	ParamStoreKeyDepositParams   = []byte("depositparams")
+	ParamStoreKeyVotingParams    = []byte("votingparams")
+	ParamStoreKeyTallyParams     = []byte("tallyparams")
+	ParamStoreKeyExpeditedParams = []byte("expeditedparams")
 )
 
 // Key declaration for parameters
@@ -22,6 +23,7 @@ func ParamKeyTable() subspace.KeyTable {
 			{ParamStoreKeyDepositParams, DepositParams{}, validateDepositParams},
 			{ParamStoreKeyVotingParams, VotingParams{}, validateVotingParams},
 			{ParamStoreKeyTallyParams, TallyParams{}, validateTallyParams},
+			{ParamStoreKeyExpeditedParams, ExpeditedParams{}, validateExpeditedParams},
 		}...,
 	)
 }
@@ -182,23 +184,110 @@ func validateVotingParams(i interface{}) error {
 	return nil
 }
 
+// Param around expedited proposals in governance
+type ExpeditedParams struct {
+	MinDeposit   sdk.SysCoins  `json:"min_deposit,omitempty" yaml:"min_deposit,omitempty"`     //  Minimum deposit for an expedited proposal to enter voting period.
+	VotingPeriod time.Duration `json:"voting_period,omitempty" yaml:"voting_period,omitempty"` //  Length of the voting period of an expedited proposal.
+	Threshold    sdk.Dec       `json:"threshold,omitempty" yaml:"threshold,omitempty"`         //  Minimum proportion of Yes votes for an expedited proposal to pass.
+}
+
+// NewExpeditedParams creates a new ExpeditedParams object
+func NewExpeditedParams(minDeposit sdk.SysCoins, votingPeriod time.Duration, threshold sdk.Dec) ExpeditedParams {
+	return ExpeditedParams{
+		MinDeposit:   minDeposit,
+		VotingPeriod: votingPeriod,
+		Threshold:    threshold,
+	}
+}
+
+// DefaultExpeditedParams returns the params which disable expedited proposals
+func DefaultExpeditedParams() ExpeditedParams {
+	return NewExpeditedParams(sdk.SysCoins{}, 0, sdk.ZeroDec())
+}
+
+// Enabled returns whether expedited proposals are allowed to be submitted
+func (ep ExpeditedParams) Enabled() bool {
+	return ep.VotingPeriod > 0
+}
+
+func (ep ExpeditedParams) String() string {
+	return fmt.Sprintf(`Expedited Params:
+  Min Deposit:        %s
+  Voting Period:      %s
+  Threshold:          %s`, ep.MinDeposit, ep.VotingPeriod, ep.Threshold)
+}
+
+// ValidateAgainst checks the expedited params are stricter than the regular ones
+func (ep ExpeditedParams) ValidateAgainst(dp DepositParams, vp VotingParams, tp TallyParams) error {
+	if !ep.Enabled() {
+		return nil
+	}
+	if ep.VotingPeriod >= vp.VotingPeriod {
+		return fmt.Errorf("expedited voting period %s must be strictly less than the voting period %s",
+			ep.VotingPeriod, vp.VotingPeriod)
+	}
+	if !ep.MinDeposit.IsAllGTE(dp.MinDeposit) {
+		return fmt.Errorf("expedited minimum deposit %s must be greater than or equal to the minimum deposit %s",
+			ep.MinDeposit, dp.MinDeposit)
+	}
+	if ep.Threshold.LTE(tp.Threshold) {
+		return fmt.Errorf("expedited vote threshold %s must be greater than the vote threshold %s",
+			ep.Threshold, tp.Threshold)
+	}
+	return nil
+}
+
+// ValidateExpeditedParams checks the expedited params are valid on their own
+func ValidateExpeditedParams(ep ExpeditedParams) error {
+	return validateExpeditedParams(ep)
+}
+
+func validateExpeditedParams(i interface{}) error {
+	v, ok := i.(ExpeditedParams)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	// zero voting period disables expedited proposals
+	if v.VotingPeriod == 0 {
+		return nil
+	}
+	if v.VotingPeriod < 0 {
+		return fmt.Errorf("expedited voting period must be positive: %s", v.VotingPeriod)
+	}
+	if !v.MinDeposit.IsValid() {
+		return fmt.Errorf("invalid expedited minimum deposit: %s", v.MinDeposit)
+	}
+	if !v.Threshold.IsPositive() {
+		return fmt.Errorf("expedited vote threshold must be positive: %s", v.Threshold)
+	}
+	if v.Threshold.GT(sdk.OneDec()) {
+		return fmt.Errorf("expedited vote threshold too large: %s", v)
+	}
+
+	return nil
+}
+
 // Params returns all of the governance params
 type Params struct {
-	VotingParams  VotingParams  `json:"voting_params" yaml:"voting_params"`
-	TallyParams   TallyParams   `json:"tally_params" yaml:"tally_params"`
-	DepositParams DepositParams `json:"deposit_params" yaml:"deposit_parmas"`
+	VotingParams    VotingParams    `json:"voting_params" yaml:"voting_params"`
+	TallyParams     TallyParams     `json:"tally_params" yaml:"tally_params"`
+	DepositParams   DepositParams   `json:"deposit_params" yaml:"deposit_parmas"`
+	ExpeditedParams ExpeditedParams `json:"expedited_params" yaml:"expedited_params"`
 }
 
 func (gp Params) String() string {
 	return gp.VotingParams.String() + "\n" +
 		gp.TallyParams.String() + "\n" +
-		gp.DepositParams.String()
+		gp.DepositParams.String() + "\n" +
+		gp.ExpeditedParams.String()
 }
 
-func NewParams(vp VotingParams, tp TallyParams, dp DepositParams) Params {
+func NewParams(vp VotingParams, tp TallyParams, dp DepositParams, ep ExpeditedParams) Params {
 	return Params{
-		VotingParams:  vp,
-		DepositParams: dp,
-		TallyParams:   tp,
+		VotingParams:    vp,
+		DepositParams:   dp,
+		TallyParams:     tp,
+		ExpeditedParams: ep,
 	}
 }
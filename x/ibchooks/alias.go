@@ -0,0 +1,18 @@
+package ibchooks
+
+import (
+	"github.com/okex/exchain/x/ibchooks/keeper"
+	"github.com/okex/exchain/x/ibchooks/types"
+)
+
+const (
+	ModuleName = types.ModuleName
+)
+
+var (
+	NewKeeper = keeper.NewKeeper
+)
+
+type (
+	Keeper = keeper.Keeper
+)
@@ -0,0 +1,265 @@
+package ibchooks
+
+import (
+	"encoding/json"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	capabilitytypes "github.com/okex/exchain/libs/cosmos-sdk/x/capability/types"
+	transfertypes "github.com/okex/exchain/libs/ibc-go/modules/apps/transfer/types"
+	channeltypes "github.com/okex/exchain/libs/ibc-go/modules/core/04-channel/types"
+	porttypes "github.com/okex/exchain/libs/ibc-go/modules/core/05-port/types"
+	"github.com/okex/exchain/libs/ibc-go/modules/core/exported"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	"github.com/okex/exchain/x/ibchooks/keeper"
+	"github.com/okex/exchain/x/ibchooks/types"
+)
+
+var _ porttypes.Middleware = &IBCMiddleware{}
+
+// IBCMiddleware implements the ICS26 callbacks of the ibc hooks middleware, which wraps the ics20 transfer module
+// to execute the wasm or evm contract named in the memo of the received packets with the received tokens.
+type IBCMiddleware struct {
+	app         porttypes.IBCModule
+	ics4Wrapper porttypes.ICS4Wrapper
+	keeper      keeper.Keeper
+}
+
+// NewIBCMiddleware creates a new IBCMiddleware given the keeper, the ics4 wrapper and the underlying application
+func NewIBCMiddleware(app porttypes.IBCModule, ics4Wrapper porttypes.ICS4Wrapper, k keeper.Keeper) IBCMiddleware {
+	return IBCMiddleware{
+		app:         app,
+		ics4Wrapper: ics4Wrapper,
+		keeper:      k,
+	}
+}
+
+// OnChanOpenInit implements the IBCMiddleware interface
+func (im IBCMiddleware) OnChanOpenInit(
+	ctx sdk.Context,
+	order channeltypes.Order,
+	connectionHops []string,
+	portID string,
+	channelID string,
+	chanCap *capabilitytypes.Capability,
+	counterparty channeltypes.Counterparty,
+	version string,
+) (string, error) {
+	return im.app.OnChanOpenInit(ctx, order, connectionHops, portID, channelID, chanCap, counterparty, version)
+}
+
+// OnChanOpenTry implements the IBCMiddleware interface
+func (im IBCMiddleware) OnChanOpenTry(
+	ctx sdk.Context,
+	order channeltypes.Order,
+	connectionHops []string,
+	portID,
+	channelID string,
+	chanCap *capabilitytypes.Capability,
+	counterparty channeltypes.Counterparty,
+	version string,
+	counterpartyVersion string,
+) (string, error) {
+	return im.app.OnChanOpenTry(ctx, order, connectionHops, portID, channelID, chanCap, counterparty, version, counterpartyVersion)
+}
+
+// OnChanOpenAck implements the IBCMiddleware interface
+func (im IBCMiddleware) OnChanOpenAck(
+	ctx sdk.Context,
+	portID,
+	channelID string,
+	counterpartyChannelID string,
+	counterpartyVersion string,
+) error {
+	return im.app.OnChanOpenAck(ctx, portID, channelID, counterpartyChannelID, counterpartyVersion)
+}
+
+// OnChanOpenConfirm implements the IBCMiddleware interface
+func (im IBCMiddleware) OnChanOpenConfirm(
+	ctx sdk.Context,
+	portID,
+	channelID string,
+) error {
+	return im.app.OnChanOpenConfirm(ctx, portID, channelID)
+}
+
+// OnChanCloseInit implements the IBCMiddleware interface
+func (im IBCMiddleware) OnChanCloseInit(
+	ctx sdk.Context,
+	portID,
+	channelID string,
+) error {
+	return im.app.OnChanCloseInit(ctx, portID, channelID)
+}
+
+// OnChanCloseConfirm implements the IBCMiddleware interface
+func (im IBCMiddleware) OnChanCloseConfirm(
+	ctx sdk.Context,
+	portID,
+	channelID string,
+) error {
+	return im.app.OnChanCloseConfirm(ctx, portID, channelID)
+}
+
+// OnRecvPacket implements the IBCMiddleware interface.
+// A packet whose memo carries a hook must be sent to the hook contract. The tokens are received by an intermediary
+// account derived from the channel and the sender, then the contract is executed by the intermediary with the tokens.
+// Packets whose memo does not carry a hook are passed to the underlying app untouched.
+func (im IBCMiddleware) OnRecvPacket(
+	ctx sdk.Context,
+	packet channeltypes.Packet,
+	relayer sdk.AccAddress,
+) exported.Acknowledgement {
+	if !tmtypes.HigherThanJupiter(ctx.BlockHeight()) {
+		return im.app.OnRecvPacket(ctx, packet, relayer)
+	}
+
+	data, err := types.ParsePacketData(packet.GetData())
+	if err != nil || len(data.Memo) == 0 {
+		return im.app.OnRecvPacket(ctx, packet, relayer)
+	}
+	memo, found, err := types.ParseMemo(data.Memo)
+	if err != nil {
+		return channeltypes.NewErrorAcknowledgementV4(err)
+	}
+	if !found {
+		return im.app.OnRecvPacket(ctx, packet, relayer)
+	}
+
+	receiver, err := sdk.WasmAddressFromBech32(data.Receiver)
+	if err != nil || !receiver.Equals(memo.GetContract()) {
+		return channeltypes.NewErrorAcknowledgementV4(
+			sdkerrors.Wrapf(types.ErrInvalidReceiver, "receiver %s must be the hook contract", data.Receiver))
+	}
+
+	intermediary := types.DeriveIntermediary(packet.GetDestChannel(), data.Sender)
+	before := im.keeper.GetCoins(ctx, intermediary)
+	ftpd := data
+	ftpd.Receiver = intermediary.String()
+	ftpd.Memo = ""
+	ack := im.app.OnRecvPacket(ctx, withPacketData(packet, ftpd), relayer)
+	if ack == nil || !ack.Success() {
+		return ack
+	}
+
+	// the tokens may have been converted by the transfer hooks, the hook only runs with the received coins
+	funds, negative := im.keeper.GetCoins(ctx, intermediary).SafeSub(before)
+	if negative || funds.Empty() {
+		return channeltypes.NewErrorAcknowledgementV4(types.ErrNoFundsReceived)
+	}
+
+	result, err := im.keeper.ExecuteHook(ctx, memo, intermediary, funds)
+	if err != nil {
+		return channeltypes.NewErrorAcknowledgementV4(err)
+	}
+	bz, err := json.Marshal(result)
+	if err != nil {
+		return channeltypes.NewErrorAcknowledgementV4(err)
+	}
+	return channeltypes.NewResultAcknowledgement(bz)
+}
+
+// OnAcknowledgementPacket implements the IBCMiddleware interface.
+// The wasm contract that sent the packet is called back with the acknowledgement once the transfer module handled it,
+// if the memo of the packet opted in with {"ibc_callback": "<sender>"}.
+func (im IBCMiddleware) OnAcknowledgementPacket(
+	ctx sdk.Context,
+	packet channeltypes.Packet,
+	acknowledgement []byte,
+	relayer sdk.AccAddress,
+) error {
+	if err := im.app.OnAcknowledgementPacket(ctx, packet, acknowledgement, relayer); err != nil {
+		return err
+	}
+	if !tmtypes.HigherThanJupiter(ctx.BlockHeight()) {
+		return nil
+	}
+
+	data, err := types.ParsePacketData(packet.GetData())
+	if err != nil {
+		return nil
+	}
+	var ack channeltypes.Acknowledgement
+	if err := channeltypes.SubModuleCdc.UnmarshalJSON(acknowledgement, &ack); err != nil {
+		return nil
+	}
+	im.keeper.OnPacketLifecycleComplete(ctx, packet, data.Sender, data.Memo, types.IBCLifecycleComplete{
+		IBCAck: &types.IBCAck{
+			Channel:  packet.GetSourceChannel(),
+			Sequence: packet.GetSequence(),
+			Ack:      string(acknowledgement),
+			Success:  ack.Success(),
+		},
+	})
+	return nil
+}
+
+// OnTimeoutPacket implements the IBCMiddleware interface.
+// The wasm contract that sent the packet is called back with the timeout once the transfer module refunded it,
+// if the memo of the packet opted in to the callback.
+func (im IBCMiddleware) OnTimeoutPacket(
+	ctx sdk.Context,
+	packet channeltypes.Packet,
+	relayer sdk.AccAddress,
+) error {
+	if err := im.app.OnTimeoutPacket(ctx, packet, relayer); err != nil {
+		return err
+	}
+	if !tmtypes.HigherThanJupiter(ctx.BlockHeight()) {
+		return nil
+	}
+
+	data, err := types.ParsePacketData(packet.GetData())
+	if err != nil {
+		return nil
+	}
+	im.keeper.OnPacketLifecycleComplete(ctx, packet, data.Sender, data.Memo, types.IBCLifecycleComplete{
+		IBCTimeout: &types.IBCTimeout{
+			Channel:  packet.GetSourceChannel(),
+			Sequence: packet.GetSequence(),
+		},
+	})
+	return nil
+}
+
+// NegotiateAppVersion implements the IBCMiddleware interface
+func (im IBCMiddleware) NegotiateAppVersion(
+	ctx sdk.Context,
+	order channeltypes.Order,
+	connectionID string,
+	portID string,
+	counterparty channeltypes.Counterparty,
+	proposedVersion string,
+) (string, error) {
+	return im.app.NegotiateAppVersion(ctx, order, connectionID, portID, counterparty, proposedVersion)
+}
+
+// SendPacket implements the ICS4 Wrapper interface
+func (im IBCMiddleware) SendPacket(
+	ctx sdk.Context,
+	chanCap *capabilitytypes.Capability,
+	packet exported.PacketI,
+) error {
+	return im.ics4Wrapper.SendPacket(ctx, chanCap, packet)
+}
+
+// WriteAcknowledgement implements the ICS4 Wrapper interface
+func (im IBCMiddleware) WriteAcknowledgement(
+	ctx sdk.Context,
+	chanCap *capabilitytypes.Capability,
+	packet exported.PacketI,
+	ack exported.Acknowledgement,
+) error {
+	return im.ics4Wrapper.WriteAcknowledgement(ctx, chanCap, packet, ack)
+}
+
+// GetAppVersion returns the application version of the underlying application
+func (im IBCMiddleware) GetAppVersion(ctx sdk.Context, portID, channelID string) (string, bool) {
+	return im.ics4Wrapper.GetAppVersion(ctx, portID, channelID)
+}
+
+// withPacketData returns a copy of the packet with the ics20 packet data
+func withPacketData(packet channeltypes.Packet, data transfertypes.FungibleTokenPacketData) channeltypes.Packet {
+	packet.Data = data.GetBytes()
+	return packet
+}
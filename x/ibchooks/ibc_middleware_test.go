@@ -0,0 +1,353 @@
+package ibchooks_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/okex/exchain/libs/cosmos-sdk/store"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	transfertypes "github.com/okex/exchain/libs/ibc-go/modules/apps/transfer/types"
+	clienttypes "github.com/okex/exchain/libs/ibc-go/modules/core/02-client/types"
+	channeltypes "github.com/okex/exchain/libs/ibc-go/modules/core/04-channel/types"
+	porttypes "github.com/okex/exchain/libs/ibc-go/modules/core/05-port/types"
+	"github.com/okex/exchain/libs/ibc-go/modules/core/exported"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	tmdb "github.com/okex/exchain/libs/tm-db"
+	evmtypes "github.com/okex/exchain/x/evm/types"
+	"github.com/okex/exchain/x/ibchooks"
+	"github.com/okex/exchain/x/ibchooks/types"
+	wasmtypes "github.com/okex/exchain/x/wasm/types"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	testDenom   = "ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2"
+	testChannel = "channel-0"
+	testSender  = "cosmos1sender"
+)
+
+var (
+	testContract = common.HexToAddress("0x5A8D648DEE57b2fc90D98DC17fa887159b69638b")
+	testFallback = sdk.AccAddress(common.HexToAddress("0x00000000000000000000000000000000000000fb").Bytes())
+)
+
+type MiddlewareTestSuite struct {
+	suite.Suite
+
+	ctx        sdk.Context
+	bank       *mockBankKeeper
+	contracts  *mockContractKeeper
+	evm        *mockEvmKeeper
+	app        *mockTransferModule
+	middleware ibchooks.IBCMiddleware
+}
+
+func TestMiddlewareTestSuite(t *testing.T) {
+	suite.Run(t, new(MiddlewareTestSuite))
+}
+
+func (suite *MiddlewareTestSuite) SetupTest() {
+	key := sdk.NewKVStoreKey(types.ModuleName)
+	ms := store.NewCommitMultiStore(tmdb.NewMemDB())
+	ms.MountStoreWithDB(key, sdk.StoreTypeIAVL, nil)
+	suite.Require().NoError(ms.LoadLatestVersion())
+	suite.ctx = sdk.NewContext(ms, abci.Header{Height: 2}, false, log.NewNopLogger())
+	tmtypes.UnittestOnlySetMilestoneJupiterHeight(1)
+
+	suite.bank = &mockBankKeeper{balances: map[string]sdk.Coins{}}
+	suite.contracts = &mockContractKeeper{contracts: map[string]bool{sdk.WasmAddress(testContract.Bytes()).String(): true}}
+	suite.evm = &mockEvmKeeper{}
+	suite.app = &mockTransferModule{bank: suite.bank}
+	k := ibchooks.NewKeeper(suite.bank, suite.contracts, suite.contracts, suite.evm)
+	suite.middleware = ibchooks.NewIBCMiddleware(suite.app, nil, k)
+}
+
+func (suite *MiddlewareTestSuite) packet(receiver, memo string) channeltypes.Packet {
+	data := transfertypes.NewFungibleTokenPacketData("transfer/channel-7/uatom", "100", testSender, receiver)
+	data.Memo = memo
+	return channeltypes.NewPacket(data.GetBytes(), 1, "transfer", "channel-7", "transfer", testChannel, clienttypes.NewHeight(0, 100), 0)
+}
+
+func (suite *MiddlewareTestSuite) TestOnRecvPacket() {
+	contract := testContract.String()
+	intermediary := types.DeriveIntermediary(testChannel, testSender)
+	funds := sdk.NewCoins(sdk.NewDecCoinFromDec(testDenom, sdk.NewDec(100)))
+	receiver := sdk.AccAddress(testContract.Bytes())
+
+	testCases := []struct {
+		msg        string
+		receiver   string
+		memo       string
+		malleate   func()
+		expSuccess bool
+		expFunds   map[string]sdk.Coins
+		expExecute bool
+		expEvmCall bool
+	}{
+		{"no memo", contract, "", func() {}, true, map[string]sdk.Coins{receiver.String(): funds}, false, false},
+		{"memo without hook is passed through", contract, "hello", func() {}, true, map[string]sdk.Coins{receiver.String(): funds}, false, false},
+		{
+			"wasm hook",
+			contract,
+			`{"wasm":{"contract":"` + contract + `","msg":{"deposit":{}}}}`,
+			func() {},
+			true,
+			map[string]sdk.Coins{intermediary.String(): funds},
+			true,
+			false,
+		},
+		{
+			"evm hook",
+			contract,
+			`{"evm":{"contract":"` + contract + `","calldata":"0xd0e30db0"}}`,
+			func() {},
+			true,
+			map[string]sdk.Coins{receiver.String(): funds},
+			false,
+			true,
+		},
+		{
+			"hook before the jupiter height is passed through",
+			contract,
+			`{"wasm":{"contract":"` + contract + `","msg":{"deposit":{}}}}`,
+			func() { tmtypes.UnittestOnlySetMilestoneJupiterHeight(3) },
+			true,
+			map[string]sdk.Coins{receiver.String(): funds},
+			false,
+			false,
+		},
+		{"receiver is not the contract", testFallback.String(), `{"wasm":{"contract":"` + contract + `","msg":{}}}`, func() {}, false, map[string]sdk.Coins{}, false, false},
+		{"invalid hook", contract, `{"wasm":{"contract":"` + contract + `","msg":"deposit"}}`, func() {}, false, map[string]sdk.Coins{}, false, false},
+		{
+			"transfer failed",
+			contract,
+			`{"wasm":{"contract":"` + contract + `","msg":{}}}`,
+			func() { suite.app.fail = true },
+			false,
+			map[string]sdk.Coins{},
+			false,
+			false,
+		},
+		{
+			"contract failed without fallback",
+			contract,
+			`{"wasm":{"contract":"` + contract + `","msg":{}}}`,
+			func() { suite.contracts.fail = true },
+			false,
+			map[string]sdk.Coins{intermediary.String(): funds},
+			true,
+			false,
+		},
+		{
+			"contract failed with fallback",
+			contract,
+			`{"wasm":{"contract":"` + contract + `","msg":{}},"fallback":"` + testFallback.String() + `"}`,
+			func() { suite.contracts.fail = true },
+			true,
+			map[string]sdk.Coins{testFallback.String(): funds},
+			true,
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.msg, func() {
+			suite.SetupTest()
+			tc.malleate()
+
+			ack := suite.middleware.OnRecvPacket(suite.ctx, suite.packet(tc.receiver, tc.memo), nil)
+			suite.Require().Equal(tc.expSuccess, ack.Success())
+			suite.Require().Equal(tc.expExecute, suite.contracts.executed != nil)
+			suite.Require().Equal(tc.expEvmCall, suite.evm.called)
+			if tc.expExecute {
+				suite.Require().Equal(sdk.WasmAddress(intermediary), suite.contracts.executed.caller)
+				suite.Require().Equal(funds, suite.contracts.executed.funds)
+			}
+			if tc.expEvmCall {
+				suite.Require().Equal(common.BytesToAddress(intermediary), suite.evm.caller)
+				suite.Require().Equal(0, suite.evm.value.Sign())
+			}
+			if !tc.expSuccess {
+				// the ibc core discards the state changes of the failed acknowledgements
+				return
+			}
+			for addr, coins := range tc.expFunds {
+				suite.Require().Equal(coins.String(), suite.bank.balances[addr].String(), addr)
+			}
+		})
+	}
+}
+
+func (suite *MiddlewareTestSuite) TestOnAcknowledgementPacket() {
+	contract := sdk.WasmAddress(testContract.Bytes())
+	ack := channeltypes.NewResultAcknowledgement([]byte{1})
+
+	callback := func(addr string) string { return `{"ibc_callback":"` + addr + `"}` }
+
+	testCases := []struct {
+		msg       string
+		sender    string
+		memo      string
+		malleate  func()
+		expCalled bool
+	}{
+		{"sender is a contract", contract.String(), callback(contract.String()), func() {}, true},
+		{"sender is an account", testFallback.String(), callback(testFallback.String()), func() {}, false},
+		{"contract did not opt in", contract.String(), "", func() {}, false},
+		{"callback to another address", contract.String(), callback(testFallback.String()), func() {}, false},
+		{"contract callback failed", contract.String(), callback(contract.String()), func() { suite.contracts.fail = true }, true},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.msg, func() {
+			suite.SetupTest()
+			tc.malleate()
+
+			data := transfertypes.NewFungibleTokenPacketData("okt", "1", tc.sender, "cosmos1receiver")
+			data.Memo = tc.memo
+			packet := channeltypes.NewPacket(data.GetBytes(),
+				3, "transfer", testChannel, "transfer", "channel-7", clienttypes.NewHeight(0, 100), 0)
+			suite.Require().NoError(suite.middleware.OnAcknowledgementPacket(suite.ctx, packet, ack.Acknowledgement(), nil))
+			suite.Require().True(suite.app.acked)
+			suite.Require().Equal(tc.expCalled, suite.contracts.sudo != nil)
+			if tc.expCalled {
+				var msg types.SudoMsg
+				suite.Require().NoError(json.Unmarshal(suite.contracts.sudo, &msg))
+				suite.Require().Equal(types.IBCAck{Channel: testChannel, Sequence: 3, Ack: string(ack.Acknowledgement()), Success: true}, *msg.IBCLifecycleComplete.IBCAck)
+			}
+
+			suite.contracts.sudo = nil
+			suite.Require().NoError(suite.middleware.OnTimeoutPacket(suite.ctx, packet, nil))
+			suite.Require().Equal(tc.expCalled, suite.contracts.sudo != nil)
+			if tc.expCalled {
+				var msg types.SudoMsg
+				suite.Require().NoError(json.Unmarshal(suite.contracts.sudo, &msg))
+				suite.Require().Equal(types.IBCTimeout{Channel: testChannel, Sequence: 3}, *msg.IBCLifecycleComplete.IBCTimeout)
+			}
+		})
+	}
+}
+
+func (suite *MiddlewareTestSuite) TestCallbackOutOfGas() {
+	contract := sdk.WasmAddress(testContract.Bytes())
+	suite.contracts.sudoGas = types.CallbackGasLimit + 1
+	suite.ctx.SetGasMeter(sdk.NewInfiniteGasMeter())
+
+	data := transfertypes.NewFungibleTokenPacketData("okt", "1", contract.String(), "cosmos1receiver")
+	data.Memo = `{"ibc_callback":"` + contract.String() + `"}`
+	packet := channeltypes.NewPacket(data.GetBytes(), 3, "transfer", testChannel, "transfer", "channel-7", clienttypes.NewHeight(0, 100), 0)
+	suite.Require().NoError(suite.middleware.OnTimeoutPacket(suite.ctx, packet, nil))
+	suite.Require().GreaterOrEqual(suite.ctx.GasMeter().GasConsumed(), types.CallbackGasLimit)
+
+	events := suite.ctx.EventManager().Events()
+	suite.Require().NotEmpty(events)
+	event := events[len(events)-1]
+	suite.Require().Equal(types.EventTypeHookCallback, event.Type)
+	attributes := map[string]string{}
+	for _, attr := range event.Attributes {
+		attributes[string(attr.Key)] = string(attr.Value)
+	}
+	suite.Require().Equal("false", attributes[types.AttributeKeySuccess])
+	suite.Require().Contains(attributes[types.AttributeKeyError], "out of gas")
+}
+
+type mockTransferModule struct {
+	porttypes.IBCModule
+	bank  *mockBankKeeper
+	fail  bool
+	acked bool
+}
+
+// OnRecvPacket credits the receiver like the transfer module, which does not know the memo
+func (m *mockTransferModule) OnRecvPacket(ctx sdk.Context, packet channeltypes.Packet, _ sdk.AccAddress) exported.Acknowledgement {
+	var data transfertypes.FungibleTokenPacketData
+	if err := transfertypes.ModuleCdc.UnmarshalJSON(packet.GetData(), &data); err != nil || m.fail {
+		return channeltypes.NewErrorAcknowledgement("transfer failed")
+	}
+	receiver, err := sdk.AccAddressFromBech32(data.Receiver)
+	if err != nil {
+		return channeltypes.NewErrorAcknowledgement(err.Error())
+	}
+	m.bank.balances[receiver.String()] = m.bank.balances[receiver.String()].Add(sdk.NewDecCoinFromDec(testDenom, sdk.NewDec(100)))
+	return channeltypes.NewResultAcknowledgement([]byte{1})
+}
+
+func (m *mockTransferModule) OnAcknowledgementPacket(sdk.Context, channeltypes.Packet, []byte, sdk.AccAddress) error {
+	m.acked = true
+	return nil
+}
+
+func (m *mockTransferModule) OnTimeoutPacket(sdk.Context, channeltypes.Packet, sdk.AccAddress) error {
+	return nil
+}
+
+type mockBankKeeper struct {
+	balances map[string]sdk.Coins
+}
+
+func (m *mockBankKeeper) GetCoins(_ sdk.Context, addr sdk.AccAddress) sdk.Coins {
+	return m.balances[addr.String()]
+}
+
+func (m *mockBankKeeper) SendCoins(_ sdk.Context, from sdk.AccAddress, to sdk.AccAddress, amt sdk.Coins) error {
+	balance, negative := m.balances[from.String()].SafeSub(amt)
+	if negative {
+		return errors.New("insufficient funds")
+	}
+	m.balances[from.String()] = balance
+	m.balances[to.String()] = m.balances[to.String()].Add(amt...)
+	return nil
+}
+
+type execution struct {
+	caller sdk.WasmAddress
+	funds  sdk.Coins
+}
+
+type mockContractKeeper struct {
+	contracts map[string]bool
+	fail      bool
+	executed  *execution
+	sudo      []byte
+	sudoGas   uint64
+}
+
+func (m *mockContractKeeper) GetContractInfo(_ sdk.Context, addr sdk.WasmAddress) *wasmtypes.ContractInfo {
+	if !m.contracts[addr.String()] {
+		return nil
+	}
+	return &wasmtypes.ContractInfo{}
+}
+
+func (m *mockContractKeeper) Execute(_ sdk.Context, _ sdk.WasmAddress, caller sdk.WasmAddress, _ []byte, coins sdk.Coins) ([]byte, error) {
+	m.executed = &execution{caller: caller, funds: coins}
+	if m.fail {
+		return nil, errors.New("execute failed")
+	}
+	return []byte("{}"), nil
+}
+
+func (m *mockContractKeeper) Sudo(ctx sdk.Context, _ sdk.WasmAddress, msg []byte) ([]byte, error) {
+	m.sudo = msg
+	ctx.GasMeter().ConsumeGas(m.sudoGas, "sudo")
+	if m.fail {
+		return nil, errors.New("sudo failed")
+	}
+	return nil, nil
+}
+
+type mockEvmKeeper struct {
+	called bool
+	caller common.Address
+	value  *big.Int
+}
+
+func (m *mockEvmKeeper) CallEvm(_ sdk.Context, caller common.Address, _ *common.Address, value *big.Int, _ []byte) (*evmtypes.ExecutionResult, *evmtypes.ResultData, error) {
+	m.called, m.caller, m.value = true, caller, value
+	return nil, &evmtypes.ResultData{}, nil
+}
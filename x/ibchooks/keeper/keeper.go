@@ -0,0 +1,174 @@
+package keeper
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	channeltypes "github.com/okex/exchain/libs/ibc-go/modules/core/04-channel/types"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	"github.com/okex/exchain/x/ibchooks/types"
+)
+
+// Keeper runs the contract hooks of the ics20 packets
+type Keeper struct {
+	bankKeeper     types.BankKeeper
+	wasmKeeper     types.WasmViewKeeper
+	contractKeeper types.ContractOpsKeeper
+	evmKeeper      types.EvmKeeper
+}
+
+// NewKeeper creates a new ibc hooks Keeper instance
+func NewKeeper(bankKeeper types.BankKeeper, wasmKeeper types.WasmViewKeeper, contractKeeper types.ContractOpsKeeper, evmKeeper types.EvmKeeper) Keeper {
+	return Keeper{
+		bankKeeper:     bankKeeper,
+		wasmKeeper:     wasmKeeper,
+		contractKeeper: contractKeeper,
+		evmKeeper:      evmKeeper,
+	}
+}
+
+// Logger returns a module-specific logger.
+func (k Keeper) Logger(ctx sdk.Context) log.Logger {
+	return ctx.Logger().With("module", fmt.Sprintf("x/%s", types.ModuleName))
+}
+
+// GetCoins returns the coins of the account
+func (k Keeper) GetCoins(ctx sdk.Context, addr sdk.AccAddress) sdk.Coins {
+	return k.bankKeeper.GetCoins(ctx, addr)
+}
+
+// ExecuteHook runs the hook of the memo with the funds held by the intermediary. If the contract fails the funds are
+// sent to the fallback account of the memo, without a fallback account the error is returned.
+func (k Keeper) ExecuteHook(ctx sdk.Context, memo types.Memo, intermediary sdk.AccAddress, funds sdk.Coins) (types.HookResult, error) {
+	contract := memo.GetContract()
+	vm := types.AttributeValueWasm
+	if memo.Evm != nil {
+		vm = types.AttributeValueEvm
+	}
+
+	cacheCtx, write := ctx.CacheContext()
+	var res []byte
+	var err error
+	if memo.Wasm != nil {
+		res, err = k.contractKeeper.Execute(cacheCtx, contract, sdk.WasmAddress(intermediary), memo.Wasm.Msg, funds)
+	} else {
+		res, err = k.callEvm(cacheCtx, memo.Evm, intermediary, funds)
+	}
+	if err == nil {
+		write()
+		ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeHook,
+			sdk.NewAttribute(types.AttributeKeyVM, vm),
+			sdk.NewAttribute(types.AttributeKeyContract, contract.String()),
+			sdk.NewAttribute(types.AttributeKeyIntermediary, intermediary.String()),
+			sdk.NewAttribute(types.AttributeKeySuccess, strconv.FormatBool(true)),
+		))
+		return types.HookResult{ContractResult: res}, nil
+	}
+
+	fallback := memo.GetFallback()
+	if fallback == nil {
+		return types.HookResult{}, sdkerrors.Wrap(types.ErrHookExecuteFailed, err.Error())
+	}
+	k.Logger(ctx).Info("ibc hook failed, send funds to fallback", "contract", contract.String(), "fallback", fallback.String(), "error", err.Error())
+	if sendErr := k.bankKeeper.SendCoins(ctx, intermediary, fallback, funds); sendErr != nil {
+		return types.HookResult{}, sendErr
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeHook,
+		sdk.NewAttribute(types.AttributeKeyVM, vm),
+		sdk.NewAttribute(types.AttributeKeyContract, contract.String()),
+		sdk.NewAttribute(types.AttributeKeyIntermediary, intermediary.String()),
+		sdk.NewAttribute(types.AttributeKeySuccess, strconv.FormatBool(false)),
+		sdk.NewAttribute(types.AttributeKeyFallback, fallback.String()),
+		sdk.NewAttribute(types.AttributeKeyError, err.Error()),
+	))
+	return types.HookResult{Fallback: fallback.String()}, nil
+}
+
+// callEvm sends the funds to the evm contract and calls it from the intermediary, the native token is the value of the call
+func (k Keeper) callEvm(ctx sdk.Context, hook *types.EvmHook, intermediary sdk.AccAddress, funds sdk.Coins) ([]byte, error) {
+	calldata, err := hook.GetCalldata()
+	if err != nil {
+		return nil, err
+	}
+	contract := common.HexToAddress(hook.Contract)
+	value := big.NewInt(0)
+	others := sdk.Coins{}
+	for _, coin := range funds {
+		if coin.Denom == sdk.DefaultBondDenom {
+			value = coin.Amount.BigInt()
+		} else {
+			others = others.Add(coin)
+		}
+	}
+	if !others.Empty() {
+		if err := k.bankKeeper.SendCoins(ctx, intermediary, contract.Bytes(), others); err != nil {
+			return nil, err
+		}
+	}
+
+	_, result, err := k.evmKeeper.CallEvm(ctx, common.BytesToAddress(intermediary), &contract, value, calldata)
+	if err != nil {
+		return nil, err
+	}
+	return result.Ret, nil
+}
+
+// OnPacketLifecycleComplete notifies the wasm contract that sent the ics20 packet of its acknowledgement or timeout
+// if the memo of the packet opted in to the callback. The contract can not fail the acknowledgement or the refund of
+// the packet, its errors are only emitted as events.
+func (k Keeper) OnPacketLifecycleComplete(ctx sdk.Context, packet channeltypes.Packet, sender, memo string, complete types.IBCLifecycleComplete) {
+	if !types.IsCallbackRequested(memo, sender) {
+		return
+	}
+	contract, err := sdk.WasmAddressFromBech32(sender)
+	if err != nil || k.wasmKeeper.GetContractInfo(ctx, contract) == nil {
+		return
+	}
+	msg, err := types.NewSudoMsg(complete)
+	if err != nil {
+		return
+	}
+
+	cacheCtx, write := ctx.CacheContext()
+	err = k.sudo(cacheCtx, contract, msg)
+	attributes := []sdk.Attribute{
+		sdk.NewAttribute(types.AttributeKeyContract, contract.String()),
+		sdk.NewAttribute(types.AttributeKeyChannel, packet.GetSourceChannel()),
+		sdk.NewAttribute(types.AttributeKeySequence, strconv.FormatUint(packet.GetSequence(), 10)),
+		sdk.NewAttribute(types.AttributeKeySuccess, strconv.FormatBool(err == nil)),
+	}
+	if err == nil {
+		write()
+		ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+	} else {
+		k.Logger(ctx).Info("ibc hook callback failed", "contract", contract.String(), "error", err.Error())
+		attributes = append(attributes, sdk.NewAttribute(types.AttributeKeyError, err.Error()))
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(types.EventTypeHookCallback, attributes...))
+}
+
+// sudo calls the sudo entry point of the contract with the gas limited to CallbackGasLimit, the gas spent is charged
+// to the relayer and running out of it is returned as an error
+func (k Keeper) sudo(ctx sdk.Context, contract sdk.WasmAddress, msg []byte) (err error) {
+	gasMeter := sdk.NewGasMeter(types.CallbackGasLimit)
+	subCtx := ctx
+	subCtx.SetGasMeter(gasMeter)
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(sdk.ErrorOutOfGas); !ok {
+				panic(r)
+			}
+			err = sdkerrors.Wrap(sdkerrors.ErrOutOfGas, "ibc hook callback hit gas limit")
+		}
+		ctx.GasMeter().ConsumeGas(gasMeter.GasConsumedToLimit(), "ibc hook callback")
+	}()
+	_, err = k.contractKeeper.Sudo(subCtx, contract, msg)
+	return err
+}
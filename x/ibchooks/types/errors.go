@@ -0,0 +1,14 @@
+package types
+
+import (
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+)
+
+// ibc hooks sentinel errors
+var (
+	ErrInvalidMemo       = sdkerrors.Register(ModuleName, 2, "invalid ibc hooks memo")
+	ErrInvalidReceiver   = sdkerrors.Register(ModuleName, 3, "invalid ibc hooks receiver")
+	ErrNoFundsReceived   = sdkerrors.Register(ModuleName, 4, "no funds received by the ibc hooks intermediary")
+	ErrHookExecuteFailed = sdkerrors.Register(ModuleName, 5, "ibc hook execution failed")
+	ErrInvalidPacketData = sdkerrors.Register(ModuleName, 6, "invalid ics20 packet data")
+)
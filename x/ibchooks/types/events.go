@@ -0,0 +1,19 @@
+package types
+
+// ibc hooks events
+const (
+	EventTypeHook         = "ibc_hook"
+	EventTypeHookCallback = "ibc_hook_callback"
+
+	AttributeKeyContract     = "contract"
+	AttributeKeyVM           = "vm"
+	AttributeKeyIntermediary = "intermediary"
+	AttributeKeyFallback     = "fallback"
+	AttributeKeySuccess      = "success"
+	AttributeKeyError        = "error"
+	AttributeKeyChannel      = "channel"
+	AttributeKeySequence     = "sequence"
+
+	AttributeValueWasm = "wasm"
+	AttributeValueEvm  = "evm"
+)
@@ -0,0 +1,32 @@
+package types
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	evmtypes "github.com/okex/exchain/x/evm/types"
+	wasmtypes "github.com/okex/exchain/x/wasm/types"
+)
+
+// BankKeeper defines the expected bank keeper
+type BankKeeper interface {
+	GetCoins(ctx sdk.Context, addr sdk.AccAddress) sdk.Coins
+	SendCoins(ctx sdk.Context, fromAddr sdk.AccAddress, toAddr sdk.AccAddress, amt sdk.Coins) error
+}
+
+// WasmViewKeeper defines the expected wasm keeper to look up contracts
+type WasmViewKeeper interface {
+	GetContractInfo(ctx sdk.Context, contractAddress sdk.WasmAddress) *wasmtypes.ContractInfo
+}
+
+// ContractOpsKeeper defines the expected wasm keeper to execute contracts
+type ContractOpsKeeper interface {
+	Execute(ctx sdk.Context, contractAddress sdk.WasmAddress, caller sdk.WasmAddress, msg []byte, coins sdk.Coins) ([]byte, error)
+	Sudo(ctx sdk.Context, contractAddress sdk.WasmAddress, msg []byte) ([]byte, error)
+}
+
+// EvmKeeper defines the expected keeper to call evm contracts
+type EvmKeeper interface {
+	CallEvm(ctx sdk.Context, callerAddr common.Address, to *common.Address, value *big.Int, data []byte) (*evmtypes.ExecutionResult, *evmtypes.ResultData, error)
+}
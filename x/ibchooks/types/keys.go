@@ -0,0 +1,13 @@
+package types
+
+const (
+	// ModuleName is the name of the ibc hooks middleware
+	ModuleName = "ibchooks"
+
+	// intermediaryPrefix is hashed with the channel and the sender to derive the account that receives the
+	// tokens before they are passed on to the contract
+	intermediaryPrefix = "ibc-hooks-intermediary"
+)
+
+// CallbackGasLimit is the gas a contract can spend in the sudo callback of the lifecycle of its packet
+const CallbackGasLimit uint64 = 1000000
@@ -0,0 +1,177 @@
+package types
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	transfertypes "github.com/okex/exchain/libs/ibc-go/modules/apps/transfer/types"
+	"github.com/okex/exchain/libs/tendermint/crypto"
+)
+
+// ParsePacketData decodes the json ics20 packet data
+func ParsePacketData(bz []byte) (transfertypes.FungibleTokenPacketData, error) {
+	var data transfertypes.FungibleTokenPacketData
+	if err := transfertypes.ModuleCdc.UnmarshalJSON(bz, &data); err != nil {
+		return transfertypes.FungibleTokenPacketData{}, sdkerrors.Wrap(ErrInvalidPacketData, err.Error())
+	}
+	return data, nil
+}
+
+// Memo is the instruction sent with the tokens, exactly one of Wasm and Evm must be set
+type Memo struct {
+	Wasm *WasmHook `json:"wasm,omitempty"`
+	Evm  *EvmHook  `json:"evm,omitempty"`
+	// Fallback receives the tokens if the contract execution fails, otherwise the packet is acknowledged with an error
+	// and the tokens are refunded on the counterparty chain
+	Fallback string `json:"fallback,omitempty"`
+}
+
+// WasmHook executes the wasm contract with the received tokens as funds
+type WasmHook struct {
+	Contract string          `json:"contract"`
+	Msg      json.RawMessage `json:"msg"`
+}
+
+// EvmHook sends the received tokens to the evm contract and calls it with the calldata,
+// the native token is sent as the value of the call
+type EvmHook struct {
+	Contract string `json:"contract"`
+	Calldata string `json:"calldata"`
+}
+
+// ParseMemo returns the hook of the memo, found is false if the memo does not carry a hook
+func ParseMemo(memo string) (m Memo, found bool, err error) {
+	memo = strings.TrimSpace(memo)
+	if !strings.HasPrefix(memo, "{") {
+		return Memo{}, false, nil
+	}
+	if err := json.Unmarshal([]byte(memo), &m); err != nil {
+		return Memo{}, false, nil
+	}
+	if m.Wasm == nil && m.Evm == nil {
+		return Memo{}, false, nil
+	}
+	return m, true, m.ValidateBasic()
+}
+
+// ValidateBasic checks the hook of the memo
+func (m Memo) ValidateBasic() error {
+	if m.Wasm != nil && m.Evm != nil {
+		return sdkerrors.Wrap(ErrInvalidMemo, "only one of wasm and evm hooks can be set")
+	}
+	if m.Wasm != nil {
+		if _, err := sdk.WasmAddressFromBech32(m.Wasm.Contract); err != nil {
+			return sdkerrors.Wrapf(ErrInvalidMemo, "invalid wasm contract %s: %s", m.Wasm.Contract, err)
+		}
+		var msg map[string]interface{}
+		if err := json.Unmarshal(m.Wasm.Msg, &msg); err != nil {
+			return sdkerrors.Wrap(ErrInvalidMemo, "wasm msg must be a json object")
+		}
+	}
+	if m.Evm != nil {
+		if !sdk.IsETHAddress(m.Evm.Contract) || !common.IsHexAddress(m.Evm.Contract) {
+			return sdkerrors.Wrapf(ErrInvalidMemo, "invalid evm contract %s", m.Evm.Contract)
+		}
+		if _, err := m.Evm.GetCalldata(); err != nil {
+			return sdkerrors.Wrapf(ErrInvalidMemo, "invalid evm calldata: %s", err)
+		}
+	}
+	if len(m.Fallback) > 0 {
+		if _, err := sdk.AccAddressFromBech32(m.Fallback); err != nil {
+			return sdkerrors.Wrapf(ErrInvalidMemo, "invalid fallback %s: %s", m.Fallback, err)
+		}
+	}
+	return nil
+}
+
+// GetContract returns the address of the contract of the hook
+func (m Memo) GetContract() sdk.WasmAddress {
+	var addr sdk.WasmAddress
+	if m.Wasm != nil {
+		addr, _ = sdk.WasmAddressFromBech32(m.Wasm.Contract)
+	} else if m.Evm != nil {
+		addr = common.HexToAddress(m.Evm.Contract).Bytes()
+	}
+	return addr
+}
+
+// GetFallback returns the fallback account, nil if it is not set
+func (m Memo) GetFallback() sdk.AccAddress {
+	if len(m.Fallback) == 0 {
+		return nil
+	}
+	addr, _ := sdk.AccAddressFromBech32(m.Fallback)
+	return addr
+}
+
+// callbackMemo is the memo of an outgoing packet whose sender contract opts in to the lifecycle callbacks
+type callbackMemo struct {
+	IBCCallback string `json:"ibc_callback"`
+}
+
+// IsCallbackRequested returns true if the memo of the packet names its sender as the contract to call back
+// with the acknowledgement or the timeout of the packet
+func IsCallbackRequested(memo, sender string) bool {
+	memo = strings.TrimSpace(memo)
+	if !strings.HasPrefix(memo, "{") {
+		return false
+	}
+	var m callbackMemo
+	if err := json.Unmarshal([]byte(memo), &m); err != nil {
+		return false
+	}
+	return len(m.IBCCallback) > 0 && m.IBCCallback == sender
+}
+
+// GetCalldata decodes the hex calldata
+func (h EvmHook) GetCalldata() ([]byte, error) {
+	return hex.DecodeString(strings.TrimPrefix(h.Calldata, "0x"))
+}
+
+// DeriveIntermediary returns the account that receives the tokens of the sender from the channel before the hook runs.
+// Deriving it from the sender keeps the contracts from trusting tokens of other senders as their own.
+func DeriveIntermediary(channel, sender string) sdk.AccAddress {
+	return sdk.AccAddress(crypto.AddressHash([]byte(fmt.Sprintf("%s/%s/%s", intermediaryPrefix, channel, sender))))
+}
+
+// HookResult is the result of the acknowledgement of a packet with a hook
+type HookResult struct {
+	ContractResult []byte `json:"contract_result,omitempty"`
+	// Fallback is set if the contract execution failed and the tokens were sent to the fallback account
+	Fallback string `json:"fallback,omitempty"`
+}
+
+// SudoMsg is sent to the wasm contract that sent an ics20 packet once the packet is acknowledged or timed out
+type SudoMsg struct {
+	IBCLifecycleComplete IBCLifecycleComplete `json:"ibc_lifecycle_complete"`
+}
+
+// IBCLifecycleComplete holds either the acknowledgement or the timeout of the packet
+type IBCLifecycleComplete struct {
+	IBCAck     *IBCAck     `json:"ibc_ack,omitempty"`
+	IBCTimeout *IBCTimeout `json:"ibc_timeout,omitempty"`
+}
+
+// IBCAck is the acknowledgement of the packet sent by the contract
+type IBCAck struct {
+	Channel  string `json:"channel"`
+	Sequence uint64 `json:"sequence"`
+	Ack      string `json:"ack"`
+	Success  bool   `json:"success"`
+}
+
+// IBCTimeout is the timeout of the packet sent by the contract
+type IBCTimeout struct {
+	Channel  string `json:"channel"`
+	Sequence uint64 `json:"sequence"`
+}
+
+// NewSudoMsg returns the json sudo message of the packet lifecycle
+func NewSudoMsg(complete IBCLifecycleComplete) ([]byte, error) {
+	return json.Marshal(SudoMsg{IBCLifecycleComplete: complete})
+}
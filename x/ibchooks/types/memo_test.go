@@ -0,0 +1,60 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMemo(t *testing.T) {
+	contract := "0x5A8D648DEE57b2fc90D98DC17fa887159b69638b"
+	testCases := []struct {
+		name    string
+		memo    string
+		found   bool
+		expPass bool
+	}{
+		{"empty", "", false, true},
+		{"plain text", "hello", false, true},
+		{"json without hook", `{"forward":{}}`, false, true},
+		{"wasm hook", `{"wasm":{"contract":"` + contract + `","msg":{"deposit":{}}}}`, true, true},
+		{"evm hook", `{"evm":{"contract":"` + contract + `","calldata":"0xd0e30db0"}}`, true, true},
+		{"hook with fallback", `{"evm":{"contract":"` + contract + `","calldata":""},"fallback":"` + contract + `"}`, true, true},
+		{"both hooks", `{"wasm":{"contract":"` + contract + `","msg":{}},"evm":{"contract":"` + contract + `","calldata":""}}`, true, false},
+		{"wasm msg not an object", `{"wasm":{"contract":"` + contract + `","msg":"deposit"}}`, true, false},
+		{"invalid wasm contract", `{"wasm":{"contract":"ex1invalid","msg":{}}}`, true, false},
+		{"invalid evm contract", `{"evm":{"contract":"0x1234","calldata":""}}`, true, false},
+		{"invalid evm calldata", `{"evm":{"contract":"` + contract + `","calldata":"0xzz"}}`, true, false},
+		{"invalid fallback", `{"evm":{"contract":"` + contract + `","calldata":""},"fallback":"ex1invalid"}`, true, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, found, err := ParseMemo(tc.memo)
+			require.Equal(t, tc.found, found)
+			if tc.expPass {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestDeriveIntermediary(t *testing.T) {
+	addr := DeriveIntermediary("channel-0", "cosmos1sender")
+	require.Equal(t, addr, DeriveIntermediary("channel-0", "cosmos1sender"))
+	require.NotEqual(t, addr, DeriveIntermediary("channel-1", "cosmos1sender"))
+	require.NotEqual(t, addr, DeriveIntermediary("channel-0", "cosmos1other"))
+}
+
+func TestIsCallbackRequested(t *testing.T) {
+	sender := "ex1sender"
+	require.True(t, IsCallbackRequested(`{"ibc_callback":"ex1sender"}`, sender))
+	require.True(t, IsCallbackRequested(` {"ibc_callback":"ex1sender","forward":{}}`, sender))
+	require.False(t, IsCallbackRequested("", sender))
+	require.False(t, IsCallbackRequested("ex1sender", sender))
+	require.False(t, IsCallbackRequested(`{"ibc_callback":"ex1other"}`, sender))
+	require.False(t, IsCallbackRequested(`{"ibc_callback":""}`, ""))
+	require.False(t, IsCallbackRequested(`{"ibc_callback":true}`, sender))
+}
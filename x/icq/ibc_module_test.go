@@ -0,0 +1,288 @@
+package icq_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/baseapp"
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	"github.com/okex/exchain/libs/cosmos-sdk/store"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	capabilitytypes "github.com/okex/exchain/libs/cosmos-sdk/x/capability/types"
+	channeltypes "github.com/okex/exchain/libs/ibc-go/modules/core/04-channel/types"
+	"github.com/okex/exchain/libs/ibc-go/modules/core/exported"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	"github.com/okex/exchain/libs/tendermint/crypto/ed25519"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	tmdb "github.com/okex/exchain/libs/tm-db"
+	"github.com/okex/exchain/x/icq"
+	"github.com/okex/exchain/x/icq/keeper"
+	"github.com/okex/exchain/x/icq/types"
+	"github.com/okex/exchain/x/params"
+	wasmtypes "github.com/okex/exchain/x/wasm/types"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	testChannelController = "channel-0"
+	testChannelHost       = "channel-1"
+	balancePath           = "/cosmos.bank.v1beta1.Query/Balance"
+	supplyPath            = "/cosmos.bank.v1beta1.Query/TotalSupply"
+)
+
+var contract = sdk.WasmAddress(ed25519.GenPrivKey().PubKey().Address())
+
+// testChain is one side of the channel, with its own interchain query keeper
+type testChain struct {
+	channel *mockChannelKeeper
+	wasm    *mockWasmKeeper
+	keeper  keeper.Keeper
+	module  icq.IBCModule
+}
+
+type IBCModuleTestSuite struct {
+	suite.Suite
+
+	ctx        sdk.Context
+	controller testChain
+	host       testChain
+}
+
+func TestIBCModuleTestSuite(t *testing.T) {
+	suite.Run(t, new(IBCModuleTestSuite))
+}
+
+func (suite *IBCModuleTestSuite) SetupTest() {
+	keyController, keyHost := sdk.NewKVStoreKey("controller"), sdk.NewKVStoreKey("host")
+	paramsKey, paramsTKey := sdk.NewKVStoreKey(params.StoreKey), sdk.NewTransientStoreKey(params.TStoreKey)
+	ms := store.NewCommitMultiStore(tmdb.NewMemDB())
+	ms.MountStoreWithDB(keyController, sdk.StoreTypeIAVL, nil)
+	ms.MountStoreWithDB(keyHost, sdk.StoreTypeIAVL, nil)
+	ms.MountStoreWithDB(paramsKey, sdk.StoreTypeIAVL, nil)
+	ms.MountStoreWithDB(paramsTKey, sdk.StoreTypeTransient, nil)
+	suite.Require().NoError(ms.LoadLatestVersion())
+	suite.ctx = sdk.NewContext(ms, abci.Header{Height: 2, Time: time.Unix(1000, 0)}, false, log.NewNopLogger())
+
+	paramsKeeper := params.NewKeeper(codec.New(), paramsKey, paramsTKey, log.NewNopLogger())
+	suite.controller = newTestChain(keyController, paramsKeeper.Subspace("controller"), testChannelController, testChannelHost)
+	suite.host = newTestChain(keyHost, paramsKeeper.Subspace("host"), testChannelHost, testChannelController)
+
+	allowed := types.NewParams(true, []string{balancePath}, true)
+	suite.controller.keeper.SetParams(suite.ctx, allowed)
+	suite.host.keeper.SetParams(suite.ctx, allowed)
+}
+
+func newTestChain(key sdk.StoreKey, subspace params.Subspace, channelID, counterpartyChannelID string) testChain {
+	channelKeeper := &mockChannelKeeper{channelID: channelID, counterpartyChannelID: counterpartyChannelID}
+	wasmKeeper := &mockWasmKeeper{}
+	k := keeper.NewKeeper(key, subspace, channelKeeper, nil, mockScopedKeeper{}, mockQueryRouter{}, wasmKeeper, wasmKeeper)
+	return testChain{
+		channel: channelKeeper,
+		wasm:    wasmKeeper,
+		keeper:  k,
+		module:  icq.NewIBCModule(k),
+	}
+}
+
+func (suite *IBCModuleTestSuite) send(paths ...string) channeltypes.Packet {
+	requests := make([]abci.RequestQuery, len(paths))
+	for i, path := range paths {
+		requests[i] = abci.RequestQuery{Path: path, Data: []byte("okt")}
+	}
+	_, err := suite.controller.keeper.SendQuery(suite.ctx, contract, testChannelController, requests, time.Minute)
+	suite.Require().NoError(err)
+	return suite.controller.channel.packet
+}
+
+func (suite *IBCModuleTestSuite) sudoMsg() types.SudoMsg {
+	suite.Require().Len(suite.controller.wasm.sudoMsgs, 1)
+	var msg types.SudoMsg
+	suite.Require().NoError(json.Unmarshal(suite.controller.wasm.sudoMsgs[0], &msg))
+	return msg
+}
+
+func (suite *IBCModuleTestSuite) TestRoundTrip() {
+	packet := suite.send(balancePath)
+	suite.Require().Equal(types.HostPortID, packet.GetDestPort())
+	suite.Require().Len(suite.controller.keeper.GetPendingQueries(suite.ctx), 1)
+
+	ack := suite.host.module.OnRecvPacket(suite.ctx, packet, nil)
+	suite.Require().True(ack.Success())
+
+	suite.Require().NoError(suite.controller.module.OnAcknowledgementPacket(suite.ctx, packet, ack.Acknowledgement(), nil))
+	msg := suite.sudoMsg()
+	suite.Require().NotNil(msg.ICQResult)
+	suite.Require().Equal(packet.GetSequence(), msg.ICQResult.Sequence)
+	suite.Require().Len(msg.ICQResult.Responses, 1)
+	suite.Require().Equal([]byte("balance of okt"), msg.ICQResult.Responses[0].Value)
+	suite.Require().Empty(suite.controller.keeper.GetPendingQueries(suite.ctx))
+}
+
+func (suite *IBCModuleTestSuite) TestHostRejectsQuery() {
+	// the total supply is not allowed by the host
+	packet := suite.send(balancePath, supplyPath)
+	ack := suite.host.module.OnRecvPacket(suite.ctx, packet, nil)
+	suite.Require().False(ack.Success())
+
+	suite.Require().NoError(suite.controller.module.OnAcknowledgementPacket(suite.ctx, packet, ack.Acknowledgement(), nil))
+	msg := suite.sudoMsg()
+	suite.Require().NotNil(msg.ICQError)
+	suite.Require().Nil(msg.ICQResult)
+
+	// nothing is answered once the host is disabled
+	suite.host.keeper.SetParams(suite.ctx, types.NewParams(false, []string{balancePath}, true))
+	suite.Require().False(suite.host.module.OnRecvPacket(suite.ctx, suite.send(balancePath), nil).Success())
+}
+
+func (suite *IBCModuleTestSuite) TestTimeout() {
+	packet := suite.send(balancePath)
+	suite.Require().NoError(suite.controller.module.OnTimeoutPacket(suite.ctx, packet, nil))
+	msg := suite.sudoMsg()
+	suite.Require().NotNil(msg.ICQTimeout)
+	suite.Require().Equal(testChannelController, msg.ICQTimeout.ChannelID)
+	suite.Require().Empty(suite.controller.keeper.GetPendingQueries(suite.ctx))
+}
+
+func (suite *IBCModuleTestSuite) TestCallbackFailure() {
+	// a failing contract does not fail the acknowledgement
+	suite.controller.wasm.sudoErr = errors.New("contract failed")
+	packet := suite.send(balancePath)
+	ack := suite.host.module.OnRecvPacket(suite.ctx, packet, nil)
+	suite.Require().NoError(suite.controller.module.OnAcknowledgementPacket(suite.ctx, packet, ack.Acknowledgement(), nil))
+	suite.Require().Empty(suite.controller.keeper.GetPendingQueries(suite.ctx))
+}
+
+func (suite *IBCModuleTestSuite) TestCallbackOutOfGas() {
+	// a contract running out of the callback gas does not fail the acknowledgement
+	suite.controller.wasm.sudoGas = types.CallbackGasLimit + 1
+	packet := suite.send(balancePath)
+	ack := suite.host.module.OnRecvPacket(suite.ctx, packet, nil)
+	ctx := suite.ctx
+	ctx.SetGasMeter(sdk.NewInfiniteGasMeter())
+	ctx.SetEventManager(sdk.NewEventManager())
+	suite.Require().NoError(suite.controller.module.OnAcknowledgementPacket(ctx, packet, ack.Acknowledgement(), nil))
+	suite.Require().Empty(suite.controller.wasm.sudoMsgs)
+	suite.Require().Empty(suite.controller.keeper.GetPendingQueries(ctx))
+	suite.Require().GreaterOrEqual(ctx.GasMeter().GasConsumed(), types.CallbackGasLimit)
+
+	events := ctx.EventManager().Events()
+	suite.Require().Len(events, 1)
+	suite.Require().Equal(types.EventTypeICQCallback, events[0].Type)
+	attributes := make(map[string]string)
+	for _, attribute := range events[0].Attributes {
+		attributes[string(attribute.Key)] = string(attribute.Value)
+	}
+	suite.Require().Equal("false", attributes[types.AttributeKeySuccess])
+	suite.Require().Contains(attributes[types.AttributeKeyError], "out of gas")
+}
+
+func (suite *IBCModuleTestSuite) TestControllerDisabled() {
+	suite.controller.keeper.SetParams(suite.ctx, types.NewParams(true, nil, false))
+	_, err := suite.controller.keeper.SendQuery(suite.ctx, contract, testChannelController,
+		[]abci.RequestQuery{{Path: balancePath}}, time.Minute)
+	suite.Require().ErrorIs(err, types.ErrControllerDisabled)
+}
+
+func (suite *IBCModuleTestSuite) TestOnChanOpenInit() {
+	testCases := []struct {
+		name             string
+		order            channeltypes.Order
+		portID           string
+		counterpartyPort string
+		version          string
+		expPass          bool
+	}{
+		{"valid", channeltypes.UNORDERED, types.ControllerPortID, types.HostPortID, types.Version, true},
+		{"empty version", channeltypes.UNORDERED, types.ControllerPortID, types.HostPortID, "", true},
+		{"ordered channel", channeltypes.ORDERED, types.ControllerPortID, types.HostPortID, types.Version, false},
+		{"opened by the host", channeltypes.UNORDERED, types.HostPortID, types.HostPortID, types.Version, false},
+		{"counterparty controller", channeltypes.UNORDERED, types.ControllerPortID, types.ControllerPortID, types.Version, false},
+		{"ics20 version", channeltypes.UNORDERED, types.ControllerPortID, types.HostPortID, "ics20-1", false},
+	}
+	for _, tc := range testCases {
+		version, err := suite.controller.module.OnChanOpenInit(suite.ctx, tc.order, nil, tc.portID, testChannelController,
+			&capabilitytypes.Capability{}, channeltypes.NewCounterparty(tc.counterpartyPort, testChannelHost), tc.version)
+		if tc.expPass {
+			suite.Require().NoError(err, tc.name)
+			suite.Require().Equal(types.Version, version, tc.name)
+		} else {
+			suite.Require().Error(err, tc.name)
+		}
+	}
+}
+
+type mockChannelKeeper struct {
+	channelID             string
+	counterpartyChannelID string
+	sequence              uint64
+	packet                channeltypes.Packet
+}
+
+func (m *mockChannelKeeper) GetChannel(_ sdk.Context, _, channelID string) (channeltypes.Channel, bool) {
+	if channelID != m.channelID {
+		return channeltypes.Channel{}, false
+	}
+	counterparty := channeltypes.NewCounterparty(types.HostPortID, m.counterpartyChannelID)
+	return channeltypes.NewChannel(channeltypes.OPEN, channeltypes.UNORDERED, counterparty, nil, types.Version), true
+}
+
+func (m *mockChannelKeeper) GetNextSequenceSend(sdk.Context, string, string) (uint64, bool) {
+	return m.sequence + 1, true
+}
+
+func (m *mockChannelKeeper) SendPacket(_ sdk.Context, _ *capabilitytypes.Capability, packet exported.PacketI) error {
+	m.sequence++
+	m.packet = packet.(channeltypes.Packet)
+	return nil
+}
+
+type mockScopedKeeper struct{}
+
+func (mockScopedKeeper) GetCapability(sdk.Context, string) (*capabilitytypes.Capability, bool) {
+	return &capabilitytypes.Capability{}, true
+}
+
+func (mockScopedKeeper) AuthenticateCapability(sdk.Context, *capabilitytypes.Capability, string) bool {
+	return true
+}
+
+func (mockScopedKeeper) ClaimCapability(sdk.Context, *capabilitytypes.Capability, string) error {
+	return nil
+}
+
+// mockQueryRouter answers the balance and the total supply queries
+type mockQueryRouter struct{}
+
+func (mockQueryRouter) Route(path string) baseapp.GRPCQueryHandler {
+	if path != balancePath && path != supplyPath {
+		return nil
+	}
+	return func(_ sdk.Context, req abci.RequestQuery) (abci.ResponseQuery, error) {
+		return abci.ResponseQuery{Value: append([]byte("balance of "), req.Data...)}, nil
+	}
+}
+
+// mockWasmKeeper records the sudo messages of the contract
+type mockWasmKeeper struct {
+	sudoMsgs [][]byte
+	sudoErr  error
+	sudoGas  uint64
+}
+
+func (m *mockWasmKeeper) GetContractInfo(_ sdk.Context, addr sdk.WasmAddress) *wasmtypes.ContractInfo {
+	if !addr.Equals(contract) {
+		return nil
+	}
+	return &wasmtypes.ContractInfo{}
+}
+
+func (m *mockWasmKeeper) Sudo(ctx sdk.Context, _ sdk.WasmAddress, msg []byte) ([]byte, error) {
+	ctx.GasMeter().ConsumeGas(m.sudoGas, "sudo")
+	if m.sudoErr != nil {
+		return nil, m.sudoErr
+	}
+	m.sudoMsgs = append(m.sudoMsgs, msg)
+	return nil, nil
+}
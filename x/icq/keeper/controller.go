@@ -0,0 +1,140 @@
+package keeper
+
+import (
+	"strconv"
+	"time"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	clienttypes "github.com/okex/exchain/libs/ibc-go/modules/core/02-client/types"
+	channeltypes "github.com/okex/exchain/libs/ibc-go/modules/core/04-channel/types"
+	host "github.com/okex/exchain/libs/ibc-go/modules/core/24-host"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	"github.com/okex/exchain/x/icq/types"
+)
+
+// SendQuery sends the queries of the contract over a channel of the controller port, it returns the sequence of
+// the sent packet. The result is delivered to the contract through a sudo callback.
+func (k Keeper) SendQuery(ctx sdk.Context, contract sdk.WasmAddress, channelID string, requests []abci.RequestQuery, timeout time.Duration) (uint64, error) {
+	if !k.GetParams(ctx).ControllerEnabled {
+		return 0, types.ErrControllerDisabled
+	}
+	sourcePort := types.ControllerPortID
+	channel, found := k.channelKeeper.GetChannel(ctx, sourcePort, channelID)
+	if !found {
+		return 0, sdkerrors.Wrapf(types.ErrChannelNotFound, "port ID (%s) channel ID (%s)", sourcePort, channelID)
+	}
+	sequence, found := k.channelKeeper.GetNextSequenceSend(ctx, sourcePort, channelID)
+	if !found {
+		return 0, sdkerrors.Wrapf(channeltypes.ErrSequenceSendNotFound, "source port: %s, source channel: %s", sourcePort, channelID)
+	}
+	channelCap, ok := k.scopedKeeper.GetCapability(ctx, host.ChannelCapabilityPath(sourcePort, channelID))
+	if !ok {
+		return 0, sdkerrors.Wrap(channeltypes.ErrChannelCapabilityNotFound, "module does not own channel capability")
+	}
+
+	packetData, err := types.NewInterchainQueryPacketData(requests, "")
+	if err != nil {
+		return 0, err
+	}
+	packet := channeltypes.NewPacket(
+		packetData.GetBytes(),
+		sequence,
+		sourcePort,
+		channelID,
+		channel.GetCounterparty().GetPortID(),
+		channel.GetCounterparty().GetChannelID(),
+		clienttypes.ZeroHeight(),
+		uint64(ctx.BlockTime().Add(timeout).UnixNano()),
+	)
+	if err := k.channelKeeper.SendPacket(ctx, channelCap, packet); err != nil {
+		return 0, err
+	}
+	k.SetPendingQuery(ctx, channelID, sequence, contract)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeSendQuery,
+		sdk.NewAttribute(types.AttributeKeyContract, contract.String()),
+		sdk.NewAttribute(types.AttributeKeyChannel, channelID),
+		sdk.NewAttribute(types.AttributeKeySequence, strconv.FormatUint(sequence, 10)),
+		sdk.NewAttribute(types.AttributeKeyRequests, strconv.Itoa(len(requests))),
+	))
+	return sequence, nil
+}
+
+// OnAcknowledgementPacket delivers the responses or the error of the host to the contract that sent the queries
+func (k Keeper) OnAcknowledgementPacket(ctx sdk.Context, packet channeltypes.Packet, ack channeltypes.Acknowledgement) {
+	channelID, sequence := packet.GetSourceChannel(), packet.GetSequence()
+	var msg types.SudoMsg
+	if ack.Success() {
+		responses, err := types.UnmarshalPacketAck(ack.GetResult())
+		if err != nil {
+			msg.ICQError = &types.ICQError{ChannelID: channelID, Sequence: sequence, Error: err.Error()}
+		} else {
+			msg.ICQResult = types.NewICQResult(channelID, sequence, responses)
+		}
+	} else {
+		msg.ICQError = &types.ICQError{ChannelID: channelID, Sequence: sequence, Error: ack.GetError()}
+	}
+	k.callback(ctx, channelID, sequence, msg)
+}
+
+// OnTimeoutPacket notifies the contract that sent the queries of the timeout
+func (k Keeper) OnTimeoutPacket(ctx sdk.Context, packet channeltypes.Packet) {
+	channelID, sequence := packet.GetSourceChannel(), packet.GetSequence()
+	k.callback(ctx, channelID, sequence, types.SudoMsg{ICQTimeout: &types.ICQTimeout{ChannelID: channelID, Sequence: sequence}})
+}
+
+// callback calls the sudo entry point of the contract waiting for the query packet. The contract can not fail the
+// acknowledgement or the timeout of the packet, its errors and running out of the callback gas are only emitted
+// as events.
+func (k Keeper) callback(ctx sdk.Context, channelID string, sequence uint64, msg types.SudoMsg) {
+	contract, found := k.GetPendingQuery(ctx, channelID, sequence)
+	if !found {
+		return
+	}
+	k.DeletePendingQuery(ctx, channelID, sequence)
+	if k.wasmKeeper.GetContractInfo(ctx, contract) == nil {
+		return
+	}
+	bz, err := types.NewSudoMsg(msg)
+	if err != nil {
+		return
+	}
+
+	cacheCtx, write := ctx.CacheContext()
+	err = k.sudo(cacheCtx, contract, bz)
+	attributes := []sdk.Attribute{
+		sdk.NewAttribute(types.AttributeKeyContract, contract.String()),
+		sdk.NewAttribute(types.AttributeKeyChannel, channelID),
+		sdk.NewAttribute(types.AttributeKeySequence, strconv.FormatUint(sequence, 10)),
+		sdk.NewAttribute(types.AttributeKeySuccess, strconv.FormatBool(err == nil)),
+	}
+	if err == nil {
+		write()
+		ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+	} else {
+		k.Logger(ctx).Info("interchain query callback failed", "contract", contract.String(), "error", err.Error())
+		attributes = append(attributes, sdk.NewAttribute(types.AttributeKeyError, err.Error()))
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(types.EventTypeICQCallback, attributes...))
+}
+
+// sudo calls the sudo entry point of the contract with the gas limited to CallbackGasLimit, the gas spent is charged
+// to the packet and running out of it is returned as an error
+func (k Keeper) sudo(ctx sdk.Context, contract sdk.WasmAddress, msg []byte) (err error) {
+	gasMeter := sdk.NewGasMeter(types.CallbackGasLimit)
+	subCtx := ctx
+	subCtx.SetGasMeter(gasMeter)
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(sdk.ErrorOutOfGas); !ok {
+				panic(r)
+			}
+			err = sdkerrors.Wrap(sdkerrors.ErrOutOfGas, "interchain query callback hit gas limit")
+		}
+		ctx.GasMeter().ConsumeGas(gasMeter.GasConsumedToLimit(), "interchain query callback")
+	}()
+	_, err = k.contractKeeper.Sudo(subCtx, contract, msg)
+	return err
+}
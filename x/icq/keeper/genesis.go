@@ -0,0 +1,28 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/icq/types"
+)
+
+// InitGenesis sets the params of the interchain query module and binds the host and the controller ports
+func (k Keeper) InitGenesis(ctx sdk.Context, state types.GenesisState) {
+	k.SetParams(ctx, state.Params)
+
+	// Only try to bind to port if it is not already bound, since we may already own
+	// port capability from capability InitGenesis
+	for _, portID := range []string{state.HostPort, state.ControllerPort} {
+		if !k.IsBound(ctx, portID) {
+			if err := k.BindPort(ctx, portID); err != nil {
+				panic(fmt.Sprintf("could not claim port capability: %v", err))
+			}
+		}
+	}
+}
+
+// ExportGenesis exports the ports and the params of the interchain query module
+func (k Keeper) ExportGenesis(ctx sdk.Context) types.GenesisState {
+	return types.NewGenesisState(types.HostPortID, types.ControllerPortID, k.GetParams(ctx))
+}
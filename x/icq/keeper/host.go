@@ -0,0 +1,62 @@
+package keeper
+
+import (
+	"strconv"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	channeltypes "github.com/okex/exchain/libs/ibc-go/modules/core/04-channel/types"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	"github.com/okex/exchain/x/icq/types"
+)
+
+// OnRecvPacket answers the queries of the packet received on the host port. All the query paths must be allowed,
+// a failing query fails the whole packet. The acknowledgement data is the protobuf encoded CosmosResponse.
+func (k Keeper) OnRecvPacket(ctx sdk.Context, packet channeltypes.Packet) ([]byte, error) {
+	params := k.GetParams(ctx)
+	if !params.HostEnabled {
+		return nil, types.ErrHostDisabled
+	}
+	_, requests, err := types.UnmarshalPacketData(packet.GetData())
+	if err != nil {
+		return nil, err
+	}
+
+	responses, err := k.executeQueries(ctx, params, requests)
+	if err != nil {
+		return nil, err
+	}
+	ack, err := types.NewInterchainQueryPacketAck(responses)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeHostQuery,
+		sdk.NewAttribute(types.AttributeKeyChannel, packet.GetDestChannel()),
+		sdk.NewAttribute(types.AttributeKeySequence, strconv.FormatUint(packet.GetSequence(), 10)),
+		sdk.NewAttribute(types.AttributeKeyRequests, strconv.Itoa(len(requests))),
+	))
+	return ack.GetBytes(), nil
+}
+
+// executeQueries answers the queries at the current height without proofs, the queries can not write the state
+func (k Keeper) executeQueries(ctx sdk.Context, params types.Params, requests []abci.RequestQuery) ([]abci.ResponseQuery, error) {
+	cacheCtx, _ := ctx.CacheContext()
+	responses := make([]abci.ResponseQuery, len(requests))
+	for i, req := range requests {
+		if !params.IsQueryAllowed(req.Path) {
+			return nil, sdkerrors.Wrapf(types.ErrQueryNotAllowed, "path %s", req.Path)
+		}
+		route := k.queryRouter.Route(req.Path)
+		if route == nil {
+			return nil, sdkerrors.Wrapf(types.ErrUnknownQueryPath, "path %s", req.Path)
+		}
+		res, err := route(cacheCtx, abci.RequestQuery{Path: req.Path, Data: req.Data})
+		if err != nil {
+			return nil, err
+		}
+		responses[i] = abci.ResponseQuery{Code: res.Code, Value: res.Value, Log: res.Log, Height: ctx.BlockHeight()}
+	}
+	return responses, nil
+}
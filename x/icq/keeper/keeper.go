@@ -0,0 +1,118 @@
+package keeper
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	capabilitytypes "github.com/okex/exchain/libs/cosmos-sdk/x/capability/types"
+	host "github.com/okex/exchain/libs/ibc-go/modules/core/24-host"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	"github.com/okex/exchain/x/icq/types"
+	paramtypes "github.com/okex/exchain/x/params"
+)
+
+// Keeper defines the interchain query keeper, it answers the queries received on the host port and sends the
+// queries of the wasm contracts from the controller port
+type Keeper struct {
+	storeKey   sdk.StoreKey
+	paramSpace paramtypes.Subspace
+
+	channelKeeper  types.ChannelKeeper
+	portKeeper     types.PortKeeper
+	scopedKeeper   types.ScopedKeeper
+	queryRouter    types.QueryRouter
+	wasmKeeper     types.WasmViewKeeper
+	contractKeeper types.ContractOpsKeeper
+}
+
+// NewKeeper creates a new interchain query Keeper instance
+func NewKeeper(
+	key sdk.StoreKey, paramSpace paramtypes.Subspace, channelKeeper types.ChannelKeeper, portKeeper types.PortKeeper,
+	scopedKeeper types.ScopedKeeper, queryRouter types.QueryRouter, wasmKeeper types.WasmViewKeeper,
+	contractKeeper types.ContractOpsKeeper,
+) Keeper {
+	// set KeyTable if it has not already been set
+	if !paramSpace.HasKeyTable() {
+		paramSpace = paramSpace.WithKeyTable(types.ParamKeyTable())
+	}
+
+	return Keeper{
+		storeKey:       key,
+		paramSpace:     paramSpace,
+		channelKeeper:  channelKeeper,
+		portKeeper:     portKeeper,
+		scopedKeeper:   scopedKeeper,
+		queryRouter:    queryRouter,
+		wasmKeeper:     wasmKeeper,
+		contractKeeper: contractKeeper,
+	}
+}
+
+// Logger returns a module-specific logger.
+func (k Keeper) Logger(ctx sdk.Context) log.Logger {
+	return ctx.Logger().With("module", "x/"+types.ModuleName)
+}
+
+// IsBound checks if the interchain query module is already bound to the desired port
+func (k Keeper) IsBound(ctx sdk.Context, portID string) bool {
+	_, ok := k.scopedKeeper.GetCapability(ctx, host.PortPath(portID))
+	return ok
+}
+
+// BindPort stores the port, binds to it and claims the returned capability
+func (k Keeper) BindPort(ctx sdk.Context, portID string) error {
+	ctx.KVStore(k.storeKey).Set(types.GetPortKey(portID), []byte{0x01})
+	cap := k.portKeeper.BindPort(ctx, portID)
+	return k.ClaimCapability(ctx, cap, host.PortPath(portID))
+}
+
+// AuthenticateCapability wraps the scopedKeeper's AuthenticateCapability function
+func (k Keeper) AuthenticateCapability(ctx sdk.Context, cap *capabilitytypes.Capability, name string) bool {
+	return k.scopedKeeper.AuthenticateCapability(ctx, cap, name)
+}
+
+// ClaimCapability allows the interchain query module to claim a capability that IBC module passes to it
+func (k Keeper) ClaimCapability(ctx sdk.Context, cap *capabilitytypes.Capability, name string) error {
+	return k.scopedKeeper.ClaimCapability(ctx, cap, name)
+}
+
+// GetParams returns the total set of the interchain query parameters.
+func (k Keeper) GetParams(ctx sdk.Context) (params types.Params) {
+	k.paramSpace.GetParamSet(ctx, &params)
+	return params
+}
+
+// SetParams sets the total set of the interchain query parameters.
+func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
+	k.paramSpace.SetParamSet(ctx, &params)
+}
+
+// SetPendingQuery records the contract waiting for the result of the query packet
+func (k Keeper) SetPendingQuery(ctx sdk.Context, channelID string, sequence uint64, contract sdk.WasmAddress) {
+	ctx.KVStore(k.storeKey).Set(types.GetPendingQueryKey(channelID, sequence), contract.Bytes())
+}
+
+// GetPendingQuery returns the contract waiting for the result of the query packet
+func (k Keeper) GetPendingQuery(ctx sdk.Context, channelID string, sequence uint64) (sdk.WasmAddress, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetPendingQueryKey(channelID, sequence))
+	if len(bz) == 0 {
+		return nil, false
+	}
+	return sdk.WasmAddress(bz), true
+}
+
+// DeletePendingQuery removes the query packet once it is acknowledged or timed out
+func (k Keeper) DeletePendingQuery(ctx sdk.Context, channelID string, sequence uint64) {
+	ctx.KVStore(k.storeKey).Delete(types.GetPendingQueryKey(channelID, sequence))
+}
+
+// GetPendingQueries returns all the queries waiting for their results
+func (k Keeper) GetPendingQueries(ctx sdk.Context) []types.PendingQuery {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.KeyPrefixPendingQuery)
+	defer iterator.Close()
+
+	queries := make([]types.PendingQuery, 0)
+	for ; iterator.Valid(); iterator.Next() {
+		channelID, sequence := types.SplitPendingQueryKey(iterator.Key())
+		queries = append(queries, types.NewPendingQuery(channelID, sequence, sdk.WasmAddress(iterator.Value())))
+	}
+	return queries
+}
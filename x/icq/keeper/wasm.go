@@ -0,0 +1,59 @@
+package keeper
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	"github.com/okex/exchain/x/icq/types"
+	"github.com/okex/exchain/x/wasm"
+)
+
+// RegisterICQMessenger needs to be registered in app setup to let wasm contracts send interchain queries
+func RegisterICQMessenger(k Keeper) func(old wasm.Messenger) wasm.Messenger {
+	return func(old wasm.Messenger) wasm.Messenger {
+		return icqMessenger{Keeper: k, next: old}
+	}
+}
+
+// icqMessenger handles the interchain query messages of the wasm contracts and passes the others on to the next handler
+type icqMessenger struct {
+	Keeper
+	next wasm.Messenger
+}
+
+func (m icqMessenger) DispatchMsg(ctx sdk.Context, contractAddr sdk.WasmAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) ([]sdk.Event, [][]byte, error) {
+	if msg.Custom != nil {
+		var icqMsg types.ICQMsg
+		if err := json.Unmarshal(msg.Custom, &icqMsg); err == nil && !icqMsg.IsEmpty() {
+			ctx.SetEventManager(sdk.NewEventManager())
+			data, err := m.handleICQMsg(ctx, contractAddr, icqMsg)
+			if err != nil {
+				return nil, nil, err
+			}
+			return ctx.EventManager().Events(), [][]byte{data}, nil
+		}
+	}
+	return m.next.DispatchMsg(ctx, contractAddr, contractIBCPortID, msg)
+}
+
+func (m icqMessenger) handleICQMsg(ctx sdk.Context, contract sdk.WasmAddress, msg types.ICQMsg) ([]byte, error) {
+	if !tmtypes.HigherThanJupiter(ctx.BlockHeight()) {
+		errMsg := fmt.Sprintf("interchain query not support at height %d", ctx.BlockHeight())
+		return nil, sdkerrors.Wrap(types.ErrICQNotSupport, errMsg)
+	}
+	query := msg.InterchainQuery
+	if err := query.ValidateBasic(); err != nil {
+		return nil, sdkerrors.Wrap(types.ErrInvalidICQMsg, err.Error())
+	}
+
+	sequence, err := m.SendQuery(ctx, contract, query.ChannelID, query.ABCIRequests(), time.Duration(query.TimeoutSeconds)*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(types.InterchainQueryMsgResponse{Sequence: sequence})
+}
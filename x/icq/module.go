@@ -0,0 +1,133 @@
+package icq
+
+import (
+	"encoding/json"
+
+	"github.com/gorilla/mux"
+	"github.com/spf13/cobra"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/client/context"
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/types/module"
+	"github.com/okex/exchain/libs/cosmos-sdk/types/upgrade"
+	"github.com/okex/exchain/libs/ibc-go/modules/core/base"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	"github.com/okex/exchain/x/icq/client/cli"
+	"github.com/okex/exchain/x/icq/keeper"
+	"github.com/okex/exchain/x/icq/types"
+)
+
+// type check to ensure the interface is properly implemented
+var (
+	_ module.AppModule      = AppModule{}
+	_ module.AppModuleBasic = AppModuleBasic{}
+	_ upgrade.UpgradeModule = AppModule{}
+)
+
+// AppModuleBasic type for the interchain query module
+type AppModuleBasic struct{}
+
+// Name returns the interchain query module's name.
+func (AppModuleBasic) Name() string {
+	return types.ModuleName
+}
+
+// RegisterCodec does nothing, the interchain query module has no messages
+func (AppModuleBasic) RegisterCodec(*codec.Codec) {}
+
+// DefaultGenesis returns nil, the ports are bound by the upgrade task
+func (AppModuleBasic) DefaultGenesis() json.RawMessage {
+	return nil
+}
+
+// ValidateGenesis is the validation check of the Genesis
+func (AppModuleBasic) ValidateGenesis(json.RawMessage) error {
+	return nil
+}
+
+// RegisterRESTRoutes Registers rest routes
+func (AppModuleBasic) RegisterRESTRoutes(context.CLIContext, *mux.Router) {}
+
+// GetQueryCmd Gets the root query command of this module
+func (AppModuleBasic) GetQueryCmd(cdc *codec.Codec) *cobra.Command {
+	return cli.GetQueryCmd(types.QuerierRoute, cdc)
+}
+
+// GetTxCmd returns nil, the interchain queries are sent by wasm contracts
+func (AppModuleBasic) GetTxCmd(*codec.Codec) *cobra.Command {
+	return nil
+}
+
+// ___________________________________________________________________________
+
+// AppModule implements the AppModule interface for the interchain query module.
+type AppModule struct {
+	AppModuleBasic
+	*base.BaseIBCUpgradeModule
+	keeper keeper.Keeper
+}
+
+// NewAppModule creates a new AppModule Object
+func NewAppModule(k keeper.Keeper) AppModule {
+	m := AppModule{
+		AppModuleBasic: AppModuleBasic{},
+		keeper:         k,
+	}
+	m.BaseIBCUpgradeModule = base.NewBaseIBCUpgradeModule(m)
+	return m
+}
+
+// Name returns the interchain query module's name.
+func (AppModule) Name() string {
+	return types.ModuleName
+}
+
+// RegisterInvariants registers the interchain query module's invariants.
+func (AppModule) RegisterInvariants(sdk.InvariantRegistry) {}
+
+// NewHandler returns nil - the interchain query module has no messages
+func (AppModule) NewHandler() sdk.Handler {
+	return nil
+}
+
+// Route returns an empty route, so no legacy handler is registered
+func (AppModule) Route() string {
+	return ""
+}
+
+// QuerierRoute returns the interchain query module's query routing key.
+func (AppModule) QuerierRoute() string {
+	return types.QuerierRoute
+}
+
+// NewQuerierHandler sets up new querier handler for module
+func (am AppModule) NewQuerierHandler() sdk.Querier {
+	return keeper.NewQuerier(am.keeper)
+}
+
+// BeginBlock returns the begin blocker for the interchain query module.
+func (AppModule) BeginBlock(sdk.Context, abci.RequestBeginBlock) {}
+
+// EndBlock executes all ABCI EndBlock logic respective to the interchain query module. It
+// returns no validator updates.
+func (AppModule) EndBlock(sdk.Context, abci.RequestEndBlock) []abci.ValidatorUpdate {
+	return []abci.ValidatorUpdate{}
+}
+
+// InitGenesis performs nothing, the ports are bound by the upgrade task
+func (AppModule) InitGenesis(sdk.Context, json.RawMessage) []abci.ValidatorUpdate {
+	return nil
+}
+
+func (am AppModule) initGenesis(ctx sdk.Context, data json.RawMessage) []abci.ValidatorUpdate {
+	var genesisState types.GenesisState
+	types.ModuleCdc.MustUnmarshalJSON(data, &genesisState)
+	am.keeper.InitGenesis(ctx, genesisState)
+	return nil
+}
+
+// ExportGenesis returns nil, the state of the module is set by the upgrade task
+func (AppModule) ExportGenesis(sdk.Context) json.RawMessage {
+	return nil
+}
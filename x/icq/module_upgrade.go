@@ -0,0 +1,86 @@
+package icq
+
+import (
+	store "github.com/okex/exchain/libs/cosmos-sdk/store/types"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/types/upgrade"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	"github.com/okex/exchain/x/icq/types"
+)
+
+var (
+	defaultVersionFilter store.VersionFilter = func(h int64) func(cb func(name string, version int64)) {
+		if h < 0 {
+			return func(cb func(name string, version int64)) {}
+		}
+
+		return func(cb func(name string, version int64)) {
+			cb(types.ModuleName, tmtypes.GetJupiterHeight())
+		}
+	}
+)
+
+// RegisterTask sets the params and binds the interchain query ports at the upgrade height
+func (am AppModule) RegisterTask() upgrade.HeightTask {
+	return upgrade.NewHeightTask(
+		7, func(ctx sdk.Context) error {
+			if am.Sealed() {
+				return nil
+			}
+			data := types.ModuleCdc.MustMarshalJSON(types.DefaultGenesisState())
+			am.initGenesis(ctx, data)
+			return nil
+		})
+}
+
+func (am AppModule) CommitFilter() *store.StoreFilter {
+	var filter store.StoreFilter
+	filter = func(module string, h int64, s store.CommitKVStore) bool {
+		if module != types.ModuleName {
+			return false
+		}
+		if am.UpgradeHeight() == 0 {
+			return true
+		}
+		if h == tmtypes.GetJupiterHeight() {
+			if s != nil {
+				s.SetUpgradeVersion(h)
+			}
+			return false
+		}
+
+		if tmtypes.HigherThanJupiter(h) {
+			return false
+		}
+
+		return true
+	}
+	return &filter
+}
+
+func (am AppModule) PruneFilter() *store.StoreFilter {
+	var filter store.StoreFilter
+	filter = func(module string, h int64, s store.CommitKVStore) bool {
+		if module != types.ModuleName {
+			return false
+		}
+
+		if am.UpgradeHeight() == 0 {
+			return true
+		}
+		if tmtypes.HigherThanJupiter(h) {
+			return false
+		}
+
+		return true
+	}
+	return &filter
+}
+
+func (am AppModule) VersionFilter() *store.VersionFilter {
+	return &defaultVersionFilter
+}
+
+func (am AppModule) UpgradeHeight() int64 {
+	return tmtypes.GetJupiterHeight()
+}
@@ -0,0 +1,13 @@
+package types
+
+import (
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+)
+
+// ModuleCdc defines the interchain query module's amino codec, the module has no messages
+var ModuleCdc = codec.New()
+
+func init() {
+	codec.RegisterCrypto(ModuleCdc)
+	ModuleCdc.Seal()
+}
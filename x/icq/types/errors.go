@@ -0,0 +1,20 @@
+package types
+
+import (
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+)
+
+// interchain query sentinel errors
+var (
+	ErrInvalidVersion         = sdkerrors.Register(ModuleName, 2, "invalid interchain query version")
+	ErrInvalidPort            = sdkerrors.Register(ModuleName, 3, "invalid interchain query port")
+	ErrInvalidPacketData      = sdkerrors.Register(ModuleName, 4, "invalid interchain query packet data")
+	ErrHostDisabled           = sdkerrors.Register(ModuleName, 5, "interchain query host is disabled")
+	ErrControllerDisabled     = sdkerrors.Register(ModuleName, 6, "interchain query controller is disabled")
+	ErrQueryNotAllowed        = sdkerrors.Register(ModuleName, 7, "query path is not allowed")
+	ErrUnknownQueryPath       = sdkerrors.Register(ModuleName, 8, "unknown query path")
+	ErrChannelNotFound        = sdkerrors.Register(ModuleName, 9, "channel not found")
+	ErrInvalidICQMsg          = sdkerrors.Register(ModuleName, 10, "invalid interchain query message")
+	ErrICQNotSupport          = sdkerrors.Register(ModuleName, 11, "interchain query is not supported")
+	ErrInvalidChannelOrdering = sdkerrors.Register(ModuleName, 12, "invalid channel ordering")
+)
@@ -0,0 +1,15 @@
+package types
+
+// interchain query events
+const (
+	EventTypeSendQuery   = "send_interchain_query"
+	EventTypeHostQuery   = "host_interchain_query"
+	EventTypeICQCallback = "interchain_query_callback"
+
+	AttributeKeyContract = "contract"
+	AttributeKeyChannel  = "channel"
+	AttributeKeySequence = "sequence"
+	AttributeKeyRequests = "requests"
+	AttributeKeySuccess  = "success"
+	AttributeKeyError    = "error"
+)
@@ -0,0 +1,55 @@
+package types
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	host "github.com/okex/exchain/libs/ibc-go/modules/core/24-host"
+)
+
+// GenesisState defines the interchain query module's genesis state.
+type GenesisState struct {
+	HostPort       string `json:"host_port"`
+	ControllerPort string `json:"controller_port"`
+	Params         Params `json:"params"`
+}
+
+// NewGenesisState creates a new genesis state.
+func NewGenesisState(hostPort, controllerPort string, params Params) GenesisState {
+	return GenesisState{
+		HostPort:       hostPort,
+		ControllerPort: controllerPort,
+		Params:         params,
+	}
+}
+
+// DefaultGenesisState returns the default genesis state, binding both ports
+func DefaultGenesisState() GenesisState {
+	return NewGenesisState(HostPortID, ControllerPortID, DefaultParams())
+}
+
+// Validate performs basic genesis state validation returning an error upon any
+// failure.
+func (gs GenesisState) Validate() error {
+	if err := host.PortIdentifierValidator(gs.HostPort); err != nil {
+		return err
+	}
+	if err := host.PortIdentifierValidator(gs.ControllerPort); err != nil {
+		return err
+	}
+	return gs.Params.Validate()
+}
+
+// PendingQuery is a query packet of a wasm contract waiting for its acknowledgement
+type PendingQuery struct {
+	ChannelID string `json:"channel_id"`
+	Sequence  uint64 `json:"sequence"`
+	Contract  string `json:"contract"`
+}
+
+// NewPendingQuery creates a new PendingQuery instance
+func NewPendingQuery(channelID string, sequence uint64, contract sdk.WasmAddress) PendingQuery {
+	return PendingQuery{
+		ChannelID: channelID,
+		Sequence:  sequence,
+		Contract:  contract.String(),
+	}
+}
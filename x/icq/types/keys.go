@@ -0,0 +1,71 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
+const (
+	// ModuleName defines the interchain query module name
+	ModuleName = "icq"
+
+	// Version defines the current version the ICS-31 interchain queries are implemented for
+	Version = "icq-1"
+
+	// HostPortID is the port the host side answers the interchain queries on
+	HostPortID = "icqhost"
+
+	// ControllerPortID is the port the wasm contracts send the interchain queries from
+	ControllerPortID = "icqcontroller"
+
+	// StoreKey is the store key string for the interchain query module
+	StoreKey = ModuleName
+
+	// RouterKey is the message route for the interchain query module
+	RouterKey = ModuleName
+
+	// QuerierRoute is the querier route for the interchain query module
+	QuerierRoute = ModuleName
+
+	QueryParams         = "params"
+	QueryPendingQueries = "pending-queries"
+)
+
+// CallbackGasLimit is the gas a contract can spend in the sudo callback of its interchain query
+const CallbackGasLimit uint64 = 1000000
+
+// prefix bytes for the interchain query persistent store
+const (
+	prefixPort = iota + 1
+	prefixPendingQuery
+)
+
+// KVStore key prefixes
+var (
+	KeyPrefixPort         = []byte{prefixPort}
+	KeyPrefixPendingQuery = []byte{prefixPendingQuery}
+)
+
+// GetPortKey returns the key of the bound port
+func GetPortKey(portID string) []byte {
+	return append(KeyPrefixPort, []byte(portID)...)
+}
+
+// GetPendingQueryChannelPrefix returns the prefix of the pending queries sent over the channel
+func GetPendingQueryChannelPrefix(channelID string) []byte {
+	return append(KeyPrefixPendingQuery, []byte(fmt.Sprintf("%s/", channelID))...)
+}
+
+// GetPendingQueryKey returns the key of the contract waiting for the result of the query packet
+func GetPendingQueryKey(channelID string, sequence uint64) []byte {
+	return append(GetPendingQueryChannelPrefix(channelID), sdk.Uint64ToBigEndian(sequence)...)
+}
+
+// SplitPendingQueryKey returns the channel and the sequence of the pending query key
+func SplitPendingQueryKey(key []byte) (channelID string, sequence uint64) {
+	key = key[len(KeyPrefixPendingQuery):]
+	// the sequence is the last 8 bytes, it follows the channel and a separator
+	channelID = string(key[:len(key)-9])
+	return channelID, sdk.BigEndianToUint64(key[len(key)-8:])
+}
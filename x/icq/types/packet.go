@@ -0,0 +1,175 @@
+package types
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+)
+
+// protobuf key of the repeated field 1 of CosmosQuery and CosmosResponse, with the length-delimited wire type
+const repeatedFieldKey = 1<<3 | 2
+
+// InterchainQueryPacketData is the ICS-31 packet sent by the controller, its data is a protobuf encoded CosmosQuery
+type InterchainQueryPacketData struct {
+	Data []byte `json:"data"`
+	Memo string `json:"memo"`
+}
+
+// NewInterchainQueryPacketData creates the packet data of the query requests
+func NewInterchainQueryPacketData(requests []abci.RequestQuery, memo string) (InterchainQueryPacketData, error) {
+	data, err := SerializeCosmosQuery(requests)
+	if err != nil {
+		return InterchainQueryPacketData{}, err
+	}
+	return InterchainQueryPacketData{Data: data, Memo: memo}, nil
+}
+
+// ValidateBasic performs a basic check of the packet data
+func (pd InterchainQueryPacketData) ValidateBasic() error {
+	if len(pd.Data) == 0 {
+		return sdkerrors.Wrap(ErrInvalidPacketData, "packet data cannot be empty")
+	}
+	return nil
+}
+
+// GetBytes returns the sorted JSON encoding of the packet data
+func (pd InterchainQueryPacketData) GetBytes() []byte {
+	return sdk.MustSortJSON(mustMarshalJSON(pd))
+}
+
+// UnmarshalPacketData decodes the packet data and its query requests
+func UnmarshalPacketData(bz []byte) (InterchainQueryPacketData, []abci.RequestQuery, error) {
+	var pd InterchainQueryPacketData
+	if err := json.Unmarshal(bz, &pd); err != nil {
+		return pd, nil, sdkerrors.Wrap(ErrInvalidPacketData, err.Error())
+	}
+	if err := pd.ValidateBasic(); err != nil {
+		return pd, nil, err
+	}
+	requests, err := DeserializeCosmosQuery(pd.Data)
+	if err != nil {
+		return pd, nil, err
+	}
+	return pd, requests, nil
+}
+
+// InterchainQueryPacketAck is the result acknowledgement of the host, its data is a protobuf encoded CosmosResponse
+type InterchainQueryPacketAck struct {
+	Data []byte `json:"data"`
+}
+
+// NewInterchainQueryPacketAck creates the acknowledgement data of the query responses
+func NewInterchainQueryPacketAck(responses []abci.ResponseQuery) (InterchainQueryPacketAck, error) {
+	data, err := SerializeCosmosResponse(responses)
+	if err != nil {
+		return InterchainQueryPacketAck{}, err
+	}
+	return InterchainQueryPacketAck{Data: data}, nil
+}
+
+// GetBytes returns the sorted JSON encoding of the acknowledgement data
+func (ack InterchainQueryPacketAck) GetBytes() []byte {
+	return sdk.MustSortJSON(mustMarshalJSON(ack))
+}
+
+// UnmarshalPacketAck decodes the acknowledgement data and its query responses
+func UnmarshalPacketAck(bz []byte) ([]abci.ResponseQuery, error) {
+	var ack InterchainQueryPacketAck
+	if err := json.Unmarshal(bz, &ack); err != nil {
+		return nil, sdkerrors.Wrap(ErrInvalidPacketData, err.Error())
+	}
+	return DeserializeCosmosResponse(ack.Data)
+}
+
+// SerializeCosmosQuery encodes the requests as the CosmosQuery message of ICS-31
+func SerializeCosmosQuery(requests []abci.RequestQuery) ([]byte, error) {
+	var bz []byte
+	for i := range requests {
+		item, err := requests[i].Marshal()
+		if err != nil {
+			return nil, err
+		}
+		bz = appendRepeatedField(bz, item)
+	}
+	return bz, nil
+}
+
+// DeserializeCosmosQuery decodes the CosmosQuery message of ICS-31
+func DeserializeCosmosQuery(bz []byte) ([]abci.RequestQuery, error) {
+	items, err := splitRepeatedField(bz)
+	if err != nil {
+		return nil, err
+	}
+	requests := make([]abci.RequestQuery, len(items))
+	for i, item := range items {
+		if err := requests[i].Unmarshal(item); err != nil {
+			return nil, sdkerrors.Wrap(ErrInvalidPacketData, err.Error())
+		}
+	}
+	return requests, nil
+}
+
+// SerializeCosmosResponse encodes the responses as the CosmosResponse message of ICS-31
+func SerializeCosmosResponse(responses []abci.ResponseQuery) ([]byte, error) {
+	var bz []byte
+	for i := range responses {
+		item, err := responses[i].Marshal()
+		if err != nil {
+			return nil, err
+		}
+		bz = appendRepeatedField(bz, item)
+	}
+	return bz, nil
+}
+
+// DeserializeCosmosResponse decodes the CosmosResponse message of ICS-31
+func DeserializeCosmosResponse(bz []byte) ([]abci.ResponseQuery, error) {
+	items, err := splitRepeatedField(bz)
+	if err != nil {
+		return nil, err
+	}
+	responses := make([]abci.ResponseQuery, len(items))
+	for i, item := range items {
+		if err := responses[i].Unmarshal(item); err != nil {
+			return nil, sdkerrors.Wrap(ErrInvalidPacketData, err.Error())
+		}
+	}
+	return responses, nil
+}
+
+func appendRepeatedField(bz, item []byte) []byte {
+	bz = binary.AppendUvarint(bz, repeatedFieldKey)
+	bz = binary.AppendUvarint(bz, uint64(len(item)))
+	return append(bz, item...)
+}
+
+func splitRepeatedField(bz []byte) ([][]byte, error) {
+	var items [][]byte
+	for len(bz) > 0 {
+		key, n := binary.Uvarint(bz)
+		if n <= 0 || key != repeatedFieldKey {
+			return nil, sdkerrors.Wrap(ErrInvalidPacketData, "unexpected field of the repeated message")
+		}
+		bz = bz[n:]
+		size, n := binary.Uvarint(bz)
+		if n <= 0 || uint64(len(bz)-n) < size {
+			return nil, sdkerrors.Wrap(ErrInvalidPacketData, "truncated message of the repeated field")
+		}
+		bz = bz[n:]
+		items = append(items, bz[:size])
+		bz = bz[size:]
+	}
+	return items, nil
+}
+
+func mustMarshalJSON(v interface{}) []byte {
+	bz, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Errorf("failed to marshal %T: %w", v, err))
+	}
+	return bz
+}
@@ -0,0 +1,53 @@
+package types
+
+import (
+	"testing"
+
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPacketDataRoundTrip(t *testing.T) {
+	requests := []abci.RequestQuery{
+		{Path: "/cosmos.bank.v1beta1.Query/Balance", Data: []byte{0x0a, 0x02, 0x6f, 0x6b}},
+		{Path: "/cosmos.bank.v1beta1.Query/TotalSupply"},
+	}
+	pd, err := NewInterchainQueryPacketData(requests, "memo")
+	require.NoError(t, err)
+
+	decoded, decodedRequests, err := UnmarshalPacketData(pd.GetBytes())
+	require.NoError(t, err)
+	require.Equal(t, "memo", decoded.Memo)
+	require.Len(t, decodedRequests, 2)
+	for i, req := range requests {
+		require.Equal(t, req.Path, decodedRequests[i].Path)
+		require.Equal(t, len(req.Data), len(decodedRequests[i].Data))
+	}
+
+	_, _, err = UnmarshalPacketData([]byte(`{"data":""}`))
+	require.ErrorIs(t, err, ErrInvalidPacketData)
+}
+
+func TestPacketAckRoundTrip(t *testing.T) {
+	responses := []abci.ResponseQuery{
+		{Value: []byte("balance"), Height: 10},
+		{Code: 1, Log: "not found", Height: 10},
+	}
+	ack, err := NewInterchainQueryPacketAck(responses)
+	require.NoError(t, err)
+
+	decoded, err := UnmarshalPacketAck(ack.GetBytes())
+	require.NoError(t, err)
+	require.Len(t, decoded, 2)
+	require.Equal(t, []byte("balance"), decoded[0].Value)
+	require.Equal(t, int64(10), decoded[0].Height)
+	require.Equal(t, uint32(1), decoded[1].Code)
+	require.Equal(t, "not found", decoded[1].Log)
+}
+
+func TestParamsValidate(t *testing.T) {
+	require.NoError(t, DefaultParams().Validate())
+	require.NoError(t, NewParams(true, []string{"/cosmos.bank.v1beta1.Query/Balance"}, true).Validate())
+	require.Error(t, NewParams(true, []string{""}, true).Validate())
+	require.Error(t, NewParams(true, []string{"cosmos.bank.v1beta1.Query/Balance"}, true).Validate())
+}
@@ -0,0 +1,115 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	paramtypes "github.com/okex/exchain/x/params"
+)
+
+const (
+	// DefaultHostEnabled is the default value for the host param (set to true)
+	DefaultHostEnabled = true
+	// DefaultControllerEnabled is the default value for the controller param (set to true)
+	DefaultControllerEnabled = true
+)
+
+var (
+	// KeyHostEnabled is the store key for HostEnabled Params
+	KeyHostEnabled = []byte("HostEnabled")
+	// KeyAllowQueries is the store key for the AllowQueries Params
+	KeyAllowQueries = []byte("AllowQueries")
+	// KeyControllerEnabled is the store key for ControllerEnabled Params
+	KeyControllerEnabled = []byte("ControllerEnabled")
+)
+
+// Params defines the parameters of the interchain query module. The host only answers the grpc query paths
+// of the allowlist, which starts empty.
+type Params struct {
+	HostEnabled       bool     `json:"host_enabled" yaml:"host_enabled"`
+	AllowQueries      []string `json:"allow_queries" yaml:"allow_queries"`
+	ControllerEnabled bool     `json:"controller_enabled" yaml:"controller_enabled"`
+}
+
+// ParamKeyTable type declaration for parameters
+func ParamKeyTable() paramtypes.KeyTable {
+	return paramtypes.NewKeyTable().RegisterParamSet(&Params{})
+}
+
+// NewParams creates a new parameter configuration for the interchain query module
+func NewParams(hostEnabled bool, allowQueries []string, controllerEnabled bool) Params {
+	return Params{
+		HostEnabled:       hostEnabled,
+		AllowQueries:      allowQueries,
+		ControllerEnabled: controllerEnabled,
+	}
+}
+
+// DefaultParams is the default parameter configuration for the interchain query module
+func DefaultParams() Params {
+	return NewParams(DefaultHostEnabled, []string{}, DefaultControllerEnabled)
+}
+
+// Validate validates all interchain query module parameters
+func (p Params) Validate() error {
+	if err := validateEnabled(p.HostEnabled); err != nil {
+		return err
+	}
+	if err := validateAllowlist(p.AllowQueries); err != nil {
+		return err
+	}
+	return validateEnabled(p.ControllerEnabled)
+}
+
+// IsQueryAllowed returns true if the host answers the query path
+func (p Params) IsQueryAllowed(path string) bool {
+	for _, allowed := range p.AllowQueries {
+		if allowed == path {
+			return true
+		}
+	}
+	return false
+}
+
+// ParamSetPairs implements params.ParamSet
+func (p *Params) ParamSetPairs() paramtypes.ParamSetPairs {
+	return paramtypes.ParamSetPairs{
+		paramtypes.NewParamSetPair(KeyHostEnabled, &p.HostEnabled, validateEnabled),
+		paramtypes.NewParamSetPair(KeyAllowQueries, &p.AllowQueries, validateAllowlist),
+		paramtypes.NewParamSetPair(KeyControllerEnabled, &p.ControllerEnabled, validateEnabled),
+	}
+}
+
+func (p Params) String() string {
+	return fmt.Sprintf(`Params:
+  Host Enabled:       %t
+  Allow Queries:      %s
+  Controller Enabled: %t`, p.HostEnabled, strings.Join(p.AllowQueries, ", "), p.ControllerEnabled)
+}
+
+func validateEnabled(i interface{}) error {
+	_, ok := i.(bool)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	return nil
+}
+
+func validateAllowlist(i interface{}) error {
+	allowQueries, ok := i.([]string)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	for _, path := range allowQueries {
+		if strings.TrimSpace(path) == "" {
+			return fmt.Errorf("parameter must not contain empty strings: %s", allowQueries)
+		}
+		if !strings.HasPrefix(path, "/") {
+			return fmt.Errorf("query path must be a grpc method path: %s", path)
+		}
+	}
+
+	return nil
+}
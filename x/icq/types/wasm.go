@@ -0,0 +1,114 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+)
+
+// ICQMsg is the custom message a wasm contract sends to query the state of the counterparty chain
+type ICQMsg struct {
+	InterchainQuery *InterchainQueryMsg `json:"interchain_query,omitempty"`
+}
+
+// IsEmpty returns true if the custom message is not an interchain query
+func (m ICQMsg) IsEmpty() bool {
+	return m.InterchainQuery == nil
+}
+
+// InterchainQueryMsg sends the query requests over a channel of the controller port, the result is delivered to
+// the contract through a sudo callback
+type InterchainQueryMsg struct {
+	ChannelID      string         `json:"channel_id"`
+	Requests       []QueryRequest `json:"requests"`
+	TimeoutSeconds uint64         `json:"timeout_seconds"`
+}
+
+// ValidateBasic checks the interchain query message
+func (m InterchainQueryMsg) ValidateBasic() error {
+	if m.ChannelID == "" {
+		return fmt.Errorf("channel id cannot be empty")
+	}
+	if len(m.Requests) == 0 {
+		return fmt.Errorf("requests cannot be empty")
+	}
+	for _, req := range m.Requests {
+		if req.Path == "" {
+			return fmt.Errorf("query path cannot be empty")
+		}
+	}
+	if m.TimeoutSeconds == 0 {
+		return fmt.Errorf("timeout cannot be zero")
+	}
+	return nil
+}
+
+// ABCIRequests returns the abci query requests of the message
+func (m InterchainQueryMsg) ABCIRequests() []abci.RequestQuery {
+	requests := make([]abci.RequestQuery, len(m.Requests))
+	for i, req := range m.Requests {
+		requests[i] = abci.RequestQuery{Path: req.Path, Data: req.Data}
+	}
+	return requests
+}
+
+// QueryRequest is a grpc query of the counterparty chain, data is the protobuf encoded request
+type QueryRequest struct {
+	Path string `json:"path"`
+	Data []byte `json:"data"`
+}
+
+// InterchainQueryMsgResponse is the data returned to the contract that sent the interchain query
+type InterchainQueryMsgResponse struct {
+	Sequence uint64 `json:"sequence"`
+}
+
+// SudoMsg is sent to the wasm contract that sent the interchain query once the packet is acknowledged or timed out
+type SudoMsg struct {
+	ICQResult  *ICQResult  `json:"icq_result,omitempty"`
+	ICQError   *ICQError   `json:"icq_error,omitempty"`
+	ICQTimeout *ICQTimeout `json:"icq_timeout,omitempty"`
+}
+
+// ICQResult carries the responses of the host, in the order of the requests
+type ICQResult struct {
+	ChannelID string          `json:"channel_id"`
+	Sequence  uint64          `json:"sequence"`
+	Responses []QueryResponse `json:"responses"`
+}
+
+// QueryResponse is the response of a grpc query of the counterparty chain, value is the protobuf encoded response
+type QueryResponse struct {
+	Code   uint32 `json:"code"`
+	Value  []byte `json:"value"`
+	Log    string `json:"log,omitempty"`
+	Height int64  `json:"height"`
+}
+
+// NewICQResult converts the abci query responses of the host
+func NewICQResult(channelID string, sequence uint64, responses []abci.ResponseQuery) *ICQResult {
+	result := &ICQResult{ChannelID: channelID, Sequence: sequence, Responses: make([]QueryResponse, len(responses))}
+	for i, res := range responses {
+		result.Responses[i] = QueryResponse{Code: res.Code, Value: res.Value, Log: res.Log, Height: res.Height}
+	}
+	return result
+}
+
+// ICQError is the error acknowledgement of the host
+type ICQError struct {
+	ChannelID string `json:"channel_id"`
+	Sequence  uint64 `json:"sequence"`
+	Error     string `json:"error"`
+}
+
+// ICQTimeout is the timeout of the query packet
+type ICQTimeout struct {
+	ChannelID string `json:"channel_id"`
+	Sequence  uint64 `json:"sequence"`
+}
+
+// NewSudoMsg returns the json sudo message of the query lifecycle
+func NewSudoMsg(msg SudoMsg) ([]byte, error) {
+	return json.Marshal(msg)
+}
@@ -0,0 +1,224 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/client"
+	"github.com/okex/exchain/libs/cosmos-sdk/client/context"
+	"github.com/okex/exchain/libs/cosmos-sdk/client/flags"
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/version"
+	"github.com/okex/exchain/x/nft/types"
+	"github.com/spf13/cobra"
+)
+
+const flagOwner = "owner"
+
+// GetQueryCmd returns the cli query commands for this module
+func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        types.ModuleName,
+		Short:                      fmt.Sprintf("Querying commands for the %s module", types.ModuleName),
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	cmd.AddCommand(flags.GetCommands(
+		GetCmdQueryClass(queryRoute, cdc),
+		GetCmdQueryClasses(queryRoute, cdc),
+		GetCmdQueryNFT(queryRoute, cdc),
+		GetCmdQueryNFTs(queryRoute, cdc),
+		GetCmdQueryOwner(queryRoute, cdc),
+		GetCmdQueryBalance(queryRoute, cdc),
+		GetCmdQuerySupply(queryRoute, cdc),
+	)...)
+
+	return cmd
+}
+
+// GetCmdQueryClass implements a command to return a class
+func GetCmdQueryClass(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "class [class-id]",
+		Short:   "Query a class",
+		Example: fmt.Sprintf(`$ %s query %s class my-class`, version.ClientName, types.ModuleName),
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			bz, err := query(cliCtx, queryRoute, types.QueryClass, types.NewQueryClassParams(args[0]))
+			if err != nil {
+				return err
+			}
+
+			var class types.Class
+			cdc.MustUnmarshalJSON(bz, &class)
+			return cliCtx.PrintOutput(class)
+		},
+	}
+}
+
+// GetCmdQueryClasses implements a command to return all the classes
+func GetCmdQueryClasses(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "classes",
+		Short:   "Query all the classes",
+		Example: fmt.Sprintf(`$ %s query %s classes`, version.ClientName, types.ModuleName),
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			bz, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryClasses), nil)
+			if err != nil {
+				return err
+			}
+
+			var classes []types.Class
+			cdc.MustUnmarshalJSON(bz, &classes)
+			return cliCtx.PrintOutput(classes)
+		},
+	}
+}
+
+// GetCmdQueryNFT implements a command to return an nft
+func GetCmdQueryNFT(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "nft [class-id] [id]",
+		Short:   "Query an nft",
+		Example: fmt.Sprintf(`$ %s query %s nft my-class token-1`, version.ClientName, types.ModuleName),
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			bz, err := query(cliCtx, queryRoute, types.QueryNFT, types.NewQueryNFTParams(args[0], args[1]))
+			if err != nil {
+				return err
+			}
+
+			var nft types.NFT
+			cdc.MustUnmarshalJSON(bz, &nft)
+			return cliCtx.PrintOutput(nft)
+		},
+	}
+}
+
+// GetCmdQueryNFTs implements a command to return the nfts of a class, optionally of an owner
+func GetCmdQueryNFTs(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "nfts [class-id]",
+		Short: "Query the nfts of a class, or all the nfts of an owner",
+		Example: fmt.Sprintf(`$ %s query %s nfts my-class
+$ %s query %s nfts --owner=ex1...`, version.ClientName, types.ModuleName, version.ClientName, types.ModuleName),
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			var params types.QueryNFTsParams
+			if len(args) == 1 {
+				params.ClassID = args[0]
+			}
+			if ownerStr, _ := cmd.Flags().GetString(flagOwner); ownerStr != "" {
+				owner, err := sdk.AccAddressFromBech32(ownerStr)
+				if err != nil {
+					return err
+				}
+				params.Owner = owner
+			}
+			if params.ClassID == "" && params.Owner.Empty() {
+				return fmt.Errorf("either the class id or the owner is required")
+			}
+
+			bz, err := query(cliCtx, queryRoute, types.QueryNFTs, params)
+			if err != nil {
+				return err
+			}
+
+			var nfts []types.NFT
+			cdc.MustUnmarshalJSON(bz, &nfts)
+			return cliCtx.PrintOutput(nfts)
+		},
+	}
+	cmd.Flags().String(flagOwner, "", "the owner of the nfts")
+	return cmd
+}
+
+// GetCmdQueryOwner implements a command to return the owner of an nft
+func GetCmdQueryOwner(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "owner [class-id] [id]",
+		Short:   "Query the owner of an nft",
+		Example: fmt.Sprintf(`$ %s query %s owner my-class token-1`, version.ClientName, types.ModuleName),
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			bz, err := query(cliCtx, queryRoute, types.QueryOwner, types.NewQueryNFTParams(args[0], args[1]))
+			if err != nil {
+				return err
+			}
+
+			var owner sdk.AccAddress
+			cdc.MustUnmarshalJSON(bz, &owner)
+			return cliCtx.PrintOutput(owner)
+		},
+	}
+}
+
+// GetCmdQueryBalance implements a command to return the number of the nfts of a class of an owner
+func GetCmdQueryBalance(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "balance [class-id] [owner]",
+		Short:   "Query the number of the nfts of a class of an owner",
+		Example: fmt.Sprintf(`$ %s query %s balance my-class ex1...`, version.ClientName, types.ModuleName),
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			owner, err := sdk.AccAddressFromBech32(args[1])
+			if err != nil {
+				return err
+			}
+			bz, err := query(cliCtx, queryRoute, types.QueryBalance, types.NewQueryBalanceParams(args[0], owner))
+			if err != nil {
+				return err
+			}
+
+			var balance uint64
+			cdc.MustUnmarshalJSON(bz, &balance)
+			return cliCtx.PrintOutput(balance)
+		},
+	}
+}
+
+// GetCmdQuerySupply implements a command to return the number of the nfts of a class
+func GetCmdQuerySupply(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "supply [class-id]",
+		Short:   "Query the number of the nfts of a class",
+		Example: fmt.Sprintf(`$ %s query %s supply my-class`, version.ClientName, types.ModuleName),
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			bz, err := query(cliCtx, queryRoute, types.QuerySupply, types.NewQueryClassParams(args[0]))
+			if err != nil {
+				return err
+			}
+
+			var supply uint64
+			cdc.MustUnmarshalJSON(bz, &supply)
+			return cliCtx.PrintOutput(supply)
+		},
+	}
+}
+
+func query(cliCtx context.CLIContext, queryRoute, path string, params interface{}) ([]byte, error) {
+	data, err := cliCtx.Codec.MarshalJSON(params)
+	if err != nil {
+		return nil, err
+	}
+	bz, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, path), data)
+	return bz, err
+}
@@ -0,0 +1,54 @@
+package nft
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/nft/keeper"
+	"github.com/okex/exchain/x/nft/types"
+)
+
+// InitGenesis import module genesis
+func InitGenesis(ctx sdk.Context, k keeper.Keeper, data types.GenesisState) {
+	for _, class := range data.Classes {
+		save := k.SaveClass
+		if types.IsVoucherClassID(class.ID) {
+			save = k.SaveVoucherClass
+		}
+		if err := save(ctx, class); err != nil {
+			panic(err)
+		}
+	}
+	for _, entry := range data.Entries {
+		for _, nft := range entry.NFTs {
+			if err := k.Mint(ctx, nft, entry.Owner); err != nil {
+				panic(err)
+			}
+		}
+	}
+	for _, minter := range data.Minters {
+		k.SetClassMinter(ctx, minter.ClassID, minter.Minter)
+	}
+}
+
+// ExportGenesis export module state
+func ExportGenesis(ctx sdk.Context, k keeper.Keeper) types.GenesisState {
+	entries := make(map[string]*types.Entry)
+	var owners []string
+	for _, class := range k.GetClasses(ctx) {
+		for _, nft := range k.GetNFTsOfClass(ctx, class.ID) {
+			owner := k.GetOwner(ctx, nft.ClassID, nft.ID)
+			entry, ok := entries[owner.String()]
+			if !ok {
+				entry = &types.Entry{Owner: owner}
+				entries[owner.String()] = entry
+				owners = append(owners, owner.String())
+			}
+			entry.NFTs = append(entry.NFTs, nft)
+		}
+	}
+
+	var genesisEntries []types.Entry
+	for _, owner := range owners {
+		genesisEntries = append(genesisEntries, *entries[owner])
+	}
+	return types.NewGenesisState(k.GetClasses(ctx), genesisEntries, k.GetClassMinters(ctx))
+}
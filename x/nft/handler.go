@@ -0,0 +1,46 @@
+package nft
+
+import (
+	"fmt"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	"github.com/okex/exchain/x/nft/keeper"
+	"github.com/okex/exchain/x/nft/types"
+)
+
+// NewHandler returns a handler for "nft" type messages.
+func NewHandler(k keeper.Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+		ctx.SetEventManager(sdk.NewEventManager())
+		if !tmtypes.HigherThanJupiter(ctx.BlockHeight()) {
+			errMsg := fmt.Sprintf("nft not support at height %d", ctx.BlockHeight())
+			return nil, sdkerrors.Wrap(types.ErrNFTNotSupport, errMsg)
+		}
+
+		switch msg := msg.(type) {
+		case types.MsgSend:
+			return handleMsgSend(ctx, k, msg)
+		default:
+			errMsg := fmt.Sprintf("unrecognized %s message type: %T", types.ModuleName, msg)
+			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, errMsg)
+		}
+	}
+}
+
+func handleMsgSend(ctx sdk.Context, k keeper.Keeper, msg types.MsgSend) (*sdk.Result, error) {
+	if owner := k.GetOwner(ctx, msg.ClassID, msg.ID); !owner.Equals(msg.Sender) {
+		return nil, sdkerrors.Wrapf(types.ErrUnauthorized, "%s is not the owner of nft %s of class %s", msg.Sender, msg.ID, msg.ClassID)
+	}
+	if err := k.Transfer(ctx, msg.ClassID, msg.ID, msg.Receiver); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender.String()),
+	))
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
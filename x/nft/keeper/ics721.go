@@ -0,0 +1,59 @@
+package keeper
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/nft/types"
+)
+
+// ICS721Keeper adapts the nft store to the nft keeper expected by the ics721 transfer module,
+// which passes the classes and the nfts as plain fields
+type ICS721Keeper struct {
+	k Keeper
+}
+
+// NewICS721Keeper creates a new ICS721Keeper instance
+func NewICS721Keeper(k Keeper) ICS721Keeper {
+	return ICS721Keeper{k: k}
+}
+
+// HasClass returns true if the class exists
+func (ik ICS721Keeper) HasClass(ctx sdk.Context, classID string) bool {
+	return ik.k.HasClass(ctx, classID)
+}
+
+// GetClassInfo returns the uri and the data of the class
+func (ik ICS721Keeper) GetClassInfo(ctx sdk.Context, classID string) (uri, data string, found bool) {
+	class, found := ik.k.GetClass(ctx, classID)
+	return class.URI, class.Data, found
+}
+
+// SaveClassInfo stores a new class with the uri and the data
+func (ik ICS721Keeper) SaveClassInfo(ctx sdk.Context, classID, uri, data string) error {
+	return ik.k.SaveVoucherClass(ctx, types.Class{ID: classID, URI: uri, Data: data})
+}
+
+// GetNFTInfo returns the uri and the data of the nft
+func (ik ICS721Keeper) GetNFTInfo(ctx sdk.Context, classID, tokenID string) (uri, data string, found bool) {
+	nft, found := ik.k.GetNFT(ctx, classID, tokenID)
+	return nft.URI, nft.Data, found
+}
+
+// GetNFTOwner returns the owner of the nft
+func (ik ICS721Keeper) GetNFTOwner(ctx sdk.Context, classID, tokenID string) sdk.AccAddress {
+	return ik.k.GetOwner(ctx, classID, tokenID)
+}
+
+// MintNFT mints a new nft with the uri and the data to the receiver
+func (ik ICS721Keeper) MintNFT(ctx sdk.Context, classID, tokenID, uri, data string, receiver sdk.AccAddress) error {
+	return ik.k.Mint(ctx, types.NFT{ClassID: classID, ID: tokenID, URI: uri, Data: data}, receiver)
+}
+
+// BurnNFT deletes the nft
+func (ik ICS721Keeper) BurnNFT(ctx sdk.Context, classID, tokenID string) error {
+	return ik.k.Burn(ctx, classID, tokenID)
+}
+
+// TransferNFT sets the receiver as the owner of the nft
+func (ik ICS721Keeper) TransferNFT(ctx sdk.Context, classID, tokenID string, receiver sdk.AccAddress) error {
+	return ik.k.Transfer(ctx, classID, tokenID, receiver)
+}
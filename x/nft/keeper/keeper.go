@@ -0,0 +1,307 @@
+package keeper
+
+import (
+	"encoding/binary"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	"github.com/okex/exchain/x/nft/types"
+)
+
+// Keeper of the nft store. It keeps the classes, the nfts and their owners without any permission,
+// the callers are the messages, the wasm bindings, the evm wrappers and the ics721 transfer module.
+type Keeper struct {
+	storeKey sdk.StoreKey
+}
+
+// NewKeeper creates a new nft Keeper instance
+func NewKeeper(storeKey sdk.StoreKey) Keeper {
+	return Keeper{storeKey: storeKey}
+}
+
+// Logger returns a module-specific logger.
+func (k Keeper) Logger(ctx sdk.Context) log.Logger {
+	return ctx.Logger().With("module", "x/"+types.ModuleName)
+}
+
+// SaveClass stores a new class issued on this chain
+func (k Keeper) SaveClass(ctx sdk.Context, class types.Class) error {
+	if err := types.ValidateClassID(class.ID); err != nil {
+		return err
+	}
+	return k.saveClass(ctx, class)
+}
+
+// SaveVoucherClass stores a new ibc voucher class, it is only used by the nft transfer module
+func (k Keeper) SaveVoucherClass(ctx sdk.Context, class types.Class) error {
+	if err := types.ValidateVoucherClassID(class.ID); err != nil {
+		return err
+	}
+	return k.saveClass(ctx, class)
+}
+
+func (k Keeper) saveClass(ctx sdk.Context, class types.Class) error {
+	if err := class.Validate(); err != nil {
+		return err
+	}
+	if k.HasClass(ctx, class.ID) {
+		return sdkerrors.Wrapf(types.ErrClassExists, "class %s", class.ID)
+	}
+	k.setClass(ctx, class)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeSaveClass,
+		sdk.NewAttribute(types.AttributeKeyClassID, class.ID),
+	))
+	return nil
+}
+
+// UpdateClass updates the metadata of an existing class
+func (k Keeper) UpdateClass(ctx sdk.Context, class types.Class) error {
+	if !k.HasClass(ctx, class.ID) {
+		return sdkerrors.Wrapf(types.ErrClassNotFound, "class %s", class.ID)
+	}
+	k.setClass(ctx, class)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeUpdateClass,
+		sdk.NewAttribute(types.AttributeKeyClassID, class.ID),
+	))
+	return nil
+}
+
+func (k Keeper) setClass(ctx sdk.Context, class types.Class) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetClassKey(class.ID), types.ModuleCdc.MustMarshalBinaryBare(class))
+}
+
+// GetClass returns the class
+func (k Keeper) GetClass(ctx sdk.Context, classID string) (class types.Class, found bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetClassKey(classID))
+	if bz == nil {
+		return class, false
+	}
+	types.ModuleCdc.MustUnmarshalBinaryBare(bz, &class)
+	return class, true
+}
+
+// HasClass returns true if the class exists
+func (k Keeper) HasClass(ctx sdk.Context, classID string) bool {
+	return ctx.KVStore(k.storeKey).Has(types.GetClassKey(classID))
+}
+
+// GetClasses returns all the classes
+func (k Keeper) GetClasses(ctx sdk.Context) []types.Class {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.KeyPrefixClass)
+	defer iterator.Close()
+
+	classes := make([]types.Class, 0)
+	for ; iterator.Valid(); iterator.Next() {
+		var class types.Class
+		types.ModuleCdc.MustUnmarshalBinaryBare(iterator.Value(), &class)
+		classes = append(classes, class)
+	}
+	return classes
+}
+
+// Mint mints a new nft of an existing class to the receiver
+func (k Keeper) Mint(ctx sdk.Context, nft types.NFT, receiver sdk.AccAddress) error {
+	if err := nft.Validate(); err != nil {
+		return err
+	}
+	if !k.HasClass(ctx, nft.ClassID) {
+		return sdkerrors.Wrapf(types.ErrClassNotFound, "class %s", nft.ClassID)
+	}
+	if k.HasNFT(ctx, nft.ClassID, nft.ID) {
+		return sdkerrors.Wrapf(types.ErrNFTExists, "nft %s of class %s", nft.ID, nft.ClassID)
+	}
+
+	k.setNFT(ctx, nft)
+	k.setOwner(ctx, nft.ClassID, nft.ID, receiver)
+	k.setTotalSupply(ctx, nft.ClassID, k.GetTotalSupply(ctx, nft.ClassID)+1)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeMint,
+		sdk.NewAttribute(types.AttributeKeyClassID, nft.ClassID),
+		sdk.NewAttribute(types.AttributeKeyID, nft.ID),
+		sdk.NewAttribute(types.AttributeKeyOwner, receiver.String()),
+	))
+	return nil
+}
+
+// Burn deletes an nft
+func (k Keeper) Burn(ctx sdk.Context, classID, id string) error {
+	owner := k.GetOwner(ctx, classID, id)
+	if owner.Empty() {
+		return sdkerrors.Wrapf(types.ErrNFTNotFound, "nft %s of class %s", id, classID)
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetNFTKey(classID, id))
+	store.Delete(types.GetOwnerKey(classID, id))
+	store.Delete(types.GetNFTOfOwnerKey(owner, classID, id))
+	k.setTotalSupply(ctx, classID, k.GetTotalSupply(ctx, classID)-1)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeBurn,
+		sdk.NewAttribute(types.AttributeKeyClassID, classID),
+		sdk.NewAttribute(types.AttributeKeyID, id),
+		sdk.NewAttribute(types.AttributeKeyOwner, owner.String()),
+	))
+	return nil
+}
+
+// Update updates the uri and the data of an existing nft
+func (k Keeper) Update(ctx sdk.Context, nft types.NFT) error {
+	if !k.HasNFT(ctx, nft.ClassID, nft.ID) {
+		return sdkerrors.Wrapf(types.ErrNFTNotFound, "nft %s of class %s", nft.ID, nft.ClassID)
+	}
+	k.setNFT(ctx, nft)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeUpdate,
+		sdk.NewAttribute(types.AttributeKeyClassID, nft.ClassID),
+		sdk.NewAttribute(types.AttributeKeyID, nft.ID),
+	))
+	return nil
+}
+
+// Transfer moves an nft to the receiver
+func (k Keeper) Transfer(ctx sdk.Context, classID, id string, receiver sdk.AccAddress) error {
+	owner := k.GetOwner(ctx, classID, id)
+	if owner.Empty() {
+		return sdkerrors.Wrapf(types.ErrNFTNotFound, "nft %s of class %s", id, classID)
+	}
+
+	ctx.KVStore(k.storeKey).Delete(types.GetNFTOfOwnerKey(owner, classID, id))
+	k.setOwner(ctx, classID, id, receiver)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeSend,
+		sdk.NewAttribute(types.AttributeKeyClassID, classID),
+		sdk.NewAttribute(types.AttributeKeyID, id),
+		sdk.NewAttribute(types.AttributeKeySender, owner.String()),
+		sdk.NewAttribute(types.AttributeKeyReceiver, receiver.String()),
+	))
+	return nil
+}
+
+func (k Keeper) setNFT(ctx sdk.Context, nft types.NFT) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetNFTKey(nft.ClassID, nft.ID), types.ModuleCdc.MustMarshalBinaryBare(nft))
+}
+
+func (k Keeper) setOwner(ctx sdk.Context, classID, id string, owner sdk.AccAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetOwnerKey(classID, id), owner.Bytes())
+	store.Set(types.GetNFTOfOwnerKey(owner, classID, id), []byte{0x01})
+}
+
+// GetNFT returns the nft
+func (k Keeper) GetNFT(ctx sdk.Context, classID, id string) (nft types.NFT, found bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetNFTKey(classID, id))
+	if bz == nil {
+		return nft, false
+	}
+	types.ModuleCdc.MustUnmarshalBinaryBare(bz, &nft)
+	return nft, true
+}
+
+// HasNFT returns true if the nft exists
+func (k Keeper) HasNFT(ctx sdk.Context, classID, id string) bool {
+	return ctx.KVStore(k.storeKey).Has(types.GetNFTKey(classID, id))
+}
+
+// GetOwner returns the owner of the nft, it is empty if the nft does not exist
+func (k Keeper) GetOwner(ctx sdk.Context, classID, id string) sdk.AccAddress {
+	return ctx.KVStore(k.storeKey).Get(types.GetOwnerKey(classID, id))
+}
+
+// GetNFTsOfClass returns all the nfts of the class
+func (k Keeper) GetNFTsOfClass(ctx sdk.Context, classID string) []types.NFT {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.GetNFTClassPrefix(classID))
+	defer iterator.Close()
+
+	nfts := make([]types.NFT, 0)
+	for ; iterator.Valid(); iterator.Next() {
+		var nft types.NFT
+		types.ModuleCdc.MustUnmarshalBinaryBare(iterator.Value(), &nft)
+		nfts = append(nfts, nft)
+	}
+	return nfts
+}
+
+// GetNFTsOfOwner returns all the nfts of the owner, of one class if the class is given
+func (k Keeper) GetNFTsOfOwner(ctx sdk.Context, owner sdk.AccAddress, classID string) []types.NFT {
+	prefix := types.GetNFTOfOwnerPrefix(owner)
+	if classID != "" {
+		prefix = types.GetNFTOfClassByOwnerPrefix(owner, classID)
+	}
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), prefix)
+	defer iterator.Close()
+
+	nfts := make([]types.NFT, 0)
+	for ; iterator.Valid(); iterator.Next() {
+		_, class, id := types.SplitNFTOfOwnerKey(iterator.Key())
+		if nft, found := k.GetNFT(ctx, class, id); found {
+			nfts = append(nfts, nft)
+		}
+	}
+	return nfts
+}
+
+// GetBalance returns the number of the nfts of the class of the owner
+func (k Keeper) GetBalance(ctx sdk.Context, classID string, owner sdk.AccAddress) uint64 {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.GetNFTOfClassByOwnerPrefix(owner, classID))
+	defer iterator.Close()
+
+	var balance uint64
+	for ; iterator.Valid(); iterator.Next() {
+		balance++
+	}
+	return balance
+}
+
+// GetTotalSupply returns the number of the nfts of the class
+func (k Keeper) GetTotalSupply(ctx sdk.Context, classID string) uint64 {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetClassTotalSupplyKey(classID))
+	if bz == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(bz)
+}
+
+func (k Keeper) setTotalSupply(ctx sdk.Context, classID string, supply uint64) {
+	store := ctx.KVStore(k.storeKey)
+	if supply == 0 {
+		store.Delete(types.GetClassTotalSupplyKey(classID))
+		return
+	}
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, supply)
+	store.Set(types.GetClassTotalSupplyKey(classID), bz)
+}
+
+// SetClassMinter sets the account allowed to mint and update the nfts of the class through the wasm bindings
+func (k Keeper) SetClassMinter(ctx sdk.Context, classID string, minter sdk.AccAddress) {
+	ctx.KVStore(k.storeKey).Set(types.GetClassMinterKey(classID), minter.Bytes())
+}
+
+// GetClassMinter returns the account allowed to mint the nfts of the class, it is empty for the other classes
+func (k Keeper) GetClassMinter(ctx sdk.Context, classID string) sdk.AccAddress {
+	return ctx.KVStore(k.storeKey).Get(types.GetClassMinterKey(classID))
+}
+
+// GetClassMinters returns the minters of all the classes issued through the wasm bindings
+func (k Keeper) GetClassMinters(ctx sdk.Context) []types.ClassMinter {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.KeyPrefixClassMinter)
+	defer iterator.Close()
+
+	minters := make([]types.ClassMinter, 0)
+	for ; iterator.Valid(); iterator.Next() {
+		classID := string(iterator.Key()[len(types.KeyPrefixClassMinter):])
+		minters = append(minters, types.ClassMinter{ClassID: classID, Minter: iterator.Value()})
+	}
+	return minters
+}
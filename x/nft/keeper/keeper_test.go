@@ -0,0 +1,125 @@
+package keeper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/store"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	"github.com/okex/exchain/libs/tendermint/crypto/ed25519"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	tmdb "github.com/okex/exchain/libs/tm-db"
+	"github.com/okex/exchain/x/nft/keeper"
+	"github.com/okex/exchain/x/nft/types"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	testClass = "kitty"
+	testNFT   = "kitty-1"
+)
+
+var (
+	alice = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	bob   = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+)
+
+type KeeperTestSuite struct {
+	suite.Suite
+
+	ctx    sdk.Context
+	keeper keeper.Keeper
+}
+
+func TestKeeperTestSuite(t *testing.T) {
+	suite.Run(t, new(KeeperTestSuite))
+}
+
+func (suite *KeeperTestSuite) SetupTest() {
+	key := sdk.NewKVStoreKey(types.StoreKey)
+	ms := store.NewCommitMultiStore(tmdb.NewMemDB())
+	ms.MountStoreWithDB(key, sdk.StoreTypeIAVL, nil)
+	suite.Require().NoError(ms.LoadLatestVersion())
+	suite.ctx = sdk.NewContext(ms, abci.Header{Height: 2, Time: time.Unix(1000, 0)}, false, log.NewNopLogger())
+	tmtypes.UnittestOnlySetMilestoneEarthHeight(1)
+	tmtypes.UnittestOnlySetMilestoneJupiterHeight(1)
+
+	suite.keeper = keeper.NewKeeper(key)
+}
+
+func (suite *KeeperTestSuite) saveClass(classID string) {
+	suite.Require().NoError(suite.keeper.SaveClass(suite.ctx, types.Class{ID: classID, Name: "Kitty", Symbol: "KT"}))
+}
+
+func (suite *KeeperTestSuite) mint(classID, id string, owner sdk.AccAddress) {
+	suite.Require().NoError(suite.keeper.Mint(suite.ctx, types.NFT{ClassID: classID, ID: id, URI: "ipfs://" + id}, owner))
+}
+
+func (suite *KeeperTestSuite) TestSaveClass() {
+	suite.saveClass(testClass)
+	class, found := suite.keeper.GetClass(suite.ctx, testClass)
+	suite.Require().True(found)
+	suite.Require().Equal("KT", class.Symbol)
+
+	suite.Require().ErrorIs(suite.keeper.SaveClass(suite.ctx, types.Class{ID: testClass}), types.ErrClassExists)
+	suite.Require().Error(suite.keeper.SaveClass(suite.ctx, types.Class{ID: "1-invalid"}))
+	suite.Require().ErrorIs(suite.keeper.SaveClass(suite.ctx, types.Class{ID: types.IBCClassPrefix + testClass}), types.ErrInvalidClass)
+	suite.Require().ErrorIs(suite.keeper.SaveVoucherClass(suite.ctx, types.Class{ID: testClass}), types.ErrInvalidClass)
+	suite.Require().NoError(suite.keeper.SaveVoucherClass(suite.ctx, types.Class{ID: types.IBCClassPrefix + testClass}))
+	suite.Require().ErrorIs(suite.keeper.UpdateClass(suite.ctx, types.Class{ID: "unknown"}), types.ErrClassNotFound)
+
+	class.Description = "updated"
+	suite.Require().NoError(suite.keeper.UpdateClass(suite.ctx, class))
+	class, _ = suite.keeper.GetClass(suite.ctx, testClass)
+	suite.Require().Equal("updated", class.Description)
+	suite.Require().Len(suite.keeper.GetClasses(suite.ctx), 2)
+}
+
+func (suite *KeeperTestSuite) TestMintTransferBurn() {
+	suite.Require().ErrorIs(suite.keeper.Mint(suite.ctx, types.NFT{ClassID: testClass, ID: testNFT}, alice), types.ErrClassNotFound)
+
+	suite.saveClass(testClass)
+	suite.mint(testClass, testNFT, alice)
+	suite.Require().ErrorIs(suite.keeper.Mint(suite.ctx, types.NFT{ClassID: testClass, ID: testNFT}, bob), types.ErrNFTExists)
+	suite.Require().Equal(alice, suite.keeper.GetOwner(suite.ctx, testClass, testNFT))
+	suite.Require().Equal(uint64(1), suite.keeper.GetBalance(suite.ctx, testClass, alice))
+	suite.Require().Equal(uint64(1), suite.keeper.GetTotalSupply(suite.ctx, testClass))
+
+	suite.Require().NoError(suite.keeper.Transfer(suite.ctx, testClass, testNFT, bob))
+	suite.Require().Equal(bob, suite.keeper.GetOwner(suite.ctx, testClass, testNFT))
+	suite.Require().Equal(uint64(0), suite.keeper.GetBalance(suite.ctx, testClass, alice))
+	suite.Require().Equal(uint64(1), suite.keeper.GetBalance(suite.ctx, testClass, bob))
+
+	suite.Require().NoError(suite.keeper.Update(suite.ctx, types.NFT{ClassID: testClass, ID: testNFT, URI: "ipfs://updated"}))
+	nft, found := suite.keeper.GetNFT(suite.ctx, testClass, testNFT)
+	suite.Require().True(found)
+	suite.Require().Equal("ipfs://updated", nft.URI)
+
+	suite.Require().NoError(suite.keeper.Burn(suite.ctx, testClass, testNFT))
+	suite.Require().False(suite.keeper.HasNFT(suite.ctx, testClass, testNFT))
+	suite.Require().True(suite.keeper.GetOwner(suite.ctx, testClass, testNFT).Empty())
+	suite.Require().Equal(uint64(0), suite.keeper.GetBalance(suite.ctx, testClass, bob))
+	suite.Require().Equal(uint64(0), suite.keeper.GetTotalSupply(suite.ctx, testClass))
+	suite.Require().ErrorIs(suite.keeper.Burn(suite.ctx, testClass, testNFT), types.ErrNFTNotFound)
+	suite.Require().ErrorIs(suite.keeper.Transfer(suite.ctx, testClass, testNFT, alice), types.ErrNFTNotFound)
+}
+
+func (suite *KeeperTestSuite) TestGetNFTsOfOwner() {
+	// the class ids share a prefix, the nfts of one class must not leak into the other
+	suite.saveClass(testClass)
+	suite.saveClass(testClass + "s")
+	suite.mint(testClass, "1", alice)
+	suite.mint(testClass, "2", alice)
+	suite.mint(testClass+"s", "1", alice)
+	suite.mint(testClass, "3", bob)
+
+	suite.Require().Len(suite.keeper.GetNFTsOfOwner(suite.ctx, alice, ""), 3)
+	suite.Require().Len(suite.keeper.GetNFTsOfOwner(suite.ctx, alice, testClass), 2)
+	suite.Require().Len(suite.keeper.GetNFTsOfOwner(suite.ctx, alice, testClass+"s"), 1)
+	suite.Require().Len(suite.keeper.GetNFTsOfOwner(suite.ctx, bob, ""), 1)
+	suite.Require().Len(suite.keeper.GetNFTsOfClass(suite.ctx, testClass), 3)
+	suite.Require().Equal(uint64(2), suite.keeper.GetBalance(suite.ctx, testClass, alice))
+	suite.Require().Equal(uint64(3), suite.keeper.GetTotalSupply(suite.ctx, testClass))
+}
@@ -0,0 +1,70 @@
+package keeper
+
+import (
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	"github.com/okex/exchain/x/nft/types"
+)
+
+// NewQuerier is the module level router for state queries
+func NewQuerier(k Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, error) {
+		if len(path) < 1 {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest,
+				"Insufficient parameters, at least 1 parameter is required")
+		}
+
+		switch path[0] {
+		case types.QueryClass:
+			var params types.QueryClassParams
+			if err := types.ModuleCdc.UnmarshalJSON(req.Data, &params); err != nil {
+				return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+			}
+			class, found := k.GetClass(ctx, params.ClassID)
+			if !found {
+				return nil, sdkerrors.Wrapf(types.ErrClassNotFound, "class %s", params.ClassID)
+			}
+			return codec.MarshalJSONIndent(types.ModuleCdc, class)
+		case types.QueryClasses:
+			return codec.MarshalJSONIndent(types.ModuleCdc, k.GetClasses(ctx))
+		case types.QueryNFT, types.QueryOwner:
+			var params types.QueryNFTParams
+			if err := types.ModuleCdc.UnmarshalJSON(req.Data, &params); err != nil {
+				return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+			}
+			nft, found := k.GetNFT(ctx, params.ClassID, params.ID)
+			if !found {
+				return nil, sdkerrors.Wrapf(types.ErrNFTNotFound, "nft %s of class %s", params.ID, params.ClassID)
+			}
+			if path[0] == types.QueryOwner {
+				return codec.MarshalJSONIndent(types.ModuleCdc, k.GetOwner(ctx, params.ClassID, params.ID))
+			}
+			return codec.MarshalJSONIndent(types.ModuleCdc, nft)
+		case types.QueryNFTs:
+			var params types.QueryNFTsParams
+			if err := types.ModuleCdc.UnmarshalJSON(req.Data, &params); err != nil {
+				return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+			}
+			if params.Owner.Empty() {
+				return codec.MarshalJSONIndent(types.ModuleCdc, k.GetNFTsOfClass(ctx, params.ClassID))
+			}
+			return codec.MarshalJSONIndent(types.ModuleCdc, k.GetNFTsOfOwner(ctx, params.Owner, params.ClassID))
+		case types.QueryBalance:
+			var params types.QueryBalanceParams
+			if err := types.ModuleCdc.UnmarshalJSON(req.Data, &params); err != nil {
+				return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+			}
+			return codec.MarshalJSONIndent(types.ModuleCdc, k.GetBalance(ctx, params.ClassID, params.Owner))
+		case types.QuerySupply:
+			var params types.QueryClassParams
+			if err := types.ModuleCdc.UnmarshalJSON(req.Data, &params); err != nil {
+				return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+			}
+			return codec.MarshalJSONIndent(types.ModuleCdc, k.GetTotalSupply(ctx, params.ClassID))
+		default:
+			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "unknown query endpoint")
+		}
+	}
+}
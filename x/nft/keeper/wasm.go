@@ -0,0 +1,148 @@
+package keeper
+
+import (
+	"encoding/json"
+	"fmt"
+
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	"github.com/okex/exchain/x/nft/types"
+	"github.com/okex/exchain/x/wasm"
+)
+
+// RegisterNFTMessenger needs to be registered in app setup to let wasm contracts manage the nfts of the native store
+func RegisterNFTMessenger(k Keeper) func(old wasm.Messenger) wasm.Messenger {
+	return func(old wasm.Messenger) wasm.Messenger {
+		return nftMessenger{Keeper: k, next: old}
+	}
+}
+
+// nftMessenger handles the nft messages of the wasm contracts and passes the others on to the next handler
+type nftMessenger struct {
+	Keeper
+	next wasm.Messenger
+}
+
+func (m nftMessenger) DispatchMsg(ctx sdk.Context, contractAddr sdk.WasmAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) ([]sdk.Event, [][]byte, error) {
+	if msg.Custom != nil {
+		var nftMsg types.NFTMsg
+		if err := json.Unmarshal(msg.Custom, &nftMsg); err == nil && !nftMsg.IsEmpty() {
+			ctx.SetEventManager(sdk.NewEventManager())
+			if err := m.handleNFTMsg(ctx, sdk.WasmToAccAddress(contractAddr), nftMsg); err != nil {
+				return nil, nil, err
+			}
+			return ctx.EventManager().Events(), nil, nil
+		}
+	}
+	return m.next.DispatchMsg(ctx, contractAddr, contractIBCPortID, msg)
+}
+
+func (m nftMessenger) handleNFTMsg(ctx sdk.Context, contract sdk.AccAddress, msg types.NFTMsg) error {
+	if !tmtypes.HigherThanJupiter(ctx.BlockHeight()) {
+		errMsg := fmt.Sprintf("nft not support at height %d", ctx.BlockHeight())
+		return sdkerrors.Wrap(types.ErrNFTNotSupport, errMsg)
+	}
+
+	switch {
+	case msg.IssueClass != nil:
+		if err := m.SaveClass(ctx, msg.IssueClass.Class); err != nil {
+			return err
+		}
+		m.SetClassMinter(ctx, msg.IssueClass.ID, contract)
+		return nil
+	case msg.Mint != nil:
+		if err := m.checkMinter(ctx, msg.Mint.ClassID, contract); err != nil {
+			return err
+		}
+		receiver, err := sdk.WasmAddressFromBech32(msg.Mint.Receiver)
+		if err != nil {
+			return sdkerrors.Wrap(types.ErrInvalidNFTMsg, err.Error())
+		}
+		return m.Mint(ctx, msg.Mint.NFT, sdk.WasmToAccAddress(receiver))
+	case msg.Update != nil:
+		if err := m.checkMinter(ctx, msg.Update.ClassID, contract); err != nil {
+			return err
+		}
+		return m.Update(ctx, msg.Update.NFT)
+	case msg.Burn != nil:
+		if err := m.checkOwner(ctx, msg.Burn.ClassID, msg.Burn.ID, contract); err != nil {
+			return err
+		}
+		return m.Burn(ctx, msg.Burn.ClassID, msg.Burn.ID)
+	default:
+		if err := m.checkOwner(ctx, msg.Send.ClassID, msg.Send.ID, contract); err != nil {
+			return err
+		}
+		receiver, err := sdk.WasmAddressFromBech32(msg.Send.Receiver)
+		if err != nil {
+			return sdkerrors.Wrap(types.ErrInvalidNFTMsg, err.Error())
+		}
+		return m.Transfer(ctx, msg.Send.ClassID, msg.Send.ID, sdk.WasmToAccAddress(receiver))
+	}
+}
+
+func (k Keeper) checkMinter(ctx sdk.Context, classID string, contract sdk.AccAddress) error {
+	if minter := k.GetClassMinter(ctx, classID); !minter.Equals(contract) {
+		return sdkerrors.Wrapf(types.ErrUnauthorized, "contract %s is not the minter of class %s", contract, classID)
+	}
+	return nil
+}
+
+func (k Keeper) checkOwner(ctx sdk.Context, classID, id string, contract sdk.AccAddress) error {
+	if owner := k.GetOwner(ctx, classID, id); !owner.Equals(contract) {
+		return sdkerrors.Wrapf(types.ErrUnauthorized, "contract %s is not the owner of nft %s of class %s", contract, id, classID)
+	}
+	return nil
+}
+
+// RegisterCustomQuerier needs to be registered in app setup to let wasm contracts read the native nft store.
+// The queries that are not nft queries are passed on to the next custom querier.
+func RegisterCustomQuerier(k Keeper, next wasm.CustomQuerier) *wasm.QueryPlugins {
+	return &wasm.QueryPlugins{
+		Custom: customQuerier(k, next),
+	}
+}
+
+func customQuerier(k Keeper, next wasm.CustomQuerier) wasm.CustomQuerier {
+	return func(ctx sdk.Context, request json.RawMessage) ([]byte, error) {
+		var query types.NFTQuery
+		if err := json.Unmarshal(request, &query); err != nil || query.IsEmpty() {
+			if next == nil {
+				return nil, wasmvmtypes.UnsupportedRequest{Kind: "custom"}
+			}
+			return next(ctx, request)
+		}
+
+		switch {
+		case query.Class != nil:
+			class, found := k.GetClass(ctx, query.Class.ClassID)
+			if !found {
+				return nil, sdkerrors.Wrapf(types.ErrClassNotFound, "class %s", query.Class.ClassID)
+			}
+			return json.Marshal(class)
+		case query.NFT != nil:
+			nft, found := k.GetNFT(ctx, query.NFT.ClassID, query.NFT.ID)
+			if !found {
+				return nil, sdkerrors.Wrapf(types.ErrNFTNotFound, "nft %s of class %s", query.NFT.ID, query.NFT.ClassID)
+			}
+			return json.Marshal(nft)
+		case query.Owner != nil:
+			owner := k.GetOwner(ctx, query.Owner.ClassID, query.Owner.ID)
+			if owner.Empty() {
+				return nil, sdkerrors.Wrapf(types.ErrNFTNotFound, "nft %s of class %s", query.Owner.ID, query.Owner.ClassID)
+			}
+			return json.Marshal(types.OwnerQueryResponse{Owner: sdk.AccToAWasmddress(owner).String()})
+		case query.Balance != nil:
+			owner, err := sdk.WasmAddressFromBech32(query.Balance.Owner)
+			if err != nil {
+				return nil, err
+			}
+			balance := k.GetBalance(ctx, query.Balance.ClassID, sdk.WasmToAccAddress(owner))
+			return json.Marshal(types.AmountQueryResponse{Amount: balance})
+		default:
+			return json.Marshal(types.AmountQueryResponse{Amount: k.GetTotalSupply(ctx, query.Supply.ClassID)})
+		}
+	}
+}
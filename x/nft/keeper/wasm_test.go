@@ -0,0 +1,161 @@
+package keeper_test
+
+import (
+	"encoding/json"
+	"fmt"
+
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/nft/keeper"
+	"github.com/okex/exchain/x/nft/types"
+)
+
+func (suite *KeeperTestSuite) TestNFTMessenger() {
+	contract := sdk.AccToAWasmddress(alice)
+	receiver := sdk.AccToAWasmddress(bob)
+	custom := func(format string, args ...interface{}) wasmvmtypes.CosmosMsg {
+		return wasmvmtypes.CosmosMsg{Custom: json.RawMessage(fmt.Sprintf(format, args...))}
+	}
+	issue := custom(`{"nft_issue_class":{"id":"%s","name":"Kitty"}}`, testClass)
+	mint := custom(`{"nft_mint":{"class_id":"%s","id":"%s","receiver":"%s"}}`, testClass, testNFT, contract)
+
+	testCases := []struct {
+		msg      string
+		malleate func() []wasmvmtypes.CosmosMsg
+		sender   sdk.WasmAddress
+		error    error
+		owner    sdk.AccAddress
+		next     bool
+	}{
+		{
+			"issue and mint",
+			func() []wasmvmtypes.CosmosMsg { return []wasmvmtypes.CosmosMsg{issue, mint} },
+			contract, nil, alice, false,
+		},
+		{
+			"mint by another contract",
+			func() []wasmvmtypes.CosmosMsg {
+				suite.Require().NoError(suite.keeper.SaveClass(suite.ctx, types.Class{ID: testClass}))
+				suite.keeper.SetClassMinter(suite.ctx, testClass, bob)
+				return []wasmvmtypes.CosmosMsg{mint}
+			},
+			contract, types.ErrUnauthorized, nil, false,
+		},
+		{
+			"send",
+			func() []wasmvmtypes.CosmosMsg {
+				return []wasmvmtypes.CosmosMsg{issue, mint,
+					custom(`{"nft_send":{"class_id":"%s","id":"%s","receiver":"%s"}}`, testClass, testNFT, receiver)}
+			},
+			contract, nil, bob, false,
+		},
+		{
+			"send nft of another owner",
+			func() []wasmvmtypes.CosmosMsg {
+				suite.Require().NoError(suite.keeper.SaveClass(suite.ctx, types.Class{ID: testClass}))
+				suite.mint(testClass, testNFT, bob)
+				return []wasmvmtypes.CosmosMsg{
+					custom(`{"nft_send":{"class_id":"%s","id":"%s","receiver":"%s"}}`, testClass, testNFT, contract)}
+			},
+			contract, types.ErrUnauthorized, bob, false,
+		},
+		{
+			"issue an ibc voucher class",
+			func() []wasmvmtypes.CosmosMsg {
+				return []wasmvmtypes.CosmosMsg{custom(`{"nft_issue_class":{"id":"%s%s","name":"Kitty"}}`, types.IBCClassPrefix, testClass)}
+			},
+			contract, types.ErrInvalidClass, nil, false,
+		},
+		{
+			"burn",
+			func() []wasmvmtypes.CosmosMsg {
+				return []wasmvmtypes.CosmosMsg{issue, mint,
+					custom(`{"nft_burn":{"class_id":"%s","id":"%s"}}`, testClass, testNFT)}
+			},
+			contract, nil, nil, false,
+		},
+		{
+			"other custom message",
+			func() []wasmvmtypes.CosmosMsg { return []wasmvmtypes.CosmosMsg{custom(`{"token_mint":{}}`)} },
+			contract, nil, nil, true,
+		},
+		{
+			"bank message",
+			func() []wasmvmtypes.CosmosMsg {
+				return []wasmvmtypes.CosmosMsg{{Bank: &wasmvmtypes.BankMsg{}}}
+			},
+			contract, nil, nil, true,
+		},
+	}
+	for _, tc := range testCases {
+		suite.Run(fmt.Sprintf("Case %s", tc.msg), func() {
+			suite.SetupTest()
+			next := &nextMessenger{}
+			messenger := keeper.RegisterNFTMessenger(suite.keeper)(next)
+
+			var err error
+			for _, msg := range tc.malleate() {
+				var events []sdk.Event
+				events, _, err = messenger.DispatchMsg(suite.ctx, tc.sender, "", msg)
+				if err != nil {
+					break
+				}
+				suite.Require().Equal(!tc.next, len(events) > 0)
+			}
+			if tc.error != nil {
+				suite.Require().ErrorIs(err, tc.error)
+			} else {
+				suite.Require().NoError(err)
+			}
+			suite.Require().Equal(tc.next, next.called)
+			suite.Require().Equal(tc.owner, suite.keeper.GetOwner(suite.ctx, testClass, testNFT))
+		})
+	}
+}
+
+func (suite *KeeperTestSuite) TestCustomQuerier() {
+	suite.saveClass(testClass)
+	suite.mint(testClass, testNFT, alice)
+
+	var nextCalled bool
+	next := func(sdk.Context, json.RawMessage) ([]byte, error) {
+		nextCalled = true
+		return nil, nil
+	}
+	querier := keeper.RegisterCustomQuerier(suite.keeper, next).Custom
+
+	ret, err := querier(suite.ctx, []byte(fmt.Sprintf(`{"nft_owner":{"class_id":"%s","id":"%s"}}`, testClass, testNFT)))
+	suite.Require().NoError(err)
+	var owner types.OwnerQueryResponse
+	suite.Require().NoError(json.Unmarshal(ret, &owner))
+	suite.Require().Equal(sdk.AccToAWasmddress(alice).String(), owner.Owner)
+
+	ret, err = querier(suite.ctx, []byte(fmt.Sprintf(`{"nft_balance":{"class_id":"%s","owner":"%s"}}`, testClass, sdk.AccToAWasmddress(alice))))
+	suite.Require().NoError(err)
+	var balance types.AmountQueryResponse
+	suite.Require().NoError(json.Unmarshal(ret, &balance))
+	suite.Require().Equal(uint64(1), balance.Amount)
+
+	ret, err = querier(suite.ctx, []byte(fmt.Sprintf(`{"nft":{"class_id":"%s","id":"%s"}}`, testClass, testNFT)))
+	suite.Require().NoError(err)
+	var nft types.NFT
+	suite.Require().NoError(json.Unmarshal(ret, &nft))
+	suite.Require().Equal("ipfs://"+testNFT, nft.URI)
+
+	_, err = querier(suite.ctx, []byte(fmt.Sprintf(`{"nft":{"class_id":"%s","id":"unknown"}}`, testClass)))
+	suite.Require().ErrorIs(err, types.ErrNFTNotFound)
+	suite.Require().False(nextCalled)
+
+	_, err = querier(suite.ctx, []byte(`{"token_supply":{"contract":"ex1"}}`))
+	suite.Require().NoError(err)
+	suite.Require().True(nextCalled)
+}
+
+type nextMessenger struct {
+	called bool
+}
+
+func (m *nextMessenger) DispatchMsg(sdk.Context, sdk.WasmAddress, string, wasmvmtypes.CosmosMsg) ([]sdk.Event, [][]byte, error) {
+	m.called = true
+	return nil, nil, nil
+}
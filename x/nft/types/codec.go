@@ -0,0 +1,19 @@
+package types
+
+import (
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+)
+
+// ModuleCdc defines the nft module's amino codec
+var ModuleCdc = codec.New()
+
+func init() {
+	RegisterCodec(ModuleCdc)
+	codec.RegisterCrypto(ModuleCdc)
+	ModuleCdc.Seal()
+}
+
+// RegisterCodec registers the amino types of the nft module
+func RegisterCodec(cdc *codec.Codec) {
+	cdc.RegisterConcrete(MsgSend{}, "okexchain/nft/MsgSend", nil)
+}
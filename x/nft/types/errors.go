@@ -0,0 +1,19 @@
+package types
+
+import (
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+)
+
+// nft sentinel errors
+var (
+	ErrInvalidClass   = sdkerrors.Register(ModuleName, 2, "invalid class")
+	ErrClassExists    = sdkerrors.Register(ModuleName, 3, "class already exists")
+	ErrClassNotFound  = sdkerrors.Register(ModuleName, 4, "class not found")
+	ErrInvalidNFT     = sdkerrors.Register(ModuleName, 5, "invalid nft")
+	ErrNFTExists      = sdkerrors.Register(ModuleName, 6, "nft already exists")
+	ErrNFTNotFound    = sdkerrors.Register(ModuleName, 7, "nft not found")
+	ErrUnauthorized   = sdkerrors.Register(ModuleName, 8, "unauthorized nft operation")
+	ErrInvalidNFTMsg  = sdkerrors.Register(ModuleName, 9, "invalid nft message")
+	ErrNFTNotSupport  = sdkerrors.Register(ModuleName, 10, "nft is not supported")
+	ErrInvalidNFTData = sdkerrors.Register(ModuleName, 11, "invalid nft query")
+)
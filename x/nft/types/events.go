@@ -0,0 +1,17 @@
+package types
+
+// nft module event types
+const (
+	EventTypeSaveClass   = "save_nft_class"
+	EventTypeUpdateClass = "update_nft_class"
+	EventTypeMint        = "mint_nft"
+	EventTypeBurn        = "burn_nft"
+	EventTypeUpdate      = "update_nft"
+	EventTypeSend        = "send_nft"
+
+	AttributeKeyClassID  = "class_id"
+	AttributeKeyID       = "id"
+	AttributeKeyOwner    = "owner"
+	AttributeKeySender   = "sender"
+	AttributeKeyReceiver = "receiver"
+)
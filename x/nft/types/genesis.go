@@ -0,0 +1,93 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
+// GenesisState defines the module's genesis state.
+type GenesisState struct {
+	// classes of the nfts
+	Classes []Class `json:"classes"`
+	// nfts grouped by their owners
+	Entries []Entry `json:"entries"`
+	// minters of the classes issued through the wasm bindings
+	Minters []ClassMinter `json:"minters"`
+}
+
+// Entry is the nfts of an owner
+type Entry struct {
+	Owner sdk.AccAddress `json:"owner"`
+	NFTs  []NFT          `json:"nfts"`
+}
+
+// ClassMinter is the account allowed to mint the nfts of a class through the wasm bindings
+type ClassMinter struct {
+	ClassID string         `json:"class_id"`
+	Minter  sdk.AccAddress `json:"minter"`
+}
+
+// NewGenesisState creates a new genesis state.
+func NewGenesisState(classes []Class, entries []Entry, minters []ClassMinter) GenesisState {
+	return GenesisState{
+		Classes: classes,
+		Entries: entries,
+		Minters: minters,
+	}
+}
+
+// DefaultGenesisState sets default nft genesis state without classes.
+func DefaultGenesisState() GenesisState {
+	return GenesisState{}
+}
+
+// Validate performs basic genesis state validation returning an error upon any
+// failure.
+func (gs GenesisState) Validate() error {
+	seenClass := make(map[string]bool)
+	for _, class := range gs.Classes {
+		if err := class.Validate(); err != nil {
+			return err
+		}
+		if seenClass[class.ID] {
+			return fmt.Errorf("class duplicated on genesis '%s'", class.ID)
+		}
+		seenClass[class.ID] = true
+	}
+
+	seenNFT := make(map[string]bool)
+	for _, entry := range gs.Entries {
+		if entry.Owner.Empty() {
+			return fmt.Errorf("owner of nfts cannot be empty on genesis")
+		}
+		for _, nft := range entry.NFTs {
+			if err := nft.Validate(); err != nil {
+				return err
+			}
+			if !seenClass[nft.ClassID] {
+				return fmt.Errorf("nft of unknown class on genesis '%s'", nft.ClassID)
+			}
+			key := nft.ClassID + "/" + nft.ID
+			if seenNFT[key] {
+				return fmt.Errorf("nft duplicated on genesis '%s' of '%s'", nft.ID, nft.ClassID)
+			}
+			seenNFT[key] = true
+		}
+	}
+
+	seenMinter := make(map[string]bool)
+	for _, minter := range gs.Minters {
+		if !seenClass[minter.ClassID] {
+			return fmt.Errorf("minter of unknown class on genesis '%s'", minter.ClassID)
+		}
+		if minter.Minter.Empty() {
+			return fmt.Errorf("minter of class cannot be empty on genesis '%s'", minter.ClassID)
+		}
+		if seenMinter[minter.ClassID] {
+			return fmt.Errorf("minter duplicated on genesis '%s'", minter.ClassID)
+		}
+		seenMinter[minter.ClassID] = true
+	}
+	return nil
+}
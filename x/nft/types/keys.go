@@ -0,0 +1,111 @@
+package types
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
+const (
+	// ModuleName is the name of the nft module
+	ModuleName = "nft"
+
+	// StoreKey is the string store representation
+	StoreKey = ModuleName
+
+	// QuerierRoute is the querier route for the nft module
+	QuerierRoute = ModuleName
+
+	// RouterKey is the msg router key for the nft module
+	RouterKey = ModuleName
+
+	QueryClass   = "class"
+	QueryClasses = "classes"
+	QueryNFT     = "nft"
+	QueryNFTs    = "nfts"
+	QueryOwner   = "owner"
+	QueryBalance = "balance"
+	QuerySupply  = "supply"
+)
+
+// prefix bytes for the nft persistent store
+const (
+	prefixClass = iota + 1
+	prefixNFT
+	prefixOwner
+	prefixNFTOfOwner
+	prefixClassTotalSupply
+	prefixClassMinter
+)
+
+// KVStore key prefixes
+var (
+	KeyPrefixClass            = []byte{prefixClass}
+	KeyPrefixNFT              = []byte{prefixNFT}
+	KeyPrefixOwner            = []byte{prefixOwner}
+	KeyPrefixNFTOfOwner       = []byte{prefixNFTOfOwner}
+	KeyPrefixClassTotalSupply = []byte{prefixClassTotalSupply}
+	KeyPrefixClassMinter      = []byte{prefixClassMinter}
+)
+
+// GetClassKey returns the key of the class
+func GetClassKey(classID string) []byte {
+	return append(KeyPrefixClass, []byte(classID)...)
+}
+
+// GetNFTClassPrefix returns the prefix of all the nfts of the class
+func GetNFTClassPrefix(classID string) []byte {
+	return append(KeyPrefixNFT, lengthPrefix([]byte(classID))...)
+}
+
+// GetNFTKey returns the key of the nft
+func GetNFTKey(classID, id string) []byte {
+	return append(GetNFTClassPrefix(classID), []byte(id)...)
+}
+
+// GetOwnerKey returns the key of the owner of the nft
+func GetOwnerKey(classID, id string) []byte {
+	key := append(KeyPrefixOwner, lengthPrefix([]byte(classID))...)
+	return append(key, []byte(id)...)
+}
+
+// GetNFTOfOwnerPrefix returns the prefix of all the nfts of the owner
+func GetNFTOfOwnerPrefix(owner sdk.AccAddress) []byte {
+	return append(KeyPrefixNFTOfOwner, lengthPrefix(owner)...)
+}
+
+// GetNFTOfClassByOwnerPrefix returns the prefix of the nfts of the class of the owner
+func GetNFTOfClassByOwnerPrefix(owner sdk.AccAddress, classID string) []byte {
+	return append(GetNFTOfOwnerPrefix(owner), lengthPrefix([]byte(classID))...)
+}
+
+// GetNFTOfOwnerKey returns the key indexing the nft by its owner
+func GetNFTOfOwnerKey(owner sdk.AccAddress, classID, id string) []byte {
+	return append(GetNFTOfClassByOwnerPrefix(owner, classID), []byte(id)...)
+}
+
+// SplitNFTOfOwnerKey returns the class and the id of the nft indexed by its owner
+func SplitNFTOfOwnerKey(key []byte) (owner sdk.AccAddress, classID, id string) {
+	key = key[len(KeyPrefixNFTOfOwner):]
+	owner, key = splitLengthPrefix(key)
+	class, key := splitLengthPrefix(key)
+	return owner, string(class), string(key)
+}
+
+// GetClassTotalSupplyKey returns the key of the number of the nfts of the class
+func GetClassTotalSupplyKey(classID string) []byte {
+	return append(KeyPrefixClassTotalSupply, []byte(classID)...)
+}
+
+// GetClassMinterKey returns the key of the account allowed to mint the nfts of the class through the bindings
+func GetClassMinterKey(classID string) []byte {
+	return append(KeyPrefixClassMinter, []byte(classID)...)
+}
+
+// lengthPrefix prefixes the bytes with their length, the class ids and addresses are shorter than 256 bytes
+func lengthPrefix(bz []byte) []byte {
+	return append([]byte{byte(len(bz))}, bz...)
+}
+
+func splitLengthPrefix(bz []byte) (prefix, rest []byte) {
+	l := int(bz[0])
+	return bz[1 : 1+l], bz[1+l:]
+}
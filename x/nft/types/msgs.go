@@ -0,0 +1,49 @@
+package types
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+)
+
+// MsgSend sends an nft to another account
+type MsgSend struct {
+	ClassID  string         `json:"class_id"`
+	ID       string         `json:"id"`
+	Sender   sdk.AccAddress `json:"sender"`
+	Receiver sdk.AccAddress `json:"receiver"`
+}
+
+func NewMsgSend(classID, id string, sender, receiver sdk.AccAddress) MsgSend {
+	return MsgSend{
+		ClassID:  classID,
+		ID:       id,
+		Sender:   sender,
+		Receiver: receiver,
+	}
+}
+
+func (msg MsgSend) Route() string { return RouterKey }
+
+func (msg MsgSend) Type() string { return "send" }
+
+func (msg MsgSend) ValidateBasic() error {
+	if msg.Sender.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "sender cannot be empty")
+	}
+	if msg.Receiver.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "receiver cannot be empty")
+	}
+	if err := ValidateClassID(msg.ClassID); err != nil {
+		return err
+	}
+	return ValidateNFTID(msg.ID)
+}
+
+func (msg MsgSend) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg MsgSend) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
@@ -0,0 +1,114 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+)
+
+// IBCClassPrefix is the prefix of the class ids of the ibc vouchers, which are only issued by the nft transfer module
+const IBCClassPrefix = "ibc/"
+
+var (
+	// the class ids of the ibc vouchers are "ibc/{hash}"
+	reClassID = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9/:._-]{2,127}$`)
+	// the ids of the nfts of other chains are often decimal numbers
+	reNFTID = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9/:._-]{0,127}$`)
+)
+
+// Class is a collection of nfts
+type Class struct {
+	ID          string `json:"id" yaml:"id"`
+	Name        string `json:"name" yaml:"name"`
+	Symbol      string `json:"symbol" yaml:"symbol"`
+	Description string `json:"description" yaml:"description"`
+	URI         string `json:"uri" yaml:"uri"`
+	URIHash     string `json:"uri_hash" yaml:"uri_hash"`
+	Data        string `json:"data" yaml:"data"`
+}
+
+// Validate checks the class
+func (c Class) Validate() error {
+	return validateClassID(c.ID)
+}
+
+func (c Class) String() string {
+	return fmt.Sprintf(`Class:
+  ID:          %s
+  Name:        %s
+  Symbol:      %s
+  Description: %s
+  URI:         %s
+  URI Hash:    %s
+  Data:        %s`, c.ID, c.Name, c.Symbol, c.Description, c.URI, c.URIHash, c.Data)
+}
+
+// NFT is a non-fungible token of a class
+type NFT struct {
+	ClassID string `json:"class_id" yaml:"class_id"`
+	ID      string `json:"id" yaml:"id"`
+	URI     string `json:"uri" yaml:"uri"`
+	URIHash string `json:"uri_hash" yaml:"uri_hash"`
+	Data    string `json:"data" yaml:"data"`
+}
+
+// Validate checks the nft
+func (n NFT) Validate() error {
+	if err := validateClassID(n.ClassID); err != nil {
+		return err
+	}
+	return ValidateNFTID(n.ID)
+}
+
+func (n NFT) String() string {
+	return fmt.Sprintf(`NFT:
+  Class ID: %s
+  ID:       %s
+  URI:      %s
+  URI Hash: %s
+  Data:     %s`, n.ClassID, n.ID, n.URI, n.URIHash, n.Data)
+}
+
+// IsVoucherClassID returns true if the class id is the id of an ibc voucher class
+func IsVoucherClassID(id string) bool {
+	return strings.HasPrefix(id, IBCClassPrefix)
+}
+
+// ValidateClassID checks the id of a class issued on this chain, the ids of the ibc vouchers are reserved
+func ValidateClassID(id string) error {
+	if err := validateClassID(id); err != nil {
+		return err
+	}
+	if IsVoucherClassID(id) {
+		return sdkerrors.Wrapf(ErrInvalidClass, "class id %s is reserved for the ibc vouchers", id)
+	}
+	return nil
+}
+
+// ValidateVoucherClassID checks the id of an ibc voucher class
+func ValidateVoucherClassID(id string) error {
+	if err := validateClassID(id); err != nil {
+		return err
+	}
+	if !IsVoucherClassID(id) {
+		return sdkerrors.Wrapf(ErrInvalidClass, "class id %s is not an ibc voucher class id", id)
+	}
+	return nil
+}
+
+func validateClassID(id string) error {
+	if !reClassID.MatchString(id) {
+		return sdkerrors.Wrapf(ErrInvalidClass, "invalid class id %s", id)
+	}
+	return nil
+}
+
+// ValidateNFTID checks the nft id
+func ValidateNFTID(id string) error {
+	if !reNFTID.MatchString(id) {
+		return sdkerrors.Wrapf(ErrInvalidNFT, "invalid nft id %s", id)
+	}
+	return nil
+}
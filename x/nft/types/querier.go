@@ -0,0 +1,48 @@
+package types
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
+// QueryNFTsParams queries the nfts of a class, of an owner, or of a class of an owner
+type QueryNFTsParams struct {
+	ClassID string         `json:"class_id"`
+	Owner   sdk.AccAddress `json:"owner"`
+}
+
+// NewQueryNFTsParams creates a new instance of QueryNFTsParams
+func NewQueryNFTsParams(classID string, owner sdk.AccAddress) QueryNFTsParams {
+	return QueryNFTsParams{ClassID: classID, Owner: owner}
+}
+
+// QueryBalanceParams queries the number of the nfts of a class of an owner
+type QueryBalanceParams struct {
+	ClassID string         `json:"class_id"`
+	Owner   sdk.AccAddress `json:"owner"`
+}
+
+// NewQueryBalanceParams creates a new instance of QueryBalanceParams
+func NewQueryBalanceParams(classID string, owner sdk.AccAddress) QueryBalanceParams {
+	return QueryBalanceParams{ClassID: classID, Owner: owner}
+}
+
+// QueryClassParams queries a class or the number of its nfts
+type QueryClassParams struct {
+	ClassID string `json:"class_id"`
+}
+
+// NewQueryClassParams creates a new instance of QueryClassParams
+func NewQueryClassParams(classID string) QueryClassParams {
+	return QueryClassParams{ClassID: classID}
+}
+
+// QueryNFTParams queries an nft or its owner
+type QueryNFTParams struct {
+	ClassID string `json:"class_id"`
+	ID      string `json:"id"`
+}
+
+// NewQueryNFTParams creates a new instance of QueryNFTParams
+func NewQueryNFTParams(classID, id string) QueryNFTParams {
+	return QueryNFTParams{ClassID: classID, ID: id}
+}
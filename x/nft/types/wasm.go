@@ -0,0 +1,86 @@
+package types
+
+// NFTMsg is the custom message that a wasm contract sends to manage the nfts of the native store.
+// The contract that issues a class is its minter, only the owner of an nft can send or burn it.
+type NFTMsg struct {
+	IssueClass *IssueClassMsg `json:"nft_issue_class,omitempty"`
+	Mint       *MintMsg       `json:"nft_mint,omitempty"`
+	Update     *UpdateMsg     `json:"nft_update,omitempty"`
+	Burn       *BurnMsg       `json:"nft_burn,omitempty"`
+	Send       *SendMsg       `json:"nft_send,omitempty"`
+}
+
+// IsEmpty returns true when the message is not an nft message, so it is left to the other handlers
+func (msg NFTMsg) IsEmpty() bool {
+	return msg.IssueClass == nil && msg.Mint == nil && msg.Update == nil && msg.Burn == nil && msg.Send == nil
+}
+
+// IssueClassMsg saves a new class whose minter is the contract
+type IssueClassMsg struct {
+	Class
+}
+
+// MintMsg mints an nft of a class of the contract to the receiver
+type MintMsg struct {
+	NFT
+	Receiver string `json:"receiver"`
+}
+
+// UpdateMsg updates the uri and the data of an nft of a class of the contract
+type UpdateMsg struct {
+	NFT
+}
+
+// BurnMsg burns an nft owned by the contract
+type BurnMsg struct {
+	ClassID string `json:"class_id"`
+	ID      string `json:"id"`
+}
+
+// SendMsg sends an nft owned by the contract to the receiver
+type SendMsg struct {
+	ClassID  string `json:"class_id"`
+	ID       string `json:"id"`
+	Receiver string `json:"receiver"`
+}
+
+// NFTQuery is the custom query that a wasm contract sends to read the native nft store
+type NFTQuery struct {
+	Class   *ClassQuery   `json:"nft_class,omitempty"`
+	NFT     *NFTIDQuery   `json:"nft,omitempty"`
+	Owner   *NFTIDQuery   `json:"nft_owner,omitempty"`
+	Balance *BalanceQuery `json:"nft_balance,omitempty"`
+	Supply  *ClassQuery   `json:"nft_supply,omitempty"`
+}
+
+// IsEmpty returns true when the query is not an nft query, so it is left to the other queriers
+func (q NFTQuery) IsEmpty() bool {
+	return q.Class == nil && q.NFT == nil && q.Owner == nil && q.Balance == nil && q.Supply == nil
+}
+
+// ClassQuery queries a class
+type ClassQuery struct {
+	ClassID string `json:"class_id"`
+}
+
+// NFTIDQuery queries an nft
+type NFTIDQuery struct {
+	ClassID string `json:"class_id"`
+	ID      string `json:"id"`
+}
+
+// BalanceQuery queries the number of the nfts of a class of an owner
+type BalanceQuery struct {
+	ClassID string `json:"class_id"`
+	Owner   string `json:"owner"`
+}
+
+// OwnerQueryResponse is the response of the owner query
+type OwnerQueryResponse struct {
+	Owner string `json:"owner"`
+}
+
+// AmountQueryResponse is the response of the balance and supply queries
+type AmountQueryResponse struct {
+	Amount uint64 `json:"amount,string"`
+}
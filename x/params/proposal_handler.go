@@ -8,6 +8,7 @@ import (
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
 	sdkparams "github.com/okex/exchain/libs/cosmos-sdk/x/params"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 	"github.com/okex/exchain/x/common"
 	govtypes "github.com/okex/exchain/x/gov/types"
 	"github.com/okex/exchain/x/params/types"
@@ -46,12 +47,43 @@ func changeParams(ctx sdk.Context, k *Keeper, paramProposal types.ParameterChang
 		if !ok {
 			return sdkerrors.Wrap(sdkparams.ErrUnknownSubspace, c.Subspace)
 		}
+		if !isParamEnabled(ctx, c) {
+			return sdkerrors.Wrap(sdkparams.ErrSettingParameter, fmt.Sprintf("parameter %s not registered", c.Key))
+		}
 
 		err := ss.Update(ctx, []byte(c.Key), []byte(c.Value))
 		if err != nil {
 			return sdkerrors.Wrap(sdkparams.ErrSettingParameter, err.Error())
 		}
 	}
+	return checkGovParams(ctx, k, paramProposal)
+}
+
+// checkGovParams checks the expedited gov params are still stricter than the regular ones after the changes
+func checkGovParams(ctx sdk.Context, k *Keeper, paramProposal types.ParameterChangeProposal) sdk.Error {
+	changed := false
+	for _, c := range paramProposal.Changes {
+		changed = changed || c.Subspace == govtypes.DefaultParamspace
+	}
+	if !changed {
+		return nil
+	}
+
+	ss, ok := k.GetSubspace(govtypes.DefaultParamspace)
+	if !ok {
+		return sdkerrors.Wrap(sdkparams.ErrUnknownSubspace, govtypes.DefaultParamspace)
+	}
+	var depositParams govtypes.DepositParams
+	var votingParams govtypes.VotingParams
+	var tallyParams govtypes.TallyParams
+	expeditedParams := govtypes.DefaultExpeditedParams()
+	ss.Get(ctx, govtypes.ParamStoreKeyDepositParams, &depositParams)
+	ss.Get(ctx, govtypes.ParamStoreKeyVotingParams, &votingParams)
+	ss.Get(ctx, govtypes.ParamStoreKeyTallyParams, &tallyParams)
+	ss.GetIfExists(ctx, govtypes.ParamStoreKeyExpeditedParams, &expeditedParams)
+	if err := expeditedParams.ValidateAgainst(depositParams, votingParams, tallyParams); err != nil {
+		return sdkerrors.Wrap(sdkparams.ErrSettingParameter, err.Error())
+	}
 	return nil
 }
 
@@ -64,6 +96,17 @@ func (k *Keeper) signalUpdate() {
 	}
 }
 
+// isParamEnabled returns false for the params which don't exist before their milestone
+func isParamEnabled(ctx sdk.Context, c types.ParamChange) bool {
+	if c.Subspace == "farm" && (c.Key == "LockTiers" || c.Key == "EarlyUnlockPenaltyRate") {
+		return tmtypes.HigherThanJupiter(ctx.BlockHeight())
+	}
+	if c.Subspace == govtypes.DefaultParamspace && c.Key == string(govtypes.ParamStoreKeyExpeditedParams) {
+		return tmtypes.HigherThanJupiter(ctx.BlockHeight())
+	}
+	return true
+}
+
 func checkDenom(paramProposal types.ParameterChangeProposal) sdk.Error {
 	for _, c := range paramProposal.Changes {
 		if c.Subspace == "evm" && c.Key == "EVMDenom" {
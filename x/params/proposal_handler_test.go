@@ -3,6 +3,7 @@ package params
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/okex/exchain/libs/cosmos-sdk/codec"
 	"github.com/okex/exchain/libs/cosmos-sdk/store"
@@ -14,6 +15,7 @@ import (
 	abci "github.com/okex/exchain/libs/tendermint/abci/types"
 	"github.com/okex/exchain/libs/tendermint/crypto/secp256k1"
 	"github.com/okex/exchain/libs/tendermint/libs/log"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 	tmdb "github.com/okex/exchain/libs/tm-db"
 	govtypes "github.com/okex/exchain/x/gov/types"
 	"github.com/okex/exchain/x/params/types"
@@ -289,3 +291,75 @@ func (suite *ProposalHandlerSuite) TestAfterSubmitProposalHandler() {
 	suite.NoError(err)
 	suite.Equal(expectInfo, info)
 }
+
+func (suite *ProposalHandlerSuite) TestChangeGovParams() {
+	ctx := suite.Context(10)
+	ss := suite.paramsKeeper.Subspace(govtypes.DefaultParamspace).WithKeyTable(govtypes.ParamKeyTable())
+	minDeposit := sdk.NewDecCoins(sdk.NewDecCoin(sdk.DefaultBondDenom, sdk.NewInt(100)))
+	votingPeriod := 48 * time.Hour
+	threshold := sdk.NewDecWithPrec(5, 1)
+	ss.Set(ctx, govtypes.ParamStoreKeyDepositParams, govtypes.NewDepositParams(minDeposit, votingPeriod))
+	ss.Set(ctx, govtypes.ParamStoreKeyVotingParams, govtypes.NewVotingParams(votingPeriod))
+	ss.Set(ctx, govtypes.ParamStoreKeyTallyParams, govtypes.NewTallyParams(sdk.NewDecWithPrec(334, 3), threshold, sdk.NewDecWithPrec(334, 3)))
+	proposal := func(ep govtypes.ExpeditedParams) types.ParameterChangeProposal {
+		change := types.NewParamChange(govtypes.DefaultParamspace, string(govtypes.ParamStoreKeyExpeditedParams),
+			string(suite.paramsKeeper.cdc.MustMarshalJSON(ep)))
+		return types.NewParameterChangeProposal("title", "desc", []types.ParamChange{change}, 10)
+	}
+
+	tests := []struct {
+		params      govtypes.ExpeditedParams
+		jupiter     int64
+		expectError bool
+	}{
+		{ // the expedited params don't exist before the jupiter height
+			params:      govtypes.NewExpeditedParams(minDeposit, votingPeriod/2, sdk.NewDecWithPrec(667, 3)),
+			jupiter:     11,
+			expectError: true,
+		},
+		{ // the expedited voting period is not shorter
+			params:      govtypes.NewExpeditedParams(minDeposit, votingPeriod, sdk.NewDecWithPrec(667, 3)),
+			jupiter:     10,
+			expectError: true,
+		},
+		{ // the expedited min deposit is lower
+			params:      govtypes.NewExpeditedParams(minDeposit.QuoDec(sdk.NewDec(2)), votingPeriod/2, sdk.NewDecWithPrec(667, 3)),
+			jupiter:     10,
+			expectError: true,
+		},
+		{ // the expedited threshold is not higher
+			params:      govtypes.NewExpeditedParams(minDeposit, votingPeriod/2, threshold),
+			jupiter:     10,
+			expectError: true,
+		},
+		{ // disabling the expedited proposals
+			params:      govtypes.DefaultExpeditedParams(),
+			jupiter:     10,
+			expectError: false,
+		},
+		{
+			params:      govtypes.NewExpeditedParams(minDeposit, votingPeriod/2, sdk.NewDecWithPrec(667, 3)),
+			jupiter:     10,
+			expectError: false,
+		},
+	}
+
+	defer tmtypes.UnittestOnlySetMilestoneJupiterHeight(0)
+	for _, tt := range tests {
+		tmtypes.UnittestOnlySetMilestoneJupiterHeight(tt.jupiter)
+		cacheCtx, _ := ctx.CacheContext()
+		err := changeParams(cacheCtx, &suite.paramsKeeper, proposal(tt.params))
+		if tt.expectError {
+			suite.Error(err)
+		} else {
+			suite.NoError(err)
+		}
+	}
+
+	// the regular params can't be changed to be stricter than the expedited ones
+	tmtypes.UnittestOnlySetMilestoneJupiterHeight(10)
+	suite.NoError(changeParams(ctx, &suite.paramsKeeper, proposal(tests[len(tests)-1].params)))
+	change := types.NewParamChange(govtypes.DefaultParamspace, string(govtypes.ParamStoreKeyVotingParams),
+		string(suite.paramsKeeper.cdc.MustMarshalJSON(govtypes.NewVotingParams(votingPeriod/4))))
+	suite.Error(changeParams(ctx, &suite.paramsKeeper, types.NewParameterChangeProposal("title", "desc", []types.ParamChange{change}, 10)))
+}
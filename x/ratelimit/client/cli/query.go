@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/client/context"
+	"github.com/spf13/cobra"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/client"
+	"github.com/okex/exchain/libs/cosmos-sdk/client/flags"
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	"github.com/okex/exchain/libs/cosmos-sdk/version"
+	"github.com/okex/exchain/x/ratelimit/types"
+)
+
+// GetQueryCmd returns the cli query commands for this module
+func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        types.ModuleName,
+		Short:                      fmt.Sprintf("Querying commands for the %s module", types.ModuleName),
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	cmd.AddCommand(flags.GetCommands(
+		GetCmdQueryRateLimits(queryRoute, cdc),
+		GetCmdQueryRateLimit(queryRoute, cdc),
+	)...)
+
+	return cmd
+}
+
+// GetCmdQueryRateLimits implements a command to return the rate limits with their quota and current flow
+func GetCmdQueryRateLimits(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "rate-limits [channel-id]",
+		Short: "Query all the rate limits, or the rate limits of a channel",
+		Example: fmt.Sprintf(`$ %s query %s rate-limits
+$ %s query %s rate-limits channel-0`, version.ClientName, types.ModuleName, version.ClientName, types.ModuleName),
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			route := fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryRateLimits)
+			if len(args) == 1 {
+				route = fmt.Sprintf("%s/%s", route, args[0])
+			}
+			bz, _, err := cliCtx.QueryWithData(route, nil)
+			if err != nil {
+				return err
+			}
+
+			var rateLimits []types.RateLimit
+			cdc.MustUnmarshalJSON(bz, &rateLimits)
+			return cliCtx.PrintOutput(rateLimits)
+		},
+	}
+}
+
+// GetCmdQueryRateLimit implements a command to return the quota and the current flow of a denom over a channel
+func GetCmdQueryRateLimit(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "rate-limit [channel-id] [denom]",
+		Short: "Query the rate limit of a denom over a channel",
+		Example: fmt.Sprintf(`$ %s query %s rate-limit channel-0 okt`,
+			version.ClientName, types.ModuleName),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			route := fmt.Sprintf("custom/%s/%s/%s/%s", queryRoute, types.QueryRateLimit, args[0], args[1])
+			bz, _, err := cliCtx.QueryWithData(route, nil)
+			if err != nil {
+				return err
+			}
+
+			var rateLimit types.RateLimit
+			cdc.MustUnmarshalJSON(bz, &rateLimit)
+			return cliCtx.PrintOutput(rateLimit)
+		},
+	}
+}
@@ -0,0 +1,180 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/client/context"
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	interfacetypes "github.com/okex/exchain/libs/cosmos-sdk/codec/types"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/version"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/auth"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/auth/client/utils"
+	"github.com/okex/exchain/x/gov"
+	ratelimitutils "github.com/okex/exchain/x/ratelimit/client/utils"
+	"github.com/okex/exchain/x/ratelimit/types"
+	"github.com/spf13/cobra"
+)
+
+// GetCmdSetRateLimitProposal implements a command handler for submitting a set rate limit proposal transaction
+func GetCmdSetRateLimitProposal(cdcP *codec.CodecProxy, reg interfacetypes.InterfaceRegistry) *cobra.Command {
+	return &cobra.Command{
+		Use:   "set-rate-limit [proposal-file]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Submit a set rate limit proposal",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Submit a set rate limit proposal along with an initial deposit.
+The proposal limits the net flow of a denom over a transfer channel in each window, as a percentage
+of the supply of the denom at the start of the window. A quota of 0 percent blocks the direction.
+The flow of an existing rate limit starts a new window.
+The proposal details must be supplied via a JSON file.
+
+Example:
+$ %s tx gov submit-proposal set-rate-limit <path/to/proposal.json> --from=<key_or_address>
+
+Where proposal.json contains:
+
+{
+ "title": "set rate limit",
+ "description": "limit the flow of okt over channel-0 to 10 percent a day",
+ "path": {
+   "denom": "%s",
+   "channel_id": "channel-0"
+ },
+ "quota": {
+   "max_percent_send": "10",
+   "max_percent_recv": "10",
+   "duration_hours": "24"
+ },
+ "deposit": [
+   {
+     "denom": "%s",
+     "amount": "100"
+   }
+ ]
+}
+`, version.ClientName, sdk.DefaultBondDenom, sdk.DefaultBondDenom,
+			)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cdc := cdcP.GetCdc()
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			proposal, err := ratelimitutils.ParseSetRateLimitProposalJSON(cdc, args[0])
+			if err != nil {
+				return err
+			}
+
+			from := cliCtx.GetFromAddress()
+			content := types.NewSetRateLimitProposal(proposal.Title, proposal.Description, proposal.Path, proposal.Quota)
+			msg := gov.NewMsgSubmitProposal(content, proposal.Deposit, from)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdRemoveRateLimitProposal implements a command handler for submitting a remove rate limit proposal transaction
+func GetCmdRemoveRateLimitProposal(cdcP *codec.CodecProxy, reg interfacetypes.InterfaceRegistry) *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove-rate-limit [proposal-file]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Submit a remove rate limit proposal",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Submit a remove rate limit proposal along with an initial deposit.
+The proposal removes the rate limit of a denom over a transfer channel.
+The proposal details must be supplied via a JSON file.
+
+Example:
+$ %s tx gov submit-proposal remove-rate-limit <path/to/proposal.json> --from=<key_or_address>
+
+Where proposal.json contains:
+
+{
+ "title": "remove rate limit",
+ "description": "stop limiting the flow of okt over channel-0",
+ "path": {
+   "denom": "%s",
+   "channel_id": "channel-0"
+ },
+ "deposit": [
+   {
+     "denom": "%s",
+     "amount": "100"
+   }
+ ]
+}
+`, version.ClientName, sdk.DefaultBondDenom, sdk.DefaultBondDenom,
+			)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cdc := cdcP.GetCdc()
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			proposal, err := ratelimitutils.ParseRateLimitPathProposalJSON(cdc, args[0])
+			if err != nil {
+				return err
+			}
+
+			from := cliCtx.GetFromAddress()
+			content := types.NewRemoveRateLimitProposal(proposal.Title, proposal.Description, proposal.Path)
+			msg := gov.NewMsgSubmitProposal(content, proposal.Deposit, from)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdResetRateLimitProposal implements a command handler for submitting a reset rate limit proposal transaction
+func GetCmdResetRateLimitProposal(cdcP *codec.CodecProxy, reg interfacetypes.InterfaceRegistry) *cobra.Command {
+	return &cobra.Command{
+		Use:   "reset-rate-limit [proposal-file]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Submit a reset rate limit proposal",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Submit a reset rate limit proposal along with an initial deposit.
+The proposal clears the flow of the rate limit of a denom over a transfer channel, the rate limit
+starts a new window with the current supply of the denom.
+The proposal details must be supplied via a JSON file.
+
+Example:
+$ %s tx gov submit-proposal reset-rate-limit <path/to/proposal.json> --from=<key_or_address>
+
+Where proposal.json contains:
+
+{
+ "title": "reset rate limit",
+ "description": "clear the flow of okt over channel-0",
+ "path": {
+   "denom": "%s",
+   "channel_id": "channel-0"
+ },
+ "deposit": [
+   {
+     "denom": "%s",
+     "amount": "100"
+   }
+ ]
+}
+`, version.ClientName, sdk.DefaultBondDenom, sdk.DefaultBondDenom,
+			)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cdc := cdcP.GetCdc()
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			proposal, err := ratelimitutils.ParseRateLimitPathProposalJSON(cdc, args[0])
+			if err != nil {
+				return err
+			}
+
+			from := cliCtx.GetFromAddress()
+			content := types.NewResetRateLimitProposal(proposal.Title, proposal.Description, proposal.Path)
+			msg := gov.NewMsgSubmitProposal(content, proposal.Deposit, from)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
@@ -0,0 +1,16 @@
+package client
+
+import (
+	govcli "github.com/okex/exchain/x/gov/client"
+	"github.com/okex/exchain/x/ratelimit/client/cli"
+	"github.com/okex/exchain/x/ratelimit/client/rest"
+)
+
+var (
+	// SetRateLimitProposalHandler alias gov NewProposalHandler
+	SetRateLimitProposalHandler = govcli.NewProposalHandler(cli.GetCmdSetRateLimitProposal, rest.SetRateLimitProposalRESTHandler)
+	// RemoveRateLimitProposalHandler alias gov NewProposalHandler
+	RemoveRateLimitProposalHandler = govcli.NewProposalHandler(cli.GetCmdRemoveRateLimitProposal, rest.RemoveRateLimitProposalRESTHandler)
+	// ResetRateLimitProposalHandler alias gov NewProposalHandler
+	ResetRateLimitProposalHandler = govcli.NewProposalHandler(cli.GetCmdResetRateLimitProposal, rest.ResetRateLimitProposalRESTHandler)
+)
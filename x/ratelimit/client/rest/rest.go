@@ -0,0 +1,21 @@
+package rest
+
+import (
+	"github.com/okex/exchain/libs/cosmos-sdk/client/context"
+	govRest "github.com/okex/exchain/x/gov/client/rest"
+)
+
+// SetRateLimitProposalRESTHandler defines ratelimit proposal handler
+func SetRateLimitProposalRESTHandler(context.CLIContext) govRest.ProposalRESTHandler {
+	return govRest.ProposalRESTHandler{}
+}
+
+// RemoveRateLimitProposalRESTHandler defines ratelimit proposal handler
+func RemoveRateLimitProposalRESTHandler(context.CLIContext) govRest.ProposalRESTHandler {
+	return govRest.ProposalRESTHandler{}
+}
+
+// ResetRateLimitProposalRESTHandler defines ratelimit proposal handler
+func ResetRateLimitProposalRESTHandler(context.CLIContext) govRest.ProposalRESTHandler {
+	return govRest.ProposalRESTHandler{}
+}
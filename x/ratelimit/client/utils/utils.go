@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"io/ioutil"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/ratelimit/types"
+)
+
+// SetRateLimitProposalJSON defines a SetRateLimitProposalJSON with a deposit used to parse set rate limit
+// proposals from a JSON file.
+type SetRateLimitProposalJSON struct {
+	Title       string       `json:"title" yaml:"title"`
+	Description string       `json:"description" yaml:"description"`
+	Path        types.Path   `json:"path" yaml:"path"`
+	Quota       types.Quota  `json:"quota" yaml:"quota"`
+	Deposit     sdk.SysCoins `json:"deposit" yaml:"deposit"`
+}
+
+// RateLimitPathProposalJSON defines a RateLimitPathProposalJSON with a deposit used to parse remove rate limit
+// and reset rate limit proposals from a JSON file.
+type RateLimitPathProposalJSON struct {
+	Title       string       `json:"title" yaml:"title"`
+	Description string       `json:"description" yaml:"description"`
+	Path        types.Path   `json:"path" yaml:"path"`
+	Deposit     sdk.SysCoins `json:"deposit" yaml:"deposit"`
+}
+
+// ParseSetRateLimitProposalJSON parse json from proposal file to SetRateLimitProposalJSON struct
+func ParseSetRateLimitProposalJSON(cdc *codec.Codec, proposalFilePath string) (
+	proposal SetRateLimitProposalJSON, err error) {
+	contents, err := ioutil.ReadFile(proposalFilePath)
+	if err != nil {
+		return
+	}
+
+	err = cdc.UnmarshalJSON(contents, &proposal)
+	return
+}
+
+// ParseRateLimitPathProposalJSON parse json from proposal file to RateLimitPathProposalJSON struct
+func ParseRateLimitPathProposalJSON(cdc *codec.Codec, proposalFilePath string) (
+	proposal RateLimitPathProposalJSON, err error) {
+	contents, err := ioutil.ReadFile(proposalFilePath)
+	if err != nil {
+		return
+	}
+
+	err = cdc.UnmarshalJSON(contents, &proposal)
+	return
+}
@@ -0,0 +1,31 @@
+package ratelimit
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/ratelimit/keeper"
+	"github.com/okex/exchain/x/ratelimit/types"
+)
+
+// InitGenesis import module genesis
+func InitGenesis(ctx sdk.Context, k keeper.Keeper, data types.GenesisState) {
+	for _, rateLimit := range data.RateLimits {
+		k.SetRateLimit(ctx, rateLimit)
+	}
+	for _, pending := range data.PendingSendPackets {
+		k.SetPendingSendPacket(ctx, pending.ChannelID, pending.Sequence, pending.Packet)
+	}
+}
+
+// ExportGenesis export module state
+func ExportGenesis(ctx sdk.Context, k keeper.Keeper) types.GenesisState {
+	var pendingSendPackets []types.GenesisPendingSendPacket
+	k.IteratePendingSendPackets(ctx, func(channelID string, sequence uint64, pending types.PendingSendPacket) bool {
+		pendingSendPackets = append(pendingSendPackets, types.GenesisPendingSendPacket{
+			ChannelID: channelID,
+			Sequence:  sequence,
+			Packet:    pending,
+		})
+		return false
+	})
+	return types.NewGenesisState(k.GetRateLimits(ctx), pendingSendPackets)
+}
@@ -0,0 +1,256 @@
+package ratelimit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/store"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	capabilitytypes "github.com/okex/exchain/libs/cosmos-sdk/x/capability/types"
+	transfertypes "github.com/okex/exchain/libs/ibc-go/modules/apps/transfer/types"
+	clienttypes "github.com/okex/exchain/libs/ibc-go/modules/core/02-client/types"
+	channeltypes "github.com/okex/exchain/libs/ibc-go/modules/core/04-channel/types"
+	porttypes "github.com/okex/exchain/libs/ibc-go/modules/core/05-port/types"
+	"github.com/okex/exchain/libs/ibc-go/modules/core/exported"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	tmdb "github.com/okex/exchain/libs/tm-db"
+	"github.com/okex/exchain/x/ratelimit"
+	"github.com/okex/exchain/x/ratelimit/keeper"
+	"github.com/okex/exchain/x/ratelimit/types"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	testChannel = "channel-0"
+	testDenom   = "uatom"
+)
+
+var testVoucher = transfertypes.ParseDenomTrace("transfer/" + testChannel + "/" + testDenom).IBCDenom()
+
+type MiddlewareTestSuite struct {
+	suite.Suite
+
+	ctx        sdk.Context
+	supply     *mockSupplyKeeper
+	channel    *mockChannelKeeper
+	keeper     keeper.Keeper
+	middleware ratelimit.IBCMiddleware
+}
+
+func TestMiddlewareTestSuite(t *testing.T) {
+	suite.Run(t, new(MiddlewareTestSuite))
+}
+
+func (suite *MiddlewareTestSuite) SetupTest() {
+	key := sdk.NewKVStoreKey(types.StoreKey)
+	ms := store.NewCommitMultiStore(tmdb.NewMemDB())
+	ms.MountStoreWithDB(key, sdk.StoreTypeIAVL, nil)
+	suite.Require().NoError(ms.LoadLatestVersion())
+	suite.ctx = sdk.NewContext(ms, abci.Header{Height: 2, Time: time.Unix(1000, 0)}, false, log.NewNopLogger())
+	tmtypes.UnittestOnlySetMilestoneEarthHeight(1)
+	tmtypes.UnittestOnlySetMilestoneJupiterHeight(1)
+
+	// the channel value of each denom is 1000 of the smallest unit of the packets
+	suite.supply = &mockSupplyKeeper{supply: sdk.NewDecWithPrec(1000, sdk.Precision)}
+	suite.channel = &mockChannelKeeper{}
+	suite.keeper = keeper.NewKeeper(key, suite.supply, suite.channel)
+	suite.middleware = ratelimit.NewIBCMiddleware(mockTransferModule{}, nil, suite.keeper)
+}
+
+func (suite *MiddlewareTestSuite) setRateLimit(denom string, maxPercentSend, maxPercentRecv int64) {
+	path := types.Path{Denom: denom, ChannelID: testChannel}
+	quota := types.Quota{MaxPercentSend: sdk.NewInt(maxPercentSend), MaxPercentRecv: sdk.NewInt(maxPercentRecv), DurationHours: 24}
+	suite.Require().NoError(suite.keeper.AddRateLimit(suite.ctx, path, quota))
+}
+
+func (suite *MiddlewareTestSuite) flow(denom string) types.Flow {
+	rateLimit, found := suite.keeper.GetRateLimit(suite.ctx, denom, testChannel)
+	suite.Require().True(found)
+	return rateLimit.Flow
+}
+
+func sendPacket(sequence uint64, amount string) channeltypes.Packet {
+	data := transfertypes.NewFungibleTokenPacketData(testDenom, amount, "cosmos1sender", "cosmos1receiver")
+	return channeltypes.NewPacket(data.GetBytes(), sequence, transfertypes.PortID, testChannel, transfertypes.PortID, "channel-7",
+		clienttypes.NewHeight(0, 100), 0)
+}
+
+// recvPacket returns a packet of the counterparty chain, the tokens of this chain return with the prefixed denom
+func recvPacket(denom, amount string) channeltypes.Packet {
+	data := transfertypes.NewFungibleTokenPacketData(denom, amount, "cosmos1sender", "cosmos1receiver")
+	return channeltypes.NewPacket(data.GetBytes(), 1, transfertypes.PortID, "channel-7", transfertypes.PortID, testChannel,
+		clienttypes.NewHeight(0, 100), 0)
+}
+
+func (suite *MiddlewareTestSuite) TestAddRateLimit() {
+	path := types.Path{Denom: testDenom, ChannelID: "channel-9"}
+	quota := types.Quota{MaxPercentSend: sdk.NewInt(10), MaxPercentRecv: sdk.NewInt(10), DurationHours: 24}
+	suite.Require().ErrorIs(suite.keeper.AddRateLimit(suite.ctx, path, quota), types.ErrChannelNotFound)
+
+	suite.supply.supply = sdk.ZeroDec()
+	path.ChannelID = testChannel
+	suite.Require().ErrorIs(suite.keeper.AddRateLimit(suite.ctx, path, quota), types.ErrZeroChannelValue)
+
+	suite.Require().ErrorIs(suite.keeper.RemoveRateLimit(suite.ctx, path), types.ErrRateLimitNotFound)
+	suite.Require().ErrorIs(suite.keeper.ResetRateLimit(suite.ctx, path), types.ErrRateLimitNotFound)
+}
+
+func (suite *MiddlewareTestSuite) TestSendPacket() {
+	// packets of denoms without a rate limit always pass
+	suite.Require().NoError(suite.keeper.SendPacket(suite.ctx, nil, sendPacket(1, "1000")))
+
+	suite.setRateLimit(testDenom, 10, 10)
+	suite.Require().NoError(suite.keeper.SendPacket(suite.ctx, nil, sendPacket(2, "60")))
+	err := suite.keeper.SendPacket(suite.ctx, nil, sendPacket(3, "50"))
+	suite.Require().ErrorIs(err, types.ErrQuotaExceeded)
+	suite.Require().Len(suite.channel.sent, 2)
+	suite.Require().Equal(sdk.NewDecWithPrec(60, sdk.Precision), suite.flow(testDenom).Outflow)
+
+	// the inflow of the window lowers the net outflow
+	suite.Require().NotNil(suite.middleware.OnRecvPacket(suite.ctx, recvPacket("transfer/channel-7/"+testDenom, "50"), nil))
+	suite.Require().NoError(suite.keeper.SendPacket(suite.ctx, nil, sendPacket(3, "50")))
+	suite.Require().Len(suite.channel.sent, 3)
+}
+
+func (suite *MiddlewareTestSuite) TestRecvPacket() {
+	suite.setRateLimit(testVoucher, 10, 10)
+
+	ack := suite.middleware.OnRecvPacket(suite.ctx, recvPacket(testDenom, "100"), nil)
+	suite.Require().True(ack.Success())
+	suite.Require().Equal(sdk.NewDecWithPrec(100, sdk.Precision), suite.flow(testVoucher).Inflow)
+
+	ack = suite.middleware.OnRecvPacket(suite.ctx, recvPacket(testDenom, "1"), nil)
+	suite.Require().False(ack.Success())
+	suite.Require().Equal(sdk.NewDecWithPrec(100, sdk.Precision), suite.flow(testVoucher).Inflow)
+}
+
+func (suite *MiddlewareTestSuite) TestFailedSendPacket() {
+	suite.setRateLimit(testDenom, 10, 10)
+	for seq := uint64(1); seq <= 3; seq++ {
+		suite.Require().NoError(suite.keeper.SendPacket(suite.ctx, nil, sendPacket(seq, "30")))
+	}
+	suite.Require().Equal(sdk.NewDecWithPrec(90, sdk.Precision), suite.flow(testDenom).Outflow)
+
+	// the outflow of a successful packet is kept
+	success := channeltypes.NewResultAcknowledgement([]byte{1})
+	suite.Require().NoError(suite.middleware.OnAcknowledgementPacket(suite.ctx, sendPacket(1, "30"), success.Acknowledgement(), nil))
+	suite.Require().Equal(sdk.NewDecWithPrec(90, sdk.Precision), suite.flow(testDenom).Outflow)
+	_, found := suite.keeper.GetPendingSendPacket(suite.ctx, testChannel, 1)
+	suite.Require().False(found)
+
+	// the outflow of the refunded packets is reverted
+	failure := channeltypes.NewErrorAcknowledgement("failed")
+	suite.Require().NoError(suite.middleware.OnAcknowledgementPacket(suite.ctx, sendPacket(2, "30"), failure.Acknowledgement(), nil))
+	suite.Require().Equal(sdk.NewDecWithPrec(60, sdk.Precision), suite.flow(testDenom).Outflow)
+	suite.Require().NoError(suite.middleware.OnTimeoutPacket(suite.ctx, sendPacket(3, "30"), nil))
+	suite.Require().Equal(sdk.NewDecWithPrec(30, sdk.Precision), suite.flow(testDenom).Outflow)
+
+	// a packet sent in a previous window does not change the flow of the current window
+	suite.Require().NoError(suite.keeper.SendPacket(suite.ctx, nil, sendPacket(4, "30")))
+	suite.ctx.SetBlockTime(suite.ctx.BlockTime().Add(24 * time.Hour))
+	suite.keeper.ResetExpiredRateLimits(suite.ctx)
+	suite.Require().NoError(suite.middleware.OnTimeoutPacket(suite.ctx, sendPacket(4, "30"), nil))
+	suite.Require().True(suite.flow(testDenom).Outflow.IsZero())
+}
+
+func (suite *MiddlewareTestSuite) TestResetExpiredRateLimits() {
+	suite.setRateLimit(testDenom, 10, 10)
+	suite.Require().NoError(suite.keeper.SendPacket(suite.ctx, nil, sendPacket(1, "100")))
+
+	suite.supply.supply = sdk.NewDecWithPrec(2000, sdk.Precision)
+	suite.ctx.SetBlockTime(suite.ctx.BlockTime().Add(23 * time.Hour))
+	suite.keeper.ResetExpiredRateLimits(suite.ctx)
+	suite.Require().Equal(sdk.NewDecWithPrec(100, sdk.Precision), suite.flow(testDenom).Outflow)
+
+	suite.ctx.SetBlockTime(suite.ctx.BlockTime().Add(time.Hour))
+	suite.keeper.ResetExpiredRateLimits(suite.ctx)
+	flow := suite.flow(testDenom)
+	suite.Require().True(flow.Outflow.IsZero())
+	suite.Require().Equal(sdk.NewDecWithPrec(2000, sdk.Precision), flow.ChannelValue)
+	suite.Require().Equal(suite.ctx.BlockTime().Unix(), flow.WindowStart)
+
+	// the governance resets the flow at once
+	suite.Require().NoError(suite.keeper.SendPacket(suite.ctx, nil, sendPacket(2, "200")))
+	suite.Require().NoError(suite.keeper.ResetRateLimit(suite.ctx, types.Path{Denom: testDenom, ChannelID: testChannel}))
+	suite.Require().True(suite.flow(testDenom).Outflow.IsZero())
+}
+
+func (suite *MiddlewareTestSuite) TestGenesis() {
+	suite.setRateLimit(testDenom, 10, 10)
+	suite.setRateLimit(testVoucher, 20, 20)
+	suite.Require().NoError(suite.keeper.SendPacket(suite.ctx, nil, sendPacket(1, "30")))
+	suite.Require().NotNil(suite.middleware.OnRecvPacket(suite.ctx, recvPacket(testDenom, "50"), nil))
+
+	module := ratelimit.NewAppModule(suite.keeper)
+	exported := module.ExportGenesis(suite.ctx)
+	suite.Require().NoError(module.ValidateGenesis(exported))
+	var genesisState types.GenesisState
+	types.ModuleCdc.MustUnmarshalJSON(exported, &genesisState)
+	suite.Require().Len(genesisState.RateLimits, 2)
+	suite.Require().Equal([]types.GenesisPendingSendPacket{{
+		ChannelID: testChannel,
+		Sequence:  1,
+		Packet:    types.PendingSendPacket{Denom: testDenom, Amount: sdk.NewDecWithPrec(30, sdk.Precision), WindowStart: suite.ctx.BlockTime().Unix()},
+	}}, genesisState.PendingSendPackets)
+
+	// the flow of the window and the pending packets are kept
+	flow := suite.flow(testDenom)
+	suite.SetupTest()
+	module = ratelimit.NewAppModule(suite.keeper)
+	module.InitGenesis(suite.ctx, exported)
+	suite.Require().Equal(flow, suite.flow(testDenom))
+	suite.Require().Equal(exported, module.ExportGenesis(suite.ctx))
+	_, found := suite.keeper.GetPendingSendPacket(suite.ctx, testChannel, 1)
+	suite.Require().True(found)
+
+	tmtypes.UnittestOnlySetMilestoneJupiterHeight(3)
+	defer tmtypes.UnittestOnlySetMilestoneJupiterHeight(1)
+	suite.Require().Nil(module.ExportGenesis(suite.ctx))
+}
+
+type mockSupplyKeeper struct {
+	supply sdk.Dec
+}
+
+func (m *mockSupplyKeeper) GetSupplyByDenom(sdk.Context, string) sdk.Dec {
+	return m.supply
+}
+
+type mockChannelKeeper struct {
+	sent []exported.PacketI
+}
+
+func (m *mockChannelKeeper) GetChannel(_ sdk.Context, _, channelID string) (channeltypes.Channel, bool) {
+	return channeltypes.Channel{}, channelID == testChannel
+}
+
+func (m *mockChannelKeeper) GetNextSequenceSend(sdk.Context, string, string) (uint64, bool) {
+	return uint64(len(m.sent) + 1), true
+}
+
+func (m *mockChannelKeeper) SendPacket(_ sdk.Context, _ *capabilitytypes.Capability, packet exported.PacketI) error {
+	m.sent = append(m.sent, packet)
+	return nil
+}
+
+func (m *mockChannelKeeper) ChanCloseInit(sdk.Context, string, string, *capabilitytypes.Capability) error {
+	return nil
+}
+
+type mockTransferModule struct {
+	porttypes.IBCModule
+}
+
+func (mockTransferModule) OnRecvPacket(sdk.Context, channeltypes.Packet, sdk.AccAddress) exported.Acknowledgement {
+	return channeltypes.NewResultAcknowledgement([]byte{1})
+}
+
+func (mockTransferModule) OnAcknowledgementPacket(sdk.Context, channeltypes.Packet, []byte, sdk.AccAddress) error {
+	return nil
+}
+
+func (mockTransferModule) OnTimeoutPacket(sdk.Context, channeltypes.Packet, sdk.AccAddress) error {
+	return nil
+}
@@ -0,0 +1,56 @@
+package keeper
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	capabilitytypes "github.com/okex/exchain/libs/cosmos-sdk/x/capability/types"
+	transfertypes "github.com/okex/exchain/libs/ibc-go/modules/apps/transfer/types"
+	channeltypes "github.com/okex/exchain/libs/ibc-go/modules/core/04-channel/types"
+	"github.com/okex/exchain/libs/ibc-go/modules/core/exported"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	"github.com/okex/exchain/x/ratelimit/types"
+)
+
+// The keeper must implement the channel keeper expected by the transfer keeper,
+// so that the outflow of the sent packets is limited
+var _ transfertypes.ChannelKeeper = Keeper{}
+
+// GetChannel wraps IBC ChannelKeeper's GetChannel function
+func (k Keeper) GetChannel(ctx sdk.Context, srcPort, srcChan string) (channeltypes.Channel, bool) {
+	return k.channelKeeper.GetChannel(ctx, srcPort, srcChan)
+}
+
+// GetNextSequenceSend wraps IBC ChannelKeeper's GetNextSequenceSend function
+func (k Keeper) GetNextSequenceSend(ctx sdk.Context, portID, channelID string) (uint64, bool) {
+	return k.channelKeeper.GetNextSequenceSend(ctx, portID, channelID)
+}
+
+// ChanCloseInit wraps IBC ChannelKeeper's ChanCloseInit function
+func (k Keeper) ChanCloseInit(ctx sdk.Context, portID, channelID string, chanCap *capabilitytypes.Capability) error {
+	return k.channelKeeper.ChanCloseInit(ctx, portID, channelID, chanCap)
+}
+
+// SendPacket wraps IBC ChannelKeeper's SendPacket function, the ics20 packet fails if the outflow
+// of its denom over its channel exceeds the quota
+func (k Keeper) SendPacket(ctx sdk.Context, chanCap *capabilitytypes.Capability, packet exported.PacketI) error {
+	if !tmtypes.HigherThanJupiter(ctx.BlockHeight()) || packet.GetSourcePort() != transfertypes.PortID {
+		return k.channelKeeper.SendPacket(ctx, chanCap, packet)
+	}
+
+	info, err := types.ParseSendPacket(packet)
+	if err != nil {
+		return err
+	}
+	updated, err := k.CheckRateLimitAndUpdateFlow(ctx, types.PacketSend, info)
+	if err != nil {
+		return err
+	}
+	if updated {
+		rateLimit, _ := k.GetRateLimit(ctx, info.Denom, info.ChannelID)
+		k.SetPendingSendPacket(ctx, info.ChannelID, packet.GetSequence(), types.PendingSendPacket{
+			Denom:       info.Denom,
+			Amount:      info.Amount,
+			WindowStart: rateLimit.Flow.WindowStart,
+		})
+	}
+	return k.channelKeeper.SendPacket(ctx, chanCap, packet)
+}
@@ -0,0 +1,231 @@
+package keeper
+
+import (
+	"strconv"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	transfertypes "github.com/okex/exchain/libs/ibc-go/modules/apps/transfer/types"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	"github.com/okex/exchain/x/ratelimit/types"
+)
+
+// Keeper of the ratelimit store
+type Keeper struct {
+	storeKey      sdk.StoreKey
+	supplyKeeper  types.SupplyKeeper
+	channelKeeper types.ChannelKeeper
+}
+
+// NewKeeper creates a new ratelimit Keeper instance. The keeper wraps the channel keeper of the transfer
+// keeper to limit the outflow of the sent packets.
+func NewKeeper(storeKey sdk.StoreKey, supplyKeeper types.SupplyKeeper, channelKeeper types.ChannelKeeper) Keeper {
+	return Keeper{
+		storeKey:      storeKey,
+		supplyKeeper:  supplyKeeper,
+		channelKeeper: channelKeeper,
+	}
+}
+
+// Logger returns a module-specific logger.
+func (k Keeper) Logger(ctx sdk.Context) log.Logger {
+	return ctx.Logger().With("module", "x/"+types.ModuleName)
+}
+
+// SetRateLimit stores the rate limit
+func (k Keeper) SetRateLimit(ctx sdk.Context, rateLimit types.RateLimit) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetRateLimitKey(rateLimit.Path.Denom, rateLimit.Path.ChannelID), types.ModuleCdc.MustMarshalBinaryBare(rateLimit))
+}
+
+// GetRateLimit returns the rate limit of the denom on the channel
+func (k Keeper) GetRateLimit(ctx sdk.Context, denom, channelID string) (rateLimit types.RateLimit, found bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetRateLimitKey(denom, channelID))
+	if bz == nil {
+		return rateLimit, false
+	}
+	types.ModuleCdc.MustUnmarshalBinaryBare(bz, &rateLimit)
+	return rateLimit, true
+}
+
+// GetRateLimits returns all the rate limits
+func (k Keeper) GetRateLimits(ctx sdk.Context) []types.RateLimit {
+	return k.getRateLimitsWithPrefix(ctx, types.KeyPrefixRateLimit)
+}
+
+// GetRateLimitsOfChannel returns the rate limits of all the denoms on the channel
+func (k Keeper) GetRateLimitsOfChannel(ctx sdk.Context, channelID string) []types.RateLimit {
+	return k.getRateLimitsWithPrefix(ctx, types.GetRateLimitChannelPrefix(channelID))
+}
+
+func (k Keeper) getRateLimitsWithPrefix(ctx sdk.Context, prefix []byte) []types.RateLimit {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), prefix)
+	defer iterator.Close()
+
+	rateLimits := make([]types.RateLimit, 0)
+	for ; iterator.Valid(); iterator.Next() {
+		var rateLimit types.RateLimit
+		types.ModuleCdc.MustUnmarshalBinaryBare(iterator.Value(), &rateLimit)
+		rateLimits = append(rateLimits, rateLimit)
+	}
+	return rateLimits
+}
+
+// AddRateLimit sets the quota of the denom on a transfer channel, the flow starts a new window
+func (k Keeper) AddRateLimit(ctx sdk.Context, path types.Path, quota types.Quota) error {
+	if _, found := k.channelKeeper.GetChannel(ctx, transfertypes.PortID, path.ChannelID); !found {
+		return sdkerrors.Wrapf(types.ErrChannelNotFound, "port %s channel %s", transfertypes.PortID, path.ChannelID)
+	}
+	rateLimit := types.RateLimit{Path: path, Quota: quota}
+	if err := k.resetFlow(ctx, &rateLimit); err != nil {
+		return err
+	}
+	k.SetRateLimit(ctx, rateLimit)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeSetRateLimit,
+		sdk.NewAttribute(types.AttributeDenom, path.Denom),
+		sdk.NewAttribute(types.AttributeChannelID, path.ChannelID),
+	))
+	return nil
+}
+
+// RemoveRateLimit removes the rate limit of the denom on the channel
+func (k Keeper) RemoveRateLimit(ctx sdk.Context, path types.Path) error {
+	if _, found := k.GetRateLimit(ctx, path.Denom, path.ChannelID); !found {
+		return sdkerrors.Wrapf(types.ErrRateLimitNotFound, "denom %s channel %s", path.Denom, path.ChannelID)
+	}
+	ctx.KVStore(k.storeKey).Delete(types.GetRateLimitKey(path.Denom, path.ChannelID))
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeRemoveRateLimit,
+		sdk.NewAttribute(types.AttributeDenom, path.Denom),
+		sdk.NewAttribute(types.AttributeChannelID, path.ChannelID),
+	))
+	return nil
+}
+
+// ResetRateLimit clears the flow of the rate limit of the denom on the channel and starts a new window
+func (k Keeper) ResetRateLimit(ctx sdk.Context, path types.Path) error {
+	rateLimit, found := k.GetRateLimit(ctx, path.Denom, path.ChannelID)
+	if !found {
+		return sdkerrors.Wrapf(types.ErrRateLimitNotFound, "denom %s channel %s", path.Denom, path.ChannelID)
+	}
+	if err := k.resetFlow(ctx, &rateLimit); err != nil {
+		return err
+	}
+	k.SetRateLimit(ctx, rateLimit)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeResetRateLimit,
+		sdk.NewAttribute(types.AttributeDenom, path.Denom),
+		sdk.NewAttribute(types.AttributeChannelID, path.ChannelID),
+	))
+	return nil
+}
+
+// ResetExpiredRateLimits starts a new window for the rate limits whose window ended
+func (k Keeper) ResetExpiredRateLimits(ctx sdk.Context) {
+	for _, rateLimit := range k.GetRateLimits(ctx) {
+		if !rateLimit.WindowExpired(ctx.BlockTime()) {
+			continue
+		}
+		if err := k.resetFlow(ctx, &rateLimit); err != nil {
+			// keep the channel value of the previous window, the supply of the denom is gone
+			k.Logger(ctx).Error("failed to reset the rate limit", "denom", rateLimit.Path.Denom,
+				"channel", rateLimit.Path.ChannelID, "error", err)
+			rateLimit.Flow = types.NewFlow(rateLimit.Flow.ChannelValue, ctx.BlockTime())
+		}
+		k.SetRateLimit(ctx, rateLimit)
+	}
+}
+
+func (k Keeper) resetFlow(ctx sdk.Context, rateLimit *types.RateLimit) error {
+	channelValue := k.supplyKeeper.GetSupplyByDenom(ctx, rateLimit.Path.Denom)
+	if !channelValue.IsPositive() {
+		return sdkerrors.Wrapf(types.ErrZeroChannelValue, "denom %s", rateLimit.Path.Denom)
+	}
+	rateLimit.Flow = types.NewFlow(channelValue, ctx.BlockTime())
+	return nil
+}
+
+// CheckRateLimitAndUpdateFlow adds the amount of the packet to the flow of the rate limit of its denom and channel,
+// it fails if the quota is exceeded. Packets without a rate limit always pass.
+func (k Keeper) CheckRateLimitAndUpdateFlow(ctx sdk.Context, direction types.PacketDirection, info types.PacketInfo) (updated bool, err error) {
+	rateLimit, found := k.GetRateLimit(ctx, info.Denom, info.ChannelID)
+	if !found {
+		return false, nil
+	}
+	if err := rateLimit.AddFlow(direction, info.Amount); err != nil {
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeQuotaExceeded,
+			sdk.NewAttribute(types.AttributeDenom, info.Denom),
+			sdk.NewAttribute(types.AttributeChannelID, info.ChannelID),
+			sdk.NewAttribute(types.AttributeDirection, string(direction)),
+			sdk.NewAttribute(types.AttributeAmount, info.Amount.String()),
+			sdk.NewAttribute(types.AttributeThreshold, rateLimit.Threshold(direction).String()),
+		))
+		return false, err
+	}
+	k.SetRateLimit(ctx, rateLimit)
+	return true, nil
+}
+
+// SetPendingSendPacket records the outflow of the sent packet until it is acknowledged or timed out
+func (k Keeper) SetPendingSendPacket(ctx sdk.Context, channelID string, sequence uint64, pending types.PendingSendPacket) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetPendingSendPacketKey(channelID, sequence), types.ModuleCdc.MustMarshalBinaryBare(pending))
+}
+
+// GetPendingSendPacket returns the outflow of the sent packet
+func (k Keeper) GetPendingSendPacket(ctx sdk.Context, channelID string, sequence uint64) (pending types.PendingSendPacket, found bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetPendingSendPacketKey(channelID, sequence))
+	if bz == nil {
+		return pending, false
+	}
+	types.ModuleCdc.MustUnmarshalBinaryBare(bz, &pending)
+	return pending, true
+}
+
+// IteratePendingSendPackets iterates over all the pending sent packets
+func (k Keeper) IteratePendingSendPackets(ctx sdk.Context, cb func(channelID string, sequence uint64, pending types.PendingSendPacket) (stop bool)) {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.KeyPrefixPendingSendPacket)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		channelID, sequence, err := types.ParsePendingSendPacketKey(iterator.Key())
+		if err != nil {
+			panic(err)
+		}
+		var pending types.PendingSendPacket
+		types.ModuleCdc.MustUnmarshalBinaryBare(iterator.Value(), &pending)
+		if cb(channelID, sequence, pending) {
+			break
+		}
+	}
+}
+
+// RemovePendingSendPacket removes the sent packet once it is acknowledged or timed out. The outflow of a failed
+// packet is reverted if its window has not ended, since the tokens are refunded to the sender.
+func (k Keeper) RemovePendingSendPacket(ctx sdk.Context, channelID string, sequence uint64, failed bool) {
+	pending, found := k.GetPendingSendPacket(ctx, channelID, sequence)
+	if !found {
+		return
+	}
+	ctx.KVStore(k.storeKey).Delete(types.GetPendingSendPacketKey(channelID, sequence))
+	if !failed {
+		return
+	}
+
+	rateLimit, found := k.GetRateLimit(ctx, pending.Denom, channelID)
+	if !found || rateLimit.Flow.WindowStart != pending.WindowStart {
+		return
+	}
+	rateLimit.Flow.Outflow = rateLimit.Flow.Outflow.Sub(pending.Amount)
+	if rateLimit.Flow.Outflow.IsNegative() {
+		rateLimit.Flow.Outflow = sdk.ZeroDec()
+	}
+	k.SetRateLimit(ctx, rateLimit)
+	k.Logger(ctx).Debug("reverted the outflow of the failed packet", "denom", pending.Denom,
+		"channel", channelID, "sequence", strconv.FormatUint(sequence, 10))
+}
@@ -0,0 +1,43 @@
+package keeper
+
+import (
+	"strings"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	"github.com/okex/exchain/x/ratelimit/types"
+)
+
+// NewQuerier is the module level router for state queries
+func NewQuerier(k Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, error) {
+		if len(path) < 1 {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest,
+				"Insufficient parameters, at least 1 parameter is required")
+		}
+
+		switch path[0] {
+		case types.QueryRateLimits:
+			// the rate limits of one channel are queried if the channel is given
+			if len(path) > 1 && path[1] != "" {
+				return codec.MarshalJSONIndent(types.ModuleCdc, k.GetRateLimitsOfChannel(ctx, path[1]))
+			}
+			return codec.MarshalJSONIndent(types.ModuleCdc, k.GetRateLimits(ctx))
+		case types.QueryRateLimit:
+			if len(path) < 3 {
+				return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "the channel and the denom of the rate limit are required")
+			}
+			// ibc denoms contain a slash
+			denom := strings.Join(path[2:], "/")
+			rateLimit, found := k.GetRateLimit(ctx, denom, path[1])
+			if !found {
+				return nil, sdkerrors.Wrapf(types.ErrRateLimitNotFound, "denom %s channel %s", denom, path[1])
+			}
+			return codec.MarshalJSONIndent(types.ModuleCdc, rateLimit)
+		default:
+			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "unknown query endpoint")
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package ratelimit
+
+import (
+	"fmt"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	"github.com/okex/exchain/x/common"
+	govTypes "github.com/okex/exchain/x/gov/types"
+	"github.com/okex/exchain/x/ratelimit/keeper"
+	"github.com/okex/exchain/x/ratelimit/types"
+)
+
+// NewProposalHandler handles "gov" type message in "ratelimit"
+func NewProposalHandler(k keeper.Keeper) govTypes.Handler {
+	return func(ctx sdk.Context, proposal *govTypes.Proposal) (err sdk.Error) {
+		if !tmtypes.HigherThanJupiter(ctx.BlockHeight()) {
+			return govTypes.ErrInvalidProposalContent(fmt.Sprintf("ratelimit not support at height %d", ctx.BlockHeight()))
+		}
+		switch content := proposal.Content.(type) {
+		case types.SetRateLimitProposal:
+			return k.AddRateLimit(ctx, content.Path, content.Quota)
+		case types.RemoveRateLimitProposal:
+			return k.RemoveRateLimit(ctx, content.Path)
+		case types.ResetRateLimitProposal:
+			return k.ResetRateLimit(ctx, content.Path)
+		default:
+			return common.ErrUnknownProposalType(types.ModuleName, content.ProposalType())
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package types
+
+import (
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+)
+
+// ModuleCdc defines the ratelimit module's amino codec
+var ModuleCdc = codec.New()
+
+const (
+	// Amino names
+	setRateLimitProposalName    = "okexchain/ratelimit/SetRateLimitProposal"
+	removeRateLimitProposalName = "okexchain/ratelimit/RemoveRateLimitProposal"
+	resetRateLimitProposalName  = "okexchain/ratelimit/ResetRateLimitProposal"
+)
+
+func init() {
+	RegisterCodec(ModuleCdc)
+	ModuleCdc.Seal()
+}
+
+// RegisterCodec registers the amino types of the ratelimit module
+func RegisterCodec(cdc *codec.Codec) {
+	cdc.RegisterConcrete(SetRateLimitProposal{}, setRateLimitProposalName, nil)
+	cdc.RegisterConcrete(RemoveRateLimitProposal{}, removeRateLimitProposalName, nil)
+	cdc.RegisterConcrete(ResetRateLimitProposal{}, resetRateLimitProposalName, nil)
+}
@@ -0,0 +1,16 @@
+package types
+
+import (
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+)
+
+// ratelimit sentinel errors
+var (
+	ErrInvalidRateLimit    = sdkerrors.Register(ModuleName, 2, "invalid rate limit")
+	ErrRateLimitNotFound   = sdkerrors.Register(ModuleName, 3, "rate limit not found")
+	ErrQuotaExceeded       = sdkerrors.Register(ModuleName, 4, "quota exceeded")
+	ErrZeroChannelValue    = sdkerrors.Register(ModuleName, 5, "channel value is zero")
+	ErrChannelNotFound     = sdkerrors.Register(ModuleName, 6, "channel not found")
+	ErrInvalidPacketData   = sdkerrors.Register(ModuleName, 7, "invalid packet data")
+	ErrRateLimitNotSupport = sdkerrors.Register(ModuleName, 8, "rate limit is not supported")
+)
@@ -0,0 +1,14 @@
+package types
+
+const (
+	EventTypeSetRateLimit    = "set_rate_limit"
+	EventTypeRemoveRateLimit = "remove_rate_limit"
+	EventTypeResetRateLimit  = "reset_rate_limit"
+	EventTypeQuotaExceeded   = "rate_limit_quota_exceeded"
+
+	AttributeDenom     = "denom"
+	AttributeChannelID = "channel_id"
+	AttributeDirection = "direction"
+	AttributeAmount    = "amount"
+	AttributeThreshold = "threshold"
+)
@@ -0,0 +1,87 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	host "github.com/okex/exchain/libs/ibc-go/modules/core/24-host"
+)
+
+// GenesisState defines the ratelimit module's genesis state.
+type GenesisState struct {
+	// rate limits with the flow of their current window
+	RateLimits []RateLimit `json:"rate_limits"`
+	// sent packets waiting for their acknowledgement or timeout
+	PendingSendPackets []GenesisPendingSendPacket `json:"pending_send_packets"`
+}
+
+// GenesisPendingSendPacket is a pending sent packet with the channel and the sequence of the packet
+type GenesisPendingSendPacket struct {
+	ChannelID string            `json:"channel_id"`
+	Sequence  uint64            `json:"sequence"`
+	Packet    PendingSendPacket `json:"packet"`
+}
+
+// NewGenesisState creates a new genesis state.
+func NewGenesisState(rateLimits []RateLimit, pendingSendPackets []GenesisPendingSendPacket) GenesisState {
+	return GenesisState{
+		RateLimits:         rateLimits,
+		PendingSendPackets: pendingSendPackets,
+	}
+}
+
+// Validate performs basic genesis state validation returning an error upon any
+// failure.
+func (gs GenesisState) Validate() error {
+	seenRateLimit := make(map[string]bool)
+	for _, rateLimit := range gs.RateLimits {
+		key := string(GetRateLimitKey(rateLimit.Path.Denom, rateLimit.Path.ChannelID))
+		if seenRateLimit[key] {
+			return fmt.Errorf("rate limit of denom %s channel %s duplicated on genesis", rateLimit.Path.Denom, rateLimit.Path.ChannelID)
+		}
+		if err := rateLimit.Path.Validate(); err != nil {
+			return err
+		}
+		if err := rateLimit.Quota.Validate(); err != nil {
+			return err
+		}
+		if err := rateLimit.Flow.Validate(); err != nil {
+			return err
+		}
+		seenRateLimit[key] = true
+	}
+
+	seenPacket := make(map[string]bool)
+	for _, pending := range gs.PendingSendPackets {
+		key := string(GetPendingSendPacketKey(pending.ChannelID, pending.Sequence))
+		if seenPacket[key] {
+			return fmt.Errorf("pending send packet of channel %s sequence %d duplicated on genesis", pending.ChannelID, pending.Sequence)
+		}
+		if err := host.ChannelIdentifierValidator(pending.ChannelID); err != nil {
+			return err
+		}
+		if strings.TrimSpace(pending.Packet.Denom) == "" {
+			return fmt.Errorf("denom of pending send packet of channel %s sequence %d cannot be blank", pending.ChannelID, pending.Sequence)
+		}
+		if pending.Packet.Amount.IsNil() || pending.Packet.Amount.IsNegative() {
+			return fmt.Errorf("amount of pending send packet of channel %s sequence %d must not be negative", pending.ChannelID, pending.Sequence)
+		}
+		seenPacket[key] = true
+	}
+	return nil
+}
+
+// Validate checks the flow of a window
+func (f Flow) Validate() error {
+	if f.Inflow.IsNil() || f.Inflow.IsNegative() {
+		return sdkerrors.Wrap(ErrInvalidRateLimit, "inflow must not be negative")
+	}
+	if f.Outflow.IsNil() || f.Outflow.IsNegative() {
+		return sdkerrors.Wrap(ErrInvalidRateLimit, "outflow must not be negative")
+	}
+	if f.ChannelValue.IsNil() || !f.ChannelValue.IsPositive() {
+		return sdkerrors.Wrap(ErrInvalidRateLimit, "channel value must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
+const (
+	// ModuleName is the name of the ratelimit module
+	ModuleName = "ratelimit"
+
+	// StoreKey is the string store representation
+	StoreKey = ModuleName
+
+	// QuerierRoute is the querier route for the ratelimit module
+	QuerierRoute = ModuleName
+
+	// RouterKey is the msg router key for the ratelimit module
+	RouterKey = ModuleName
+
+	QueryRateLimits = "rate-limits"
+	QueryRateLimit  = "rate-limit"
+)
+
+// prefix bytes for the ratelimit persistent store
+const (
+	prefixRateLimit = iota + 1
+	prefixPendingSendPacket
+)
+
+// KVStore key prefixes
+var (
+	KeyPrefixRateLimit         = []byte{prefixRateLimit}
+	KeyPrefixPendingSendPacket = []byte{prefixPendingSendPacket}
+)
+
+// GetRateLimitChannelPrefix returns the prefix of all the rate limits of the channel
+func GetRateLimitChannelPrefix(channelID string) []byte {
+	return append(KeyPrefixRateLimit, []byte(channelID+"/")...)
+}
+
+// GetRateLimitKey returns the key of the rate limit of the denom on the channel
+func GetRateLimitKey(denom, channelID string) []byte {
+	return append(GetRateLimitChannelPrefix(channelID), []byte(denom)...)
+}
+
+// GetPendingSendPacketKey returns the key of a sent packet whose outflow is reverted if the packet fails
+func GetPendingSendPacketKey(channelID string, sequence uint64) []byte {
+	return append(KeyPrefixPendingSendPacket, []byte(fmt.Sprintf("%s/%d", channelID, sequence))...)
+}
+
+// GetPendingSendPacketChannelPrefix returns the prefix of all the pending sent packets of the channel
+func GetPendingSendPacketChannelPrefix(channelID string) []byte {
+	return append(KeyPrefixPendingSendPacket, []byte(channelID+"/")...)
+}
+
+// ParsePendingSendPacketKey returns the channel and the sequence of the key of a pending sent packet
+func ParsePendingSendPacketKey(key []byte) (channelID string, sequence uint64, err error) {
+	s := string(key[len(KeyPrefixPendingSendPacket):])
+	i := strings.LastIndex(s, "/")
+	if i < 0 {
+		return "", 0, fmt.Errorf("invalid pending send packet key %s", s)
+	}
+	sequence, err = strconv.ParseUint(s[i+1:], 10, 64)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid sequence of pending send packet key %s: %s", s, err)
+	}
+	return s[:i], sequence, nil
+}
+
+// the denom of the native token in the packets of the transfer module
+func nativeDenom(denom string) string {
+	if denom == sdk.DefaultIbcWei {
+		return sdk.DefaultBondDenom
+	}
+	return denom
+}
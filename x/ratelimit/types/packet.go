@@ -0,0 +1,61 @@
+package types
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	transfertypes "github.com/okex/exchain/libs/ibc-go/modules/apps/transfer/types"
+	"github.com/okex/exchain/libs/ibc-go/modules/core/exported"
+)
+
+// PacketInfo is the denom on this chain, the channel on this chain and the amount of an ics20 packet
+type PacketInfo struct {
+	Denom     string
+	ChannelID string
+	Amount    sdk.Dec
+}
+
+// ParseSendPacket returns the info of an ics20 packet sent by this chain
+func ParseSendPacket(packet exported.PacketI) (PacketInfo, error) {
+	data, amount, err := parsePacketData(packet)
+	if err != nil {
+		return PacketInfo{}, err
+	}
+	// the transfer module sends the full denom path, whether the tokens are escrowed or burned
+	denom := nativeDenom(transfertypes.ParseDenomTrace(data.Denom).IBCDenom())
+	return PacketInfo{Denom: denom, ChannelID: packet.GetSourceChannel(), Amount: amount}, nil
+}
+
+// ParseRecvPacket returns the info of an ics20 packet received by this chain
+func ParseRecvPacket(packet exported.PacketI) (PacketInfo, error) {
+	data, amount, err := parsePacketData(packet)
+	if err != nil {
+		return PacketInfo{}, err
+	}
+
+	var denom string
+	if transfertypes.ReceiverChainIsSource(packet.GetSourcePort(), packet.GetSourceChannel(), data.Denom) {
+		// the tokens return to this chain and are unescrowed
+		voucherPrefix := transfertypes.GetDenomPrefix(packet.GetSourcePort(), packet.GetSourceChannel())
+		denom = data.Denom[len(voucherPrefix):]
+		if trace := transfertypes.ParseDenomTrace(denom); trace.Path != "" {
+			denom = trace.IBCDenom()
+		}
+	} else {
+		// vouchers are minted
+		prefixedDenom := transfertypes.GetDenomPrefix(packet.GetDestPort(), packet.GetDestChannel()) + data.Denom
+		denom = transfertypes.ParseDenomTrace(prefixedDenom).IBCDenom()
+	}
+	return PacketInfo{Denom: nativeDenom(denom), ChannelID: packet.GetDestChannel(), Amount: amount}, nil
+}
+
+func parsePacketData(packet exported.PacketI) (transfertypes.FungibleTokenPacketData, sdk.Dec, error) {
+	var data transfertypes.FungibleTokenPacketData
+	if err := transfertypes.ModuleCdc.UnmarshalJSON(packet.GetData(), &data); err != nil {
+		return data, sdk.Dec{}, sdkerrors.Wrap(ErrInvalidPacketData, err.Error())
+	}
+	amount, ok := sdk.NewIntFromString(data.Amount)
+	if !ok {
+		return data, sdk.Dec{}, sdkerrors.Wrapf(ErrInvalidPacketData, "unable to parse transfer amount (%s) into sdk.Int", data.Amount)
+	}
+	return data, sdk.NewDecFromIntWithPrec(amount, sdk.Precision), nil
+}
@@ -0,0 +1,170 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/tendermint/global"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	govtypes "github.com/okex/exchain/x/gov/types"
+)
+
+const (
+	// proposalTypeSetRateLimit defines the type for a SetRateLimitProposal
+	proposalTypeSetRateLimit = "SetRateLimit"
+	// proposalTypeRemoveRateLimit defines the type for a RemoveRateLimitProposal
+	proposalTypeRemoveRateLimit = "RemoveRateLimit"
+	// proposalTypeResetRateLimit defines the type for a ResetRateLimitProposal
+	proposalTypeResetRateLimit = "ResetRateLimit"
+)
+
+func init() {
+	govtypes.RegisterProposalType(proposalTypeSetRateLimit)
+	govtypes.RegisterProposalType(proposalTypeRemoveRateLimit)
+	govtypes.RegisterProposalType(proposalTypeResetRateLimit)
+	govtypes.RegisterProposalTypeCodec(SetRateLimitProposal{}, setRateLimitProposalName)
+	govtypes.RegisterProposalTypeCodec(RemoveRateLimitProposal{}, removeRateLimitProposalName)
+	govtypes.RegisterProposalTypeCodec(ResetRateLimitProposal{}, resetRateLimitProposalName)
+}
+
+var (
+	_ govtypes.Content = (*SetRateLimitProposal)(nil)
+	_ govtypes.Content = (*RemoveRateLimitProposal)(nil)
+	_ govtypes.Content = (*ResetRateLimitProposal)(nil)
+)
+
+// SetRateLimitProposal adds the rate limit of the denom on the channel or updates its quota,
+// the flow of the rate limit starts a new window
+type SetRateLimitProposal struct {
+	Title       string `json:"title" yaml:"title"`
+	Description string `json:"description" yaml:"description"`
+	Path        Path   `json:"path" yaml:"path"`
+	Quota       Quota  `json:"quota" yaml:"quota"`
+}
+
+func NewSetRateLimitProposal(title, description string, path Path, quota Quota) SetRateLimitProposal {
+	return SetRateLimitProposal{title, description, path, quota}
+}
+
+func (p SetRateLimitProposal) GetTitle() string       { return p.Title }
+func (p SetRateLimitProposal) GetDescription() string { return p.Description }
+func (p SetRateLimitProposal) ProposalRoute() string  { return RouterKey }
+func (p SetRateLimitProposal) ProposalType() string   { return proposalTypeSetRateLimit }
+func (p SetRateLimitProposal) ValidateBasic() sdk.Error {
+	if err := validateProposal(p, proposalTypeSetRateLimit); err != nil {
+		return err
+	}
+	if err := p.Path.Validate(); err != nil {
+		return govtypes.ErrInvalidProposalContent(err.Error())
+	}
+	if err := p.Quota.Validate(); err != nil {
+		return govtypes.ErrInvalidProposalContent(err.Error())
+	}
+	return nil
+}
+
+func (p SetRateLimitProposal) String() string {
+	return fmt.Sprintf(`Set Rate Limit Proposal:
+  Title:            %s
+  Description:      %s
+  Denom:            %s
+  Channel:          %s
+  Max Percent Send: %s
+  Max Percent Recv: %s
+  Duration Hours:   %d
+`, p.Title, p.Description, p.Path.Denom, p.Path.ChannelID, p.Quota.MaxPercentSend, p.Quota.MaxPercentRecv, p.Quota.DurationHours)
+}
+
+// RemoveRateLimitProposal removes the rate limit of the denom on the channel
+type RemoveRateLimitProposal struct {
+	Title       string `json:"title" yaml:"title"`
+	Description string `json:"description" yaml:"description"`
+	Path        Path   `json:"path" yaml:"path"`
+}
+
+func NewRemoveRateLimitProposal(title, description string, path Path) RemoveRateLimitProposal {
+	return RemoveRateLimitProposal{title, description, path}
+}
+
+func (p RemoveRateLimitProposal) GetTitle() string       { return p.Title }
+func (p RemoveRateLimitProposal) GetDescription() string { return p.Description }
+func (p RemoveRateLimitProposal) ProposalRoute() string  { return RouterKey }
+func (p RemoveRateLimitProposal) ProposalType() string   { return proposalTypeRemoveRateLimit }
+func (p RemoveRateLimitProposal) ValidateBasic() sdk.Error {
+	if err := validateProposal(p, proposalTypeRemoveRateLimit); err != nil {
+		return err
+	}
+	if err := p.Path.Validate(); err != nil {
+		return govtypes.ErrInvalidProposalContent(err.Error())
+	}
+	return nil
+}
+
+func (p RemoveRateLimitProposal) String() string {
+	return fmt.Sprintf(`Remove Rate Limit Proposal:
+  Title:       %s
+  Description: %s
+  Denom:       %s
+  Channel:     %s
+`, p.Title, p.Description, p.Path.Denom, p.Path.ChannelID)
+}
+
+// ResetRateLimitProposal clears the flow of the rate limit of the denom on the channel and starts a new window
+type ResetRateLimitProposal struct {
+	Title       string `json:"title" yaml:"title"`
+	Description string `json:"description" yaml:"description"`
+	Path        Path   `json:"path" yaml:"path"`
+}
+
+func NewResetRateLimitProposal(title, description string, path Path) ResetRateLimitProposal {
+	return ResetRateLimitProposal{title, description, path}
+}
+
+func (p ResetRateLimitProposal) GetTitle() string       { return p.Title }
+func (p ResetRateLimitProposal) GetDescription() string { return p.Description }
+func (p ResetRateLimitProposal) ProposalRoute() string  { return RouterKey }
+func (p ResetRateLimitProposal) ProposalType() string   { return proposalTypeResetRateLimit }
+func (p ResetRateLimitProposal) ValidateBasic() sdk.Error {
+	if err := validateProposal(p, proposalTypeResetRateLimit); err != nil {
+		return err
+	}
+	if err := p.Path.Validate(); err != nil {
+		return govtypes.ErrInvalidProposalContent(err.Error())
+	}
+	return nil
+}
+
+func (p ResetRateLimitProposal) String() string {
+	return fmt.Sprintf(`Reset Rate Limit Proposal:
+  Title:       %s
+  Description: %s
+  Denom:       %s
+  Channel:     %s
+`, p.Title, p.Description, p.Path.Denom, p.Path.ChannelID)
+}
+
+func validateProposal(p govtypes.Content, proposalType string) sdk.Error {
+	if global.GetGlobalHeight() > 0 && !tmtypes.HigherThanJupiter(global.GetGlobalHeight()) {
+		return govtypes.ErrInvalidProposalContent(fmt.Sprintf("ratelimit not support at height %d", global.GetGlobalHeight()))
+	}
+
+	if len(strings.TrimSpace(p.GetTitle())) == 0 {
+		return govtypes.ErrInvalidProposalContent("title is required")
+	}
+	if len(p.GetTitle()) > govtypes.MaxTitleLength {
+		return govtypes.ErrInvalidProposalContent("title length is longer than the max")
+	}
+
+	if len(p.GetDescription()) == 0 {
+		return govtypes.ErrInvalidProposalContent("description is required")
+	}
+	if len(p.GetDescription()) > govtypes.MaxDescriptionLength {
+		return govtypes.ErrInvalidProposalContent("description length is longer than the max")
+	}
+
+	if p.ProposalType() != proposalType {
+		return govtypes.ErrInvalidProposalType(p.ProposalType())
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	host "github.com/okex/exchain/libs/ibc-go/modules/core/24-host"
+)
+
+// PacketDirection is the direction of the packets a quota applies to
+type PacketDirection string
+
+const (
+	PacketSend PacketDirection = "send"
+	PacketRecv PacketDirection = "recv"
+)
+
+// Path identifies a rate limit by the denom on this chain and the channel on this chain
+type Path struct {
+	Denom     string `json:"denom" yaml:"denom"`
+	ChannelID string `json:"channel_id" yaml:"channel_id"`
+}
+
+// Validate checks the path
+func (p Path) Validate() error {
+	if strings.TrimSpace(p.Denom) == "" {
+		return sdkerrors.Wrap(ErrInvalidRateLimit, "denom cannot be blank")
+	}
+	if err := host.ChannelIdentifierValidator(p.ChannelID); err != nil {
+		return sdkerrors.Wrapf(ErrInvalidRateLimit, "invalid channel: %s", err)
+	}
+	return nil
+}
+
+// Quota is the largest net flow of a window as a percentage of the channel value, for each direction
+type Quota struct {
+	MaxPercentSend sdk.Int `json:"max_percent_send" yaml:"max_percent_send"`
+	MaxPercentRecv sdk.Int `json:"max_percent_recv" yaml:"max_percent_recv"`
+	DurationHours  uint64  `json:"duration_hours" yaml:"duration_hours"`
+}
+
+// Validate checks the quota
+func (q Quota) Validate() error {
+	hundred := sdk.NewInt(100)
+	if q.MaxPercentSend.IsNil() || q.MaxPercentSend.IsNegative() || q.MaxPercentSend.GT(hundred) {
+		return sdkerrors.Wrap(ErrInvalidRateLimit, "max percent send must be between 0 and 100")
+	}
+	if q.MaxPercentRecv.IsNil() || q.MaxPercentRecv.IsNegative() || q.MaxPercentRecv.GT(hundred) {
+		return sdkerrors.Wrap(ErrInvalidRateLimit, "max percent recv must be between 0 and 100")
+	}
+	if q.MaxPercentSend.IsZero() && q.MaxPercentRecv.IsZero() {
+		return sdkerrors.Wrap(ErrInvalidRateLimit, "max percent send and max percent recv cannot both be 0")
+	}
+	if q.DurationHours == 0 {
+		return sdkerrors.Wrap(ErrInvalidRateLimit, "duration hours must be positive")
+	}
+	return nil
+}
+
+// Duration returns the length of a window
+func (q Quota) Duration() time.Duration {
+	return time.Duration(q.DurationHours) * time.Hour
+}
+
+// Flow is the inflow and outflow of the current window. The channel value is the supply of the denom
+// at the start of the window, the quota is a percentage of it.
+type Flow struct {
+	Inflow       sdk.Dec `json:"inflow" yaml:"inflow"`
+	Outflow      sdk.Dec `json:"outflow" yaml:"outflow"`
+	ChannelValue sdk.Dec `json:"channel_value" yaml:"channel_value"`
+	WindowStart  int64   `json:"window_start" yaml:"window_start"`
+}
+
+// NewFlow returns an empty flow of a window starting at the given time
+func NewFlow(channelValue sdk.Dec, windowStart time.Time) Flow {
+	return Flow{
+		Inflow:       sdk.ZeroDec(),
+		Outflow:      sdk.ZeroDec(),
+		ChannelValue: channelValue,
+		WindowStart:  windowStart.Unix(),
+	}
+}
+
+// RateLimit limits the net flow of a denom over a channel in each window
+type RateLimit struct {
+	Path  Path  `json:"path" yaml:"path"`
+	Quota Quota `json:"quota" yaml:"quota"`
+	Flow  Flow  `json:"flow" yaml:"flow"`
+}
+
+// Threshold returns the largest net flow of the direction in the current window
+func (r RateLimit) Threshold(direction PacketDirection) sdk.Dec {
+	percent := r.Quota.MaxPercentRecv
+	if direction == PacketSend {
+		percent = r.Quota.MaxPercentSend
+	}
+	return r.Flow.ChannelValue.MulInt(percent).QuoInt64(100)
+}
+
+// AddFlow adds the amount to the flow of the direction, it fails if the net flow of the direction exceeds the quota
+func (r *RateLimit) AddFlow(direction PacketDirection, amount sdk.Dec) error {
+	inflow, outflow := r.Flow.Inflow, r.Flow.Outflow
+	var netFlow sdk.Dec
+	if direction == PacketSend {
+		outflow = outflow.Add(amount)
+		netFlow = outflow.Sub(inflow)
+	} else {
+		inflow = inflow.Add(amount)
+		netFlow = inflow.Sub(outflow)
+	}
+
+	if threshold := r.Threshold(direction); netFlow.GT(threshold) {
+		return sdkerrors.Wrapf(ErrQuotaExceeded, "%s of %s over %s: net flow %s exceeds threshold %s",
+			direction, r.Path.Denom, r.Path.ChannelID, netFlow, threshold)
+	}
+	r.Flow.Inflow, r.Flow.Outflow = inflow, outflow
+	return nil
+}
+
+// WindowExpired returns true if the current window of the rate limit ended
+func (r RateLimit) WindowExpired(blockTime time.Time) bool {
+	return !blockTime.Before(time.Unix(r.Flow.WindowStart, 0).Add(r.Quota.Duration()))
+}
+
+func (r RateLimit) String() string {
+	return fmt.Sprintf(`Rate Limit:
+  Denom:            %s
+  Channel:          %s
+  Max Percent Send: %s
+  Max Percent Recv: %s
+  Duration Hours:   %d
+  Inflow:           %s
+  Outflow:          %s
+  Channel Value:    %s
+  Window Start:     %s`,
+		r.Path.Denom, r.Path.ChannelID, r.Quota.MaxPercentSend, r.Quota.MaxPercentRecv, r.Quota.DurationHours,
+		r.Flow.Inflow, r.Flow.Outflow, r.Flow.ChannelValue, time.Unix(r.Flow.WindowStart, 0).UTC())
+}
+
+// PendingSendPacket is a sent packet whose outflow is reverted if it fails in the same window
+type PendingSendPacket struct {
+	Denom       string  `json:"denom"`
+	Amount      sdk.Dec `json:"amount"`
+	WindowStart int64   `json:"window_start"`
+}
@@ -0,0 +1,128 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	transfertypes "github.com/okex/exchain/libs/ibc-go/modules/apps/transfer/types"
+	clienttypes "github.com/okex/exchain/libs/ibc-go/modules/core/02-client/types"
+	channeltypes "github.com/okex/exchain/libs/ibc-go/modules/core/04-channel/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuotaValidate(t *testing.T) {
+	testCases := []struct {
+		name       string
+		send, recv int64
+		hours      uint64
+		expPass    bool
+	}{
+		{"valid", 10, 20, 24, true},
+		{"blocked direction", 0, 100, 1, true},
+		{"both zero", 0, 0, 24, false},
+		{"above 100", 101, 10, 24, false},
+		{"negative", -1, 10, 24, false},
+		{"zero duration", 10, 10, 0, false},
+	}
+	for _, tc := range testCases {
+		quota := Quota{MaxPercentSend: sdk.NewInt(tc.send), MaxPercentRecv: sdk.NewInt(tc.recv), DurationHours: tc.hours}
+		require.Equal(t, tc.expPass, quota.Validate() == nil, tc.name)
+	}
+	require.Error(t, Quota{DurationHours: 1}.Validate())
+}
+
+func TestGenesisStateValidate(t *testing.T) {
+	rateLimit := RateLimit{
+		Path:  Path{Denom: "uatom", ChannelID: "channel-0"},
+		Quota: Quota{MaxPercentSend: sdk.NewInt(10), MaxPercentRecv: sdk.NewInt(10), DurationHours: 24},
+		Flow:  NewFlow(sdk.NewDec(1000), time.Unix(1000, 0)),
+	}
+	pending := GenesisPendingSendPacket{
+		ChannelID: "channel-0",
+		Sequence:  1,
+		Packet:    PendingSendPacket{Denom: "uatom", Amount: sdk.NewDec(10), WindowStart: 1000},
+	}
+
+	require.NoError(t, GenesisState{}.Validate())
+	require.NoError(t, NewGenesisState([]RateLimit{rateLimit}, []GenesisPendingSendPacket{pending}).Validate())
+	require.Error(t, NewGenesisState([]RateLimit{rateLimit, rateLimit}, nil).Validate())
+	require.Error(t, NewGenesisState(nil, []GenesisPendingSendPacket{pending, pending}).Validate())
+
+	invalid := rateLimit
+	invalid.Quota.DurationHours = 0
+	require.Error(t, NewGenesisState([]RateLimit{invalid}, nil).Validate())
+	invalid = rateLimit
+	invalid.Flow.ChannelValue = sdk.ZeroDec()
+	require.Error(t, NewGenesisState([]RateLimit{invalid}, nil).Validate())
+	invalid = rateLimit
+	invalid.Flow.Outflow = sdk.NewDec(-1)
+	require.Error(t, NewGenesisState([]RateLimit{invalid}, nil).Validate())
+
+	invalidPending := pending
+	invalidPending.ChannelID = ""
+	require.Error(t, NewGenesisState(nil, []GenesisPendingSendPacket{invalidPending}).Validate())
+	invalidPending = pending
+	invalidPending.Packet.Amount = sdk.NewDec(-1)
+	require.Error(t, NewGenesisState(nil, []GenesisPendingSendPacket{invalidPending}).Validate())
+}
+
+func TestParsePendingSendPacketKey(t *testing.T) {
+	channelID, sequence, err := ParsePendingSendPacketKey(GetPendingSendPacketKey("channel-10", 42))
+	require.NoError(t, err)
+	require.Equal(t, "channel-10", channelID)
+	require.Equal(t, uint64(42), sequence)
+
+	_, _, err = ParsePendingSendPacketKey(append(KeyPrefixPendingSendPacket, []byte("channel-10")...))
+	require.Error(t, err)
+}
+
+func TestRateLimitAddFlow(t *testing.T) {
+	rateLimit := RateLimit{
+		Path:  Path{Denom: sdk.DefaultBondDenom, ChannelID: "channel-0"},
+		Quota: Quota{MaxPercentSend: sdk.NewInt(10), MaxPercentRecv: sdk.NewInt(0), DurationHours: 1},
+		Flow:  NewFlow(sdk.NewDec(1000), time.Unix(0, 0)),
+	}
+	require.Equal(t, sdk.NewDec(100), rateLimit.Threshold(PacketSend))
+
+	require.NoError(t, rateLimit.AddFlow(PacketSend, sdk.NewDec(100)))
+	require.ErrorIs(t, rateLimit.AddFlow(PacketSend, sdk.NewDec(1)), ErrQuotaExceeded)
+	require.Equal(t, sdk.NewDec(100), rateLimit.Flow.Outflow)
+
+	// the returned tokens net out the outflow, the zero quota blocks any net inflow
+	require.NoError(t, rateLimit.AddFlow(PacketRecv, sdk.NewDec(100)))
+	require.ErrorIs(t, rateLimit.AddFlow(PacketRecv, sdk.NewDec(1)), ErrQuotaExceeded)
+	require.NoError(t, rateLimit.AddFlow(PacketSend, sdk.NewDec(100)))
+
+	require.False(t, rateLimit.WindowExpired(time.Unix(3599, 0)))
+	require.True(t, rateLimit.WindowExpired(time.Unix(3600, 0)))
+}
+
+func TestParsePacket(t *testing.T) {
+	height := clienttypes.NewHeight(0, 100)
+	data := transfertypes.NewFungibleTokenPacketData(sdk.DefaultIbcWei, "1000000000000000000", "sender", "receiver")
+	packet := channeltypes.NewPacket(data.GetBytes(), 1, "transfer", "channel-0", "transfer", "channel-1", height, 0)
+	info, err := ParseSendPacket(packet)
+	require.NoError(t, err)
+	require.Equal(t, PacketInfo{Denom: sdk.DefaultBondDenom, ChannelID: "channel-0", Amount: sdk.OneDec()}, info)
+
+	// the native tokens return
+	data.Denom = "transfer/channel-1/" + sdk.DefaultIbcWei
+	packet = channeltypes.NewPacket(data.GetBytes(), 1, "transfer", "channel-1", "transfer", "channel-0", height, 0)
+	info, err = ParseRecvPacket(packet)
+	require.NoError(t, err)
+	require.Equal(t, sdk.DefaultBondDenom, info.Denom)
+	require.Equal(t, "channel-0", info.ChannelID)
+
+	// vouchers are minted
+	data.Denom = "uatom"
+	packet = channeltypes.NewPacket(data.GetBytes(), 1, "transfer", "channel-1", "transfer", "channel-0", height, 0)
+	info, err = ParseRecvPacket(packet)
+	require.NoError(t, err)
+	require.Equal(t, transfertypes.ParseDenomTrace("transfer/channel-0/uatom").IBCDenom(), info.Denom)
+
+	data.Amount = "invalid"
+	packet = channeltypes.NewPacket(data.GetBytes(), 1, "transfer", "channel-1", "transfer", "channel-0", height, 0)
+	_, err = ParseRecvPacket(packet)
+	require.ErrorIs(t, err, ErrInvalidPacketData)
+}
@@ -11,11 +11,19 @@ var (
 	NewSendToWasmEventHandler = keeper.NewSendToWasmEventHandler
 	NewCallToWasmEventHandler = keeper.NewCallToWasmEventHandler
 	RegisterSendToEvmEncoder  = keeper.RegisterSendToEvmEncoder
+	RegisterCustomQuerier     = keeper.RegisterCustomQuerier
+	RegisterTokenMessenger    = keeper.RegisterTokenMessenger
 	NewKeeper                 = keeper.NewKeeper
 	RegisterInterface         = types.RegisterInterface
 	PrecompileHooks           = keeper.PrecompileHooks
 )
 
+const (
+	ModuleName = types.ModuleName
+	StoreKey   = types.StoreKey
+	RouterKey  = types.RouterKey
+)
+
 type (
 	MsgSendToEvm = types.MsgSendToEvm
 	Keeper       = keeper.Keeper
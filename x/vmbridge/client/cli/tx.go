@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/client/context"
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	interfacetypes "github.com/okex/exchain/libs/cosmos-sdk/codec/types"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/version"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/auth"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/auth/client/utils"
+	"github.com/okex/exchain/x/gov"
+	vmbridgeutils "github.com/okex/exchain/x/vmbridge/client/utils"
+	"github.com/okex/exchain/x/vmbridge/types"
+	"github.com/spf13/cobra"
+)
+
+// GetCmdRegisterTokenPairProposal implements a command handler for submitting a register token pair proposal transaction
+func GetCmdRegisterTokenPairProposal(cdcP *codec.CodecProxy, reg interfacetypes.InterfaceRegistry) *cobra.Command {
+	return &cobra.Command{
+		Use:   "register-token-pair [proposal-file]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Submit a register token pair proposal",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Submit a register token pair proposal along with an initial deposit.
+The proposal pairs an erc20 contract with a cw20 contract, both of them then keep their balances
+in one ledger of the vmbridge module. Neither contract can be paired twice.
+The proposal details must be supplied via a JSON file.
+
+Example:
+$ %s tx gov submit-proposal register-token-pair <path/to/proposal.json> --from=<key_or_address>
+
+Where proposal.json contains:
+
+{
+ "title": "register token pair",
+ "description": "pair the erc20 and cw20 contracts of usdt",
+ "token_pair": {
+   "erc20_address": "0x5FbDB2315678afecb367f032d93F642f64180aa3",
+   "cw20_address": "ex14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s6fqu27"
+ },
+ "deposit": [
+   {
+     "denom": "%s",
+     "amount": "100"
+   }
+ ]
+}
+`, version.ClientName, sdk.DefaultBondDenom,
+			)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cdc := cdcP.GetCdc()
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			proposal, err := vmbridgeutils.ParseRegisterTokenPairProposalJSON(cdc, args[0])
+			if err != nil {
+				return err
+			}
+
+			from := cliCtx.GetFromAddress()
+			content := types.NewRegisterTokenPairProposal(proposal.Title, proposal.Description, proposal.TokenPair)
+			msg := gov.NewMsgSubmitProposal(content, proposal.Deposit, from)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdRegisterNFTPairProposal implements a command handler for submitting a register nft pair proposal transaction
+func GetCmdRegisterNFTPairProposal(cdcP *codec.CodecProxy, reg interfacetypes.InterfaceRegistry) *cobra.Command {
+	return &cobra.Command{
+		Use:   "register-nft-pair [proposal-file]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Submit a register nft pair proposal",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Submit a register nft pair proposal along with an initial deposit.
+The proposal pairs an erc721 wrapper contract with a class of the nft module, the wrapper then serves
+the nfts of the class to the evm. Neither the contract nor the class can be paired twice.
+The proposal details must be supplied via a JSON file.
+
+Example:
+$ %s tx gov submit-proposal register-nft-pair <path/to/proposal.json> --from=<key_or_address>
+
+Where proposal.json contains:
+
+{
+ "title": "register nft pair",
+ "description": "wrap the kitty class as an erc721 contract",
+ "nft_pair": {
+   "erc721_address": "0x5FbDB2315678afecb367f032d93F642f64180aa3",
+   "class_id": "kitty"
+ },
+ "deposit": [
+   {
+     "denom": "%s",
+     "amount": "100"
+   }
+ ]
+}
+`, version.ClientName, sdk.DefaultBondDenom,
+			)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cdc := cdcP.GetCdc()
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			proposal, err := vmbridgeutils.ParseRegisterNFTPairProposalJSON(cdc, args[0])
+			if err != nil {
+				return err
+			}
+
+			from := cliCtx.GetFromAddress()
+			content := types.NewRegisterNFTPairProposal(proposal.Title, proposal.Description, proposal.NFTPair)
+			msg := gov.NewMsgSubmitProposal(content, proposal.Deposit, from)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
@@ -0,0 +1,14 @@
+package client
+
+import (
+	govcli "github.com/okex/exchain/x/gov/client"
+	"github.com/okex/exchain/x/vmbridge/client/cli"
+	"github.com/okex/exchain/x/vmbridge/client/rest"
+)
+
+var (
+	// RegisterTokenPairProposalHandler alias gov NewProposalHandler
+	RegisterTokenPairProposalHandler = govcli.NewProposalHandler(cli.GetCmdRegisterTokenPairProposal, rest.RegisterTokenPairProposalRESTHandler)
+	// RegisterNFTPairProposalHandler alias gov NewProposalHandler
+	RegisterNFTPairProposalHandler = govcli.NewProposalHandler(cli.GetCmdRegisterNFTPairProposal, rest.RegisterNFTPairProposalRESTHandler)
+)
@@ -0,0 +1,16 @@
+package rest
+
+import (
+	"github.com/okex/exchain/libs/cosmos-sdk/client/context"
+	govRest "github.com/okex/exchain/x/gov/client/rest"
+)
+
+// RegisterTokenPairProposalRESTHandler defines vmbridge proposal handler
+func RegisterTokenPairProposalRESTHandler(context.CLIContext) govRest.ProposalRESTHandler {
+	return govRest.ProposalRESTHandler{}
+}
+
+// RegisterNFTPairProposalRESTHandler defines vmbridge proposal handler
+func RegisterNFTPairProposalRESTHandler(context.CLIContext) govRest.ProposalRESTHandler {
+	return govRest.ProposalRESTHandler{}
+}
@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"io/ioutil"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/vmbridge/types"
+)
+
+// RegisterTokenPairProposalJSON defines a RegisterTokenPairProposalJSON with a deposit used to parse register
+// token pair proposals from a JSON file.
+type RegisterTokenPairProposalJSON struct {
+	Title       string          `json:"title" yaml:"title"`
+	Description string          `json:"description" yaml:"description"`
+	TokenPair   types.TokenPair `json:"token_pair" yaml:"token_pair"`
+	Deposit     sdk.SysCoins    `json:"deposit" yaml:"deposit"`
+}
+
+// ParseRegisterTokenPairProposalJSON parse json from proposal file to RegisterTokenPairProposalJSON struct
+func ParseRegisterTokenPairProposalJSON(cdc *codec.Codec, proposalFilePath string) (
+	proposal RegisterTokenPairProposalJSON, err error) {
+	contents, err := ioutil.ReadFile(proposalFilePath)
+	if err != nil {
+		return
+	}
+
+	err = cdc.UnmarshalJSON(contents, &proposal)
+	return
+}
+
+// RegisterNFTPairProposalJSON defines a RegisterNFTPairProposalJSON with a deposit used to parse register
+// nft pair proposals from a JSON file.
+type RegisterNFTPairProposalJSON struct {
+	Title       string        `json:"title" yaml:"title"`
+	Description string        `json:"description" yaml:"description"`
+	NFTPair     types.NFTPair `json:"nft_pair" yaml:"nft_pair"`
+	Deposit     sdk.SysCoins  `json:"deposit" yaml:"deposit"`
+}
+
+// ParseRegisterNFTPairProposalJSON parse json from proposal file to RegisterNFTPairProposalJSON struct
+func ParseRegisterNFTPairProposalJSON(cdc *codec.Codec, proposalFilePath string) (
+	proposal RegisterNFTPairProposalJSON, err error) {
+	contents, err := ioutil.ReadFile(proposalFilePath)
+	if err != nil {
+		return
+	}
+
+	err = cdc.UnmarshalJSON(contents, &proposal)
+	return
+}
@@ -0,0 +1,37 @@
+package vmbridge
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/vmbridge/keeper"
+	"github.com/okex/exchain/x/vmbridge/types"
+)
+
+// InitGenesis import module genesis
+func InitGenesis(ctx sdk.Context, k keeper.Keeper, data types.GenesisState) {
+	for _, tokenPair := range data.TokenPairs {
+		k.SetTokenPair(ctx, tokenPair)
+	}
+	for _, balance := range data.Balances {
+		erc20, account := common.HexToAddress(balance.Erc20Address), common.HexToAddress(balance.Address)
+		k.SetTokenBalance(ctx, erc20, account, balance.Amount)
+		k.SetTokenSupply(ctx, erc20, k.GetTokenSupply(ctx, erc20).Add(balance.Amount))
+	}
+	for _, nftPair := range data.NFTPairs {
+		k.SetNFTPair(ctx, nftPair)
+	}
+}
+
+// ExportGenesis export module state
+func ExportGenesis(ctx sdk.Context, k keeper.Keeper) types.GenesisState {
+	tokenPairs := k.GetTokenPairs(ctx)
+	var balances []types.TokenBalance
+	for _, tokenPair := range tokenPairs {
+		erc20 := tokenPair.GetErc20()
+		k.IterateTokenBalances(ctx, erc20, func(account common.Address, balance sdk.Int) bool {
+			balances = append(balances, types.NewTokenBalance(erc20, account, balance))
+			return false
+		})
+	}
+	return types.NewGenesisState(tokenPairs, balances, k.GetNFTPairs(ctx))
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/okex/exchain/x/evm/watcher"
 	"github.com/okex/exchain/x/vmbridge/types"
 	"math/big"
+	"strings"
 )
 
 // event __SendToWasmEventName(string wasmAddr,string recipient, string amount)
@@ -180,48 +181,66 @@ func (k Keeper) CallToEvm(ctx sdk.Context, caller, contract string, calldata str
 	return string(result.Ret), nil
 }
 
-// callEvm execute an evm message from native module
-func (k Keeper) CallEvm(ctx sdk.Context, callerAddr common.Address, to *common.Address, value *big.Int, data []byte) (*evmtypes.ExecutionResult, *evmtypes.ResultData, error) {
-
-	config, found := k.evmKeeper.GetChainConfig(ctx)
-	if !found {
-		return nil, nil, types.ErrChainConfigNotFound
+// wasm query evm, the call runs on a cache context which is dropped afterwards,
+// so only the gas it consumes is charged to the caller
+func (k Keeper) QueryEvm(ctx sdk.Context, caller, contract string, calldata string) ([]byte, error) {
+	if !tmtypes.HigherThanJupiter(ctx.BlockHeight()) {
+		errMsg := fmt.Sprintf("vmbridge not supprt at height %d", ctx.BlockHeight())
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, errMsg)
+	}
+	params := k.wasmKeeper.GetParams(ctx)
+	if !params.VmbridgeEnable {
+		return nil, types.ErrVMBridgeEnable
 	}
 
-	chainIDEpoch, err := ethermint.ParseChainID(ctx.ChainID())
+	if !sdk.IsETHAddress(contract) {
+		return nil, types.ErrIsNotETHAddr
+	}
+	contractAccAddr, err := sdk.AccAddressFromBech32(contract)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
+	conrtractAddr := common.BytesToAddress(contractAccAddr.Bytes())
 
-	acc := k.accountKeeper.GetAccount(ctx, callerAddr.Bytes())
-	if acc == nil {
-		acc = k.accountKeeper.NewAccountWithAddress(ctx, callerAddr.Bytes())
+	var callerAddr common.Address
+	if caller != "" {
+		if !sdk.IsETHAddress(caller) {
+			return nil, types.ErrIsNotETHAddr
+		}
+		callerAccAddr, err := sdk.AccAddressFromBech32(caller)
+		if err != nil {
+			return nil, err
+		}
+		callerAddr = common.BytesToAddress(callerAccAddr.Bytes())
 	}
-	nonce := acc.GetSequence()
-	txHash := tmtypes.Tx(ctx.TxBytes()).Hash(ctx.BlockHeight())
-	ethTxHash := common.BytesToHash(txHash)
 
-	gasLimit := ctx.GasMeter().Limit()
-	if gasLimit == sdk.NewInfiniteGasMeter().Limit() {
-		gasLimit = k.evmKeeper.GetParams(ctx).MaxGasLimitPerTx
+	realCall, err := hex.DecodeString(strings.TrimPrefix(calldata, "0x"))
+	if err != nil {
+		return nil, sdkerrors.Wrap(types.ErrInvalidEvmQuery, err.Error())
 	}
 
-	st := evmtypes.StateTransition{
-		AccountNonce: nonce,
-		Price:        big.NewInt(0),
-		GasLimit:     gasLimit,
-		Recipient:    to,
-		Amount:       value,
-		Payload:      data,
-		Csdb:         evmtypes.CreateEmptyCommitStateDB(k.evmKeeper.GenerateCSDBParams(), ctx),
-		ChainID:      chainIDEpoch,
-		TxHash:       &ethTxHash,
-		Sender:       callerAddr,
-		Simulate:     ctx.IsCheckTx(),
-		TraceTx:      false,
-		TraceTxLog:   false,
+	// the state written by the call, the nonce of caller and the watcher data must never be committed
+	cacheCtx, _ := ctx.CacheContext()
+	cacheCtx.ResetWatcher()
+	result, err := k.staticCallEvm(cacheCtx, callerAddr, &conrtractAddr, realCall)
+	if err != nil {
+		return nil, sdkerrors.Wrap(types.ErrEvmExecuteFailed, err.Error())
 	}
-	st.Csdb.Prepare(ethTxHash, k.evmKeeper.GetBlockHash(), 0)
+	return result.Ret, nil
+}
+
+// callEvm execute an evm message from native module
+func (k Keeper) CallEvm(ctx sdk.Context, callerAddr common.Address, to *common.Address, value *big.Int, data []byte) (*evmtypes.ExecutionResult, *evmtypes.ResultData, error) {
+	st, config, err := k.newStateTransition(ctx, callerAddr, to, value, data)
+	if err != nil {
+		return nil, nil, err
+	}
+	acc := k.accountKeeper.GetAccount(ctx, callerAddr.Bytes())
+	if acc == nil {
+		acc = k.accountKeeper.NewAccountWithAddress(ctx, callerAddr.Bytes())
+	}
+	nonce := st.AccountNonce
+	ethTxHash := *st.TxHash
 
 	st.SetCallToCM(k.evmKeeper.GetCallToCM())
 	addVMBridgeInnertx(ctx, k.evmKeeper, callerAddr.String(), to, VMBRIDGE_START_INNERTX, value)
@@ -277,3 +296,60 @@ func (k Keeper) CallEvm(ctx sdk.Context, callerAddr common.Address, to *common.A
 
 	return executionResult, resultData, err
 }
+
+// newStateTransition builds the state transition of an evm message from native module
+func (k Keeper) newStateTransition(ctx sdk.Context, callerAddr common.Address, to *common.Address, value *big.Int, data []byte) (evmtypes.StateTransition, evmtypes.ChainConfig, error) {
+	config, found := k.evmKeeper.GetChainConfig(ctx)
+	if !found {
+		return evmtypes.StateTransition{}, config, types.ErrChainConfigNotFound
+	}
+
+	chainIDEpoch, err := ethermint.ParseChainID(ctx.ChainID())
+	if err != nil {
+		return evmtypes.StateTransition{}, config, err
+	}
+
+	var nonce uint64
+	if acc := k.accountKeeper.GetAccount(ctx, callerAddr.Bytes()); acc != nil {
+		nonce = acc.GetSequence()
+	}
+	txHash := tmtypes.Tx(ctx.TxBytes()).Hash(ctx.BlockHeight())
+	ethTxHash := common.BytesToHash(txHash)
+
+	gasLimit := ctx.GasMeter().Limit()
+	if gasLimit == sdk.NewInfiniteGasMeter().Limit() {
+		gasLimit = k.evmKeeper.GetParams(ctx).MaxGasLimitPerTx
+	}
+
+	st := evmtypes.StateTransition{
+		AccountNonce: nonce,
+		Price:        big.NewInt(0),
+		GasLimit:     gasLimit,
+		Recipient:    to,
+		Amount:       value,
+		Payload:      data,
+		Csdb:         evmtypes.CreateEmptyCommitStateDB(k.evmKeeper.GenerateCSDBParams(), ctx),
+		ChainID:      chainIDEpoch,
+		TxHash:       &ethTxHash,
+		Sender:       callerAddr,
+		Simulate:     ctx.IsCheckTx(),
+		TraceTx:      false,
+		TraceTxLog:   false,
+	}
+	st.Csdb.Prepare(ethTxHash, k.evmKeeper.GetBlockHash(), 0)
+	return st, config, nil
+}
+
+// staticCallEvm runs an evm message from native module without any side effect out of ctx: neither the inner
+// txs and the contracts recorded by the evm keeper nor the events and the nonce of the caller are written,
+// and the state transition is never committed
+func (k Keeper) staticCallEvm(ctx sdk.Context, callerAddr common.Address, to *common.Address, data []byte) (*evmtypes.ResultData, error) {
+	st, config, err := k.newStateTransition(ctx, callerAddr, to, big.NewInt(0), data)
+	if err != nil {
+		return nil, err
+	}
+	st.Simulate = true
+	st.SetCallToCM(k.evmKeeper.GetCallToCM())
+	_, resultData, err, _, _ := st.TransitionDb(ctx, config)
+	return resultData, err
+}
@@ -3,6 +3,7 @@ package keeper_test
 import (
 	"bytes"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/ethereum/go-ethereum/accounts/abi"
@@ -1069,3 +1070,113 @@ func getCallByWasmOutput(abi abi.ABI, data []byte) (string, error) {
 	}
 	return result[0].(string), nil
 }
+
+func (suite *KeeperTestSuite) TestEvmQuerier() {
+	caller := common.BytesToAddress(suite.addr.Bytes())
+	contract := suite.evmContract.String()
+	balanceInput, err := suite.evmABI.Pack("balanceOf", caller)
+	suite.Require().NoError(err)
+	calldata := hex.EncodeToString(balanceInput)
+	queryFormat := "{\"call_evm\":{\"contract\":\"%s\",\"calldata\":\"%s\"}}"
+	var request string
+	reset := func() {
+		contract = suite.evmContract.String()
+		calldata = hex.EncodeToString(balanceInput)
+		request = fmt.Sprintf(queryFormat, contract, calldata)
+	}
+
+	testCases := []struct {
+		msg      string
+		malleate func()
+		error    error
+		expect   *big.Int
+	}{
+		{
+			"query balance",
+			func() {},
+			nil,
+			big.NewInt(1000),
+		},
+		{
+			"query balance with 0x calldata and caller",
+			func() {
+				request = fmt.Sprintf("{\"call_evm\":{\"caller\":\"%s\",\"contract\":\"%s\",\"calldata\":\"0x%s\"}}", caller.String(), contract, calldata)
+			},
+			nil,
+			big.NewInt(1000),
+		},
+		{
+			"state changing call is discarded",
+			func() {
+				mint, err := suite.evmABI.Pack("mint", caller, big.NewInt(1000))
+				suite.Require().NoError(err)
+				request = fmt.Sprintf(queryFormat, contract, hex.EncodeToString(mint))
+			},
+			nil,
+			nil,
+		},
+		{
+			"contract(ex)",
+			func() {
+				request = fmt.Sprintf(queryFormat, sdk.AccAddress(suite.evmContract.Bytes()).String(), calldata)
+			},
+			types.ErrIsNotETHAddr,
+			nil,
+		},
+		{
+			"calldata(not hex)",
+			func() {
+				request = fmt.Sprintf(queryFormat, contract, "zz")
+			},
+			sdkerrors.Wrap(types.ErrInvalidEvmQuery, "encoding/hex: invalid byte: U+007A 'z'"),
+			nil,
+		},
+		{
+			"unknown query",
+			func() {
+				request = "{\"unknown\":{}}"
+			},
+			sdkerrors.Wrap(types.ErrInvalidEvmQuery, "unknown custom query variant"),
+			nil,
+		},
+		{
+			"vmbridge disable",
+			func() {
+				params := suite.app.WasmKeeper.GetParams(suite.ctx)
+				params.VmbridgeEnable = false
+				suite.app.WasmKeeper.SetParams(suite.ctx, params)
+			},
+			types.ErrVMBridgeEnable,
+			nil,
+		},
+	}
+	for _, tc := range testCases {
+		suite.Run(fmt.Sprintf("Case %s", tc.msg), func() {
+			suite.SetupTest()
+			reset()
+			tc.malleate()
+
+			querier := keeper2.RegisterCustomQuerier(*suite.keeper).Custom
+			ctx := suite.ctx
+			ctx.SetGasMeter(sdk.NewGasMeter(10000000))
+			ret, err := querier(ctx, []byte(request))
+			if tc.error != nil {
+				suite.Require().EqualError(err, tc.error.Error())
+				return
+			}
+			suite.Require().NoError(err)
+			suite.Require().NotZero(ctx.GasMeter().GasConsumed())
+
+			var response types.CallEvmQueryResponse
+			suite.Require().NoError(json.Unmarshal(ret, &response))
+			if tc.expect != nil {
+				data, err := hex.DecodeString(response.Data)
+				suite.Require().NoError(err)
+				r, err := suite.evmABI.Unpack("balanceOf", data)
+				suite.Require().NoError(err)
+				suite.Require().Equal(tc.expect, r[0].(*big.Int))
+			}
+			suite.Require().Equal(big.NewInt(1000), suite.queryBalance(caller))
+		})
+	}
+}
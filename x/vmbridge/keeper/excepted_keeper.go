@@ -7,6 +7,7 @@ import (
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	authexported "github.com/okex/exchain/libs/cosmos-sdk/x/auth/exported"
 	evmtypes "github.com/okex/exchain/x/evm/types"
+	nfttypes "github.com/okex/exchain/x/nft/types"
 	wasmtypes "github.com/okex/exchain/x/wasm/types"
 )
 
@@ -38,3 +39,17 @@ type AccountKeeper interface {
 type BankKeeper interface {
 	SendCoins(ctx sdk.Context, fromAddr sdk.AccAddress, toAddr sdk.AccAddress, amt sdk.Coins) error
 }
+
+// SwapKeeper defines the expected ammswap keeper interface
+type SwapKeeper interface {
+	GetTWAPPrice(ctx sdk.Context, token, quoteToken string, window int64) (sdk.Dec, error)
+}
+
+// NFTKeeper defines the expected nft keeper interface
+type NFTKeeper interface {
+	HasClass(ctx sdk.Context, classID string) bool
+	GetNFT(ctx sdk.Context, classID, id string) (nfttypes.NFT, bool)
+	GetOwner(ctx sdk.Context, classID, id string) sdk.AccAddress
+	GetBalance(ctx sdk.Context, classID string, owner sdk.AccAddress) uint64
+	Transfer(ctx sdk.Context, classID, id string, receiver sdk.AccAddress) error
+}
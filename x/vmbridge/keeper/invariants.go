@@ -0,0 +1,38 @@
+package keeper
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/vmbridge/types"
+)
+
+// RegisterInvariants registers all vmbridge invariants
+func RegisterInvariants(ir sdk.InvariantRegistry, k Keeper) {
+	ir.RegisterRoute(types.ModuleName, "token-supply", tokenSupplyInvariant(k))
+}
+
+// tokenSupplyInvariant checks that the total supply of every token pair equals the sum of the balances in its ledger
+func tokenSupplyInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var msg string
+		broken := false
+		k.IterateTokenPairs(ctx, func(tokenPair types.TokenPair) bool {
+			erc20 := tokenPair.GetErc20()
+			sum := sdk.ZeroInt()
+			k.IterateTokenBalances(ctx, erc20, func(_ common.Address, balance sdk.Int) bool {
+				sum = sum.Add(balance)
+				return false
+			})
+			supply := k.GetTokenSupply(ctx, erc20)
+			if !supply.Equal(sum) {
+				broken = true
+				msg += fmt.Sprintf("\ttoken pair %s: total supply %s, sum of balances %s\n", tokenPair, supply, sum)
+			}
+			return false
+		})
+
+		return sdk.FormatInvariant(types.ModuleName, "token supply", msg), broken
+	}
+}
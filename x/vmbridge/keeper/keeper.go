@@ -4,11 +4,13 @@ import (
 	"github.com/okex/exchain/x/vmbridge/types"
 
 	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	"github.com/okex/exchain/libs/tendermint/libs/log"
 )
 
 type Keeper struct {
-	cdc *codec.CodecProxy
+	cdc      *codec.CodecProxy
+	storeKey sdk.StoreKey
 
 	logger log.Logger
 
@@ -16,11 +18,13 @@ type Keeper struct {
 	wasmKeeper    WASMKeeper
 	accountKeeper AccountKeeper
 	bankKeeper    BankKeeper
+	swapKeeper    SwapKeeper
+	nftKeeper     NFTKeeper
 }
 
-func NewKeeper(cdc *codec.CodecProxy, logger log.Logger, evmKeeper EVMKeeper, wasmKeeper WASMKeeper, accountKeeper AccountKeeper, bk BankKeeper) *Keeper {
+func NewKeeper(cdc *codec.CodecProxy, logger log.Logger, storeKey sdk.StoreKey, evmKeeper EVMKeeper, wasmKeeper WASMKeeper, accountKeeper AccountKeeper, bk BankKeeper, sk SwapKeeper, nk NFTKeeper) *Keeper {
 	logger = logger.With("module", types.ModuleName)
-	return &Keeper{cdc: cdc, logger: logger, evmKeeper: evmKeeper, wasmKeeper: wasmKeeper, accountKeeper: accountKeeper, bankKeeper: bk}
+	return &Keeper{cdc: cdc, storeKey: storeKey, logger: logger, evmKeeper: evmKeeper, wasmKeeper: wasmKeeper, accountKeeper: accountKeeper, bankKeeper: bk, swapKeeper: sk, nftKeeper: nk}
 }
 
 func (k Keeper) Logger() log.Logger {
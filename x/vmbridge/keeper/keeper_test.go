@@ -60,6 +60,7 @@ func (suite *KeeperTestSuite) SetupTest() {
 	})
 	suite.keeper = suite.app.VMBridgeKeeper
 	types.UnittestOnlySetMilestoneEarthHeight(1)
+	types.UnittestOnlySetMilestoneJupiterHeight(1)
 
 	suite.addr = sdk.AccAddress{0x1, 0x2, 0x3, 0x4, 0x5, 0x6, 0x7, 0x8, 0x9, 0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x20}
 	acc := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, suite.addr)
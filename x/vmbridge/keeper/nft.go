@@ -0,0 +1,121 @@
+package keeper
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	"github.com/okex/exchain/x/vmbridge/types"
+)
+
+// RegisterNFTPair pairs the erc721 contract with the class of the nft module, neither of them can be paired twice
+func (k Keeper) RegisterNFTPair(ctx sdk.Context, nftPair types.NFTPair) error {
+	if err := nftPair.Validate(); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+	erc721 := nftPair.GetErc721()
+	if _, found := k.GetNFTPair(ctx, erc721); found {
+		return sdkerrors.Wrapf(types.ErrNFTPairExists, "erc721 contract %s", erc721)
+	}
+	if _, found := k.GetNFTPairByClass(ctx, nftPair.ClassID); found {
+		return sdkerrors.Wrapf(types.ErrNFTPairExists, "class %s", nftPair.ClassID)
+	}
+	if !k.nftKeeper.HasClass(ctx, nftPair.ClassID) {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "class %s does not exist", nftPair.ClassID)
+	}
+
+	k.SetNFTPair(ctx, types.NewNFTPair(erc721, nftPair.ClassID))
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeRegisterNFTPair,
+			sdk.NewAttribute(types.AttributeErc721Address, erc721.String()),
+			sdk.NewAttribute(types.AttributeClassID, nftPair.ClassID),
+		),
+	)
+	return nil
+}
+
+// SetNFTPair stores the nft pair and its class index
+func (k Keeper) SetNFTPair(ctx sdk.Context, nftPair types.NFTPair) {
+	store := ctx.KVStore(k.storeKey)
+	erc721 := nftPair.GetErc721()
+	store.Set(types.GetNFTPairKey(erc721), k.getAminoCodec().MustMarshalBinaryBare(nftPair))
+	store.Set(types.GetClassIndexKey(nftPair.ClassID), erc721.Bytes())
+}
+
+// GetNFTPair returns the nft pair of the erc721 contract
+func (k Keeper) GetNFTPair(ctx sdk.Context, erc721 common.Address) (nftPair types.NFTPair, found bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetNFTPairKey(erc721))
+	if len(bz) == 0 {
+		return nftPair, false
+	}
+	k.getAminoCodec().MustUnmarshalBinaryBare(bz, &nftPair)
+	return nftPair, true
+}
+
+// GetNFTPairByClass returns the nft pair of the class
+func (k Keeper) GetNFTPairByClass(ctx sdk.Context, classID string) (types.NFTPair, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetClassIndexKey(classID))
+	if len(bz) == 0 {
+		return types.NFTPair{}, false
+	}
+	return k.GetNFTPair(ctx, common.BytesToAddress(bz))
+}
+
+// GetNFTPairs returns all the registered nft pairs
+func (k Keeper) GetNFTPairs(ctx sdk.Context) []types.NFTPair {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.KeyPrefixNFTPair)
+	defer iterator.Close()
+
+	nftPairs := []types.NFTPair{}
+	for ; iterator.Valid(); iterator.Next() {
+		var nftPair types.NFTPair
+		k.getAminoCodec().MustUnmarshalBinaryBare(iterator.Value(), &nftPair)
+		nftPairs = append(nftPairs, nftPair)
+	}
+	return nftPairs
+}
+
+// GetNFTOwner returns the owner of the nft of the token id, it is the zero address if the nft does not exist
+func (k Keeper) GetNFTOwner(ctx sdk.Context, nftPair types.NFTPair, tokenID *big.Int) common.Address {
+	return common.BytesToAddress(k.nftKeeper.GetOwner(ctx, nftPair.ClassID, types.TokenIDToNFTID(tokenID)))
+}
+
+// GetNFTBalance returns the number of the nfts of the class owned by the account
+func (k Keeper) GetNFTBalance(ctx sdk.Context, nftPair types.NFTPair, account common.Address) uint64 {
+	return k.nftKeeper.GetBalance(ctx, nftPair.ClassID, sdk.AccAddress(account.Bytes()))
+}
+
+// GetNFTTokenURI returns the uri of the nft of the token id
+func (k Keeper) GetNFTTokenURI(ctx sdk.Context, nftPair types.NFTPair, tokenID *big.Int) (string, error) {
+	nft, found := k.nftKeeper.GetNFT(ctx, nftPair.ClassID, types.TokenIDToNFTID(tokenID))
+	if !found {
+		return "", sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "nft %s of class %s does not exist", tokenID, nftPair.ClassID)
+	}
+	return nft.URI, nil
+}
+
+// TransferNFT moves the nft of the token id from its owner to another account.
+// The paired contract is trusted to check that the transfer is authorized by the owner or an approved operator.
+func (k Keeper) TransferNFT(ctx sdk.Context, nftPair types.NFTPair, from, to common.Address, tokenID *big.Int) error {
+	id := types.TokenIDToNFTID(tokenID)
+	if owner := k.nftKeeper.GetOwner(ctx, nftPair.ClassID, id); !owner.Equals(sdk.AccAddress(from.Bytes())) {
+		return sdkerrors.Wrapf(types.ErrNFTNotOwner, "%s of nft %s", from, id)
+	}
+	if err := k.nftKeeper.Transfer(ctx, nftPair.ClassID, id, sdk.AccAddress(to.Bytes())); err != nil {
+		return err
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeNFTTransfer,
+			sdk.NewAttribute(types.AttributeErc721Address, nftPair.Erc721Address),
+			sdk.NewAttribute(types.AttributeClassID, nftPair.ClassID),
+			sdk.NewAttribute(types.AttributeFrom, from.String()),
+			sdk.NewAttribute(types.AttributeTo, to.String()),
+			sdk.NewAttribute(types.AttributeTokenID, id),
+		),
+	)
+	return nil
+}
@@ -0,0 +1,163 @@
+package keeper_test
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	nfttypes "github.com/okex/exchain/x/nft/types"
+	"github.com/okex/exchain/x/vmbridge/types"
+)
+
+func (suite *KeeperTestSuite) saveNFTClass(classID string) {
+	suite.Require().NoError(suite.app.NFTKeeper.SaveClass(suite.ctx, nfttypes.Class{ID: classID}))
+}
+
+func (suite *KeeperTestSuite) TestKeeper_RegisterNFTPair() {
+	otherErc721 := common.BigToAddress(big.NewInt(1))
+
+	testCases := []struct {
+		msg     string
+		nftPair types.NFTPair
+		error   error
+	}{
+		{"register a new pair", types.NewNFTPair(otherErc721, "puppy"), nil},
+		{"erc721 is paired", types.NewNFTPair(suite.evmContract, "puppy"), sdkerrors.Wrapf(types.ErrNFTPairExists, "erc721 contract %s", suite.evmContract)},
+		{"class is paired", types.NewNFTPair(otherErc721, "kitty"), sdkerrors.Wrapf(types.ErrNFTPairExists, "class kitty")},
+		{"class does not exist", types.NewNFTPair(otherErc721, "bunny"), sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "class bunny does not exist")},
+		{"zero erc721", types.NewNFTPair(common.Address{}, "puppy"), errors.New("invalid request: erc721 address can not be zero")},
+	}
+	for _, tc := range testCases {
+		suite.Run(fmt.Sprintf("Case %s", tc.msg), func() {
+			suite.SetupTest()
+			suite.saveNFTClass("kitty")
+			suite.saveNFTClass("puppy")
+			suite.Require().NoError(suite.keeper.RegisterNFTPair(suite.ctx, types.NewNFTPair(suite.evmContract, "kitty")))
+
+			err := suite.keeper.RegisterNFTPair(suite.ctx, tc.nftPair)
+			if tc.error != nil {
+				suite.Require().EqualError(err, tc.error.Error())
+				suite.Require().Equal(1, len(suite.keeper.GetNFTPairs(suite.ctx)))
+				return
+			}
+			suite.Require().NoError(err)
+			nftPair, found := suite.keeper.GetNFTPairByClass(suite.ctx, "puppy")
+			suite.Require().True(found)
+			suite.Require().Equal(tc.nftPair, nftPair)
+			suite.Require().Equal(2, len(suite.keeper.GetNFTPairs(suite.ctx)))
+		})
+	}
+}
+
+func (suite *KeeperTestSuite) TestPrecompileNFT() {
+	cmBridgePrecompileAddress := common.HexToAddress("0x0000000000000000000000000000000000000100")
+	erc721 := suite.evmContract
+	alice, bob := common.BigToAddress(big.NewInt(0xa)), common.BigToAddress(big.NewInt(0xb))
+	call := func(caller common.Address, method string, args ...interface{}) ([]interface{}, error) {
+		ctx := suite.ctx
+		ctx.SetGasMeter(sdk.NewInfiniteGasMeter())
+		input, err := types.PreCompileABI.Pack(method, args...)
+		suite.Require().NoError(err)
+		_, result, err := suite.app.VMBridgeKeeper.CallEvm(ctx, caller, &cmBridgePrecompileAddress, big.NewInt(0), input)
+		if err != nil {
+			return nil, err
+		}
+		return types.PreCompileABI.Methods[method].Outputs.Unpack(result.Ret)
+	}
+
+	testCases := []struct {
+		msg      string
+		malleate func()
+		owner    common.Address
+		alice    int64
+		bob      int64
+	}{
+		{
+			"mint",
+			func() {},
+			alice,
+			2,
+			0,
+		},
+		{
+			"transfer",
+			func() {
+				_, err := call(erc721, types.PrecompileNFTTransfer, alice, bob, big.NewInt(1))
+				suite.Require().NoError(err)
+			},
+			bob,
+			1,
+			1,
+		},
+		{
+			"transfer by other than the owner",
+			func() {
+				_, err := call(erc721, types.PrecompileNFTTransfer, bob, alice, big.NewInt(1))
+				suite.Require().EqualError(err, sdkerrors.Wrapf(types.ErrNFTNotOwner, "%s of nft 1", bob).Error())
+			},
+			alice,
+			2,
+			0,
+		},
+		{
+			"transfer a nft that does not exist",
+			func() {
+				_, err := call(erc721, types.PrecompileNFTTransfer, alice, bob, big.NewInt(3))
+				suite.Require().Error(err)
+			},
+			alice,
+			2,
+			0,
+		},
+		{
+			"before the jupiter height",
+			func() {
+				tmtypes.UnittestOnlySetMilestoneJupiterHeight(suite.ctx.BlockHeight() + 1)
+				defer tmtypes.UnittestOnlySetMilestoneJupiterHeight(1)
+				_, err := call(erc721, types.PrecompileNFTTransfer, alice, bob, big.NewInt(1))
+				suite.Require().Error(err)
+			},
+			alice,
+			2,
+			0,
+		},
+		{
+			"caller is not paired",
+			func() {
+				_, err := call(bob, types.PrecompileNFTTransfer, alice, bob, big.NewInt(1))
+				suite.Require().EqualError(err, sdkerrors.Wrapf(types.ErrNFTPairNotFound, "erc721 contract %s", bob).Error())
+			},
+			alice,
+			2,
+			0,
+		},
+	}
+	for _, tc := range testCases {
+		suite.Run(fmt.Sprintf("Case %s", tc.msg), func() {
+			suite.SetupTest()
+			suite.saveNFTClass("kitty")
+			for _, id := range []string{"1", "2"} {
+				nft := nfttypes.NFT{ClassID: "kitty", ID: id, URI: "ipfs://kitty/" + id}
+				suite.Require().NoError(suite.app.NFTKeeper.Mint(suite.ctx, nft, sdk.AccAddress(alice.Bytes())))
+			}
+			suite.Require().NoError(suite.keeper.RegisterNFTPair(suite.ctx, types.NewNFTPair(erc721, "kitty")))
+			tc.malleate()
+
+			ret, err := call(erc721, types.PrecompileNFTOwnerOf, big.NewInt(1))
+			suite.Require().NoError(err)
+			suite.Require().Equal(tc.owner, ret[0].(common.Address))
+			for account, expect := range map[common.Address]int64{alice: tc.alice, bob: tc.bob} {
+				ret, err := call(erc721, types.PrecompileNFTBalanceOf, account)
+				suite.Require().NoError(err)
+				suite.Require().Equal(big.NewInt(expect).String(), ret[0].(*big.Int).String())
+			}
+			ret, err = call(erc721, types.PrecompileNFTTokenURI, big.NewInt(2))
+			suite.Require().NoError(err)
+			suite.Require().Equal("ipfs://kitty/2", ret[0].(string))
+		})
+	}
+}
@@ -3,9 +3,12 @@ package keeper
 import (
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/vm"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 	evmtypes "github.com/okex/exchain/x/evm/types"
 	"github.com/okex/exchain/x/vmbridge/types"
 	"math/big"
@@ -42,6 +45,15 @@ func methodDispatch(k *Keeper, csdb *evmtypes.CommitStateDB, sdkCtx sdk.Context,
 	if err != nil {
 		return nil, 0, err
 	}
+	// the token, nft and twap methods are unknown before the pairs and the twap oracle are supported
+	if (types.IsPrecompileTokenMethod(method.Name) || types.IsPrecompileNFTMethod(method.Name) ||
+		method.Name == types.PrecompileQueryTwap) && !tmtypes.HigherThanJupiter(sdkCtx.BlockHeight()) {
+		return nil, 0, fmt.Errorf("no method with id: %#x", input[:4])
+	}
+	// the twap oracle is read from ammswap, it does not depend on wasm
+	if method.Name == types.PrecompileQueryTwap {
+		return queryTwap(k, sdkCtx, value, input, remainGas)
+	}
 
 	params := k.wasmKeeper.GetParams(sdkCtx)
 	if !params.VmbridgeEnable {
@@ -58,6 +70,11 @@ func methodDispatch(k *Keeper, csdb *evmtypes.CommitStateDB, sdkCtx sdk.Context,
 		result, leftGas, err = callToWasm(k, subCtx, caller, to, value, input)
 	case types.PrecompileQueryToWasm:
 		result, leftGas, err = queryToWasm(k, subCtx, caller, to, value, input)
+	case types.PrecompileTokenTransfer, types.PrecompileTokenMint, types.PrecompileTokenBurn,
+		types.PrecompileTokenBalanceOf, types.PrecompileTokenTotalSupply:
+		result, leftGas, err = callToken(k, subCtx, caller, value, method.Name, input)
+	case types.PrecompileNFTTransfer, types.PrecompileNFTOwnerOf, types.PrecompileNFTBalanceOf, types.PrecompileNFTTokenURI:
+		result, leftGas, err = callNFT(k, subCtx, caller, value, method.Name, input)
 	default:
 		result, leftGas, err = nil, 0, errors.New("methodDispatch failed: unknown method")
 	}
@@ -117,3 +134,141 @@ func queryToWasm(k *Keeper, sdkCtx sdk.Context, caller, to common.Address, value
 	result, err := types.EncodePrecompileQueryToWasmOutput(string(ret))
 	return result, left, err
 }
+
+func queryTwap(k *Keeper, sdkCtx sdk.Context, value *big.Int, input []byte, remainGas uint64) (result []byte, leftGas uint64, err error) {
+	if value.Sign() != 0 {
+		return nil, 0, errors.New("queryTwapPrice can not be send token")
+	}
+	token, quoteToken, window, err := types.DecodePrecompileQueryTwapInput(input)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	gasMeter := sdk.NewGasMeter(remainGas)
+	sdkCtx.SetGasMeter(gasMeter)
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(sdk.ErrorOutOfGas); !ok {
+				panic(r)
+			}
+			result, leftGas, err = nil, 0, errors.New("queryTwapPrice out of gas")
+		}
+	}()
+
+	price, err := k.swapKeeper.GetTWAPPrice(sdkCtx, token, quoteToken, window)
+	left := gasMeter.Limit() - gasMeter.GasConsumed()
+	if err != nil {
+		return nil, left, err
+	}
+
+	result, err = types.EncodePrecompileQueryTwapOutput(price.BigInt())
+	return result, left, err
+}
+
+// callToken serves the ledger of the token pair to its erc20 contract, which is the caller of the precompile
+func callToken(k *Keeper, sdkCtx sdk.Context, caller common.Address, value *big.Int, method string, input []byte) (result []byte, leftGas uint64, err error) {
+	if value.Sign() != 0 {
+		return nil, 0, fmt.Errorf("%s can not be send token", method)
+	}
+	gasMeter := sdkCtx.GasMeter()
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(sdk.ErrorOutOfGas); !ok {
+				panic(r)
+			}
+			result, leftGas, err = nil, 0, fmt.Errorf("%s out of gas", method)
+		}
+	}()
+
+	tokenPair, found := k.GetTokenPair(sdkCtx, caller)
+	if !found {
+		return nil, gasMeter.Limit() - gasMeter.GasConsumed(), sdkerrors.Wrapf(types.ErrTokenPairNotFound, "erc20 contract %s", caller)
+	}
+	var output interface{}
+	switch method {
+	case types.PrecompileTokenTransfer:
+		var from, to common.Address
+		var amount *big.Int
+		if from, to, amount, err = types.DecodePrecompileTokenTransferInput(input); err == nil {
+			err = k.TransferToken(sdkCtx, tokenPair, from, to, sdk.NewIntFromBigInt(amount))
+		}
+		output = true
+	case types.PrecompileTokenMint, types.PrecompileTokenBurn:
+		var account common.Address
+		var amount *big.Int
+		if account, amount, err = types.DecodePrecompileTokenAmountInput(method, input); err == nil {
+			if method == types.PrecompileTokenMint {
+				err = k.MintToken(sdkCtx, tokenPair, account, sdk.NewIntFromBigInt(amount))
+			} else {
+				err = k.BurnToken(sdkCtx, tokenPair, account, sdk.NewIntFromBigInt(amount))
+			}
+		}
+		output = true
+	case types.PrecompileTokenBalanceOf:
+		var account common.Address
+		if account, err = types.DecodePrecompileTokenBalanceOfInput(input); err == nil {
+			output = k.GetTokenBalance(sdkCtx, tokenPair.GetErc20(), account).BigInt()
+		}
+	case types.PrecompileTokenTotalSupply:
+		output = k.GetTokenSupply(sdkCtx, tokenPair.GetErc20()).BigInt()
+	}
+	left := gasMeter.Limit() - gasMeter.GasConsumed()
+	if err != nil {
+		return nil, left, err
+	}
+
+	result, err = types.EncodePrecompileTokenOutput(method, output)
+	return result, left, err
+}
+
+// callNFT serves the nfts of the class of the nft pair to its erc721 contract, which is the caller of the precompile
+func callNFT(k *Keeper, sdkCtx sdk.Context, caller common.Address, value *big.Int, method string, input []byte) (result []byte, leftGas uint64, err error) {
+	if value.Sign() != 0 {
+		return nil, 0, fmt.Errorf("%s can not be send token", method)
+	}
+	gasMeter := sdkCtx.GasMeter()
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(sdk.ErrorOutOfGas); !ok {
+				panic(r)
+			}
+			result, leftGas, err = nil, 0, fmt.Errorf("%s out of gas", method)
+		}
+	}()
+
+	nftPair, found := k.GetNFTPair(sdkCtx, caller)
+	if !found {
+		return nil, gasMeter.Limit() - gasMeter.GasConsumed(), sdkerrors.Wrapf(types.ErrNFTPairNotFound, "erc721 contract %s", caller)
+	}
+	var output interface{}
+	switch method {
+	case types.PrecompileNFTTransfer:
+		var from, to common.Address
+		var tokenID *big.Int
+		if from, to, tokenID, err = types.DecodePrecompileNFTTransferInput(input); err == nil {
+			err = k.TransferNFT(sdkCtx, nftPair, from, to, tokenID)
+		}
+		output = true
+	case types.PrecompileNFTOwnerOf, types.PrecompileNFTTokenURI:
+		var tokenID *big.Int
+		if tokenID, err = types.DecodePrecompileNFTTokenIDInput(method, input); err == nil {
+			if method == types.PrecompileNFTOwnerOf {
+				output = k.GetNFTOwner(sdkCtx, nftPair, tokenID)
+			} else {
+				output, err = k.GetNFTTokenURI(sdkCtx, nftPair, tokenID)
+			}
+		}
+	case types.PrecompileNFTBalanceOf:
+		var account common.Address
+		if account, err = types.DecodePrecompileNFTBalanceOfInput(input); err == nil {
+			output = new(big.Int).SetUint64(k.GetNFTBalance(sdkCtx, nftPair, account))
+		}
+	}
+	left := gasMeter.Limit() - gasMeter.GasConsumed()
+	if err != nil {
+		return nil, left, err
+	}
+
+	result, err = types.EncodePrecompileTokenOutput(method, output)
+	return result, left, err
+}
@@ -9,11 +9,14 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	ammswaptypes "github.com/okex/exchain/x/ammswap/types"
 	evmtypes "github.com/okex/exchain/x/evm/types"
 	"github.com/okex/exchain/x/vmbridge/types"
 	"math/big"
 	"strconv"
 	"strings"
+	"time"
 )
 
 var (
@@ -660,6 +663,77 @@ func (suite *KeeperTestSuite) TestPrecompileHooks() {
 	}
 }
 
+func (suite *KeeperTestSuite) TestPrecompileQueryTwap() {
+	cmBridgePrecompileAddress := common.HexToAddress("0x0000000000000000000000000000000000000100")
+	caller := common.BytesToAddress(suite.addr)
+	swapKeeper := suite.app.SwapKeeper
+	start := suite.ctx.BlockTime()
+	at := func(seconds int64) sdk.Context {
+		ctx := suite.ctx
+		ctx.SetBlockTime(start.Add(time.Duration(seconds) * time.Second))
+		ctx.SetGasMeter(sdk.NewInfiniteGasMeter())
+		return ctx
+	}
+
+	swapKeeper.SetParams(suite.ctx, ammswaptypes.DefaultParams())
+	pair := ammswaptypes.SwapTokenPair{
+		BasePooledCoin:  sdk.NewDecCoinFromDec("aab", sdk.NewDec(100)),
+		QuotePooledCoin: sdk.NewDecCoinFromDec("okt", sdk.NewDec(200)),
+		PoolTokenName:   ammswaptypes.GetPoolTokenName("aab", "okt"),
+	}
+	swapKeeper.SetSwapTokenPair(suite.ctx, pair.TokenPairName(), pair)
+	swapKeeper.UpdateTWAPCheckpoints(at(0))
+	// the price of aab is 2okt for 10 seconds, then 4okt for 30 seconds
+	pair.QuotePooledCoin.Amount = sdk.NewDec(400)
+	swapKeeper.SetSwapTokenPair(suite.ctx, pair.TokenPairName(), pair)
+	swapKeeper.UpdateTWAPCheckpoints(at(10))
+
+	testCases := []struct {
+		msg        string
+		token      string
+		quoteToken string
+		window     int64
+		amount     *big.Int
+		price      sdk.Dec
+		error      error
+	}{
+		{"base token price", "aab", "okt", 40, big.NewInt(0), sdk.MustNewDecFromStr("3.5"), nil},
+		{"base token price in a short window", "aab", "okt", 30, big.NewInt(0), sdk.NewDec(4), nil},
+		{"quote token price", "okt", "aab", 30, big.NewInt(0), sdk.MustNewDecFromStr("0.25"), nil},
+		{"window longer than the checkpoints", "aab", "okt", 41, big.NewInt(0), sdk.Dec{}, errors.New("invalid twap window: no price checkpoint of aab_okt before " + strconv.FormatInt(start.Unix()-1, 10))},
+		{"unknown pair", "aab", "ccb", 40, big.NewInt(0), sdk.Dec{}, errors.New("swap token pair is not exist: aab_ccb")},
+		{"send token", "aab", "okt", 40, big.NewInt(1), sdk.Dec{}, errors.New("queryTwapPrice can not be send token")},
+	}
+	for _, tc := range testCases {
+		suite.Run(fmt.Sprintf("Case %s", tc.msg), func() {
+			ctx := at(40)
+			subCtx, _ := ctx.CacheContext()
+			evmCalldata, err := types.PreCompileABI.Pack(types.PrecompileQueryTwap, tc.token, tc.quoteToken, big.NewInt(tc.window))
+			suite.Require().NoError(err)
+			_, evmResult, err := suite.app.VMBridgeKeeper.CallEvm(subCtx, caller, &cmBridgePrecompileAddress, tc.amount, evmCalldata)
+			if tc.error != nil {
+				suite.Require().EqualError(err, tc.error.Error())
+				return
+			}
+			suite.Require().NoError(err)
+			pack, err := types.PreCompileABI.Methods[types.PrecompileQueryTwap].Outputs.Unpack(evmResult.Ret)
+			suite.Require().NoError(err)
+			suite.Require().Equal(1, len(pack))
+			suite.Require().Equal(tc.price.BigInt(), pack[0].(*big.Int))
+		})
+	}
+
+	// the twap oracle is unknown before the jupiter height
+	tmtypes.UnittestOnlySetMilestoneJupiterHeight(suite.ctx.BlockHeight() + 1)
+	defer tmtypes.UnittestOnlySetMilestoneJupiterHeight(1)
+	ctx := at(40)
+	subCtx, _ := ctx.CacheContext()
+	evmCalldata, err := types.PreCompileABI.Pack(types.PrecompileQueryTwap, "aab", "okt", big.NewInt(40))
+	suite.Require().NoError(err)
+	_, _, err = suite.app.VMBridgeKeeper.CallEvm(subCtx, caller, &cmBridgePrecompileAddress, big.NewInt(0), evmCalldata)
+	suite.Require().EqualError(err, fmt.Sprintf("no method with id: %#x", evmCalldata[:4]))
+}
+
 func (suite *KeeperTestSuite) queryPrecompileWasmBalance(ctx sdk.Context, caller, wasmContract, to string) (int, error) {
 	testQueryMsg := fmt.Sprintf("{\"balance\":{\"address\":\"%s\"}}", to)
 	wasmsmartRequest := wasmvmtypes.WasmQuery{Smart: &wasmvmtypes.SmartQuery{ContractAddr: wasmContract, Msg: []byte(testQueryMsg)}}
@@ -0,0 +1,89 @@
+package keeper
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	"github.com/okex/exchain/x/vmbridge/types"
+)
+
+// NewQuerier is the module level router for state queries
+func NewQuerier(k Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, error) {
+		if len(path) < 1 {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest,
+				"Insufficient parameters, at least 1 parameter is required")
+		}
+
+		switch path[0] {
+		case types.QueryTokenPairs:
+			return codec.MarshalJSONIndent(k.getAminoCodec(), k.GetTokenPairs(ctx))
+		case types.QueryTokenPair:
+			if len(path) < 2 {
+				return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "the contract of the token pair is required")
+			}
+			tokenPair, err := k.getTokenPairOfContract(ctx, path[1])
+			if err != nil {
+				return nil, err
+			}
+			return codec.MarshalJSONIndent(k.getAminoCodec(), tokenPair)
+		case types.QueryTokenBalance:
+			if len(path) < 3 {
+				return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "the contract of the token pair and the account are required")
+			}
+			tokenPair, err := k.getTokenPairOfContract(ctx, path[1])
+			if err != nil {
+				return nil, err
+			}
+			account, err := toLedgerAddress(path[2])
+			if err != nil {
+				return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, err.Error())
+			}
+			balance := types.NewTokenBalance(tokenPair.GetErc20(), account, k.GetTokenBalance(ctx, tokenPair.GetErc20(), account))
+			return codec.MarshalJSONIndent(k.getAminoCodec(), balance)
+		case types.QueryNFTPairs:
+			return codec.MarshalJSONIndent(k.getAminoCodec(), k.GetNFTPairs(ctx))
+		case types.QueryNFTPair:
+			if len(path) < 2 {
+				return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "the erc721 contract or the class of the nft pair is required")
+			}
+			nftPair, err := k.getNFTPairOfContractOrClass(ctx, path[1])
+			if err != nil {
+				return nil, err
+			}
+			return codec.MarshalJSONIndent(k.getAminoCodec(), nftPair)
+		default:
+			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "unknown query endpoint")
+		}
+	}
+}
+
+// getTokenPairOfContract returns the token pair of either its erc20 or its cw20 contract
+func (k Keeper) getTokenPairOfContract(ctx sdk.Context, contract string) (types.TokenPair, error) {
+	addr, err := sdk.WasmAddressFromBech32(contract)
+	if err != nil {
+		return types.TokenPair{}, sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid contract address %s", contract)
+	}
+	if tokenPair, found := k.GetTokenPair(ctx, common.BytesToAddress(addr.Bytes())); found {
+		return tokenPair, nil
+	}
+	if tokenPair, found := k.GetTokenPairByCw20(ctx, addr); found {
+		return tokenPair, nil
+	}
+	return types.TokenPair{}, sdkerrors.Wrapf(types.ErrTokenPairNotFound, "contract %s", contract)
+}
+
+// getNFTPairOfContractOrClass returns the nft pair of either its erc721 contract or its class
+func (k Keeper) getNFTPairOfContractOrClass(ctx sdk.Context, contractOrClass string) (types.NFTPair, error) {
+	if common.IsHexAddress(contractOrClass) {
+		if nftPair, found := k.GetNFTPair(ctx, common.HexToAddress(contractOrClass)); found {
+			return nftPair, nil
+		}
+	}
+	if nftPair, found := k.GetNFTPairByClass(ctx, contractOrClass); found {
+		return nftPair, nil
+	}
+	return types.NFTPair{}, sdkerrors.Wrapf(types.ErrNFTPairNotFound, "%s", contractOrClass)
+}
@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: MIT
+
+pragma solidity 0.8.7;
+
+// ERC721Wrapper serves a class of the nft module as an erc721 contract. The ownership of the nfts is kept by the
+// nft module through the vmbridge precompile, the wrapper only keeps the approvals. The wrapper must be paired with
+// the class by a RegisterNFTPairProposal.
+contract ERC721Wrapper {
+    address constant precompile = 0x0000000000000000000000000000000000000100;
+
+    string public name;
+    string public symbol;
+
+    mapping(uint256 => address) private _tokenApprovals;
+    mapping(address => mapping(address => bool)) private _operatorApprovals;
+
+    event Transfer(address indexed from, address indexed to, uint256 indexed tokenId);
+    event Approval(address indexed owner, address indexed approved, uint256 indexed tokenId);
+    event ApprovalForAll(address indexed owner, address indexed operator, bool approved);
+
+    constructor(string memory name_, string memory symbol_) {
+        name = name_;
+        symbol = symbol_;
+    }
+
+    function supportsInterface(bytes4 interfaceId) public pure returns (bool) {
+        return interfaceId == 0x80ac58cd || interfaceId == 0x5b5e139f || interfaceId == 0x01ffc9a7;
+    }
+
+    function balanceOf(address owner) public view returns (uint256) {
+        require(owner != address(0), "ERC721: balance query for the zero address");
+        (bool success, bytes memory data) = precompile.staticcall(abi.encodeWithSignature("nftBalanceOf(address)", owner));
+        require(success, "ERC721: nftBalanceOf failed");
+        return abi.decode(data, (uint256));
+    }
+
+    function ownerOf(uint256 tokenId) public view returns (address) {
+        (bool success, bytes memory data) = precompile.staticcall(abi.encodeWithSignature("nftOwnerOf(uint256)", tokenId));
+        require(success, "ERC721: nftOwnerOf failed");
+        address owner = abi.decode(data, (address));
+        require(owner != address(0), "ERC721: owner query for nonexistent token");
+        return owner;
+    }
+
+    function tokenURI(uint256 tokenId) public view returns (string memory) {
+        (bool success, bytes memory data) = precompile.staticcall(abi.encodeWithSignature("nftTokenURI(uint256)", tokenId));
+        require(success, "ERC721: URI query for nonexistent token");
+        return abi.decode(data, (string));
+    }
+
+    function approve(address to, uint256 tokenId) public {
+        address owner = ownerOf(tokenId);
+        require(to != owner, "ERC721: approval to current owner");
+        require(msg.sender == owner || isApprovedForAll(owner, msg.sender), "ERC721: approve caller is not owner nor approved for all");
+        _tokenApprovals[tokenId] = to;
+        emit Approval(owner, to, tokenId);
+    }
+
+    function getApproved(uint256 tokenId) public view returns (address) {
+        ownerOf(tokenId);
+        return _tokenApprovals[tokenId];
+    }
+
+    function setApprovalForAll(address operator, bool approved) public {
+        require(operator != msg.sender, "ERC721: approve to caller");
+        _operatorApprovals[msg.sender][operator] = approved;
+        emit ApprovalForAll(msg.sender, operator, approved);
+    }
+
+    function isApprovedForAll(address owner, address operator) public view returns (bool) {
+        return _operatorApprovals[owner][operator];
+    }
+
+    function transferFrom(address from, address to, uint256 tokenId) public {
+        address owner = ownerOf(tokenId);
+        require(msg.sender == owner || getApproved(tokenId) == msg.sender || isApprovedForAll(owner, msg.sender),
+            "ERC721: transfer caller is not owner nor approved");
+        require(to != address(0), "ERC721: transfer to the zero address");
+        delete _tokenApprovals[tokenId];
+        (bool success, ) = precompile.call(abi.encodeWithSignature("nftTransfer(address,address,uint256)", from, to, tokenId));
+        require(success, "ERC721: nftTransfer failed");
+        emit Transfer(from, to, tokenId);
+    }
+
+    function safeTransferFrom(address from, address to, uint256 tokenId) public {
+        safeTransferFrom(from, to, tokenId, "");
+    }
+
+    function safeTransferFrom(address from, address to, uint256 tokenId, bytes memory data) public {
+        transferFrom(from, to, tokenId);
+        if (to.code.length > 0) {
+            bytes4 retval = IERC721Receiver(to).onERC721Received(msg.sender, from, tokenId, data);
+            require(retval == IERC721Receiver.onERC721Received.selector, "ERC721: transfer to non ERC721Receiver implementer");
+        }
+    }
+}
+
+interface IERC721Receiver {
+    function onERC721Received(address operator, address from, uint256 tokenId, bytes calldata data) external returns (bytes4);
+}
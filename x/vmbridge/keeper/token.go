@@ -0,0 +1,210 @@
+package keeper
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	"github.com/okex/exchain/x/vmbridge/types"
+)
+
+// RegisterTokenPair pairs the erc20 contract with the cw20 contract, neither of them can be paired twice
+func (k Keeper) RegisterTokenPair(ctx sdk.Context, tokenPair types.TokenPair) error {
+	if err := tokenPair.Validate(); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+	erc20, cw20 := tokenPair.GetErc20(), tokenPair.GetCw20()
+	if _, found := k.GetTokenPair(ctx, erc20); found {
+		return sdkerrors.Wrapf(types.ErrTokenPairExists, "erc20 contract %s", erc20)
+	}
+	if _, found := k.GetTokenPairByCw20(ctx, cw20); found {
+		return sdkerrors.Wrapf(types.ErrTokenPairExists, "cw20 contract %s", cw20)
+	}
+
+	k.SetTokenPair(ctx, types.NewTokenPair(erc20, cw20))
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeRegisterTokenPair,
+			sdk.NewAttribute(types.AttributeErc20Address, erc20.String()),
+			sdk.NewAttribute(types.AttributeCw20Address, cw20.String()),
+		),
+	)
+	return nil
+}
+
+// SetTokenPair stores the token pair and its cw20 index
+func (k Keeper) SetTokenPair(ctx sdk.Context, tokenPair types.TokenPair) {
+	store := ctx.KVStore(k.storeKey)
+	erc20 := tokenPair.GetErc20()
+	store.Set(types.GetTokenPairKey(erc20), k.getAminoCodec().MustMarshalBinaryBare(tokenPair))
+	store.Set(types.GetCw20IndexKey(tokenPair.GetCw20()), erc20.Bytes())
+}
+
+// GetTokenPair returns the token pair of the erc20 contract
+func (k Keeper) GetTokenPair(ctx sdk.Context, erc20 common.Address) (tokenPair types.TokenPair, found bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetTokenPairKey(erc20))
+	if len(bz) == 0 {
+		return tokenPair, false
+	}
+	k.getAminoCodec().MustUnmarshalBinaryBare(bz, &tokenPair)
+	return tokenPair, true
+}
+
+// GetTokenPairByCw20 returns the token pair of the cw20 contract
+func (k Keeper) GetTokenPairByCw20(ctx sdk.Context, cw20 sdk.WasmAddress) (types.TokenPair, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetCw20IndexKey(cw20))
+	if len(bz) == 0 {
+		return types.TokenPair{}, false
+	}
+	return k.GetTokenPair(ctx, common.BytesToAddress(bz))
+}
+
+// GetTokenPairs returns all the registered token pairs
+func (k Keeper) GetTokenPairs(ctx sdk.Context) []types.TokenPair {
+	tokenPairs := []types.TokenPair{}
+	k.IterateTokenPairs(ctx, func(tokenPair types.TokenPair) bool {
+		tokenPairs = append(tokenPairs, tokenPair)
+		return false
+	})
+	return tokenPairs
+}
+
+// IterateTokenPairs iterates over all the registered token pairs
+func (k Keeper) IterateTokenPairs(ctx sdk.Context, handlerFn func(tokenPair types.TokenPair) (stop bool)) {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.KeyPrefixTokenPair)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var tokenPair types.TokenPair
+		k.getAminoCodec().MustUnmarshalBinaryBare(iterator.Value(), &tokenPair)
+		if handlerFn(tokenPair) {
+			break
+		}
+	}
+}
+
+// GetTokenBalance returns the balance of the account in the ledger of the token pair
+func (k Keeper) GetTokenBalance(ctx sdk.Context, erc20, account common.Address) sdk.Int {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetTokenBalanceKey(erc20, account))
+	if len(bz) == 0 {
+		return sdk.ZeroInt()
+	}
+	var balance sdk.Int
+	k.getAminoCodec().MustUnmarshalBinaryBare(bz, &balance)
+	return balance
+}
+
+// SetTokenBalance stores the balance of the account, a zero balance is deleted from the store
+func (k Keeper) SetTokenBalance(ctx sdk.Context, erc20, account common.Address, balance sdk.Int) {
+	store := ctx.KVStore(k.storeKey)
+	key := types.GetTokenBalanceKey(erc20, account)
+	if balance.IsZero() {
+		store.Delete(key)
+		return
+	}
+	store.Set(key, k.getAminoCodec().MustMarshalBinaryBare(balance))
+}
+
+// IterateTokenBalances iterates over all the non-zero balances in the ledger of the token pair
+func (k Keeper) IterateTokenBalances(ctx sdk.Context, erc20 common.Address, handlerFn func(account common.Address, balance sdk.Int) (stop bool)) {
+	prefix := types.GetTokenBalancePrefix(erc20)
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), prefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var balance sdk.Int
+		k.getAminoCodec().MustUnmarshalBinaryBare(iterator.Value(), &balance)
+		if handlerFn(common.BytesToAddress(iterator.Key()[len(prefix):]), balance) {
+			break
+		}
+	}
+}
+
+// GetTokenSupply returns the total supply of the token pair
+func (k Keeper) GetTokenSupply(ctx sdk.Context, erc20 common.Address) sdk.Int {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetTokenSupplyKey(erc20))
+	if len(bz) == 0 {
+		return sdk.ZeroInt()
+	}
+	var supply sdk.Int
+	k.getAminoCodec().MustUnmarshalBinaryBare(bz, &supply)
+	return supply
+}
+
+// SetTokenSupply stores the total supply of the token pair
+func (k Keeper) SetTokenSupply(ctx sdk.Context, erc20 common.Address, supply sdk.Int) {
+	ctx.KVStore(k.storeKey).Set(types.GetTokenSupplyKey(erc20), k.getAminoCodec().MustMarshalBinaryBare(supply))
+}
+
+// TransferToken moves the amount from one account to another in the ledger of the token pair.
+// The paired contracts are trusted to check that the transfer is authorized by the owner of the balance.
+func (k Keeper) TransferToken(ctx sdk.Context, tokenPair types.TokenPair, from, to common.Address, amount sdk.Int) error {
+	if amount.IsNil() || !amount.IsPositive() {
+		return types.ErrInvalidTokenAmount
+	}
+	erc20 := tokenPair.GetErc20()
+	fromBalance := k.GetTokenBalance(ctx, erc20, from)
+	if fromBalance.LT(amount) {
+		return sdkerrors.Wrapf(types.ErrInsufficientTokenBalance, "%s is smaller than %s", fromBalance, amount)
+	}
+	k.SetTokenBalance(ctx, erc20, from, fromBalance.Sub(amount))
+	k.SetTokenBalance(ctx, erc20, to, k.GetTokenBalance(ctx, erc20, to).Add(amount))
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeTokenTransfer,
+			sdk.NewAttribute(types.AttributeErc20Address, erc20.String()),
+			sdk.NewAttribute(types.AttributeFrom, from.String()),
+			sdk.NewAttribute(types.AttributeTo, to.String()),
+			sdk.NewAttribute(types.AttributeAmount, amount.String()),
+		),
+	)
+	return nil
+}
+
+// MintToken increases the balance of the account and the total supply of the token pair
+func (k Keeper) MintToken(ctx sdk.Context, tokenPair types.TokenPair, to common.Address, amount sdk.Int) error {
+	if amount.IsNil() || !amount.IsPositive() {
+		return types.ErrInvalidTokenAmount
+	}
+	erc20 := tokenPair.GetErc20()
+	supply := k.GetTokenSupply(ctx, erc20)
+	if supply.BigInt().Add(supply.BigInt(), amount.BigInt()).BitLen() > types.MaxTokenAmountBitLen {
+		return sdkerrors.Wrapf(types.ErrInvalidTokenAmount, "total supply overflows after minting %s", amount)
+	}
+	k.SetTokenBalance(ctx, erc20, to, k.GetTokenBalance(ctx, erc20, to).Add(amount))
+	k.SetTokenSupply(ctx, erc20, supply.Add(amount))
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeTokenMint,
+			sdk.NewAttribute(types.AttributeErc20Address, erc20.String()),
+			sdk.NewAttribute(types.AttributeTo, to.String()),
+			sdk.NewAttribute(types.AttributeAmount, amount.String()),
+		),
+	)
+	return nil
+}
+
+// BurnToken decreases the balance of the account and the total supply of the token pair
+func (k Keeper) BurnToken(ctx sdk.Context, tokenPair types.TokenPair, from common.Address, amount sdk.Int) error {
+	if amount.IsNil() || !amount.IsPositive() {
+		return types.ErrInvalidTokenAmount
+	}
+	erc20 := tokenPair.GetErc20()
+	fromBalance := k.GetTokenBalance(ctx, erc20, from)
+	if fromBalance.LT(amount) {
+		return sdkerrors.Wrapf(types.ErrInsufficientTokenBalance, "%s is smaller than %s", fromBalance, amount)
+	}
+	k.SetTokenBalance(ctx, erc20, from, fromBalance.Sub(amount))
+	k.SetTokenSupply(ctx, erc20, k.GetTokenSupply(ctx, erc20).Sub(amount))
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeTokenBurn,
+			sdk.NewAttribute(types.AttributeErc20Address, erc20.String()),
+			sdk.NewAttribute(types.AttributeFrom, from.String()),
+			sdk.NewAttribute(types.AttributeAmount, amount.String()),
+		),
+	)
+	return nil
+}
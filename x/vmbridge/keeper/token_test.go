@@ -0,0 +1,330 @@
+package keeper_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	"github.com/ethereum/go-ethereum/common"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	keeper2 "github.com/okex/exchain/x/vmbridge/keeper"
+	"github.com/okex/exchain/x/vmbridge/types"
+)
+
+func (suite *KeeperTestSuite) TestKeeper_RegisterTokenPair() {
+	otherErc20 := common.BigToAddress(big.NewInt(1))
+	otherCw20 := sdk.WasmAddress(common.BigToAddress(big.NewInt(2)).Bytes())
+
+	testCases := []struct {
+		msg       string
+		tokenPair types.TokenPair
+		error     error
+	}{
+		{"register a new pair", types.NewTokenPair(otherErc20, otherCw20), nil},
+		{"erc20 is paired", types.NewTokenPair(suite.evmContract, otherCw20), sdkerrors.Wrapf(types.ErrTokenPairExists, "erc20 contract %s", suite.evmContract)},
+		{"cw20 is paired", types.NewTokenPair(otherErc20, suite.wasmContract), sdkerrors.Wrapf(types.ErrTokenPairExists, "cw20 contract %s", suite.wasmContract)},
+		{"zero erc20", types.NewTokenPair(common.Address{}, otherCw20), errors.New("invalid request: erc20 address can not be zero")},
+	}
+	for _, tc := range testCases {
+		suite.Run(fmt.Sprintf("Case %s", tc.msg), func() {
+			suite.SetupTest()
+			suite.Require().NoError(suite.keeper.RegisterTokenPair(suite.ctx, types.NewTokenPair(suite.evmContract, suite.wasmContract)))
+
+			err := suite.keeper.RegisterTokenPair(suite.ctx, tc.tokenPair)
+			if tc.error != nil {
+				suite.Require().EqualError(err, tc.error.Error())
+				suite.Require().Equal(1, len(suite.keeper.GetTokenPairs(suite.ctx)))
+				return
+			}
+			suite.Require().NoError(err)
+			tokenPair, found := suite.keeper.GetTokenPairByCw20(suite.ctx, otherCw20)
+			suite.Require().True(found)
+			suite.Require().Equal(tc.tokenPair, tokenPair)
+			suite.Require().Equal(2, len(suite.keeper.GetTokenPairs(suite.ctx)))
+		})
+	}
+}
+
+func (suite *KeeperTestSuite) TestPrecompileToken() {
+	cmBridgePrecompileAddress := common.HexToAddress("0x0000000000000000000000000000000000000100")
+	erc20 := suite.evmContract
+	alice, bob := common.BigToAddress(big.NewInt(0xa)), common.BigToAddress(big.NewInt(0xb))
+	call := func(caller common.Address, value *big.Int, method string, args ...interface{}) ([]interface{}, error) {
+		ctx := suite.ctx
+		ctx.SetGasMeter(sdk.NewInfiniteGasMeter())
+		input, err := types.PreCompileABI.Pack(method, args...)
+		suite.Require().NoError(err)
+		_, result, err := suite.app.VMBridgeKeeper.CallEvm(ctx, caller, &cmBridgePrecompileAddress, value, input)
+		if err != nil {
+			return nil, err
+		}
+		return types.PreCompileABI.Methods[method].Outputs.Unpack(result.Ret)
+	}
+
+	testCases := []struct {
+		msg      string
+		malleate func()
+		error    error
+		alice    int64
+		bob      int64
+	}{
+		{
+			"mint",
+			func() {},
+			nil,
+			1000,
+			0,
+		},
+		{
+			"transfer",
+			func() {
+				_, err := call(erc20, big.NewInt(0), types.PrecompileTokenTransfer, alice, bob, big.NewInt(400))
+				suite.Require().NoError(err)
+			},
+			nil,
+			600,
+			400,
+		},
+		{
+			"burn",
+			func() {
+				_, err := call(erc20, big.NewInt(0), types.PrecompileTokenBurn, alice, big.NewInt(300))
+				suite.Require().NoError(err)
+			},
+			nil,
+			700,
+			0,
+		},
+		{
+			"transfer more than the balance",
+			func() {
+				_, err := call(erc20, big.NewInt(0), types.PrecompileTokenTransfer, alice, bob, big.NewInt(1001))
+				suite.Require().EqualError(err, sdkerrors.Wrapf(types.ErrInsufficientTokenBalance, "1000 is smaller than 1001").Error())
+			},
+			nil,
+			1000,
+			0,
+		},
+		{
+			"before the jupiter height",
+			func() {
+				tmtypes.UnittestOnlySetMilestoneJupiterHeight(suite.ctx.BlockHeight() + 1)
+				defer tmtypes.UnittestOnlySetMilestoneJupiterHeight(1)
+				_, err := call(erc20, big.NewInt(0), types.PrecompileTokenMint, bob, big.NewInt(1))
+				suite.Require().Error(err)
+			},
+			nil,
+			1000,
+			0,
+		},
+		{
+			"caller is not paired",
+			func() {
+				_, err := call(bob, big.NewInt(0), types.PrecompileTokenMint, bob, big.NewInt(1))
+				suite.Require().EqualError(err, sdkerrors.Wrapf(types.ErrTokenPairNotFound, "erc20 contract %s", bob).Error())
+			},
+			nil,
+			1000,
+			0,
+		},
+		{
+			"send token",
+			func() {
+				suite.SetAccountCoins(sdk.AccAddress(erc20.Bytes()), sdk.NewInt(1))
+				_, err := call(erc20, big.NewInt(1), types.PrecompileTokenMint, bob, big.NewInt(1))
+				suite.Require().EqualError(err, "tokenMint can not be send token")
+			},
+			nil,
+			1000,
+			0,
+		},
+	}
+	for _, tc := range testCases {
+		suite.Run(fmt.Sprintf("Case %s", tc.msg), func() {
+			suite.SetupTest()
+			suite.Require().NoError(suite.keeper.RegisterTokenPair(suite.ctx, types.NewTokenPair(erc20, suite.wasmContract)))
+			_, err := call(erc20, big.NewInt(0), types.PrecompileTokenMint, alice, big.NewInt(1000))
+			suite.Require().NoError(err)
+			tc.malleate()
+
+			for account, expect := range map[common.Address]int64{alice: tc.alice, bob: tc.bob} {
+				ret, err := call(erc20, big.NewInt(0), types.PrecompileTokenBalanceOf, account)
+				suite.Require().NoError(err)
+				suite.Require().Equal(big.NewInt(expect).String(), ret[0].(*big.Int).String())
+			}
+			ret, err := call(erc20, big.NewInt(0), types.PrecompileTokenTotalSupply)
+			suite.Require().NoError(err)
+			suite.Require().Equal(big.NewInt(tc.alice+tc.bob).String(), ret[0].(*big.Int).String())
+			suite.requireTokenSupplyInvariant()
+		})
+	}
+}
+
+func (suite *KeeperTestSuite) TestTokenMessenger() {
+	erc20 := suite.evmContract
+	alice := sdk.WasmAddress(common.BigToAddress(big.NewInt(0xa)).Bytes())
+	bob := sdk.WasmAddress(common.BigToAddress(big.NewInt(0xb)).Bytes())
+	var next *nextMessenger
+	var contract sdk.WasmAddress
+	var msg wasmvmtypes.CosmosMsg
+	custom := func(format string, args ...interface{}) wasmvmtypes.CosmosMsg {
+		return wasmvmtypes.CosmosMsg{Custom: json.RawMessage(fmt.Sprintf(format, args...))}
+	}
+
+	testCases := []struct {
+		msg      string
+		malleate func()
+		error    error
+		alice    int64
+		bob      int64
+		next     bool
+	}{
+		{
+			"mint",
+			func() {
+				msg = custom("{\"token_mint\":{\"to\":\"%s\",\"amount\":\"100\"}}", bob)
+			},
+			nil,
+			1000,
+			100,
+			false,
+		},
+		{
+			"transfer",
+			func() {
+				msg = custom("{\"token_transfer\":{\"from\":\"%s\",\"to\":\"%s\",\"amount\":\"400\"}}", alice, bob)
+			},
+			nil,
+			600,
+			400,
+			false,
+		},
+		{
+			"burn",
+			func() {
+				msg = custom("{\"token_burn\":{\"from\":\"%s\",\"amount\":\"1000\"}}", alice)
+			},
+			nil,
+			0,
+			0,
+			false,
+		},
+		{
+			"burn more than the balance",
+			func() {
+				msg = custom("{\"token_burn\":{\"from\":\"%s\",\"amount\":\"1001\"}}", alice)
+			},
+			sdkerrors.Wrapf(types.ErrInsufficientTokenBalance, "1000 is smaller than 1001"),
+			1000,
+			0,
+			false,
+		},
+		{
+			"contract is not paired",
+			func() {
+				contract = bob
+				msg = custom("{\"token_mint\":{\"to\":\"%s\",\"amount\":\"100\"}}", bob)
+			},
+			sdkerrors.Wrapf(types.ErrTokenPairNotFound, "cw20 contract %s", bob),
+			1000,
+			0,
+			false,
+		},
+		{
+			"other custom message",
+			func() {
+				msg = custom("{\"unknown\":{}}")
+			},
+			nil,
+			1000,
+			0,
+			true,
+		},
+		{
+			"bank message",
+			func() {
+				msg = wasmvmtypes.CosmosMsg{Bank: &wasmvmtypes.BankMsg{}}
+			},
+			nil,
+			1000,
+			0,
+			true,
+		},
+	}
+	for _, tc := range testCases {
+		suite.Run(fmt.Sprintf("Case %s", tc.msg), func() {
+			suite.SetupTest()
+			next = &nextMessenger{}
+			contract = suite.wasmContract
+			suite.Require().NoError(suite.keeper.RegisterTokenPair(suite.ctx, types.NewTokenPair(erc20, suite.wasmContract)))
+			tokenPair, _ := suite.keeper.GetTokenPair(suite.ctx, erc20)
+			suite.Require().NoError(suite.keeper.MintToken(suite.ctx, tokenPair, common.BytesToAddress(alice.Bytes()), sdk.NewInt(1000)))
+			tc.malleate()
+
+			events, _, err := keeper2.RegisterTokenMessenger(*suite.keeper)(next).DispatchMsg(suite.ctx, contract, "", msg)
+			if tc.error != nil {
+				suite.Require().EqualError(err, tc.error.Error())
+			} else {
+				suite.Require().NoError(err)
+				suite.Require().Equal(!tc.next, len(events) > 0)
+			}
+			suite.Require().Equal(tc.next, next.called)
+
+			for account, expect := range map[string]int64{alice.String(): tc.alice, bob.String(): tc.bob} {
+				query := fmt.Sprintf("{\"token_balance\":{\"contract\":\"%s\",\"address\":\"%s\"}}", suite.wasmContract, account)
+				ret, err := keeper2.RegisterCustomQuerier(*suite.keeper).Custom(suite.ctx, []byte(query))
+				suite.Require().NoError(err)
+				var response types.TokenBalanceQueryResponse
+				suite.Require().NoError(json.Unmarshal(ret, &response))
+				suite.Require().Equal(sdk.NewInt(expect), response.Balance)
+			}
+			query := fmt.Sprintf("{\"token_supply\":{\"contract\":\"%s\"}}", suite.wasmContract)
+			ret, err := keeper2.RegisterCustomQuerier(*suite.keeper).Custom(suite.ctx, []byte(query))
+			suite.Require().NoError(err)
+			var response types.TokenSupplyQueryResponse
+			suite.Require().NoError(json.Unmarshal(ret, &response))
+			suite.Require().Equal(sdk.NewInt(tc.alice+tc.bob), response.TotalSupply)
+			suite.requireTokenSupplyInvariant()
+		})
+	}
+}
+
+func (suite *KeeperTestSuite) TestTokenSupplyInvariant() {
+	erc20 := suite.evmContract
+	suite.Require().NoError(suite.keeper.RegisterTokenPair(suite.ctx, types.NewTokenPair(erc20, suite.wasmContract)))
+	tokenPair, _ := suite.keeper.GetTokenPair(suite.ctx, erc20)
+	suite.Require().NoError(suite.keeper.MintToken(suite.ctx, tokenPair, erc20, sdk.NewInt(1000)))
+	suite.requireTokenSupplyInvariant()
+
+	suite.keeper.SetTokenSupply(suite.ctx, erc20, sdk.NewInt(999))
+	ir := invariantRegistry{}
+	keeper2.RegisterInvariants(ir, *suite.keeper)
+	_, broken := ir["token-supply"](suite.ctx)
+	suite.Require().True(broken)
+}
+
+func (suite *KeeperTestSuite) requireTokenSupplyInvariant() {
+	ir := invariantRegistry{}
+	keeper2.RegisterInvariants(ir, *suite.keeper)
+	msg, broken := ir["token-supply"](suite.ctx)
+	suite.Require().False(broken, msg)
+}
+
+type invariantRegistry map[string]sdk.Invariant
+
+func (ir invariantRegistry) RegisterRoute(_, route string, invar sdk.Invariant) {
+	ir[route] = invar
+}
+
+type nextMessenger struct {
+	called bool
+}
+
+func (m *nextMessenger) DispatchMsg(sdk.Context, sdk.WasmAddress, string, wasmvmtypes.CosmosMsg) ([]sdk.Event, [][]byte, error) {
+	m.called = true
+	return nil, nil, nil
+}
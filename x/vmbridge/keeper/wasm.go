@@ -2,8 +2,11 @@ package keeper
 
 import (
 	"context"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/okex/exchain/libs/cosmos-sdk/codec"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
@@ -109,6 +112,153 @@ func sendToEvmEncoder(cdc *codec.ProtoCodec) wasm.CustomEncoder {
 	}
 }
 
+// RegisterCustomQuerier needs to be registered in app setup to let wasm contracts query evm contracts and token pairs
+func RegisterCustomQuerier(k Keeper) *wasm.QueryPlugins {
+	return &wasm.QueryPlugins{
+		Custom: customQuerier(k),
+	}
+}
+
+// checkTokenQueryHeight rejects the token queries before the token pairs are supported
+func checkTokenQueryHeight(ctx sdk.Context) error {
+	if !tmtypes.HigherThanJupiter(ctx.BlockHeight()) {
+		errMsg := fmt.Sprintf("vmbridge not supprt at height %d", ctx.BlockHeight())
+		return sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, errMsg)
+	}
+	return nil
+}
+
+func customQuerier(k Keeper) wasm.CustomQuerier {
+	return func(ctx sdk.Context, request json.RawMessage) ([]byte, error) {
+		var query types.CustomQuery
+		if err := json.Unmarshal(request, &query); err != nil {
+			return nil, sdkerrors.Wrap(types.ErrInvalidEvmQuery, err.Error())
+		}
+
+		switch {
+		case query.CallEvm != nil:
+			ret, err := k.QueryEvm(ctx, query.CallEvm.Caller, query.CallEvm.Contract, query.CallEvm.Calldata)
+			if err != nil {
+				return nil, err
+			}
+			return json.Marshal(types.CallEvmQueryResponse{Data: hex.EncodeToString(ret)})
+		case query.TokenBalance != nil:
+			if err := checkTokenQueryHeight(ctx); err != nil {
+				return nil, err
+			}
+			tokenPair, err := k.getTokenPairOfCw20(ctx, query.TokenBalance.Contract)
+			if err != nil {
+				return nil, err
+			}
+			account, err := sdk.WasmAddressFromBech32(query.TokenBalance.Address)
+			if err != nil {
+				return nil, err
+			}
+			balance := k.GetTokenBalance(ctx, tokenPair.GetErc20(), common.BytesToAddress(account.Bytes()))
+			return json.Marshal(types.TokenBalanceQueryResponse{Balance: balance})
+		case query.TokenSupply != nil:
+			if err := checkTokenQueryHeight(ctx); err != nil {
+				return nil, err
+			}
+			tokenPair, err := k.getTokenPairOfCw20(ctx, query.TokenSupply.Contract)
+			if err != nil {
+				return nil, err
+			}
+			return json.Marshal(types.TokenSupplyQueryResponse{TotalSupply: k.GetTokenSupply(ctx, tokenPair.GetErc20())})
+		default:
+			return nil, sdkerrors.Wrap(types.ErrInvalidEvmQuery, "unknown custom query variant")
+		}
+	}
+}
+
+func (k Keeper) getTokenPairOfCw20(ctx sdk.Context, contract string) (types.TokenPair, error) {
+	cw20, err := sdk.WasmAddressFromBech32(contract)
+	if err != nil {
+		return types.TokenPair{}, err
+	}
+	tokenPair, found := k.GetTokenPairByCw20(ctx, cw20)
+	if !found {
+		return types.TokenPair{}, sdkerrors.Wrapf(types.ErrTokenPairNotFound, "cw20 contract %s", contract)
+	}
+	return tokenPair, nil
+}
+
+// RegisterTokenMessenger needs to be registered in app setup to let the paired cw20 contracts update their ledgers
+func RegisterTokenMessenger(k Keeper) func(old wasm.Messenger) wasm.Messenger {
+	return func(old wasm.Messenger) wasm.Messenger {
+		return tokenMessenger{Keeper: k, next: old}
+	}
+}
+
+// tokenMessenger handles the token messages of the cw20 contracts and passes the others on to the next handler
+type tokenMessenger struct {
+	Keeper
+	next wasm.Messenger
+}
+
+func (m tokenMessenger) DispatchMsg(ctx sdk.Context, contractAddr sdk.WasmAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) ([]sdk.Event, [][]byte, error) {
+	if msg.Custom != nil {
+		var tokenMsg types.TokenMsg
+		if err := json.Unmarshal(msg.Custom, &tokenMsg); err == nil && !tokenMsg.IsEmpty() {
+			ctx.SetEventManager(sdk.NewEventManager())
+			if err := m.handleTokenMsg(ctx, contractAddr, tokenMsg); err != nil {
+				return nil, nil, err
+			}
+			return ctx.EventManager().Events(), nil, nil
+		}
+	}
+	return m.next.DispatchMsg(ctx, contractAddr, contractIBCPortID, msg)
+}
+
+func (m tokenMessenger) handleTokenMsg(ctx sdk.Context, contractAddr sdk.WasmAddress, msg types.TokenMsg) error {
+	if !tmtypes.HigherThanJupiter(ctx.BlockHeight()) {
+		errMsg := fmt.Sprintf("vmbridge not supprt at height %d", ctx.BlockHeight())
+		return sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, errMsg)
+	}
+	params := m.wasmKeeper.GetParams(ctx)
+	if !params.VmbridgeEnable {
+		return types.ErrVMBridgeEnable
+	}
+	tokenPair, found := m.GetTokenPairByCw20(ctx, contractAddr)
+	if !found {
+		return sdkerrors.Wrapf(types.ErrTokenPairNotFound, "cw20 contract %s", contractAddr)
+	}
+
+	switch {
+	case msg.TokenTransfer != nil:
+		from, err := toLedgerAddress(msg.TokenTransfer.From)
+		if err != nil {
+			return err
+		}
+		to, err := toLedgerAddress(msg.TokenTransfer.To)
+		if err != nil {
+			return err
+		}
+		return m.TransferToken(ctx, tokenPair, from, to, msg.TokenTransfer.Amount)
+	case msg.TokenMint != nil:
+		to, err := toLedgerAddress(msg.TokenMint.To)
+		if err != nil {
+			return err
+		}
+		return m.MintToken(ctx, tokenPair, to, msg.TokenMint.Amount)
+	default:
+		from, err := toLedgerAddress(msg.TokenBurn.From)
+		if err != nil {
+			return err
+		}
+		return m.BurnToken(ctx, tokenPair, from, msg.TokenBurn.Amount)
+	}
+}
+
+// toLedgerAddress converts a wasm or 0x address to the account address of the ledger
+func toLedgerAddress(addr string) (common.Address, error) {
+	wasmAddr, err := sdk.WasmAddressFromBech32(addr)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return common.BytesToAddress(wasmAddr.Bytes()), nil
+}
+
 type msgServer struct {
 	Keeper
 }
@@ -0,0 +1,28 @@
+package vmbridge
+
+import (
+	"fmt"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	"github.com/okex/exchain/x/common"
+	govTypes "github.com/okex/exchain/x/gov/types"
+	"github.com/okex/exchain/x/vmbridge/types"
+)
+
+// NewProposalHandler handles "gov" type message in "vmbridge"
+func NewProposalHandler(k *Keeper) govTypes.Handler {
+	return func(ctx sdk.Context, proposal *govTypes.Proposal) (err sdk.Error) {
+		if !tmtypes.HigherThanJupiter(ctx.BlockHeight()) {
+			return govTypes.ErrInvalidProposalContent(fmt.Sprintf("vmbridge not supprt at height %d", ctx.BlockHeight()))
+		}
+		switch content := proposal.Content.(type) {
+		case types.RegisterTokenPairProposal:
+			return k.RegisterTokenPair(ctx, content.TokenPair)
+		case types.RegisterNFTPairProposal:
+			return k.RegisterNFTPair(ctx, content.NFTPair)
+		default:
+			return common.ErrUnknownProposalType(types.ModuleName, content.ProposalType())
+		}
+	}
+}
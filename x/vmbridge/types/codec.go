@@ -1,11 +1,32 @@
 package types
 
 import (
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
 	interfacetypes "github.com/okex/exchain/libs/cosmos-sdk/codec/types"
 	txmsg "github.com/okex/exchain/libs/cosmos-sdk/types/ibc-adapter"
 	"github.com/okex/exchain/libs/cosmos-sdk/types/msgservice"
 )
 
+// ModuleCdc defines the vmbridge module's amino codec
+var ModuleCdc = codec.New()
+
+const (
+	// Amino names
+	registerTokenPairProposalName = "okexchain/vmbridge/RegisterTokenPairProposal"
+	registerNFTPairProposalName   = "okexchain/vmbridge/RegisterNFTPairProposal"
+)
+
+func init() {
+	RegisterCodec(ModuleCdc)
+	ModuleCdc.Seal()
+}
+
+// RegisterCodec registers the amino types of the vmbridge module
+func RegisterCodec(cdc *codec.Codec) {
+	cdc.RegisterConcrete(RegisterTokenPairProposal{}, registerTokenPairProposalName, nil)
+	cdc.RegisterConcrete(RegisterNFTPairProposal{}, registerNFTPairProposalName, nil)
+}
+
 func RegisterInterface(registry interfacetypes.InterfaceRegistry) {
 	registry.RegisterImplementations(
 		(*txmsg.Msg)(nil),
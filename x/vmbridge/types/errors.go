@@ -23,6 +23,17 @@ var (
 	ErrVMBridgeEnable = sdkerrors.Register(ModuleName, 8, "the vmbridge is disable")
 	ErrIsNotOKCAddr   = sdkerrors.Register(ModuleName, 9, "the address prefix must be ex")
 	ErrIsNotETHAddr   = sdkerrors.Register(ModuleName, 10, "the address prefix must be 0x")
+
+	ErrInvalidEvmQuery = sdkerrors.Register(ModuleName, 12, "invalid evm query")
+
+	ErrTokenPairExists          = sdkerrors.Register(ModuleName, 13, "the token pair is already registered")
+	ErrTokenPairNotFound        = sdkerrors.Register(ModuleName, 14, "the token pair is not registered")
+	ErrInsufficientTokenBalance = sdkerrors.Register(ModuleName, 15, "insufficient token balance")
+	ErrInvalidTokenAmount       = sdkerrors.Register(ModuleName, 16, "the token amount must be positive")
+
+	ErrNFTPairExists   = sdkerrors.Register(ModuleName, 17, "the nft pair is already registered")
+	ErrNFTPairNotFound = sdkerrors.Register(ModuleName, 18, "the nft pair is not registered")
+	ErrNFTNotOwner     = sdkerrors.Register(ModuleName, 19, "the account is not the owner of the nft")
 )
 
 func ErrMsgSendToEvm(str string) sdk.EnvelopedErr {
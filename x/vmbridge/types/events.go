@@ -5,4 +5,20 @@ const (
 	EventTypeEvmCallWasm = "evm_call_wasm"
 	EventTypeEvmSendWasm = "evm_send_wasm"
 	AttributeResult      = "result"
+
+	EventTypeRegisterTokenPair = "register_token_pair"
+	EventTypeTokenTransfer     = "token_transfer"
+	EventTypeTokenMint         = "token_mint"
+	EventTypeTokenBurn         = "token_burn"
+	AttributeErc20Address      = "erc20_address"
+	AttributeCw20Address       = "cw20_address"
+	AttributeFrom              = "from"
+	AttributeTo                = "to"
+	AttributeAmount            = "amount"
+
+	EventTypeRegisterNFTPair = "register_nft_pair"
+	EventTypeNFTTransfer     = "nft_transfer"
+	AttributeErc721Address   = "erc721_address"
+	AttributeClassID         = "class_id"
+	AttributeTokenID         = "token_id"
 )
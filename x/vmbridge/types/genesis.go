@@ -0,0 +1,87 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// GenesisState defines the module's genesis state.
+type GenesisState struct {
+	// token pairs registered by governance
+	TokenPairs []TokenPair `json:"token_pairs"`
+	// balances of the ledgers of the token pairs, the total supplies are derived from them
+	Balances []TokenBalance `json:"balances"`
+	// nft pairs registered by governance
+	NFTPairs []NFTPair `json:"nft_pairs"`
+}
+
+// NewGenesisState creates a new genesis state.
+func NewGenesisState(tokenPairs []TokenPair, balances []TokenBalance, nftPairs []NFTPair) GenesisState {
+	return GenesisState{
+		TokenPairs: tokenPairs,
+		Balances:   balances,
+		NFTPairs:   nftPairs,
+	}
+}
+
+// DefaultGenesisState sets default vmbridge genesis state without token pairs.
+func DefaultGenesisState() GenesisState {
+	return GenesisState{}
+}
+
+// Validate performs basic genesis state validation returning an error upon any
+// failure.
+func (gs GenesisState) Validate() error {
+	seenErc20 := make(map[common.Address]bool)
+	seenCw20 := make(map[string]bool)
+	for _, tp := range gs.TokenPairs {
+		if err := tp.Validate(); err != nil {
+			return err
+		}
+		if seenErc20[tp.GetErc20()] {
+			return fmt.Errorf("erc20 contract duplicated on genesis '%s'", tp.Erc20Address)
+		}
+		if seenCw20[tp.GetCw20().String()] {
+			return fmt.Errorf("cw20 contract duplicated on genesis '%s'", tp.Cw20Address)
+		}
+		seenErc20[tp.GetErc20()] = true
+		seenCw20[tp.GetCw20().String()] = true
+	}
+
+	seenBalance := make(map[string]bool)
+	for _, balance := range gs.Balances {
+		if !common.IsHexAddress(balance.Erc20Address) || !seenErc20[common.HexToAddress(balance.Erc20Address)] {
+			return fmt.Errorf("balance of unregistered erc20 contract on genesis '%s'", balance.Erc20Address)
+		}
+		if !common.IsHexAddress(balance.Address) {
+			return fmt.Errorf("invalid balance address on genesis '%s'", balance.Address)
+		}
+		if balance.Amount.IsNil() || !balance.Amount.IsPositive() {
+			return fmt.Errorf("balance amount must be positive on genesis, got %s", balance.Amount)
+		}
+		key := strings.ToLower(balance.Erc20Address + balance.Address)
+		if seenBalance[key] {
+			return fmt.Errorf("balance duplicated on genesis '%s' of '%s'", balance.Address, balance.Erc20Address)
+		}
+		seenBalance[key] = true
+	}
+
+	seenErc721 := make(map[common.Address]bool)
+	seenClass := make(map[string]bool)
+	for _, np := range gs.NFTPairs {
+		if err := np.Validate(); err != nil {
+			return err
+		}
+		if seenErc721[np.GetErc721()] {
+			return fmt.Errorf("erc721 contract duplicated on genesis '%s'", np.Erc721Address)
+		}
+		if seenClass[np.ClassID] {
+			return fmt.Errorf("class duplicated on genesis '%s'", np.ClassID)
+		}
+		seenErc721[np.GetErc721()] = true
+		seenClass[np.ClassID] = true
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenesisState_Validate(t *testing.T) {
+	erc20 := common.HexToAddress("0x5A8D648DEE57b2fc90D98DC17fa887159b69638b")
+	cw20 := sdk.WasmAddress(common.HexToAddress("0xbbE4733d85bc2b90682147779DA49caB38C0aA1F").Bytes())
+	account := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	pair := NewTokenPair(erc20, cw20)
+	nftPair := NewNFTPair(erc20, "kitty")
+
+	testCases := []struct {
+		name    string
+		genesis GenesisState
+		expPass bool
+	}{
+		{"default", DefaultGenesisState(), true},
+		{"pair with balance", NewGenesisState([]TokenPair{pair}, []TokenBalance{NewTokenBalance(erc20, account, sdk.NewInt(10))}, nil), true},
+		{"zero erc20", NewGenesisState([]TokenPair{NewTokenPair(common.Address{}, cw20)}, nil, nil), false},
+		{"same erc20 and cw20", NewGenesisState([]TokenPair{NewTokenPair(erc20, sdk.WasmAddress(erc20.Bytes()))}, nil, nil), false},
+		{"invalid cw20", NewGenesisState([]TokenPair{{Erc20Address: erc20.String(), Cw20Address: "ex1invalid"}}, nil, nil), false},
+		{"duplicated erc20", NewGenesisState([]TokenPair{pair, NewTokenPair(erc20, sdk.WasmAddress(account.Bytes()))}, nil, nil), false},
+		{"duplicated cw20", NewGenesisState([]TokenPair{pair, NewTokenPair(account, cw20)}, nil, nil), false},
+		{"balance of unregistered erc20", NewGenesisState(nil, []TokenBalance{NewTokenBalance(erc20, account, sdk.NewInt(10))}, nil), false},
+		{"zero balance", NewGenesisState([]TokenPair{pair}, []TokenBalance{NewTokenBalance(erc20, account, sdk.ZeroInt())}, nil), false},
+		{"nft pair", NewGenesisState([]TokenPair{pair}, nil, []NFTPair{nftPair}), true},
+		{"invalid class", NewGenesisState(nil, nil, []NFTPair{NewNFTPair(erc20, "1")}), false},
+		{"duplicated erc721", NewGenesisState(nil, nil, []NFTPair{nftPair, NewNFTPair(erc20, "puppy")}), false},
+		{"duplicated class", NewGenesisState(nil, nil, []NFTPair{nftPair, NewNFTPair(account, "kitty")}), false},
+		{"duplicated balance", NewGenesisState([]TokenPair{pair}, []TokenBalance{NewTokenBalance(erc20, account, sdk.NewInt(10)), NewTokenBalance(erc20, account, sdk.NewInt(1))}, nil), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.genesis.Validate()
+			if tc.expPass {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
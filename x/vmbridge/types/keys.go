@@ -1,5 +1,10 @@
 package types
 
+import (
+	"github.com/ethereum/go-ethereum/common"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
 const (
 	// ModuleName is the name of the contract module
 	ModuleName = "vmbridge"
@@ -15,4 +20,65 @@ const (
 
 	// RouterKey is the msg router key for the wasm module
 	RouterKey = ModuleName
+
+	QueryTokenPairs   = "token-pairs"
+	QueryTokenPair    = "token-pair"
+	QueryTokenBalance = "token-balance"
+	QueryNFTPairs     = "nft-pairs"
+	QueryNFTPair      = "nft-pair"
+)
+
+// prefix bytes for the vmbridge persistent store
+const (
+	prefixTokenPair = iota + 1
+	prefixCw20Index
+	prefixTokenBalance
+	prefixTokenSupply
+	prefixNFTPair
+	prefixClassIndex
+)
+
+// KVStore key prefixes
+var (
+	KeyPrefixTokenPair    = []byte{prefixTokenPair}
+	KeyPrefixCw20Index    = []byte{prefixCw20Index}
+	KeyPrefixTokenBalance = []byte{prefixTokenBalance}
+	KeyPrefixTokenSupply  = []byte{prefixTokenSupply}
+	KeyPrefixNFTPair      = []byte{prefixNFTPair}
+	KeyPrefixClassIndex   = []byte{prefixClassIndex}
 )
+
+// GetTokenPairKey returns the key of the token pair, which is indexed by its erc20 contract
+func GetTokenPairKey(erc20 common.Address) []byte {
+	return append(KeyPrefixTokenPair, erc20.Bytes()...)
+}
+
+// GetCw20IndexKey returns the key of the erc20 contract that the cw20 contract is paired with
+func GetCw20IndexKey(cw20 sdk.WasmAddress) []byte {
+	return append(KeyPrefixCw20Index, cw20.Bytes()...)
+}
+
+// GetTokenBalancePrefix returns the prefix of all the balances of the token pair
+func GetTokenBalancePrefix(erc20 common.Address) []byte {
+	return append(KeyPrefixTokenBalance, erc20.Bytes()...)
+}
+
+// GetTokenBalanceKey returns the key of the balance of the account in the token pair
+func GetTokenBalanceKey(erc20 common.Address, account common.Address) []byte {
+	return append(GetTokenBalancePrefix(erc20), account.Bytes()...)
+}
+
+// GetTokenSupplyKey returns the key of the total supply of the token pair
+func GetTokenSupplyKey(erc20 common.Address) []byte {
+	return append(KeyPrefixTokenSupply, erc20.Bytes()...)
+}
+
+// GetNFTPairKey returns the key of the nft pair, which is indexed by its erc721 contract
+func GetNFTPairKey(erc721 common.Address) []byte {
+	return append(KeyPrefixNFTPair, erc721.Bytes()...)
+}
+
+// GetClassIndexKey returns the key of the erc721 contract that the class is paired with
+func GetClassIndexKey(classID string) []byte {
+	return append(KeyPrefixClassIndex, []byte(classID)...)
+}
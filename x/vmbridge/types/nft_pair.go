@@ -0,0 +1,52 @@
+package types
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	nfttypes "github.com/okex/exchain/x/nft/types"
+)
+
+// NFTPair pairs an erc721 contract with a class of the nft module, the erc721 contract is a wrapper whose
+// ownership and transfers are kept by the nft module, so the nfts are visible from both the evm and wasm.
+// The uint256 token ids of the erc721 contract are the decimal ids of the nfts of the class.
+// The nft module trusts the paired contract to authorize the transfers of the owners, it is registered by governance.
+type NFTPair struct {
+	Erc721Address string `json:"erc721_address" yaml:"erc721_address"`
+	ClassID       string `json:"class_id" yaml:"class_id"`
+}
+
+// NewNFTPair creates a new instance of NFTPair
+func NewNFTPair(erc721 common.Address, classID string) NFTPair {
+	return NFTPair{
+		Erc721Address: erc721.String(),
+		ClassID:       classID,
+	}
+}
+
+// GetErc721 returns the erc721 contract of the nft pair
+func (np NFTPair) GetErc721() common.Address {
+	return common.HexToAddress(np.Erc721Address)
+}
+
+// Validate checks the contract address and the class of the nft pair
+func (np NFTPair) Validate() error {
+	if !common.IsHexAddress(np.Erc721Address) {
+		return fmt.Errorf("invalid erc721 address: %s", np.Erc721Address)
+	}
+	if np.GetErc721() == (common.Address{}) {
+		return fmt.Errorf("erc721 address can not be zero")
+	}
+	return nfttypes.ValidateClassID(np.ClassID)
+}
+
+// String returns a human readable string representation of NFTPair
+func (np NFTPair) String() string {
+	return fmt.Sprintf("erc721: %s, class: %s", np.Erc721Address, np.ClassID)
+}
+
+// TokenIDToNFTID converts the uint256 token id of the erc721 contract to the id of the nft
+func TokenIDToNFTID(tokenID *big.Int) string {
+	return tokenID.String()
+}
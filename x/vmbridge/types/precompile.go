@@ -5,12 +5,26 @@ import (
 	_ "embed"
 	"fmt"
 	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
 	evm_types "github.com/okex/exchain/x/evm/types"
+	"math/big"
 )
 
 const (
 	PrecompileCallToWasm  = "callToWasm"
 	PrecompileQueryToWasm = "queryToWasm"
+	PrecompileQueryTwap   = "queryTwapPrice"
+
+	PrecompileTokenTransfer    = "tokenTransfer"
+	PrecompileTokenMint        = "tokenMint"
+	PrecompileTokenBurn        = "tokenBurn"
+	PrecompileTokenBalanceOf   = "tokenBalanceOf"
+	PrecompileTokenTotalSupply = "tokenTotalSupply"
+
+	PrecompileNFTTransfer  = "nftTransfer"
+	PrecompileNFTOwnerOf   = "nftOwnerOf"
+	PrecompileNFTBalanceOf = "nftBalanceOf"
+	PrecompileNFTTokenURI  = "nftTokenURI"
 )
 
 var (
@@ -24,6 +38,25 @@ func init() {
 	PreCompileABI = GetPreCompileABI(preCompileJson)
 }
 
+// IsPrecompileTokenMethod returns true if the method is one of the token pair methods of the precompile
+func IsPrecompileTokenMethod(name string) bool {
+	switch name {
+	case PrecompileTokenTransfer, PrecompileTokenMint, PrecompileTokenBurn,
+		PrecompileTokenBalanceOf, PrecompileTokenTotalSupply:
+		return true
+	}
+	return false
+}
+
+// IsPrecompileNFTMethod returns true if the method is one of the nft pair methods of the precompile
+func IsPrecompileNFTMethod(name string) bool {
+	switch name {
+	case PrecompileNFTTransfer, PrecompileNFTOwnerOf, PrecompileNFTBalanceOf, PrecompileNFTTokenURI:
+		return true
+	}
+	return false
+}
+
 func GetPreCompileABI(data []byte) evm_types.ABI {
 	ret, err := abi.JSON(bytes.NewReader(data))
 	if err != nil {
@@ -80,6 +113,180 @@ func EncodePrecompileQueryToWasmOutput(response string) ([]byte, error) {
 	return PreCompileABI.EncodeOutput(PrecompileQueryToWasm, []byte(response))
 }
 
+func DecodePrecompileQueryTwapInput(input []byte) (token, quoteToken string, window int64, err error) {
+	if !PreCompileABI.IsMatchFunction(PrecompileQueryTwap, input) {
+		return "", "", 0, fmt.Errorf("decode precomplie query twap input :  input sginature is not %s", PrecompileQueryTwap)
+	}
+	unpacked, err := PreCompileABI.DecodeInputParam(PrecompileQueryTwap, input)
+	if err != nil {
+		return "", "", 0, fmt.Errorf("decode precomplie query twap input unpack err :  %s", err)
+	}
+	if len(unpacked) != 3 {
+		return "", "", 0, fmt.Errorf("decode precomplie query twap input unpack err :  unpack data len expect 3 but got %v", len(unpacked))
+	}
+	token, ok := unpacked[0].(string)
+	if !ok {
+		return "", "", 0, fmt.Errorf("decode precomplie query twap input unpack err : token is not type of string")
+	}
+	quoteToken, ok = unpacked[1].(string)
+	if !ok {
+		return "", "", 0, fmt.Errorf("decode precomplie query twap input unpack err : quoteToken is not type of string")
+	}
+	windowInt, ok := unpacked[2].(*big.Int)
+	if !ok || !windowInt.IsInt64() {
+		return "", "", 0, fmt.Errorf("decode precomplie query twap input unpack err : window is not a valid int64")
+	}
+	return token, quoteToken, windowInt.Int64(), nil
+}
+
+// EncodePrecompileQueryTwapOutput encodes the price, which is a sdk.Dec in its 18 decimals integer form
+func EncodePrecompileQueryTwapOutput(price *big.Int) ([]byte, error) {
+	method, ok := PreCompileABI.Methods[PrecompileQueryTwap]
+	if !ok {
+		return nil, fmt.Errorf("method %s is not exist", PrecompileQueryTwap)
+	}
+	return method.Outputs.Pack(price)
+}
+
+func DecodePrecompileTokenTransferInput(input []byte) (from, to common.Address, amount *big.Int, err error) {
+	if !PreCompileABI.IsMatchFunction(PrecompileTokenTransfer, input) {
+		return from, to, nil, fmt.Errorf("decode precomplie token transfer input :  input sginature is not %s", PrecompileTokenTransfer)
+	}
+	unpacked, err := PreCompileABI.DecodeInputParam(PrecompileTokenTransfer, input)
+	if err != nil {
+		return from, to, nil, fmt.Errorf("decode precomplie token transfer input unpack err :  %s", err)
+	}
+	if len(unpacked) != 3 {
+		return from, to, nil, fmt.Errorf("decode precomplie token transfer input unpack err :  unpack data len expect 3 but got %v", len(unpacked))
+	}
+	from, ok := unpacked[0].(common.Address)
+	if !ok {
+		return from, to, nil, fmt.Errorf("decode precomplie token transfer input unpack err : from is not type of address")
+	}
+	to, ok = unpacked[1].(common.Address)
+	if !ok {
+		return from, to, nil, fmt.Errorf("decode precomplie token transfer input unpack err : to is not type of address")
+	}
+	amount, ok = unpacked[2].(*big.Int)
+	if !ok || amount.BitLen() > MaxTokenAmountBitLen {
+		return from, to, nil, fmt.Errorf("decode precomplie token transfer input unpack err : amount is not a valid uint256")
+	}
+	return from, to, amount, nil
+}
+
+// DecodePrecompileTokenAmountInput decodes the input of tokenMint and tokenBurn, which are both (address, uint256)
+func DecodePrecompileTokenAmountInput(method string, input []byte) (account common.Address, amount *big.Int, err error) {
+	if !PreCompileABI.IsMatchFunction(method, input) {
+		return account, nil, fmt.Errorf("decode precomplie token input :  input sginature is not %s", method)
+	}
+	unpacked, err := PreCompileABI.DecodeInputParam(method, input)
+	if err != nil {
+		return account, nil, fmt.Errorf("decode precomplie %s input unpack err :  %s", method, err)
+	}
+	if len(unpacked) != 2 {
+		return account, nil, fmt.Errorf("decode precomplie %s input unpack err :  unpack data len expect 2 but got %v", method, len(unpacked))
+	}
+	account, ok := unpacked[0].(common.Address)
+	if !ok {
+		return account, nil, fmt.Errorf("decode precomplie %s input unpack err : account is not type of address", method)
+	}
+	amount, ok = unpacked[1].(*big.Int)
+	if !ok || amount.BitLen() > MaxTokenAmountBitLen {
+		return account, nil, fmt.Errorf("decode precomplie %s input unpack err : amount is not a valid uint256", method)
+	}
+	return account, amount, nil
+}
+
+func DecodePrecompileTokenBalanceOfInput(input []byte) (account common.Address, err error) {
+	if !PreCompileABI.IsMatchFunction(PrecompileTokenBalanceOf, input) {
+		return account, fmt.Errorf("decode precomplie token balance input :  input sginature is not %s", PrecompileTokenBalanceOf)
+	}
+	unpacked, err := PreCompileABI.DecodeInputParam(PrecompileTokenBalanceOf, input)
+	if err != nil {
+		return account, fmt.Errorf("decode precomplie token balance input unpack err :  %s", err)
+	}
+	if len(unpacked) != 1 {
+		return account, fmt.Errorf("decode precomplie token balance input unpack err :  unpack data len expect 1 but got %v", len(unpacked))
+	}
+	account, ok := unpacked[0].(common.Address)
+	if !ok {
+		return account, fmt.Errorf("decode precomplie token balance input unpack err : account is not type of address")
+	}
+	return account, nil
+}
+
+// EncodePrecompileTokenOutput encodes the single output of the token methods
+func EncodePrecompileTokenOutput(method string, output interface{}) ([]byte, error) {
+	m, ok := PreCompileABI.Methods[method]
+	if !ok {
+		return nil, fmt.Errorf("method %s is not exist", method)
+	}
+	return m.Outputs.Pack(output)
+}
+
+func DecodePrecompileNFTTransferInput(input []byte) (from, to common.Address, tokenID *big.Int, err error) {
+	if !PreCompileABI.IsMatchFunction(PrecompileNFTTransfer, input) {
+		return from, to, nil, fmt.Errorf("decode precomplie nft transfer input :  input sginature is not %s", PrecompileNFTTransfer)
+	}
+	unpacked, err := PreCompileABI.DecodeInputParam(PrecompileNFTTransfer, input)
+	if err != nil {
+		return from, to, nil, fmt.Errorf("decode precomplie nft transfer input unpack err :  %s", err)
+	}
+	if len(unpacked) != 3 {
+		return from, to, nil, fmt.Errorf("decode precomplie nft transfer input unpack err :  unpack data len expect 3 but got %v", len(unpacked))
+	}
+	from, ok := unpacked[0].(common.Address)
+	if !ok {
+		return from, to, nil, fmt.Errorf("decode precomplie nft transfer input unpack err : from is not type of address")
+	}
+	to, ok = unpacked[1].(common.Address)
+	if !ok {
+		return from, to, nil, fmt.Errorf("decode precomplie nft transfer input unpack err : to is not type of address")
+	}
+	tokenID, ok = unpacked[2].(*big.Int)
+	if !ok {
+		return from, to, nil, fmt.Errorf("decode precomplie nft transfer input unpack err : tokenId is not type of uint256")
+	}
+	return from, to, tokenID, nil
+}
+
+// DecodePrecompileNFTTokenIDInput decodes the input of nftOwnerOf and nftTokenURI, which are both (uint256)
+func DecodePrecompileNFTTokenIDInput(method string, input []byte) (tokenID *big.Int, err error) {
+	if !PreCompileABI.IsMatchFunction(method, input) {
+		return nil, fmt.Errorf("decode precomplie nft input :  input sginature is not %s", method)
+	}
+	unpacked, err := PreCompileABI.DecodeInputParam(method, input)
+	if err != nil {
+		return nil, fmt.Errorf("decode precomplie %s input unpack err :  %s", method, err)
+	}
+	if len(unpacked) != 1 {
+		return nil, fmt.Errorf("decode precomplie %s input unpack err :  unpack data len expect 1 but got %v", method, len(unpacked))
+	}
+	tokenID, ok := unpacked[0].(*big.Int)
+	if !ok {
+		return nil, fmt.Errorf("decode precomplie %s input unpack err : tokenId is not type of uint256", method)
+	}
+	return tokenID, nil
+}
+
+func DecodePrecompileNFTBalanceOfInput(input []byte) (account common.Address, err error) {
+	if !PreCompileABI.IsMatchFunction(PrecompileNFTBalanceOf, input) {
+		return account, fmt.Errorf("decode precomplie nft balance input :  input sginature is not %s", PrecompileNFTBalanceOf)
+	}
+	unpacked, err := PreCompileABI.DecodeInputParam(PrecompileNFTBalanceOf, input)
+	if err != nil {
+		return account, fmt.Errorf("decode precomplie nft balance input unpack err :  %s", err)
+	}
+	if len(unpacked) != 1 {
+		return account, fmt.Errorf("decode precomplie nft balance input unpack err :  unpack data len expect 1 but got %v", len(unpacked))
+	}
+	account, ok := unpacked[0].(common.Address)
+	if !ok {
+		return account, fmt.Errorf("decode precomplie nft balance input unpack err : account is not type of address")
+	}
+	return account, nil
+}
+
 func GetMethodByIdFromCallData(calldata []byte) (*abi.Method, error) {
 	return PreCompileABI.GetMethodById(calldata)
 }
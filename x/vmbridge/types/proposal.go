@@ -0,0 +1,128 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/tendermint/global"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	govtypes "github.com/okex/exchain/x/gov/types"
+)
+
+const (
+	// proposalTypeRegisterTokenPair defines the type for a RegisterTokenPairProposal
+	proposalTypeRegisterTokenPair = "RegisterTokenPair"
+	// proposalTypeRegisterNFTPair defines the type for a RegisterNFTPairProposal
+	proposalTypeRegisterNFTPair = "RegisterNFTPair"
+)
+
+func init() {
+	govtypes.RegisterProposalType(proposalTypeRegisterTokenPair)
+	govtypes.RegisterProposalTypeCodec(RegisterTokenPairProposal{}, registerTokenPairProposalName)
+	govtypes.RegisterProposalType(proposalTypeRegisterNFTPair)
+	govtypes.RegisterProposalTypeCodec(RegisterNFTPairProposal{}, registerNFTPairProposalName)
+}
+
+var (
+	_ govtypes.Content = (*RegisterTokenPairProposal)(nil)
+	_ govtypes.Content = (*RegisterNFTPairProposal)(nil)
+)
+
+// RegisterTokenPairProposal pairs an erc20 contract with a cw20 contract on one ledger
+type RegisterTokenPairProposal struct {
+	Title       string    `json:"title" yaml:"title"`
+	Description string    `json:"description" yaml:"description"`
+	TokenPair   TokenPair `json:"token_pair" yaml:"token_pair"`
+}
+
+func NewRegisterTokenPairProposal(title, description string, tokenPair TokenPair) RegisterTokenPairProposal {
+	return RegisterTokenPairProposal{title, description, tokenPair}
+}
+
+func (p RegisterTokenPairProposal) GetTitle() string       { return p.Title }
+func (p RegisterTokenPairProposal) GetDescription() string { return p.Description }
+func (p RegisterTokenPairProposal) ProposalRoute() string  { return RouterKey }
+func (p RegisterTokenPairProposal) ProposalType() string   { return proposalTypeRegisterTokenPair }
+func (p RegisterTokenPairProposal) ValidateBasic() sdk.Error {
+	if err := validateProposalContent(p, proposalTypeRegisterTokenPair); err != nil {
+		return err
+	}
+
+	if err := p.TokenPair.Validate(); err != nil {
+		return govtypes.ErrInvalidProposalContent(err.Error())
+	}
+
+	return nil
+}
+
+func (p RegisterTokenPairProposal) String() string {
+	return fmt.Sprintf(`Register Token Pair Proposal:
+  Title:       %s
+  Description: %s
+  Token Pair:  %s
+`, p.Title, p.Description, p.TokenPair)
+}
+
+// RegisterNFTPairProposal pairs an erc721 contract with a class of the nft module
+type RegisterNFTPairProposal struct {
+	Title       string  `json:"title" yaml:"title"`
+	Description string  `json:"description" yaml:"description"`
+	NFTPair     NFTPair `json:"nft_pair" yaml:"nft_pair"`
+}
+
+func NewRegisterNFTPairProposal(title, description string, nftPair NFTPair) RegisterNFTPairProposal {
+	return RegisterNFTPairProposal{title, description, nftPair}
+}
+
+func (p RegisterNFTPairProposal) GetTitle() string       { return p.Title }
+func (p RegisterNFTPairProposal) GetDescription() string { return p.Description }
+func (p RegisterNFTPairProposal) ProposalRoute() string  { return RouterKey }
+func (p RegisterNFTPairProposal) ProposalType() string   { return proposalTypeRegisterNFTPair }
+func (p RegisterNFTPairProposal) ValidateBasic() sdk.Error {
+	if err := validateProposalContent(p, proposalTypeRegisterNFTPair); err != nil {
+		return err
+	}
+
+	if err := p.NFTPair.Validate(); err != nil {
+		return govtypes.ErrInvalidProposalContent(err.Error())
+	}
+
+	return nil
+}
+
+func (p RegisterNFTPairProposal) String() string {
+	return fmt.Sprintf(`Register NFT Pair Proposal:
+  Title:       %s
+  Description: %s
+  NFT Pair:    %s
+`, p.Title, p.Description, p.NFTPair)
+}
+
+// validateProposalContent checks the height, the title, the description and the type of the proposal
+func validateProposalContent(p govtypes.Content, proposalType string) sdk.Error {
+	if global.GetGlobalHeight() > 0 && !tmtypes.HigherThanJupiter(global.GetGlobalHeight()) {
+		return govtypes.ErrInvalidProposalContent(fmt.Sprintf("vmbridge not supprt at height %d", global.GetGlobalHeight()))
+	}
+
+	if len(strings.TrimSpace(p.GetTitle())) == 0 {
+		return govtypes.ErrInvalidProposalContent("title is required")
+	}
+	if len(p.GetTitle()) > govtypes.MaxTitleLength {
+		return govtypes.ErrInvalidProposalContent("title length is longer than the max")
+	}
+
+	if len(p.GetDescription()) == 0 {
+		return govtypes.ErrInvalidProposalContent("description is required")
+	}
+
+	if len(p.GetDescription()) > govtypes.MaxDescriptionLength {
+		return govtypes.ErrInvalidProposalContent("description length is longer than the max")
+	}
+
+	if p.ProposalType() != proposalType {
+		return govtypes.ErrInvalidProposalType(p.ProposalType())
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package types
+
+import sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+
+// CustomQuery is the custom query that a wasm contract sends to the vmbridge
+type CustomQuery struct {
+	CallEvm      *CallEvmQuery      `json:"call_evm,omitempty"`
+	TokenBalance *TokenBalanceQuery `json:"token_balance,omitempty"`
+	TokenSupply  *TokenSupplyQuery  `json:"token_supply,omitempty"`
+}
+
+// CallEvmQuery is a read-only call of an evm contract, calldata is hex encoded
+type CallEvmQuery struct {
+	Caller   string `json:"caller,omitempty"`
+	Contract string `json:"contract"`
+	Calldata string `json:"calldata"`
+}
+
+// CallEvmQueryResponse carries the hex encoded return data of the evm call
+type CallEvmQueryResponse struct {
+	Data string `json:"data"`
+}
+
+// TokenBalanceQuery reads the balance of the address in the ledger of the token pair of the cw20 contract
+type TokenBalanceQuery struct {
+	Contract string `json:"contract"`
+	Address  string `json:"address"`
+}
+
+// TokenBalanceQueryResponse carries the balance of TokenBalanceQuery
+type TokenBalanceQueryResponse struct {
+	Balance sdk.Int `json:"balance"`
+}
+
+// TokenSupplyQuery reads the total supply of the token pair of the cw20 contract
+type TokenSupplyQuery struct {
+	Contract string `json:"contract"`
+}
+
+// TokenSupplyQueryResponse carries the total supply of TokenSupplyQuery
+type TokenSupplyQueryResponse struct {
+	TotalSupply sdk.Int `json:"total_supply"`
+}
@@ -0,0 +1,35 @@
+package types
+
+import sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+
+// TokenMsg is the custom message that a paired cw20 contract sends to update the ledger of its token pair.
+// The addresses are either wasm or 0x addresses, they refer to the same accounts on both vms.
+type TokenMsg struct {
+	TokenTransfer *TokenTransferMsg `json:"token_transfer,omitempty"`
+	TokenMint     *TokenMintMsg     `json:"token_mint,omitempty"`
+	TokenBurn     *TokenBurnMsg     `json:"token_burn,omitempty"`
+}
+
+// IsEmpty returns true when the message is not a token message, so it is left to the other handlers
+func (msg TokenMsg) IsEmpty() bool {
+	return msg.TokenTransfer == nil && msg.TokenMint == nil && msg.TokenBurn == nil
+}
+
+// TokenTransferMsg moves the amount from one account to another
+type TokenTransferMsg struct {
+	From   string  `json:"from"`
+	To     string  `json:"to"`
+	Amount sdk.Int `json:"amount"`
+}
+
+// TokenMintMsg increases the balance of the account and the total supply
+type TokenMintMsg struct {
+	To     string  `json:"to"`
+	Amount sdk.Int `json:"amount"`
+}
+
+// TokenBurnMsg decreases the balance of the account and the total supply
+type TokenBurnMsg struct {
+	From   string  `json:"from"`
+	Amount sdk.Int `json:"amount"`
+}
@@ -0,0 +1,77 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
+// MaxTokenAmountBitLen is the bit length limit of sdk.Int, which the ledger amounts are stored as
+const MaxTokenAmountBitLen = 255
+
+// TokenPair pairs an erc20 contract with a cw20 contract, both of them keep their balances in one ledger of the vmbridge
+// store, so a transfer on either vm is visible from the other one at once.
+// The ledger trusts the paired contracts to authorize the transfers of their holders, they are registered by governance.
+type TokenPair struct {
+	Erc20Address string `json:"erc20_address" yaml:"erc20_address"`
+	Cw20Address  string `json:"cw20_address" yaml:"cw20_address"`
+}
+
+// NewTokenPair creates a new instance of TokenPair
+func NewTokenPair(erc20 common.Address, cw20 sdk.WasmAddress) TokenPair {
+	return TokenPair{
+		Erc20Address: erc20.String(),
+		Cw20Address:  cw20.String(),
+	}
+}
+
+// GetErc20 returns the erc20 contract of the token pair
+func (tp TokenPair) GetErc20() common.Address {
+	return common.HexToAddress(tp.Erc20Address)
+}
+
+// GetCw20 returns the cw20 contract of the token pair
+func (tp TokenPair) GetCw20() sdk.WasmAddress {
+	cw20, _ := sdk.WasmAddressFromBech32(tp.Cw20Address)
+	return cw20
+}
+
+// Validate checks the contract addresses of the token pair
+func (tp TokenPair) Validate() error {
+	if !sdk.IsETHAddress(tp.Erc20Address) || !common.IsHexAddress(tp.Erc20Address) {
+		return fmt.Errorf("invalid erc20 address: %s", tp.Erc20Address)
+	}
+	if tp.GetErc20() == (common.Address{}) {
+		return fmt.Errorf("erc20 address can not be zero")
+	}
+	cw20, err := sdk.WasmAddressFromBech32(tp.Cw20Address)
+	if err != nil {
+		return fmt.Errorf("invalid cw20 address %s: %s", tp.Cw20Address, err)
+	}
+	if common.BytesToAddress(cw20.Bytes()) == tp.GetErc20() {
+		return fmt.Errorf("erc20 and cw20 can not be the same contract")
+	}
+	return nil
+}
+
+// String returns a human readable string representation of TokenPair
+func (tp TokenPair) String() string {
+	return fmt.Sprintf("erc20: %s, cw20: %s", tp.Erc20Address, tp.Cw20Address)
+}
+
+// TokenBalance is the balance of an account in the ledger of a token pair
+type TokenBalance struct {
+	Erc20Address string  `json:"erc20_address" yaml:"erc20_address"`
+	Address      string  `json:"address" yaml:"address"`
+	Amount       sdk.Int `json:"amount" yaml:"amount"`
+}
+
+// NewTokenBalance creates a new instance of TokenBalance
+func NewTokenBalance(erc20, account common.Address, amount sdk.Int) TokenBalance {
+	return TokenBalance{
+		Erc20Address: erc20.String(),
+		Address:      account.String(),
+		Amount:       amount,
+	}
+}
@@ -71,6 +71,7 @@ var (
 	NewQuerier             = keeper.Querier
 	ContractFromPortID     = keeper.ContractFromPortID
 	WithWasmEngine         = keeper.WithWasmEngine
+	WithQueryCacheMetrics  = keeper.WithQueryCacheMetrics
 	NewCountTXDecorator    = keeper.NewCountTXDecorator
 
 	// variable aliases
@@ -129,6 +130,7 @@ type (
 	StakingEncoder                 = keeper.StakingEncoder
 	WasmEncoder                    = keeper.WasmEncoder
 	MessageEncoders                = keeper.MessageEncoders
+	Messenger                      = keeper.Messenger
 	Keeper                         = keeper.Keeper
 	QueryHandler                   = keeper.QueryHandler
 	CustomQuerier                  = keeper.CustomQuerier
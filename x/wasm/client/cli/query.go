@@ -52,6 +52,7 @@ func NewQueryCmd(cdc *codec.CodecProxy, reg codectypes.InterfaceRegistry) *cobra
 		NewCmdGetParams(cdc, reg),
 		NewCmdGetExtraParams(cdc, reg),
 		NewCmdGetAddressWhitelist(cdc, reg),
+		NewCmdDryRunMigrate(cdc, reg),
 	)
 
 	return queryCmd
@@ -531,6 +532,73 @@ func newCmdGetContractStateSmart(m *codec.CodecProxy, reg codectypes.InterfaceRe
 	return cmd
 }
 
+const flagStatePrefix = "state-prefix"
+
+// NewCmdDryRunMigrate simulates a contract migration and prints the result with the contract storage diff
+func NewCmdDryRunMigrate(m *codec.CodecProxy, reg codectypes.InterfaceRegistry) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dry-run-migrate [bech32_address] [new_code_id_int64] [json_encoded_migration_args]",
+		Short: "Simulates a contract migration against the current state without committing it",
+		Long: `Simulates a contract migration against the current state without committing it.
+Prints the response data, the emitted events and the diff of the contract storage.
+Without --run-as the migration is simulated as if it was passed by governance.`,
+		Aliases: []string{"drm"},
+		Args:    cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := clientCtx.NewCLIContext().WithProxy(m).WithInterfaceRegistry(reg)
+
+			if _, err := sdk.WasmAddressFromBech32(args[0]); err != nil {
+				return err
+			}
+			codeID, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("code id: %s", err)
+			}
+			if !json.Valid([]byte(args[2])) {
+				return errors.New("migrate msg must be json")
+			}
+			sender, err := cmd.Flags().GetString(flagRunAs)
+			if err != nil {
+				return err
+			}
+			prefixStr, err := cmd.Flags().GetString(flagStatePrefix)
+			if err != nil {
+				return err
+			}
+			prefix, err := hex.DecodeString(prefixStr)
+			if err != nil {
+				return fmt.Errorf("state prefix: %s", err)
+			}
+
+			bz, err := json.Marshal(types.QueryDryRunMigrateRequest{
+				Contract: args[0],
+				Sender:   sender,
+				CodeID:   codeID,
+				Msg:      types.RawContractMessage(args[2]),
+				Prefix:   prefix,
+			})
+			if err != nil {
+				return err
+			}
+			route := fmt.Sprintf("custom/%s/%s", types.QuerierRoute, keeper.QueryDryRunMigrate)
+			res, _, err := clientCtx.QueryWithData(route, bz)
+			if err != nil {
+				return err
+			}
+
+			var out types.QueryDryRunMigrateResponse
+			if err := json.Unmarshal(res, &out); err != nil {
+				return err
+			}
+			return clientCtx.PrintOutput(out)
+		},
+	}
+	cmd.Flags().String(flagRunAs, "", "The address the migration is executed as, governance if empty")
+	cmd.Flags().String(flagStatePrefix, "", "Hex encoded prefix the storage diff is restricted to")
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
 // NewCmdListPinnedCode lists all wasm code ids that are pinned
 func NewCmdListPinnedCode(m *codec.CodecProxy, reg codectypes.InterfaceRegistry) *cobra.Command {
 	cmd := &cobra.Command{
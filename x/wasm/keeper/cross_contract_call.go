@@ -48,7 +48,7 @@ func getCallerInfo(ctx sdk.Context, keeper Keeper, contractAddress, storeAddress
 	if err != nil {
 		return nil, nil, nil, nil, err
 	}
-	prefixStore = types.NewStoreAdapter(keeper.getStorageStore(ctx, sAddr))
+	prefixStore = types.NewStoreAdapter(trackContractWrites(ctx, sAddr, keeper.getStorageStore(ctx, sAddr)))
 	queryHandler := keeper.newQueryHandler(ctx, sAddr)
 	return codeInfo.CodeHash, prefixStore, queryHandler, keeper.gasMeter(ctx), nil
 }
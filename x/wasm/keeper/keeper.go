@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"math"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -33,11 +34,16 @@ import (
 const ContractMemoryLimit = 32
 const SupportedFeatures = "iterator,staking,stargate"
 
+// dryRunMigrateMaxEntries is the max number of contract storage entries compared by a dry run migration
+var dryRunMigrateMaxEntries = 1000
+
 type contextKey int
 
 const (
 	// private type creates an interface key for Context that cannot be accessed by any other package
 	contextKeyQueryStackSize contextKey = iota
+	// contextKeyExternalQuery marks a smart query whose result depends on state outside of the contract storage
+	contextKeyExternalQuery
 )
 
 // Option is an extension point to instantiate keeper with non default values
@@ -131,6 +137,7 @@ func NewKeeper(
 	*WasmbankKeeper = bankKeeper
 	k := newKeeper(cdc, storeKey, paramSpace, accountKeeper, bankKeeper, channelKeeper, portKeeper, capabilityKeeper, portSource, router, queryRouter, homeDir, wasmConfig, supportedFeatures, defaultAdapter{}, opts...)
 	*wasmGasRegister = k.gasRegister
+	queryResultCache.Configure(wasmConfig.QueryCacheSize, int(wasmConfig.QueryCacheMemory)*1024*1024)
 	accountKeeper.SetObserverKeeper(k)
 
 	return k
@@ -541,7 +548,7 @@ func (k Keeper) instantiate(ctx sdk.Context, codeID uint64, creator, admin, cont
 	// create prefixed data store
 	// 0x03 | BuildContractAddress (sdk.WasmAddress)
 	prefixStore := prefix.NewStore(store, types.GetContractStorePrefix(contractAddress))
-	prefixStoreAdapter := types.NewStoreAdapter(prefixStore)
+	prefixStoreAdapter := types.NewStoreAdapter(trackContractWrites(ctx, contractAddress, prefixStore))
 
 	// prepare querier
 	querier := k.newQueryHandler(ctx, contractAddress)
@@ -707,7 +714,7 @@ func (k Keeper) migrate(ctx sdk.Context, contractAddress sdk.WasmAddress, caller
 
 	prefixStoreKey := types.GetContractStorePrefix(contractAddress)
 	prefixStore := k.ada.NewStore(ctx, k.storeKey, prefixStoreKey)
-	prefixAdapater := types.NewStoreAdapter(prefixStore)
+	prefixAdapater := types.NewStoreAdapter(trackContractWrites(ctx, contractAddress, prefixStore))
 
 	gas := k.runtimeGasForContract(ctx)
 	res, gasUsed, err := k.wasmVM.Migrate(newCodeInfo.CodeHash, env, msg, &prefixAdapater, cosmwasmAPI, &querier, k.gasMeter(ctx), gas, costJSONDeserialization)
@@ -719,6 +726,8 @@ func (k Keeper) migrate(ctx sdk.Context, contractAddress sdk.WasmAddress, caller
 		return nil, sdkerrors.Wrap(types.ErrMigrationFailed, err.Error())
 	}
 
+	// the new code may answer queries differently
+	invalidateQueryCache(ctx, contractAddress)
 	// delete old secondary index entry
 	k.removeFromContractCodeSecondaryIndex(ctx, contractAddress, k.getLastContractHistoryEntry(ctx, contractAddress))
 	// persist migration updates
@@ -741,6 +750,91 @@ func (k Keeper) migrate(ctx sdk.Context, contractAddress sdk.WasmAddress, caller
 	return data, nil
 }
 
+// DryRunMigrate executes a contract migration against the current state without committing it and returns the
+// response data, the emitted events and the diff of contract storage keys starting with prefix.
+// It fails if more than dryRunMigrateMaxEntries keys start with prefix, a narrower prefix is needed then.
+// An empty caller simulates the migration as if it was passed by governance.
+func (k Keeper) DryRunMigrate(ctx sdk.Context, contractAddress, caller sdk.WasmAddress, newCodeID uint64, msg []byte, prefix []byte) (*types.QueryDryRunMigrateResponse, error) {
+	contractInfo := k.GetContractInfo(ctx, contractAddress)
+	if contractInfo == nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "unknown contract")
+	}
+	newCodeInfo := k.GetCodeInfo(ctx, newCodeID)
+	if newCodeInfo == nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "unknown code")
+	}
+	// binding a port registers a capability in memory which can not be rolled back
+	if report, err := k.wasmVM.AnalyzeCode(newCodeInfo.CodeHash); err == nil && report.HasIBCEntryPoints && contractInfo.IBCPortID == "" {
+		return nil, sdkerrors.Wrap(types.ErrMigrationFailed, "dry run can not bind a new ibc port")
+	}
+
+	var authZ AuthorizationPolicy = GovAuthorizationPolicy{}
+	if !caller.Empty() {
+		authZ = DefaultAuthorizationPolicy{}
+	}
+
+	before, err := k.contractStateWithPrefix(ctx, contractAddress, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheCtx, _ := ctx.CacheContext()
+	cacheCtx.SetEventManager(sdk.NewEventManager())
+	cacheCtx.SetGasMeter(sdk.NewGasMeter(k.queryGasLimit))
+	data, err := k.migrate(cacheCtx, contractAddress, caller, newCodeID, msg, authZ)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := k.contractStateWithPrefix(cacheCtx, contractAddress, prefix)
+	if err != nil {
+		return nil, err
+	}
+	diff := make([]types.StorageDiffEntry, 0)
+	for _, model := range after {
+		old, ok := before[string(model.Key)]
+		switch {
+		case !ok:
+			diff = append(diff, types.StorageDiffEntry{Op: types.StorageDiffAdded, Key: model.Key, After: model.Value})
+		case !bytes.Equal(old.Value, model.Value):
+			diff = append(diff, types.StorageDiffEntry{Op: types.StorageDiffModified, Key: model.Key, Before: old.Value, After: model.Value})
+		}
+	}
+	for _, model := range before {
+		if _, ok := after[string(model.Key)]; !ok {
+			diff = append(diff, types.StorageDiffEntry{Op: types.StorageDiffDeleted, Key: model.Key, Before: model.Value})
+		}
+	}
+	sort.Slice(diff, func(i, j int) bool {
+		return bytes.Compare(diff[i].Key, diff[j].Key) < 0
+	})
+
+	return &types.QueryDryRunMigrateResponse{
+		Data:        data,
+		GasUsed:     cacheCtx.GasMeter().GasConsumed(),
+		Events:      sdk.StringifyEvents(cacheCtx.EventManager().Events()),
+		StorageDiff: diff,
+	}, nil
+}
+
+// contractStateWithPrefix returns the contract storage entries starting with keyPrefix, indexed by key.
+// At most dryRunMigrateMaxEntries entries are read, which bounds the storage diff of a dry run migration.
+func (k Keeper) contractStateWithPrefix(ctx sdk.Context, contractAddress sdk.WasmAddress, keyPrefix []byte) (map[string]types.Model, error) {
+	contractStore := k.ada.NewStore(ctx, k.storeKey, types.GetContractStorePrefix(contractAddress))
+	iter := prefix.NewStore(contractStore, keyPrefix).Iterator(nil, nil)
+	defer iter.Close()
+
+	state := make(map[string]types.Model)
+	for ; iter.Valid(); iter.Next() {
+		if len(state) == dryRunMigrateMaxEntries {
+			return nil, sdkerrors.Wrapf(types.ErrLimit, "more than %d contract storage entries with prefix %X", dryRunMigrateMaxEntries, keyPrefix)
+		}
+		key := append(append([]byte{}, keyPrefix...), iter.Key()...)
+		state[string(key)] = types.Model{Key: key, Value: append([]byte{}, iter.Value()...)}
+	}
+	return state, nil
+}
+
 // Sudo allows priviledged access to a contract. This can never be called by an external tx, but only by
 // another native Go module directly, or on-chain governance (if sudo proposals are enabled). Thus, the keeper doesn't
 // place any access controls on it, that is the responsibility or the app developer (who passes the wasm.Keeper in app.go)
@@ -991,7 +1085,7 @@ func (k Keeper) contractInstance(ctx sdk.Context, contractAddress sdk.WasmAddres
 	k.cdc.GetProtocMarshal().MustUnmarshal(codeInfoBz, &codeInfo)
 	prefixStoreKey := types.GetContractStorePrefix(contractAddress)
 	prefixStore := prefix.NewStore(store, prefixStoreKey)
-	return contractInfo, codeInfo, types.NewStoreAdapter(prefixStore), nil
+	return contractInfo, codeInfo, types.NewStoreAdapter(trackContractWrites(ctx, contractAddress, prefixStore)), nil
 }
 
 func (k Keeper) getStorageStore(ctx sdk.Context, acc sdk.WasmAddress) sdk.KVStore {
@@ -1056,6 +1150,7 @@ func (k Keeper) IterateContractState(ctx sdk.Context, contractAddress sdk.WasmAd
 func (k Keeper) importContractState(ctx sdk.Context, contractAddress sdk.WasmAddress, models []types.Model) error {
 	prefixStoreKey := types.GetContractStorePrefix(contractAddress)
 	prefixStore := k.ada.NewStore(ctx, k.storeKey, prefixStoreKey)
+	invalidateQueryCache(ctx, contractAddress)
 	for _, model := range models {
 		if model.Value == nil {
 			model.Value = []byte{}
@@ -1392,6 +1487,19 @@ func (k *Keeper) SetInnerTxKeeper(innertxKeeper innertx.InnerTxKeeper) {
 	k.innertxKeeper = innertxKeeper
 }
 
+// SetQueryPlugins merges custom query plugins into the default query handler after the keeper is constructed.
+// It is meant for plugins that depend on keepers which themselves need the wasm keeper, like the vmbridge.
+func (k *Keeper) SetQueryPlugins(x *QueryPlugins) {
+	WithQueryPlugins(x).apply(k)
+}
+
+// SetMessageHandlerDecorator decorates the wasm message handler after the keeper is constructed, see SetQueryPlugins.
+// The contract response handler is rebuilt, as it holds the message handler it was created with.
+func (k *Keeper) SetMessageHandlerDecorator(d func(old Messenger) Messenger) {
+	WithMessageHandlerDecorator(d).apply(k)
+	k.wasmVMResponseHandler = NewDefaultWasmVMContractResponseHandler(NewMessageDispatcher(k.messenger, k))
+}
+
 func moduleLogger(ctx sdk.Context) log.Logger {
 	return ctx.Logger().With("module", fmt.Sprintf("x/%s", types.ModuleName))
 }
@@ -22,6 +22,7 @@ const (
 	QueryListContractBlockedMethod = "list-contract-blocked-method"
 	QueryParams                    = "params"
 	QueryExtraParams               = "extra-params"
+	QueryDryRunMigrate             = "dry-run-migrate"
 )
 
 const (
@@ -79,6 +80,8 @@ func NewLegacyQuerier(keeper types.ViewKeeper, gasLimit sdk.Gas) sdk.Querier {
 			rsp = queryParams(ctx, keeper)
 		case QueryExtraParams:
 			rsp = queryExtraParams(ctx, keeper)
+		case QueryDryRunMigrate:
+			rsp, err = queryDryRunMigrate(ctx, req.Data, keeper)
 		default:
 			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "unknown data query endpoint")
 		}
@@ -126,7 +129,7 @@ func queryContractState(ctx sdk.Context, bech, queryMethod string, data []byte,
 			return nil, sdkerrors.Wrap(err, "json msg")
 		}
 		// this returns raw bytes (must be base64-encoded)
-		bz, err := keeper.QuerySmart(ctx, contractAddr, msg)
+		bz, err := keeper.QuerySmartCached(ctx, contractAddr, msg)
 		return bz, err
 	default:
 		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, queryMethod)
@@ -181,3 +184,27 @@ func queryExtraParams(ctx sdk.Context, keeper types.ViewKeeper) *types.QueryExtr
 	}
 	return &params
 }
+
+func queryDryRunMigrate(ctx sdk.Context, data []byte, keeper types.ViewKeeper) (*types.QueryDryRunMigrateResponse, error) {
+	var params types.QueryDryRunMigrateRequest
+	if err := json.Unmarshal(data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+	contractAddr, err := sdk.WasmAddressFromBech32(params.Contract)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, params.Contract)
+	}
+	var sender sdk.WasmAddress
+	if params.Sender != "" {
+		if sender, err = sdk.WasmAddressFromBech32(params.Sender); err != nil {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, params.Sender)
+		}
+	}
+	if params.CodeID == 0 {
+		return nil, sdkerrors.Wrap(types.ErrInvalid, "code id is required")
+	}
+	if err := params.Msg.ValidateBasic(); err != nil {
+		return nil, sdkerrors.Wrap(err, "migrate msg")
+	}
+	return keeper.DryRunMigrate(ctx, contractAddr, sender, params.CodeID, params.Msg, params.Prefix)
+}
@@ -362,3 +362,96 @@ func TestLegacyQueryCodeList(t *testing.T) {
 		})
 	}
 }
+
+func TestLegacyQueryDryRunMigrate(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	example := InstantiateHackatomExampleContract(t, ctx, keepers)
+	keepers.WasmKeeper.importContractState(ctx, example.Contract, []types.Model{{Key: []byte("foo"), Value: []byte(`"bar"`)}})
+
+	var stateBefore []types.Model
+	keepers.WasmKeeper.IterateContractState(ctx, example.Contract, func(key, value []byte) bool {
+		stateBefore = append(stateBefore, types.Model{Key: key, Value: value})
+		return false
+	})
+
+	newVerifierAddr := RandomAccountAddress(t)
+	migMsgBz, err := json.Marshal(struct {
+		Verifier sdk.WasmAddress `json:"verifier"`
+	}{Verifier: newVerifierAddr})
+	require.NoError(t, err)
+
+	q := NewLegacyQuerier(keepers.WasmKeeper, 3000000)
+	specs := map[string]struct {
+		req     types.QueryDryRunMigrateRequest
+		expDiff int
+		expErr  bool
+	}{
+		"as governance": {
+			req:     types.QueryDryRunMigrateRequest{Contract: example.Contract.String(), CodeID: example.CodeID, Msg: migMsgBz},
+			expDiff: 1,
+		},
+		"as admin": {
+			req:     types.QueryDryRunMigrateRequest{Contract: example.Contract.String(), Sender: example.CreatorAddr.String(), CodeID: example.CodeID, Msg: migMsgBz},
+			expDiff: 1,
+		},
+		"prefix without changes": {
+			req:     types.QueryDryRunMigrateRequest{Contract: example.Contract.String(), CodeID: example.CodeID, Msg: migMsgBz, Prefix: []byte("foo")},
+			expDiff: 0,
+		},
+		"not the admin": {
+			req:    types.QueryDryRunMigrateRequest{Contract: example.Contract.String(), Sender: example.VerifierAddr.String(), CodeID: example.CodeID, Msg: migMsgBz},
+			expErr: true,
+		},
+		"unknown code": {
+			req:    types.QueryDryRunMigrateRequest{Contract: example.Contract.String(), CodeID: 99, Msg: migMsgBz},
+			expErr: true,
+		},
+		"unknown contract": {
+			req:    types.QueryDryRunMigrateRequest{Contract: RandomAccountAddress(t).String(), CodeID: example.CodeID, Msg: migMsgBz},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			reqBz, err := json.Marshal(spec.req)
+			require.NoError(t, err)
+			bz, err := q(ctx, []string{QueryDryRunMigrate}, abci.RequestQuery{Data: reqBz})
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			var res types.QueryDryRunMigrateResponse
+			require.NoError(t, json.Unmarshal(bz, &res))
+			require.Len(t, res.StorageDiff, spec.expDiff)
+			for _, entry := range res.StorageDiff {
+				assert.Equal(t, types.StorageDiffModified, entry.Op)
+				assert.Contains(t, string(entry.After), newVerifierAddr.String())
+			}
+			assert.NotZero(t, res.GasUsed)
+			var hasMigrateEvent bool
+			for _, e := range res.Events {
+				hasMigrateEvent = hasMigrateEvent || e.Type == types.EventTypeMigrate
+			}
+			assert.True(t, hasMigrateEvent)
+
+			// nothing was committed
+			var stateAfter []types.Model
+			keepers.WasmKeeper.IterateContractState(ctx, example.Contract, func(key, value []byte) bool {
+				stateAfter = append(stateAfter, types.Model{Key: key, Value: value})
+				return false
+			})
+			assert.Equal(t, stateBefore, stateAfter)
+			assert.Len(t, keepers.WasmKeeper.GetContractHistory(ctx, example.Contract), 1)
+		})
+	}
+
+	// the compared storage entries are bounded
+	defer func(max int) { dryRunMigrateMaxEntries = max }(dryRunMigrateMaxEntries)
+	dryRunMigrateMaxEntries = len(stateBefore) - 1
+	reqBz, err := json.Marshal(types.QueryDryRunMigrateRequest{Contract: example.Contract.String(), CodeID: example.CodeID, Msg: migMsgBz})
+	require.NoError(t, err)
+	_, err = q(ctx, []string{QueryDryRunMigrate}, abci.RequestQuery{Data: reqBz})
+	require.ErrorIs(t, err, types.ErrLimit)
+}
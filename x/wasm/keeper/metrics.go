@@ -70,3 +70,51 @@ func (p *WasmVMMetricsCollector) Collect(c chan<- prometheus.Metric) {
 	// We had to either scan the whole directory of potentially thousands of files or track the values when files are added or removed.
 	// Such a tracking would need to be on disk such that the values are not cleared when the node is restarted.
 }
+
+var _ prometheus.Collector = (*QueryCacheMetricsCollector)(nil)
+
+// QueryCacheMetricsCollector custom metrics collector for the smart query result cache
+type QueryCacheMetricsCollector struct {
+	source           *QueryCache
+	CacheHitsDescr   *prometheus.Desc
+	CacheMissesDescr *prometheus.Desc
+	EvictionsDescr   *prometheus.Desc
+	EntriesDescr     *prometheus.Desc
+	SizeDescr        *prometheus.Desc
+}
+
+// NewQueryCacheMetricsCollector constructor
+func NewQueryCacheMetricsCollector(s *QueryCache) *QueryCacheMetricsCollector {
+	return &QueryCacheMetricsCollector{
+		source:           s,
+		CacheHitsDescr:   prometheus.NewDesc("wasm_query_cache_hits_total", "Total number of smart query cache hits", nil, nil),
+		CacheMissesDescr: prometheus.NewDesc("wasm_query_cache_misses_total", "Total number of smart query cache misses", nil, nil),
+		EvictionsDescr:   prometheus.NewDesc("wasm_query_cache_evictions_total", "Total number of evicted smart query results", nil, nil),
+		EntriesDescr:     prometheus.NewDesc("wasm_query_cache_elements_total", "Total number of elements in the smart query cache", nil, nil),
+		SizeDescr:        prometheus.NewDesc("wasm_query_cache_size_bytes", "Total size of the elements in the smart query cache", nil, nil),
+	}
+}
+
+// Register registers all metrics
+func (p *QueryCacheMetricsCollector) Register(r prometheus.Registerer) {
+	r.MustRegister(p)
+}
+
+// Describe sends the super-set of all possible descriptors of metrics
+func (p *QueryCacheMetricsCollector) Describe(descs chan<- *prometheus.Desc) {
+	descs <- p.CacheHitsDescr
+	descs <- p.CacheMissesDescr
+	descs <- p.EvictionsDescr
+	descs <- p.EntriesDescr
+	descs <- p.SizeDescr
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (p *QueryCacheMetricsCollector) Collect(c chan<- prometheus.Metric) {
+	stats := p.source.Stats()
+	c <- prometheus.MustNewConstMetric(p.CacheHitsDescr, prometheus.CounterValue, float64(stats.Hits))
+	c <- prometheus.MustNewConstMetric(p.CacheMissesDescr, prometheus.CounterValue, float64(stats.Misses))
+	c <- prometheus.MustNewConstMetric(p.EvictionsDescr, prometheus.CounterValue, float64(stats.Evictions))
+	c <- prometheus.MustNewConstMetric(p.EntriesDescr, prometheus.GaugeValue, float64(stats.Entries))
+	c <- prometheus.MustNewConstMetric(p.SizeDescr, prometheus.GaugeValue, float64(stats.Bytes))
+}
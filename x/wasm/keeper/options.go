@@ -2,6 +2,7 @@ package keeper
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/prometheus/client_golang/prometheus"
 
@@ -100,6 +101,18 @@ func WithVMCacheMetrics(r prometheus.Registerer) Option {
 	})
 }
 
+// WithQueryCacheMetrics registers the metrics of the smart query result cache. The cache is shared by all keepers
+// of the process, so its metrics are registered once only.
+func WithQueryCacheMetrics(r prometheus.Registerer) Option {
+	return optsFn(func(k *Keeper) {
+		registerQueryCacheMetrics.Do(func() {
+			NewQueryCacheMetricsCollector(queryResultCache).Register(r)
+		})
+	})
+}
+
+var registerQueryCacheMetrics sync.Once
+
 // WithGasRegister set a new gas register to implement custom gas costs.
 // When the "gas multiplier" for wasmvm gas conversion is modified inside the new register,
 // make sure to also use `WithApiCosts` option for non default values
@@ -207,6 +207,7 @@ func (q grpcQuerier) SmartContractState(c context.Context, req *types.QuerySmart
 		}
 	}()
 
+	// the block height of a grpc query context is not the height of the queried state, so the results are not cached
 	bz, err := q.keeper.QuerySmart(ctx, contractAddr, req.QueryData)
 	switch {
 	case err != nil:
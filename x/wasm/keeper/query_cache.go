@@ -0,0 +1,245 @@
+package keeper
+
+import (
+	"context"
+	"encoding/binary"
+	"sync"
+	"sync/atomic"
+
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	"github.com/hashicorp/golang-lru/simplelru"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/wasm/types"
+)
+
+var queryResultCache = NewQueryCache()
+
+func GetQueryCache() *QueryCache {
+	return queryResultCache
+}
+
+type queryCacheEntry struct {
+	result []byte
+	gas    uint64
+	size   int
+}
+
+// QueryCache caches smart query results served to external clients. Entries are keyed by the contract address,
+// the query bytes, the block height and time of the env passed to the contract and the contract's storage version,
+// which is the height of the last block that wrote to the contract's prefix store. Writes from blocks before the
+// first block delivered by this process are unknown, so that height is the lowest possible version.
+type QueryCache struct {
+	enabled atomic.Bool
+
+	mtx        sync.Mutex
+	entries    *simplelru.LRU
+	maxBytes   int
+	bytes      int
+	floor      int64
+	lastWrites map[string]int64
+
+	hits      uint64
+	misses    uint64
+	evictions uint64
+}
+
+func NewQueryCache() *QueryCache {
+	return &QueryCache{lastWrites: make(map[string]int64)}
+}
+
+// Configure sets the size limits of the cache and drops all entries and recorded writes. A size of 0 disables the cache.
+func (c *QueryCache) Configure(size uint32, maxBytes int) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.entries = nil
+	c.bytes = 0
+	c.maxBytes = maxBytes
+	c.floor = 0
+	c.lastWrites = make(map[string]int64)
+	if size == 0 || maxBytes <= 0 {
+		c.enabled.Store(false)
+		return
+	}
+	c.entries, _ = simplelru.NewLRU(int(size), func(_, value interface{}) {
+		c.bytes -= value.(queryCacheEntry).size
+		c.evictions++
+	})
+	c.enabled.Store(true)
+}
+
+func (c *QueryCache) Enabled() bool {
+	return c.enabled.Load()
+}
+
+// ObserveBlock records the height of a block delivered by this process.
+func (c *QueryCache) ObserveBlock(height int64) {
+	if !c.Enabled() {
+		return
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if c.floor == 0 {
+		c.floor = height
+	}
+}
+
+// Invalidate records a write to the contract storage in the block at the given height.
+func (c *QueryCache) Invalidate(contractAddr sdk.WasmAddress, height int64) {
+	if !c.Enabled() {
+		return
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if c.lastWrites[string(contractAddr)] < height {
+		c.lastWrites[string(contractAddr)] = height
+	}
+}
+
+// version returns the storage version of the contract for a query against the state at the given height.
+// It returns false when the state at that height may differ from the state at the current version.
+func (c *QueryCache) version(contractAddr sdk.WasmAddress, height int64) (int64, bool) {
+	if !c.Enabled() {
+		return 0, false
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if c.floor == 0 {
+		return 0, false
+	}
+	version := c.floor
+	if lastWrite := c.lastWrites[string(contractAddr)]; lastWrite > version {
+		version = lastWrite
+	}
+	return version, height >= version
+}
+
+func (c *QueryCache) get(contractAddr sdk.WasmAddress, version int64, block wasmvmtypes.BlockInfo, req []byte) ([]byte, uint64, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if c.entries == nil {
+		return nil, 0, false
+	}
+	value, ok := c.entries.Get(queryCacheKey(contractAddr, version, block, req))
+	if !ok {
+		c.misses++
+		return nil, 0, false
+	}
+	c.hits++
+	entry := value.(queryCacheEntry)
+	return entry.result, entry.gas, true
+}
+
+func (c *QueryCache) add(contractAddr sdk.WasmAddress, version int64, block wasmvmtypes.BlockInfo, req []byte, result []byte, gas uint64) {
+	key := queryCacheKey(contractAddr, version, block, req)
+	entry := queryCacheEntry{result: result, gas: gas, size: len(key) + len(result)}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if c.entries == nil || entry.size > c.maxBytes {
+		return
+	}
+	if old, ok := c.entries.Peek(key); ok {
+		c.bytes -= old.(queryCacheEntry).size
+	}
+	c.entries.Add(key, entry)
+	c.bytes += entry.size
+	for c.bytes > c.maxBytes {
+		c.entries.RemoveOldest()
+	}
+}
+
+// QueryCacheStats is a snapshot of the query cache counters
+type QueryCacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Entries   int
+	Bytes     int
+}
+
+func (c *QueryCache) Stats() QueryCacheStats {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	stats := QueryCacheStats{Hits: c.hits, Misses: c.misses, Evictions: c.evictions, Bytes: c.bytes}
+	if c.entries != nil {
+		stats.Entries = c.entries.Len()
+	}
+	return stats
+}
+
+func queryCacheKey(contractAddr sdk.WasmAddress, version int64, block wasmvmtypes.BlockInfo, req []byte) string {
+	key := make([]byte, 0, len(contractAddr)+24+len(req))
+	key = append(key, contractAddr...)
+	key = binary.BigEndian.AppendUint64(key, uint64(version))
+	key = binary.BigEndian.AppendUint64(key, block.Height)
+	key = binary.BigEndian.AppendUint64(key, block.Time)
+	key = append(key, req...)
+	return string(key)
+}
+
+// QuerySmartCached serves a smart query from the query result cache when the contract storage did not change since
+// the result was computed with the same block env. Results of queries that reach out to other modules or contracts
+// are never cached. It must only be used for queries of external clients and never within tx execution, and only
+// with a context whose block height is the height of the queried state.
+func (k Keeper) QuerySmartCached(ctx sdk.Context, contractAddr sdk.WasmAddress, req []byte) ([]byte, error) {
+	version, ok := queryResultCache.version(contractAddr, ctx.BlockHeight())
+	if !ok {
+		return k.QuerySmart(ctx, contractAddr, req)
+	}
+	block := types.NewEnv(ctx, contractAddr).Block
+	if result, gas, found := queryResultCache.get(contractAddr, version, block, req); found {
+		ctx.GasMeter().ConsumeGas(gas, "Loading CosmWasm module: cached query")
+		return result, nil
+	}
+
+	external := false
+	ctx.SetContext(context.WithValue(ctx.Context(), contextKeyExternalQuery, &external))
+	gasBefore := ctx.GasMeter().GasConsumed()
+	result, err := k.QuerySmart(ctx, contractAddr, req)
+	if err != nil || external {
+		return result, err
+	}
+	queryResultCache.add(contractAddr, version, block, req, result, ctx.GasMeter().GasConsumed()-gasBefore)
+	return result, nil
+}
+
+// markExternalQuery flags the smart query in ctx as depending on state outside of the contract storage
+func markExternalQuery(ctx sdk.Context) {
+	if c := ctx.Context(); c != nil {
+		if external, ok := c.Value(contextKeyExternalQuery).(*bool); ok {
+			*external = true
+		}
+	}
+}
+
+// invalidateQueryCache invalidates the cached query results of the contract
+func invalidateQueryCache(ctx sdk.Context, contractAddr sdk.WasmAddress) {
+	if !ctx.IsCheckTx() {
+		queryResultCache.Invalidate(contractAddr, ctx.BlockHeight())
+	}
+}
+
+// trackContractWrites invalidates the cached query results of the contract on every write to its storage
+func trackContractWrites(ctx sdk.Context, contractAddr sdk.WasmAddress, store sdk.KVStore) sdk.KVStore {
+	if !queryResultCache.Enabled() || ctx.IsCheckTx() {
+		return store
+	}
+	return &invalidatingStore{KVStore: store, contractAddr: contractAddr, height: ctx.BlockHeight()}
+}
+
+type invalidatingStore struct {
+	sdk.KVStore
+	contractAddr sdk.WasmAddress
+	height       int64
+}
+
+func (s *invalidatingStore) Set(key, value []byte) {
+	queryResultCache.Invalidate(s.contractAddr, s.height)
+	s.KVStore.Set(key, value)
+}
+
+func (s *invalidatingStore) Delete(key []byte) {
+	queryResultCache.Invalidate(s.contractAddr, s.height)
+	s.KVStore.Delete(key)
+}
@@ -0,0 +1,163 @@
+package keeper
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuerySmartCached(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	keeper := keepers.WasmKeeper
+	GetQueryCache().Configure(100, 1024*1024)
+	defer GetQueryCache().Configure(0, 0)
+
+	example := InstantiateHackatomExampleContract(t, ctx, keepers)
+	height := ctx.BlockHeight()
+	GetQueryCache().ObserveBlock(height)
+	verifierQuery := []byte(`{"verifier":{}}`)
+
+	queryAt := func(height int64, req []byte) ([]byte, uint64) {
+		qCtx := ctx
+		qCtx.SetBlockHeight(height)
+		qCtx.SetGasMeter(sdk.NewGasMeter(keeper.QueryGasLimit()))
+		res, err := keeper.QuerySmartCached(qCtx, example.Contract, req)
+		require.NoError(t, err)
+		return res, qCtx.GasMeter().GasConsumed()
+	}
+
+	// the second query is served from the cache and charges the same gas
+	res, gas := queryAt(height, verifierQuery)
+	assert.JSONEq(t, fmt.Sprintf(`{"verifier":"%s"}`, example.VerifierAddr.String()), string(res))
+	cachedRes, cachedGas := queryAt(height, verifierQuery)
+	assert.Equal(t, res, cachedRes)
+	assert.Equal(t, gas, cachedGas)
+	stats := GetQueryCache().Stats()
+	assert.Equal(t, uint64(1), stats.Hits)
+	assert.Equal(t, uint64(1), stats.Misses)
+	assert.Equal(t, 1, stats.Entries)
+
+	// the results are only reused with the same block env
+	timeCtx := ctx
+	ctx.SetBlockTime(ctx.BlockTime().Add(time.Second))
+	queryAt(height, verifierQuery)
+	ctx = timeCtx
+	stats = GetQueryCache().Stats()
+	assert.Equal(t, uint64(1), stats.Hits)
+	assert.Equal(t, uint64(2), stats.Misses)
+	assert.Equal(t, 2, stats.Entries)
+
+	// a migration in the next block changes the contract storage
+	newVerifier := RandomAccountAddress(t)
+	migMsgBz, err := json.Marshal(struct {
+		Verifier sdk.WasmAddress `json:"verifier"`
+	}{Verifier: newVerifier})
+	require.NoError(t, err)
+	deliverCtx := ctx
+	deliverCtx.SetBlockHeight(height + 1)
+	_, err = keepers.ContractKeeper.Migrate(deliverCtx, example.Contract, example.CreatorAddr, example.CodeID, migMsgBz)
+	require.NoError(t, err)
+
+	// queries against the state before the block bypass the cache
+	queryAt(height, verifierQuery)
+	assert.Equal(t, stats, GetQueryCache().Stats())
+
+	// queries against the new state miss the cache once
+	res, _ = queryAt(height+1, verifierQuery)
+	assert.JSONEq(t, fmt.Sprintf(`{"verifier":"%s"}`, newVerifier.String()), string(res))
+	queryAt(height+1, verifierQuery)
+	stats = GetQueryCache().Stats()
+	assert.Equal(t, uint64(2), stats.Hits)
+	assert.Equal(t, uint64(3), stats.Misses)
+
+	// queries reading state outside of the contract storage are not cached
+	balanceQuery := []byte(fmt.Sprintf(`{"other_balance":{"address":"%s"}}`, example.VerifierAddr.String()))
+	queryAt(height+1, balanceQuery)
+	queryAt(height+1, balanceQuery)
+	stats = GetQueryCache().Stats()
+	assert.Equal(t, uint64(2), stats.Hits)
+	assert.Equal(t, uint64(5), stats.Misses)
+	assert.Equal(t, 3, stats.Entries)
+}
+
+func TestQueryCacheLimits(t *testing.T) {
+	addr := RandomAccountAddress(t)
+	result := make([]byte, 100)
+	specs := map[string]struct {
+		size         uint32
+		maxBytes     int
+		expEntries   int
+		expEvictions uint64
+	}{
+		"within limits": {
+			size:       10,
+			maxBytes:   1024,
+			expEntries: 3,
+		},
+		"max entries": {
+			size:         2,
+			maxBytes:     1024,
+			expEntries:   2,
+			expEvictions: 1,
+		},
+		"max bytes": {
+			size:         10,
+			maxBytes:     300,
+			expEntries:   2,
+			expEvictions: 1,
+		},
+		"result exceeds max bytes": {
+			size:       10,
+			maxBytes:   100,
+			expEntries: 0,
+		},
+		"disabled": {
+			size:       0,
+			maxBytes:   1024,
+			expEntries: 0,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			cache := NewQueryCache()
+			cache.Configure(spec.size, spec.maxBytes)
+			for i := 0; i < 3; i++ {
+				cache.add(addr, 1, wasmvmtypes.BlockInfo{}, []byte{byte(i)}, result, 10)
+			}
+			stats := cache.Stats()
+			assert.Equal(t, spec.expEntries, stats.Entries)
+			assert.Equal(t, spec.expEvictions, stats.Evictions)
+			assert.LessOrEqual(t, stats.Bytes, spec.maxBytes)
+		})
+	}
+}
+
+func TestQueryCacheVersion(t *testing.T) {
+	addr := RandomAccountAddress(t)
+	cache := NewQueryCache()
+	cache.Configure(10, 1024)
+
+	// nothing is known before the first block is delivered
+	_, ok := cache.version(addr, 10)
+	assert.False(t, ok)
+
+	cache.ObserveBlock(10)
+	_, ok = cache.version(addr, 9)
+	assert.False(t, ok)
+	version, ok := cache.version(addr, 10)
+	assert.True(t, ok)
+	assert.Equal(t, int64(10), version)
+
+	cache.Invalidate(addr, 12)
+	_, ok = cache.version(addr, 11)
+	assert.False(t, ok)
+	version, ok = cache.version(addr, 12)
+	assert.True(t, ok)
+	assert.Equal(t, int64(12), version)
+}
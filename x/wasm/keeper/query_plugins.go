@@ -44,6 +44,8 @@ var _ wasmvmtypes.Querier = QueryHandler{}
 func (q QueryHandler) Query(request wasmvmtypes.QueryRequest, gasLimit uint64) ([]byte, error) {
 	// set a limit for a subCtx
 	sdkGas := q.gasRegister.FromWasmVMGas(gasLimit)
+	// the result of the running smart query is not a function of the contract storage anymore
+	markExternalQuery(q.Ctx)
 	// discard all changes/ events in subCtx by not committing the cached context
 	subCtx, _ := q.Ctx.CacheContext()
 	subCtx.SetGasMeter(sdk.NewGasMeter(sdkGas))
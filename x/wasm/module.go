@@ -38,6 +38,8 @@ const (
 	flagWasmMemoryCacheSize    = "wasm.memory_cache_size"
 	flagWasmQueryGasLimit      = "wasm.query_gas_limit"
 	flagWasmSimulationGasLimit = "wasm.simulation_gas_limit"
+	flagWasmQueryCacheSize     = "wasm.query_cache_size"
+	flagWasmQueryCacheMemory   = "wasm.query_cache_memory"
 )
 
 // AppModuleBasic defines the basic application module used by the wasm module.
@@ -187,6 +189,7 @@ func (am AppModule) BeginBlock(ctx sdk.Context, _ abci.RequestBeginBlock) {
 	}
 	am.keeper.UpdateGasRegister(ctx)
 	am.keeper.UpdateCurBlockNum(ctx)
+	keeper.GetQueryCache().ObserveBlock(ctx.BlockHeight())
 }
 
 // EndBlock returns the end blocker for the wasm module. It returns no validator
@@ -232,6 +235,8 @@ func AddModuleInitFlags(startCmd *cobra.Command) {
 	startCmd.Flags().Uint32(flagWasmMemoryCacheSize, defaults.MemoryCacheSize, "Sets the size in MiB (NOT bytes) of an in-memory cache for Wasm modules. Set to 0 to disable.")
 	startCmd.Flags().Uint64(flagWasmQueryGasLimit, defaults.SmartQueryGasLimit, "Set the max gas that can be spent on executing a query with a Wasm contract")
 	startCmd.Flags().String(flagWasmSimulationGasLimit, "", "Set the max gas that can be spent when executing a simulation TX")
+	startCmd.Flags().Uint32(flagWasmQueryCacheSize, defaults.QueryCacheSize, "Sets the max number of cached smart query results, reused until the contract storage changes. Set to 0 to disable.")
+	startCmd.Flags().Uint32(flagWasmQueryCacheMemory, defaults.QueryCacheMemory, "Sets the size in MiB (NOT bytes) of the smart query result cache")
 }
 
 //// ReadWasmConfig reads the wasm specifig configuration
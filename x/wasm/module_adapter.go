@@ -215,6 +215,16 @@ func ReadWasmConfig() (types.WasmConfig, error) {
 			cfg.SimulationGasLimit = &limit
 		}
 	}
+	if v := viper.Get(flagWasmQueryCacheSize); v != nil {
+		if cfg.QueryCacheSize, err = cast.ToUint32E(v); err != nil {
+			return cfg, err
+		}
+	}
+	if v := viper.Get(flagWasmQueryCacheMemory); v != nil {
+		if cfg.QueryCacheMemory, err = cast.ToUint32E(v); err != nil {
+			return cfg, err
+		}
+	}
 	// attach contract debugging to global "trace" flag
 	if v := viper.Get(server.FlagTrace); v != nil {
 		if cfg.ContractDebugMode, err = cast.ToBoolE(v); err != nil {
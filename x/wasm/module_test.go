@@ -411,6 +411,7 @@ func TestReadWasmConfig(t *testing.T) {
 				SimulationGasLimit: defaults.SimulationGasLimit,
 				SmartQueryGasLimit: 1,
 				MemoryCacheSize:    defaults.MemoryCacheSize,
+				QueryCacheMemory:   defaults.QueryCacheMemory,
 			},
 		},
 		"set cache via opts": {
@@ -421,6 +422,20 @@ func TestReadWasmConfig(t *testing.T) {
 				SimulationGasLimit: defaults.SimulationGasLimit,
 				MemoryCacheSize:    2,
 				SmartQueryGasLimit: defaults.SmartQueryGasLimit,
+				QueryCacheMemory:   defaults.QueryCacheMemory,
+			},
+		},
+		"set query cache via opts": {
+			src: AppOptionsMock{
+				"wasm.query_cache_size":   1000,
+				"wasm.query_cache_memory": 8,
+			},
+			exp: types.WasmConfig{
+				SimulationGasLimit: defaults.SimulationGasLimit,
+				SmartQueryGasLimit: defaults.SmartQueryGasLimit,
+				MemoryCacheSize:    defaults.MemoryCacheSize,
+				QueryCacheSize:     1000,
+				QueryCacheMemory:   8,
 			},
 		},
 		"set debug via opts": {
@@ -432,6 +447,7 @@ func TestReadWasmConfig(t *testing.T) {
 				SmartQueryGasLimit: defaults.SmartQueryGasLimit,
 				MemoryCacheSize:    defaults.MemoryCacheSize,
 				ContractDebugMode:  true,
+				QueryCacheMemory:   defaults.QueryCacheMemory,
 			},
 		},
 		"all defaults when no options set": {
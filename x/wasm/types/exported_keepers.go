@@ -10,6 +10,7 @@ import (
 type ViewKeeper interface {
 	GetContractHistory(ctx sdk.Context, contractAddr sdk.WasmAddress) []ContractCodeHistoryEntry
 	QuerySmart(ctx sdk.Context, contractAddr sdk.WasmAddress, req []byte) ([]byte, error)
+	QuerySmartCached(ctx sdk.Context, contractAddr sdk.WasmAddress, req []byte) ([]byte, error)
 	QueryRaw(ctx sdk.Context, contractAddress sdk.WasmAddress, key []byte) []byte
 	HasContractInfo(ctx sdk.Context, contractAddress sdk.WasmAddress) bool
 	GetContractInfo(ctx sdk.Context, contractAddress sdk.WasmAddress) *ContractInfo
@@ -23,6 +24,7 @@ type ViewKeeper interface {
 	GetContractMethodBlockedList(ctx sdk.Context, contractAddr string) *ContractMethods
 	GetParams(ctx sdk.Context) Params
 	GetGasFactor(ctx sdk.Context) uint64
+	DryRunMigrate(ctx sdk.Context, contractAddress, caller sdk.WasmAddress, newCodeID uint64, msg []byte, prefix []byte) (*QueryDryRunMigrateResponse, error)
 }
 
 // ContractOpsKeeper contains mutable operations on a contract.
@@ -1,5 +1,10 @@
 package types
 
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	tmbytes "github.com/okex/exchain/libs/tendermint/libs/bytes"
+)
+
 type QueryAddressWhitelistResponse struct {
 	Whitelist []string `json:"whitelist,omitempty"`
 }
@@ -9,3 +14,42 @@ func NewQueryAddressWhitelistResponse(whitelist []string) *QueryAddressWhitelist
 		Whitelist: whitelist,
 	}
 }
+
+// Storage diff operations reported by a dry-run migration.
+const (
+	StorageDiffAdded    = "added"
+	StorageDiffModified = "modified"
+	StorageDiffDeleted  = "deleted"
+)
+
+// QueryDryRunMigrateRequest is the request for simulating a contract migration
+// against the current state without committing it.
+type QueryDryRunMigrateRequest struct {
+	// Contract is the address of the contract to migrate
+	Contract string `json:"contract"`
+	// Sender is the address the migration is executed as. When it is empty the
+	// migration is simulated as if it was passed by governance.
+	Sender string `json:"sender,omitempty"`
+	// CodeID references the new WASM code
+	CodeID uint64 `json:"code_id"`
+	// Msg json encoded message to be passed to the contract on migration
+	Msg RawContractMessage `json:"msg"`
+	// Prefix restricts the storage diff to contract keys with this prefix
+	Prefix []byte `json:"prefix,omitempty"`
+}
+
+// StorageDiffEntry describes the change of a single contract storage key.
+type StorageDiffEntry struct {
+	Op     string           `json:"op"`
+	Key    tmbytes.HexBytes `json:"key"`
+	Before []byte           `json:"before,omitempty"`
+	After  []byte           `json:"after,omitempty"`
+}
+
+// QueryDryRunMigrateResponse is the result of a simulated contract migration.
+type QueryDryRunMigrateResponse struct {
+	Data        []byte             `json:"data,omitempty"`
+	GasUsed     uint64             `json:"gas_used"`
+	Events      sdk.StringEvents   `json:"events"`
+	StorageDiff []StorageDiffEntry `json:"storage_diff"`
+}
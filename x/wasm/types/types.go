@@ -19,6 +19,7 @@ const (
 	defaultMemoryCacheSize    uint32 = 100 // in MiB
 	defaultSmartQueryGasLimit uint64 = 3_000_000
 	defaultContractDebugMode         = false
+	defaultQueryCacheMemory   uint32 = 64 // in MiB
 
 	// SDKAddrLen defines a valid address length that was used in sdk address generation
 	SDKAddrLen = 20
@@ -333,6 +334,10 @@ type WasmConfig struct {
 	MemoryCacheSize uint32
 	// ContractDebugMode log what contract print
 	ContractDebugMode bool
+	// QueryCacheSize is the max number of cached smart query results, 0 disables the cache
+	QueryCacheSize uint32
+	// QueryCacheMemory in MiB not bytes
+	QueryCacheMemory uint32
 }
 
 // DefaultWasmConfig returns the default settings for WasmConfig
@@ -341,6 +346,7 @@ func DefaultWasmConfig() WasmConfig {
 		SmartQueryGasLimit: defaultSmartQueryGasLimit,
 		MemoryCacheSize:    defaultMemoryCacheSize,
 		ContractDebugMode:  defaultContractDebugMode,
+		QueryCacheMemory:   defaultQueryCacheMemory,
 	}
 }
 